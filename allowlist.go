@@ -0,0 +1,167 @@
+package bob
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// ErrNotAllowed is returned when user input names a field that is not in the allowlist
+var ErrNotAllowed = errors.New("field is not allowed")
+
+// OrderByAllowed converts a user supplied sort parameter into an ORDER BY
+// expression, using the allowlist to map the field names to column expressions.
+// The parameter is a comma separated list of fields, each one sorted descending
+// if it starts with "-" or ends with " desc", e.g. "-created_at,name".
+// It returns nil if the parameter is empty, and an error wrapping [ErrNotAllowed]
+// for a field that is not in the allowlist
+//
+//	order, err := bob.OrderByAllowed(r.URL.Query().Get("sort"), map[string]bob.Expression{
+//		"name":       psql.Quote("users", "name"),
+//		"created_at": psql.Quote("users", "created_at"),
+//	})
+//	if order != nil {
+//		q.Apply(sm.OrderBy(order))
+//	}
+func OrderByAllowed(param string, allowed map[string]Expression) (Expression, error) {
+	type orderBy struct {
+		e    Expression
+		desc bool
+	}
+
+	var fields []orderBy
+	for _, field := range strings.Split(param, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		desc := false
+		switch lower := strings.ToLower(field); {
+		case strings.HasPrefix(field, "-"):
+			field, desc = strings.TrimSpace(field[1:]), true
+		case strings.HasSuffix(lower, " desc"):
+			field, desc = strings.TrimSpace(field[:len(field)-5]), true
+		case strings.HasSuffix(lower, " asc"):
+			field = strings.TrimSpace(field[:len(field)-4])
+		}
+
+		e, ok := allowed[field]
+		if !ok {
+			return nil, fmt.Errorf("%w: cannot sort by %q", ErrNotAllowed, field)
+		}
+
+		fields = append(fields, orderBy{e: e, desc: desc})
+	}
+
+	if len(fields) == 0 {
+		return nil, nil
+	}
+
+	return ExpressionFunc(func(w io.Writer, d Dialect, start int) ([]any, error) {
+		var args []any
+
+		for i, f := range fields {
+			if i > 0 {
+				w.Write([]byte(", "))
+			}
+
+			fArgs, err := f.e.WriteSQL(w, d, start+len(args))
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, fArgs...)
+
+			if f.desc {
+				w.Write([]byte(" DESC"))
+			} else {
+				w.Write([]byte(" ASC"))
+			}
+		}
+
+		return args, nil
+	}), nil
+}
+
+// WhereAllowed converts user supplied filter parameters, such as the query
+// parameters of a request, into a WHERE expression, using the allowlist to map
+// the field names to column expressions.
+// A field with one value is compared with =, and one with several values with IN.
+// The values are sent as string args, see the filter package for typed values
+// and other operators.
+// It returns nil if there are no values, and an error wrapping [ErrNotAllowed]
+// for a field that is not in the allowlist, so parameters that are not filters,
+// such as the sort parameter, should be removed first
+func WhereAllowed(params map[string][]string, allowed map[string]Expression) (Expression, error) {
+	names := make([]string, 0, len(params))
+	for name, values := range params {
+		if len(values) == 0 {
+			continue
+		}
+
+		if _, ok := allowed[name]; !ok {
+			return nil, fmt.Errorf("%w: cannot filter by %q", ErrNotAllowed, name)
+		}
+
+		names = append(names, name)
+	}
+
+	if len(names) == 0 {
+		return nil, nil
+	}
+
+	// The conditions are in a stable order
+	sort.Strings(names)
+
+	type condition struct {
+		e      Expression
+		values []string
+	}
+
+	conditions := make([]condition, len(names))
+	for i, name := range names {
+		conditions[i] = condition{
+			e:      allowed[name],
+			values: append([]string(nil), params[name]...),
+		}
+	}
+
+	return ExpressionFunc(func(w io.Writer, d Dialect, start int) ([]any, error) {
+		var args []any
+
+		for i, c := range conditions {
+			if i > 0 {
+				w.Write([]byte(" AND "))
+			}
+
+			w.Write([]byte(openPar))
+			eArgs, err := c.e.WriteSQL(w, d, start+len(args))
+			if err != nil {
+				return nil, err
+			}
+			args = append(args, eArgs...)
+
+			values := c.values
+			if len(values) == 1 {
+				w.Write([]byte(" = "))
+				d.WriteArg(w, start+len(args))
+				args = append(args, values[0])
+			} else {
+				w.Write([]byte(" IN ("))
+				for j, v := range values {
+					if j > 0 {
+						w.Write([]byte(", "))
+					}
+					d.WriteArg(w, start+len(args))
+					args = append(args, v)
+				}
+				w.Write([]byte(closePar))
+			}
+			w.Write([]byte(closePar))
+		}
+
+		return args, nil
+	}), nil
+}
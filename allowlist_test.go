@@ -0,0 +1,93 @@
+package bob
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+)
+
+func column(name string) Expression {
+	return ExpressionFunc(func(w io.Writer, d Dialect, start int) ([]any, error) {
+		d.WriteQuoted(w, name)
+		return nil, nil
+	})
+}
+
+//nolint:gochecknoglobals
+var allowedFields = map[string]Expression{
+	"name":       column("name"),
+	"created_at": column("created_at"),
+	"status":     column("status"),
+}
+
+func TestOrderByAllowed(t *testing.T) {
+	examples := map[string]string{
+		"name":                     `"name" ASC`,
+		"-created_at,name":         `"created_at" DESC, "name" ASC`,
+		" name desc , status ASC ": `"name" DESC, "status" ASC`,
+		"name,,":                   `"name" ASC`,
+	}
+
+	for param, expected := range examples {
+		e, err := OrderByAllowed(param, allowedFields)
+		if err != nil {
+			t.Fatalf("%q: %v", param, err)
+		}
+
+		buf := &bytes.Buffer{}
+		args, err := Express(buf, d, 1, e)
+		if err != nil {
+			t.Fatalf("%q: %v", param, err)
+		}
+
+		compare(t, expected, buf.String(), nil, args)
+	}
+
+	e, err := OrderByAllowed("", allowedFields)
+	if e != nil || err != nil {
+		t.Fatalf("expected nil for an empty param, got %v, %v", e, err)
+	}
+
+	_, err = OrderByAllowed("name,password", allowedFields)
+	if !errors.Is(err, ErrNotAllowed) {
+		t.Fatalf("expected ErrNotAllowed, got %v", err)
+	}
+}
+
+func TestWhereAllowed(t *testing.T) {
+	params := map[string][]string{
+		"status": {"active", "pending"},
+		"name":   {"Stephen"},
+		"empty":  {},
+	}
+
+	e, err := WhereAllowed(params, allowedFields)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// changes to the params after the expression is created are ignored
+	params["name"][0] = "changed"
+
+	buf := &bytes.Buffer{}
+	args, err := Express(buf, d, 1, e)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	compare(t,
+		`("name" = $1) AND ("status" IN ($2, $3))`, buf.String(),
+		[]any{"Stephen", "active", "pending"}, args,
+	)
+
+	e, err = WhereAllowed(nil, allowedFields)
+	if e != nil || err != nil {
+		t.Fatalf("expected nil without params, got %v, %v", e, err)
+	}
+
+	_, err = WhereAllowed(map[string][]string{"password": {"x"}}, allowedFields)
+	if !errors.Is(err, ErrNotAllowed) {
+		t.Fatalf("expected ErrNotAllowed, got %v", err)
+	}
+}
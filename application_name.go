@@ -0,0 +1,60 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+)
+
+type applicationNameCtx struct{}
+
+// WithApplicationName returns a context that attributes the transactions begun
+// with an [AttributedDB] to the given application name, e.g. the name of a
+// background job, so that the database administrators can tell where the load comes from
+func WithApplicationName(ctx context.Context, name string) context.Context {
+	return context.WithValue(ctx, applicationNameCtx{}, name)
+}
+
+// ApplicationNameFrom returns the application name of the context
+func ApplicationNameFrom(ctx context.Context) (string, bool) {
+	name, ok := ctx.Value(applicationNameCtx{}).(string)
+	return name, ok && name != ""
+}
+
+// AttributedDB is a [DB] that sets the application name at the start of each
+// transaction it begins, to the name of the context or else to Name.
+// Queries run outside of a transaction are not attributed, since the name
+// would stay set on the connection when it goes back to the pool.
+// To name all the connections of a pool, set the name in the DSN instead
+type AttributedDB struct {
+	DB
+	// The name used when the context has none
+	Name string
+	// SetName returns the query that sets the application name of the
+	// transaction, such as psql.SetApplicationName
+	SetName func(name string) Query
+}
+
+// BeginTx begins a transaction and sets its application name
+func (a AttributedDB) BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error) {
+	tx, err := a.DB.BeginTx(ctx, opts)
+	if err != nil {
+		return tx, err
+	}
+
+	name, ok := ApplicationNameFrom(ctx)
+	if !ok {
+		name = a.Name
+	}
+
+	if name == "" || a.SetName == nil {
+		return tx, nil
+	}
+
+	if _, err := Exec(ctx, tx, a.SetName(name)); err != nil {
+		_ = tx.Rollback()
+		return Tx{}, fmt.Errorf("set application name: %w", err)
+	}
+
+	return tx, nil
+}
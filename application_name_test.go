@@ -0,0 +1,49 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestAttributedDB(t *testing.T) {
+	ctx := context.Background()
+
+	sqlDB, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+
+	var names []string
+	db := AttributedDB{
+		DB:   NewDB(sqlDB),
+		Name: "api",
+		SetName: func(name string) Query {
+			names = append(names, name)
+			return rawQuery(d, "SELECT #", name)
+		},
+	}
+
+	for _, ctx := range []context.Context{ctx, WithApplicationName(ctx, "billing")} {
+		tx, err := db.BeginTx(ctx, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := tx.Commit(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(names) != 2 || names[0] != "api" || names[1] != "billing" {
+		t.Fatalf("unexpected names %q", names)
+	}
+
+	// A failing query rolls back the transaction
+	db.SetName = func(string) Query { return rawQuery(d, "SELECT nope()") }
+	if _, err := db.BeginTx(ctx, nil); err == nil {
+		t.Fatal("expected an error")
+	}
+}
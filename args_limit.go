@@ -0,0 +1,58 @@
+package bob
+
+import "fmt"
+
+// ArgLimiter is implemented by dialects that limit the number of args in a query
+type ArgLimiter interface {
+	MaxArgs() int
+}
+
+// TooManyArgsError is returned when a query is built with more args than
+// its dialect allows, instead of failing with a driver error when executed
+type TooManyArgsError struct {
+	Args int
+	Max  int
+}
+
+func (e TooManyArgsError) Error() string {
+	return fmt.Sprintf(
+		"query has %d args but the dialect allows at most %d, split it into smaller queries, e.g. with bob.Chunks",
+		e.Args, e.Max,
+	)
+}
+
+// checkArgs returns a [TooManyArgsError] if the dialect limits the
+// number of args and there are too many
+func checkArgs(d Dialect, args []any) error {
+	l, ok := d.(ArgLimiter)
+	if !ok {
+		return nil
+	}
+
+	if max := l.MaxArgs(); max > 0 && len(args) > max {
+		return TooManyArgsError{Args: len(args), Max: max}
+	}
+
+	return nil
+}
+
+// Chunks splits the slice into chunks of at most size elements,
+// e.g. to insert many rows with several queries that stay under the args limit
+// of the dialect. A size less than 1 returns the whole slice as one chunk
+func Chunks[T any](s []T, size int) [][]T {
+	if len(s) == 0 {
+		return nil
+	}
+
+	if size < 1 || size >= len(s) {
+		return [][]T{s}
+	}
+
+	chunks := make([][]T, 0, (len(s)+size-1)/size)
+	for size < len(s) {
+		chunks = append(chunks, s[:size:size])
+		s = s[size:]
+	}
+
+	return append(chunks, s)
+}
@@ -0,0 +1,78 @@
+package bob
+
+import (
+	"errors"
+	"io"
+	"reflect"
+	"testing"
+)
+
+type limitedDialect struct {
+	dialect
+	max int
+}
+
+func (l limitedDialect) MaxArgs() int {
+	return l.max
+}
+
+func argsQuery(d Dialect, n int) BaseQuery[Expression] {
+	return BaseQuery[Expression]{
+		Expression: ExpressionFunc(func(w io.Writer, d Dialect, start int) ([]any, error) {
+			args := make([]any, n)
+			for i := range args {
+				d.WriteArg(w, start+i)
+				args[i] = i
+			}
+			return args, nil
+		}),
+		Dialect: d,
+	}
+}
+
+func TestTooManyArgs(t *testing.T) {
+	if _, args, err := Build(argsQuery(limitedDialect{max: 3}, 3)); err != nil || len(args) != 3 {
+		t.Fatalf("expected 3 args without an error, got %d, %v", len(args), err)
+	}
+
+	_, _, err := Build(argsQuery(limitedDialect{max: 3}, 4))
+	var tooMany TooManyArgsError
+	if !errors.As(err, &tooMany) {
+		t.Fatalf("expected a TooManyArgsError, got %v", err)
+	}
+	if tooMany.Args != 4 || tooMany.Max != 3 {
+		t.Fatalf("wrong error: %#v", tooMany)
+	}
+
+	// Dialects without a limit
+	if _, _, err := Build(argsQuery(d, 100)); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestChunks(t *testing.T) {
+	examples := []struct {
+		s        []int
+		size     int
+		expected [][]int
+	}{
+		{s: nil, size: 2, expected: nil},
+		{s: []int{1, 2, 3}, size: 0, expected: [][]int{{1, 2, 3}}},
+		{s: []int{1, 2, 3}, size: 3, expected: [][]int{{1, 2, 3}}},
+		{s: []int{1, 2, 3, 4, 5}, size: 2, expected: [][]int{{1, 2}, {3, 4}, {5}}},
+	}
+
+	for _, tc := range examples {
+		if got := Chunks(tc.s, tc.size); !reflect.DeepEqual(tc.expected, got) {
+			t.Errorf("Chunks(%v, %d): expected %v, got %v", tc.s, tc.size, tc.expected, got)
+		}
+	}
+
+	// Appending to a chunk does not change the next one
+	s := []int{1, 2, 3, 4}
+	chunks := Chunks(s, 2)
+	_ = append(chunks[0], 10)
+	if s[2] != 3 {
+		t.Error("a chunk shares its capacity with the next one")
+	}
+}
@@ -0,0 +1,421 @@
+// Package bobqueue implements a job queue in a database table.
+//
+// Jobs are claimed with SELECT ... FOR UPDATE SKIP LOCKED on Postgres and
+// MySQL, so several workers can claim jobs at once without blocking each other.
+// SQLite has no row locks, so a claim is a single UPDATE ... RETURNING that
+// marks the jobs as locked until a deadline. On every dialect, a job whose lock
+// has expired, e.g. because its worker crashed, can be claimed again.
+package bobqueue
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/scan"
+)
+
+// DefaultTable is the name of the jobs table if [Queue.Table] is empty
+const DefaultTable = "bob_jobs"
+
+// The status of a job
+const (
+	StatusPending = "pending"
+	StatusRunning = "running"
+	StatusDead    = "dead"
+)
+
+// ErrNotLocked is returned when a job is completed or failed by a worker
+// that no longer holds its lock, e.g. because the lock expired and
+// another worker claimed the job
+var ErrNotLocked = errors.New("bobqueue: job is not locked by the worker")
+
+// Dialect is the database of a [Queue]
+type Dialect int
+
+const (
+	Postgres Dialect = iota
+	MySQL
+	SQLite
+)
+
+func (d Dialect) String() string {
+	switch d {
+	case Postgres:
+		return "postgres"
+	case MySQL:
+		return "mysql"
+	case SQLite:
+		return "sqlite"
+	default:
+		return fmt.Sprintf("Dialect(%d)", int(d))
+	}
+}
+
+// Job is a row of the jobs table
+type Job struct {
+	ID      int64
+	Queue   string
+	Payload []byte
+	Status  string
+	// The number of times the job was claimed
+	Attempts int
+	// The job is not claimed before this time
+	RunAt time.Time
+	// The worker that claimed the job, if it is running
+	LockedBy string
+	// The time the claim expires, if it is running
+	LockedUntil time.Time
+	// The error of the last failed attempt
+	LastError string
+	CreatedAt time.Time
+}
+
+// Times are stored as unix milliseconds, so they compare the same way on
+// every dialect
+type jobRow struct {
+	ID          int64          `db:"id"`
+	Queue       string         `db:"queue"`
+	Payload     []byte         `db:"payload"`
+	Status      string         `db:"status"`
+	Attempts    int            `db:"attempts"`
+	RunAt       int64          `db:"run_at"`
+	LockedBy    sql.NullString `db:"locked_by"`
+	LockedUntil sql.NullInt64  `db:"locked_until"`
+	LastError   sql.NullString `db:"last_error"`
+	CreatedAt   int64          `db:"created_at"`
+}
+
+func (r jobRow) job() Job {
+	j := Job{
+		ID:        r.ID,
+		Queue:     r.Queue,
+		Payload:   r.Payload,
+		Status:    r.Status,
+		Attempts:  r.Attempts,
+		RunAt:     time.UnixMilli(r.RunAt),
+		LockedBy:  r.LockedBy.String,
+		LastError: r.LastError.String,
+		CreatedAt: time.UnixMilli(r.CreatedAt),
+	}
+	if r.LockedUntil.Valid {
+		j.LockedUntil = time.UnixMilli(r.LockedUntil.Int64)
+	}
+
+	return j
+}
+
+var columns = []any{
+	"id", "queue", "payload", "status", "attempts",
+	"run_at", "locked_by", "locked_until", "last_error", "created_at",
+}
+
+// Queue is a named queue in a jobs table.
+// Several queues can share the same table
+type Queue struct {
+	Dialect Dialect
+	// The jobs table, DefaultTable if empty
+	Table string
+	// The name of the queue, "default" if empty
+	Name string
+	// How long a claimed job is locked for, 5 minutes by default.
+	// Workers running longer jobs should call [Queue.Heartbeat] to extend it
+	LockTimeout time.Duration
+	// Jobs are moved to the dead letters after failing this many times.
+	// 0 means they are always retried
+	MaxAttempts int
+	// The time to wait before retrying a failed job, given the number of
+	// attempts so far. By default it is the square of the attempts in seconds
+	Backoff func(attempts int) time.Duration
+
+	// For tests
+	now func() time.Time
+}
+
+func (q Queue) table() string {
+	if q.Table == "" {
+		return DefaultTable
+	}
+	return q.Table
+}
+
+func (q Queue) name() string {
+	if q.Name == "" {
+		return "default"
+	}
+	return q.Name
+}
+
+func (q Queue) lockTimeout() time.Duration {
+	if q.LockTimeout <= 0 {
+		return 5 * time.Minute
+	}
+	return q.LockTimeout
+}
+
+func (q Queue) backoff(attempts int) time.Duration {
+	if q.Backoff != nil {
+		return q.Backoff(attempts)
+	}
+	return time.Duration(attempts*attempts) * time.Second
+}
+
+func (q Queue) nowMilli() int64 {
+	if q.now != nil {
+		return q.now().UnixMilli()
+	}
+	return time.Now().UnixMilli()
+}
+
+// Schema returns the statement that creates the jobs table
+func (q Queue) Schema() string {
+	switch q.Dialect {
+	case MySQL:
+		return fmt.Sprintf("CREATE TABLE IF NOT EXISTS `%s` (\n%s,\n\tINDEX (queue, status, run_at)\n)", q.table(), `	id BIGINT AUTO_INCREMENT PRIMARY KEY,
+	queue VARCHAR(255) NOT NULL,
+	payload LONGBLOB NOT NULL,
+	status VARCHAR(16) NOT NULL DEFAULT 'pending',
+	attempts INTEGER NOT NULL DEFAULT 0,
+	run_at BIGINT NOT NULL,
+	locked_by VARCHAR(255),
+	locked_until BIGINT,
+	last_error TEXT,
+	created_at BIGINT NOT NULL`)
+
+	case SQLite:
+		return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %q (\n%s\n)", q.table(), `	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	queue TEXT NOT NULL,
+	payload BLOB NOT NULL,
+	status TEXT NOT NULL DEFAULT 'pending',
+	attempts INTEGER NOT NULL DEFAULT 0,
+	run_at INTEGER NOT NULL,
+	locked_by TEXT,
+	locked_until INTEGER,
+	last_error TEXT,
+	created_at INTEGER NOT NULL`)
+
+	default:
+		return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %q (\n%s\n)", q.table(), `	id BIGSERIAL PRIMARY KEY,
+	queue TEXT NOT NULL,
+	payload BYTEA NOT NULL,
+	status TEXT NOT NULL DEFAULT 'pending',
+	attempts INTEGER NOT NULL DEFAULT 0,
+	run_at BIGINT NOT NULL,
+	locked_by TEXT,
+	locked_until BIGINT,
+	last_error TEXT,
+	created_at BIGINT NOT NULL`)
+	}
+}
+
+// CreateTable creates the jobs table if it does not exist
+func (q Queue) CreateTable(ctx context.Context, exec bob.Executor) error {
+	_, err := exec.ExecContext(ctx, q.Schema())
+	return err
+}
+
+// Enqueue adds a job to the queue.
+// With a transaction, the job can only be claimed once it is committed
+func (q Queue) Enqueue(ctx context.Context, exec bob.Executor, payload []byte) error {
+	now := q.nowMilli()
+	_, err := bob.Exec(ctx, exec, q.enqueueQuery(payload, now, now))
+	return err
+}
+
+// EnqueueAt adds a job to the queue that is not claimed before runAt
+func (q Queue) EnqueueAt(ctx context.Context, exec bob.Executor, payload []byte, runAt time.Time) error {
+	_, err := bob.Exec(ctx, exec, q.enqueueQuery(payload, runAt.UnixMilli(), q.nowMilli()))
+	return err
+}
+
+// Beginner starts transactions, such as [bob.DB] and [bob.Conn]
+type Beginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (bob.Tx, error)
+}
+
+// Claim locks up to limit jobs for the worker and returns them.
+// The jobs are the ones due to run, and the running ones whose lock expired.
+// Their attempts are incremented, and they are locked for [Queue.LockTimeout].
+//
+// Each claimed job should end with [Queue.Complete], [Queue.Fail] or [Queue.DeadLetter].
+//
+// On MySQL, which cannot return the updated rows, the jobs are selected and
+// updated in a transaction. If the executor is a [Beginner], Claim starts
+// the transaction itself, otherwise it should already be a transaction
+func (q Queue) Claim(ctx context.Context, exec bob.Executor, worker string, limit int) ([]Job, error) {
+	now := q.nowMilli()
+
+	if q.MaxAttempts > 0 {
+		if _, err := bob.Exec(ctx, exec, q.reapQuery(now)); err != nil {
+			return nil, fmt.Errorf("bobqueue: moving expired jobs to the dead letters: %w", err)
+		}
+	}
+
+	var rows []jobRow
+	var err error
+
+	switch q.Dialect {
+	case MySQL:
+		rows, err = q.claimMySQL(ctx, exec, worker, limit, now)
+	default:
+		rows, err = bob.All(ctx, exec, q.claimQuery(worker, limit, now), scan.StructMapper[jobRow]())
+	}
+	if err != nil {
+		return nil, fmt.Errorf("bobqueue: claiming jobs: %w", err)
+	}
+
+	jobs := make([]Job, len(rows))
+	for i, r := range rows {
+		jobs[i] = r.job()
+	}
+
+	return jobs, nil
+}
+
+func (q Queue) claimMySQL(ctx context.Context, exec bob.Executor, worker string, limit int, now int64) ([]jobRow, error) {
+	if b, ok := exec.(Beginner); ok {
+		tx, err := b.BeginTx(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		defer tx.Rollback() //nolint:errcheck
+
+		rows, err := q.claimMySQL(ctx, tx, worker, limit, now)
+		if err != nil {
+			return nil, err
+		}
+
+		return rows, tx.Commit()
+	}
+
+	rows, err := bob.All(ctx, exec, q.selectClaimableQuery(limit, now), scan.StructMapper[jobRow]())
+	if err != nil || len(rows) == 0 {
+		return nil, err
+	}
+
+	ids := make([]any, len(rows))
+	for i, r := range rows {
+		ids[i] = r.ID
+	}
+
+	until := now + q.lockTimeout().Milliseconds()
+	if _, err := bob.Exec(ctx, exec, q.lockQuery(ids, worker, until)); err != nil {
+		return nil, err
+	}
+
+	for i := range rows {
+		rows[i].Status = StatusRunning
+		rows[i].Attempts++
+		rows[i].LockedBy = sql.NullString{String: worker, Valid: true}
+		rows[i].LockedUntil = sql.NullInt64{Int64: until, Valid: true}
+	}
+
+	return rows, nil
+}
+
+// Heartbeat extends the lock of running jobs claimed by the worker,
+// so that they are not claimed again by another worker
+func (q Queue) Heartbeat(ctx context.Context, exec bob.Executor, worker string, ids ...int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	until := q.nowMilli() + q.lockTimeout().Milliseconds()
+	_, err := bob.Exec(ctx, exec, q.heartbeatQuery(anys(ids), worker, until))
+	return err
+}
+
+// Complete deletes jobs claimed by the worker once they are done.
+// It returns [ErrNotLocked] if any of the jobs is no longer locked by the worker
+func (q Queue) Complete(ctx context.Context, exec bob.Executor, worker string, ids ...int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	return execLocked(ctx, exec, q.deleteQuery(anys(ids), worker), len(ids))
+}
+
+// Fail records the error of a claimed job. The job is retried after
+// [Queue.Backoff], or moved to the dead letters if it has used up [Queue.MaxAttempts].
+// It returns [ErrNotLocked] if the job is no longer locked by its worker
+func (q Queue) Fail(ctx context.Context, exec bob.Executor, job Job, jobErr error) error {
+	if jobErr == nil {
+		return errors.New("bobqueue: failing a job without an error")
+	}
+
+	if q.MaxAttempts > 0 && job.Attempts >= q.MaxAttempts {
+		return q.DeadLetter(ctx, exec, job, jobErr)
+	}
+
+	runAt := q.nowMilli() + q.backoff(job.Attempts).Milliseconds()
+	return execLocked(ctx, exec, q.retryQuery(job.ID, job.LockedBy, jobErr.Error(), runAt), 1)
+}
+
+// DeadLetter moves a claimed job to the dead letters, where it is no longer claimed,
+// e.g. for errors that will not go away when retrying.
+// It returns [ErrNotLocked] if the job is no longer locked by its worker
+func (q Queue) DeadLetter(ctx context.Context, exec bob.Executor, job Job, jobErr error) error {
+	var msg string
+	if jobErr != nil {
+		msg = jobErr.Error()
+	}
+
+	return execLocked(ctx, exec, q.deadLetterQuery(job.ID, job.LockedBy, msg), 1)
+}
+
+// execLocked runs a query on jobs locked by a worker, and returns
+// [ErrNotLocked] if it changed fewer than n of them
+func execLocked(ctx context.Context, exec bob.Executor, q bob.Query, n int) error {
+	res, err := bob.Exec(ctx, exec, q)
+	if err != nil {
+		return err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected < int64(n) {
+		return ErrNotLocked
+	}
+
+	return nil
+}
+
+// DeadLetters returns the jobs of the queue that are in the dead letters
+func (q Queue) DeadLetters(ctx context.Context, exec bob.Executor) ([]Job, error) {
+	rows, err := bob.All(ctx, exec, q.deadLettersQuery(), scan.StructMapper[jobRow]())
+	if err != nil {
+		return nil, err
+	}
+
+	jobs := make([]Job, len(rows))
+	for i, r := range rows {
+		jobs[i] = r.job()
+	}
+
+	return jobs, nil
+}
+
+// Retry moves jobs from the dead letters back to the queue,
+// with their attempts reset
+func (q Queue) Retry(ctx context.Context, exec bob.Executor, ids ...int64) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err := bob.Exec(ctx, exec, q.requeueQuery(anys(ids), q.nowMilli()))
+	return err
+}
+
+func anys(ids []int64) []any {
+	a := make([]any, len(ids))
+	for i, id := range ids {
+		a[i] = id
+	}
+	return a
+}
@@ -0,0 +1,232 @@
+package bobqueue
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stephenafamo/bob"
+	testutils "github.com/stephenafamo/bob/test_utils"
+	_ "modernc.org/sqlite"
+)
+
+func TestQueries(t *testing.T) {
+	pg := Queue{Table: "jobs", Name: "emails", MaxAttempts: 3, LockTimeout: time.Second}
+	my := Queue{Dialect: MySQL, Table: "jobs", Name: "emails"}
+
+	examples := testutils.Testcases{
+		"enqueue": {
+			Query: pg.enqueueQuery([]byte("hi"), 10, 5),
+			ExpectedSQL: `INSERT INTO "jobs" ("queue", "payload", "status", "run_at", "created_at")
+				VALUES ($1, $2, $3, $4, $5)`,
+			ExpectedArgs: []any{"emails", []byte("hi"), StatusPending, int64(10), int64(5)},
+		},
+		"claim": {
+			Query: pg.claimQuery("worker-1", 5, 1000),
+			ExpectedSQL: `UPDATE "jobs" SET "status" = $1, "attempts" = attempts + 1, "locked_by" = $2, "locked_until" = $3
+				WHERE "id" IN (SELECT id FROM "jobs"
+					WHERE ("queue" = $4 AND (("status" = $5 AND "run_at" <= $6) OR ("status" = $7 AND "locked_until" < $8)) AND "attempts" < $9)
+					ORDER BY run_at, id LIMIT 5 FOR UPDATE SKIP LOCKED)
+				RETURNING id, queue, payload, status, attempts, run_at, locked_by, locked_until, last_error, created_at`,
+			ExpectedArgs: []any{
+				StatusRunning, "worker-1", int64(2000),
+				"emails", StatusPending, int64(1000), StatusRunning, int64(1000), 3,
+			},
+		},
+		"mysql select claimable": {
+			Query: my.selectClaimableQuery(5, 1000),
+			ExpectedSQL: "SELECT id, queue, payload, status, attempts, run_at, locked_by, locked_until, last_error, created_at FROM `jobs`" +
+				" WHERE (`queue` = ? AND ((`status` = ? AND `run_at` <= ?) OR (`status` = ? AND `locked_until` < ?)))" +
+				" ORDER BY run_at, id LIMIT 5 FOR UPDATE SKIP LOCKED",
+			ExpectedArgs: []any{"emails", StatusPending, int64(1000), StatusRunning, int64(1000)},
+		},
+		"mysql lock": {
+			Query: my.lockQuery([]any{int64(1), int64(2)}, "worker-1", 2000),
+			ExpectedSQL: "UPDATE `jobs` SET `status` = ?, `attempts` = attempts + 1, `locked_by` = ?, `locked_until` = ?" +
+				" WHERE `id` IN (?, ?)",
+			ExpectedArgs: []any{StatusRunning, "worker-1", int64(2000), int64(1), int64(2)},
+		},
+		"heartbeat": {
+			Query: pg.heartbeatQuery([]any{int64(1)}, "worker-1", 3000),
+			ExpectedSQL: `UPDATE "jobs" SET "locked_until" = $1
+				WHERE ("queue" = $2 AND "status" = $3 AND "locked_by" = $4 AND "id" IN ($5))`,
+			ExpectedArgs: []any{int64(3000), "emails", StatusRunning, "worker-1", int64(1)},
+		},
+		"retry": {
+			Query: pg.retryQuery(1, "worker-1", "timeout", 2000),
+			ExpectedSQL: `UPDATE "jobs" SET "status" = $1, "run_at" = $2, "locked_by" = NULL, "locked_until" = NULL, "last_error" = $3
+				WHERE ("queue" = $4 AND "status" = $5 AND "locked_by" = $6 AND "id" = $7)`,
+			ExpectedArgs: []any{StatusPending, int64(2000), "timeout", "emails", StatusRunning, "worker-1", int64(1)},
+		},
+		"dead letter": {
+			Query: pg.deadLetterQuery(1, "worker-1", "bad payload"),
+			ExpectedSQL: `UPDATE "jobs" SET "status" = $1, "locked_by" = NULL, "locked_until" = NULL, "last_error" = $2
+				WHERE ("queue" = $3 AND "status" = $4 AND "locked_by" = $5 AND "id" = $6)`,
+			ExpectedArgs: []any{StatusDead, "bad payload", "emails", StatusRunning, "worker-1", int64(1)},
+		},
+		"reap": {
+			Query: pg.reapQuery(1000),
+			ExpectedSQL: `UPDATE "jobs" SET "status" = $1, "locked_by" = NULL, "locked_until" = NULL, "last_error" = $2
+				WHERE ("queue" = $3 AND "status" = $4 AND "locked_until" < $5 AND "attempts" >= $6)`,
+			ExpectedArgs: []any{StatusDead, "lock expired", "emails", StatusRunning, int64(1000), 3},
+		},
+		"complete": {
+			Query:        pg.deleteQuery([]any{int64(1), int64(2)}, "worker-1"),
+			ExpectedSQL:  `DELETE FROM "jobs" WHERE ("queue" = $1 AND "status" = $2 AND "locked_by" = $3 AND "id" IN ($4, $5))`,
+			ExpectedArgs: []any{"emails", StatusRunning, "worker-1", int64(1), int64(2)},
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+}
+
+func TestSQLite(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	exec := bob.NewDB(db)
+
+	now := time.UnixMilli(1_000_000)
+	q := Queue{
+		Dialect:     SQLite,
+		MaxAttempts: 2,
+		LockTimeout: time.Minute,
+		Backoff:     func(int) time.Duration { return time.Second },
+		now:         func() time.Time { return now },
+	}
+
+	if err := q.CreateTable(ctx, exec); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := q.Enqueue(ctx, exec, []byte("a")); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.EnqueueAt(ctx, exec, []byte("later"), now.Add(time.Hour)); err != nil {
+		t.Fatal(err)
+	}
+
+	jobs, err := q.Claim(ctx, exec, "w1", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || string(jobs[0].Payload) != "a" {
+		t.Fatalf("claimed %+v, want only the due job", jobs)
+	}
+	job := jobs[0]
+	if job.Status != StatusRunning || job.Attempts != 1 || job.LockedBy != "w1" ||
+		!job.LockedUntil.Equal(now.Add(time.Minute)) {
+		t.Fatalf("unexpected claimed job %+v", job)
+	}
+
+	// The job is locked
+	if jobs, err := q.Claim(ctx, exec, "w2", 10); err != nil || len(jobs) != 0 {
+		t.Fatalf("claimed a locked job: %+v, %v", jobs, err)
+	}
+
+	// The lock expires, and the job is claimed by another worker
+	now = now.Add(2 * time.Minute)
+	jobs, err = q.Claim(ctx, exec, "w2", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || jobs[0].ID != job.ID || jobs[0].LockedBy != "w2" || jobs[0].Attempts != 2 {
+		t.Fatalf("expected the expired job to be claimed again, got %+v", jobs)
+	}
+
+	// The heartbeat of the first worker does not steal it back
+	if err := q.Heartbeat(ctx, exec, "w1", job.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.Heartbeat(ctx, exec, "w2", job.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// Nor can it complete or fail the job
+	if err := q.Complete(ctx, exec, "w1", job.ID); !errors.Is(err, ErrNotLocked) {
+		t.Fatalf("expected ErrNotLocked when completing a stolen job, got %v", err)
+	}
+	if err := q.Fail(ctx, exec, job, errors.New("late")); !errors.Is(err, ErrNotLocked) {
+		t.Fatalf("expected ErrNotLocked when failing a stolen job, got %v", err)
+	}
+
+	// The last attempt fails, so the job is dead
+	if err := q.Fail(ctx, exec, jobs[0], errors.New("boom")); err != nil {
+		t.Fatal(err)
+	}
+
+	dead, err := q.DeadLetters(ctx, exec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dead) != 1 || dead[0].ID != job.ID || dead[0].LastError != "boom" || dead[0].LockedBy != "" {
+		t.Fatalf("unexpected dead letters %+v", dead)
+	}
+
+	// Retrying puts it back in the queue
+	if err := q.Retry(ctx, exec, job.ID); err != nil {
+		t.Fatal(err)
+	}
+	jobs, err = q.Claim(ctx, exec, "w1", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || jobs[0].ID != job.ID || jobs[0].Attempts != 1 {
+		t.Fatalf("expected the retried job to be claimed, got %+v", jobs)
+	}
+
+	// A failure with attempts left is retried after the backoff
+	if err := q.Fail(ctx, exec, jobs[0], errors.New("timeout")); err != nil {
+		t.Fatal(err)
+	}
+	if jobs, err := q.Claim(ctx, exec, "w1", 10); err != nil || len(jobs) != 0 {
+		t.Fatalf("claimed a job before its backoff: %+v, %v", jobs, err)
+	}
+
+	now = now.Add(time.Second)
+	jobs, err = q.Claim(ctx, exec, "w1", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || jobs[0].LastError != "timeout" {
+		t.Fatalf("expected the job to be retried, got %+v", jobs)
+	}
+
+	if err := q.Complete(ctx, exec, "w1", jobs[0].ID); err != nil {
+		t.Fatal(err)
+	}
+
+	// A job whose lock expires on its last attempt is moved to the dead letters
+	now = now.Add(time.Hour)
+	jobs, err = q.Claim(ctx, exec, "w1", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 1 || string(jobs[0].Payload) != "later" {
+		t.Fatalf("expected the scheduled job, got %+v", jobs)
+	}
+
+	now = now.Add(2 * time.Minute)
+	if _, err := q.Claim(ctx, exec, "w1", 10); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(2 * time.Minute)
+	if jobs, err := q.Claim(ctx, exec, "w1", 10); err != nil || len(jobs) != 0 {
+		t.Fatalf("claimed a job over its max attempts: %+v, %v", jobs, err)
+	}
+
+	dead, err = q.DeadLetters(ctx, exec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dead) != 1 || string(dead[0].Payload) != "later" || dead[0].LastError != "lock expired" {
+		t.Fatalf("unexpected dead letters %+v", dead)
+	}
+}
@@ -0,0 +1,261 @@
+package bobqueue
+
+import (
+	"io"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/mysql"
+	mysqldm "github.com/stephenafamo/bob/dialect/mysql/dm"
+	mysqlim "github.com/stephenafamo/bob/dialect/mysql/im"
+	mysqlsm "github.com/stephenafamo/bob/dialect/mysql/sm"
+	mysqlum "github.com/stephenafamo/bob/dialect/mysql/um"
+	"github.com/stephenafamo/bob/dialect/psql"
+	psqldm "github.com/stephenafamo/bob/dialect/psql/dm"
+	psqlim "github.com/stephenafamo/bob/dialect/psql/im"
+	psqlsm "github.com/stephenafamo/bob/dialect/psql/sm"
+	psqlum "github.com/stephenafamo/bob/dialect/psql/um"
+	"github.com/stephenafamo/bob/dialect/sqlite"
+	sqlitedm "github.com/stephenafamo/bob/dialect/sqlite/dm"
+	sqliteim "github.com/stephenafamo/bob/dialect/sqlite/im"
+	sqlitesm "github.com/stephenafamo/bob/dialect/sqlite/sm"
+	sqliteum "github.com/stephenafamo/bob/dialect/sqlite/um"
+	"github.com/stephenafamo/bob/expr"
+)
+
+// The conditions are built with the dialect-neutral expressions,
+// and only the statements depend on the dialect
+
+func eq(col string, val any) bob.Expression {
+	return expr.OP("=", expr.Quote(col), val)
+}
+
+func in(col string, ids []any) bob.Expression {
+	return expr.OP("IN", expr.Quote(col), group(expr.Arg(ids...)))
+}
+
+func and(exprs ...bob.Expression) bob.Expression {
+	return bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+		return bob.ExpressSlice(w, d, start, exprs, "(", " AND ", ")")
+	})
+}
+
+func or(exprs ...bob.Expression) bob.Expression {
+	return bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+		return bob.ExpressSlice(w, d, start, exprs, "(", " OR ", ")")
+	})
+}
+
+func group(e bob.Expression) bob.Expression {
+	return bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+		return bob.ExpressIf(w, d, start, e, true, "(", ")")
+	})
+}
+
+func (q Queue) inQueue() bob.Expression {
+	return eq("queue", expr.Arg(q.name()))
+}
+
+// claimable matches the jobs that are due, and the running ones whose lock expired
+func (q Queue) claimable(now int64) bob.Expression {
+	where := []bob.Expression{
+		q.inQueue(),
+		or(
+			and(eq("status", expr.Arg(StatusPending)), expr.OP("<=", expr.Quote("run_at"), expr.Arg(now))),
+			and(eq("status", expr.Arg(StatusRunning)), expr.OP("<", expr.Quote("locked_until"), expr.Arg(now))),
+		),
+	}
+
+	if q.MaxAttempts > 0 {
+		where = append(where, expr.OP("<", expr.Quote("attempts"), expr.Arg(q.MaxAttempts)))
+	}
+
+	return and(where...)
+}
+
+// lockedBy matches the running jobs of the queue claimed by the worker
+func (q Queue) lockedBy(worker string, where ...bob.Expression) bob.Expression {
+	return and(append([]bob.Expression{
+		q.inQueue(),
+		eq("status", expr.Arg(StatusRunning)),
+		eq("locked_by", expr.Arg(worker)),
+	}, where...)...)
+}
+
+func (q Queue) lockSets(worker string, until int64) []bob.Expression {
+	return []bob.Expression{
+		eq("status", expr.Arg(StatusRunning)),
+		eq("attempts", expr.Raw("attempts + 1")),
+		eq("locked_by", expr.Arg(worker)),
+		eq("locked_until", expr.Arg(until)),
+	}
+}
+
+func (q Queue) enqueueQuery(payload []byte, runAt, now int64) bob.Query {
+	table := expr.Quote(q.table())
+	cols := []string{"queue", "payload", "status", "run_at", "created_at"}
+	values := expr.Arg(q.name(), payload, StatusPending, runAt, now)
+
+	switch q.Dialect {
+	case MySQL:
+		return mysql.Insert(mysqlim.Into(table, cols...), mysqlim.Values(values))
+	case SQLite:
+		return sqlite.Insert(sqliteim.Into(table, cols...), sqliteim.Values(values))
+	default:
+		return psql.Insert(psqlim.Into(table, cols...), psqlim.Values(values))
+	}
+}
+
+// claimQuery locks the claimable jobs in a single statement, and returns them.
+// It is not used for MySQL, which has no RETURNING
+func (q Queue) claimQuery(worker string, limit int, now int64) bob.Query {
+	table := expr.Quote(q.table())
+	until := now + q.lockTimeout().Milliseconds()
+
+	switch q.Dialect {
+	case SQLite:
+		// SQLite runs one write at a time, so the jobs cannot be claimed
+		// by another worker between the subquery and the update
+		return sqlite.Update(
+			sqliteum.Table(table),
+			sqliteum.Set(q.lockSets(worker, until)...),
+			sqliteum.Where(expr.OP("IN", expr.Quote("id"), sqlite.Select(
+				sqlitesm.Columns("id"),
+				sqlitesm.From(table),
+				sqlitesm.Where(q.claimable(now)),
+				sqlitesm.OrderBy("run_at"),
+				sqlitesm.OrderBy("id"),
+				sqlitesm.Limit(limit),
+			))),
+			sqliteum.Returning(columns...),
+		)
+
+	default:
+		return psql.Update(
+			psqlum.Table(table),
+			psqlum.Set(q.lockSets(worker, until)...),
+			psqlum.Where(expr.OP("IN", expr.Quote("id"), psql.Select(
+				psqlsm.Columns("id"),
+				psqlsm.From(table),
+				psqlsm.Where(q.claimable(now)),
+				psqlsm.OrderBy("run_at"),
+				psqlsm.OrderBy("id"),
+				psqlsm.Limit(limit),
+				psqlsm.ForUpdate().SkipLocked(),
+			))),
+			psqlum.Returning(columns...),
+		)
+	}
+}
+
+// selectClaimableQuery locks the claimable jobs on MySQL, skipping the ones
+// locked by other workers. They are then claimed with lockQuery
+func (q Queue) selectClaimableQuery(limit int, now int64) bob.Query {
+	return mysql.Select(
+		mysqlsm.Columns(columns...),
+		mysqlsm.From(expr.Quote(q.table())),
+		mysqlsm.Where(q.claimable(now)),
+		mysqlsm.OrderBy("run_at"),
+		mysqlsm.OrderBy("id"),
+		mysqlsm.Limit(int64(limit)),
+		mysqlsm.ForUpdate().SkipLocked(),
+	)
+}
+
+func (q Queue) lockQuery(ids []any, worker string, until int64) bob.Query {
+	return q.update(in("id", ids), q.lockSets(worker, until)...)
+}
+
+func (q Queue) heartbeatQuery(ids []any, worker string, until int64) bob.Query {
+	return q.update(
+		q.lockedBy(worker, in("id", ids)),
+		eq("locked_until", expr.Arg(until)),
+	)
+}
+
+func (q Queue) retryQuery(id int64, worker, msg string, runAt int64) bob.Query {
+	return q.update(
+		q.lockedBy(worker, eq("id", expr.Arg(id))),
+		eq("status", expr.Arg(StatusPending)),
+		eq("run_at", expr.Arg(runAt)),
+		eq("locked_by", expr.Raw("NULL")),
+		eq("locked_until", expr.Raw("NULL")),
+		eq("last_error", expr.Arg(msg)),
+	)
+}
+
+func (q Queue) deadLetterQuery(id int64, worker, msg string) bob.Query {
+	return q.update(
+		q.lockedBy(worker, eq("id", expr.Arg(id))),
+		eq("status", expr.Arg(StatusDead)),
+		eq("locked_by", expr.Raw("NULL")),
+		eq("locked_until", expr.Raw("NULL")),
+		eq("last_error", expr.Arg(msg)),
+	)
+}
+
+// reapQuery moves the jobs whose lock expired on their last attempt to the
+// dead letters, e.g. because they crashed their worker every time
+func (q Queue) reapQuery(now int64) bob.Query {
+	return q.update(
+		and(
+			q.inQueue(),
+			eq("status", expr.Arg(StatusRunning)),
+			expr.OP("<", expr.Quote("locked_until"), expr.Arg(now)),
+			expr.OP(">=", expr.Quote("attempts"), expr.Arg(q.MaxAttempts)),
+		),
+		eq("status", expr.Arg(StatusDead)),
+		eq("locked_by", expr.Raw("NULL")),
+		eq("locked_until", expr.Raw("NULL")),
+		eq("last_error", expr.Arg("lock expired")),
+	)
+}
+
+func (q Queue) requeueQuery(ids []any, now int64) bob.Query {
+	return q.update(
+		and(q.inQueue(), eq("status", expr.Arg(StatusDead)), in("id", ids)),
+		eq("status", expr.Arg(StatusPending)),
+		eq("attempts", expr.Arg(0)),
+		eq("run_at", expr.Arg(now)),
+	)
+}
+
+func (q Queue) deadLettersQuery() bob.Query {
+	table := expr.Quote(q.table())
+	where := and(q.inQueue(), eq("status", expr.Arg(StatusDead)))
+
+	switch q.Dialect {
+	case MySQL:
+		return mysql.Select(mysqlsm.Columns(columns...), mysqlsm.From(table), mysqlsm.Where(where), mysqlsm.OrderBy("id"))
+	case SQLite:
+		return sqlite.Select(sqlitesm.Columns(columns...), sqlitesm.From(table), sqlitesm.Where(where), sqlitesm.OrderBy("id"))
+	default:
+		return psql.Select(psqlsm.Columns(columns...), psqlsm.From(table), psqlsm.Where(where), psqlsm.OrderBy("id"))
+	}
+}
+
+func (q Queue) deleteQuery(ids []any, worker string) bob.Query {
+	table := expr.Quote(q.table())
+	where := q.lockedBy(worker, in("id", ids))
+
+	switch q.Dialect {
+	case MySQL:
+		return mysql.Delete(mysqldm.From(table), mysqldm.Where(where))
+	case SQLite:
+		return sqlite.Delete(sqlitedm.From(table), sqlitedm.Where(where))
+	default:
+		return psql.Delete(psqldm.From(table), psqldm.Where(where))
+	}
+}
+
+func (q Queue) update(where bob.Expression, sets ...bob.Expression) bob.Query {
+	table := expr.Quote(q.table())
+
+	switch q.Dialect {
+	case MySQL:
+		return mysql.Update(mysqlum.Table(table), mysqlum.Set(sets...), mysqlum.Where(where))
+	case SQLite:
+		return sqlite.Update(sqliteum.Table(table), sqliteum.Set(sets...), sqliteum.Where(where))
+	default:
+		return psql.Update(psqlum.Table(table), psqlum.Set(sets...), psqlum.Where(where))
+	}
+}
@@ -0,0 +1,266 @@
+// Package bobtest runs integration tests against real databases.
+//
+// A Server is a database server in a docker container, usually started once
+// in TestMain and shared by the tests of the package. Each test gets its own
+// database with the schema applied, which is dropped when the test ends, so
+// tests can run in parallel without seeing each other's data.
+//
+//	var server *bobtest.Server
+//
+//	func TestMain(m *testing.M) {
+//		var err error
+//		server, err = bobtest.Postgres(context.Background(), bobtest.Options{Schema: schema})
+//		if err != nil {
+//			log.Fatal(err)
+//		}
+//
+//		code := m.Run()
+//		server.Close()
+//		os.Exit(code)
+//	}
+//
+//	func TestUsers(t *testing.T) {
+//		db := server.DB(t)
+//		// ...
+//	}
+//
+// The database/sql driver of the dialect has to be imported by the tests.
+package bobtest
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/internal/container"
+)
+
+// Options for the databases
+type Options struct {
+	// The docker image of the server. Defaults to postgres:16, mysql:8 or
+	// mcr.microsoft.com/mssql/server:2022-latest
+	Image string
+	// The name of the registered database/sql driver.
+	// Defaults to pgx, mysql, sqlserver or sqlite
+	Driver string
+	// The SQL that is run on each new database to create the schema
+	Schema string
+}
+
+type dialect struct {
+	image  string
+	driver string
+	port   string
+	env    []string
+	// The DSN format for the address and the database name
+	dsn string
+	// The database to connect to for creating the test databases
+	admin string
+	// Create the test databases as copies of a template with the schema
+	template bool
+}
+
+var (
+	postgres = dialect{
+		image:    "postgres:16",
+		driver:   "pgx",
+		port:     "5432/tcp",
+		env:      []string{"POSTGRES_USER=bob", "POSTGRES_PASSWORD=bob", "POSTGRES_DB=bob"},
+		dsn:      "postgres://bob:bob@%s/%s?sslmode=disable",
+		admin:    "bob",
+		template: true,
+	}
+	mysql = dialect{
+		image:  "mysql:8",
+		driver: "mysql",
+		port:   "3306/tcp",
+		env:    []string{"MYSQL_ROOT_PASSWORD=bob"},
+		dsn:    "root:bob@tcp(%s)/%s?multiStatements=true&parseTime=true",
+	}
+	mssql = dialect{
+		image:  "mcr.microsoft.com/mssql/server:2022-latest",
+		driver: "sqlserver",
+		port:   "1433/tcp",
+		env:    []string{"ACCEPT_EULA=Y", "MSSQL_SA_PASSWORD=Bob-passw0rd"},
+		dsn:    "sqlserver://sa:Bob-passw0rd@%s?database=%s",
+		admin:  "master",
+	}
+)
+
+// Server is a database server in a docker container
+type Server struct {
+	dialect   dialect
+	opts      Options
+	container *container.Container
+	admin     *sql.DB
+
+	// Postgres copies a template database for each test
+	templateOnce sync.Once
+	templateErr  error
+
+	count int64
+}
+
+// Postgres starts a Postgres server.
+// The schema is applied once to a template database, and each test database
+// is created as a copy of it
+func Postgres(ctx context.Context, opts Options) (*Server, error) {
+	return start(ctx, postgres, opts)
+}
+
+// MySQL starts a MySQL server
+func MySQL(ctx context.Context, opts Options) (*Server, error) {
+	return start(ctx, mysql, opts)
+}
+
+// MSSQL starts a Microsoft SQL Server
+func MSSQL(ctx context.Context, opts Options) (*Server, error) {
+	return start(ctx, mssql, opts)
+}
+
+func start(ctx context.Context, d dialect, opts Options) (*Server, error) {
+	if opts.Image == "" {
+		opts.Image = d.image
+	}
+	if opts.Driver == "" {
+		opts.Driver = d.driver
+	}
+
+	c, err := container.Run(ctx, opts.Image, d.port, d.env)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Server{dialect: d, opts: opts, container: c}
+
+	s.admin, err = sql.Open(opts.Driver, s.dsn(d.admin))
+	if err != nil {
+		c.Stop()
+		return nil, err
+	}
+
+	// MSSQL takes a while to start
+	if err := container.WaitForDB(ctx, s.admin, 2*time.Minute); err != nil {
+		s.Close()
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *Server) dsn(database string) string {
+	return fmt.Sprintf(s.dialect.dsn, s.container.Addr, database)
+}
+
+// Close stops and removes the server
+func (s *Server) Close() error {
+	s.admin.Close()
+	return s.container.Stop()
+}
+
+// DB creates a database with the schema for the test.
+// The database is dropped when the test ends
+func (s *Server) DB(t testing.TB) bob.DB {
+	t.Helper()
+
+	ctx := context.Background()
+	name := fmt.Sprintf("bobtest_%d", atomic.AddInt64(&s.count, 1))
+
+	if err := s.createDB(ctx, name); err != nil {
+		t.Fatalf("creating the test database: %v", err)
+	}
+
+	db, err := sql.Open(s.opts.Driver, s.dsn(name))
+	if err != nil {
+		t.Fatalf("connecting to the test database: %v", err)
+	}
+
+	t.Cleanup(func() {
+		db.Close()
+		if _, err := s.admin.ExecContext(ctx, "DROP DATABASE "+name); err != nil {
+			t.Errorf("dropping the test database: %v", err)
+		}
+	})
+
+	// The schema is in the template
+	if s.dialect.template {
+		return bob.NewDB(db)
+	}
+
+	if err := applySchema(ctx, db, s.opts.Schema); err != nil {
+		t.Fatalf("applying the schema: %v", err)
+	}
+
+	return bob.NewDB(db)
+}
+
+func (s *Server) createDB(ctx context.Context, name string) error {
+	if !s.dialect.template {
+		_, err := s.admin.ExecContext(ctx, "CREATE DATABASE "+name)
+		return err
+	}
+
+	s.templateOnce.Do(func() {
+		s.templateErr = s.createTemplate(ctx)
+	})
+	if s.templateErr != nil {
+		return s.templateErr
+	}
+
+	_, err := s.admin.ExecContext(ctx, "CREATE DATABASE "+name+" TEMPLATE bobtest_template")
+	return err
+}
+
+func (s *Server) createTemplate(ctx context.Context) error {
+	if _, err := s.admin.ExecContext(ctx, "CREATE DATABASE bobtest_template"); err != nil {
+		return err
+	}
+
+	db, err := sql.Open(s.opts.Driver, s.dsn("bobtest_template"))
+	if err != nil {
+		return err
+	}
+	// A database cannot be copied while there are connections to it
+	defer db.Close()
+
+	return applySchema(ctx, db, s.opts.Schema)
+}
+
+// SQLite returns an in-memory SQLite database with the schema for the test.
+// The database is closed when the test ends
+func SQLite(t testing.TB, opts Options) bob.DB {
+	t.Helper()
+
+	if opts.Driver == "" {
+		opts.Driver = "sqlite"
+	}
+
+	db, err := sql.Open(opts.Driver, ":memory:")
+	if err != nil {
+		t.Fatalf("opening the test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	// Each connection to :memory: is a different database
+	db.SetMaxOpenConns(1)
+
+	if err := applySchema(context.Background(), db, opts.Schema); err != nil {
+		t.Fatalf("applying the schema: %v", err)
+	}
+
+	return bob.NewDB(db)
+}
+
+func applySchema(ctx context.Context, db *sql.DB, schema string) error {
+	if schema == "" {
+		return nil
+	}
+
+	_, err := db.ExecContext(ctx, schema)
+	return err
+}
@@ -0,0 +1,39 @@
+package bobtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stephenafamo/scan"
+	_ "modernc.org/sqlite"
+)
+
+const schema = `CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL);`
+
+func TestSQLite(t *testing.T) {
+	ctx := context.Background()
+
+	db := SQLite(t, Options{Schema: schema})
+	if _, err := db.ExecContext(ctx, "INSERT INTO users (id, name) VALUES (1, 'bob')"); err != nil {
+		t.Fatal(err)
+	}
+
+	name, err := scan.One(ctx, db, scan.SingleColumnMapper[string], "SELECT name FROM users WHERE id = 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "bob" {
+		t.Fatalf("expected bob, got %q", name)
+	}
+
+	// Each call is a separate database
+	other := SQLite(t, Options{Schema: schema})
+
+	count, err := scan.One(ctx, other, scan.SingleColumnMapper[int], "SELECT count(*) FROM users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Fatalf("expected an empty table in the other database, got %d rows", count)
+	}
+}
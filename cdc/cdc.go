@@ -0,0 +1,256 @@
+// Package cdc reads the changes of Postgres tables with logical decoding, e.g. to
+// update caches or search indexes when the data changes.
+// The changes are read from a replication slot with the SQL functions of Postgres,
+// so no replication connection is needed, and can be decoded into the generated
+// model types with [Decode].
+//
+// The database needs wal_level = logical. With the built-in pgoutput plugin, the tables
+// are chosen with a publication, e.g. CREATE PUBLICATION app FOR TABLE users, orders
+package cdc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/scan"
+)
+
+// Plugin is the output plugin of a replication slot
+type Plugin string
+
+const (
+	// PgOutput is the plugin built into Postgres, used by logical replication.
+	// It needs a publication
+	PgOutput Plugin = "pgoutput"
+	// Wal2JSON is the wal2json extension, which writes the changes as JSON
+	Wal2JSON Plugin = "wal2json"
+)
+
+// Operation is the kind of a change
+type Operation string
+
+const (
+	Insert   Operation = "INSERT"
+	Update   Operation = "UPDATE"
+	Delete   Operation = "DELETE"
+	Truncate Operation = "TRUNCATE"
+)
+
+// Change is a change to a row of a table, or the truncation of a table
+type Change struct {
+	// The position of the change in the WAL
+	LSN string
+	// The id of the transaction that made the change
+	XID       int64
+	Operation Operation
+	Schema    string
+	Table     string
+	// The new values of an insert or update. The TOAST values that an update
+	// did not change are left out, since they are not in the WAL
+	Columns []Column
+	// The old values of an update or delete. These are the columns of the replica
+	// identity, usually the primary key, or all the columns with REPLICA IDENTITY FULL.
+	// An update that did not change the key has no old values
+	Old []Column
+}
+
+// Column is the value of a column in a [Change]
+type Column struct {
+	Name string
+	// The name of the type, such as integer or timestamp with time zone
+	Type string
+	// The value in the text format of Postgres, nil for NULL
+	Value *string
+}
+
+// Slot is a logical replication slot. The slot keeps the changes in the
+// database until they are acknowledged, also while no one reads them,
+// so a slot that is no longer used must be dropped
+type Slot struct {
+	Name string
+	// The output plugin, [PgOutput] by default
+	Plugin Plugin
+	// The publication that lists the tables, for [PgOutput]
+	Publication string
+}
+
+// Create creates the replication slot
+func (s Slot) Create(ctx context.Context, exec bob.Executor) error {
+	_, err := bob.Exec(ctx, exec, psql.RawQuery("SELECT pg_create_logical_replication_slot(?, ?)", s.Name, string(s.plugin())))
+	return err
+}
+
+// Drop drops the replication slot and the changes it keeps
+func (s Slot) Drop(ctx context.Context, exec bob.Executor) error {
+	_, err := bob.Exec(ctx, exec, psql.RawQuery("SELECT pg_drop_replication_slot(?)", s.Name))
+	return err
+}
+
+// Exists reports if the replication slot exists
+func (s Slot) Exists(ctx context.Context, exec bob.Executor) (bool, error) {
+	return bob.One(ctx, exec, psql.RawQuery("SELECT EXISTS (SELECT 1 FROM pg_replication_slots WHERE slot_name = ?)", s.Name), scan.SingleColumnMapper[bool])
+}
+
+// Batch is a number of changes read from a slot
+type Batch struct {
+	Changes []Change
+	// The position to acknowledge once the changes are handled, see [Slot.Ack]
+	LSN string
+}
+
+type rawChange struct {
+	LSN  string `db:"lsn"`
+	XID  int64  `db:"xid"`
+	Data []byte `db:"data"`
+}
+
+// Peek reads the next changes of the slot without removing them.
+// Whole transactions are read, so there can be more than limit changes.
+// With a limit of 0, all the changes are read
+func (s Slot) Peek(ctx context.Context, exec bob.Executor, limit int) (Batch, error) {
+	rows, err := bob.All(ctx, exec, s.peekQuery(limit), scan.StructMapper[rawChange]())
+	if err != nil {
+		return Batch{}, fmt.Errorf("cdc: reading slot %s: %w", s.Name, err)
+	}
+
+	var batch Batch
+	switch s.plugin() {
+	case Wal2JSON:
+		batch.Changes, err = decodeWal2JSON(rows)
+	case PgOutput:
+		batch.Changes, err = decodePgOutput(rows)
+	default:
+		err = fmt.Errorf("unknown plugin %q", s.Plugin)
+	}
+	if err != nil {
+		return Batch{}, fmt.Errorf("cdc: decoding slot %s: %w", s.Name, err)
+	}
+
+	if len(rows) > 0 {
+		batch.LSN = rows[len(rows)-1].LSN
+	}
+
+	return batch, nil
+}
+
+// Ack removes the changes up to the LSN of a [Batch] from the slot,
+// so that they are not read again
+func (s Slot) Ack(ctx context.Context, exec bob.Executor, lsn string) error {
+	if lsn == "" {
+		return nil
+	}
+
+	_, err := bob.Exec(ctx, exec, s.ackQuery(lsn))
+	return err
+}
+
+func (s Slot) plugin() Plugin {
+	if s.Plugin == "" {
+		return PgOutput
+	}
+
+	return s.Plugin
+}
+
+func (s Slot) peekQuery(limit int) bob.Query {
+	var upto any
+	if limit > 0 {
+		upto = limit
+	}
+
+	if s.plugin() == PgOutput {
+		return psql.RawQuery(`SELECT lsn::text AS lsn, xid::text::bigint AS xid, data
+			FROM pg_logical_slot_peek_binary_changes(?, NULL, ?, 'proto_version', '1', 'publication_names', ?)`,
+			s.Name, upto, s.Publication)
+	}
+
+	return psql.RawQuery(`SELECT lsn::text AS lsn, xid::text::bigint AS xid, convert_to(data, 'UTF8') AS data
+		FROM pg_logical_slot_peek_changes(?, NULL, ?, 'format-version', '2')`,
+		s.Name, upto)
+}
+
+func (s Slot) ackQuery(lsn string) bob.Query {
+	if s.plugin() == PgOutput {
+		return psql.RawQuery(`SELECT count(*) FROM pg_logical_slot_get_binary_changes(?, ?::pg_lsn, NULL, 'proto_version', '1', 'publication_names', ?)`,
+			s.Name, lsn, s.Publication)
+	}
+
+	return psql.RawQuery(`SELECT count(*) FROM pg_logical_slot_get_changes(?, ?::pg_lsn, NULL, 'format-version', '2')`,
+		s.Name, lsn)
+}
+
+// Handler is called with each change of the slot
+type Handler func(ctx context.Context, c Change) error
+
+// Poller reads the changes of a slot and calls the handler with them.
+// The changes are acknowledged once the handler has handled all the changes of a batch.
+// If the handler returns an error, the batch is read again in the next poll,
+// so the handler may see a change more than once
+type Poller struct {
+	DB      bob.Executor
+	Slot    Slot
+	Handler Handler
+	// The number of changes read at once, 100 by default
+	BatchSize int
+	// The time to wait after a poll that found no changes, 1 second by default
+	Interval time.Duration
+}
+
+// Run polls the slot until the context is canceled or the handler fails
+func (p Poller) Run(ctx context.Context) error {
+	interval := p.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	for {
+		n, err := p.Poll(ctx)
+		if err != nil {
+			return err
+		}
+
+		if n > 0 {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Poll reads one batch of changes and handles them.
+// It returns the number of changes read
+func (p Poller) Poll(ctx context.Context) (int, error) {
+	if p.Handler == nil {
+		return 0, errors.New("cdc: no handler")
+	}
+
+	batchSize := p.BatchSize
+	if batchSize <= 0 {
+		batchSize = 100
+	}
+
+	batch, err := p.Slot.Peek(ctx, p.DB, batchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	for _, c := range batch.Changes {
+		if err := p.Handler(ctx, c); err != nil {
+			return 0, fmt.Errorf("cdc: handling %s on %s at %s: %w", c.Operation, c.Table, c.LSN, err)
+		}
+	}
+
+	if err := p.Slot.Ack(ctx, p.DB, batch.LSN); err != nil {
+		return 0, fmt.Errorf("cdc: acknowledging changes: %w", err)
+	}
+
+	return len(batch.Changes), nil
+}
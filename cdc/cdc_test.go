@@ -0,0 +1,224 @@
+package cdc
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"testing"
+	"time"
+
+	"github.com/aarondl/opt/null"
+	"github.com/google/go-cmp/cmp"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+func TestQueries(t *testing.T) {
+	pgoutput := Slot{Name: "app", Publication: "app_pub"}
+	wal2json := Slot{Name: "app", Plugin: Wal2JSON}
+
+	examples := testutils.Testcases{
+		"peek pgoutput": {
+			Query: pgoutput.peekQuery(100),
+			ExpectedSQL: `SELECT lsn::text AS lsn, xid::text::bigint AS xid, data
+				FROM pg_logical_slot_peek_binary_changes($1, NULL, $2, 'proto_version', '1', 'publication_names', $3)`,
+			ExpectedArgs: []any{"app", 100, "app_pub"},
+		},
+		"ack pgoutput": {
+			Query:        pgoutput.ackQuery("0/16B3748"),
+			ExpectedSQL:  `SELECT count(*) FROM pg_logical_slot_get_binary_changes($1, $2::pg_lsn, NULL, 'proto_version', '1', 'publication_names', $3)`,
+			ExpectedArgs: []any{"app", "0/16B3748", "app_pub"},
+		},
+		"peek wal2json": {
+			Query: wal2json.peekQuery(0),
+			ExpectedSQL: `SELECT lsn::text AS lsn, xid::text::bigint AS xid, convert_to(data, 'UTF8') AS data
+				FROM pg_logical_slot_peek_changes($1, NULL, $2, 'format-version', '2')`,
+			ExpectedArgs: []any{"app", nil},
+		},
+		"ack wal2json": {
+			Query:        wal2json.ackQuery("0/16B3748"),
+			ExpectedSQL:  `SELECT count(*) FROM pg_logical_slot_get_changes($1, $2::pg_lsn, NULL, 'format-version', '2')`,
+			ExpectedArgs: []any{"app", "0/16B3748"},
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+}
+
+// pgoutputMessage writes the fields of a pgoutput message
+type pgoutputMessage struct{ bytes.Buffer }
+
+func (m *pgoutputMessage) str(s string) *pgoutputMessage {
+	m.WriteString(s)
+	m.WriteByte(0)
+	return m
+}
+
+func (m *pgoutputMessage) u8(b byte) *pgoutputMessage {
+	m.WriteByte(b)
+	return m
+}
+
+func (m *pgoutputMessage) u16(n uint16) *pgoutputMessage {
+	binary.Write(&m.Buffer, binary.BigEndian, n) //nolint:errcheck
+	return m
+}
+
+func (m *pgoutputMessage) u32(n uint32) *pgoutputMessage {
+	binary.Write(&m.Buffer, binary.BigEndian, n) //nolint:errcheck
+	return m
+}
+
+func strPtr(s string) *string { return &s }
+
+func TestDecodePgOutput(t *testing.T) {
+	relation := new(pgoutputMessage).u8('R').u32(42).str("public").str("users").u8('d').u16(3).
+		u8(1).str("id").u32(23).u32(0xffffffff).
+		u8(0).str("name").u32(25).u32(0xffffffff).
+		u8(0).str("active").u32(16).u32(0xffffffff)
+
+	tuple := func(m *pgoutputMessage, id, name string) *pgoutputMessage {
+		m.u16(3)
+		m.u8('t').u32(uint32(len(id))).Write([]byte(id))
+		if name == "" {
+			m.u8('n')
+		} else {
+			m.u8('t').u32(uint32(len(name))).Write([]byte(name))
+		}
+		return m.u8('u')
+	}
+
+	insert := tuple(new(pgoutputMessage).u8('I').u32(42).u8('N'), "1", "Alice")
+	update := tuple(tuple(new(pgoutputMessage).u8('U').u32(42).u8('O'), "1", "Alice").u8('N'), "1", "")
+	del := tuple(new(pgoutputMessage).u8('D').u32(42).u8('K'), "1", "")
+	truncate := new(pgoutputMessage).u8('T').u32(1).u8(0).u32(42)
+
+	rows := []rawChange{
+		{LSN: "0/1", XID: 7, Data: []byte{'B'}},
+		{LSN: "0/2", XID: 7, Data: relation.Bytes()},
+		{LSN: "0/3", XID: 7, Data: insert.Bytes()},
+		{LSN: "0/4", XID: 7, Data: update.Bytes()},
+		{LSN: "0/5", XID: 7, Data: del.Bytes()},
+		{LSN: "0/6", XID: 7, Data: truncate.Bytes()},
+		{LSN: "0/7", XID: 7, Data: []byte{'C'}},
+	}
+
+	changes, err := decodePgOutput(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	id := Column{Name: "id", Type: "23", Value: strPtr("1")}
+	alice := Column{Name: "name", Type: "25", Value: strPtr("Alice")}
+	null := Column{Name: "name", Type: "25"}
+	expected := []Change{
+		{LSN: "0/3", XID: 7, Operation: Insert, Schema: "public", Table: "users", Columns: []Column{id, alice}},
+		{LSN: "0/4", XID: 7, Operation: Update, Schema: "public", Table: "users", Columns: []Column{id, null}, Old: []Column{id, alice}},
+		{LSN: "0/5", XID: 7, Operation: Delete, Schema: "public", Table: "users", Old: []Column{id, null}},
+		{LSN: "0/6", XID: 7, Operation: Truncate, Schema: "public", Table: "users"},
+	}
+	if diff := cmp.Diff(expected, changes); diff != "" {
+		t.Fatal(diff)
+	}
+
+	short := insert.Bytes()[:insert.Len()-3]
+	if _, err := decodePgOutput([]rawChange{{Data: relation.Bytes()}, {Data: short}}); err == nil {
+		t.Fatal("expected an error for a short message")
+	}
+	if _, err := decodePgOutput([]rawChange{{Data: insert.Bytes()}}); err == nil {
+		t.Fatal("expected an error for an unknown relation")
+	}
+}
+
+func TestDecodeWal2JSON(t *testing.T) {
+	rows := []rawChange{
+		{LSN: "0/1", XID: 7, Data: []byte(`{"action":"B"}`)},
+		{LSN: "0/2", XID: 7, Data: []byte(`{"action":"U","schema":"public","table":"users",
+			"columns":[{"name":"id","type":"integer","value":1},{"name":"name","type":"text","value":"Bob"},
+				{"name":"active","type":"boolean","value":true},{"name":"deleted_at","type":"timestamp with time zone","value":null}],
+			"identity":[{"name":"id","type":"integer","value":1}]}`)},
+		{LSN: "0/3", XID: 7, Data: []byte(`{"action":"C"}`)},
+	}
+
+	changes, err := decodeWal2JSON(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Change{{
+		LSN: "0/2", XID: 7, Operation: Update, Schema: "public", Table: "users",
+		Columns: []Column{
+			{Name: "id", Type: "integer", Value: strPtr("1")},
+			{Name: "name", Type: "text", Value: strPtr("Bob")},
+			{Name: "active", Type: "boolean", Value: strPtr("true")},
+			{Name: "deleted_at", Type: "timestamp with time zone"},
+		},
+		Old: []Column{{Name: "id", Type: "integer", Value: strPtr("1")}},
+	}}
+	if diff := cmp.Diff(expected, changes); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+type user struct {
+	ID        int64               `db:"id"`
+	Name      string              `db:"name"`
+	Active    bool                `db:"active"`
+	Avatar    []byte              `db:"avatar"`
+	CreatedAt time.Time           `db:"created_at"`
+	DeletedAt null.Val[time.Time] `db:"deleted_at"`
+}
+
+func TestDecode(t *testing.T) {
+	ctx := context.Background()
+
+	c := Change{
+		Operation: Update,
+		Table:     "users",
+		Columns: []Column{
+			{Name: "id", Type: "integer", Value: strPtr("1")},
+			{Name: "name", Type: "text", Value: strPtr("Alice")},
+			{Name: "active", Type: "boolean", Value: strPtr("t")},
+			{Name: "avatar", Type: "bytea", Value: strPtr(`\x0102`)},
+			{Name: "created_at", Type: "timestamp with time zone", Value: strPtr("2024-01-02 03:04:05.5+01")},
+			{Name: "deleted_at", Type: "timestamp with time zone"},
+		},
+		Old: []Column{{Name: "id", Type: "integer", Value: strPtr("1")}},
+	}
+
+	var handled Event[user]
+	handler := Route(map[string]Handler{
+		"public.users": Typed(func(_ context.Context, e Event[user]) error {
+			handled = e
+			return nil
+		}),
+	})
+	if err := handler(ctx, Change{Schema: "public", Table: "orders"}); err != nil {
+		t.Fatal(err)
+	}
+
+	c.Schema = "public"
+	if err := handler(ctx, c); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := user{
+		ID:        1,
+		Name:      "Alice",
+		Active:    true,
+		Avatar:    []byte{1, 2},
+		CreatedAt: time.Date(2024, 1, 2, 2, 4, 5, 500000000, time.UTC),
+	}
+	if handled.New.ID != expected.ID || handled.New.Name != expected.Name || !handled.New.Active ||
+		!bytes.Equal(handled.New.Avatar, expected.Avatar) || !handled.New.CreatedAt.Equal(expected.CreatedAt) ||
+		!handled.New.DeletedAt.IsNull() {
+		t.Fatalf("got %+v", handled.New)
+	}
+	if handled.Old.ID != 1 || handled.Old.Name != "" {
+		t.Fatalf("got old %+v", handled.Old)
+	}
+
+	c.Columns = append(c.Columns, Column{Name: "unknown", Type: "text", Value: strPtr("x")})
+	if _, err := Decode[user](ctx, c); err == nil {
+		t.Fatal("expected an error for a column that is not in the model")
+	}
+}
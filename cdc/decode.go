@@ -0,0 +1,165 @@
+package cdc
+
+import (
+	"context"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aarondl/opt"
+	"github.com/stephenafamo/scan"
+)
+
+// Event is a [Change] with the values decoded into a model type
+type Event[T any] struct {
+	Change
+	// The new row of an insert or update
+	New T
+	// The old row of an update or delete. Only the columns of the replica identity are set
+	Old T
+}
+
+// Decode decodes the values of the change into T, usually a generated model, with the
+// same mapping of the columns as a query. The columns that are not in the change,
+// such as unchanged TOAST values, keep their zero value
+func Decode[T any](ctx context.Context, c Change) (Event[T], error) {
+	e := Event[T]{Change: c}
+
+	var err error
+	if len(c.Columns) > 0 {
+		if e.New, err = scan.OneFromRows(ctx, scan.StructMapper[T](), &changeRows{columns: c.Columns}); err != nil {
+			return e, fmt.Errorf("cdc: decoding %s: %w", c.Table, err)
+		}
+	}
+
+	if len(c.Old) > 0 {
+		if e.Old, err = scan.OneFromRows(ctx, scan.StructMapper[T](), &changeRows{columns: c.Old}); err != nil {
+			return e, fmt.Errorf("cdc: decoding old %s: %w", c.Table, err)
+		}
+	}
+
+	return e, nil
+}
+
+// Typed returns a handler that decodes the changes into T before calling fn
+func Typed[T any](fn func(ctx context.Context, e Event[T]) error) Handler {
+	return func(ctx context.Context, c Change) error {
+		e, err := Decode[T](ctx, c)
+		if err != nil {
+			return err
+		}
+
+		return fn(ctx, e)
+	}
+}
+
+// Route returns a handler that calls the handler of the table of each change.
+// The keys are table names, optionally with the schema, e.g. "users" or "audit.logins".
+// The changes of other tables are skipped
+func Route(handlers map[string]Handler) Handler {
+	return func(ctx context.Context, c Change) error {
+		h, ok := handlers[c.Schema+"."+c.Table]
+		if !ok {
+			h, ok = handlers[c.Table]
+		}
+		if !ok {
+			return nil
+		}
+
+		return h(ctx, c)
+	}
+}
+
+// changeRows is a single row with the values of a change
+type changeRows struct {
+	columns []Column
+	read    bool
+}
+
+func (r *changeRows) Columns() ([]string, error) {
+	names := make([]string, len(r.columns))
+	for i, col := range r.columns {
+		names[i] = col.Name
+	}
+
+	return names, nil
+}
+
+func (r *changeRows) Next() bool {
+	if r.read {
+		return false
+	}
+
+	r.read = true
+	return true
+}
+
+func (r *changeRows) Scan(dest ...any) error {
+	for i, col := range r.columns {
+		if i >= len(dest) || dest[i] == nil {
+			continue
+		}
+
+		value, err := columnValue(col)
+		if err != nil {
+			return fmt.Errorf("column %s: %w", col.Name, err)
+		}
+
+		if err := opt.ConvertAssign(dest[i], value); err != nil {
+			return fmt.Errorf("column %s: %w", col.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (r *changeRows) Close() error {
+	return nil
+}
+
+func (r *changeRows) Err() error {
+	return nil
+}
+
+// columnValue converts the text of the types that a driver would not
+// return as strings, as pgx does for the values of queries
+func columnValue(col Column) (any, error) {
+	if col.Value == nil {
+		return nil, nil
+	}
+
+	s := *col.Value
+	switch typ := col.Type; {
+	case typ == "boolean" || typ == "bool":
+		return strconv.ParseBool(s)
+
+	case typ == "bytea":
+		if !strings.HasPrefix(s, `\x`) {
+			return nil, fmt.Errorf("bytea %q is not in the hex format", s)
+		}
+		return hex.DecodeString(s[2:])
+
+	case typ == "date":
+		return time.Parse("2006-01-02", s)
+
+	case strings.HasPrefix(typ, "timestamp"):
+		for _, layout := range timestampLayouts {
+			if t, err := time.Parse(layout, s); err == nil {
+				return t, nil
+			}
+		}
+		return nil, fmt.Errorf("cannot parse timestamp %q", s)
+
+	default:
+		return s, nil
+	}
+}
+
+var timestampLayouts = []string{
+	"2006-01-02 15:04:05.999999999-07",
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05.999999999-07:00:00",
+	"2006-01-02 15:04:05.999999999",
+}
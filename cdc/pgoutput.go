@@ -0,0 +1,226 @@
+package cdc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+)
+
+// The names of the types that are converted when decoding, by OID
+var pgoutputTypes = map[uint32]string{
+	16:   "boolean",
+	17:   "bytea",
+	1082: "date",
+	1114: "timestamp without time zone",
+	1184: "timestamp with time zone",
+}
+
+type relation struct {
+	schema  string
+	table   string
+	columns []Column
+}
+
+// decodePgOutput decodes the messages of version 1 of the pgoutput protocol, see
+// https://www.postgresql.org/docs/current/protocol-logicalrep-message-formats.html
+// The relations are sent before their first change in each call to the decoding functions
+func decodePgOutput(rows []rawChange) ([]Change, error) {
+	relations := map[uint32]relation{}
+	var changes []Change
+
+	for _, row := range rows {
+		if len(row.Data) == 0 {
+			continue
+		}
+
+		m := &message{data: row.Data[1:]}
+		c := Change{LSN: row.LSN, XID: row.XID}
+
+		switch row.Data[0] {
+		case 'R':
+			id := m.uint32()
+			rel := relation{schema: m.string(), table: m.string()}
+			m.skip(1) // replica identity setting
+			rel.columns = make([]Column, m.uint16())
+			for i := range rel.columns {
+				m.skip(1) // flags
+				name := m.string()
+				oid := m.uint32()
+				m.skip(4) // type modifier
+
+				typ, ok := pgoutputTypes[oid]
+				if !ok {
+					typ = strconv.FormatUint(uint64(oid), 10)
+				}
+				rel.columns[i] = Column{Name: name, Type: typ}
+			}
+			if m.err != nil {
+				return nil, fmt.Errorf("at %s: %w", row.LSN, m.err)
+			}
+			relations[id] = rel
+			continue
+
+		case 'I':
+			c.Operation = Insert
+			rel, err := m.relation(relations)
+			if err != nil {
+				return nil, fmt.Errorf("at %s: %w", row.LSN, err)
+			}
+			c.Schema, c.Table = rel.schema, rel.table
+			m.skip(1) // N
+			c.Columns = m.tuple(rel)
+
+		case 'U':
+			c.Operation = Update
+			rel, err := m.relation(relations)
+			if err != nil {
+				return nil, fmt.Errorf("at %s: %w", row.LSN, err)
+			}
+			c.Schema, c.Table = rel.schema, rel.table
+			if kind := m.byte(); kind == 'K' || kind == 'O' {
+				c.Old = m.tuple(rel)
+				m.skip(1) // N
+			}
+			c.Columns = m.tuple(rel)
+
+		case 'D':
+			c.Operation = Delete
+			rel, err := m.relation(relations)
+			if err != nil {
+				return nil, fmt.Errorf("at %s: %w", row.LSN, err)
+			}
+			c.Schema, c.Table = rel.schema, rel.table
+			m.skip(1) // K or O
+			c.Old = m.tuple(rel)
+
+		case 'T':
+			n := m.uint32()
+			m.skip(1) // options
+			for i := uint32(0); i < n; i++ {
+				rel, err := m.relation(relations)
+				if err != nil {
+					return nil, fmt.Errorf("at %s: %w", row.LSN, err)
+				}
+				changes = append(changes, Change{
+					LSN: row.LSN, XID: row.XID, Operation: Truncate,
+					Schema: rel.schema, Table: rel.table,
+				})
+			}
+			if m.err != nil {
+				return nil, fmt.Errorf("at %s: %w", row.LSN, m.err)
+			}
+			continue
+
+		default:
+			// Begin, commit, origin, type and logical messages
+			continue
+		}
+
+		if m.err != nil {
+			return nil, fmt.Errorf("at %s: %w", row.LSN, m.err)
+		}
+		changes = append(changes, c)
+	}
+
+	return changes, nil
+}
+
+var errShortMessage = errors.New("pgoutput message is too short")
+
+// message reads the fields of a pgoutput message.
+// Once the message is too short, err is set and zero values are returned
+type message struct {
+	data []byte
+	err  error
+}
+
+func (m *message) next(n int) []byte {
+	if m.err != nil || len(m.data) < n {
+		m.err = errShortMessage
+		return make([]byte, n)
+	}
+
+	b := m.data[:n]
+	m.data = m.data[n:]
+	return b
+}
+
+func (m *message) skip(n int) {
+	m.next(n)
+}
+
+func (m *message) byte() byte {
+	return m.next(1)[0]
+}
+
+func (m *message) uint16() uint16 {
+	return binary.BigEndian.Uint16(m.next(2))
+}
+
+func (m *message) uint32() uint32 {
+	return binary.BigEndian.Uint32(m.next(4))
+}
+
+func (m *message) string() string {
+	i := bytes.IndexByte(m.data, 0)
+	if m.err != nil || i < 0 {
+		m.err = errShortMessage
+		return ""
+	}
+
+	s := string(m.data[:i])
+	m.data = m.data[i+1:]
+	return s
+}
+
+func (m *message) relation(relations map[uint32]relation) (relation, error) {
+	id := m.uint32()
+	if m.err != nil {
+		return relation{}, m.err
+	}
+
+	rel, ok := relations[id]
+	if !ok {
+		return relation{}, fmt.Errorf("unknown relation %d", id)
+	}
+
+	return rel, nil
+}
+
+// tuple reads the values of a row. Unchanged TOAST values are left out
+func (m *message) tuple(rel relation) []Column {
+	n := int(m.uint16())
+	columns := make([]Column, 0, n)
+
+	for i := 0; i < n && m.err == nil; i++ {
+		kind := m.byte()
+		if i >= len(rel.columns) {
+			m.err = fmt.Errorf("relation %s.%s has %d columns, got %d", rel.schema, rel.table, len(rel.columns), n)
+			break
+		}
+
+		col := rel.columns[i]
+		switch kind {
+		case 'n':
+		case 'u':
+			continue
+		case 't':
+			size := int(m.uint32())
+			if m.err == nil && size > len(m.data) {
+				m.err = errShortMessage
+				continue
+			}
+			s := string(m.next(size))
+			col.Value = &s
+		default:
+			m.err = fmt.Errorf("unknown tuple data %q", kind)
+			continue
+		}
+
+		columns = append(columns, col)
+	}
+
+	return columns
+}
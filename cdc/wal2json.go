@@ -0,0 +1,97 @@
+package cdc
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// wal2jsonChange is a row of the format version 2 of wal2json
+type wal2jsonChange struct {
+	Action   string           `json:"action"`
+	Schema   string           `json:"schema"`
+	Table    string           `json:"table"`
+	Columns  []wal2jsonColumn `json:"columns"`
+	Identity []wal2jsonColumn `json:"identity"`
+}
+
+type wal2jsonColumn struct {
+	Name  string          `json:"name"`
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+func decodeWal2JSON(rows []rawChange) ([]Change, error) {
+	var changes []Change
+
+	for _, row := range rows {
+		var raw wal2jsonChange
+		if err := json.Unmarshal(row.Data, &raw); err != nil {
+			return nil, fmt.Errorf("at %s: %w", row.LSN, err)
+		}
+
+		var op Operation
+		switch raw.Action {
+		case "I":
+			op = Insert
+		case "U":
+			op = Update
+		case "D":
+			op = Delete
+		case "T":
+			op = Truncate
+		default:
+			// Begin, commit and logical messages
+			continue
+		}
+
+		c := Change{
+			LSN:       row.LSN,
+			XID:       row.XID,
+			Operation: op,
+			Schema:    raw.Schema,
+			Table:     raw.Table,
+		}
+
+		var err error
+		if c.Columns, err = wal2jsonColumns(raw.Columns); err != nil {
+			return nil, fmt.Errorf("at %s: %w", row.LSN, err)
+		}
+		if c.Old, err = wal2jsonColumns(raw.Identity); err != nil {
+			return nil, fmt.Errorf("at %s: %w", row.LSN, err)
+		}
+
+		changes = append(changes, c)
+	}
+
+	return changes, nil
+}
+
+// wal2jsonColumns converts the JSON values to the text format of Postgres.
+// Strings are unquoted, and numbers, booleans and JSON values are kept as they are written
+func wal2jsonColumns(raw []wal2jsonColumn) ([]Column, error) {
+	if len(raw) == 0 {
+		return nil, nil
+	}
+
+	columns := make([]Column, len(raw))
+	for i, col := range raw {
+		columns[i] = Column{Name: col.Name, Type: col.Type}
+
+		value := bytes.TrimSpace(col.Value)
+		switch {
+		case len(value) == 0, bytes.Equal(value, []byte("null")):
+		case value[0] == '"':
+			var s string
+			if err := json.Unmarshal(value, &s); err != nil {
+				return nil, fmt.Errorf("column %s: %w", col.Name, err)
+			}
+			columns[i].Value = &s
+		default:
+			s := string(value)
+			columns[i].Value = &s
+		}
+	}
+
+	return columns, nil
+}
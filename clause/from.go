@@ -44,6 +44,7 @@ type From struct {
 	IndexedBy      *string     // SQLite
 	Partitions     []string    // MySQL
 	IndexHints     []IndexHint // MySQL
+	SystemTime     any         // MariaDB, written after FOR SYSTEM_TIME AS OF
 
 	// Joins
 	Joins []Join
@@ -74,6 +75,10 @@ func (f *From) SetIndexedBy(i *string) {
 	f.IndexedBy = i
 }
 
+func (f *From) SetSystemTime(t any) {
+	f.SystemTime = t
+}
+
 func (f *From) AppendJoin(j Join) {
 	f.Joins = append(f.Joins, j)
 }
@@ -113,6 +118,13 @@ func (f From) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
 		return nil, err
 	}
 
+	timeArgs, err := bob.ExpressIf(w, d, start+len(args), f.SystemTime,
+		f.SystemTime != nil, " FOR SYSTEM_TIME AS OF ", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, timeArgs...)
+
 	if f.Alias != "" {
 		w.Write([]byte(" AS "))
 		d.WriteQuoted(w, f.Alias)
@@ -182,3 +194,25 @@ func (f IndexHint) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error
 
 	return nil, nil
 }
+
+// Constructs lists the joins and modifiers that not every dialect supports
+func (f From) Constructs() []bob.Construct {
+	var constructs []bob.Construct
+
+	if f.Lateral {
+		constructs = append(constructs, bob.ConstructLateral)
+	}
+
+	if f.SystemTime != nil {
+		constructs = append(constructs, bob.ConstructAsOf)
+	}
+
+	for _, j := range f.Joins {
+		if j.Type == FullJoin {
+			constructs = append(constructs, bob.ConstructFullJoin)
+		}
+		constructs = append(constructs, j.To.Constructs()...)
+	}
+
+	return constructs
+}
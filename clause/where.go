@@ -8,12 +8,29 @@ import (
 
 type Where struct {
 	Conditions []any
+	// Allows an UPDATE or DELETE without conditions
+	AllRows bool
 }
 
 func (wh *Where) AppendWhere(e ...any) {
 	wh.Conditions = append(wh.Conditions, e...)
 }
 
+func (wh *Where) SetAllRows() {
+	wh.AllRows = true
+}
+
+// CheckAllRows returns [bob.ErrMissingWhere] if there are no conditions
+// and all rows were not explicitly allowed.
+// It is used by UPDATE and DELETE queries
+func (wh Where) CheckAllRows() error {
+	if len(wh.Conditions) == 0 && !wh.AllRows {
+		return bob.ErrMissingWhere
+	}
+
+	return nil
+}
+
 func (wh Where) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
 	args, err := bob.ExpressSlice(w, d, start, wh.Conditions, "WHERE ", " AND ", "")
 	if err != nil {
@@ -0,0 +1,267 @@
+package bob
+
+import (
+	"context"
+	"database/sql/driver"
+	"fmt"
+	"reflect"
+	"sort"
+
+	"github.com/stephenafamo/scan"
+)
+
+// ResultDiff is the difference between the rows that a query returned
+// from two executors, such as the old and the new database of a migration
+type ResultDiff[T any] struct {
+	// The rows that only A returned
+	OnlyA []T
+	// The rows that only B returned
+	OnlyB []T
+	// The rows of A and B that are the same row with different values
+	Changed []RowDiff[T]
+	// The number of rows that both returned
+	Matched int
+}
+
+// RowDiff is a row that the executors returned with different values
+type RowDiff[T any] struct {
+	A, B T
+	// The struct fields or map keys that differ
+	Fields []string
+}
+
+// Equal reports if both executors returned the same rows
+func (r ResultDiff[T]) Equal() bool {
+	return len(r.OnlyA) == 0 && len(r.OnlyB) == 0 && len(r.Changed) == 0
+}
+
+// CompareResults runs the query on both executors and returns the rows that differ.
+// Rows are compared in any order, since databases can return the rows of a query
+// without ORDER BY in different orders. A row that is returned more than once
+// has to be returned as many times by the other executor.
+//
+// Values with an Equal method, such as [time.Time], are compared with it, so that
+// the same instant in another location matches. Other [driver.Valuer] values are
+// compared by their Value.
+//
+// When the rows are structs or maps, a row of A that was not matched is paired with
+// the row of B that has the fewest different fields, if some of its fields are equal.
+// These pairs are in Changed with the fields that differ
+func CompareResults[T any](ctx context.Context, execA, execB Executor, q Query, m scan.Mapper[T]) (ResultDiff[T], error) {
+	rowsA, err := All(ctx, execA, q, m)
+	if err != nil {
+		return ResultDiff[T]{}, fmt.Errorf("query A: %w", err)
+	}
+
+	rowsB, err := All(ctx, execB, q, m)
+	if err != nil {
+		return ResultDiff[T]{}, fmt.Errorf("query B: %w", err)
+	}
+
+	return diffRows(rowsA, rowsB), nil
+}
+
+func diffRows[T any](rowsA, rowsB []T) ResultDiff[T] {
+	var diff ResultDiff[T]
+	matched := make([]bool, len(rowsB))
+
+	// The search starts after the last match,
+	// so rows in the same order are matched in one pass
+	next := 0
+
+	for _, a := range rowsA {
+		found := false
+		for k := range rowsB {
+			j := (next + k) % len(rowsB)
+			if matched[j] || !valuesEqual(reflect.ValueOf(a), reflect.ValueOf(rowsB[j])) {
+				continue
+			}
+
+			matched[j] = true
+			next = j + 1
+			found = true
+			break
+		}
+
+		if found {
+			diff.Matched++
+		} else {
+			diff.OnlyA = append(diff.OnlyA, a)
+		}
+	}
+
+	onlyA := diff.OnlyA
+	diff.OnlyA = nil
+	for _, a := range onlyA {
+		best, bestFields := -1, []string(nil)
+		for j, b := range rowsB {
+			if matched[j] {
+				continue
+			}
+
+			fields, all := diffFields(reflect.ValueOf(a), reflect.ValueOf(b))
+			if len(fields) == 0 || len(fields) == all {
+				continue
+			}
+
+			if best == -1 || len(fields) < len(bestFields) {
+				best, bestFields = j, fields
+			}
+		}
+
+		if best == -1 {
+			diff.OnlyA = append(diff.OnlyA, a)
+			continue
+		}
+
+		matched[best] = true
+		diff.Changed = append(diff.Changed, RowDiff[T]{A: a, B: rowsB[best], Fields: bestFields})
+	}
+
+	for j, b := range rowsB {
+		if !matched[j] {
+			diff.OnlyB = append(diff.OnlyB, b)
+		}
+	}
+
+	return diff
+}
+
+// diffFields returns the exported struct fields or the map keys
+// of the rows that differ, and the number of fields that were compared
+func diffFields(a, b reflect.Value) ([]string, int) {
+	for a.Kind() == reflect.Pointer && b.Kind() == reflect.Pointer && !a.IsNil() && !b.IsNil() {
+		a, b = a.Elem(), b.Elem()
+	}
+
+	if !a.IsValid() || !b.IsValid() || a.Type() != b.Type() {
+		return nil, 0
+	}
+
+	var fields []string
+
+	switch a.Kind() {
+	case reflect.Struct:
+		all := 0
+		for i := 0; i < a.NumField(); i++ {
+			if !a.Type().Field(i).IsExported() {
+				continue
+			}
+
+			all++
+			if !valuesEqual(a.Field(i), b.Field(i)) {
+				fields = append(fields, a.Type().Field(i).Name)
+			}
+		}
+		return fields, all
+
+	case reflect.Map:
+		if a.Type().Key().Kind() != reflect.String {
+			return nil, 0
+		}
+
+		keys := map[string]struct{}{}
+		for _, k := range append(a.MapKeys(), b.MapKeys()...) {
+			keys[k.String()] = struct{}{}
+		}
+
+		for k := range keys {
+			key := reflect.ValueOf(k).Convert(a.Type().Key())
+			if !valuesEqual(a.MapIndex(key), b.MapIndex(key)) {
+				fields = append(fields, k)
+			}
+		}
+		sort.Strings(fields)
+		return fields, len(keys)
+
+	default:
+		return nil, 0
+	}
+}
+
+//nolint:gochecknoglobals
+var valuerType = reflect.TypeOf((*driver.Valuer)(nil)).Elem()
+
+// valuesEqual is like [reflect.DeepEqual], but uses the Equal method of the values
+// that have one, and compares other [driver.Valuer] values by their Value.
+// Nil and empty slices and maps are equal
+func valuesEqual(a, b reflect.Value) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+
+	if a.Type() != b.Type() {
+		return false
+	}
+
+	if a.CanInterface() && b.CanInterface() {
+		if m, ok := a.Type().MethodByName("Equal"); ok &&
+			m.Type.NumIn() == 2 && m.Type.In(1) == a.Type() &&
+			m.Type.NumOut() == 1 && m.Type.Out(0).Kind() == reflect.Bool {
+			return a.Method(m.Index).Call([]reflect.Value{b})[0].Bool()
+		}
+
+		if a.Type().Implements(valuerType) && a.Kind() != reflect.Pointer && a.Kind() != reflect.Interface {
+			valA, errA := a.Interface().(driver.Valuer).Value()
+			valB, errB := b.Interface().(driver.Valuer).Value()
+			if errA == nil && errB == nil {
+				return valuesEqual(reflect.ValueOf(valA), reflect.ValueOf(valB))
+			}
+		}
+	}
+
+	switch a.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return a.IsNil() == b.IsNil()
+		}
+		return valuesEqual(a.Elem(), b.Elem())
+
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if !valuesEqual(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.Slice, reflect.Array:
+		if a.Len() != b.Len() {
+			return false
+		}
+		for i := 0; i < a.Len(); i++ {
+			if !valuesEqual(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.Map:
+		if a.Len() != b.Len() {
+			return false
+		}
+		iter := a.MapRange()
+		for iter.Next() {
+			if !valuesEqual(iter.Value(), b.MapIndex(iter.Key())) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return a.Int() == b.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return a.Uint() == b.Uint()
+	case reflect.Float32, reflect.Float64:
+		return a.Float() == b.Float()
+	case reflect.Complex64, reflect.Complex128:
+		return a.Complex() == b.Complex()
+	case reflect.String:
+		return a.String() == b.String()
+	default:
+		// funcs, channels and unsafe pointers
+		return a.Pointer() == b.Pointer()
+	}
+}
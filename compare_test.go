@@ -0,0 +1,111 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/aarondl/opt/null"
+
+	"github.com/stephenafamo/scan"
+	_ "modernc.org/sqlite"
+)
+
+func TestCompareResults(t *testing.T) {
+	ctx := context.Background()
+
+	open := func(names ...string) Executor {
+		t.Helper()
+		sqlDB, err := sql.Open("sqlite", ":memory:")
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { sqlDB.Close() })
+		sqlDB.SetMaxOpenConns(1)
+
+		if _, err := sqlDB.ExecContext(ctx, "CREATE TABLE users (name TEXT NOT NULL)"); err != nil {
+			t.Fatal(err)
+		}
+		for _, name := range names {
+			if _, err := sqlDB.ExecContext(ctx, "INSERT INTO users (name) VALUES (?)", name); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return NewDB(sqlDB)
+	}
+
+	q := rawQuery(d, "SELECT name FROM users")
+
+	diff, err := CompareResults(ctx, open("a", "b", "c"), open("c", "a", "b"), q, scan.SingleColumnMapper[string])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !diff.Equal() || diff.Matched != 3 {
+		t.Fatalf("expected the same rows in another order to be equal, got %+v", diff)
+	}
+
+	diff, err = CompareResults(ctx, open("a", "b", "b", "c"), open("b", "c", "d"), q, scan.SingleColumnMapper[string])
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := ResultDiff[string]{OnlyA: []string{"a", "b"}, OnlyB: []string{"d"}, Matched: 2}
+	if !reflect.DeepEqual(diff, expected) {
+		t.Fatalf("wrong diff\nexpected: %+v\n     got: %+v", expected, diff)
+	}
+	if diff.Equal() {
+		t.Fatal("expected the diff not to be equal")
+	}
+
+	_, err = CompareResults(ctx, open(), open(), rawQuery(d, "SELECT missing FROM users"), scan.SingleColumnMapper[string])
+	if err == nil {
+		t.Fatal("expected an error for a query that fails")
+	}
+}
+
+func TestDiffRows(t *testing.T) {
+	type user struct {
+		ID      int
+		Name    string
+		Created time.Time
+		Deleted null.Val[time.Time]
+	}
+
+	created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	rowsA := []user{
+		{ID: 1, Name: "a", Created: created, Deleted: null.From(created)},
+		{ID: 2, Name: "b", Created: created},
+		{ID: 3, Name: "c", Created: created},
+	}
+	rowsB := []user{
+		{ID: 1, Name: "a", Created: created.In(time.FixedZone("X", 3600)), Deleted: null.From(created.Local())},
+		{ID: 2, Name: "B", Created: created.Add(time.Second)},
+		{ID: 4, Name: "d", Created: created.Add(time.Hour), Deleted: null.From(created)},
+	}
+
+	diff := diffRows(rowsA, rowsB)
+	expected := ResultDiff[user]{
+		OnlyA: []user{rowsA[2]},
+		OnlyB: []user{rowsB[2]},
+		Changed: []RowDiff[user]{
+			{A: rowsA[1], B: rowsB[1], Fields: []string{"Name", "Created"}},
+		},
+		Matched: 1,
+	}
+	if !reflect.DeepEqual(diff, expected) {
+		t.Fatalf("wrong diff\nexpected: %+v\n     got: %+v", expected, diff)
+	}
+
+	mapDiff := diffRows(
+		[]map[string]any{{"id": 1, "name": "a"}},
+		[]map[string]any{{"id": 1, "name": "b", "extra": true}},
+	)
+	if len(mapDiff.Changed) != 1 || !reflect.DeepEqual(mapDiff.Changed[0].Fields, []string{"extra", "name"}) {
+		t.Fatalf("expected the map keys that differ, got %+v", mapDiff)
+	}
+
+	if !valuesEqual(reflect.ValueOf([]byte(nil)), reflect.ValueOf([]byte{})) {
+		t.Fatal("expected nil and empty slices to be equal")
+	}
+}
@@ -0,0 +1,261 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"unicode"
+
+	"github.com/stephenafamo/scan"
+)
+
+// ErrOverBudget is returned by a [CostBudget] executor for queries
+// whose estimated cost or rows are over the budget
+var ErrOverBudget = errors.New("query is over the cost budget")
+
+// QueryCost is the estimate of the planner for a query
+type QueryCost struct {
+	// The total cost of the plan, in the units of the planner
+	Cost float64
+	// The number of rows the plan is expected to return
+	Rows float64
+}
+
+// CostBudgetOptions configure the executor returned by [CostBudget]
+type CostBudgetOptions struct {
+	// The maximum estimated cost of a query, 0 for no limit
+	MaxCost float64
+	// The maximum estimated number of rows of a query, 0 for no limit
+	MaxRows float64
+	// Called for the queries that are over the budget, e.g. to log them.
+	// The error it returns is returned instead of running the query,
+	// so returning nil lets the query run.
+	// If it is nil, the queries are rejected with an error wrapping [ErrOverBudget]
+	OnOverBudget func(ctx context.Context, query string, cost QueryCost) error
+	// The number of fingerprints whose estimates are cached, 1000 if zero.
+	// The least recently used ones are explained again when they are seen
+	CacheSize int
+}
+
+// CostBudget wraps an [Executor] so that queries are checked with the
+// estimates of the Postgres planner before they run, as a guard rail for
+// queries assembled from user input.
+//
+// The first time a query is seen, it is run with EXPLAIN, and the result is
+// cached by its [Fingerprint], so queries that only differ in their values
+// are only explained once. Since the plan can depend on the values, the
+// estimate of the first query is used for all of them.
+// The estimates of the most recently used fingerprints are kept, up to CacheSize.
+// Statements that cannot be explained, such as DDL, are not checked.
+//
+// The returned executor cannot prepare statements, to make sure
+// every query is checked
+func CostBudget(exec Executor, opts CostBudgetOptions) Executor {
+	if opts.CacheSize <= 0 {
+		opts.CacheSize = 1000
+	}
+
+	return &costBudgetExecutor{
+		exec:  exec,
+		opts:  opts,
+		costs: newLRU[QueryCost](opts.CacheSize),
+	}
+}
+
+type costBudgetExecutor struct {
+	exec Executor
+	opts CostBudgetOptions
+
+	mu    sync.Mutex
+	costs *lru[QueryCost]
+}
+
+// Unwrap returns the wrapped executor
+func (c *costBudgetExecutor) Unwrap() Executor {
+	return c.exec
+}
+
+func (c *costBudgetExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if err := c.check(ctx, query, args); err != nil {
+		return nil, err
+	}
+
+	return c.exec.ExecContext(ctx, query, args...)
+}
+
+func (c *costBudgetExecutor) QueryContext(ctx context.Context, query string, args ...any) (scan.Rows, error) {
+	if err := c.check(ctx, query, args); err != nil {
+		return nil, err
+	}
+
+	return c.exec.QueryContext(ctx, query, args...)
+}
+
+func (c *costBudgetExecutor) check(ctx context.Context, query string, args []any) error {
+	if !explainable(query) {
+		return nil
+	}
+
+	fingerprint := Fingerprint(query)
+
+	c.mu.Lock()
+	cost, ok := c.costs.get(fingerprint)
+	c.mu.Unlock()
+
+	if !ok {
+		var err error
+		cost, err = c.explain(ctx, query, args)
+		if err != nil {
+			return err
+		}
+
+		c.mu.Lock()
+		c.costs.add(fingerprint, cost)
+		c.mu.Unlock()
+	}
+
+	var reason string
+	switch {
+	case c.opts.MaxCost > 0 && cost.Cost > c.opts.MaxCost:
+		reason = fmt.Sprintf("estimated cost %.2f is over %.2f", cost.Cost, c.opts.MaxCost)
+	case c.opts.MaxRows > 0 && cost.Rows > c.opts.MaxRows:
+		reason = fmt.Sprintf("estimated rows %.0f are over %.0f", cost.Rows, c.opts.MaxRows)
+	default:
+		return nil
+	}
+
+	if c.opts.OnOverBudget != nil {
+		return c.opts.OnOverBudget(ctx, query, cost)
+	}
+
+	return fmt.Errorf("%w: %s", ErrOverBudget, reason)
+}
+
+func (c *costBudgetExecutor) explain(ctx context.Context, query string, args []any) (QueryCost, error) {
+	rows, err := c.exec.QueryContext(ctx, "EXPLAIN (FORMAT JSON) "+query, args...)
+	if err != nil {
+		return QueryCost{}, fmt.Errorf("cost budget: %w", err)
+	}
+	defer rows.Close()
+
+	var plan string
+	if rows.Next() {
+		if err := rows.Scan(&plan); err != nil {
+			return QueryCost{}, fmt.Errorf("cost budget: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return QueryCost{}, fmt.Errorf("cost budget: %w", err)
+	}
+
+	var plans []struct {
+		Plan struct {
+			TotalCost float64 `json:"Total Cost"`
+			PlanRows  float64 `json:"Plan Rows"`
+		}
+	}
+	if err := json.Unmarshal([]byte(plan), &plans); err != nil {
+		return QueryCost{}, fmt.Errorf("cost budget: parsing the plan: %w", err)
+	}
+	if len(plans) == 0 {
+		return QueryCost{}, errors.New("cost budget: EXPLAIN returned no plan")
+	}
+
+	return QueryCost{Cost: plans[0].Plan.TotalCost, Rows: plans[0].Plan.PlanRows}, nil
+}
+
+// explainable returns true for the statements that Postgres can EXPLAIN
+func explainable(query string) bool {
+	words := sqlKeywords(query)
+	if len(words) == 0 {
+		return false
+	}
+
+	switch words[0] {
+	case "SELECT", "INSERT", "UPDATE", "DELETE", "MERGE", "VALUES", "WITH", "TABLE":
+		return true
+	}
+
+	return false
+}
+
+// A list of values, e.g. (?, ?, ?)
+var fingerprintList = regexp.MustCompile(`\?(?:, \?)+`)
+
+// Fingerprint normalizes a query so that queries that only differ in their
+// values have the same fingerprint, e.g. for caching or grouping queries in logs.
+// Literals and placeholders become ?, lists of them become a single ?,
+// comments are removed, and whitespace and the case of keywords are normalized
+func Fingerprint(query string) string {
+	var tokens []string
+
+	for i := 0; i < len(query); {
+		c := query[i]
+		start := i
+
+		switch {
+		case strings.HasPrefix(query[i:], "--"):
+			i = skipTo(query, i, "\n")
+
+		case strings.HasPrefix(query[i:], "/*"):
+			i = skipTo(query, i+2, "*/")
+
+		case unicode.IsSpace(rune(c)):
+			i++
+
+		case c == '\'':
+			i = skipTo(query, i+1, "'")
+			tokens = append(tokens, "?")
+
+		case c == '"' || c == '`' || c == '[':
+			end := string(c)
+			if c == '[' {
+				end = "]"
+			}
+			i = skipTo(query, i+1, end)
+			tokens = append(tokens, query[start:i])
+
+		// Placeholders, such as $1, ?1, @p1 and :name
+		case (c == '$' || c == '?' || c == '@' || c == ':') && i+1 < len(query) && isWordChar(query[i+1]):
+			for i++; i < len(query) && isWordChar(query[i]); i++ {
+			}
+			tokens = append(tokens, "?")
+
+		case isDigit(c):
+			for i < len(query) && (isDigit(query[i]) || query[i] == '.') {
+				i++
+			}
+			tokens = append(tokens, "?")
+
+		case isWordChar(c):
+			for i < len(query) && (isWordChar(query[i]) || query[i] == '$') {
+				i++
+			}
+			tokens = append(tokens, strings.ToLower(query[start:i]))
+
+		case c == '(' || c == ')' || c == ',' || c == ';':
+			i++
+			tokens = append(tokens, query[start:i])
+
+		default:
+			// Operators, e.g. >= or ::
+			for i++; i < len(query) && strings.IndexByte("<>=!~+-*/%|&^:.", query[i]) >= 0; i++ {
+			}
+			tokens = append(tokens, query[start:i])
+		}
+	}
+
+	fingerprint := strings.Join(tokens, " ")
+	fingerprint = strings.NewReplacer("( ", "(", " )", ")", " ,", ",", " ;", ";", " . ", ".").Replace(fingerprint)
+
+	return fingerprintList.ReplaceAllString(fingerprint, "?")
+}
+
+func isWordChar(c byte) bool {
+	return c == '_' || isDigit(c) || unicode.IsLetter(rune(c))
+}
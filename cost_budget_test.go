@@ -0,0 +1,149 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stephenafamo/scan"
+)
+
+type planExecutor struct {
+	NoopExecutor
+	plan     string
+	explains *int
+	queries  *int
+}
+
+func (p planExecutor) ExecContext(_ context.Context, query string, _ ...any) (sql.Result, error) {
+	*p.queries++
+	return dryRunResult{}, nil
+}
+
+func (p planExecutor) QueryContext(_ context.Context, query string, _ ...any) (scan.Rows, error) {
+	if strings.HasPrefix(query, "EXPLAIN") {
+		*p.explains++
+		return &planRows{plan: p.plan}, nil
+	}
+
+	*p.queries++
+	return emptyRows{}, nil
+}
+
+type planRows struct {
+	emptyRows
+	plan string
+	read bool
+}
+
+func (p *planRows) Next() bool {
+	if p.read {
+		return false
+	}
+
+	p.read = true
+	return true
+}
+
+func (p *planRows) Scan(dest ...any) error {
+	*dest[0].(*string) = p.plan
+	return nil
+}
+
+func TestCostBudget(t *testing.T) {
+	ctx := context.Background()
+	plan := `[{"Plan": {"Node Type": "Seq Scan", "Total Cost": 1500.5, "Plan Rows": 20000}}]`
+
+	var explains, queries int
+	exec := planExecutor{plan: plan, explains: &explains, queries: &queries}
+
+	cheap := CostBudget(exec, CostBudgetOptions{MaxCost: 2000, MaxRows: 50000})
+	if _, err := cheap.QueryContext(ctx, "SELECT * FROM users WHERE id IN ($1, $2)", 1, 2); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := cheap.QueryContext(ctx, "SELECT * FROM users WHERE id IN ($1, $2, $3)", 1, 2, 3); err != nil {
+		t.Fatal(err)
+	}
+	if explains != 1 || queries != 2 {
+		t.Fatalf("expected 1 explain and 2 queries, got %d and %d", explains, queries)
+	}
+
+	// DDL is not explained
+	if _, err := cheap.ExecContext(ctx, "CREATE TABLE a (id int)"); err != nil || explains != 1 {
+		t.Fatalf("expected DDL to run without EXPLAIN, got %d explains, %v", explains, err)
+	}
+
+	expensive := CostBudget(exec, CostBudgetOptions{MaxRows: 10000})
+	_, err := expensive.ExecContext(ctx, "DELETE FROM users WHERE name = $1", "x")
+	if !errors.Is(err, ErrOverBudget) {
+		t.Fatalf("expected ErrOverBudget, got %v", err)
+	}
+	if queries != 3 {
+		t.Fatalf("expected the query over the budget not to run")
+	}
+
+	var logged QueryCost
+	logging := CostBudget(exec, CostBudgetOptions{
+		MaxCost: 1000,
+		OnOverBudget: func(_ context.Context, _ string, cost QueryCost) error {
+			logged = cost
+			return nil
+		},
+	})
+	if _, err := logging.QueryContext(ctx, "SELECT * FROM users"); err != nil {
+		t.Fatal(err)
+	}
+	if logged != (QueryCost{Cost: 1500.5, Rows: 20000}) || queries != 4 {
+		t.Fatalf("expected the query to be logged and run, got %#v", logged)
+	}
+}
+
+func TestCostBudgetCacheSize(t *testing.T) {
+	ctx := context.Background()
+	plan := `[{"Plan": {"Node Type": "Seq Scan", "Total Cost": 10, "Plan Rows": 10}}]`
+
+	var explains, queries int
+	exec := CostBudget(planExecutor{plan: plan, explains: &explains, queries: &queries}, CostBudgetOptions{
+		MaxCost:   100,
+		CacheSize: 2,
+	})
+
+	for _, query := range []string{
+		"SELECT * FROM users",
+		"SELECT * FROM posts",
+		"SELECT * FROM users",    // cached
+		"SELECT * FROM comments", // evicts posts
+		"SELECT * FROM users",    // cached
+		"SELECT * FROM posts",    // explained again
+	} {
+		if _, err := exec.QueryContext(ctx, query); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if explains != 4 {
+		t.Fatalf("expected the least recently used estimate to be evicted, got %d explains", explains)
+	}
+	if n := exec.(*costBudgetExecutor).costs.len(); n != 2 {
+		t.Fatalf("expected 2 cached estimates, got %d", n)
+	}
+}
+
+func TestFingerprint(t *testing.T) {
+	tests := map[string]string{
+		"SELECT * FROM users WHERE id = $1":                           "select * from users where id = ?",
+		"select *\n  from users -- comment\n where id = 10":           "select * from users where id = ?",
+		`SELECT "users"."name" FROM users WHERE name = 'O''Brien'`:    `select "users"."name" from users where name = ? ?`,
+		"SELECT * FROM users WHERE id IN (?1, ?2, ?3) AND age >= @p4": "select * from users where id in (?) and age >= ?",
+		"INSERT INTO t (a, b) VALUES ($1, $2), ($3, $4)":              "insert into t (a, b) values (?), (?)",
+		"SELECT created_at::date, t.x FROM t /* a, b */ LIMIT 5":      "select created_at :: date, t.x from t limit ?",
+	}
+
+	for query, expected := range tests {
+		if got := Fingerprint(query); got != expected {
+			t.Errorf("Fingerprint(%q)\nexpected: %s\n     got: %s", query, expected, got)
+		}
+	}
+}
@@ -57,6 +57,11 @@ type debugExecutor struct {
 	exec    Executor
 }
 
+// Unwrap returns the wrapped executor
+func (d debugExecutor) Unwrap() Executor {
+	return d.exec
+}
+
 func (d debugExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
 	d.printer.PrintQuery(query, args...)
 	return d.exec.ExecContext(ctx, query, args...)
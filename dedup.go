@@ -0,0 +1,204 @@
+package bob
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/stephenafamo/scan"
+)
+
+// DedupArgs returns the query with identical args sent only once, with their
+// placeholder reused wherever they appear, e.g. "id = $1 OR parent_id = $1".
+// This keeps queries that repeat the same values many times under the args
+// limit of the dialect.
+//
+// Only dialects with numbered placeholders can reuse them, such as Postgres,
+// SQLite and SQL Server. With other dialects, such as MySQL, the query is unchanged.
+// Values that cannot be compared, such as []byte, are never deduplicated
+func DedupArgs[E Expression](q BaseQuery[E]) BaseQuery[Expression] {
+	return BaseQuery[Expression]{
+		Expression: dedupExpression{inner: q.Expression},
+		Dialect:    q.Dialect,
+		trace:      q.trace,
+	}
+}
+
+type dedupExpression struct {
+	inner Expression
+}
+
+func (d dedupExpression) GetLoaders() []Loader {
+	if l, ok := d.inner.(Loadable); ok {
+		return l.GetLoaders()
+	}
+
+	return nil
+}
+
+func (d dedupExpression) GetMapperMods() []scan.MapperMod {
+	if l, ok := d.inner.(MapperModder); ok {
+		return l.GetMapperMods()
+	}
+
+	return nil
+}
+
+func (d dedupExpression) Constructs() []Construct {
+	if l, ok := d.inner.(ConstructLister); ok {
+		return l.Constructs()
+	}
+
+	return nil
+}
+
+func (d dedupExpression) WriteSQL(w io.Writer, dl Dialect, start int) ([]any, error) {
+	var buf bytes.Buffer
+	args, err := d.inner.WriteSQL(&buf, dl, start)
+	if err != nil {
+		return nil, err
+	}
+
+	sql, args := dedupPlaceholders(dl, buf.String(), args, start)
+	if _, err := io.WriteString(w, sql); err != nil {
+		return nil, err
+	}
+
+	return args, nil
+}
+
+// argPrefix returns what the dialect writes before the number of a placeholder,
+// or false if the placeholders are not numbered
+func argPrefix(d Dialect) (string, bool) {
+	var buf bytes.Buffer
+	d.WriteArg(&buf, 1)
+
+	prefix := strings.TrimSuffix(buf.String(), "1")
+	if prefix == buf.String() || prefix == "" {
+		return "", false
+	}
+
+	return prefix, true
+}
+
+func dedupPlaceholders(d Dialect, sql string, args []any, start int) (string, []any) {
+	prefix, ok := argPrefix(d)
+	if !ok || len(args) < 2 {
+		return sql, args
+	}
+
+	var out strings.Builder
+	var unique []any
+	seen := make(map[any]int)  // value => new position
+	moved := make(map[int]int) // old position => new position
+
+	newPosition := func(old int) int {
+		if n, ok := moved[old]; ok {
+			return n
+		}
+
+		i := old - start
+		if i < 0 || i >= len(args) {
+			// Not one of our args, leave it alone
+			return old
+		}
+
+		key, comparable := dedupKey(args[i])
+		if comparable {
+			if n, ok := seen[key]; ok {
+				moved[old] = n
+				return n
+			}
+		}
+
+		n := start + len(unique)
+		unique = append(unique, args[i])
+		moved[old] = n
+		if comparable {
+			seen[key] = n
+		}
+		return n
+	}
+
+	for i := 0; i < len(sql); {
+		c := sql[i]
+
+		switch {
+		case strings.HasPrefix(sql[i:], prefix) && i+len(prefix) < len(sql) && isDigit(sql[i+len(prefix)]):
+			j := i + len(prefix)
+			for j < len(sql) && isDigit(sql[j]) {
+				j++
+			}
+
+			old, err := strconv.Atoi(sql[i+len(prefix) : j])
+			if err != nil {
+				out.WriteString(sql[i:j])
+			} else {
+				d.WriteArg(&out, newPosition(old))
+			}
+			i = j
+			continue
+
+		case strings.HasPrefix(sql[i:], "--"):
+			end := skipTo(sql, i, "\n")
+			out.WriteString(sql[i:end])
+			i = end
+			continue
+
+		case strings.HasPrefix(sql[i:], "/*"):
+			end := skipTo(sql, i+2, "*/")
+			out.WriteString(sql[i:end])
+			i = end
+			continue
+
+		case c == '\'' || c == '"' || c == '`':
+			end := skipTo(sql, i+1, string(c))
+			out.WriteString(sql[i:end])
+			i = end
+			continue
+
+		case c == '$':
+			if tag := dollarTag(sql[i:]); tag != "" {
+				end := skipTo(sql, i+len(tag), tag)
+				out.WriteString(sql[i:end])
+				i = end
+				continue
+			}
+		}
+
+		out.WriteByte(c)
+		i++
+	}
+
+	// Every placeholder has to be accounted for,
+	// otherwise the args would not match anymore
+	if len(moved) != len(args) {
+		return sql, args
+	}
+
+	return out.String(), unique
+}
+
+// dedupKey returns the value as a map key if it can be compared
+func dedupKey(v any) (key any, ok bool) {
+	t := reflect.TypeOf(v)
+	if t == nil || !t.Comparable() {
+		return nil, false
+	}
+
+	// Structs and arrays can hold values that cannot be compared
+	defer func() {
+		if recover() != nil {
+			key, ok = nil, false
+		}
+	}()
+	_ = map[any]struct{}{v: {}}
+
+	return v, true
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
@@ -0,0 +1,107 @@
+package bob
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type questionDialect struct{ dialect }
+
+func (questionDialect) WriteArg(w io.Writer, position int) {
+	w.Write([]byte("?"))
+}
+
+func rawQuery(d Dialect, sql string, args ...any) BaseQuery[Expression] {
+	return BaseQuery[Expression]{
+		Expression: ExpressionFunc(func(w io.Writer, dl Dialect, start int) ([]any, error) {
+			for i := 0; i < len(sql); i++ {
+				if sql[i] == '#' {
+					dl.WriteArg(w, start)
+					start++
+					continue
+				}
+				w.Write([]byte{sql[i]})
+			}
+			return args, nil
+		}),
+		Dialect: d,
+	}
+}
+
+func TestDedupArgs(t *testing.T) {
+	tests := map[string]struct {
+		query        Query
+		expectedSQL  string
+		expectedArgs []any
+	}{
+		"repeated": {
+			query:        DedupArgs(rawQuery(d, "a = # OR b = # OR c = #", 1, 2, 1)),
+			expectedSQL:  "a = $1 OR b = $2 OR c = $1",
+			expectedArgs: []any{1, 2},
+		},
+		"different types": {
+			query:        DedupArgs(rawQuery(d, "a = # OR b = #", 1, int64(1))),
+			expectedSQL:  "a = $1 OR b = $2",
+			expectedArgs: []any{1, int64(1)},
+		},
+		"not comparable": {
+			query:        DedupArgs(rawQuery(d, "a = # OR b = # OR c = #", []byte("x"), []byte("x"), "y")),
+			expectedSQL:  "a = $1 OR b = $2 OR c = $3",
+			expectedArgs: []any{[]byte("x"), []byte("x"), "y"},
+		},
+		"strings and comments": {
+			query:        DedupArgs(rawQuery(d, "a = # /* $2 */ AND b = '$3' AND c = # -- $2\n", 1, 1)),
+			expectedSQL:  "a = $1 /* $2 */ AND b = '$3' AND c = $1 -- $2\n",
+			expectedArgs: []any{1},
+		},
+		"not numbered": {
+			query:        DedupArgs(rawQuery(questionDialect{}, "a = # OR b = #", 1, 1)),
+			expectedSQL:  "a = ? OR b = ?",
+			expectedArgs: []any{1, 1},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sql, args, err := Build(tc.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if sql != tc.expectedSQL {
+				t.Fatalf("wrong SQL\nexpected: %s\n     got: %s", tc.expectedSQL, sql)
+			}
+
+			if !reflect.DeepEqual(args, tc.expectedArgs) {
+				t.Fatalf("wrong args\nexpected: %#v\n     got: %#v", tc.expectedArgs, args)
+			}
+		})
+	}
+}
+
+func TestDedupArgsStart(t *testing.T) {
+	// Deduplicating a subquery keeps the positions after the start
+	var buf strings.Builder
+	args, err := DedupArgs(rawQuery(d, "# #", "x", "x")).WriteSQL(&buf, d, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if buf.String() != "($3 $3)" || !reflect.DeepEqual(args, []any{"x"}) {
+		t.Fatalf("got %q %#v", buf.String(), args)
+	}
+}
+
+func TestDedupArgsLimit(t *testing.T) {
+	args := make([]any, 5)
+	for i := range args {
+		args[i] = 7
+	}
+
+	q := DedupArgs(rawQuery(limitedDialect{max: 3}, "#####", args...))
+	if _, args, err := Build(q); err != nil || len(args) != 1 {
+		t.Fatalf("expected 1 arg without an error, got %d, %v", len(args), err)
+	}
+}
@@ -0,0 +1,198 @@
+package bob
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/stephenafamo/scan"
+)
+
+// ForeignKeyRef is a foreign key from the columns of Table to
+// the columns of ForeignTable. The tables are keys of the generated models,
+// qualified with the schema if it is not the default one
+type ForeignKeyRef struct {
+	Table          string
+	Columns        []string
+	ForeignTable   string
+	ForeignColumns []string
+}
+
+// CascadeInfo is what [DeleteCascade] needs to know about the table of a model
+type CascadeInfo struct {
+	Dialect    Dialect
+	Table      string
+	PrimaryKey []string
+	// The foreign keys of all the tables of the schema
+	ForeignKeys []ForeignKeyRef
+}
+
+// CascadeModel is a model that can be deleted with [DeleteCascade].
+// The generated models of tables with a primary key implement it
+type CascadeModel interface {
+	PrimaryKeyVals() Expression
+	CascadeInfo() CascadeInfo
+}
+
+// CascadeStep is a DELETE of [DeleteCascade] with the number of rows it removes
+type CascadeStep struct {
+	Table string
+	// The foreign key that is followed to the rows, nil for the row of the model
+	ForeignKey *ForeignKeyRef
+	Rows       int64
+}
+
+// DeleteCascade deletes the row of the model and the rows that reference it through
+// foreign keys, for tables without ON DELETE CASCADE. The referencing rows are deleted
+// first, the deepest first, and the deletes are returned in the order they ran.
+//
+// All the referencing rows are deleted, whatever the ON DELETE action of the foreign key.
+// Foreign keys that lead back to a table that is already being deleted from, such as a
+// self reference, are not followed, so the database rejects the delete if such rows exist.
+// Run it in a transaction, so that a failed delete does not leave the rows half deleted
+func DeleteCascade(ctx context.Context, exec Executor, model CascadeModel) ([]CascadeStep, error) {
+	return deleteCascade(ctx, exec, model, false)
+}
+
+// DeleteCascadeDryRun returns the deletes that [DeleteCascade] would run, with the
+// number of rows each would remove, without deleting anything.
+// Rows that are reached through several foreign keys are counted for each of them
+func DeleteCascadeDryRun(ctx context.Context, exec Executor, model CascadeModel) ([]CascadeStep, error) {
+	return deleteCascade(ctx, exec, model, true)
+}
+
+func deleteCascade(ctx context.Context, exec Executor, model CascadeModel, dryRun bool) ([]CascadeStep, error) {
+	info := model.CascadeInfo()
+	if len(info.PrimaryKey) == 0 {
+		return nil, fmt.Errorf("delete cascade: %s has no primary key", info.Table)
+	}
+
+	root := cascadeNode{table: info.Table, where: ExpressionFunc(func(w io.Writer, d Dialect, start int) ([]any, error) {
+		writeColumnList(w, d, info.PrimaryKey)
+		w.Write([]byte(" = "))
+		return Express(w, d, start, model.PrimaryKeyVals())
+	})}
+
+	var steps []CascadeStep
+	var run func(n cascadeNode, path []string) error
+	run = func(n cascadeNode, path []string) error {
+		path = append(path, n.table)
+
+		for _, fk := range info.ForeignKeys {
+			if fk.ForeignTable != n.table || sliceHas(path, fk.Table) {
+				continue
+			}
+
+			if err := run(n.child(fk), path); err != nil {
+				return err
+			}
+		}
+
+		step := CascadeStep{Table: n.table, ForeignKey: n.fk}
+		q := BaseQuery[Expression]{Expression: n.query(dryRun), Dialect: info.Dialect}
+
+		if dryRun {
+			count, err := One(ctx, exec, q, scan.SingleColumnMapper[int64])
+			if err != nil {
+				return fmt.Errorf("delete cascade: count %s: %w", n.table, err)
+			}
+			step.Rows = count
+		} else {
+			result, err := Exec(ctx, exec, q)
+			if err != nil {
+				return fmt.Errorf("delete cascade: delete from %s: %w", n.table, err)
+			}
+			if step.Rows, err = result.RowsAffected(); err != nil {
+				return fmt.Errorf("delete cascade: delete from %s: %w", n.table, err)
+			}
+		}
+
+		steps = append(steps, step)
+		return nil
+	}
+
+	if err := run(root, nil); err != nil {
+		return steps, err
+	}
+
+	return steps, nil
+}
+
+// cascadeNode is the rows of a table that are deleted, selected by the where expression
+type cascadeNode struct {
+	table string
+	fk    *ForeignKeyRef
+	where Expression
+}
+
+// child selects the rows that reference the rows of n through the foreign key
+func (n cascadeNode) child(fk ForeignKeyRef) cascadeNode {
+	return cascadeNode{
+		table: fk.Table,
+		fk:    &fk,
+		where: ExpressionFunc(func(w io.Writer, d Dialect, start int) ([]any, error) {
+			writeColumnList(w, d, fk.Columns)
+			w.Write([]byte(" IN (SELECT "))
+			writeColumnList(w, d, fk.ForeignColumns)
+			w.Write([]byte(" FROM "))
+			writeTableKey(w, d, n.table)
+			w.Write([]byte(" WHERE "))
+			args, err := Express(w, d, start, n.where)
+			if err != nil {
+				return nil, err
+			}
+			w.Write([]byte(closePar))
+			return args, nil
+		}),
+	}
+}
+
+func (n cascadeNode) query(count bool) Expression {
+	return ExpressionFunc(func(w io.Writer, d Dialect, start int) ([]any, error) {
+		if count {
+			w.Write([]byte("SELECT count(*) FROM "))
+		} else {
+			w.Write([]byte("DELETE FROM "))
+		}
+		writeTableKey(w, d, n.table)
+		w.Write([]byte(" WHERE "))
+		return Express(w, d, start, n.where)
+	})
+}
+
+// writeTableKey writes the key of a table, which may be qualified with the schema
+func writeTableKey(w io.Writer, d Dialect, key string) {
+	for i, part := range strings.Split(key, ".") {
+		if i > 0 {
+			w.Write([]byte("."))
+		}
+		d.WriteQuoted(w, part)
+	}
+}
+
+// writeColumnList writes a column, or a row of columns if there are several
+func writeColumnList(w io.Writer, d Dialect, columns []string) {
+	if len(columns) > 1 {
+		w.Write([]byte(openPar))
+	}
+	for i, c := range columns {
+		if i > 0 {
+			w.Write([]byte(", "))
+		}
+		d.WriteQuoted(w, c)
+	}
+	if len(columns) > 1 {
+		w.Write([]byte(closePar))
+	}
+}
+
+func sliceHas(s []string, v string) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,121 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"reflect"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+var cascadeForeignKeys = []ForeignKeyRef{
+	{Table: "posts", Columns: []string{"user_id"}, ForeignTable: "users", ForeignColumns: []string{"id"}},
+	{Table: "comments", Columns: []string{"post_id"}, ForeignTable: "posts", ForeignColumns: []string{"id"}},
+	{Table: "comments", Columns: []string{"user_id"}, ForeignTable: "users", ForeignColumns: []string{"id"}},
+	{Table: "users", Columns: []string{"invited_by"}, ForeignTable: "users", ForeignColumns: []string{"id"}},
+}
+
+type cascadeUser struct {
+	ID int
+}
+
+func (u cascadeUser) PrimaryKeyVals() Expression {
+	return ExpressionFunc(func(w io.Writer, d Dialect, start int) ([]any, error) {
+		d.WriteArg(w, start)
+		return []any{u.ID}, nil
+	})
+}
+
+func (u cascadeUser) CascadeInfo() CascadeInfo {
+	return CascadeInfo{
+		Dialect:     questionDialect{},
+		Table:       "users",
+		PrimaryKey:  []string{"id"},
+		ForeignKeys: cascadeForeignKeys,
+	}
+}
+
+func TestDeleteCascade(t *testing.T) {
+	ctx := context.Background()
+
+	sqlDB, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	sqlDB.SetMaxOpenConns(1)
+	db := NewDB(sqlDB)
+
+	for _, stmt := range []string{
+		`PRAGMA foreign_keys = ON`,
+		`CREATE TABLE users (id INTEGER PRIMARY KEY, invited_by INTEGER REFERENCES users (id))`,
+		`CREATE TABLE posts (id INTEGER PRIMARY KEY, user_id INTEGER NOT NULL REFERENCES users (id))`,
+		`CREATE TABLE comments (id INTEGER PRIMARY KEY, post_id INTEGER NOT NULL REFERENCES posts (id), user_id INTEGER NOT NULL REFERENCES users (id))`,
+		`INSERT INTO users (id) VALUES (1), (2)`,
+		`INSERT INTO posts (id, user_id) VALUES (1, 1), (2, 1), (3, 2)`,
+		`INSERT INTO comments (id, post_id, user_id) VALUES (1, 1, 2), (2, 2, 2), (3, 3, 1), (4, 3, 2)`,
+	} {
+		if _, err := db.ExecContext(ctx, stmt); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	type step struct {
+		Table string
+		Rows  int64
+	}
+	summarize := func(steps []CascadeStep) []step {
+		s := make([]step, len(steps))
+		for i, st := range steps {
+			s[i] = step{Table: st.Table, Rows: st.Rows}
+		}
+		return s
+	}
+
+	want := []step{
+		{Table: "comments", Rows: 2},
+		{Table: "posts", Rows: 2},
+		{Table: "comments", Rows: 1},
+		{Table: "users", Rows: 1},
+	}
+
+	planned, err := DeleteCascadeDryRun(ctx, db, cascadeUser{ID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := summarize(planned); !reflect.DeepEqual(got, want) {
+		t.Fatalf("dry run = %v, want %v", got, want)
+	}
+	if planned[0].ForeignKey == nil || planned[0].ForeignKey.Columns[0] != "post_id" {
+		t.Fatalf("first step follows %v, want comments.post_id", planned[0].ForeignKey)
+	}
+	if planned[3].ForeignKey != nil {
+		t.Fatalf("the row of the model has a foreign key %v", planned[3].ForeignKey)
+	}
+
+	var comments int
+	if err := sqlDB.QueryRowContext(ctx, `SELECT count(*) FROM comments`).Scan(&comments); err != nil {
+		t.Fatal(err)
+	}
+	if comments != 4 {
+		t.Fatalf("the dry run deleted comments, %d are left", comments)
+	}
+
+	deleted, err := DeleteCascade(ctx, db, cascadeUser{ID: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := summarize(deleted); !reflect.DeepEqual(got, want) {
+		t.Fatalf("deleted = %v, want %v", got, want)
+	}
+
+	var users, posts int
+	if err := sqlDB.QueryRowContext(ctx, `SELECT (SELECT count(*) FROM users), (SELECT count(*) FROM posts), (SELECT count(*) FROM comments)`).Scan(&users, &posts, &comments); err != nil {
+		t.Fatal(err)
+	}
+	if users != 1 || posts != 1 || comments != 1 {
+		t.Fatalf("left %d users, %d posts and %d comments, want 1 of each", users, posts, comments)
+	}
+}
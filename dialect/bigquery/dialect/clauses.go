@@ -0,0 +1,74 @@
+package dialect
+
+import (
+	"io"
+
+	"github.com/stephenafamo/bob"
+)
+
+// Qualify filters the rows on the results of window functions,
+// like HAVING does for aggregates
+type Qualify struct {
+	Conditions []any
+}
+
+func (q *Qualify) AppendQualify(e ...any) {
+	q.Conditions = append(q.Conditions, e...)
+}
+
+func (q Qualify) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	return bob.ExpressSlice(w, d, start, q.Conditions, "QUALIFY ", " AND ", "")
+}
+
+// Star is * or table.* with the EXCEPT and REPLACE modifiers,
+// to select all the columns but some, or some of them changed
+type Star struct {
+	Table    []string
+	Excepts  []string
+	Replaces []bob.Expression
+}
+
+// Except leaves the columns out
+func (s Star) Except(columns ...string) Star {
+	s.Excepts = append(s.Excepts[:len(s.Excepts):len(s.Excepts)], columns...)
+	return s
+}
+
+// Replace selects the expression instead of the value of the column
+func (s Star) Replace(e any, column string) Star {
+	replace := bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+		args, err := bob.Express(w, d, start, e)
+		if err != nil {
+			return nil, err
+		}
+
+		w.Write([]byte(" AS "))
+		d.WriteQuoted(w, column)
+		return args, nil
+	})
+
+	s.Replaces = append(s.Replaces[:len(s.Replaces):len(s.Replaces)], replace)
+	return s
+}
+
+func (s Star) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	for _, part := range s.Table {
+		d.WriteQuoted(w, part)
+		w.Write([]byte("."))
+	}
+	w.Write([]byte("*"))
+
+	for i, col := range s.Excepts {
+		if i == 0 {
+			w.Write([]byte(" EXCEPT ("))
+		} else {
+			w.Write([]byte(", "))
+		}
+		d.WriteQuoted(w, col)
+	}
+	if len(s.Excepts) > 0 {
+		w.Write([]byte(")"))
+	}
+
+	return bob.ExpressSlice(w, d, start, s.Replaces, " REPLACE (", ", ", ")")
+}
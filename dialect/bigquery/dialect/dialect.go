@@ -0,0 +1,47 @@
+package dialect
+
+import (
+	"io"
+	"strconv"
+
+	"github.com/stephenafamo/bob"
+)
+
+//nolint:gochecknoglobals
+var (
+	Dialect  dialect
+	atSign   = []byte("@")
+	atSignP  = []byte("@p")
+	backtick = []byte("`")
+)
+
+//nolint:gochecknoinits
+func init() {
+	bob.RegisterDialect("bigquery", Dialect)
+}
+
+type dialect struct{}
+
+// WriteArg writes a named @p1 placeholder, since the BigQuery client binds
+// parameters by name. See ParamName for the names of the arguments
+func (d dialect) WriteArg(w io.Writer, position int) {
+	w.Write(atSignP)
+	w.Write([]byte(strconv.Itoa(position)))
+}
+
+func (d dialect) WriteNamedArg(w io.Writer, name string) {
+	w.Write(atSign)
+	w.Write([]byte(name))
+}
+
+func (d dialect) WriteQuoted(w io.Writer, s string) {
+	w.Write(backtick)
+	w.Write([]byte(s))
+	w.Write(backtick)
+}
+
+// ParamName is the name of the parameter at the position, starting at 1,
+// as written by the dialect without the @
+func ParamName(position int) string {
+	return "p" + strconv.Itoa(position)
+}
@@ -0,0 +1,45 @@
+package bigquery
+
+import (
+	"database/sql"
+
+	"github.com/stephenafamo/bob/dialect/bigquery/dialect"
+)
+
+// Parameter is an argument of a query with the name of its placeholder
+type Parameter struct {
+	Name  string
+	Value any
+}
+
+// Parameters names the args of a built query the way their placeholders are written,
+// @p1, @p2 and so on, or the name of a [sql.NamedArg].
+// They map to the Name and Value of the query parameters of the BigQuery Go client:
+//
+//	query, args, err := bob.Build(q)
+//	bq := client.Query(query)
+//	for _, p := range bigquery.Parameters(args) {
+//		bq.Parameters = append(bq.Parameters, bqclient.QueryParameter{Name: p.Name, Value: p.Value})
+//	}
+//
+// A named arg that is used more than once is only returned once
+func Parameters(args []any) []Parameter {
+	params := make([]Parameter, 0, len(args))
+	named := make(map[string]bool)
+
+	for i, arg := range args {
+		n, ok := arg.(sql.NamedArg)
+		if !ok {
+			params = append(params, Parameter{Name: dialect.ParamName(i + 1), Value: arg})
+			continue
+		}
+
+		if named[n.Name] {
+			continue
+		}
+		named[n.Name] = true
+		params = append(params, Parameter{Name: n.Name, Value: n.Value})
+	}
+
+	return params
+}
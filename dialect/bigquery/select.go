@@ -0,0 +1,18 @@
+package bigquery
+
+import (
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/bigquery/dialect"
+)
+
+func Select(queryMods ...bob.Mod[*dialect.SelectQuery]) bob.BaseQuery[*dialect.SelectQuery] {
+	q := &dialect.SelectQuery{}
+	for _, mod := range queryMods {
+		mod.Apply(q)
+	}
+
+	return bob.BaseQuery[*dialect.SelectQuery]{
+		Expression: q,
+		Dialect:    dialect.Dialect,
+	}
+}
@@ -0,0 +1,103 @@
+package bigquery_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/bigquery"
+	"github.com/stephenafamo/bob/dialect/bigquery/sm"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+func TestSelect(t *testing.T) {
+	examples := testutils.Testcases{
+		"simple select": {
+			ExpectedSQL:  "SELECT id, name FROM `project`.`dataset`.`users` WHERE (`id` IN (@p1, @p2, @p3))",
+			ExpectedArgs: []any{100, 200, 300},
+			Query: bigquery.Select(
+				sm.Columns("id", "name"),
+				sm.From(bigquery.Quote("project", "dataset", "users")),
+				sm.Where(bigquery.Quote("id").In(bigquery.Arg(100, 200, 300))),
+			),
+		},
+		"star modifiers": {
+			ExpectedSQL:  "SELECT `u`.* EXCEPT (`password`, `salt`) REPLACE (LOWER(`email`) AS `email`, @p1 AS `plan`) FROM `users` AS `u`",
+			ExpectedArgs: []any{"free"},
+			Query: bigquery.Select(
+				sm.Columns(bigquery.Star("u").Except("password", "salt").
+					Replace(bigquery.Raw("LOWER(`email`)"), "email").
+					Replace(bigquery.Arg("free"), "plan")),
+				sm.From(bigquery.Quote("users")).As("u"),
+			),
+		},
+		"qualify": {
+			ExpectedSQL:  "SELECT * FROM `events` WHERE (`type` = @p1) QUALIFY ROW_NUMBER() OVER (PARTITION BY `user_id` ORDER BY `at` DESC) = 1 ORDER BY `at` DESC NULLS LAST LIMIT @p2",
+			ExpectedArgs: []any{"click", 10},
+			Query: bigquery.Select(
+				sm.From(bigquery.Quote("events")),
+				sm.Where(bigquery.Quote("type").EQ(bigquery.Arg("click"))),
+				sm.Qualify(bigquery.Raw("ROW_NUMBER() OVER (PARTITION BY `user_id` ORDER BY `at` DESC) = 1")),
+				sm.OrderBy(bigquery.Quote("at")).Desc().NullsLast(),
+				sm.Limit(bigquery.Arg(10)),
+			),
+		},
+		"array and struct literals": {
+			ExpectedSQL:  "SELECT [@p1, @p2] AS `ids`, [] AS `none`, STRUCT(@p3 AS `id`, `name`) AS `owner`, `tag` FROM `events` CROSS JOIN UNNEST(`events`.`tags`) AS `tag`",
+			ExpectedArgs: []any{1, 2, 3},
+			Query: bigquery.Select(
+				sm.Columns(
+					bigquery.As(bigquery.Array(bigquery.Arg(1), bigquery.Arg(2)), "ids"),
+					bigquery.As(bigquery.Array(), "none"),
+					bigquery.As(bigquery.Struct(bigquery.As(bigquery.Arg(3), "id"), bigquery.Quote("name")), "owner"),
+					bigquery.Quote("tag"),
+				),
+				sm.From(bigquery.Quote("events")),
+				sm.CrossJoin(bigquery.As(bigquery.Unnest(bigquery.Quote("events", "tags")), "tag")),
+			),
+		},
+		"with and union": {
+			ExpectedSQL:  "WITH recent AS (SELECT * FROM `events` WHERE (`at` > @p1)) SELECT `id` FROM `recent` UNION DISTINCT (SELECT `id` FROM `archive`)",
+			ExpectedArgs: []any{"2024-01-01"},
+			Query: bigquery.Select(
+				sm.With("recent").As(bigquery.Select(
+					sm.From(bigquery.Quote("events")),
+					sm.Where(bigquery.Quote("at").GT(bigquery.Arg("2024-01-01"))),
+				)),
+				sm.Columns(bigquery.Quote("id")),
+				sm.From(bigquery.Quote("recent")),
+				sm.Union(bigquery.Select(
+					sm.Columns(bigquery.Quote("id")),
+					sm.From(bigquery.Quote("archive")),
+				)),
+			),
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+}
+
+func TestParameters(t *testing.T) {
+	query, args, err := bob.Build(bigquery.Select(
+		sm.From(bigquery.Quote("events")),
+		sm.Where(bigquery.Quote("at").GT(bigquery.Named("since", "2024-01-01"))),
+		sm.Where(bigquery.Quote("type").EQ(bigquery.Arg("click"))),
+		sm.Where(bigquery.Quote("seen").LT(bigquery.Named("since", "2024-01-01"))),
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wantSQL := "SELECT * FROM `events` WHERE (`at` > @since) AND (`type` = @p2) AND (`seen` < @since)"
+	if diff, err := testutils.QueryDiff(wantSQL, query, nil); err != nil || diff != "" {
+		t.Fatalf("diff: %s %v", diff, err)
+	}
+
+	want := []bigquery.Parameter{
+		{Name: "since", Value: "2024-01-01"},
+		{Name: "p2", Value: "click"},
+	}
+	if got := bigquery.Parameters(args); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,140 @@
+package sm
+
+import (
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/clause"
+	"github.com/stephenafamo/bob/dialect/bigquery/dialect"
+	"github.com/stephenafamo/bob/mods"
+)
+
+func With(name string, columns ...string) dialect.CTEChain[*dialect.SelectQuery] {
+	return dialect.With[*dialect.SelectQuery](name, columns...)
+}
+
+func Recursive(r bool) bob.Mod[*dialect.SelectQuery] {
+	return mods.Recursive[*dialect.SelectQuery](r)
+}
+
+func Distinct() bob.Mod[*dialect.SelectQuery] {
+	return mods.QueryModFunc[*dialect.SelectQuery](func(q *dialect.SelectQuery) {
+		q.Distinct = true
+	})
+}
+
+func Columns(clauses ...any) bob.Mod[*dialect.SelectQuery] {
+	return mods.Select[*dialect.SelectQuery](clauses)
+}
+
+func From(table any) dialect.FromChain[*dialect.SelectQuery] {
+	return dialect.From[*dialect.SelectQuery](table)
+}
+
+// Join is a join of the given type, such as "LEFT OUTER JOIN"
+func Join(typ string, e any) dialect.JoinChain[*dialect.SelectQuery] {
+	return dialect.Join[*dialect.SelectQuery](typ, e)
+}
+
+func InnerJoin(e any) dialect.JoinChain[*dialect.SelectQuery] {
+	return dialect.InnerJoin[*dialect.SelectQuery](e)
+}
+
+func LeftJoin(e any) dialect.JoinChain[*dialect.SelectQuery] {
+	return dialect.LeftJoin[*dialect.SelectQuery](e)
+}
+
+func RightJoin(e any) dialect.JoinChain[*dialect.SelectQuery] {
+	return dialect.RightJoin[*dialect.SelectQuery](e)
+}
+
+func FullJoin(e any) dialect.JoinChain[*dialect.SelectQuery] {
+	return dialect.FullJoin[*dialect.SelectQuery](e)
+}
+
+// CrossJoin joins every row of the table, which is also how an array
+// of the row is unfolded
+//
+//	SQL: SELECT `id`, `tag` FROM `events` CROSS JOIN UNNEST(`events`.`tags`) AS `tag`
+//	Go: bigquery.Select(sm.Columns(bigquery.Quote("id"), bigquery.Quote("tag")), sm.From(bigquery.Quote("events")), sm.CrossJoin(bigquery.As(bigquery.Unnest(bigquery.Quote("events", "tags")), "tag")))
+func CrossJoin(e any) bob.Mod[*dialect.SelectQuery] {
+	return dialect.CrossJoin[*dialect.SelectQuery](e)
+}
+
+func Where(e bob.Expression) mods.Where[*dialect.SelectQuery] {
+	return mods.Where[*dialect.SelectQuery]{E: e}
+}
+
+func GroupBy(e any) bob.Mod[*dialect.SelectQuery] {
+	return mods.GroupBy[*dialect.SelectQuery]{
+		E: e,
+	}
+}
+
+func Having(e any) bob.Mod[*dialect.SelectQuery] {
+	return mods.Having[*dialect.SelectQuery]{e}
+}
+
+// Qualify filters the rows on the results of window functions
+//
+//	SQL: SELECT * FROM `events` QUALIFY ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY at DESC) = 1
+//	Go: bigquery.Select(sm.From(bigquery.Quote("events")), sm.Qualify(bigquery.Raw("ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY at DESC) = 1")))
+func Qualify(e any) bob.Mod[*dialect.SelectQuery] {
+	return mods.QueryModFunc[*dialect.SelectQuery](func(q *dialect.SelectQuery) {
+		q.AppendQualify(e)
+	})
+}
+
+func OrderBy(e any) dialect.OrderBy[*dialect.SelectQuery] {
+	return dialect.OrderBy[*dialect.SelectQuery](func() clause.OrderDef {
+		return clause.OrderDef{
+			Expression: e,
+		}
+	})
+}
+
+func Limit(count any) bob.Mod[*dialect.SelectQuery] {
+	return mods.Limit[*dialect.SelectQuery]{
+		Count: count,
+	}
+}
+
+func Offset(count any) bob.Mod[*dialect.SelectQuery] {
+	return mods.Offset[*dialect.SelectQuery]{
+		Count: count,
+	}
+}
+
+// Union is UNION DISTINCT, because BigQuery requires
+// ALL or DISTINCT after the set operators
+func Union(q bob.Query) bob.Mod[*dialect.SelectQuery] {
+	return mods.Combine[*dialect.SelectQuery]{
+		Strategy: clause.Union + " DISTINCT",
+		Query:    q,
+		All:      false,
+	}
+}
+
+func UnionAll(q bob.Query) bob.Mod[*dialect.SelectQuery] {
+	return mods.Combine[*dialect.SelectQuery]{
+		Strategy: clause.Union,
+		Query:    q,
+		All:      true,
+	}
+}
+
+// Intersect is INTERSECT DISTINCT, see [Union]
+func Intersect(q bob.Query) bob.Mod[*dialect.SelectQuery] {
+	return mods.Combine[*dialect.SelectQuery]{
+		Strategy: clause.Intersect + " DISTINCT",
+		Query:    q,
+		All:      false,
+	}
+}
+
+// Except is EXCEPT DISTINCT, see [Union]
+func Except(q bob.Query) bob.Mod[*dialect.SelectQuery] {
+	return mods.Combine[*dialect.SelectQuery]{
+		Strategy: clause.Except + " DISTINCT",
+		Query:    q,
+		All:      false,
+	}
+}
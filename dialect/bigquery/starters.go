@@ -0,0 +1,143 @@
+package bigquery
+
+import (
+	"database/sql"
+	"io"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/bigquery/dialect"
+	"github.com/stephenafamo/bob/expr"
+)
+
+type Expression = dialect.Expression
+
+//nolint:gochecknoglobals
+var bmod = expr.Builder[Expression, Expression]{}
+
+// S creates a string literal
+// SQL: 'a string'
+// Go: bigquery.S("a string")
+func S(s string) Expression {
+	return bmod.S(s)
+}
+
+// SQL: NOT true
+// Go: bigquery.Not("true")
+func Not(exp bob.Expression) Expression {
+	return bmod.Not(exp)
+}
+
+// SQL: a OR b OR c
+// Go: bigquery.Or("a", "b", "c")
+func Or(args ...bob.Expression) Expression {
+	return bmod.Or(args...)
+}
+
+// SQL: a AND b AND c
+// Go: bigquery.And("a", "b", "c")
+func And(args ...bob.Expression) Expression {
+	return bmod.And(args...)
+}
+
+// SQL: a || b || c
+// Go: bigquery.Concat("a", "b", "c")
+func Concat(args ...bob.Expression) Expression {
+	return expr.X[Expression, Expression](expr.Join{Exprs: args, Sep: " || "})
+}
+
+// SQL: @p1, @p2, @p3
+// Go: bigquery.Args("a", "b", "c")
+func Arg(args ...any) Expression {
+	return bmod.Arg(args...)
+}
+
+// SQL: (@p1, @p2, @p3)
+// Go: bigquery.ArgGroup("a", "b", "c")
+func ArgGroup(args ...any) Expression {
+	return bmod.ArgGroup(args...)
+}
+
+// Named is an argument with a named placeholder, which can be used more than once
+//
+//	SQL: @since
+//	Go: bigquery.Named("since", t)
+func Named(name string, value any) Expression {
+	arg := sql.Named(name, value)
+	return Expression{}.New(bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+		return bob.Express(w, d, start, arg)
+	}))
+}
+
+// SQL: @p1, @p2, @p3
+// Go: bigquery.Placeholder(3)
+func Placeholder(n uint) Expression {
+	return bmod.Placeholder(n)
+}
+
+// SQL: (a, b)
+// Go: bigquery.Group("a", "b")
+func Group(exps ...bob.Expression) Expression {
+	return bmod.Group(exps...)
+}
+
+// SQL: `table`.`column`
+// Go: bigquery.Quote("table", "column")
+func Quote(ss ...string) Expression {
+	return bmod.Quote(ss...)
+}
+
+// SQL: where a = @p1
+// Go: bigquery.Raw("where a = ?", "something")
+func Raw(query string, args ...any) Expression {
+	return bmod.Raw(query, args...)
+}
+
+// SQL: a as `alias`
+// Go: bigquery.As("a", "alias")
+func As(e Expression, alias string) bob.Expression {
+	return expr.OP("AS", e, expr.Quote(alias))
+}
+
+// Star is * with the columns of every table, or of the table if given.
+// Use Except and Replace on it for the star modifiers
+//
+//	SQL: `users`.* EXCEPT (`password`) REPLACE (LOWER(email) AS `email`)
+//	Go: bigquery.Star("users").Except("password").Replace("LOWER(email)", "email")
+func Star(table ...string) dialect.Star {
+	return dialect.Star{Table: table}
+}
+
+// Array is an array literal
+//
+//	SQL: [1, 2, 3]
+//	Go: bigquery.Array("1", "2", "3")
+func Array(elems ...bob.Expression) Expression {
+	return wrapped("[", elems, "]")
+}
+
+// Struct is a struct literal. Name the fields with As
+//
+//	SQL: STRUCT(@p1 AS `id`, `name`)
+//	Go: bigquery.Struct(bigquery.As(bigquery.Arg(1), "id"), bigquery.Quote("name"))
+func Struct(fields ...bob.Expression) Expression {
+	return wrapped("STRUCT(", fields, ")")
+}
+
+// Unnest turns an array into a table, with a row for each element
+//
+//	SQL: UNNEST(`tags`)
+//	Go: bigquery.Unnest(bigquery.Quote("tags"))
+func Unnest(array bob.Expression) Expression {
+	return wrapped("UNNEST(", []bob.Expression{array}, ")")
+}
+
+// wrapped writes the expressions separated by commas between the prefix and suffix,
+// which are written even if there are no expressions
+func wrapped(prefix string, exprs []bob.Expression, suffix string) Expression {
+	return Expression{}.New(bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+		w.Write([]byte(prefix))
+		args, err := bob.ExpressSlice(w, d, start, exprs, "", ", ", "")
+		w.Write([]byte(suffix))
+		return args, err
+	}))
+}
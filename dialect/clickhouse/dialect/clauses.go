@@ -0,0 +1,162 @@
+package dialect
+
+import (
+	"io"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/clause"
+)
+
+// FromClause is the FROM clause of ClickHouse, where the table can be followed
+// by FINAL, SAMPLE and ARRAY JOIN before the joins
+type FromClause struct {
+	clause.From
+	// Merge the rows of a ReplacingMergeTree or similar table before they are read
+	Final      bool
+	Sample     *Sample
+	ArrayJoins []ArrayJoin
+}
+
+func (f *FromClause) SetFinal(final bool) {
+	f.Final = final
+}
+
+func (f *FromClause) SetSample(s Sample) {
+	f.Sample = &s
+}
+
+func (f *FromClause) AppendArrayJoin(a ArrayJoin) {
+	f.ArrayJoins = append(f.ArrayJoins, a)
+}
+
+func (f FromClause) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	if f.Table == nil {
+		return nil, nil
+	}
+
+	args, err := bob.Express(w, d, start, f.Table)
+	if err != nil {
+		return nil, err
+	}
+
+	if f.Alias != "" {
+		w.Write([]byte(" AS "))
+		d.WriteQuoted(w, f.Alias)
+	}
+
+	if f.Final {
+		w.Write([]byte(" FINAL"))
+	}
+
+	sampleArgs, err := bob.ExpressIf(w, d, start+len(args), f.Sample, f.Sample != nil, " ", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, sampleArgs...)
+
+	arrayArgs, err := bob.ExpressSlice(w, d, start+len(args), f.ArrayJoins, "\n", "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, arrayArgs...)
+
+	joinArgs, err := bob.ExpressSlice(w, d, start+len(args), f.Joins, "\n", "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, joinArgs...)
+
+	return args, nil
+}
+
+// Sample reads a part of the rows of a table with a sampling key,
+// as a ratio such as 0.1 or a number of rows
+type Sample struct {
+	Size any
+	// Skip a part of the sample, as a ratio
+	Offset any
+}
+
+func (s Sample) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	args, err := bob.ExpressIf(w, d, start, s.Size, true, "SAMPLE ", "")
+	if err != nil {
+		return nil, err
+	}
+
+	offsetArgs, err := bob.ExpressIf(w, d, start+len(args), s.Offset, s.Offset != nil, " OFFSET ", "")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(args, offsetArgs...), nil
+}
+
+// ArrayJoin unfolds the arrays into a row for each of their elements.
+// LEFT ARRAY JOIN keeps the rows with empty arrays
+type ArrayJoin struct {
+	Left   bool
+	Arrays []any
+}
+
+func (a ArrayJoin) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	if a.Left {
+		w.Write([]byte("LEFT "))
+	}
+
+	return bob.ExpressSlice(w, d, start, a.Arrays, "ARRAY JOIN ", ", ", "")
+}
+
+// Prewhere filters the rows before the columns that are not in the
+// condition are read, which is cheaper than WHERE for selective conditions
+type Prewhere struct {
+	Conditions []any
+}
+
+func (p *Prewhere) AppendPrewhere(e ...any) {
+	p.Conditions = append(p.Conditions, e...)
+}
+
+func (p Prewhere) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	return bob.ExpressSlice(w, d, start, p.Conditions, "PREWHERE ", " AND ", "")
+}
+
+// LimitBy keeps the first Count rows, after Offset rows, for each
+// distinct value of the columns
+type LimitBy struct {
+	Count   any
+	Offset  any
+	Columns []any
+}
+
+func (l LimitBy) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	w.Write([]byte("LIMIT "))
+
+	args, err := bob.ExpressIf(w, d, start, l.Offset, l.Offset != nil, "", ", ")
+	if err != nil {
+		return nil, err
+	}
+
+	countArgs, err := bob.Express(w, d, start+len(args), l.Count)
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, countArgs...)
+
+	colArgs, err := bob.ExpressSlice(w, d, start+len(args), l.Columns, " BY ", ", ", "")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(args, colArgs...), nil
+}
+
+// Setting is a setting of the SETTINGS clause, which applies to one query
+type Setting struct {
+	Name  string
+	Value any
+}
+
+func (s Setting) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	w.Write([]byte(s.Name))
+	return bob.ExpressIf(w, d, start, s.Value, true, " = ", "")
+}
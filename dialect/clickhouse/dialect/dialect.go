@@ -0,0 +1,32 @@
+package dialect
+
+import (
+	"io"
+
+	"github.com/stephenafamo/bob"
+)
+
+//nolint:gochecknoglobals
+var (
+	Dialect      dialect
+	questionMark = []byte("?")
+	backtick     = []byte("`")
+)
+
+//nolint:gochecknoinits
+func init() {
+	bob.RegisterDialect("clickhouse", Dialect)
+}
+
+type dialect struct{}
+
+// WriteArg writes a positional ? placeholder, which clickhouse-go binds on the client
+func (d dialect) WriteArg(w io.Writer, position int) {
+	w.Write(questionMark)
+}
+
+func (d dialect) WriteQuoted(w io.Writer, s string) {
+	w.Write(backtick)
+	w.Write([]byte(s))
+	w.Write(backtick)
+}
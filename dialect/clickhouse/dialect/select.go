@@ -0,0 +1,152 @@
+package dialect
+
+import (
+	"io"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/clause"
+)
+
+// Trying to represent the select query structure as documented in
+// https://clickhouse.com/docs/en/sql-reference/statements/select
+type SelectQuery struct {
+	clause.With
+	Distinct bool
+	clause.SelectList
+	FromClause
+	Prewhere
+	clause.Where
+	clause.GroupBy
+	// Add a row with the totals of all the rows to the groups
+	WithTotals bool
+	clause.Having
+	clause.OrderBy
+	LimitBy *LimitBy
+	clause.Limit
+	clause.Offset
+	Settings []Setting
+	clause.Combine
+	// The format of the result, such as JSONEachRow.
+	// Most drivers set the format themselves, so it is only for the HTTP interface
+	Format string
+	bob.Load[*SelectQuery]
+}
+
+func (s *SelectQuery) SetLimitBy(l LimitBy) {
+	s.LimitBy = &l
+}
+
+func (s *SelectQuery) AppendSetting(setting Setting) {
+	s.Settings = append(s.Settings, setting)
+}
+
+func (s SelectQuery) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	var args []any
+
+	withArgs, err := bob.ExpressIf(w, d, start+len(args), s.With,
+		len(s.With.CTEs) > 0, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, withArgs...)
+
+	w.Write([]byte("SELECT "))
+
+	if s.Distinct {
+		w.Write([]byte("DISTINCT "))
+	}
+
+	selArgs, err := bob.ExpressIf(w, d, start+len(args), s.SelectList, true, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, selArgs...)
+
+	fromArgs, err := bob.ExpressIf(w, d, start+len(args), s.FromClause,
+		s.FromClause.Table != nil, "\nFROM ", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, fromArgs...)
+
+	prewhereArgs, err := bob.ExpressIf(w, d, start+len(args), s.Prewhere,
+		len(s.Prewhere.Conditions) > 0, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, prewhereArgs...)
+
+	whereArgs, err := bob.ExpressIf(w, d, start+len(args), s.Where,
+		len(s.Where.Conditions) > 0, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, whereArgs...)
+
+	groupByArgs, err := bob.ExpressIf(w, d, start+len(args), s.GroupBy,
+		len(s.GroupBy.Groups) > 0, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, groupByArgs...)
+
+	if s.WithTotals {
+		w.Write([]byte(" WITH TOTALS"))
+	}
+
+	havingArgs, err := bob.ExpressIf(w, d, start+len(args), s.Having,
+		len(s.Having.Conditions) > 0, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, havingArgs...)
+
+	orderArgs, err := bob.ExpressIf(w, d, start+len(args), s.OrderBy,
+		len(s.OrderBy.Expressions) > 0, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, orderArgs...)
+
+	limitByArgs, err := bob.ExpressIf(w, d, start+len(args), s.LimitBy,
+		s.LimitBy != nil, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, limitByArgs...)
+
+	limitArgs, err := bob.ExpressIf(w, d, start+len(args), s.Limit,
+		s.Limit.Count != nil, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, limitArgs...)
+
+	offsetArgs, err := bob.ExpressIf(w, d, start+len(args), s.Offset,
+		s.Offset.Count != nil, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, offsetArgs...)
+
+	settingArgs, err := bob.ExpressSlice(w, d, start+len(args), s.Settings, "\nSETTINGS ", ", ", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, settingArgs...)
+
+	combineArgs, err := bob.ExpressIf(w, d, start+len(args), s.Combine,
+		s.Combine.Query != nil, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, combineArgs...)
+
+	if s.Format != "" {
+		w.Write([]byte("\nFORMAT "))
+		w.Write([]byte(s.Format))
+	}
+
+	w.Write([]byte("\n"))
+	return args, nil
+}
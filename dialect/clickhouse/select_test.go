@@ -0,0 +1,95 @@
+package clickhouse_test
+
+import (
+	"testing"
+
+	"github.com/stephenafamo/bob/dialect/clickhouse"
+	"github.com/stephenafamo/bob/dialect/clickhouse/sm"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+func TestSelect(t *testing.T) {
+	examples := testutils.Testcases{
+		"simple select": {
+			ExpectedSQL:  "SELECT id, name FROM users WHERE (`id` IN (?, ?, ?))",
+			ExpectedArgs: []any{100, 200, 300},
+			Query: clickhouse.Select(
+				sm.Columns("id", "name"),
+				sm.From("users"),
+				sm.Where(clickhouse.Quote("id").In(clickhouse.Arg(100, 200, 300))),
+			),
+		},
+		"final and sample": {
+			ExpectedSQL: "SELECT count() FROM `hits` AS `h` FINAL SAMPLE 1/10 OFFSET 1/2",
+			Query: clickhouse.Select(
+				sm.Columns("count()"),
+				sm.From(clickhouse.Quote("hits")).As("h").Final(),
+				sm.Sample("1/10").Offset("1/2"),
+			),
+		},
+		"array join before joins": {
+			ExpectedSQL: "SELECT `e`.`id`, `tag`, `u`.`name` FROM `events` AS `e` ARRAY JOIN `tags` AS `tag` LEFT ARRAY JOIN `labels` INNER JOIN `users` AS `u` ON (`u`.`id` = `e`.`user_id`) LEFT ANY JOIN `teams` USING(`team_id`)",
+			Query: clickhouse.Select(
+				sm.Columns(clickhouse.Quote("e", "id"), clickhouse.Quote("tag"), clickhouse.Quote("u", "name")),
+				sm.From(clickhouse.Quote("events")).As("e"),
+				sm.ArrayJoin(clickhouse.As(clickhouse.Quote("tags"), "tag")),
+				sm.LeftArrayJoin(clickhouse.Quote("labels")),
+				sm.InnerJoin(clickhouse.Quote("users")).As("u").OnEQ(clickhouse.Quote("u", "id"), clickhouse.Quote("e", "user_id")),
+				sm.Join("LEFT ANY JOIN", clickhouse.Quote("teams")).Using("team_id"),
+			),
+		},
+		"prewhere and where": {
+			ExpectedSQL:  "SELECT * FROM `events` PREWHERE (`type` = ?) WHERE (`duration` > ?)",
+			ExpectedArgs: []any{"click", 100},
+			Query: clickhouse.Select(
+				sm.From(clickhouse.Quote("events")),
+				sm.Prewhere(clickhouse.Quote("type").EQ(clickhouse.Arg("click"))),
+				sm.Where(clickhouse.Quote("duration").GT(clickhouse.Arg(100))),
+			),
+		},
+		"group with totals": {
+			ExpectedSQL: "SELECT `country`, count() FROM `visits` GROUP BY `country` WITH ROLLUP WITH TOTALS HAVING count() > 10 ORDER BY `country` ASC NULLS LAST",
+			Query: clickhouse.Select(
+				sm.Columns(clickhouse.Quote("country"), "count()"),
+				sm.From(clickhouse.Quote("visits")),
+				sm.GroupBy(clickhouse.Quote("country")),
+				sm.WithRollup(),
+				sm.WithTotals(),
+				sm.Having(clickhouse.Raw("count() > 10")),
+				sm.OrderBy(clickhouse.Quote("country")).Asc().NullsLast(),
+			),
+		},
+		"limit by, limit and settings": {
+			ExpectedSQL:  "SELECT * FROM `events` ORDER BY `at` DESC LIMIT 1, 5 BY `user_id` LIMIT ? OFFSET ? SETTINGS max_threads = 8, use_query_cache = 1 FORMAT JSONEachRow",
+			ExpectedArgs: []any{100, 20},
+			Query: clickhouse.Select(
+				sm.From(clickhouse.Quote("events")),
+				sm.OrderBy(clickhouse.Quote("at")).Desc(),
+				sm.LimitBy(5, clickhouse.Quote("user_id")).Offset(1),
+				sm.Limit(clickhouse.Arg(100)),
+				sm.Offset(clickhouse.Arg(20)),
+				sm.Settings("max_threads", 8),
+				sm.Settings("use_query_cache", 1),
+				sm.Format("JSONEachRow"),
+			),
+		},
+		"with and union": {
+			ExpectedSQL:  "WITH recent AS (SELECT * FROM `events` WHERE (`at` > ?)) SELECT `id` FROM `recent` UNION DISTINCT (SELECT `id` FROM `archive`)",
+			ExpectedArgs: []any{"2024-01-01"},
+			Query: clickhouse.Select(
+				sm.With("recent").As(clickhouse.Select(
+					sm.From(clickhouse.Quote("events")),
+					sm.Where(clickhouse.Quote("at").GT(clickhouse.Arg("2024-01-01"))),
+				)),
+				sm.Columns(clickhouse.Quote("id")),
+				sm.From(clickhouse.Quote("recent")),
+				sm.Union(clickhouse.Select(
+					sm.Columns(clickhouse.Quote("id")),
+					sm.From(clickhouse.Quote("archive")),
+				)),
+			),
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+}
@@ -0,0 +1,206 @@
+package sm
+
+import (
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/clause"
+	"github.com/stephenafamo/bob/dialect/clickhouse/dialect"
+	"github.com/stephenafamo/bob/mods"
+)
+
+func With(name string, columns ...string) dialect.CTEChain[*dialect.SelectQuery] {
+	return dialect.With[*dialect.SelectQuery](name, columns...)
+}
+
+func Distinct() bob.Mod[*dialect.SelectQuery] {
+	return mods.QueryModFunc[*dialect.SelectQuery](func(q *dialect.SelectQuery) {
+		q.Distinct = true
+	})
+}
+
+func Columns(clauses ...any) bob.Mod[*dialect.SelectQuery] {
+	return mods.Select[*dialect.SelectQuery](clauses)
+}
+
+// From sets the table, which can be followed by FINAL
+//
+//	SQL: SELECT * FROM `events` AS `e` FINAL
+//	Go: clickhouse.Select(sm.From(clickhouse.Quote("events")).As("e").Final())
+func From(table any) dialect.FromChain[*dialect.SelectQuery] {
+	return dialect.From[*dialect.SelectQuery](table)
+}
+
+// Sample reads a part of the rows, as a ratio such as 0.1 or a number of rows.
+// The table must have a sampling key
+//
+//	SQL: SELECT count() FROM `hits` SAMPLE 1/10 OFFSET 1/2
+//	Go: clickhouse.Select(sm.Columns("count()"), sm.From(clickhouse.Quote("hits")), sm.Sample("1/10").Offset("1/2"))
+func Sample(size any) dialect.SampleChain[*dialect.SelectQuery] {
+	return dialect.SampleChain[*dialect.SelectQuery](func() dialect.Sample {
+		return dialect.Sample{Size: size}
+	})
+}
+
+// ArrayJoin unfolds the arrays into a row for each of their elements,
+// leaving out the rows with empty arrays
+func ArrayJoin(arrays ...any) bob.Mod[*dialect.SelectQuery] {
+	return mods.QueryModFunc[*dialect.SelectQuery](func(q *dialect.SelectQuery) {
+		q.AppendArrayJoin(dialect.ArrayJoin{Arrays: arrays})
+	})
+}
+
+// LeftArrayJoin is an [ArrayJoin] that keeps the rows with empty arrays,
+// with the default value of the type of the elements
+func LeftArrayJoin(arrays ...any) bob.Mod[*dialect.SelectQuery] {
+	return mods.QueryModFunc[*dialect.SelectQuery](func(q *dialect.SelectQuery) {
+		q.AppendArrayJoin(dialect.ArrayJoin{Left: true, Arrays: arrays})
+	})
+}
+
+// Join is a join of any type, such as "LEFT ANY JOIN", "ASOF JOIN" or "GLOBAL INNER JOIN"
+func Join(typ string, e any) dialect.JoinChain[*dialect.SelectQuery] {
+	return dialect.Join[*dialect.SelectQuery](typ, e)
+}
+
+func InnerJoin(e any) dialect.JoinChain[*dialect.SelectQuery] {
+	return dialect.InnerJoin[*dialect.SelectQuery](e)
+}
+
+func LeftJoin(e any) dialect.JoinChain[*dialect.SelectQuery] {
+	return dialect.LeftJoin[*dialect.SelectQuery](e)
+}
+
+func RightJoin(e any) dialect.JoinChain[*dialect.SelectQuery] {
+	return dialect.RightJoin[*dialect.SelectQuery](e)
+}
+
+func FullJoin(e any) dialect.JoinChain[*dialect.SelectQuery] {
+	return dialect.FullJoin[*dialect.SelectQuery](e)
+}
+
+func CrossJoin(e any) bob.Mod[*dialect.SelectQuery] {
+	return dialect.CrossJoin[*dialect.SelectQuery](e)
+}
+
+// Prewhere filters the rows before the other columns are read.
+// ClickHouse moves conditions from WHERE to PREWHERE by itself,
+// this forces it for conditions it does not pick
+func Prewhere(e bob.Expression) bob.Mod[*dialect.SelectQuery] {
+	return mods.QueryModFunc[*dialect.SelectQuery](func(q *dialect.SelectQuery) {
+		q.AppendPrewhere(e)
+	})
+}
+
+func Where(e bob.Expression) mods.Where[*dialect.SelectQuery] {
+	return mods.Where[*dialect.SelectQuery]{E: e}
+}
+
+func GroupBy(e any) bob.Mod[*dialect.SelectQuery] {
+	return mods.GroupBy[*dialect.SelectQuery]{
+		E: e,
+	}
+}
+
+func WithRollup() bob.Mod[*dialect.SelectQuery] {
+	return mods.QueryModFunc[*dialect.SelectQuery](func(q *dialect.SelectQuery) {
+		q.SetGroupWith("ROLLUP")
+	})
+}
+
+func WithCube() bob.Mod[*dialect.SelectQuery] {
+	return mods.QueryModFunc[*dialect.SelectQuery](func(q *dialect.SelectQuery) {
+		q.SetGroupWith("CUBE")
+	})
+}
+
+// WithTotals adds a row with the aggregates of all the rows to the groups
+func WithTotals() bob.Mod[*dialect.SelectQuery] {
+	return mods.QueryModFunc[*dialect.SelectQuery](func(q *dialect.SelectQuery) {
+		q.WithTotals = true
+	})
+}
+
+func Having(e any) bob.Mod[*dialect.SelectQuery] {
+	return mods.Having[*dialect.SelectQuery]{e}
+}
+
+func OrderBy(e any) dialect.OrderBy[*dialect.SelectQuery] {
+	return dialect.OrderBy[*dialect.SelectQuery](func() clause.OrderDef {
+		return clause.OrderDef{
+			Expression: e,
+		}
+	})
+}
+
+// LimitBy keeps the first rows for each distinct value of the columns
+//
+//	SQL: SELECT * FROM `events` ORDER BY `at` DESC LIMIT 1, 5 BY `user_id`
+//	Go: clickhouse.Select(sm.From(clickhouse.Quote("events")), sm.OrderBy(clickhouse.Quote("at")).Desc(), sm.LimitBy(5, clickhouse.Quote("user_id")).Offset(1))
+func LimitBy(count any, columns ...any) dialect.LimitByChain[*dialect.SelectQuery] {
+	return dialect.LimitByChain[*dialect.SelectQuery](func() dialect.LimitBy {
+		return dialect.LimitBy{Count: count, Columns: columns}
+	})
+}
+
+func Limit(count any) bob.Mod[*dialect.SelectQuery] {
+	return mods.Limit[*dialect.SelectQuery]{
+		Count: count,
+	}
+}
+
+func Offset(count any) bob.Mod[*dialect.SelectQuery] {
+	return mods.Offset[*dialect.SelectQuery]{
+		Count: count,
+	}
+}
+
+// Settings changes a setting for the query
+//
+//	SQL: SELECT * FROM `events` SETTINGS max_threads = 8
+//	Go: clickhouse.Select(sm.From(clickhouse.Quote("events")), sm.Settings("max_threads", 8))
+func Settings(name string, value any) bob.Mod[*dialect.SelectQuery] {
+	return mods.QueryModFunc[*dialect.SelectQuery](func(q *dialect.SelectQuery) {
+		q.AppendSetting(dialect.Setting{Name: name, Value: value})
+	})
+}
+
+// Format sets the format of the result, such as JSONEachRow, for the HTTP interface.
+// The native drivers choose the format themselves
+func Format(format string) bob.Mod[*dialect.SelectQuery] {
+	return mods.QueryModFunc[*dialect.SelectQuery](func(q *dialect.SelectQuery) {
+		q.Format = format
+	})
+}
+
+// Union is UNION DISTINCT, because a plain UNION is an error
+// unless the union_default_mode setting is set
+func Union(q bob.Query) bob.Mod[*dialect.SelectQuery] {
+	return mods.Combine[*dialect.SelectQuery]{
+		Strategy: clause.Union + " DISTINCT",
+		Query:    q,
+		All:      false,
+	}
+}
+
+func UnionAll(q bob.Query) bob.Mod[*dialect.SelectQuery] {
+	return mods.Combine[*dialect.SelectQuery]{
+		Strategy: clause.Union,
+		Query:    q,
+		All:      true,
+	}
+}
+
+func Intersect(q bob.Query) bob.Mod[*dialect.SelectQuery] {
+	return mods.Combine[*dialect.SelectQuery]{
+		Strategy: clause.Intersect,
+		Query:    q,
+		All:      false,
+	}
+}
+
+func Except(q bob.Query) bob.Mod[*dialect.SelectQuery] {
+	return mods.Combine[*dialect.SelectQuery]{
+		Strategy: clause.Except,
+		Query:    q,
+		All:      false,
+	}
+}
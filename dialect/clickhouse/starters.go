@@ -0,0 +1,85 @@
+package clickhouse
+
+import (
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/clickhouse/dialect"
+	"github.com/stephenafamo/bob/expr"
+)
+
+type Expression = dialect.Expression
+
+//nolint:gochecknoglobals
+var bmod = expr.Builder[Expression, Expression]{}
+
+// S creates a string literal
+// SQL: 'a string'
+// Go: clickhouse.S("a string")
+func S(s string) Expression {
+	return bmod.S(s)
+}
+
+// SQL: NOT true
+// Go: clickhouse.Not("true")
+func Not(exp bob.Expression) Expression {
+	return bmod.Not(exp)
+}
+
+// SQL: a OR b OR c
+// Go: clickhouse.Or("a", "b", "c")
+func Or(args ...bob.Expression) Expression {
+	return bmod.Or(args...)
+}
+
+// SQL: a AND b AND c
+// Go: clickhouse.And("a", "b", "c")
+func And(args ...bob.Expression) Expression {
+	return bmod.And(args...)
+}
+
+// SQL: a || b || c
+// Go: clickhouse.Concat("a", "b", "c")
+func Concat(args ...bob.Expression) Expression {
+	return expr.X[Expression, Expression](expr.Join{Exprs: args, Sep: " || "})
+}
+
+// SQL: ?, ?, ?
+// Go: clickhouse.Args("a", "b", "c")
+func Arg(args ...any) Expression {
+	return bmod.Arg(args...)
+}
+
+// SQL: (?, ?, ?)
+// Go: clickhouse.ArgGroup("a", "b", "c")
+func ArgGroup(args ...any) Expression {
+	return bmod.ArgGroup(args...)
+}
+
+// SQL: ?, ?, ?
+// Go: clickhouse.Placeholder(3)
+func Placeholder(n uint) Expression {
+	return bmod.Placeholder(n)
+}
+
+// SQL: (a, b)
+// Go: clickhouse.Group("a", "b")
+func Group(exps ...bob.Expression) Expression {
+	return bmod.Group(exps...)
+}
+
+// SQL: `table`.`column`
+// Go: clickhouse.Quote("table", "column")
+func Quote(ss ...string) Expression {
+	return bmod.Quote(ss...)
+}
+
+// SQL: where a = ?
+// Go: clickhouse.Raw("where a = ?", "something")
+func Raw(query string, args ...any) Expression {
+	return bmod.Raw(query, args...)
+}
+
+// SQL: a as `alias`
+// Go: clickhouse.As("a", "alias")
+func As(e Expression, alias string) bob.Expression {
+	return expr.OP("AS", e, expr.Quote(alias))
+}
@@ -0,0 +1,17 @@
+package mssql
+
+import (
+	"github.com/stephenafamo/bob"
+)
+
+// SetApplicationName sets application_name in the session context, which is read
+// with SESSION_CONTEXT(N'application_name'). It stays set on the connection after the transaction.
+// Use it with [bob.AttributedDB].
+// SQL Server can only change the program_name when connecting, to name all the
+// connections of a pool set the app name of the DSN, e.g. app name=billing
+//
+//	SQL: EXEC sp_set_session_context @key = N'application_name', @value = @p1
+//	Go: mssql.SetApplicationName("billing")
+func SetApplicationName(name string) bob.Query {
+	return RawQuery("EXEC sp_set_session_context @key = N'application_name', @value = ?", name)
+}
@@ -0,0 +1,39 @@
+package mssql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stephenafamo/bob/dialect/mssql"
+	"github.com/stephenafamo/bob/dialect/mssql/sm"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+func TestAsOf(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	examples := testutils.Testcases{
+		"from": {
+			Query: mssql.Select(
+				sm.Columns("id", "name"),
+				sm.From("users").AsOf(at).As("u"),
+				sm.Where(mssql.Quote("id").EQ(mssql.Arg(1))),
+			),
+			ExpectedSQL:  "SELECT id, name FROM users FOR SYSTEM_TIME AS OF @p1 AS [u] WHERE ([id] = @p2)",
+			ExpectedArgs: []any{at, 1},
+		},
+		"mod and join": {
+			Query: mssql.Select(
+				sm.Columns("*"),
+				sm.From("users"),
+				sm.AsOf(at),
+				sm.InnerJoin("orders").AsOf(at).On(mssql.Quote("orders", "user_id").EQ(mssql.Quote("users", "id"))),
+			),
+			ExpectedSQL: "SELECT * FROM users FOR SYSTEM_TIME AS OF @p1 " +
+				"INNER JOIN orders FOR SYSTEM_TIME AS OF @p2 ON ([orders].[user_id] = [users].[id])",
+			ExpectedArgs: []any{at, at},
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+}
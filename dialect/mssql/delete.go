@@ -0,0 +1,18 @@
+package mssql
+
+import (
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/mssql/dialect"
+)
+
+func Delete(queryMods ...bob.Mod[*dialect.DeleteQuery]) bob.BaseQuery[*dialect.DeleteQuery] {
+	q := &dialect.DeleteQuery{}
+	for _, mod := range queryMods {
+		mod.Apply(q)
+	}
+
+	return bob.BaseQuery[*dialect.DeleteQuery]{
+		Expression: q,
+		Dialect:    dialect.Dialect,
+	}
+}
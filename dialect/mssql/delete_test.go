@@ -0,0 +1,37 @@
+package mssql_test
+
+import (
+	"testing"
+
+	"github.com/stephenafamo/bob/dialect/mssql"
+	"github.com/stephenafamo/bob/dialect/mssql/dm"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+func TestDelete(t *testing.T) {
+	examples := testutils.Testcases{
+		"simple": {
+			Query: mssql.Delete(
+				dm.From("films"),
+				dm.OutputDeleted("id"),
+				dm.Where(mssql.Quote("kind").EQ(mssql.Arg("Drama"))),
+			),
+			ExpectedSQL:  `DELETE FROM films OUTPUT [DELETED].[id] WHERE ([kind] = @p1)`,
+			ExpectedArgs: []any{"Drama"},
+		},
+		"top and join": {
+			Query: mssql.Delete(
+				dm.Top(1000),
+				dm.From(mssql.Quote("f")),
+				dm.Using("films").As("f"),
+				dm.InnerJoin("bans").As("b").OnEQ(mssql.Quote("b", "film_id"), mssql.Quote("f", "id")),
+				dm.AllRows(),
+			),
+			ExpectedSQL: `DELETE TOP (1000) FROM [f]
+				FROM films AS [f]
+				INNER JOIN bans AS [b] ON ([b].[film_id] = [f].[id])`,
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+}
@@ -0,0 +1,48 @@
+package dialect
+
+import (
+	"io"
+
+	"github.com/stephenafamo/bob"
+)
+
+// Top is the TOP clause of T-SQL, which limits the rows that are
+// selected, inserted, updated or deleted
+type Top struct {
+	Count any
+	// The count is a percentage of the rows
+	Percent bool
+	// Also return the rows that tie with the last row in the ORDER BY. Only in SELECT
+	WithTies bool
+}
+
+func (t Top) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	args, err := bob.ExpressIf(w, d, start, t.Count, true, "TOP (", ")")
+	if err != nil {
+		return nil, err
+	}
+
+	if t.Percent {
+		w.Write([]byte(" PERCENT"))
+	}
+
+	if t.WithTies {
+		w.Write([]byte(" WITH TIES"))
+	}
+
+	return args, nil
+}
+
+// Output is the OUTPUT clause of T-SQL, which returns values of the affected rows
+// from the INSERTED and DELETED tables, like RETURNING in the other dialects
+type Output struct {
+	Expressions []any
+}
+
+func (o *Output) AppendOutput(vals ...any) {
+	o.Expressions = append(o.Expressions, vals...)
+}
+
+func (o Output) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	return bob.ExpressSlice(w, d, start, o.Expressions, "OUTPUT ", ", ", "")
+}
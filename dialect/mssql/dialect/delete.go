@@ -0,0 +1,76 @@
+package dialect
+
+import (
+	"io"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/clause"
+)
+
+// Trying to represent the delete query structure as documented in
+// https://learn.microsoft.com/en-us/sql/t-sql/statements/delete-transact-sql
+type DeleteQuery struct {
+	clause.With
+	Top *Top
+	clause.Table
+	Output
+	// The second FROM, to join other tables
+	clause.From
+	clause.Where
+}
+
+func (d *DeleteQuery) SetTop(top Top) {
+	d.Top = &top
+}
+
+func (d DeleteQuery) WriteSQL(w io.Writer, dl bob.Dialect, start int) ([]any, error) {
+	if err := d.Where.CheckAllRows(); err != nil {
+		return nil, err
+	}
+
+	var args []any
+
+	withArgs, err := bob.ExpressIf(w, dl, start+len(args), d.With,
+		len(d.With.CTEs) > 0, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, withArgs...)
+
+	w.Write([]byte("DELETE"))
+
+	topArgs, err := bob.ExpressIf(w, dl, start+len(args), d.Top, d.Top != nil, " ", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, topArgs...)
+
+	tableArgs, err := bob.ExpressIf(w, dl, start+len(args), d.Table, true, " FROM ", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, tableArgs...)
+
+	outputArgs, err := bob.ExpressIf(w, dl, start+len(args), d.Output,
+		len(d.Output.Expressions) > 0, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, outputArgs...)
+
+	fromArgs, err := bob.ExpressIf(w, dl, start+len(args), d.From,
+		d.From.Table != nil, "\nFROM ", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, fromArgs...)
+
+	whereArgs, err := bob.ExpressIf(w, dl, start+len(args), d.Where,
+		len(d.Where.Conditions) > 0, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, whereArgs...)
+
+	return args, nil
+}
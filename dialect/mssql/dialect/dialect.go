@@ -0,0 +1,67 @@
+package dialect
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/stephenafamo/bob"
+)
+
+//nolint:gochecknoglobals
+var (
+	Dialect             dialect
+	atSign              = []byte("@")
+	atSignP             = []byte("@p")
+	openSquareBrackets  = []byte("[")
+	closeSquareBrackets = []byte("]")
+)
+
+//nolint:gochecknoinits
+func init() {
+	bob.RegisterDialect(bob.ServerMSSQL, Dialect)
+}
+
+type dialect struct{}
+
+func (d dialect) WriteArg(w io.Writer, position int) {
+	w.Write(atSignP)
+	w.Write([]byte(strconv.Itoa(position)))
+}
+
+func (d dialect) WriteNamedArg(w io.Writer, name string) {
+	w.Write(atSign)
+	w.Write([]byte(name))
+}
+
+func (d dialect) WriteQuoted(w io.Writer, s string) {
+	w.Write(openSquareBrackets)
+	w.Write([]byte(s))
+	w.Write(closeSquareBrackets)
+}
+
+// MaxArgs is the largest number of args in a query in SQL Server
+func (d dialect) MaxArgs() int {
+	return 2100
+}
+
+// WriteInSlice binds the slice as a JSON array that is read with OPENJSON,
+// which needs SQL Server 2016 or later. Unlike a table-valued parameter,
+// it needs no table type on the server
+func (d dialect) WriteInSlice(w io.Writer, start int, slice any, not bool) ([]any, error) {
+	values, err := json.Marshal(slice)
+	if err != nil {
+		return nil, fmt.Errorf("in slice: %w", err)
+	}
+
+	if not {
+		w.Write([]byte(" NOT IN (SELECT value FROM OPENJSON("))
+	} else {
+		w.Write([]byte(" IN (SELECT value FROM OPENJSON("))
+	}
+	d.WriteArg(w, start)
+	w.Write([]byte("))"))
+
+	return []any{string(values)}, nil
+}
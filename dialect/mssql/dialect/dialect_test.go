@@ -0,0 +1,23 @@
+package dialect
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWriteInSlice(t *testing.T) {
+	w := &strings.Builder{}
+	args, err := Dialect.WriteInSlice(w, 3, []int64{1, 2}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := " NOT IN (SELECT value FROM OPENJSON(@p3))"; w.String() != want {
+		t.Fatalf("got %q, want %q", w.String(), want)
+	}
+	if diff := cmp.Diff([]any{"[1,2]"}, args); diff != "" {
+		t.Fatal(diff)
+	}
+}
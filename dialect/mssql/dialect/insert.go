@@ -0,0 +1,63 @@
+package dialect
+
+import (
+	"io"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/clause"
+)
+
+// Trying to represent the insert query structure as documented in
+// https://learn.microsoft.com/en-us/sql/t-sql/statements/insert-transact-sql
+type InsertQuery struct {
+	clause.With
+	Top *Top
+	clause.Table
+	Output
+	clause.Values
+}
+
+func (i *InsertQuery) SetTop(top Top) {
+	i.Top = &top
+}
+
+func (i InsertQuery) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	var args []any
+
+	withArgs, err := bob.ExpressIf(w, d, start+len(args), i.With,
+		len(i.With.CTEs) > 0, "", "\n")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, withArgs...)
+
+	w.Write([]byte("INSERT"))
+
+	topArgs, err := bob.ExpressIf(w, d, start+len(args), i.Top, i.Top != nil, " ", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, topArgs...)
+
+	tableArgs, err := bob.ExpressIf(w, d, start+len(args), i.Table, true, " INTO ", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, tableArgs...)
+
+	outputArgs, err := bob.ExpressIf(w, d, start+len(args), i.Output,
+		len(i.Output.Expressions) > 0, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, outputArgs...)
+
+	valArgs, err := bob.ExpressIf(w, d, start+len(args), i.Values, true, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, valArgs...)
+
+	w.Write([]byte("\n"))
+	return args, nil
+}
@@ -0,0 +1,158 @@
+package dialect
+
+import (
+	"errors"
+	"io"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/clause"
+)
+
+// ErrNoMergeSource is returned when a MERGE query has neither rows nor a query
+// to merge from
+var ErrNoMergeSource = errors.New("merge query has no source rows or query")
+
+const (
+	// TargetAlias is the alias given to the target table of a MERGE query
+	TargetAlias = "target"
+	// SourceAlias is the alias given to the source rows of a MERGE query
+	SourceAlias = "source"
+)
+
+// Trying to represent the merge query structure as documented in
+// https://learn.microsoft.com/en-us/sql/t-sql/statements/merge-transact-sql
+//
+// MSSQL has no INSERT ... ON CONFLICT, so upserts are written as
+//
+//	MERGE INTO table AS [target]
+//	USING (VALUES ...) AS [source] (columns)
+//	ON [target].[key] = [source].[key]
+//	WHEN MATCHED THEN UPDATE SET ...
+//	WHEN NOT MATCHED THEN INSERT ... VALUES ...
+//	OUTPUT ...;
+type MergeQuery struct {
+	// The target table
+	Table any
+	// Use WITH (HOLDLOCK) on the target to avoid race conditions between
+	// concurrent upserts
+	HoldLock bool
+
+	// The columns of the source rows
+	Columns []string
+	// The source rows or query
+	clause.Values
+
+	// The columns used to match the source with the target
+	On []string
+	// The columns updated when a match is found.
+	// If empty, matched rows are left untouched
+	Update []string
+
+	// Expressions in the OUTPUT clause, e.g. INSERTED.[id]
+	Output []any
+}
+
+func (m *MergeQuery) AppendOutput(vals ...any) {
+	m.Output = append(m.Output, vals...)
+}
+
+func (m MergeQuery) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	if m.Values.Query == nil && len(m.Values.Vals) == 0 {
+		return nil, ErrNoMergeSource
+	}
+
+	var args []any
+
+	tableArgs, err := bob.ExpressIf(w, d, start+len(args), m.Table, true, "MERGE INTO ", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, tableArgs...)
+
+	if m.HoldLock {
+		w.Write([]byte(" WITH (HOLDLOCK)"))
+	}
+
+	w.Write([]byte(" AS "))
+	d.WriteQuoted(w, TargetAlias)
+
+	valArgs, err := bob.ExpressIf(w, d, start+len(args), m.Values, true, "\nUSING (", ")")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, valArgs...)
+
+	w.Write([]byte(" AS "))
+	d.WriteQuoted(w, SourceAlias)
+	if len(m.Columns) > 0 {
+		w.Write([]byte(" ("))
+		writeColumns(w, d, "", m.Columns)
+		w.Write([]byte(")"))
+	}
+
+	if len(m.On) > 0 {
+		w.Write([]byte("\nON "))
+		for i, col := range m.On {
+			if i > 0 {
+				w.Write([]byte(" AND "))
+			}
+			writeAssignment(w, d, col)
+		}
+	}
+
+	if len(m.Update) > 0 {
+		w.Write([]byte("\nWHEN MATCHED THEN UPDATE SET "))
+		for i, col := range m.Update {
+			if i > 0 {
+				w.Write([]byte(", "))
+			}
+			writeAssignment(w, d, col)
+		}
+	}
+
+	w.Write([]byte("\nWHEN NOT MATCHED THEN INSERT"))
+	if len(m.Columns) > 0 {
+		w.Write([]byte(" ("))
+		writeColumns(w, d, "", m.Columns)
+		w.Write([]byte(")"))
+		w.Write([]byte(" VALUES ("))
+		writeColumns(w, d, SourceAlias, m.Columns)
+		w.Write([]byte(")"))
+	} else {
+		w.Write([]byte(" DEFAULT VALUES"))
+	}
+
+	outputArgs, err := bob.ExpressSlice(w, d, start+len(args), m.Output, "\nOUTPUT ", ", ", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, outputArgs...)
+
+	// MERGE statements must be terminated by a semicolon
+	w.Write([]byte(";\n"))
+	return args, nil
+}
+
+// writes [target].[col] = [source].[col]
+func writeAssignment(w io.Writer, d bob.Dialect, col string) {
+	d.WriteQuoted(w, TargetAlias)
+	w.Write([]byte("."))
+	d.WriteQuoted(w, col)
+	w.Write([]byte(" = "))
+	d.WriteQuoted(w, SourceAlias)
+	w.Write([]byte("."))
+	d.WriteQuoted(w, col)
+}
+
+func writeColumns(w io.Writer, d bob.Dialect, prefix string, cols []string) {
+	for i, col := range cols {
+		if i > 0 {
+			w.Write([]byte(", "))
+		}
+		if prefix != "" {
+			d.WriteQuoted(w, prefix)
+			w.Write([]byte("."))
+		}
+		d.WriteQuoted(w, col)
+	}
+}
@@ -0,0 +1,217 @@
+package dialect
+
+import (
+	"time"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/clause"
+	"github.com/stephenafamo/bob/expr"
+	"github.com/stephenafamo/bob/mods"
+)
+
+func With[Q interface{ AppendWith(clause.CTE) }](name string, columns ...string) CTEChain[Q] {
+	return CTEChain[Q](func() clause.CTE {
+		return clause.CTE{
+			Name:    name,
+			Columns: columns,
+		}
+	})
+}
+
+type CTEChain[Q interface{ AppendWith(clause.CTE) }] func() clause.CTE
+
+func (c CTEChain[Q]) Apply(q Q) {
+	q.AppendWith(c())
+}
+
+func (c CTEChain[Q]) As(q bob.Query) CTEChain[Q] {
+	cte := c()
+	cte.Query = q
+	return CTEChain[Q](func() clause.CTE {
+		return cte
+	})
+}
+
+// TopChain is a TOP clause that is being built
+type TopChain[Q interface{ SetTop(Top) }] func() Top
+
+func (t TopChain[Q]) Apply(q Q) {
+	q.SetTop(t())
+}
+
+// Percent makes the count a percentage of the rows
+func (t TopChain[Q]) Percent() TopChain[Q] {
+	top := t()
+	top.Percent = true
+
+	return TopChain[Q](func() Top {
+		return top
+	})
+}
+
+// WithTies also returns the rows that tie with the last row in the ORDER BY.
+// It is only allowed in SELECT queries
+func (t TopChain[Q]) WithTies() TopChain[Q] {
+	top := t()
+	top.WithTies = true
+
+	return TopChain[Q](func() Top {
+		return top
+	})
+}
+
+type fromable interface {
+	SetTable(any)
+	SetTableAlias(alias string, columns ...string)
+	SetSystemTime(any)
+}
+
+func From[Q fromable](table any) FromChain[Q] {
+	return FromChain[Q](func() clause.From {
+		return clause.From{
+			Table: table,
+		}
+	})
+}
+
+type FromChain[Q fromable] func() clause.From
+
+func (f FromChain[Q]) Apply(q Q) {
+	from := f()
+
+	q.SetTable(from.Table)
+	if from.Alias != "" {
+		q.SetTableAlias(from.Alias, from.Columns...)
+	}
+	if from.SystemTime != nil {
+		q.SetSystemTime(from.SystemTime)
+	}
+}
+
+func (f FromChain[Q]) As(alias string, columns ...string) FromChain[Q] {
+	fr := f()
+	fr.Alias = alias
+	fr.Columns = columns
+
+	return FromChain[Q](func() clause.From {
+		return fr
+	})
+}
+
+// AsOf reads the temporal table as it was at the given time
+//
+//	SQL: SELECT * FROM users FOR SYSTEM_TIME AS OF @p1
+//	Go: mssql.Select(sm.From("users").AsOf(t))
+func (f FromChain[Q]) AsOf(t time.Time) FromChain[Q] {
+	fr := f()
+	fr.SystemTime = expr.Arg(t)
+
+	return FromChain[Q](func() clause.From {
+		return fr
+	})
+}
+
+type JoinChain[Q interface{ AppendJoin(clause.Join) }] func() clause.Join
+
+func (j JoinChain[Q]) Apply(q Q) {
+	q.AppendJoin(j())
+}
+
+func (j JoinChain[Q]) As(alias string) JoinChain[Q] {
+	jo := j()
+	jo.To.Alias = alias
+
+	return JoinChain[Q](func() clause.Join {
+		return jo
+	})
+}
+
+// AsOf reads the joined temporal table as it was at the given time, see [FromChain.AsOf]
+func (j JoinChain[Q]) AsOf(t time.Time) JoinChain[Q] {
+	jo := j()
+	jo.To.SystemTime = expr.Arg(t)
+
+	return JoinChain[Q](func() clause.Join {
+		return jo
+	})
+}
+
+func (j JoinChain[Q]) On(on ...bob.Expression) bob.Mod[Q] {
+	jo := j()
+	jo.On = append(jo.On, on...)
+
+	return mods.Join[Q](jo)
+}
+
+func (j JoinChain[Q]) OnEQ(a, b bob.Expression) bob.Mod[Q] {
+	jo := j()
+	jo.On = append(jo.On, expr.X[Expression, Expression](a).EQ(b))
+
+	return mods.Join[Q](jo)
+}
+
+type Joinable interface{ AppendJoin(clause.Join) }
+
+func Join[Q Joinable](typ string, e any) JoinChain[Q] {
+	return JoinChain[Q](func() clause.Join {
+		return clause.Join{
+			Type: typ,
+			To:   clause.From{Table: e},
+		}
+	})
+}
+
+func InnerJoin[Q Joinable](e any) JoinChain[Q] {
+	return Join[Q](clause.InnerJoin, e)
+}
+
+func LeftJoin[Q Joinable](e any) JoinChain[Q] {
+	return Join[Q](clause.LeftJoin, e)
+}
+
+func RightJoin[Q Joinable](e any) JoinChain[Q] {
+	return Join[Q](clause.RightJoin, e)
+}
+
+func FullJoin[Q Joinable](e any) JoinChain[Q] {
+	return Join[Q](clause.FullJoin, e)
+}
+
+func CrossJoin[Q Joinable](e any) bob.Mod[Q] {
+	return Join[Q](clause.CrossJoin, e)
+}
+
+// CrossApply joins a table-valued function or a subquery that
+// can reference the columns of the tables before it, like a LATERAL join
+func CrossApply[Q Joinable](e any) JoinChain[Q] {
+	return Join[Q]("CROSS APPLY", e)
+}
+
+// OuterApply is a [CrossApply] that keeps the rows for which e returns no rows
+func OuterApply[Q Joinable](e any) JoinChain[Q] {
+	return Join[Q]("OUTER APPLY", e)
+}
+
+type OrderBy[Q interface{ AppendOrder(clause.OrderDef) }] func() clause.OrderDef
+
+func (s OrderBy[Q]) Apply(q Q) {
+	q.AppendOrder(s())
+}
+
+func (o OrderBy[Q]) Asc() OrderBy[Q] {
+	order := o()
+	order.Direction = "ASC"
+
+	return OrderBy[Q](func() clause.OrderDef {
+		return order
+	})
+}
+
+func (o OrderBy[Q]) Desc() OrderBy[Q] {
+	order := o()
+	order.Direction = "DESC"
+
+	return OrderBy[Q](func() clause.OrderDef {
+		return order
+	})
+}
@@ -0,0 +1,149 @@
+package dialect
+
+import (
+	"errors"
+	"io"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/clause"
+)
+
+// ErrTopWithOffset is returned when a SELECT query has both TOP and OFFSET or FETCH,
+// which SQL Server does not allow
+var ErrTopWithOffset = errors.New("select query cannot have both TOP and OFFSET or FETCH")
+
+// Trying to represent the select query structure as documented in
+// https://learn.microsoft.com/en-us/sql/t-sql/queries/select-transact-sql
+type SelectQuery struct {
+	clause.With
+	Distinct bool
+	Top      *Top
+	clause.SelectList
+	clause.From
+	clause.Where
+	clause.GroupBy
+	clause.Having
+	clause.Combine
+	clause.OrderBy
+	clause.Offset
+	// The number of rows in FETCH NEXT, which is written with OFFSET
+	Fetch any
+	bob.Load[*SelectQuery]
+}
+
+func (s *SelectQuery) SetFetch(fetch any) {
+	s.Fetch = fetch
+}
+
+// SetLimit sets the number of rows in FETCH NEXT, since SQL Server has no LIMIT
+func (s *SelectQuery) SetLimit(limit any) {
+	s.Fetch = limit
+}
+
+func (s *SelectQuery) SetTop(top Top) {
+	s.Top = &top
+}
+
+func (s SelectQuery) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	paged := s.Offset.Count != nil || s.Fetch != nil
+	if s.Top != nil && paged {
+		return nil, ErrTopWithOffset
+	}
+
+	var args []any
+
+	withArgs, err := bob.ExpressIf(w, d, start+len(args), s.With,
+		len(s.With.CTEs) > 0, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, withArgs...)
+
+	w.Write([]byte("SELECT "))
+
+	if s.Distinct {
+		w.Write([]byte("DISTINCT "))
+	}
+
+	topArgs, err := bob.ExpressIf(w, d, start+len(args), s.Top, s.Top != nil, "", " ")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, topArgs...)
+
+	selArgs, err := bob.ExpressIf(w, d, start+len(args), s.SelectList, true, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, selArgs...)
+
+	fromArgs, err := bob.ExpressIf(w, d, start+len(args), s.From,
+		s.From.Table != nil, "\nFROM ", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, fromArgs...)
+
+	whereArgs, err := bob.ExpressIf(w, d, start+len(args), s.Where,
+		len(s.Where.Conditions) > 0, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, whereArgs...)
+
+	groupByArgs, err := bob.ExpressIf(w, d, start+len(args), s.GroupBy,
+		len(s.GroupBy.Groups) > 0, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, groupByArgs...)
+
+	havingArgs, err := bob.ExpressIf(w, d, start+len(args), s.Having,
+		len(s.Having.Conditions) > 0, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, havingArgs...)
+
+	combineArgs, err := bob.ExpressIf(w, d, start+len(args), s.Combine,
+		s.Combine.Query != nil, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, combineArgs...)
+
+	orderArgs, err := bob.ExpressIf(w, d, start+len(args), s.OrderBy,
+		len(s.OrderBy.Expressions) > 0, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, orderArgs...)
+
+	if paged {
+		// OFFSET needs an ORDER BY, this one keeps the order of the rows
+		if len(s.OrderBy.Expressions) == 0 {
+			w.Write([]byte("\nORDER BY (SELECT NULL)"))
+		}
+
+		var offset any = 0
+		if s.Offset.Count != nil {
+			offset = s.Offset.Count
+		}
+
+		offsetArgs, err := bob.ExpressIf(w, d, start+len(args), offset, true, "\nOFFSET ", " ROWS")
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, offsetArgs...)
+
+		fetchArgs, err := bob.ExpressIf(w, d, start+len(args), s.Fetch,
+			s.Fetch != nil, "\nFETCH NEXT ", " ROWS ONLY")
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, fetchArgs...)
+	}
+
+	w.Write([]byte("\n"))
+	return args, nil
+}
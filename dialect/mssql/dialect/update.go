@@ -0,0 +1,82 @@
+package dialect
+
+import (
+	"io"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/clause"
+)
+
+// Trying to represent the update query structure as documented in
+// https://learn.microsoft.com/en-us/sql/t-sql/queries/update-transact-sql
+type UpdateQuery struct {
+	clause.With
+	Top *Top
+	clause.Table
+	clause.Set
+	Output
+	clause.From
+	clause.Where
+}
+
+func (u *UpdateQuery) SetTop(top Top) {
+	u.Top = &top
+}
+
+func (u UpdateQuery) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	if err := u.Where.CheckAllRows(); err != nil {
+		return nil, err
+	}
+
+	var args []any
+
+	withArgs, err := bob.ExpressIf(w, d, start+len(args), u.With,
+		len(u.With.CTEs) > 0, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, withArgs...)
+
+	w.Write([]byte("UPDATE"))
+
+	topArgs, err := bob.ExpressIf(w, d, start+len(args), u.Top, u.Top != nil, " ", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, topArgs...)
+
+	tableArgs, err := bob.ExpressIf(w, d, start+len(args), u.Table, true, " ", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, tableArgs...)
+
+	setArgs, err := bob.ExpressIf(w, d, start+len(args), u.Set, true, " SET\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, setArgs...)
+
+	outputArgs, err := bob.ExpressIf(w, d, start+len(args), u.Output,
+		len(u.Output.Expressions) > 0, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, outputArgs...)
+
+	fromArgs, err := bob.ExpressIf(w, d, start+len(args), u.From,
+		u.From.Table != nil, "\nFROM ", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, fromArgs...)
+
+	whereArgs, err := bob.ExpressIf(w, d, start+len(args), u.Where,
+		len(u.Where.Conditions) > 0, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, whereArgs...)
+
+	return args, nil
+}
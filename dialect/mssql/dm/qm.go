@@ -0,0 +1,93 @@
+package dm
+
+import (
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/clause"
+	"github.com/stephenafamo/bob/dialect/mssql/dialect"
+	"github.com/stephenafamo/bob/expr"
+	"github.com/stephenafamo/bob/mods"
+)
+
+func With(name string, columns ...string) dialect.CTEChain[*dialect.DeleteQuery] {
+	return dialect.With[*dialect.DeleteQuery](name, columns...)
+}
+
+func Recursive(r bool) bob.Mod[*dialect.DeleteQuery] {
+	return mods.Recursive[*dialect.DeleteQuery](r)
+}
+
+// Top limits the number of rows that are deleted. The rows are not picked in any order
+func Top(count any) dialect.TopChain[*dialect.DeleteQuery] {
+	return dialect.TopChain[*dialect.DeleteQuery](func() dialect.Top {
+		return dialect.Top{Count: count}
+	})
+}
+
+func From(name any) bob.Mod[*dialect.DeleteQuery] {
+	return mods.QueryModFunc[*dialect.DeleteQuery](func(d *dialect.DeleteQuery) {
+		d.Table = clause.Table{
+			Expression: name,
+		}
+	})
+}
+
+// Using adds the second FROM of a T-SQL DELETE, to delete
+// the rows that match rows of other tables
+//
+//	SQL: DELETE FROM [u] FROM [users] AS [u] INNER JOIN [bans] AS [b] ON ...
+//	Go: mssql.Delete(dm.From("[u]"), dm.Using("users").As("u"), dm.InnerJoin("bans").As("b").On(...))
+func Using(table any) dialect.FromChain[*dialect.DeleteQuery] {
+	return dialect.From[*dialect.DeleteQuery](table)
+}
+
+func InnerJoin(e any) dialect.JoinChain[*dialect.DeleteQuery] {
+	return dialect.InnerJoin[*dialect.DeleteQuery](e)
+}
+
+func LeftJoin(e any) dialect.JoinChain[*dialect.DeleteQuery] {
+	return dialect.LeftJoin[*dialect.DeleteQuery](e)
+}
+
+func RightJoin(e any) dialect.JoinChain[*dialect.DeleteQuery] {
+	return dialect.RightJoin[*dialect.DeleteQuery](e)
+}
+
+func FullJoin(e any) dialect.JoinChain[*dialect.DeleteQuery] {
+	return dialect.FullJoin[*dialect.DeleteQuery](e)
+}
+
+func CrossJoin(e any) bob.Mod[*dialect.DeleteQuery] {
+	return dialect.CrossJoin[*dialect.DeleteQuery](e)
+}
+
+func Where(e bob.Expression) mods.Where[*dialect.DeleteQuery] {
+	return mods.Where[*dialect.DeleteQuery]{E: e}
+}
+
+// AllRows allows the query to have no WHERE clause, which would otherwise
+// return [bob.ErrMissingWhere] to prevent changing every row by accident
+func AllRows() mods.AllRows[*dialect.DeleteQuery] {
+	return mods.AllRows[*dialect.DeleteQuery]{}
+}
+
+func Output(clauses ...any) bob.Mod[*dialect.DeleteQuery] {
+	return mods.QueryModFunc[*dialect.DeleteQuery](func(d *dialect.DeleteQuery) {
+		d.AppendOutput(clauses...)
+	})
+}
+
+// OutputDeleted adds DELETED.[column] to the OUTPUT clause for each column
+func OutputDeleted(columns ...string) bob.Mod[*dialect.DeleteQuery] {
+	exprs := make([]any, len(columns))
+	for i, col := range columns {
+		exprs[i] = expr.Quote("DELETED", col)
+	}
+
+	return Output(exprs...)
+}
+
+// OutputDeletedAll adds DELETED.* to the OUTPUT clause.
+// This is the MSSQL equivalent of RETURNING * in the other dialects
+func OutputDeletedAll() bob.Mod[*dialect.DeleteQuery] {
+	return Output(expr.Raw("DELETED.*"))
+}
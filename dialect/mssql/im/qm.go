@@ -0,0 +1,72 @@
+package im
+
+import (
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/clause"
+	"github.com/stephenafamo/bob/dialect/mssql/dialect"
+	"github.com/stephenafamo/bob/expr"
+	"github.com/stephenafamo/bob/mods"
+)
+
+func With(name string, columns ...string) dialect.CTEChain[*dialect.InsertQuery] {
+	return dialect.With[*dialect.InsertQuery](name, columns...)
+}
+
+func Recursive(r bool) bob.Mod[*dialect.InsertQuery] {
+	return mods.Recursive[*dialect.InsertQuery](r)
+}
+
+// Top limits the number of rows that are inserted from a query
+func Top(count any) dialect.TopChain[*dialect.InsertQuery] {
+	return dialect.TopChain[*dialect.InsertQuery](func() dialect.Top {
+		return dialect.Top{Count: count}
+	})
+}
+
+func Into(name any, columns ...string) bob.Mod[*dialect.InsertQuery] {
+	return mods.QueryModFunc[*dialect.InsertQuery](func(i *dialect.InsertQuery) {
+		i.Table = clause.Table{
+			Expression: name,
+			Columns:    columns,
+		}
+	})
+}
+
+func Values(clauses ...bob.Expression) bob.Mod[*dialect.InsertQuery] {
+	return mods.Values[*dialect.InsertQuery](clauses)
+}
+
+func Rows(rows ...[]bob.Expression) bob.Mod[*dialect.InsertQuery] {
+	return mods.Rows[*dialect.InsertQuery](rows)
+}
+
+// Insert from a query
+func Query(q bob.Query) bob.Mod[*dialect.InsertQuery] {
+	return mods.QueryModFunc[*dialect.InsertQuery](func(i *dialect.InsertQuery) {
+		i.Values.Query = q
+	})
+}
+
+func Output(clauses ...any) bob.Mod[*dialect.InsertQuery] {
+	return mods.QueryModFunc[*dialect.InsertQuery](func(i *dialect.InsertQuery) {
+		i.AppendOutput(clauses...)
+	})
+}
+
+// OutputInserted adds INSERTED.[column] to the OUTPUT clause for each column.
+// This is how IDENTITY and other generated values are retrieved for every
+// inserted row, since SCOPE_IDENTITY() only returns the last one
+func OutputInserted(columns ...string) bob.Mod[*dialect.InsertQuery] {
+	exprs := make([]any, len(columns))
+	for i, col := range columns {
+		exprs[i] = expr.Quote("INSERTED", col)
+	}
+
+	return Output(exprs...)
+}
+
+// OutputInsertedAll adds INSERTED.* to the OUTPUT clause.
+// This is the MSSQL equivalent of RETURNING * in the other dialects
+func OutputInsertedAll() bob.Mod[*dialect.InsertQuery] {
+	return Output(expr.Raw("INSERTED.*"))
+}
@@ -0,0 +1,47 @@
+package mssql_test
+
+import (
+	"testing"
+
+	"github.com/stephenafamo/bob/dialect/mssql"
+	"github.com/stephenafamo/bob/dialect/mssql/im"
+	"github.com/stephenafamo/bob/dialect/mssql/sm"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+func TestInsert(t *testing.T) {
+	examples := testutils.Testcases{
+		"with output": {
+			Query: mssql.Insert(
+				im.Into("films", "code", "title"),
+				im.OutputInserted("id"),
+				im.Values(mssql.Arg("UA502", "Bananas")),
+				im.Values(mssql.Arg("UA503", "Apples")),
+			),
+			ExpectedSQL:  "INSERT INTO films ([code], [title]) OUTPUT [INSERTED].[id] VALUES (@p1, @p2), (@p3, @p4)",
+			ExpectedArgs: []any{"UA502", "Bananas", "UA503", "Apples"},
+		},
+		"default values": {
+			Query: mssql.Insert(
+				im.Into("films"),
+				im.OutputInsertedAll(),
+			),
+			ExpectedSQL: "INSERT INTO films OUTPUT INSERTED.* DEFAULT VALUES",
+		},
+		"top from query": {
+			Query: mssql.Insert(
+				im.Top(100),
+				im.Into("archive", "id"),
+				im.Query(mssql.Select(
+					sm.Columns("id"),
+					sm.From("films"),
+					sm.Where(mssql.Quote("year").LT(mssql.Arg(1990))),
+				)),
+			),
+			ExpectedSQL:  "INSERT TOP (100) INTO archive ([id]) SELECT id FROM films WHERE ([year] < @p1)",
+			ExpectedArgs: []any{1990},
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+}
@@ -0,0 +1,18 @@
+package mssql
+
+import (
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/mssql/dialect"
+)
+
+func Merge(queryMods ...bob.Mod[*dialect.MergeQuery]) bob.BaseQuery[*dialect.MergeQuery] {
+	q := &dialect.MergeQuery{}
+	for _, mod := range queryMods {
+		mod.Apply(q)
+	}
+
+	return bob.BaseQuery[*dialect.MergeQuery]{
+		Expression: q,
+		Dialect:    dialect.Dialect,
+	}
+}
@@ -0,0 +1,47 @@
+package mssql_test
+
+import (
+	"testing"
+
+	"github.com/stephenafamo/bob/dialect/mssql"
+	"github.com/stephenafamo/bob/dialect/mssql/mm"
+	"github.com/stephenafamo/bob/expr"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+func TestMerge(t *testing.T) {
+	examples := testutils.Testcases{
+		"upsert": {
+			Query: mssql.Merge(
+				mm.Into("distributors", "did", "dname"),
+				mm.HoldLock(),
+				mm.Values(expr.Arg(8, "Anvil Distribution")),
+				mm.Values(expr.Arg(9, "Sentry Distribution")),
+				mm.On("did"),
+				mm.UpdateSet("dname"),
+				mm.OutputAction(),
+				mm.OutputInserted("did"),
+			),
+			ExpectedSQL: `MERGE INTO distributors WITH (HOLDLOCK) AS [target]
+				USING (VALUES (@p1, @p2), (@p3, @p4)) AS [source] ([did], [dname])
+				ON [target].[did] = [source].[did]
+				WHEN MATCHED THEN UPDATE SET [target].[dname] = [source].[dname]
+				WHEN NOT MATCHED THEN INSERT ([did], [dname]) VALUES ([source].[did], [source].[dname])
+				OUTPUT $action, [INSERTED].[did];`,
+			ExpectedArgs: []any{8, "Anvil Distribution", 9, "Sentry Distribution"},
+		},
+		"insert if missing": {
+			Query: mssql.Merge(
+				mm.Into("distributors", "did", "dname"),
+				mm.Query(mssql.RawQuery("SELECT did, dname FROM new_distributors")),
+				mm.On("did"),
+			),
+			ExpectedSQL: `MERGE INTO distributors AS [target]
+				USING (SELECT did, dname FROM new_distributors) AS [source] ([did], [dname])
+				ON [target].[did] = [source].[did]
+				WHEN NOT MATCHED THEN INSERT ([did], [dname]) VALUES ([source].[did], [source].[dname]);`,
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+}
@@ -0,0 +1,84 @@
+package mm
+
+import (
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/mssql/dialect"
+	"github.com/stephenafamo/bob/expr"
+	"github.com/stephenafamo/bob/mods"
+)
+
+// Into sets the target table and the columns of the source rows
+func Into(name any, columns ...string) bob.Mod[*dialect.MergeQuery] {
+	return mods.QueryModFunc[*dialect.MergeQuery](func(m *dialect.MergeQuery) {
+		m.Table = name
+		m.Columns = columns
+	})
+}
+
+// HoldLock adds WITH (HOLDLOCK) to the target table
+// which is needed to make concurrent upserts safe
+func HoldLock() bob.Mod[*dialect.MergeQuery] {
+	return mods.QueryModFunc[*dialect.MergeQuery](func(m *dialect.MergeQuery) {
+		m.HoldLock = true
+	})
+}
+
+func Values(clauses ...bob.Expression) bob.Mod[*dialect.MergeQuery] {
+	return mods.Values[*dialect.MergeQuery](clauses)
+}
+
+func Rows(rows ...[]bob.Expression) bob.Mod[*dialect.MergeQuery] {
+	return mods.Rows[*dialect.MergeQuery](rows)
+}
+
+// Merge from a query
+func Query(q bob.Query) bob.Mod[*dialect.MergeQuery] {
+	return mods.QueryModFunc[*dialect.MergeQuery](func(m *dialect.MergeQuery) {
+		m.Values.Query = q
+	})
+}
+
+// On sets the columns used to match the source rows with the target rows
+func On(columns ...string) bob.Mod[*dialect.MergeQuery] {
+	return mods.QueryModFunc[*dialect.MergeQuery](func(m *dialect.MergeQuery) {
+		m.On = append(m.On, columns...)
+	})
+}
+
+// UpdateSet sets the columns that are updated from the source
+// when a matching target row is found
+func UpdateSet(columns ...string) bob.Mod[*dialect.MergeQuery] {
+	return mods.QueryModFunc[*dialect.MergeQuery](func(m *dialect.MergeQuery) {
+		m.Update = append(m.Update, columns...)
+	})
+}
+
+func Output(clauses ...any) bob.Mod[*dialect.MergeQuery] {
+	return mods.QueryModFunc[*dialect.MergeQuery](func(m *dialect.MergeQuery) {
+		m.AppendOutput(clauses...)
+	})
+}
+
+// OutputInserted adds INSERTED.[column] to the OUTPUT clause for each column.
+// This is how IDENTITY and other generated values are retrieved for every
+// affected row, since SCOPE_IDENTITY() only returns the last one
+func OutputInserted(columns ...string) bob.Mod[*dialect.MergeQuery] {
+	exprs := make([]any, len(columns))
+	for i, col := range columns {
+		exprs[i] = expr.Quote("INSERTED", col)
+	}
+
+	return Output(exprs...)
+}
+
+// OutputAction adds $action to the OUTPUT clause, which is either
+// 'INSERT' or 'UPDATE' for each affected row
+func OutputAction() bob.Mod[*dialect.MergeQuery] {
+	return Output(expr.Raw("$action"))
+}
+
+// OutputInsertedAll adds INSERTED.* to the OUTPUT clause.
+// This is the MSSQL equivalent of RETURNING * in the other dialects
+func OutputInsertedAll() bob.Mod[*dialect.MergeQuery] {
+	return Output(expr.Raw("INSERTED.*"))
+}
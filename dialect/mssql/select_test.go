@@ -0,0 +1,91 @@
+package mssql_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/mssql"
+	"github.com/stephenafamo/bob/dialect/mssql/dialect"
+	"github.com/stephenafamo/bob/dialect/mssql/sm"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+func TestSelect(t *testing.T) {
+	examples := testutils.Testcases{
+		"simple select": {
+			ExpectedSQL:  "SELECT id, name FROM users WHERE ([id] IN (@p1, @p2, @p3))",
+			ExpectedArgs: []any{100, 200, 300},
+			Query: mssql.Select(
+				sm.Columns("id", "name"),
+				sm.From("users"),
+				sm.Where(mssql.Quote("id").In(mssql.Arg(100, 200, 300))),
+			),
+		},
+		"top with ties": {
+			ExpectedSQL: "SELECT TOP (10) PERCENT WITH TIES * FROM scores ORDER BY [score] DESC",
+			Query: mssql.Select(
+				sm.Top(10).Percent().WithTies(),
+				sm.From("scores"),
+				sm.OrderBy(mssql.Quote("score")).Desc(),
+			),
+		},
+		"distinct top with arg": {
+			ExpectedSQL:  "SELECT DISTINCT TOP (@p1) [name] FROM users",
+			ExpectedArgs: []any{5},
+			Query: mssql.Select(
+				sm.Distinct(),
+				sm.Top(mssql.Arg(5)),
+				sm.Columns(mssql.Quote("name")),
+				sm.From("users"),
+			),
+		},
+		"offset fetch": {
+			ExpectedSQL:  "SELECT * FROM users ORDER BY [id] OFFSET @p1 ROWS FETCH NEXT @p2 ROWS ONLY",
+			ExpectedArgs: []any{20, 10},
+			Query: mssql.Select(
+				sm.From("users"),
+				sm.OrderBy(mssql.Quote("id")),
+				sm.Offset(mssql.Arg(20)),
+				sm.Fetch(mssql.Arg(10)),
+			),
+		},
+		"fetch without order": {
+			ExpectedSQL: "SELECT * FROM users ORDER BY (SELECT NULL) OFFSET 0 ROWS FETCH NEXT 10 ROWS ONLY",
+			Query: mssql.Select(
+				sm.From("users"),
+				sm.Fetch(10),
+			),
+		},
+		"join and cross apply": {
+			ExpectedSQL: `SELECT [u].[name], [o].[total]
+				FROM users AS [u]
+				INNER JOIN teams AS [t] ON ([t].[id] = [u].[team_id])
+				CROSS APPLY (SELECT TOP (1) total FROM orders WHERE user_id = [u].[id]) AS [o]`,
+			Query: mssql.Select(
+				sm.Columns(mssql.Quote("u", "name"), mssql.Quote("o", "total")),
+				sm.From("users").As("u"),
+				sm.InnerJoin("teams").As("t").OnEQ(mssql.Quote("t", "id"), mssql.Quote("u", "team_id")),
+				sm.CrossApply(mssql.Select(
+					sm.Top(1),
+					sm.Columns("total"),
+					sm.From("orders"),
+					sm.Where(mssql.Raw("user_id = [u].[id]")),
+				)).As("o"),
+			),
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+}
+
+func TestSelectTopWithOffset(t *testing.T) {
+	_, _, err := bob.Build(mssql.Select(
+		sm.Top(10),
+		sm.From("users"),
+		sm.Offset(10),
+	))
+	if !errors.Is(err, dialect.ErrTopWithOffset) {
+		t.Fatalf("got %v, want ErrTopWithOffset", err)
+	}
+}
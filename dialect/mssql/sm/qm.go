@@ -0,0 +1,156 @@
+package sm
+
+import (
+	"time"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/clause"
+	"github.com/stephenafamo/bob/dialect/mssql/dialect"
+	"github.com/stephenafamo/bob/expr"
+	"github.com/stephenafamo/bob/mods"
+)
+
+func With(name string, columns ...string) dialect.CTEChain[*dialect.SelectQuery] {
+	return dialect.With[*dialect.SelectQuery](name, columns...)
+}
+
+func Recursive(r bool) bob.Mod[*dialect.SelectQuery] {
+	return mods.Recursive[*dialect.SelectQuery](r)
+}
+
+func Distinct() bob.Mod[*dialect.SelectQuery] {
+	return mods.QueryModFunc[*dialect.SelectQuery](func(q *dialect.SelectQuery) {
+		q.Distinct = true
+	})
+}
+
+// Top limits the number of rows. It cannot be used with Offset or Fetch
+//
+//	SQL: SELECT TOP (10) WITH TIES * FROM [users] ORDER BY [score] DESC
+//	Go: mssql.Select(sm.Top(10).WithTies(), sm.From("users"), sm.OrderBy("[score]").Desc())
+func Top(count any) dialect.TopChain[*dialect.SelectQuery] {
+	return dialect.TopChain[*dialect.SelectQuery](func() dialect.Top {
+		return dialect.Top{Count: count}
+	})
+}
+
+func Columns(clauses ...any) bob.Mod[*dialect.SelectQuery] {
+	return mods.Select[*dialect.SelectQuery](clauses)
+}
+
+func From(table any) dialect.FromChain[*dialect.SelectQuery] {
+	return dialect.From[*dialect.SelectQuery](table)
+}
+
+// AsOf reads the temporal table of the FROM clause as it was at the given time.
+// Use it with queries that already have a FROM clause.
+// The tables of joins are set with [dialect.JoinChain.AsOf]
+//
+//	SQL: SELECT * FROM users FOR SYSTEM_TIME AS OF @p1
+//	Go: mssql.Select(sm.From("users"), sm.AsOf(t))
+func AsOf(t time.Time) bob.Mod[*dialect.SelectQuery] {
+	return mods.QueryModFunc[*dialect.SelectQuery](func(q *dialect.SelectQuery) {
+		q.SetSystemTime(expr.Arg(t))
+	})
+}
+
+func InnerJoin(e any) dialect.JoinChain[*dialect.SelectQuery] {
+	return dialect.InnerJoin[*dialect.SelectQuery](e)
+}
+
+func LeftJoin(e any) dialect.JoinChain[*dialect.SelectQuery] {
+	return dialect.LeftJoin[*dialect.SelectQuery](e)
+}
+
+func RightJoin(e any) dialect.JoinChain[*dialect.SelectQuery] {
+	return dialect.RightJoin[*dialect.SelectQuery](e)
+}
+
+func FullJoin(e any) dialect.JoinChain[*dialect.SelectQuery] {
+	return dialect.FullJoin[*dialect.SelectQuery](e)
+}
+
+func CrossJoin(e any) bob.Mod[*dialect.SelectQuery] {
+	return dialect.CrossJoin[*dialect.SelectQuery](e)
+}
+
+func CrossApply(e any) dialect.JoinChain[*dialect.SelectQuery] {
+	return dialect.CrossApply[*dialect.SelectQuery](e)
+}
+
+func OuterApply(e any) dialect.JoinChain[*dialect.SelectQuery] {
+	return dialect.OuterApply[*dialect.SelectQuery](e)
+}
+
+func Where(e bob.Expression) mods.Where[*dialect.SelectQuery] {
+	return mods.Where[*dialect.SelectQuery]{E: e}
+}
+
+func Having(e any) bob.Mod[*dialect.SelectQuery] {
+	return mods.Having[*dialect.SelectQuery]{e}
+}
+
+func GroupBy(e any) bob.Mod[*dialect.SelectQuery] {
+	return mods.GroupBy[*dialect.SelectQuery]{
+		E: e,
+	}
+}
+
+func OrderBy(e any) dialect.OrderBy[*dialect.SelectQuery] {
+	return dialect.OrderBy[*dialect.SelectQuery](func() clause.OrderDef {
+		return clause.OrderDef{
+			Expression: e,
+		}
+	})
+}
+
+// Offset skips rows with OFFSET ... ROWS. Without an ORDER BY,
+// the rows are ordered by (SELECT NULL), which SQL Server requires
+func Offset(count any) bob.Mod[*dialect.SelectQuery] {
+	return mods.Offset[*dialect.SelectQuery]{
+		Count: count,
+	}
+}
+
+// Fetch limits the number of rows with FETCH NEXT ... ROWS ONLY,
+// which is written after an OFFSET of 0 if there is no Offset
+//
+//	SQL: SELECT * FROM [users] ORDER BY [id] OFFSET 20 ROWS FETCH NEXT 10 ROWS ONLY
+//	Go: mssql.Select(sm.From("users"), sm.OrderBy("[id]"), sm.Offset(20), sm.Fetch(10))
+func Fetch(count any) bob.Mod[*dialect.SelectQuery] {
+	return mods.QueryModFunc[*dialect.SelectQuery](func(q *dialect.SelectQuery) {
+		q.SetFetch(count)
+	})
+}
+
+func Union(q bob.Query) bob.Mod[*dialect.SelectQuery] {
+	return mods.Combine[*dialect.SelectQuery]{
+		Strategy: clause.Union,
+		Query:    q,
+		All:      false,
+	}
+}
+
+func UnionAll(q bob.Query) bob.Mod[*dialect.SelectQuery] {
+	return mods.Combine[*dialect.SelectQuery]{
+		Strategy: clause.Union,
+		Query:    q,
+		All:      true,
+	}
+}
+
+func Intersect(q bob.Query) bob.Mod[*dialect.SelectQuery] {
+	return mods.Combine[*dialect.SelectQuery]{
+		Strategy: clause.Intersect,
+		Query:    q,
+		All:      false,
+	}
+}
+
+func Except(q bob.Query) bob.Mod[*dialect.SelectQuery] {
+	return mods.Combine[*dialect.SelectQuery]{
+		Strategy: clause.Except,
+		Query:    q,
+		All:      false,
+	}
+}
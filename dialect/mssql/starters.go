@@ -0,0 +1,85 @@
+package mssql
+
+import (
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/mssql/dialect"
+	"github.com/stephenafamo/bob/expr"
+)
+
+type Expression = dialect.Expression
+
+//nolint:gochecknoglobals
+var bmod = expr.Builder[Expression, Expression]{}
+
+// S creates a string literal
+// SQL: 'a string'
+// Go: mssql.S("a string")
+func S(s string) Expression {
+	return bmod.S(s)
+}
+
+// SQL: NOT true
+// Go: mssql.Not("true")
+func Not(exp bob.Expression) Expression {
+	return bmod.Not(exp)
+}
+
+// SQL: a OR b OR c
+// Go: mssql.Or("a", "b", "c")
+func Or(args ...bob.Expression) Expression {
+	return bmod.Or(args...)
+}
+
+// SQL: a AND b AND c
+// Go: mssql.And("a", "b", "c")
+func And(args ...bob.Expression) Expression {
+	return bmod.And(args...)
+}
+
+// SQL: a + b + c
+// Go: mssql.Concat("a", "b", "c")
+func Concat(args ...bob.Expression) Expression {
+	return expr.X[Expression, Expression](expr.Join{Exprs: args, Sep: " + "})
+}
+
+// SQL: @p1, @p2, @p3
+// Go: mssql.Args("a", "b", "c")
+func Arg(args ...any) Expression {
+	return bmod.Arg(args...)
+}
+
+// SQL: (@p1, @p2, @p3)
+// Go: mssql.ArgGroup("a", "b", "c")
+func ArgGroup(args ...any) Expression {
+	return bmod.ArgGroup(args...)
+}
+
+// SQL: @p1, @p2, @p3
+// Go: mssql.Placeholder(3)
+func Placeholder(n uint) Expression {
+	return bmod.Placeholder(n)
+}
+
+// SQL: (a, b)
+// Go: mssql.Group("a", "b")
+func Group(exps ...bob.Expression) Expression {
+	return bmod.Group(exps...)
+}
+
+// SQL: [table].[column]
+// Go: mssql.Quote("table", "column")
+func Quote(ss ...string) Expression {
+	return bmod.Quote(ss...)
+}
+
+// SQL: where a = @p1
+// Go: mssql.Raw("where a = ?", "something")
+func Raw(query string, args ...any) Expression {
+	return bmod.Raw(query, args...)
+}
+
+// SQL: a as [alias]
+// Go: mssql.As("a", "alias")
+func As(e Expression, alias string) bob.Expression {
+	return expr.OP("AS", e, expr.Quote(alias))
+}
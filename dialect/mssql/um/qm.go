@@ -0,0 +1,115 @@
+package um
+
+import (
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/clause"
+	"github.com/stephenafamo/bob/dialect/mssql/dialect"
+	"github.com/stephenafamo/bob/expr"
+	"github.com/stephenafamo/bob/internal"
+	"github.com/stephenafamo/bob/mods"
+)
+
+func With(name string, columns ...string) dialect.CTEChain[*dialect.UpdateQuery] {
+	return dialect.With[*dialect.UpdateQuery](name, columns...)
+}
+
+func Recursive(r bool) bob.Mod[*dialect.UpdateQuery] {
+	return mods.Recursive[*dialect.UpdateQuery](r)
+}
+
+// Top limits the number of rows that are updated. The rows are not picked in any order
+func Top(count any) dialect.TopChain[*dialect.UpdateQuery] {
+	return dialect.TopChain[*dialect.UpdateQuery](func() dialect.Top {
+		return dialect.Top{Count: count}
+	})
+}
+
+// Table sets the updated table. To update through an alias,
+// set the alias as the table and add the aliased table with From
+//
+//	SQL: UPDATE [u] SET [name] = @p1 FROM [users] AS [u] INNER JOIN ...
+//	Go: mssql.Update(um.Table("[u]"), um.SetCol("name").ToArg(name), um.From("users").As("u"), um.InnerJoin(...))
+func Table(name any) bob.Mod[*dialect.UpdateQuery] {
+	return mods.QueryModFunc[*dialect.UpdateQuery](func(u *dialect.UpdateQuery) {
+		u.Table = clause.Table{
+			Expression: name,
+		}
+	})
+}
+
+func Set(sets ...bob.Expression) bob.Mod[*dialect.UpdateQuery] {
+	return mods.QueryModFunc[*dialect.UpdateQuery](func(q *dialect.UpdateQuery) {
+		q.Set.Set = append(q.Set.Set, internal.ToAnySlice(sets)...)
+	})
+}
+
+func SetCol(from string) mods.Set[*dialect.UpdateQuery] {
+	return mods.Set[*dialect.UpdateQuery]([]string{from})
+}
+
+func From(table any) dialect.FromChain[*dialect.UpdateQuery] {
+	return dialect.From[*dialect.UpdateQuery](table)
+}
+
+func InnerJoin(e any) dialect.JoinChain[*dialect.UpdateQuery] {
+	return dialect.InnerJoin[*dialect.UpdateQuery](e)
+}
+
+func LeftJoin(e any) dialect.JoinChain[*dialect.UpdateQuery] {
+	return dialect.LeftJoin[*dialect.UpdateQuery](e)
+}
+
+func RightJoin(e any) dialect.JoinChain[*dialect.UpdateQuery] {
+	return dialect.RightJoin[*dialect.UpdateQuery](e)
+}
+
+func FullJoin(e any) dialect.JoinChain[*dialect.UpdateQuery] {
+	return dialect.FullJoin[*dialect.UpdateQuery](e)
+}
+
+func CrossJoin(e any) bob.Mod[*dialect.UpdateQuery] {
+	return dialect.CrossJoin[*dialect.UpdateQuery](e)
+}
+
+func Where(e bob.Expression) mods.Where[*dialect.UpdateQuery] {
+	return mods.Where[*dialect.UpdateQuery]{E: e}
+}
+
+// AllRows allows the query to have no WHERE clause, which would otherwise
+// return [bob.ErrMissingWhere] to prevent changing every row by accident
+func AllRows() mods.AllRows[*dialect.UpdateQuery] {
+	return mods.AllRows[*dialect.UpdateQuery]{}
+}
+
+func Output(clauses ...any) bob.Mod[*dialect.UpdateQuery] {
+	return mods.QueryModFunc[*dialect.UpdateQuery](func(u *dialect.UpdateQuery) {
+		u.AppendOutput(clauses...)
+	})
+}
+
+// OutputInserted adds INSERTED.[column] to the OUTPUT clause for each column,
+// which are the values after the update
+func OutputInserted(columns ...string) bob.Mod[*dialect.UpdateQuery] {
+	return Output(quoteAll("INSERTED", columns)...)
+}
+
+// OutputDeleted adds DELETED.[column] to the OUTPUT clause for each column,
+// which are the values before the update
+func OutputDeleted(columns ...string) bob.Mod[*dialect.UpdateQuery] {
+	return Output(quoteAll("DELETED", columns)...)
+}
+
+// OutputInsertedAll adds INSERTED.* to the OUTPUT clause.
+// This is the MSSQL equivalent of RETURNING * in the other dialects
+func OutputInsertedAll() bob.Mod[*dialect.UpdateQuery] {
+	return Output(expr.Raw("INSERTED.*"))
+}
+
+func quoteAll(table string, columns []string) []any {
+	exprs := make([]any, len(columns))
+	for i, col := range columns {
+		exprs[i] = expr.Quote(table, col)
+	}
+
+	return exprs
+}
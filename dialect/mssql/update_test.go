@@ -0,0 +1,55 @@
+package mssql_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/mssql"
+	"github.com/stephenafamo/bob/dialect/mssql/um"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+func TestUpdate(t *testing.T) {
+	examples := testutils.Testcases{
+		"simple": {
+			Query: mssql.Update(
+				um.Table("films"),
+				um.SetCol("kind").ToArg("Dramatic"),
+				um.Where(mssql.Quote("kind").EQ(mssql.Arg("Drama"))),
+			),
+			ExpectedSQL:  `UPDATE films SET [kind] = @p1 WHERE ([kind] = @p2)`,
+			ExpectedArgs: []any{"Dramatic", "Drama"},
+		},
+		"top with output and join": {
+			Query: mssql.Update(
+				um.Top(10),
+				um.Table(mssql.Quote("f")),
+				um.SetCol("price").To(mssql.Quote("f", "price").Minus(mssql.Arg(1))),
+				um.OutputDeleted("price"),
+				um.OutputInserted("price"),
+				um.From("films").As("f"),
+				um.InnerJoin("sales").As("s").OnEQ(mssql.Quote("s", "film_id"), mssql.Quote("f", "id")),
+				um.Where(mssql.Quote("s", "count").EQ(mssql.Arg(0))),
+			),
+			ExpectedSQL: `UPDATE TOP (10) [f] SET [price] = ([f].[price] - @p1)
+				OUTPUT [DELETED].[price], [INSERTED].[price]
+				FROM films AS [f]
+				INNER JOIN sales AS [s] ON ([s].[film_id] = [f].[id])
+				WHERE ([s].[count] = @p2)`,
+			ExpectedArgs: []any{1, 0},
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+}
+
+func TestUpdateMissingWhere(t *testing.T) {
+	_, _, err := bob.Build(mssql.Update(
+		um.Table("films"),
+		um.SetCol("kind").ToArg("Dramatic"),
+	))
+	if !errors.Is(err, bob.ErrMissingWhere) {
+		t.Fatalf("got %v, want ErrMissingWhere", err)
+	}
+}
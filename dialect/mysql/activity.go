@@ -0,0 +1,90 @@
+package mysql
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/scan"
+)
+
+// RunningQuery is a statement that a connection is running, from the process list.
+// See [RunningQueries]
+type RunningQuery struct {
+	// The ID of the connection, for [CancelQuery] and [KillConnection]
+	ID       int64
+	User     string
+	Host     string
+	Database string
+	// The kind of command, usually Query
+	Command string
+	// What the thread is doing, e.g. Sending data or Waiting for table metadata lock
+	State string
+	// The text of the statement
+	Query string
+	// How long the connection has been in its current state, in whole seconds
+	Duration time.Duration
+}
+
+type processRow struct {
+	ID       int64   `db:"id"`
+	User     string  `db:"user"`
+	Host     string  `db:"host"`
+	Database *string `db:"database"`
+	Command  string  `db:"command"`
+	State    *string `db:"state"`
+	Query    *string `db:"query"`
+	Seconds  int64   `db:"seconds"`
+}
+
+// RunningQueries returns the statements of the other connections that have been running
+// for at least minDuration, longest first. Sleeping connections are left out.
+// Users without the PROCESS privilege only see their own connections
+func RunningQueries(ctx context.Context, exec bob.Executor, minDuration time.Duration) ([]RunningQuery, error) {
+	rows, err := bob.All(ctx, exec, RawQuery("SELECT id AS `id`, user AS `user`, host AS `host`,"+
+		" db AS `database`, command AS `command`, state AS `state`, info AS `query`, time AS `seconds`"+
+		" FROM information_schema.processlist"+
+		" WHERE command NOT IN ('Sleep', 'Daemon', 'Binlog Dump', 'Binlog Dump GTID')"+
+		" AND id <> CONNECTION_ID() AND time >= ?"+
+		" ORDER BY time DESC, id", int64(minDuration/time.Second)), scan.StructMapper[processRow]())
+	if err != nil {
+		return nil, err
+	}
+
+	queries := make([]RunningQuery, len(rows))
+	for i, row := range rows {
+		queries[i] = RunningQuery{
+			ID:       row.ID,
+			User:     row.User,
+			Host:     row.Host,
+			Command:  row.Command,
+			Duration: time.Duration(row.Seconds) * time.Second,
+		}
+		if row.Database != nil {
+			queries[i].Database = *row.Database
+		}
+		if row.State != nil {
+			queries[i].State = *row.State
+		}
+		if row.Query != nil {
+			queries[i].Query = *row.Query
+		}
+	}
+
+	return queries, nil
+}
+
+// CancelQuery stops the statement that the connection is running, with KILL QUERY.
+// The connection stays open
+func CancelQuery(ctx context.Context, exec bob.Executor, id int64) error {
+	_, err := bob.Exec(ctx, exec, RawQuery("KILL QUERY "+strconv.FormatInt(id, 10)))
+	return err
+}
+
+// KillConnection closes the connection, with KILL CONNECTION,
+// which rolls back its transaction
+func KillConnection(ctx context.Context, exec bob.Executor, id int64) error {
+	_, err := bob.Exec(ctx, exec, RawQuery("KILL CONNECTION "+strconv.FormatInt(id, 10)))
+	return err
+}
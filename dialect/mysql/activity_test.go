@@ -0,0 +1,89 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stephenafamo/scan"
+)
+
+// activityExec records the statements, and returns its rows for the queries
+type activityExec struct {
+	queries []string
+	args    [][]any
+	rows    *batchRows
+}
+
+func (e *activityExec) ExecContext(_ context.Context, query string, args ...any) (sql.Result, error) {
+	e.queries = append(e.queries, query)
+	e.args = append(e.args, args)
+	return batchResult{}, nil
+}
+
+func (e *activityExec) QueryContext(_ context.Context, query string, args ...any) (scan.Rows, error) {
+	e.queries = append(e.queries, query)
+	e.args = append(e.args, args)
+	return e.rows, nil
+}
+
+func TestRunningQueries(t *testing.T) {
+	db, state, query := "app", "Sending data", "SELECT SLEEP(60)"
+	exec := &activityExec{rows: &batchRows{
+		columns: []string{"id", "user", "host", "database", "command", "state", "query", "seconds"},
+		rows: [][]any{
+			{int64(12), "app", "10.0.0.5:51234", &db, "Query", &state, &query, int64(75)},
+			{int64(13), "root", "localhost", (*string)(nil), "Query", (*string)(nil), (*string)(nil), int64(61)},
+		},
+	}}
+
+	queries, err := RunningQueries(context.Background(), exec, 90*time.Second+500*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, part := range []string{
+		"FROM information_schema.processlist",
+		"WHERE command NOT IN ('Sleep', 'Daemon', 'Binlog Dump', 'Binlog Dump GTID')",
+		"AND id <> CONNECTION_ID() AND time >= ?",
+		"ORDER BY time DESC, id",
+	} {
+		if !strings.Contains(exec.queries[0], part) {
+			t.Errorf("expected %q in the query:\n%s", part, exec.queries[0])
+		}
+	}
+	if !reflect.DeepEqual(exec.args[0], []any{int64(90)}) {
+		t.Errorf("expected the duration in whole seconds, got %v", exec.args[0])
+	}
+
+	expected := []RunningQuery{
+		{
+			ID: 12, User: "app", Host: "10.0.0.5:51234", Database: "app", Command: "Query",
+			State: "Sending data", Query: "SELECT SLEEP(60)", Duration: 75 * time.Second,
+		},
+		{ID: 13, User: "root", Host: "localhost", Command: "Query", Duration: 61 * time.Second},
+	}
+	if !reflect.DeepEqual(queries, expected) {
+		t.Fatalf("got %+v, expected %+v", queries, expected)
+	}
+}
+
+func TestKillQuery(t *testing.T) {
+	exec := &activityExec{}
+	ctx := context.Background()
+
+	if err := CancelQuery(ctx, exec, 42); err != nil {
+		t.Fatal(err)
+	}
+	if err := KillConnection(ctx, exec, 42); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{"KILL QUERY 42", "KILL CONNECTION 42"}
+	if !reflect.DeepEqual(exec.queries, expected) {
+		t.Fatalf("got %q, expected %q", exec.queries, expected)
+	}
+}
@@ -0,0 +1,18 @@
+package mysql
+
+import (
+	"github.com/stephenafamo/bob"
+)
+
+// SetApplicationName sets the @application_name user variable of the session,
+// which shows in performance_schema.user_variables_by_thread.
+// It stays set on the connection after the transaction. Use it with [bob.AttributedDB].
+// MySQL can only change the connection attributes when connecting, to name all the
+// connections of a pool set the connectionAttributes of the DSN,
+// e.g. connectionAttributes=program_name:billing
+//
+//	SQL: SET @application_name = ?
+//	Go: mysql.SetApplicationName("billing")
+func SetApplicationName(name string) bob.Query {
+	return RawQuery("SET @application_name = ?", name)
+}
@@ -0,0 +1,40 @@
+package mysql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stephenafamo/bob/dialect/mysql"
+	"github.com/stephenafamo/bob/dialect/mysql/sm"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+func TestAsOf(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	// FOR SYSTEM_TIME is MariaDB syntax, which the MySQL formatter cannot parse
+	examples := testutils.Testcases{
+		"from": {
+			Query: mysql.Select(
+				sm.Columns("id", "name"),
+				sm.From("users").AsOf(at).As("u"),
+				sm.Where(mysql.Quote("id").EQ(mysql.Arg(1))),
+			),
+			ExpectedSQL:  "SELECT id, name FROM users FOR SYSTEM_TIME AS OF TIMESTAMP ? AS `u` WHERE (`id` = ?)",
+			ExpectedArgs: []any{at, 1},
+		},
+		"mod and join": {
+			Query: mysql.Select(
+				sm.Columns("*"),
+				sm.From("users"),
+				sm.AsOf(at),
+				sm.InnerJoin("orders").AsOf(at).On(mysql.Quote("orders", "user_id").EQ(mysql.Quote("users", "id"))),
+			),
+			ExpectedSQL: "SELECT * FROM users FOR SYSTEM_TIME AS OF TIMESTAMP ? " +
+				"INNER JOIN orders FOR SYSTEM_TIME AS OF TIMESTAMP ? ON (`orders`.`user_id` = `users`.`id`)",
+			ExpectedArgs: []any{at, at},
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+}
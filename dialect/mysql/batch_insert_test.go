@@ -0,0 +1,196 @@
+package mysql
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/aarondl/opt/omit"
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/mysql/dialect"
+	"github.com/stephenafamo/bob/dialect/mysql/im"
+	"github.com/stephenafamo/bob/dialect/mysql/um"
+	"github.com/stephenafamo/scan"
+)
+
+type batchPost struct {
+	ID    int64  `db:"id,pk,autoincr"`
+	Title string `db:"title"`
+}
+
+func (p *batchPost) PrimaryKeyVals() bob.Expression {
+	return Arg(p.ID)
+}
+
+type batchPostSetter struct {
+	ID    omit.Val[int64]  `db:"id,pk,autoincr"`
+	Title omit.Val[string] `db:"title"`
+}
+
+func (s *batchPostSetter) SetColumns() []string {
+	cols := []string{"title"}
+	if s.ID.IsSet() {
+		cols = append(cols, "id")
+	}
+	return cols
+}
+
+func (s *batchPostSetter) Overwrite(p *batchPost) {
+	p.Title = s.Title.GetOrZero()
+}
+
+func (s *batchPostSetter) Apply(q *dialect.UpdateQuery) {
+	um.SetCol("title").ToArg(s.Title).Apply(q)
+}
+
+func (s *batchPostSetter) InsertMod() bob.Mod[*dialect.InsertQuery] {
+	id := bob.Expression(Raw("DEFAULT"))
+	if s.ID.IsSet() {
+		id = Arg(s.ID.MustGet())
+	}
+	return im.Values(id, Arg(s.Title.GetOrZero()))
+}
+
+// batchExec fakes the auto increment of a MySQL server
+type batchExec struct {
+	lockMode int64
+	nextID   int64
+	queries  []string
+}
+
+func (e *batchExec) ExecContext(_ context.Context, query string, args ...any) (sql.Result, error) {
+	e.queries = append(e.queries, query)
+
+	var res batchResult
+	for _, arg := range args {
+		switch a := arg.(type) {
+		case int64:
+			res.lastID = a
+		case string:
+			res.affected++
+		}
+	}
+
+	if res.lastID == 0 {
+		res.lastID = e.nextID
+		e.nextID += res.affected
+	}
+
+	return res, nil
+}
+
+func (e *batchExec) QueryContext(_ context.Context, query string, args ...any) (scan.Rows, error) {
+	e.queries = append(e.queries, query)
+
+	if strings.Contains(query, "@@innodb_autoinc_lock_mode") {
+		return &batchRows{columns: []string{"lock_mode", "increment"}, rows: [][]any{{e.lockMode, int64(1)}}}, nil
+	}
+
+	rows := &batchRows{columns: []string{"id", "title"}}
+	for _, arg := range args {
+		id := arg.(int64)
+		rows.rows = append(rows.rows, []any{id, fmt.Sprintf("post %d", id)})
+	}
+
+	return rows, nil
+}
+
+type batchResult struct {
+	lastID, affected int64
+}
+
+func (r batchResult) LastInsertId() (int64, error) { return r.lastID, nil }
+func (r batchResult) RowsAffected() (int64, error) { return r.affected, nil }
+
+type batchRows struct {
+	columns []string
+	rows    [][]any
+	index   int
+}
+
+func (r *batchRows) Scan(dest ...any) error {
+	for i, v := range r.rows[r.index-1] {
+		reflect.ValueOf(dest[i]).Elem().Set(reflect.ValueOf(v))
+	}
+	return nil
+}
+
+func (r *batchRows) Columns() ([]string, error) { return r.columns, nil }
+func (r *batchRows) Next() bool                 { r.index++; return r.index <= len(r.rows) }
+func (r *batchRows) Close() error               { return nil }
+func (r *batchRows) Err() error                 { return nil }
+
+func TestBatchInsert(t *testing.T) {
+	table := NewTable[*batchPost, *batchPostSetter]("posts")
+	table.BatchInsert = true
+
+	newRows := func() []*batchPostSetter {
+		return []*batchPostSetter{
+			{Title: omit.From("post 10")},
+			{Title: omit.From("post 11")},
+			{Title: omit.From("post 12")},
+		}
+	}
+
+	cases := map[string]struct {
+		lockMode int64
+		rows     []*batchPostSetter
+		inserts  int
+		ids      []int64
+	}{
+		"consecutive": {
+			lockMode: 1,
+			rows:     newRows(),
+			inserts:  1,
+			ids:      []int64{10, 11, 12},
+		},
+		"interleaved": {
+			lockMode: 2,
+			rows:     newRows(),
+			inserts:  3,
+			ids:      []int64{10, 11, 12},
+		},
+		"explicit id": {
+			lockMode: 1,
+			rows: append(newRows()[:2], &batchPostSetter{
+				ID:    omit.From(int64(50)),
+				Title: omit.From("post 50"),
+			}),
+			inserts: 3,
+			ids:     []int64{10, 11, 50},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			exec := &batchExec{lockMode: tc.lockMode, nextID: 10}
+
+			posts, err := table.InsertMany(context.Background(), exec, tc.rows...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var inserts int
+			for _, q := range exec.queries {
+				if strings.HasPrefix(strings.TrimSpace(q), "INSERT") {
+					inserts++
+				}
+			}
+			if inserts != tc.inserts {
+				t.Fatalf("expected %d inserts, got %d: %q", tc.inserts, inserts, exec.queries)
+			}
+
+			if len(posts) != len(tc.ids) {
+				t.Fatalf("expected %d posts, got %d", len(tc.ids), len(posts))
+			}
+			for i, p := range posts {
+				if p.ID != tc.ids[i] || p.Title != fmt.Sprintf("post %d", tc.ids[i]) {
+					t.Fatalf("unexpected post %d: %+v", i, p)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package mysql
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/mysql/dialect"
+)
+
+// CommentOnTable builds an ALTER TABLE statement that sets the comment of the table.
+// An empty text removes the comment. The comments are read by bobgen into the generated models
+//
+//	SQL: ALTER TABLE `users` COMMENT = 'The users of the app'
+//	Go: mysql.CommentOnTable("users", "The users of the app")
+func CommentOnTable(table, text string) bob.BaseQuery[bob.Expression] {
+	return bob.BaseQuery[bob.Expression]{
+		Expression: bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+			w.Write([]byte("ALTER TABLE "))
+			d.WriteQuoted(w, table)
+			w.Write([]byte(" COMMENT = " + quoteComment(text)))
+			return nil, nil
+		}),
+		Dialect: dialect.Dialect,
+	}
+}
+
+// CommentOnColumn builds an ALTER TABLE statement that sets the comment of a column.
+// MySQL can only change the comment together with the rest of the column,
+// so the definition must be the full definition of the column, as in SHOW CREATE TABLE
+//
+//	SQL: ALTER TABLE `users` MODIFY COLUMN `email` varchar(255) NOT NULL COMMENT 'Unique, in lower case'
+//	Go: mysql.CommentOnColumn("users", "email", "varchar(255) NOT NULL", "Unique, in lower case")
+func CommentOnColumn(table, column, definition, text string) bob.BaseQuery[bob.Expression] {
+	return bob.BaseQuery[bob.Expression]{
+		Expression: bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+			if definition == "" {
+				return nil, fmt.Errorf("comment on column %s: the definition is required", column)
+			}
+
+			w.Write([]byte("ALTER TABLE "))
+			d.WriteQuoted(w, table)
+			w.Write([]byte(" MODIFY COLUMN "))
+			d.WriteQuoted(w, column)
+			w.Write([]byte(" " + definition + " COMMENT " + quoteComment(text)))
+			return nil, nil
+		}),
+		Dialect: dialect.Dialect,
+	}
+}
+
+// quoteComment quotes the text as a string literal. Backslashes are escaped too,
+// since they start escape sequences in MySQL strings
+func quoteComment(text string) string {
+	text = strings.ReplaceAll(text, `\`, `\\`)
+	return "'" + strings.ReplaceAll(text, "'", "''") + "'"
+}
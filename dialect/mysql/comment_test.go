@@ -0,0 +1,28 @@
+package mysql_test
+
+import (
+	"testing"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/mysql"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+func TestCommentOn(t *testing.T) {
+	examples := testutils.Testcases{
+		"table": {
+			Query:       mysql.CommentOnTable("users", `The users' accounts, C:\users`),
+			ExpectedSQL: "ALTER TABLE `users` COMMENT = 'The users'' accounts, C:\\\\users'",
+		},
+		"column": {
+			Query:       mysql.CommentOnColumn("users", "email", "varchar(255) NOT NULL", "Unique, in lower case"),
+			ExpectedSQL: "ALTER TABLE `users` MODIFY COLUMN `email` varchar(255) NOT NULL COMMENT 'Unique, in lower case'",
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+
+	if _, _, err := bob.Build(mysql.CommentOnColumn("users", "email", "", "text")); err == nil {
+		t.Fatal("expected an error without a definition")
+	}
+}
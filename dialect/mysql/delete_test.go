@@ -51,6 +51,12 @@ func TestDelete(t *testing.T) {
 			),
 			ExpectedSQL:  "DELETE FROM employees USING accounts WHERE (`accounts`.`name` = ?) AND (`employees`.`id` = `accounts`.`sales_person`)",
 			ExpectedArgs: []any{"Acme Corporation"},
+		}, "all rows": {
+			Query: mysql.Delete(
+				dm.From("films"),
+				dm.AllRows(),
+			),
+			ExpectedSQL: "DELETE FROM films",
 		},
 	}
 
@@ -0,0 +1,95 @@
+package dialect
+
+import "github.com/stephenafamo/bob"
+
+// Supports reports if MySQL supports the construct when transpiling queries
+func (d dialect) Supports(c bob.Construct) bool {
+	switch c {
+	case bob.ConstructDistinctOn, bob.ConstructFetch, bob.ConstructReturning,
+		bob.ConstructUpdateReturning, bob.ConstructOnConflict, bob.ConstructFullJoin,
+		bob.ConstructOrAction:
+		return false
+	}
+
+	return true
+}
+
+// Supports reports if MariaDB supports the construct when transpiling queries.
+// It supports RETURNING in inserts and deletes, which MySQL does not,
+// but not in updates
+func (m mariaDB) Supports(c bob.Construct) bool {
+	if c == bob.ConstructReturning {
+		return true
+	}
+
+	return m.dialect.Supports(c)
+}
+
+func (h hints) constructs() []bob.Construct {
+	if len(h.hints) > 0 {
+		return []bob.Construct{bob.ConstructHints}
+	}
+
+	return nil
+}
+
+func (m modifiers[T]) constructs() []bob.Construct {
+	if len(m.modifiers) > 0 {
+		return []bob.Construct{bob.ConstructModifiers}
+	}
+
+	return nil
+}
+
+func (s SelectQuery) Constructs() []bob.Construct {
+	constructs := s.From.Constructs()
+	constructs = append(constructs, s.hints.constructs()...)
+	constructs = append(constructs, s.modifiers.constructs()...)
+
+	if s.For.Strength != "" {
+		constructs = append(constructs, bob.ConstructLocking)
+	}
+
+	return constructs
+}
+
+func (i InsertQuery) Constructs() []bob.Construct {
+	constructs := i.hints.constructs()
+	constructs = append(constructs, i.modifiers.constructs()...)
+
+	if len(i.DuplicateKeyUpdate.Set) > 0 {
+		constructs = append(constructs, bob.ConstructOnDuplicateKey)
+	}
+	if len(i.Returning.Expressions) > 0 {
+		constructs = append(constructs, bob.ConstructReturning)
+	}
+
+	return constructs
+}
+
+func (u UpdateQuery) Constructs() []bob.Construct {
+	constructs := u.From.Constructs()
+	constructs = append(constructs, u.hints.constructs()...)
+	constructs = append(constructs, u.modifiers.constructs()...)
+
+	if len(u.OrderBy.Expressions) > 0 || u.Limit.Count != nil {
+		constructs = append(constructs, bob.ConstructOrderedWrite)
+	}
+
+	return constructs
+}
+
+func (d DeleteQuery) Constructs() []bob.Construct {
+	constructs := d.From.Constructs()
+	constructs = append(constructs, d.hints.constructs()...)
+	constructs = append(constructs, d.modifiers.constructs()...)
+
+	if len(d.OrderBy.Expressions) > 0 || d.Limit.Count != nil {
+		constructs = append(constructs, bob.ConstructOrderedWrite)
+	}
+	if len(d.Returning.Expressions) > 0 {
+		constructs = append(constructs, bob.ConstructReturning)
+	}
+
+	return constructs
+}
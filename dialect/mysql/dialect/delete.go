@@ -19,9 +19,15 @@ type DeleteQuery struct {
 	clause.Where
 	clause.OrderBy
 	clause.Limit
+	// MariaDB only, for deletes from one table
+	clause.Returning
 }
 
 func (d DeleteQuery) WriteSQL(w io.Writer, dl bob.Dialect, start int) ([]any, error) {
+	if err := d.Where.CheckAllRows(); err != nil {
+		return nil, err
+	}
+
 	var args []any
 
 	withArgs, err := bob.ExpressIf(w, dl, start+len(args), d.With,
@@ -80,5 +86,12 @@ func (d DeleteQuery) WriteSQL(w io.Writer, dl bob.Dialect, start int) ([]any, er
 		return nil, err
 	}
 
+	retArgs, err := bob.ExpressIf(w, dl, start+len(args), d.Returning,
+		len(d.Returning.Expressions) > 0, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, retArgs...)
+
 	return args, nil
 }
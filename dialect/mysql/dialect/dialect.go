@@ -2,17 +2,30 @@ package dialect
 
 import (
 	"io"
+
+	"github.com/stephenafamo/bob"
 )
 
 //nolint:gochecknoglobals
 var (
-	Dialect      dialect
+	Dialect dialect
+	// MariaDB writes queries like Dialect, and also supports RETURNING
+	// when transpiling queries to it
+	MariaDB      mariaDB
 	questionMark = []byte("?")
 	backtick     = []byte("`")
 )
 
+//nolint:gochecknoinits
+func init() {
+	bob.RegisterDialect(bob.ServerMySQL, Dialect)
+	bob.RegisterDialect(bob.ServerMariaDB, MariaDB)
+}
+
 type dialect struct{}
 
+type mariaDB struct{ dialect }
+
 func (d dialect) WriteArg(w io.Writer, position int) {
 	w.Write(questionMark)
 }
@@ -22,3 +35,8 @@ func (d dialect) WriteQuoted(w io.Writer, s string) {
 	w.Write([]byte(s))
 	w.Write(backtick)
 }
+
+// MaxArgs is the largest number of args in a query in MySQL
+func (d dialect) MaxArgs() int {
+	return 65535
+}
@@ -0,0 +1,65 @@
+package dialect
+
+import (
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/clause"
+)
+
+// Features of the query builder that older versions of MySQL do not have.
+// Queries using them fail with [bob.ErrUnsupported] on an older server
+// once its version is known with [bob.ServerInfo]
+var (
+	FeatureCTE      = bob.Feature{Name: "WITH", Server: bob.ServerMySQL, Since: bob.Version{Major: 8}}
+	FeatureLockWait = bob.Feature{Name: "NOWAIT and SKIP LOCKED", Server: bob.ServerMySQL, Since: bob.Version{Major: 8, Patch: 1}}
+
+	// Features that only MariaDB has. Queries using them fail with [bob.ErrUnsupported]
+	// on MySQL and older MariaDB servers
+	FeatureInsertReturning = bob.Feature{Name: "INSERT RETURNING", Server: bob.ServerMariaDB, Since: bob.Version{Major: 10, Minor: 5}, Exclusive: true}
+	FeatureDeleteReturning = bob.Feature{Name: "DELETE RETURNING", Server: bob.ServerMariaDB, Since: bob.Version{Major: 10}, Exclusive: true}
+)
+
+func (s SelectQuery) RequiredFeatures() []bob.Feature {
+	features := withFeatures(s.With)
+	if s.For.Wait != "" {
+		features = append(features, FeatureLockWait)
+	}
+
+	return features
+}
+
+func (i InsertQuery) RequiredFeatures() []bob.Feature {
+	if len(i.Returning.Expressions) > 0 {
+		return []bob.Feature{FeatureInsertReturning}
+	}
+
+	return nil
+}
+
+func (u UpdateQuery) RequiredFeatures() []bob.Feature {
+	return withFeatures(u.With)
+}
+
+func (d DeleteQuery) RequiredFeatures() []bob.Feature {
+	features := withFeatures(d.With)
+	if len(d.Returning.Expressions) > 0 {
+		features = append(features, FeatureDeleteReturning)
+	}
+
+	return features
+}
+
+// withFeatures returns the features used by the CTEs, including their queries
+func withFeatures(with clause.With) []bob.Feature {
+	if len(with.CTEs) == 0 {
+		return nil
+	}
+
+	features := []bob.Feature{FeatureCTE}
+	for _, cte := range with.CTEs {
+		if r, ok := cte.Query.(bob.FeatureRequirer); ok {
+			features = append(features, r.RequiredFeatures()...)
+		}
+	}
+
+	return features
+}
@@ -22,6 +22,8 @@ type InsertQuery struct {
 	ColumnAlias        []string
 	Sets               []Set
 	DuplicateKeyUpdate clause.Set
+	// MariaDB only
+	clause.Returning
 }
 
 func (i InsertQuery) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
@@ -111,6 +113,13 @@ func (i InsertQuery) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, err
 	}
 	args = append(args, updateArgs...)
 
+	retArgs, err := bob.ExpressIf(w, d, start+len(args), i.Returning,
+		len(i.Returning.Expressions) > 0, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, retArgs...)
+
 	w.Write([]byte("\n"))
 	return args, nil
 }
@@ -2,6 +2,7 @@ package dialect
 
 import (
 	"io"
+	"time"
 
 	"github.com/stephenafamo/bob"
 	"github.com/stephenafamo/bob/clause"
@@ -24,6 +25,7 @@ type fromable interface {
 	SetLateral(bool)
 	AppendPartition(...string)
 	AppendIndexHint(clause.IndexHint)
+	SetSystemTime(any)
 }
 
 func From[Q fromable](table any) FromChain[Q] {
@@ -46,6 +48,9 @@ func (f FromChain[Q]) Apply(q Q) {
 
 	q.SetLateral(from.Lateral)
 	q.AppendPartition(from.Partitions...)
+	if from.SystemTime != nil {
+		q.SetSystemTime(from.SystemTime)
+	}
 }
 
 func (f FromChain[Q]) As(alias string, columns ...string) FromChain[Q] {
@@ -76,6 +81,19 @@ func (f FromChain[Q]) Partition(partitions ...string) FromChain[Q] {
 	})
 }
 
+// AsOf reads the table as it was at the given time, with the system versioning of MariaDB
+//
+//	SQL: SELECT * FROM users FOR SYSTEM_TIME AS OF TIMESTAMP ?
+//	Go: mysql.Select(sm.From("users").AsOf(t))
+func (f FromChain[Q]) AsOf(t time.Time) FromChain[Q] {
+	fr := f()
+	fr.SystemTime = SystemTime(t)
+
+	return FromChain[Q](func() clause.From {
+		return fr
+	})
+}
+
 func (f FromChain[Q]) index(Type, For, first string, others ...string) FromChain[Q] {
 	fr := f()
 	fr.IndexHints = append(fr.IndexHints, clause.IndexHint{
@@ -231,6 +249,16 @@ func (f JoinChain[Q]) Partition(partitions ...string) JoinChain[Q] {
 	})
 }
 
+// AsOf reads the joined table as it was at the given time, see [FromChain.AsOf]
+func (f JoinChain[Q]) AsOf(t time.Time) JoinChain[Q] {
+	jo := f()
+	jo.To.SystemTime = SystemTime(t)
+
+	return JoinChain[Q](func() clause.Join {
+		return jo
+	})
+}
+
 func (j JoinChain[Q]) Natural() bob.Mod[Q] {
 	jo := j()
 	jo.Natural = true
@@ -425,3 +453,13 @@ func (w *WindowChain[T]) ToUnboundedFollowing() T {
 	w.def.SetEnd("UNBOUNDED FOLLOWING")
 	return w.Wrap
 }
+
+// SystemTime is the point in time of FOR SYSTEM_TIME AS OF, written as TIMESTAMP ?
+type SystemTime time.Time
+
+func (t SystemTime) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	w.Write([]byte("TIMESTAMP "))
+	d.WriteArg(w, start)
+
+	return []any{time.Time(t)}, nil
+}
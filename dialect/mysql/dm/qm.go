@@ -80,6 +80,12 @@ func Where(e bob.Expression) mods.Where[*dialect.DeleteQuery] {
 	return mods.Where[*dialect.DeleteQuery]{E: e}
 }
 
+// AllRows allows the query to have no WHERE clause, which would otherwise
+// return [bob.ErrMissingWhere] to prevent changing every row by accident
+func AllRows() mods.AllRows[*dialect.DeleteQuery] {
+	return mods.AllRows[*dialect.DeleteQuery]{}
+}
+
 func OrderBy(e any) dialect.OrderBy[*dialect.DeleteQuery] {
 	return dialect.OrderBy[*dialect.DeleteQuery](func() clause.OrderDef {
 		return clause.OrderDef{
@@ -93,3 +99,9 @@ func Limit(count int64) bob.Mod[*dialect.DeleteQuery] {
 		Count: count,
 	}
 }
+
+// Returning returns the columns of the deleted rows. Only MariaDB supports it,
+// and only for deletes from one table
+func Returning(clauses ...any) bob.Mod[*dialect.DeleteQuery] {
+	return mods.Returning[*dialect.DeleteQuery](clauses)
+}
@@ -0,0 +1,33 @@
+package mysql
+
+import (
+	"context"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/scan"
+)
+
+// HealthCheck checks that the MySQL server responds, and returns its version
+// and the latency of the probe. See [bob.HealthCheck]
+func HealthCheck(ctx context.Context, exec bob.Executor) (bob.Health, error) {
+	return bob.HealthCheck(ctx, exec, "SELECT VERSION()")
+}
+
+// IsPrimary reports if the MySQL server accepts writes, i.e. read_only is off.
+// Use it as the probe of a [bob.Failover]
+func IsPrimary(ctx context.Context, exec bob.Executor) (bool, error) {
+	return scan.One(ctx, exec, scan.SingleColumnMapper[bool], "SELECT @@global.read_only = 0")
+}
+
+// CurrentGTID returns the set of the GTIDs of the transactions that the server has executed.
+// Use it as the Token of a [bob.ReplicaRouter], with [ExecutedGTID] as CaughtUp.
+// This needs GTIDs to be on, with gtid_mode=ON
+func CurrentGTID(ctx context.Context, exec bob.Executor) (string, error) {
+	return scan.One(ctx, exec, scan.SingleColumnMapper[string], "SELECT @@global.gtid_executed")
+}
+
+// ExecutedGTID reports if a replica has executed all the transactions of the GTID set.
+// Use it as the CaughtUp of a [bob.ReplicaRouter]
+func ExecutedGTID(ctx context.Context, exec bob.Executor, gtids string) (bool, error) {
+	return scan.One(ctx, exec, scan.SingleColumnMapper[bool], "SELECT GTID_SUBSET(?, @@global.gtid_executed)", gtids)
+}
@@ -70,6 +70,11 @@ func As(rowAlias string, colAlias ...string) bob.Mod[*dialect.InsertQuery] {
 	})
 }
 
+// Returning returns the columns of the inserted rows. Only MariaDB supports it
+func Returning(clauses ...any) bob.Mod[*dialect.InsertQuery] {
+	return mods.Returning[*dialect.InsertQuery](clauses)
+}
+
 func OnDuplicateKeyUpdate(clauses ...bob.Mod[*clause.Set]) bob.Mod[*dialect.InsertQuery] {
 	sets := clause.Set{}
 	for _, m := range clauses {
@@ -10,6 +10,7 @@ import (
 	"github.com/stephenafamo/bob"
 	"github.com/stephenafamo/bob/dialect/mysql/dialect"
 	"github.com/stephenafamo/bob/dialect/mysql/sm"
+	"github.com/stephenafamo/bob/expr"
 	"github.com/stephenafamo/bob/internal"
 	"github.com/stephenafamo/bob/mods"
 	"github.com/stephenafamo/bob/orm"
@@ -97,6 +98,86 @@ func Preload[T any, Ts ~[]T](rel orm.Relationship, cols []string, opts ...Preloa
 	}, rel.Name, settings)
 }
 
+// PreloadJSON is an alternative to [Preload] for to-many relationships.
+// Instead of joining the related rows, they are aggregated with JSON_ARRAYAGG in a
+// correlated subquery, so the parent rows are not repeated and the relationship
+// is loaded in the same query.
+//
+// The related rows are decoded with the same db tags used for scanning.
+// Nested preloaders are not supported, but loaders (ThenLoad...) are
+func PreloadJSON[T any, Ts ~[]T](rel orm.Relationship, cols []string, opts ...PreloadOption) Preloader {
+	settings := internal.NewPreloadSettings[T, Ts, *dialect.SelectQuery](cols)
+	for _, o := range opts {
+		if o == nil {
+			continue
+		}
+		o.ModifyPreloadSettings(&settings)
+	}
+
+	return func(ctx context.Context) (bob.Mod[*dialect.SelectQuery], scan.MapperMod, []bob.Loader) {
+		parent, _ := ctx.Value(orm.CtxLoadParentAlias).(string)
+		if parent == "" {
+			parent = rel.Sides[0].From
+		}
+
+		var alias string
+		var where []bob.Expression
+		queryMods := make(mods.QueryMods[*dialect.SelectQuery], 0, len(rel.Sides)+1)
+
+		for i, side := range rel.Sides {
+			alias = fmt.Sprintf("%s_%d", side.To, internal.RandInt())
+			on := make([]bob.Expression, 0, len(side.FromColumns)+len(side.FromWhere)+len(side.ToWhere))
+			for i, fromCol := range side.FromColumns {
+				toCol := side.ToColumns[i]
+				on = append(on, Quote(parent, fromCol).EQ(Quote(alias, toCol)))
+			}
+			for _, from := range side.FromWhere {
+				on = append(on, Quote(parent, from.Column).EQ(Raw(from.SQLValue)))
+			}
+			for _, to := range side.ToWhere {
+				on = append(on, Quote(alias, to.Column).EQ(Raw(to.SQLValue)))
+			}
+
+			if len(settings.Mods) > i {
+				for _, additional := range settings.Mods[i] {
+					on = append(on, additional(parent, alias)...)
+				}
+			}
+
+			// the first side is correlated with the parent query
+			if i == 0 {
+				queryMods = append(queryMods, sm.From(side.ToExpr(ctx)).As(alias))
+				where = on
+			} else {
+				queryMods = append(queryMods, sm.InnerJoin(side.ToExpr(ctx)).As(alias).On(on...))
+			}
+
+			parent = alias
+		}
+
+		object := F("JSON_OBJECT", jsonObjectArgs(alias, settings.Columns)...)
+		queryMods = append(queryMods,
+			sm.Columns(F("COALESCE", F("JSON_ARRAYAGG", object), F("JSON_ARRAY"))),
+			sm.Where(And(where...)),
+		)
+		agg := Select(queryMods...)
+
+		return mods.Preload[*dialect.SelectQuery]{expr.OP("AS", agg, expr.Quote(alias))},
+			internal.JSONPreloadMapper[T, Ts](rel.Name, alias, settings.ExtraLoader),
+			[]bob.Loader{settings.ExtraLoader}
+	}
+}
+
+// jsonObjectArgs returns the key/value pairs of a JSON object with the given columns
+func jsonObjectArgs(alias string, cols []string) []any {
+	args := make([]any, 0, len(cols)*2)
+	for _, col := range cols {
+		args = append(args, S(col), Quote(alias, col))
+	}
+
+	return args
+}
+
 func buildPreloader[T any](f func(context.Context) (string, mods.QueryMods[*dialect.SelectQuery]), name string, opt PreloadSettings) Preloader {
 	return func(ctx context.Context) (bob.Mod[*dialect.SelectQuery], scan.MapperMod, []bob.Loader) {
 		alias, queryMods := f(ctx)
@@ -122,7 +203,7 @@ func buildPreloader[T any](f func(context.Context) (string, mods.QueryMods[*dial
 		}
 
 		return queryMods, func(ctx context.Context, cols []string) (scan.BeforeFunc, scan.AfterMod) {
-			before, after := scan.StructMapper[T](
+			before, after := orm.StructMapper[T](
 				scan.WithStructTagPrefix(prefix),
 				scan.WithTypeConverter(typeConverter{}),
 				scan.WithRowValidator(rowValidator),
@@ -0,0 +1,102 @@
+package mysql_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/mysql"
+	"github.com/stephenafamo/bob/dialect/mysql/dialect"
+	"github.com/stephenafamo/bob/dialect/mysql/dm"
+	"github.com/stephenafamo/bob/dialect/mysql/im"
+	"github.com/stephenafamo/bob/dialect/mysql/sm"
+	"github.com/stephenafamo/bob/dialect/psql"
+	psqlim "github.com/stephenafamo/bob/dialect/psql/im"
+	psqlum "github.com/stephenafamo/bob/dialect/psql/um"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+func TestMariaDB(t *testing.T) {
+	examples := testutils.Testcases{
+		"insert returning": {
+			Query: mysql.Insert(
+				im.Into("orders", "number", "total"),
+				im.Values(mysql.NextVal(mysql.Quote("order_numbers")), mysql.Arg(100)),
+				im.Returning("id", mysql.Quote("number")),
+			),
+			ExpectedSQL:  "INSERT INTO orders (`number`, `total`) VALUES (NEXTVAL(`order_numbers`), ?) RETURNING id, `number`",
+			ExpectedArgs: []any{100},
+		},
+		"delete returning": {
+			Query: mysql.Delete(
+				dm.From("orders"),
+				dm.Where(mysql.Quote("total").EQ(mysql.Arg(0))),
+				dm.Limit(10),
+				dm.Returning("*"),
+			),
+			ExpectedSQL:  "DELETE FROM orders WHERE (`total` = ?) LIMIT 10 RETURNING *",
+			ExpectedArgs: []any{0},
+		},
+		"sequences": {
+			Query: mysql.Select(
+				sm.Columns(
+					mysql.LastVal(mysql.Quote("order_numbers")),
+					mysql.SetVal(mysql.Quote("shop", "order_numbers"), mysql.Arg(1000)),
+				),
+				sm.From("DUAL"),
+			),
+			ExpectedSQL:  "SELECT LASTVAL(`order_numbers`), SETVAL(`shop`.`order_numbers`, ?) FROM DUAL",
+			ExpectedArgs: []any{1000},
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+}
+
+func TestMariaDBFeatures(t *testing.T) {
+	q := mysql.Insert(im.Into("orders"), im.Values(mysql.Arg(1)), im.Returning("id"))
+
+	mysqlServer := bob.Server{Name: bob.ServerMySQL, Version: bob.Version{Major: 8}}
+	if err := mysqlServer.Check(q); !errors.Is(err, bob.ErrUnsupported) {
+		t.Fatalf("expected RETURNING to be unsupported on MySQL, got %v", err)
+	}
+
+	oldServer := bob.Server{Name: bob.ServerMariaDB, Version: bob.Version{Major: 10, Minor: 4}}
+	if err := oldServer.Check(q); !errors.Is(err, bob.ErrUnsupported) {
+		t.Fatalf("expected INSERT RETURNING to be unsupported before MariaDB 10.5, got %v", err)
+	}
+
+	server := bob.Server{Name: bob.ServerMariaDB, Version: bob.Version{Major: 10, Minor: 5}}
+	if err := server.Check(q); err != nil {
+		t.Fatal(err)
+	}
+
+	pq := psql.Insert(psqlim.Into("orders"), psqlim.Values(psql.Arg(1)), psqlim.Returning("id"))
+	if _, err := bob.Transpile(pq, dialect.Dialect); err == nil {
+		t.Fatal("expected RETURNING not to transpile to MySQL")
+	}
+
+	sql, _, err := bob.Build(must(bob.Transpile(pq, dialect.MariaDB)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "INSERT INTO orders\nVALUES (?)\nRETURNING id\n"; sql != want {
+		t.Fatalf("got %q, want %q", sql, want)
+	}
+
+	uq := psql.Update(psqlum.Table("orders"), psqlum.SetCol("total").ToArg(1), psqlum.Returning("id"))
+	_, err = bob.Transpile(uq, dialect.MariaDB)
+	var unsupported bob.UnsupportedError
+	if !errors.As(err, &unsupported) || !reflect.DeepEqual(unsupported.Constructs, []bob.Construct{bob.ConstructUpdateReturning}) {
+		t.Fatalf("expected UPDATE RETURNING not to transpile to MariaDB, got %v", err)
+	}
+}
+
+func must(q bob.Query, err error) bob.Query {
+	if err != nil {
+		panic(err)
+	}
+
+	return q
+}
@@ -0,0 +1,103 @@
+package mysql
+
+import (
+	"context"
+	"strings"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/scan"
+)
+
+// IndexInfo is an index of a table, see [ListIndexes] and [UnusedIndexes]
+type IndexInfo struct {
+	Table string
+	Name  string
+	// The columns of the index. The parts of functional indexes are left out
+	Columns []string
+	Unique  bool
+	Primary bool
+}
+
+type indexRow struct {
+	Table   string  `db:"table"`
+	Name    string  `db:"name"`
+	Columns *string `db:"columns"`
+	Unique  bool    `db:"unique"`
+	Primary bool    `db:"primary"`
+}
+
+const indexInfoColumns = "SELECT s.table_name AS `table`, s.index_name AS `name`," +
+	" GROUP_CONCAT(s.column_name ORDER BY s.seq_in_index SEPARATOR ',') AS `columns`," +
+	" MIN(s.non_unique) = 0 AS `unique`, s.index_name = 'PRIMARY' AS `primary`" +
+	" FROM information_schema.statistics s"
+
+// ListIndexes returns the indexes of the table in the current database
+//
+//	indexes, err := mysql.ListIndexes(ctx, db, "users")
+func ListIndexes(ctx context.Context, exec bob.Executor, table string) ([]IndexInfo, error) {
+	return indexInfos(ctx, exec, RawQuery(indexInfoColumns+`
+	WHERE s.table_schema = DATABASE() AND s.table_name = ?
+	GROUP BY s.table_name, s.index_name
+	ORDER BY s.index_name`, table))
+}
+
+// UnusedIndexes returns the indexes of the current database that have not been used
+// since the server started, from the performance schema. The primary keys and unique
+// indexes are left out, since they are needed even if no query uses them
+func UnusedIndexes(ctx context.Context, exec bob.Executor) ([]IndexInfo, error) {
+	return indexInfos(ctx, exec, RawQuery(indexInfoColumns+`
+	INNER JOIN performance_schema.table_io_waits_summary_by_index_usage u
+		ON u.object_schema = s.table_schema AND u.object_name = s.table_name AND u.index_name = s.index_name
+	WHERE s.table_schema = DATABASE() AND s.non_unique = 1 AND u.count_star = 0
+	GROUP BY s.table_name, s.index_name
+	ORDER BY s.table_name, s.index_name`))
+}
+
+func indexInfos(ctx context.Context, exec bob.Executor, q bob.Query) ([]IndexInfo, error) {
+	rows, err := bob.All(ctx, exec, q, scan.StructMapper[indexRow]())
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := make([]IndexInfo, len(rows))
+	for i, row := range rows {
+		indexes[i] = IndexInfo{
+			Table:   row.Table,
+			Name:    row.Name,
+			Unique:  row.Unique,
+			Primary: row.Primary,
+		}
+		if row.Columns != nil && *row.Columns != "" {
+			indexes[i].Columns = strings.Split(*row.Columns, ",")
+		}
+	}
+
+	return indexes, nil
+}
+
+// TableSize is the size of a table on disk, see [TableSizes]
+type TableSize struct {
+	Table string `db:"table"`
+	// The estimated number of rows
+	Rows int64 `db:"rows"`
+	// The size of the data, which is the clustered index for InnoDB
+	DataBytes int64 `db:"data_bytes"`
+	// The size of the secondary indexes
+	IndexBytes int64 `db:"index_bytes"`
+	// The allocated space that is not used, which OPTIMIZE TABLE gives back
+	FreeBytes int64 `db:"free_bytes"`
+}
+
+// TableSizes returns the sizes of the tables in the current database, largest first.
+// MySQL caches these statistics for information_schema_stats_expiry seconds,
+// a day by default. Run ANALYZE TABLE to update them
+func TableSizes(ctx context.Context, exec bob.Executor) ([]TableSize, error) {
+	return bob.All(ctx, exec, RawQuery("SELECT table_name AS `table`,"+
+		" COALESCE(table_rows, 0) AS `rows`,"+
+		" COALESCE(data_length, 0) AS `data_bytes`,"+
+		" COALESCE(index_length, 0) AS `index_bytes`,"+
+		" COALESCE(data_free, 0) AS `free_bytes`"+
+		" FROM information_schema.tables"+
+		" WHERE table_schema = DATABASE() AND table_type = 'BASE TABLE'"+
+		" ORDER BY data_length + index_length DESC, table_name"), scan.StructMapper[TableSize]())
+}
@@ -0,0 +1,167 @@
+package mysql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/mysql/dialect"
+	"github.com/stephenafamo/bob/expr"
+	"github.com/stephenafamo/scan"
+)
+
+// Partition is a partition of a table, see [ListPartitions]
+type Partition struct {
+	Name string `db:"name"`
+	// The partitioning method, such as RANGE COLUMNS
+	Method string `db:"method"`
+	// The bound as MySQL writes it, e.g. '2024-02-01' for VALUES LESS THAN ('2024-02-01')
+	Description string `db:"description"`
+	// The estimated number of rows
+	Rows int64 `db:"rows"`
+}
+
+// ListPartitions returns the partitions of the table in the current database,
+// in the order of their position
+//
+//	partitions, err := mysql.ListPartitions(ctx, db, "events")
+func ListPartitions(ctx context.Context, exec bob.Executor, table string) ([]Partition, error) {
+	return bob.All(ctx, exec, RawQuery("SELECT partition_name AS `name`,"+
+		" COALESCE(partition_method, '') AS `method`,"+
+		" COALESCE(partition_description, '') AS `description`,"+
+		" COALESCE(table_rows, 0) AS `rows`"+
+		" FROM information_schema.partitions"+
+		" WHERE table_schema = DATABASE() AND table_name = ? AND partition_name IS NOT NULL"+
+		" ORDER BY partition_ordinal_position", table), scan.StructMapper[Partition]())
+}
+
+// AddPartition adds a range partition with the rows below lessThan to the table.
+// It has to be above the bounds of the other partitions, so it fails if the table
+// has a MAXVALUE partition. The bound is written as a literal, since DDL statements
+// do not take parameters
+//
+//	SQL: ALTER TABLE `events` ADD PARTITION (PARTITION `p20240101` VALUES LESS THAN ('2024-02-01'))
+//	Go: mysql.AddPartition("events", "p20240101", feb)
+func AddPartition(table, partition string, lessThan any) bob.BaseQuery[bob.Expression] {
+	return bob.BaseQuery[bob.Expression]{
+		Expression: bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+			bound, err := partitionBound(lessThan)
+			if err != nil {
+				return nil, err
+			}
+
+			w.Write([]byte("ALTER TABLE "))
+			if _, err := bob.Express(w, d, start, expr.Quote(strings.Split(table, ".")...)); err != nil {
+				return nil, err
+			}
+			w.Write([]byte(" ADD PARTITION (PARTITION "))
+			d.WriteQuoted(w, partition)
+			fmt.Fprintf(w, " VALUES LESS THAN (%s))", bound)
+
+			return nil, nil
+		}),
+		Dialect: dialect.Dialect,
+	}
+}
+
+// DropPartitions drops the partitions of the table with their rows
+//
+//	SQL: ALTER TABLE `events` DROP PARTITION `p20230101`, `p20230201`
+//	Go: mysql.DropPartitions("events", "p20230101", "p20230201")
+func DropPartitions(table string, partitions ...string) bob.BaseQuery[bob.Expression] {
+	return bob.BaseQuery[bob.Expression]{
+		Expression: bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+			if len(partitions) == 0 {
+				return nil, fmt.Errorf("drop partitions: no partitions")
+			}
+
+			w.Write([]byte("ALTER TABLE "))
+			if _, err := bob.Express(w, d, start, expr.Quote(strings.Split(table, ".")...)); err != nil {
+				return nil, err
+			}
+			w.Write([]byte(" DROP PARTITION "))
+			for i, p := range partitions {
+				if i > 0 {
+					w.Write([]byte(", "))
+				}
+				d.WriteQuoted(w, p)
+			}
+
+			return nil, nil
+		}),
+		Dialect: dialect.Dialect,
+	}
+}
+
+// ApplyPartitionPolicy adds the partitions of the policy that are missing from the table,
+// which has to be partitioned by RANGE COLUMNS on a DATE or DATETIME column, and drops
+// the expired ones. The partitions are named with the date of their start, such as p20240101.
+// MySQL cannot detach partitions, so a policy with Detach returns an error.
+//
+// It is meant to run regularly, e.g. daily, and does nothing if the partitions are up to date
+func ApplyPartitionPolicy(ctx context.Context, exec bob.Executor, table string, policy bob.PartitionPolicy, now time.Time) (bob.PartitionChanges, error) {
+	var changes bob.PartitionChanges
+	if err := policy.Validate(); err != nil {
+		return changes, err
+	}
+	if policy.Detach {
+		return changes, errors.New("partition policy: MySQL cannot detach partitions")
+	}
+
+	partitions, err := ListPartitions(ctx, exec, table)
+	if err != nil {
+		return changes, fmt.Errorf("list partitions of %s: %w", table, err)
+	}
+
+	existing := make(map[string]bool, len(partitions))
+	names := make([]string, len(partitions))
+	for i, p := range partitions {
+		existing[p.Name] = true
+		names[i] = p.Name
+	}
+
+	for _, bound := range policy.Wanted(now) {
+		if existing[bound.Name] {
+			continue
+		}
+
+		if _, err := bob.Exec(ctx, exec, AddPartition(table, bound.Name, bound.To)); err != nil {
+			return changes, fmt.Errorf("add partition %s: %w", bound.Name, err)
+		}
+		changes.Created = append(changes.Created, bound.Name)
+	}
+
+	if expired := policy.Expired(names, now); len(expired) > 0 {
+		if _, err := bob.Exec(ctx, exec, DropPartitions(table, expired...)); err != nil {
+			return changes, fmt.Errorf("drop partitions %s: %w", strings.Join(expired, ", "), err)
+		}
+		changes.Dropped = expired
+	}
+
+	return changes, nil
+}
+
+// partitionBound writes a bound of a range partition as a literal.
+// Times are written as dates, which work for DATE and DATETIME columns
+// since the partitions of a policy start at midnight
+func partitionBound(v any) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return "MAXVALUE", nil
+	case time.Time:
+		if v.Hour() == 0 && v.Minute() == 0 && v.Second() == 0 && v.Nanosecond() == 0 {
+			return "'" + v.Format("2006-01-02") + "'", nil
+		}
+		return "'" + v.Format("2006-01-02 15:04:05") + "'", nil
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'", nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(v), nil
+	default:
+		return "", fmt.Errorf("add partition: unsupported bound of type %T", v)
+	}
+}
@@ -0,0 +1,28 @@
+package mysql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stephenafamo/bob/dialect/mysql"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+func TestPartitionStatements(t *testing.T) {
+	examples := testutils.Testcases{
+		"add": {
+			Query:       mysql.AddPartition("events", "p20240101", time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)),
+			ExpectedSQL: "ALTER TABLE `events` ADD PARTITION (PARTITION `p20240101` VALUES LESS THAN ('2024-02-01'))",
+		},
+		"add maxvalue": {
+			Query:       mysql.AddPartition("events", "pmax", nil),
+			ExpectedSQL: "ALTER TABLE `events` ADD PARTITION (PARTITION `pmax` VALUES LESS THAN (MAXVALUE))",
+		},
+		"drop": {
+			Query:       mysql.DropPartitions("events", "p20230101", "p20230201"),
+			ExpectedSQL: "ALTER TABLE `events` DROP PARTITION `p20230101`, `p20230201`",
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+}
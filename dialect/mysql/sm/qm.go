@@ -1,6 +1,8 @@
 package sm
 
 import (
+	"time"
+
 	"github.com/stephenafamo/bob"
 	"github.com/stephenafamo/bob/clause"
 	"github.com/stephenafamo/bob/dialect/mysql/dialect"
@@ -59,6 +61,18 @@ func From(table any) dialect.FromChain[*dialect.SelectQuery] {
 	return dialect.From[*dialect.SelectQuery](table)
 }
 
+// AsOf reads the table of the FROM clause as it was at the given time, with the
+// system versioning of MariaDB. Use it with the queries of models, which already have a FROM clause.
+// The tables of joins are set with [dialect.JoinChain.AsOf]
+//
+//	SQL: SELECT * FROM users FOR SYSTEM_TIME AS OF TIMESTAMP ?
+//	Go: models.Users.Query(ctx, db, sm.AsOf(t))
+func AsOf(t time.Time) bob.Mod[*dialect.SelectQuery] {
+	return mods.QueryModFunc[*dialect.SelectQuery](func(q *dialect.SelectQuery) {
+		q.SetSystemTime(dialect.SystemTime(t))
+	})
+}
+
 func InnerJoin(e any) dialect.JoinChain[*dialect.SelectQuery] {
 	return dialect.InnerJoin[*dialect.SelectQuery](e)
 }
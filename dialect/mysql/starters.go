@@ -97,3 +97,27 @@ func Raw(query string, args ...any) Expression {
 func As(e Expression, alias string) bob.Expression {
 	return expr.OP("AS", e, expr.Quote(alias))
 }
+
+// NextVal returns the next value of a MariaDB sequence
+//
+//	SQL: NEXTVAL(`order_numbers`)
+//	Go: mysql.NextVal(mysql.Quote("order_numbers"))
+func NextVal(sequence bob.Expression) Expression {
+	return bmod.Raw("NEXTVAL(?)", sequence)
+}
+
+// LastVal returns the last value of a MariaDB sequence that was used in the session
+//
+//	SQL: LASTVAL(`order_numbers`)
+//	Go: mysql.LastVal(mysql.Quote("order_numbers"))
+func LastVal(sequence bob.Expression) Expression {
+	return bmod.Raw("LASTVAL(?)", sequence)
+}
+
+// SetVal sets the next value of a MariaDB sequence to be after the value
+//
+//	SQL: SETVAL(`order_numbers`, ?)
+//	Go: mysql.SetVal(mysql.Quote("order_numbers"), mysql.Arg(1000))
+func SetVal(sequence bob.Expression, value bob.Expression) Expression {
+	return bmod.Raw("SETVAL(?, ?)", sequence, value)
+}
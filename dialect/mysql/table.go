@@ -3,6 +3,7 @@ package mysql
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"reflect"
 
 	"github.com/stephenafamo/bob"
@@ -14,6 +15,7 @@ import (
 	"github.com/stephenafamo/bob/internal"
 	"github.com/stephenafamo/bob/internal/mappings"
 	"github.com/stephenafamo/bob/orm"
+	"github.com/stephenafamo/scan"
 )
 
 type setter[T any] interface {
@@ -84,6 +86,9 @@ type Table[T orm.Table, Tslice ~[]T, Tset setter[T]] struct {
 	UpdateQueryHooks orm.Hooks[*dialect.UpdateQuery, orm.SkipQueryHooksKey]
 	DeleteQueryHooks orm.Hooks[*dialect.DeleteQuery, orm.SkipQueryHooksKey]
 
+	// If set, the rows changed by Insert, Upsert, Update and Delete are published to it
+	Changes *orm.Changes
+
 	// The AUTO_INCREMENT column that we can use to retrieve values using lastInsertID
 	// If empty, there is no auto inc
 	autoIncrementColumn string
@@ -93,6 +98,50 @@ type Table[T orm.Table, Tslice ~[]T, Tset setter[T]] struct {
 
 	// save if we can retrieve or not
 	unretrievable bool
+
+	// BatchInsert makes InsertMany insert all the rows in a single query
+	// when the table has an AUTO_INCREMENT column.
+	// The IDs of the inserted rows are reconstructed from LastInsertId(),
+	// which is the ID of the first row, and RowsAffected(), and the rows
+	// are then retrieved with a follow-up SELECT.
+	//
+	// This is only correct if the server assigns consecutive IDs within a
+	// statement, i.e. auto_increment_increment is 1 and
+	// innodb_autoinc_lock_mode is 0 or 1. The settings are checked before each
+	// batch, and the rows are inserted one by one with the default of 2 (interleaved),
+	// where IDs can be non consecutive when other inserts run concurrently.
+	// Rows that set the AUTO_INCREMENT column are also inserted one by one,
+	// as are the rows of tables without an AUTO_INCREMENT column.
+	BatchInsert bool
+}
+
+type autoIncrementSettings struct {
+	LockMode  int64 `db:"lock_mode"`
+	Increment int64 `db:"increment"`
+}
+
+// canBatch reports if the IDs of the rows inserted in a single query
+// can be reconstructed from LastInsertId()
+func (t *Table[T, Tslice, Tset]) canBatch(ctx context.Context, exec bob.Executor, rows []Tset) (bool, error) {
+	if !t.BatchInsert || t.autoIncrementColumn == "" {
+		return false, nil
+	}
+
+	for _, row := range rows {
+		for _, col := range row.SetColumns() {
+			if col == t.autoIncrementColumn {
+				return false, nil
+			}
+		}
+	}
+
+	settings, err := scan.One(ctx, exec, scan.StructMapper[autoIncrementSettings](),
+		"SELECT @@innodb_autoinc_lock_mode AS lock_mode, @@auto_increment_increment AS increment")
+	if err != nil {
+		return false, err
+	}
+
+	return settings.LockMode < 2 && settings.Increment == 1, nil
 }
 
 func (t *Table[T, Tslice, Tset]) getInserted(ctx context.Context, exec bob.Executor, row Tset, result sql.Result) (T, error) {
@@ -130,6 +179,45 @@ func (t *Table[T, Tslice, Tset]) getInserted(ctx context.Context, exec bob.Execu
 	return val, nil
 }
 
+// getInsertedBatch retrieves the rows inserted by a single multi-row insert
+// by reconstructing their AUTO_INCREMENT values
+func (t *Table[T, Tslice, Tset]) getInsertedBatch(ctx context.Context, exec bob.Executor, count int, result sql.Result) (Tslice, error) {
+	firstID, err := result.LastInsertId()
+	if err != nil {
+		return nil, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return nil, err
+	}
+
+	if affected != int64(count) {
+		return nil, fmt.Errorf("inserted %d rows but %d were affected: %w", count, affected, orm.ErrCannotRetrieveRow)
+	}
+
+	ids := make([]bob.Expression, count)
+	for i := range ids {
+		ids[i] = Arg(firstID + int64(i))
+	}
+
+	q := t.Query(ctx, exec,
+		sm.Where(Quote(t.autoIncrementColumn).In(ids...)),
+		sm.OrderBy(Quote(t.autoIncrementColumn)),
+	)
+
+	inserted, err := q.All()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(inserted) != count {
+		return nil, fmt.Errorf("inserted %d rows but found %d: %w", count, len(inserted), orm.ErrCannotRetrieveRow)
+	}
+
+	return inserted, nil
+}
+
 // Insert inserts a row into the table with only the set columns in Tset
 func (t *Table[T, Tslice, Tset]) Insert(ctx context.Context, exec bob.Executor, row Tset) (T, error) {
 	slice, err := t.InsertMany(ctx, exec, row)
@@ -146,7 +234,30 @@ func (t *Table[T, Tslice, Tset]) Insert(ctx context.Context, exec bob.Executor,
 
 // InsertMany inserts rows into the table with only the set columns in Tset
 // NOTE: Because of the lack of support for RETURNING in MySQL, each row is inserted in a separate query
+// unless BatchInsert is set on the table
 func (t *Table[T, Tslice, Tset]) InsertMany(ctx context.Context, exec bob.Executor, rows ...Tset) (Tslice, error) {
+	var vals Tslice
+	err := orm.Savepoint(ctx, exec, func() error {
+		var err error
+		vals, err = t.insertMany(orm.WithoutIdentityMap(ctx), exec, rows...)
+		return err
+	})
+	if err != nil {
+		return vals, err
+	}
+
+	t.publishChanges(ctx, exec, orm.OpInsert, vals, func(i int) []string {
+		if i < len(rows) {
+			return rows[i].SetColumns()
+		}
+		return nil
+	})
+	t.Identify(ctx, vals...)
+
+	return vals, nil
+}
+
+func (t *Table[T, Tslice, Tset]) insertMany(ctx context.Context, exec bob.Executor, rows ...Tset) (Tslice, error) {
 	if len(rows) == 0 {
 		return nil, nil
 	}
@@ -158,6 +269,10 @@ func (t *Table[T, Tslice, Tset]) InsertMany(ctx context.Context, exec bob.Execut
 		return nil, err
 	}
 
+	if err = orm.ValidateInsert(ctx, rows...); err != nil {
+		return nil, err
+	}
+
 	q := Insert(
 		im.Into(t.Name(ctx), internal.FilterNonZero(t.setMapping.NonGenerated)...),
 	)
@@ -180,6 +295,34 @@ func (t *Table[T, Tslice, Tset]) InsertMany(ctx context.Context, exec bob.Execut
 		return nil, orm.ErrCannotRetrieveRow
 	}
 
+	batch, err := t.canBatch(ctx, exec, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	if batch {
+		for _, row := range rows {
+			row.InsertMod().Apply(q.Expression)
+		}
+
+		result, err := q.Exec(ctx, exec)
+		if err != nil {
+			return nil, err
+		}
+
+		inserted, err := t.getInsertedBatch(ctx, exec, len(rows), result)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = t.AfterInsertHooks.Do(ctx, exec, inserted)
+		if err != nil {
+			return nil, err
+		}
+
+		return inserted, nil
+	}
+
 	inserted := make(Tslice, len(rows))
 	for i, row := range rows {
 		q.Expression.Values.Vals = nil
@@ -206,6 +349,22 @@ func (t *Table[T, Tslice, Tset]) InsertMany(ctx context.Context, exec bob.Execut
 // if columns is nil, every non-primary-key column is updated
 // NOTE: values from the DB are not refreshed into the model
 func (t *Table[T, Tslice, Tset]) Update(ctx context.Context, exec bob.Executor, vals Tset, rows ...T) error {
+	err := orm.Savepoint(ctx, exec, func() error {
+		return t.update(ctx, exec, vals, rows...)
+	})
+	if err != nil {
+		return err
+	}
+
+	columns := vals.SetColumns()
+	t.publishChanges(ctx, exec, orm.OpUpdate, rows, func(int) []string {
+		return columns
+	})
+
+	return nil
+}
+
+func (t *Table[T, Tslice, Tset]) update(ctx context.Context, exec bob.Executor, vals Tset, rows ...T) error {
 	if len(rows) == 0 {
 		return nil
 	}
@@ -215,6 +374,10 @@ func (t *Table[T, Tslice, Tset]) Update(ctx context.Context, exec bob.Executor,
 		return err
 	}
 
+	if err = orm.ValidateUpdate(ctx, vals); err != nil {
+		return err
+	}
+
 	pkPairs := make([]bob.Expression, len(rows))
 	for i, row := range rows {
 		pkPairs[i] = row.PrimaryKeyVals()
@@ -262,6 +425,28 @@ func (t *Table[T, Tslice, Tset]) Upsert(ctx context.Context, exec bob.Executor,
 // If updateCols is nil, it updates all the columns set in Tset
 // NOTE: Because of the lack of support for RETURNING in MySQL, each row is inserted in a separate query
 func (t *Table[T, Tslice, Tset]) UpsertMany(ctx context.Context, exec bob.Executor, updateOnConflict bool, updateCols []string, rows ...Tset) (Tslice, error) {
+	var vals Tslice
+	err := orm.Savepoint(ctx, exec, func() error {
+		var err error
+		vals, err = t.upsertMany(orm.WithoutIdentityMap(ctx), exec, updateOnConflict, updateCols, rows...)
+		return err
+	})
+	if err != nil {
+		return vals, err
+	}
+
+	t.publishChanges(ctx, exec, orm.OpUpsert, vals, func(i int) []string {
+		if i < len(rows) {
+			return rows[i].SetColumns()
+		}
+		return nil
+	})
+	t.Identify(ctx, vals...)
+
+	return vals, nil
+}
+
+func (t *Table[T, Tslice, Tset]) upsertMany(ctx context.Context, exec bob.Executor, updateOnConflict bool, updateCols []string, rows ...Tset) (Tslice, error) {
 	if len(rows) == 0 {
 		return nil, nil
 	}
@@ -273,6 +458,10 @@ func (t *Table[T, Tslice, Tset]) UpsertMany(ctx context.Context, exec bob.Execut
 		return nil, err
 	}
 
+	if err = orm.ValidateInsert(ctx, rows...); err != nil {
+		return nil, err
+	}
+
 	// Just get the set columns in the first row
 	columns := rows[0].SetColumns()
 
@@ -336,6 +525,69 @@ func (t *Table[T, Tslice, Tset]) UpsertMany(ctx context.Context, exec bob.Execut
 // Deletes the given model
 // if columns is nil, every column is deleted
 func (t *Table[T, Tslice, Tset]) Delete(ctx context.Context, exec bob.Executor, rows ...T) error {
+	err := orm.Savepoint(ctx, exec, func() error {
+		return t.delete(ctx, exec, rows...)
+	})
+	if err != nil {
+		return err
+	}
+
+	t.publishChanges(ctx, exec, orm.OpDelete, rows, nil)
+	t.forget(ctx, rows)
+
+	return nil
+}
+
+// UnitInsert adds rows to insert into the table when the unit of work is flushed
+func (t *Table[T, Tslice, Tset]) UnitInsert(u *bob.UnitOfWork, rows ...Tset) *bob.Pending[Tslice] {
+	return bob.UnitInsert[T, Tslice, Tset](u, t.alias, t, rows...)
+}
+
+// UnitUpdate adds an update of the rows with vals when the unit of work is flushed
+func (t *Table[T, Tslice, Tset]) UnitUpdate(u *bob.UnitOfWork, vals Tset, rows ...T) {
+	bob.UnitUpdate[T, Tslice, Tset](u, t.alias, t, vals, rows...)
+}
+
+// UnitDelete adds rows to delete from the table when the unit of work is flushed
+func (t *Table[T, Tslice, Tset]) UnitDelete(u *bob.UnitOfWork, rows ...T) {
+	bob.UnitDelete[T, Tslice, Tset](u, t.alias, t, rows...)
+}
+
+// forget removes the rows from the identity map of the context, if it has one
+func (t *Table[T, Tslice, Tset]) forget(ctx context.Context, rows []T) {
+	identities := orm.IdentityMapFrom(ctx)
+	if identities == nil || len(t.pkIndexes) == 0 {
+		return
+	}
+
+	for _, row := range rows {
+		identities.Forget(t.alias, internal.FieldValues(row, t.pkIndexes)...)
+	}
+}
+
+// publishChanges publishes an event for each row if Changes is set
+func (t *Table[T, Tslice, Tset]) publishChanges(ctx context.Context, exec bob.Executor, op orm.Operation, rows []T, columns func(int) []string) {
+	if t.Changes == nil || len(rows) == 0 {
+		return
+	}
+
+	events := make([]orm.ChangeEvent, len(rows))
+	for i, row := range rows {
+		events[i] = orm.ChangeEvent{
+			Table:     t.alias,
+			Operation: op,
+			PK:        internal.FieldValues(row, t.pkIndexes),
+			Row:       row,
+		}
+		if columns != nil {
+			events[i].Columns = columns(i)
+		}
+	}
+
+	t.Changes.Publish(ctx, exec, events...)
+}
+
+func (t *Table[T, Tslice, Tset]) delete(ctx context.Context, exec bob.Executor, rows ...T) error {
 	if len(rows) == 0 {
 		return nil
 	}
@@ -0,0 +1,158 @@
+package mysql
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/mysql/dialect"
+)
+
+// Function is a stored function. See [CreateFunction]
+type Function struct {
+	Name string
+	// The parameters, as written in SQL, e.g. "user_id BIGINT"
+	Args []string
+	// The return type, e.g. VARCHAR(255)
+	Returns string
+	// The characteristics, such as DETERMINISTIC or READS SQL DATA.
+	// With binary logging, functions must be declared DETERMINISTIC, NO SQL or READS SQL DATA
+	Characteristics []string
+	// The body, a single statement or a BEGIN ... END block
+	Body string
+}
+
+// CreateFunction builds a CREATE FUNCTION statement.
+// The statement is sent to the server as a whole, so the semicolons of the body need
+// no DELIMITER. To write it to a script for the mysql client, use [DelimitedScript]
+//
+//	SQL: CREATE FUNCTION `full_name`(first VARCHAR(50), last VARCHAR(50)) RETURNS VARCHAR(101) DETERMINISTIC RETURN CONCAT(first, ' ', last)
+//	Go: mysql.CreateFunction(mysql.Function{Name: "full_name", Args: []string{"first VARCHAR(50)", "last VARCHAR(50)"}, Returns: "VARCHAR(101)", Characteristics: []string{"DETERMINISTIC"}, Body: "RETURN CONCAT(first, ' ', last)"})
+func CreateFunction(f Function) bob.BaseQuery[bob.Expression] {
+	return bob.BaseQuery[bob.Expression]{
+		Expression: bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+			if f.Name == "" || f.Returns == "" || f.Body == "" {
+				return nil, fmt.Errorf("create function: the name, return type and body are required")
+			}
+
+			w.Write([]byte("CREATE FUNCTION "))
+			d.WriteQuoted(w, f.Name)
+			fmt.Fprintf(w, "(%s) RETURNS %s", strings.Join(f.Args, ", "), f.Returns)
+			for _, c := range f.Characteristics {
+				w.Write([]byte(" "))
+				w.Write([]byte(c))
+			}
+			w.Write([]byte("\n"))
+			w.Write([]byte(f.Body))
+
+			return nil, nil
+		}),
+		Dialect: dialect.Dialect,
+	}
+}
+
+// DropFunction builds a DROP FUNCTION IF EXISTS statement
+//
+//	SQL: DROP FUNCTION IF EXISTS `full_name`
+//	Go: mysql.DropFunction("full_name")
+func DropFunction(name string) bob.BaseQuery[bob.Expression] {
+	return dropRoutine("FUNCTION", name)
+}
+
+// Trigger is a trigger that runs a statement for each row. See [CreateTrigger]
+type Trigger struct {
+	Name  string
+	Table string
+	// BEFORE or AFTER
+	Timing string
+	// INSERT, UPDATE or DELETE. MySQL triggers have a single event
+	Event string
+	// The body, a single statement or a BEGIN ... END block
+	Body string
+}
+
+// CreateTrigger builds a CREATE TRIGGER statement.
+// The statement is sent to the server as a whole, so the semicolons of the body need
+// no DELIMITER. To write it to a script for the mysql client, use [DelimitedScript]
+//
+//	SQL: CREATE TRIGGER `users_audit` AFTER UPDATE ON `users` FOR EACH ROW BEGIN ... END
+//	Go: mysql.CreateTrigger(mysql.Trigger{Name: "users_audit", Table: "users", Timing: "AFTER", Event: "UPDATE", Body: "BEGIN ... END"})
+func CreateTrigger(t Trigger) bob.BaseQuery[bob.Expression] {
+	return bob.BaseQuery[bob.Expression]{
+		Expression: bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+			if t.Name == "" || t.Table == "" || t.Body == "" {
+				return nil, fmt.Errorf("create trigger: the name, table and body are required")
+			}
+
+			timing, event := strings.ToUpper(t.Timing), strings.ToUpper(t.Event)
+			if timing != "BEFORE" && timing != "AFTER" {
+				return nil, fmt.Errorf("create trigger %s: invalid timing %q", t.Name, t.Timing)
+			}
+			if event != "INSERT" && event != "UPDATE" && event != "DELETE" {
+				return nil, fmt.Errorf("create trigger %s: invalid event %q", t.Name, t.Event)
+			}
+
+			w.Write([]byte("CREATE TRIGGER "))
+			d.WriteQuoted(w, t.Name)
+			fmt.Fprintf(w, " %s %s ON ", timing, event)
+			d.WriteQuoted(w, t.Table)
+			w.Write([]byte(" FOR EACH ROW\n"))
+			w.Write([]byte(t.Body))
+
+			return nil, nil
+		}),
+		Dialect: dialect.Dialect,
+	}
+}
+
+// DropTrigger builds a DROP TRIGGER IF EXISTS statement
+//
+//	SQL: DROP TRIGGER IF EXISTS `users_audit`
+//	Go: mysql.DropTrigger("users_audit")
+func DropTrigger(name string) bob.BaseQuery[bob.Expression] {
+	return dropRoutine("TRIGGER", name)
+}
+
+func dropRoutine(kind, name string) bob.BaseQuery[bob.Expression] {
+	return bob.BaseQuery[bob.Expression]{
+		Expression: bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+			fmt.Fprintf(w, "DROP %s IF EXISTS ", kind)
+			d.WriteQuoted(w, name)
+			return nil, nil
+		}),
+		Dialect: dialect.Dialect,
+	}
+}
+
+// DelimitedScript writes the queries to a script for the mysql client, with
+// DELIMITER commands around them so that the semicolons in the bodies of
+// functions and triggers do not end the statements. It is also split correctly
+// by [bob.SplitStatements]. The queries cannot have arguments
+func DelimitedScript(delimiter string, queries ...bob.Query) (string, error) {
+	if delimiter == "" || delimiter == ";" || strings.ContainsAny(delimiter, " \t\r\n") {
+		return "", fmt.Errorf("invalid delimiter %q", delimiter)
+	}
+
+	var script strings.Builder
+	fmt.Fprintf(&script, "DELIMITER %s\n", delimiter)
+
+	for _, q := range queries {
+		sql, args, err := bob.Build(q)
+		if err != nil {
+			return "", err
+		}
+		if len(args) > 0 {
+			return "", fmt.Errorf("a script cannot have arguments: %s", sql)
+		}
+		if strings.Contains(sql, delimiter) {
+			return "", fmt.Errorf("the delimiter %s occurs in the query: %s", delimiter, sql)
+		}
+
+		fmt.Fprintf(&script, "%s %s\n", strings.TrimSpace(sql), delimiter)
+	}
+
+	script.WriteString("DELIMITER ;\n")
+
+	return script.String(), nil
+}
@@ -0,0 +1,77 @@
+package mysql_test
+
+import (
+	"testing"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/mysql"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+func TestTrigger(t *testing.T) {
+	trigger := mysql.CreateTrigger(mysql.Trigger{
+		Name:   "users_audit",
+		Table:  "users",
+		Timing: "after",
+		Event:  "update",
+		Body: `BEGIN
+	INSERT INTO audit_log (user_id, changed_at) VALUES (NEW.id, NOW());
+	UPDATE stats SET updates = updates + 1;
+END`,
+	})
+	function := mysql.CreateFunction(mysql.Function{
+		Name:            "full_name",
+		Args:            []string{"first VARCHAR(50)", "last VARCHAR(50)"},
+		Returns:         "VARCHAR(101)",
+		Characteristics: []string{"DETERMINISTIC"},
+		Body:            "RETURN CONCAT(first, ' ', last)",
+	})
+
+	examples := testutils.Testcases{
+		"create trigger": {
+			Query: trigger,
+			ExpectedSQL: "CREATE TRIGGER `users_audit` AFTER UPDATE ON `users` FOR EACH ROW BEGIN " +
+				"INSERT INTO audit_log (user_id, changed_at) VALUES (NEW.id, NOW()); " +
+				"UPDATE stats SET updates = updates + 1; END",
+		},
+		"drop trigger": {
+			Query:       mysql.DropTrigger("users_audit"),
+			ExpectedSQL: "DROP TRIGGER IF EXISTS `users_audit`",
+		},
+		"create function": {
+			Query: function,
+			ExpectedSQL: "CREATE FUNCTION `full_name`(first VARCHAR(50), last VARCHAR(50)) " +
+				"RETURNS VARCHAR(101) DETERMINISTIC RETURN CONCAT(first, ' ', last)",
+		},
+		"drop function": {
+			Query:       mysql.DropFunction("full_name"),
+			ExpectedSQL: "DROP FUNCTION IF EXISTS `full_name`",
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+
+	if _, _, err := bob.Build(mysql.CreateTrigger(mysql.Trigger{Name: "t", Table: "users", Timing: "INSTEAD OF", Event: "INSERT", Body: "SET @x = 1"})); err == nil {
+		t.Fatal("expected an error for an invalid timing")
+	}
+
+	script, err := mysql.DelimitedScript("//", mysql.DropTrigger("users_audit"), trigger, function)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	statements := bob.SplitStatements(script)
+	if len(statements) != 3 {
+		t.Fatalf("got %d statements from\n%s", len(statements), script)
+	}
+	for i, q := range []bob.Query{mysql.DropTrigger("users_audit"), trigger, function} {
+		sql, _, _ := bob.Build(q)
+		if statements[i] != sql {
+			t.Fatalf("statement %d: got %q, want %q", i, statements[i], sql)
+		}
+	}
+
+	if _, err := mysql.DelimitedScript(";", trigger); err == nil {
+		t.Fatal("expected an error for the ; delimiter")
+	}
+}
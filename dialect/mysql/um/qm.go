@@ -66,6 +66,12 @@ func Where(e bob.Expression) mods.Where[*dialect.UpdateQuery] {
 	return mods.Where[*dialect.UpdateQuery]{E: e}
 }
 
+// AllRows allows the query to have no WHERE clause, which would otherwise
+// return [bob.ErrMissingWhere] to prevent changing every row by accident
+func AllRows() mods.AllRows[*dialect.UpdateQuery] {
+	return mods.AllRows[*dialect.UpdateQuery]{}
+}
+
 func OrderBy(e any) dialect.OrderBy[*dialect.UpdateQuery] {
 	return dialect.OrderBy[*dialect.UpdateQuery](func() clause.OrderDef {
 		return clause.OrderDef{
@@ -0,0 +1,115 @@
+package psql
+
+import (
+	"context"
+	"time"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/scan"
+)
+
+// RunningQuery is a query that a client backend is running, from pg_stat_activity.
+// See [RunningQueries]
+type RunningQuery struct {
+	// The process ID of the backend, for [CancelQuery] and [KillConnection]
+	PID             int64
+	User            string
+	Database        string
+	ApplicationName string
+	// The address of the client, empty for Unix sockets
+	ClientAddr string
+	// active, idle in transaction, idle in transaction (aborted) or fastpath function call
+	State string
+	// The type and name of the event the backend waits for, e.g. Lock and transactionid
+	WaitEventType string
+	WaitEvent     string
+	// The text of the query, cut at track_activity_query_size
+	Query string
+	// When the query started. For an idle transaction, this is the start of its last query
+	QueryStart time.Time
+	// When the transaction started, zero outside of a transaction
+	TransactionStart time.Time
+	// How long the query has been running
+	Duration time.Duration
+}
+
+type activityRow struct {
+	PID              int64      `db:"pid"`
+	User             string     `db:"user"`
+	Database         string     `db:"database"`
+	ApplicationName  string     `db:"application_name"`
+	ClientAddr       string     `db:"client_addr"`
+	State            string     `db:"state"`
+	WaitEventType    string     `db:"wait_event_type"`
+	WaitEvent        string     `db:"wait_event"`
+	Query            string     `db:"query"`
+	QueryStart       time.Time  `db:"query_start"`
+	TransactionStart *time.Time `db:"xact_start"`
+	Seconds          float64    `db:"seconds"`
+}
+
+// RunningQueries returns the queries of the other client backends that have been
+// running for at least minDuration, longest first. Idle connections are left out,
+// but idle transactions are included, since they hold locks and keep VACUUM from
+// removing dead rows. Users without pg_read_all_stats only see the queries of their own role
+func RunningQueries(ctx context.Context, exec bob.Executor, minDuration time.Duration) ([]RunningQuery, error) {
+	rows, err := bob.All(ctx, exec, RawQuery(`SELECT
+		pid,
+		COALESCE(usename, '') AS "user",
+		COALESCE(datname, '') AS "database",
+		application_name,
+		COALESCE(host(client_addr), '') AS client_addr,
+		state,
+		COALESCE(wait_event_type, '') AS wait_event_type,
+		COALESCE(wait_event, '') AS wait_event,
+		query,
+		query_start,
+		xact_start,
+		EXTRACT(EPOCH FROM clock_timestamp() - query_start)::float8 AS seconds
+	FROM pg_catalog.pg_stat_activity
+	WHERE backend_type = 'client backend'
+		AND pid <> pg_backend_pid()
+		AND state IS NOT NULL AND state <> 'idle'
+		AND query_start IS NOT NULL
+		AND clock_timestamp() - query_start >= ?::bigint * interval '1 microsecond'
+	ORDER BY query_start`, minDuration.Microseconds()), scan.StructMapper[activityRow]())
+	if err != nil {
+		return nil, err
+	}
+
+	queries := make([]RunningQuery, len(rows))
+	for i, row := range rows {
+		queries[i] = RunningQuery{
+			PID:             row.PID,
+			User:            row.User,
+			Database:        row.Database,
+			ApplicationName: row.ApplicationName,
+			ClientAddr:      row.ClientAddr,
+			State:           row.State,
+			WaitEventType:   row.WaitEventType,
+			WaitEvent:       row.WaitEvent,
+			Query:           row.Query,
+			QueryStart:      row.QueryStart,
+			Duration:        time.Duration(row.Seconds * float64(time.Second)),
+		}
+		if row.TransactionStart != nil {
+			queries[i].TransactionStart = *row.TransactionStart
+		}
+	}
+
+	return queries, nil
+}
+
+// CancelQuery cancels the query that the backend with the process ID is running,
+// with pg_cancel_backend. The connection stays open. It reports false if there
+// is no such backend, and fails if the user may not signal it
+func CancelQuery(ctx context.Context, exec bob.Executor, pid int64) (bool, error) {
+	return bob.One(ctx, exec, RawQuery("SELECT pg_cancel_backend(?::int)", pid), scan.SingleColumnMapper[bool])
+}
+
+// KillConnection closes the connection of the backend with the process ID, with
+// pg_terminate_backend, which rolls back its transaction. It reports false if
+// there is no such backend, and fails if the user may not signal it
+func KillConnection(ctx context.Context, exec bob.Executor, pid int64) (bool, error) {
+	return bob.One(ctx, exec, RawQuery("SELECT pg_terminate_backend(?::int)", pid), scan.SingleColumnMapper[bool])
+}
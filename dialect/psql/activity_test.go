@@ -0,0 +1,125 @@
+package psql_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/scan"
+)
+
+// activityExec records the queries, and returns its rows for them
+type activityExec struct {
+	queries []string
+	args    [][]any
+	columns []string
+	rows    [][]any
+}
+
+func (e *activityExec) ExecContext(context.Context, string, ...any) (sql.Result, error) {
+	return nil, errors.New("unexpected exec")
+}
+
+func (e *activityExec) QueryContext(_ context.Context, query string, args ...any) (scan.Rows, error) {
+	e.queries = append(e.queries, query)
+	e.args = append(e.args, args)
+	return &activityRows{columns: e.columns, rows: e.rows}, nil
+}
+
+type activityRows struct {
+	columns []string
+	rows    [][]any
+	index   int
+}
+
+func (r *activityRows) Scan(dest ...any) error {
+	for i, v := range r.rows[r.index-1] {
+		reflect.ValueOf(dest[i]).Elem().Set(reflect.ValueOf(v))
+	}
+	return nil
+}
+
+func (r *activityRows) Columns() ([]string, error) { return r.columns, nil }
+func (r *activityRows) Next() bool                 { r.index++; return r.index <= len(r.rows) }
+func (r *activityRows) Close() error               { return nil }
+func (r *activityRows) Err() error                 { return nil }
+
+func TestRunningQueries(t *testing.T) {
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	xact := start.Add(-time.Minute)
+
+	exec := &activityExec{
+		columns: []string{
+			"pid", "user", "database", "application_name", "client_addr", "state",
+			"wait_event_type", "wait_event", "query", "query_start", "xact_start", "seconds",
+		},
+		rows: [][]any{
+			{int64(101), "app", "shop", "api", "10.0.0.5", "active", "Lock", "transactionid", "UPDATE orders SET paid = true", start, &xact, 2.5},
+			{int64(102), "app", "shop", "", "", "idle in transaction", "", "", "SELECT 1", start, (*time.Time)(nil), 1.5},
+		},
+	}
+
+	queries, err := psql.RunningQueries(context.Background(), exec, 1500*time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, part := range []string{
+		"FROM pg_catalog.pg_stat_activity",
+		"WHERE backend_type = 'client backend'",
+		"AND pid <> pg_backend_pid()",
+		"AND state IS NOT NULL AND state <> 'idle'",
+		"AND clock_timestamp() - query_start >= $1::bigint * interval '1 microsecond'",
+		"ORDER BY query_start",
+	} {
+		if !strings.Contains(exec.queries[0], part) {
+			t.Errorf("expected %q in the query:\n%s", part, exec.queries[0])
+		}
+	}
+	if !reflect.DeepEqual(exec.args[0], []any{int64(1500000)}) {
+		t.Errorf("expected the duration in microseconds, got %v", exec.args[0])
+	}
+
+	expected := []psql.RunningQuery{
+		{
+			PID: 101, User: "app", Database: "shop", ApplicationName: "api", ClientAddr: "10.0.0.5",
+			State: "active", WaitEventType: "Lock", WaitEvent: "transactionid",
+			Query: "UPDATE orders SET paid = true", QueryStart: start, TransactionStart: xact,
+			Duration: 2500 * time.Millisecond,
+		},
+		{
+			PID: 102, User: "app", Database: "shop", State: "idle in transaction",
+			Query: "SELECT 1", QueryStart: start, Duration: 1500 * time.Millisecond,
+		},
+	}
+	if !reflect.DeepEqual(queries, expected) {
+		t.Fatalf("got %+v, expected %+v", queries, expected)
+	}
+}
+
+func TestCancelQuery(t *testing.T) {
+	ctx := context.Background()
+	exec := &activityExec{columns: []string{"signaled"}, rows: [][]any{{true}}}
+
+	if ok, err := psql.CancelQuery(ctx, exec, 101); err != nil || !ok {
+		t.Fatalf("got %t, %v", ok, err)
+	}
+	if ok, err := psql.KillConnection(ctx, exec, 101); err != nil || !ok {
+		t.Fatalf("got %t, %v", ok, err)
+	}
+
+	expected := []string{"SELECT pg_cancel_backend($1::int)", "SELECT pg_terminate_backend($1::int)"}
+	if !reflect.DeepEqual(exec.queries, expected) {
+		t.Fatalf("got %q, expected %q", exec.queries, expected)
+	}
+	for _, args := range exec.args {
+		if !reflect.DeepEqual(args, []any{int64(101)}) {
+			t.Fatalf("expected the pid as the argument, got %v", args)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package psql
+
+import (
+	"github.com/stephenafamo/bob"
+)
+
+// SetApplicationName sets application_name until the end of the current transaction,
+// which shows in pg_stat_activity and in the logs. Use it with [bob.AttributedDB]
+//
+//	SQL: SELECT set_config('application_name', $1, true)
+//	Go: psql.SetApplicationName("billing")
+func SetApplicationName(name string) bob.Query {
+	return RawQuery("SELECT set_config('application_name', ?, true)", name)
+}
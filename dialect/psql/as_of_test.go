@@ -0,0 +1,47 @@
+package psql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/bob/dialect/psql/sm"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+func TestAsOf(t *testing.T) {
+	at := time.Date(2024, 1, 2, 4, 4, 5, 0, time.FixedZone("CET", 3600))
+
+	// AS OF SYSTEM TIME is CockroachDB syntax, which the Postgres formatter cannot parse
+	examples := testutils.Testcases{
+		"as of system time": {
+			Query: psql.Select(
+				sm.Columns("id", "name"),
+				sm.From("users"),
+				sm.AsOf(at),
+				sm.Where(psql.Quote("id").EQ(psql.Arg(1))),
+			),
+			ExpectedSQL:  `SELECT id, name FROM users AS OF SYSTEM TIME '2024-01-02 03:04:05+00:00' WHERE ("id" = $1)`,
+			ExpectedArgs: []any{1},
+		},
+		"as of system time expression": {
+			Query: psql.Select(
+				sm.From("users"),
+				sm.Where(psql.Quote("id").EQ(psql.Arg(1))),
+				sm.AsOfSystemTime(psql.F("with_max_staleness", psql.Arg("10s"))),
+				sm.Limit(psql.Arg(5)),
+			),
+			ExpectedSQL:  `SELECT * FROM users AS OF SYSTEM TIME with_max_staleness($1) WHERE ("id" = $2) LIMIT $3`,
+			ExpectedArgs: []any{"10s", 1, 5},
+		},
+		"follower read": {
+			Query: psql.Select(
+				sm.From("users"),
+				sm.FollowerRead(),
+			),
+			ExpectedSQL: `SELECT * FROM users AS OF SYSTEM TIME follower_read_timestamp()`,
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+}
@@ -0,0 +1,67 @@
+package psql_test
+
+import (
+	"testing"
+
+	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/bob/dialect/psql/dm"
+	"github.com/stephenafamo/bob/dialect/psql/im"
+	"github.com/stephenafamo/bob/dialect/psql/sm"
+	"github.com/stephenafamo/bob/dialect/psql/um"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+func TestCockroach(t *testing.T) {
+	// RETURNING NOTHING is CockroachDB syntax, which the Postgres formatter cannot parse
+	examples := testutils.Testcases{
+		"update returning nothing": {
+			Query: psql.Update(
+				um.Table("users"),
+				um.SetCol("name").ToArg("Bob"),
+				um.Where(psql.Quote("id").EQ(psql.Arg(1))),
+				um.Returning("*"),
+				um.ReturningNothing(),
+			),
+			ExpectedSQL:  `UPDATE users SET "name" = $1 WHERE ("id" = $2) RETURNING NOTHING`,
+			ExpectedArgs: []any{"Bob", 1},
+		},
+		"insert returning nothing": {
+			Query: psql.Insert(
+				im.Into("users", "name"),
+				im.Values(psql.Arg("Bob")),
+				im.ReturningNothing(),
+			),
+			ExpectedSQL:  `INSERT INTO users ("name") VALUES ($1) RETURNING NOTHING`,
+			ExpectedArgs: []any{"Bob"},
+		},
+		"delete returning nothing": {
+			Query: psql.Delete(
+				dm.From("users"),
+				dm.Where(psql.Quote("id").EQ(psql.Arg(1))),
+				dm.ReturningNothing(),
+			),
+			ExpectedSQL:  `DELETE FROM users WHERE ("id" = $1) RETURNING NOTHING`,
+			ExpectedArgs: []any{1},
+		},
+		"skip locked with arguments after it": {
+			Query: psql.Select(
+				sm.From("jobs"),
+				sm.Where(psql.Quote("status").EQ(psql.Arg("queued"))),
+				sm.Limit(psql.Arg(10)),
+				sm.ForUpdate().SkipLocked(),
+				sm.AsOfSystemTime(psql.Arg("-1s")),
+			),
+			ExpectedSQL:  `SELECT * FROM jobs AS OF SYSTEM TIME $1 WHERE ("status" = $2) LIMIT $3 FOR UPDATE SKIP LOCKED`,
+			ExpectedArgs: []any{"-1s", "queued", 10},
+		},
+		"for share of a table, no wait": {
+			Query: psql.Select(
+				sm.From("jobs"),
+				sm.ForShare("jobs").NoWait(),
+			),
+			ExpectedSQL: `SELECT * FROM jobs FOR SHARE OF jobs NOWAIT`,
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+}
@@ -0,0 +1,46 @@
+package psql
+
+import (
+	"io"
+	"strings"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql/dialect"
+)
+
+// CommentOnTable builds a COMMENT ON TABLE statement. An empty text removes the comment.
+// The comments are read by bobgen into the generated models
+//
+//	SQL: COMMENT ON TABLE "public"."users" IS 'The users of the app'
+//	Go: psql.CommentOnTable("public.users", "The users of the app")
+func CommentOnTable(table, text string) bob.BaseQuery[bob.Expression] {
+	return commentOn("TABLE", qualified(table), text)
+}
+
+// CommentOnColumn builds a COMMENT ON COLUMN statement. An empty text removes the comment
+//
+//	SQL: COMMENT ON COLUMN "public"."users"."email" IS 'Unique, in lower case'
+//	Go: psql.CommentOnColumn("public.users", "email", "Unique, in lower case")
+func CommentOnColumn(table, column, text string) bob.BaseQuery[bob.Expression] {
+	return commentOn("COLUMN", qualified(table+"."+column), text)
+}
+
+func commentOn(kind string, name bob.Expression, text string) bob.BaseQuery[bob.Expression] {
+	return bob.BaseQuery[bob.Expression]{
+		Expression: bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+			w.Write([]byte("COMMENT ON " + kind + " "))
+			if _, err := bob.Express(w, d, start, name); err != nil {
+				return nil, err
+			}
+
+			if text == "" {
+				w.Write([]byte(" IS NULL"))
+			} else {
+				w.Write([]byte(" IS '" + strings.ReplaceAll(text, "'", "''") + "'"))
+			}
+
+			return nil, nil
+		}),
+		Dialect: dialect.Dialect,
+	}
+}
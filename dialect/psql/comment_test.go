@@ -0,0 +1,27 @@
+package psql_test
+
+import (
+	"testing"
+
+	"github.com/stephenafamo/bob/dialect/psql"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+func TestCommentOn(t *testing.T) {
+	examples := testutils.Testcases{
+		"table": {
+			Query:       psql.CommentOnTable("public.users", "The users' accounts"),
+			ExpectedSQL: `COMMENT ON TABLE "public"."users" IS 'The users'' accounts'`,
+		},
+		"column": {
+			Query:       psql.CommentOnColumn("users", "email", "Unique, in lower case"),
+			ExpectedSQL: `COMMENT ON COLUMN "users"."email" IS 'Unique, in lower case'`,
+		},
+		"remove": {
+			Query:       psql.CommentOnColumn("public.users", "email", ""),
+			ExpectedSQL: `COMMENT ON COLUMN "public"."users"."email" IS NULL`,
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+}
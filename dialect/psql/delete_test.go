@@ -29,6 +29,12 @@ func TestDelete(t *testing.T) {
 			  WHERE (accounts.name = $1)
 			  AND (employees.id = accounts.sales_person)`,
 			ExpectedArgs: []any{"Acme Corporation"},
+		}, "all rows": {
+			Query: psql.Delete(
+				dm.From("films"),
+				dm.AllRows(),
+			),
+			ExpectedSQL: `DELETE FROM films`,
 		},
 	}
 
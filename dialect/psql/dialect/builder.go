@@ -52,3 +52,99 @@ func (x Expression) ILike(val bob.Expression) Expression {
 		x.Base, iLike, val,
 	}})
 }
+
+// @> val
+// Can be used with ranges, arrays, jsonb and other containers
+func (x Expression) Contains(val bob.Expression) Expression {
+	return x.OP("@>", val)
+}
+
+// <@ val
+// Can be used with ranges, arrays, jsonb and other containers
+func (x Expression) ContainedBy(val bob.Expression) Expression {
+	return x.OP("<@", val)
+}
+
+// && val
+// Can be used with ranges, arrays and geometric types
+func (x Expression) Overlaps(val bob.Expression) Expression {
+	return x.OP("&&", val)
+}
+
+// << val
+// For inet and cidr, true if the subnet is strictly contained by val
+func (x Expression) SubnetOf(val bob.Expression) Expression {
+	return x.OP("<<", val)
+}
+
+// <<= val
+// For inet and cidr, true if the subnet is contained by or equal to val
+func (x Expression) SubnetOfOrEqual(val bob.Expression) Expression {
+	return x.OP("<<=", val)
+}
+
+// >> val
+// For inet and cidr, true if the subnet strictly contains val
+func (x Expression) SupernetOf(val bob.Expression) Expression {
+	return x.OP(">>", val)
+}
+
+// >>= val
+// For inet and cidr, true if the subnet contains or is equal to val
+func (x Expression) SupernetOfOrEqual(val bob.Expression) Expression {
+	return x.OP(">>=", val)
+}
+
+// -> key
+// Gets the value of the key from an hstore or jsonb value
+func (x Expression) GetKey(key bob.Expression) Expression {
+	return x.OP("->", key)
+}
+
+// ? key
+// For hstore and jsonb, true if the key exists
+func (x Expression) HasKey(key bob.Expression) Expression {
+	return x.OP("?", key)
+}
+
+// ?& keys
+// For hstore and jsonb, true if all the keys in the array exist
+func (x Expression) HasAllKeys(keys bob.Expression) Expression {
+	return x.OP("?&", keys)
+}
+
+// ?| keys
+// For hstore and jsonb, true if any of the keys in the array exist
+func (x Expression) HasAnyKey(keys bob.Expression) Expression {
+	return x.OP("?|", keys)
+}
+
+// <-> val
+// With pgvector, the euclidean (L2) distance between the vectors
+func (x Expression) L2Distance(val bob.Expression) Expression {
+	return x.OP("<->", val)
+}
+
+// <=> val
+// With pgvector, the cosine distance between the vectors
+func (x Expression) CosineDistance(val bob.Expression) Expression {
+	return x.OP("<=>", val)
+}
+
+// <#> val
+// With pgvector, the negative inner product of the vectors
+func (x Expression) NegativeInnerProduct(val bob.Expression) Expression {
+	return x.OP("<#>", val)
+}
+
+// <+> val
+// With pgvector, the taxicab (L1) distance between the vectors
+func (x Expression) L1Distance(val bob.Expression) Expression {
+	return x.OP("<+>", val)
+}
+
+// @@ query
+// True if the tsvector matches the tsquery
+func (x Expression) Matches(query bob.Expression) Expression {
+	return x.OP("@@", query)
+}
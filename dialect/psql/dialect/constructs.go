@@ -0,0 +1,66 @@
+package dialect
+
+import "github.com/stephenafamo/bob"
+
+// Supports reports if Postgres supports the construct when transpiling queries
+func (d dialect) Supports(c bob.Construct) bool {
+	switch c {
+	case bob.ConstructOnDuplicateKey, bob.ConstructOrderedWrite,
+		bob.ConstructHints, bob.ConstructModifiers, bob.ConstructOrAction:
+		return false
+	}
+
+	return true
+}
+
+func (s SelectQuery) Constructs() []bob.Construct {
+	constructs := s.From.Constructs()
+
+	if len(s.Distinct.On) > 0 {
+		constructs = append(constructs, bob.ConstructDistinctOn)
+	}
+	if s.For.Strength != "" {
+		constructs = append(constructs, bob.ConstructLocking)
+	}
+	if s.Fetch.Count != nil {
+		constructs = append(constructs, bob.ConstructFetch)
+	}
+	if !s.AsOf.IsZero() || s.AsOfSystemTime != nil {
+		constructs = append(constructs, bob.ConstructAsOf)
+	}
+
+	return constructs
+}
+
+func (i InsertQuery) Constructs() []bob.Construct {
+	var constructs []bob.Construct
+
+	if i.Conflict.Do != "" {
+		constructs = append(constructs, bob.ConstructOnConflict)
+	}
+	if len(i.Returning.Expressions) > 0 {
+		constructs = append(constructs, bob.ConstructReturning)
+	}
+
+	return constructs
+}
+
+func (u UpdateQuery) Constructs() []bob.Construct {
+	constructs := u.From.Constructs()
+
+	if len(u.Returning.Expressions) > 0 {
+		constructs = append(constructs, bob.ConstructUpdateReturning)
+	}
+
+	return constructs
+}
+
+func (d DeleteQuery) Constructs() []bob.Construct {
+	constructs := d.From.Constructs()
+
+	if len(d.Returning.Expressions) > 0 {
+		constructs = append(constructs, bob.ConstructReturning)
+	}
+
+	return constructs
+}
@@ -19,9 +19,13 @@ type DeleteQuery struct {
 }
 
 func (d DeleteQuery) WriteSQL(w io.Writer, dl bob.Dialect, start int) ([]any, error) {
+	if err := d.Where.CheckAllRows(); err != nil {
+		return nil, err
+	}
+
 	var args []any
 
-	withArgs, err := bob.ExpressIf(w, dl, start+len(args), d.With,
+	withArgs, err := bob.ExpressIf(w, dl, start+len(args), targetWith(dl, d.With),
 		len(d.With.CTEs) > 0, "\n", "")
 	if err != nil {
 		return nil, err
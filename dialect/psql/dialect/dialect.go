@@ -3,6 +3,8 @@ package dialect
 import (
 	"io"
 	"strconv"
+
+	"github.com/stephenafamo/bob"
 )
 
 //nolint:gochecknoglobals
@@ -12,7 +14,40 @@ var (
 	doubleQuote = []byte(`"`)
 )
 
-type dialect struct{}
+//nolint:gochecknoinits
+func init() {
+	bob.RegisterDialect(bob.ServerPostgres, Dialect)
+}
+
+type dialect struct {
+	target bob.Version
+}
+
+// Target returns the dialect that writes queries for the given version of
+// Postgres and later. Syntax that the version does not have is emulated where
+// possible, such as FILTER with CASE, and is an error wrapping
+// [bob.ErrUnsupported] otherwise, such as FETCH ... WITH TIES before Postgres 13.
+// The zero version writes the syntax of the newest version
+func (d dialect) Target(v bob.Version) dialect {
+	d.target = v
+	return d
+}
+
+// TargetVersion returns the version given to [dialect.Target]
+func (d dialect) TargetVersion() bob.Version {
+	return d.target
+}
+
+// Emulates returns true for the features that are written differently
+// for the target version, so that queries using them can run on older servers
+func (d dialect) Emulates(f bob.Feature) bool {
+	switch f {
+	case FeatureFilter, FeatureMaterializedCTE:
+		return bob.TargetsBefore(d, f.Since)
+	default:
+		return false
+	}
+}
 
 func (d dialect) WriteArg(w io.Writer, position int) {
 	w.Write(dollar)
@@ -24,3 +59,22 @@ func (d dialect) WriteQuoted(w io.Writer, s string) {
 	w.Write([]byte(s))
 	w.Write(doubleQuote)
 }
+
+// MaxArgs is the largest number of args in a query in Postgres
+func (d dialect) MaxArgs() int {
+	return 65535
+}
+
+// WriteInSlice binds the slice as a single array, which pgx encodes natively.
+// With lib/pq, wrap the slice with pq.Array
+func (d dialect) WriteInSlice(w io.Writer, start int, slice any, not bool) ([]any, error) {
+	if not {
+		w.Write([]byte(" <> ALL("))
+	} else {
+		w.Write([]byte(" = ANY("))
+	}
+	d.WriteArg(w, start)
+	w.Write([]byte(")"))
+
+	return []any{slice}, nil
+}
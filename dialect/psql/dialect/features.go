@@ -0,0 +1,81 @@
+package dialect
+
+import (
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/clause"
+)
+
+// Features of the query builder that older versions of Postgres do not have.
+// Queries using them fail with [bob.ErrUnsupported] on an older server
+// once its version is known with [bob.ServerInfo].
+// A dialect targeting an older version with [dialect.Target] emulates
+// FILTER and leaves out MATERIALIZED instead
+var (
+	FeatureFilter          = bob.Feature{Name: "FILTER", Server: bob.ServerPostgres, Since: bob.Version{Major: 9, Minor: 4}}
+	FeatureMaterializedCTE = bob.Feature{Name: "MATERIALIZED", Server: bob.ServerPostgres, Since: bob.Version{Major: 12}}
+	FeatureFetchWithTies   = bob.Feature{Name: "FETCH WITH TIES", Server: bob.ServerPostgres, Since: bob.Version{Major: 13}}
+)
+
+func (s SelectQuery) RequiredFeatures() []bob.Feature {
+	features := withFeatures(s.With)
+	if s.Fetch.WithTies {
+		features = append(features, FeatureFetchWithTies)
+	}
+
+	return features
+}
+
+func (i InsertQuery) RequiredFeatures() []bob.Feature {
+	return withFeatures(i.With)
+}
+
+func (u UpdateQuery) RequiredFeatures() []bob.Feature {
+	return withFeatures(u.With)
+}
+
+func (d DeleteQuery) RequiredFeatures() []bob.Feature {
+	return withFeatures(d.With)
+}
+
+// withFeatures returns the features used by the CTEs, including their queries
+func withFeatures(with clause.With) []bob.Feature {
+	var features []bob.Feature
+	for _, cte := range with.CTEs {
+		if cte.Materialized != nil {
+			features = append(features, FeatureMaterializedCTE)
+		}
+		if r, ok := cte.Query.(bob.FeatureRequirer); ok {
+			features = append(features, r.RequiredFeatures()...)
+		}
+	}
+
+	return features
+}
+
+// targetWith leaves out MATERIALIZED and NOT MATERIALIZED for versions before
+// Postgres 12, which always materialize CTEs
+func targetWith(d bob.Dialect, with clause.With) clause.With {
+	if !bob.TargetsBefore(d, FeatureMaterializedCTE.Since) {
+		return with
+	}
+
+	ctes := make([]clause.CTE, len(with.CTEs))
+	for i, cte := range with.CTEs {
+		cte.Materialized = nil
+		ctes[i] = cte
+	}
+	with.CTEs = ctes
+
+	return with
+}
+
+// requireTarget returns an error wrapping [bob.ErrUnsupported] if the dialect
+// targets a version without the feature
+func requireTarget(d bob.Dialect, f bob.Feature) error {
+	t, ok := d.(bob.VersionTargeter)
+	if !ok || !bob.TargetsBefore(d, f.Since) {
+		return nil
+	}
+
+	return bob.Server{Name: f.Server, Version: t.TargetVersion()}.Require(f)
+}
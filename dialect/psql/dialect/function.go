@@ -1,6 +1,7 @@
 package dialect
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/stephenafamo/bob"
@@ -30,6 +31,10 @@ func (f *Function) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error
 		return nil, nil
 	}
 
+	if len(f.filter) > 0 && bob.TargetsBefore(d, FeatureFilter.Since) {
+		return f.writeFilterCase(w, d, start)
+	}
+
 	w.Write([]byte(f.name))
 	w.Write([]byte("("))
 	args, err := bob.ExpressSlice(w, d, start, f.args, "", ", ", "")
@@ -62,6 +67,37 @@ func (f *Function) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error
 	return args, nil
 }
 
+// writeFilterCase emulates FILTER for versions before Postgres 9.4,
+// e.g. count(*) FILTER (WHERE x) becomes count(CASE WHEN x THEN 1 END).
+// Aggregates ignore the NULL of the rows that do not match
+func (f *Function) writeFilterCase(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	if len(f.args) != 1 {
+		return nil, fmt.Errorf("FILTER of %s with %d arguments cannot be emulated before Postgres %s: %w",
+			f.name, len(f.args), FeatureFilter.Since, bob.ErrUnsupported)
+	}
+
+	arg := f.args[0]
+	if s, ok := arg.(string); ok && s == "*" {
+		arg = "1"
+	}
+
+	w.Write([]byte(f.name))
+	w.Write([]byte("(CASE WHEN "))
+	args, err := bob.ExpressSlice(w, d, start, f.filter, "", " AND ", "")
+	if err != nil {
+		return nil, err
+	}
+
+	w.Write([]byte(" THEN "))
+	argArgs, err := bob.Express(w, d, start+len(args), arg)
+	if err != nil {
+		return nil, err
+	}
+	w.Write([]byte(" END)"))
+
+	return append(args, argArgs...), nil
+}
+
 func (f *Function) FilterWhere(e ...any) *functionOver {
 	f.filter = append(f.filter, e...)
 
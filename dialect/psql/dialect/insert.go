@@ -21,7 +21,7 @@ type InsertQuery struct {
 func (i InsertQuery) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
 	var args []any
 
-	withArgs, err := bob.ExpressIf(w, d, start+len(args), i.With,
+	withArgs, err := bob.ExpressIf(w, d, start+len(args), targetWith(d, i.With),
 		len(i.With.CTEs) > 0, "", "\n")
 	if err != nil {
 		return nil, err
@@ -2,6 +2,7 @@ package dialect
 
 import (
 	"io"
+	"time"
 
 	"github.com/stephenafamo/bob"
 	"github.com/stephenafamo/bob/clause"
@@ -14,6 +15,11 @@ type SelectQuery struct {
 	clause.SelectList
 	Distinct
 	clause.From
+	// The time of AS OF SYSTEM TIME, as supported by CockroachDB
+	AsOf time.Time
+	// An expression for AS OF SYSTEM TIME, such as follower_read_timestamp()
+	// or an interval like '-10s'. It is used instead of AsOf
+	AsOfSystemTime any
 	clause.Where
 	clause.GroupBy
 	clause.Having
@@ -30,7 +36,7 @@ type SelectQuery struct {
 func (s SelectQuery) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
 	var args []any
 
-	withArgs, err := bob.ExpressIf(w, d, start+len(args), s.With,
+	withArgs, err := bob.ExpressIf(w, d, start+len(args), targetWith(d, s.With),
 		len(s.With.CTEs) > 0, "\n", "")
 	if err != nil {
 		return nil, err
@@ -52,12 +58,24 @@ func (s SelectQuery) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, err
 	}
 	args = append(args, selArgs...)
 
-	fromArgs, err := bob.ExpressIf(w, d, start+len(args), s.From, true, "\nFROM ", "")
+	fromArgs, err := bob.ExpressIf(w, d, start+len(args), s.From, s.From.Table != nil, "\nFROM ", "")
 	if err != nil {
 		return nil, err
 	}
 	args = append(args, fromArgs...)
 
+	if s.AsOfSystemTime != nil {
+		asOfArgs, err := bob.ExpressIf(w, d, start+len(args), s.AsOfSystemTime, true, "\nAS OF SYSTEM TIME ", "")
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, asOfArgs...)
+	} else if !s.AsOf.IsZero() {
+		w.Write([]byte("\nAS OF SYSTEM TIME '"))
+		w.Write([]byte(s.AsOf.UTC().Format("2006-01-02 15:04:05.999999-07:00")))
+		w.Write([]byte("'"))
+	}
+
 	whereArgs, err := bob.ExpressIf(w, d, start+len(args), s.Where,
 		len(s.Where.Conditions) > 0, "\n", "")
 	if err != nil {
@@ -114,6 +132,12 @@ func (s SelectQuery) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, err
 	}
 	args = append(args, offsetArgs...)
 
+	if s.Fetch.WithTies {
+		if err := requireTarget(d, FeatureFetchWithTies); err != nil {
+			return nil, err
+		}
+	}
+
 	_, err = bob.ExpressIf(w, d, start+len(args), s.Fetch,
 		s.Fetch.Count != nil, "\n", "")
 	if err != nil {
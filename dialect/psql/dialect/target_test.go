@@ -0,0 +1,101 @@
+package dialect_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/bob/dialect/psql/dialect"
+	"github.com/stephenafamo/bob/dialect/psql/sm"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+func target(major, minor int, q bob.BaseQuery[*dialect.SelectQuery]) bob.BaseQuery[*dialect.SelectQuery] {
+	q.Dialect = dialect.Dialect.Target(bob.Version{Major: major, Minor: minor})
+	return q
+}
+
+func filtered() bob.BaseQuery[*dialect.SelectQuery] {
+	return psql.Select(
+		sm.Columns(
+			psql.F("count", "*").FilterWhere(psql.Quote("active")),
+			psql.F("sum", psql.Quote("total")).FilterWhere(psql.Quote("paid"), psql.Quote("total").GT(psql.Arg(0))),
+		),
+		sm.From("orders"),
+	)
+}
+
+func materialized() bob.BaseQuery[*dialect.SelectQuery] {
+	return psql.Select(
+		sm.With("recent").NotMaterialized().As(psql.Select(sm.From("orders"), sm.Limit(10))),
+		sm.From("recent"),
+	)
+}
+
+func TestTarget(t *testing.T) {
+	examples := testutils.Testcases{
+		"filter": {
+			Query:        filtered(),
+			ExpectedSQL:  `SELECT count(*) FILTER (WHERE "active"), sum("total") FILTER (WHERE "paid" AND ("total" > $1)) FROM orders`,
+			ExpectedArgs: []any{0},
+		},
+		"filter before 9.4": {
+			Query: target(9, 3, filtered()),
+			ExpectedSQL: `SELECT count(CASE WHEN "active" THEN 1 END),
+				sum(CASE WHEN "paid" AND ("total" > $1) THEN "total" END) FROM orders`,
+			ExpectedArgs: []any{0},
+		},
+		"filter on 9.4": {
+			Query:        target(9, 4, filtered()),
+			ExpectedSQL:  `SELECT count(*) FILTER (WHERE "active"), sum("total") FILTER (WHERE "paid" AND ("total" > $1)) FROM orders`,
+			ExpectedArgs: []any{0},
+		},
+		"materialized": {
+			Query:       materialized(),
+			ExpectedSQL: `WITH recent AS NOT MATERIALIZED (SELECT * FROM orders LIMIT 10) SELECT * FROM recent`,
+		},
+		"materialized before 12": {
+			Query:       target(11, 0, materialized()),
+			ExpectedSQL: `WITH recent AS (SELECT * FROM orders LIMIT 10) SELECT * FROM recent`,
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+}
+
+func TestTargetUnsupported(t *testing.T) {
+	ties := psql.Select(
+		sm.From("scores"),
+		sm.OrderBy("points").Desc(),
+		sm.Fetch(3, true),
+	)
+
+	if _, _, err := bob.Build(target(13, 0, ties)); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err := bob.Build(target(12, 0, ties))
+	if !errors.Is(err, bob.ErrUnsupported) {
+		t.Fatalf("expected ErrUnsupported, got %v", err)
+	}
+
+	twoArgs := psql.Select(sm.Columns(psql.F("string_agg", psql.Quote("name"), psql.S(",")).FilterWhere(psql.Quote("active"))))
+	if _, _, err := bob.Build(target(9, 3, twoArgs)); !errors.Is(err, bob.ErrUnsupported) {
+		t.Fatalf("expected ErrUnsupported, got %v", err)
+	}
+}
+
+func TestTargetRequiredFeatures(t *testing.T) {
+	q := materialized()
+	if got, want := q.RequiredFeatures(), []bob.Feature{dialect.FeatureMaterializedCTE}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	// Left out for an older target, so it can run on an older server
+	if got := target(11, 0, q).RequiredFeatures(); len(got) != 0 {
+		t.Fatalf("expected no required features, got %v", got)
+	}
+
+}
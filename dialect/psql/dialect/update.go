@@ -20,9 +20,13 @@ type UpdateQuery struct {
 }
 
 func (u UpdateQuery) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	if err := u.Where.CheckAllRows(); err != nil {
+		return nil, err
+	}
+
 	var args []any
 
-	withArgs, err := bob.ExpressIf(w, d, start+len(args), u.With,
+	withArgs, err := bob.ExpressIf(w, d, start+len(args), targetWith(d, u.With),
 		len(u.With.CTEs) > 0, "\n", "")
 	if err != nil {
 		return nil, err
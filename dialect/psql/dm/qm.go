@@ -66,6 +66,20 @@ func Where(e bob.Expression) mods.Where[*dialect.DeleteQuery] {
 	return mods.Where[*dialect.DeleteQuery]{E: e}
 }
 
+// AllRows allows the query to have no WHERE clause, which would otherwise
+// return [bob.ErrMissingWhere] to prevent changing every row by accident
+func AllRows() mods.AllRows[*dialect.DeleteQuery] {
+	return mods.AllRows[*dialect.DeleteQuery]{}
+}
+
 func Returning(clauses ...any) bob.Mod[*dialect.DeleteQuery] {
 	return mods.Returning[*dialect.DeleteQuery](clauses)
 }
+
+// ReturningNothing is RETURNING NOTHING, as supported by CockroachDB.
+// It replaces the other returned columns
+func ReturningNothing() bob.Mod[*dialect.DeleteQuery] {
+	return mods.QueryModFunc[*dialect.DeleteQuery](func(q *dialect.DeleteQuery) {
+		q.Returning.Expressions = []any{"NOTHING"}
+	})
+}
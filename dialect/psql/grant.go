@@ -0,0 +1,276 @@
+package psql
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql/dialect"
+)
+
+// GrantChain is a GRANT or REVOKE statement that is being built, see [Grant] and [Revoke]
+type GrantChain struct {
+	revoke     bool
+	privileges []string
+	kind       string
+	objects    []string
+	option     bool
+	cascade    bool
+}
+
+// Grant starts a GRANT statement for the privileges, such as SELECT, INSERT or ALL
+//
+//	SQL: GRANT SELECT, INSERT ON TABLE "tenant_1"."orders" TO "tenant_1_app"
+//	Go: psql.Grant("SELECT", "INSERT").On("TABLE", "tenant_1.orders").To("tenant_1_app")
+func Grant(privileges ...string) GrantChain {
+	return GrantChain{privileges: privileges}
+}
+
+// Revoke starts a REVOKE statement for the privileges
+//
+//	SQL: REVOKE ALL ON SCHEMA "tenant_1" FROM "tenant_1_app"
+//	Go: psql.Revoke("ALL").On("SCHEMA", "tenant_1").From("tenant_1_app")
+func Revoke(privileges ...string) GrantChain {
+	return GrantChain{revoke: true, privileges: privileges}
+}
+
+// On sets the objects of the privileges. The kind is one of TABLE, SEQUENCE, DATABASE,
+// SCHEMA, FUNCTION, ALL TABLES IN SCHEMA, ALL SEQUENCES IN SCHEMA or ALL FUNCTIONS IN SCHEMA.
+// The names can be qualified with the schema
+func (g GrantChain) On(kind string, names ...string) GrantChain {
+	g.kind = strings.ToUpper(kind)
+	g.objects = names
+	return g
+}
+
+// WithGrantOption lets the roles grant the privileges to others
+func (g GrantChain) WithGrantOption() GrantChain {
+	g.option = true
+	return g
+}
+
+// Cascade also revokes the privileges that the roles granted to others
+func (g GrantChain) Cascade() GrantChain {
+	g.cascade = true
+	return g
+}
+
+// To finishes a GRANT statement with the roles that get the privileges
+func (g GrantChain) To(roles ...string) bob.BaseQuery[bob.Expression] {
+	return g.query("TO", roles)
+}
+
+// From finishes a REVOKE statement with the roles that lose the privileges
+func (g GrantChain) From(roles ...string) bob.BaseQuery[bob.Expression] {
+	return g.query("FROM", roles)
+}
+
+func (g GrantChain) query(keyword string, roles []string) bob.BaseQuery[bob.Expression] {
+	return bob.BaseQuery[bob.Expression]{
+		Expression: bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+			if err := g.check(keyword, roles); err != nil {
+				return nil, err
+			}
+
+			if g.revoke {
+				w.Write([]byte("REVOKE "))
+				if g.option {
+					w.Write([]byte("GRANT OPTION FOR "))
+				}
+			} else {
+				w.Write([]byte("GRANT "))
+			}
+
+			privileges := make([]string, len(g.privileges))
+			for i, p := range g.privileges {
+				privileges[i] = strings.ToUpper(p)
+			}
+			fmt.Fprintf(w, "%s ON %s ", strings.Join(privileges, ", "), g.kind)
+
+			for i, name := range g.objects {
+				if i > 0 {
+					w.Write([]byte(", "))
+				}
+				if _, err := bob.Express(w, d, start, qualified(name)); err != nil {
+					return nil, err
+				}
+			}
+
+			fmt.Fprintf(w, " %s ", keyword)
+			writeRoles(w, d, roles)
+
+			if g.option && !g.revoke {
+				w.Write([]byte(" WITH GRANT OPTION"))
+			}
+			if g.cascade && g.revoke {
+				w.Write([]byte(" CASCADE"))
+			}
+
+			return nil, nil
+		}),
+		Dialect: dialect.Dialect,
+	}
+}
+
+func (g GrantChain) check(keyword string, roles []string) error {
+	statement := "grant"
+	if g.revoke {
+		statement = "revoke"
+	}
+
+	switch {
+	case g.revoke && keyword == "TO":
+		return fmt.Errorf("revoke: use From instead of To")
+	case !g.revoke && keyword == "FROM":
+		return fmt.Errorf("grant: use To instead of From")
+	}
+	if len(g.privileges) == 0 || len(g.objects) == 0 || len(roles) == 0 {
+		return fmt.Errorf("%s: the privileges, objects and roles are required", statement)
+	}
+
+	for _, p := range g.privileges {
+		switch strings.ToUpper(p) {
+		case "SELECT", "INSERT", "UPDATE", "DELETE", "TRUNCATE", "REFERENCES", "TRIGGER",
+			"CREATE", "CONNECT", "TEMPORARY", "TEMP", "EXECUTE", "USAGE", "ALL", "ALL PRIVILEGES":
+		default:
+			return fmt.Errorf("%s: invalid privilege %q", statement, p)
+		}
+	}
+
+	switch g.kind {
+	case "TABLE", "SEQUENCE", "DATABASE", "SCHEMA", "FUNCTION",
+		"ALL TABLES IN SCHEMA", "ALL SEQUENCES IN SCHEMA", "ALL FUNCTIONS IN SCHEMA":
+	default:
+		return fmt.Errorf("%s: invalid object kind %q", statement, g.kind)
+	}
+
+	return nil
+}
+
+// writeRoles writes the quoted role names. PUBLIC and the CURRENT_USER,
+// CURRENT_ROLE and SESSION_USER keywords are written as they are
+func writeRoles(w io.Writer, d bob.Dialect, roles []string) {
+	for i, role := range roles {
+		if i > 0 {
+			w.Write([]byte(", "))
+		}
+
+		switch upper := strings.ToUpper(role); upper {
+		case "PUBLIC", "CURRENT_USER", "SESSION_USER", "CURRENT_ROLE":
+			w.Write([]byte(upper))
+		default:
+			d.WriteQuoted(w, role)
+		}
+	}
+}
+
+// Role is a database role, which is a user if it can log in. See [CreateRole]
+type Role struct {
+	Name string
+	// Allow the role to log in
+	Login bool
+	// The password of the role, only set if not empty
+	Password string
+	// The maximum number of connections, only set if not 0. -1 means no limit
+	ConnectionLimit int
+	// The time after which the password is no longer valid, only set if not zero
+	ValidUntil time.Time
+	// The roles the new role is a member of
+	InRoles []string
+}
+
+// CreateRole builds a CREATE ROLE statement. The password is written as a literal,
+// since DDL statements do not take parameters, so the query should not be logged
+//
+//	SQL: CREATE ROLE "tenant_1_app" WITH LOGIN PASSWORD 'secret' IN ROLE "app"
+//	Go: psql.CreateRole(psql.Role{Name: "tenant_1_app", Login: true, Password: "secret", InRoles: []string{"app"}})
+func CreateRole(r Role) bob.BaseQuery[bob.Expression] {
+	return bob.BaseQuery[bob.Expression]{
+		Expression: bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+			if r.Name == "" {
+				return nil, fmt.Errorf("create role: the name is required")
+			}
+
+			w.Write([]byte("CREATE ROLE "))
+			d.WriteQuoted(w, r.Name)
+
+			if r.Login {
+				w.Write([]byte(" WITH LOGIN"))
+			} else {
+				w.Write([]byte(" WITH NOLOGIN"))
+			}
+			if r.Password != "" {
+				w.Write([]byte(" PASSWORD '" + strings.ReplaceAll(r.Password, "'", "''") + "'"))
+			}
+			if r.ConnectionLimit != 0 {
+				w.Write([]byte(" CONNECTION LIMIT " + strconv.Itoa(r.ConnectionLimit)))
+			}
+			if !r.ValidUntil.IsZero() {
+				w.Write([]byte(" VALID UNTIL '" + r.ValidUntil.UTC().Format("2006-01-02 15:04:05-07:00") + "'"))
+			}
+			if len(r.InRoles) > 0 {
+				w.Write([]byte(" IN ROLE "))
+				writeRoles(w, d, r.InRoles)
+			}
+
+			return nil, nil
+		}),
+		Dialect: dialect.Dialect,
+	}
+}
+
+// DropRole builds a DROP ROLE IF EXISTS statement. A role that still owns objects
+// or has privileges cannot be dropped, see REASSIGN OWNED and DROP OWNED
+//
+//	SQL: DROP ROLE IF EXISTS "tenant_1_app"
+//	Go: psql.DropRole("tenant_1_app")
+func DropRole(names ...string) bob.BaseQuery[bob.Expression] {
+	return bob.BaseQuery[bob.Expression]{
+		Expression: bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+			if len(names) == 0 {
+				return nil, fmt.Errorf("drop role: no roles")
+			}
+
+			w.Write([]byte("DROP ROLE IF EXISTS "))
+			writeRoles(w, d, names)
+			return nil, nil
+		}),
+		Dialect: dialect.Dialect,
+	}
+}
+
+// GrantRole makes the members members of the role, so they get its privileges
+//
+//	SQL: GRANT "app" TO "tenant_1_app"
+//	Go: psql.GrantRole("app", "tenant_1_app")
+func GrantRole(role string, members ...string) bob.BaseQuery[bob.Expression] {
+	return roleMembership("GRANT", "TO", role, members)
+}
+
+// RevokeRole removes the members from the role
+//
+//	SQL: REVOKE "app" FROM "tenant_1_app"
+//	Go: psql.RevokeRole("app", "tenant_1_app")
+func RevokeRole(role string, members ...string) bob.BaseQuery[bob.Expression] {
+	return roleMembership("REVOKE", "FROM", role, members)
+}
+
+func roleMembership(statement, keyword, role string, members []string) bob.BaseQuery[bob.Expression] {
+	return bob.BaseQuery[bob.Expression]{
+		Expression: bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+			if role == "" || len(members) == 0 {
+				return nil, fmt.Errorf("%s role: the role and members are required", strings.ToLower(statement))
+			}
+
+			w.Write([]byte(statement + " "))
+			d.WriteQuoted(w, role)
+			w.Write([]byte(" " + keyword + " "))
+			writeRoles(w, d, members)
+			return nil, nil
+		}),
+		Dialect: dialect.Dialect,
+	}
+}
@@ -0,0 +1,73 @@
+package psql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+func TestGrant(t *testing.T) {
+	examples := testutils.Testcases{
+		"grant": {
+			Query:       psql.Grant("select", "INSERT").On("TABLE", "tenant_1.orders", "tenant_1.items").To("tenant_1_app"),
+			ExpectedSQL: `GRANT SELECT, INSERT ON TABLE "tenant_1"."orders", "tenant_1"."items" TO "tenant_1_app"`,
+		},
+		"grant all tables with grant option": {
+			Query:       psql.Grant("ALL").On("ALL TABLES IN SCHEMA", "tenant_1").WithGrantOption().To("tenant_1_owner", "public"),
+			ExpectedSQL: `GRANT ALL ON ALL TABLES IN SCHEMA "tenant_1" TO "tenant_1_owner", PUBLIC WITH GRANT OPTION`,
+		},
+		"revoke": {
+			Query:       psql.Revoke("USAGE").On("SCHEMA", "tenant_1").Cascade().From("tenant_1_app"),
+			ExpectedSQL: `REVOKE USAGE ON SCHEMA "tenant_1" FROM "tenant_1_app" CASCADE`,
+		},
+		"revoke grant option": {
+			Query:       psql.Revoke("CONNECT").On("DATABASE", "app").WithGrantOption().From("tenant_1_owner"),
+			ExpectedSQL: `REVOKE GRANT OPTION FOR CONNECT ON DATABASE "app" FROM "tenant_1_owner"`,
+		},
+		"create role": {
+			Query: psql.CreateRole(psql.Role{
+				Name:            "tenant_1_app",
+				Login:           true,
+				Password:        "it's secret",
+				ConnectionLimit: 10,
+				ValidUntil:      time.Date(2030, 1, 2, 3, 4, 5, 0, time.UTC),
+				InRoles:         []string{"app"},
+			}),
+			ExpectedSQL: `CREATE ROLE "tenant_1_app" WITH LOGIN PASSWORD 'it''s secret' CONNECTION LIMIT 10
+				VALID UNTIL '2030-01-02 03:04:05+00:00' IN ROLE "app"`,
+		},
+		"create group role": {
+			Query:       psql.CreateRole(psql.Role{Name: "tenant_1"}),
+			ExpectedSQL: `CREATE ROLE "tenant_1" WITH NOLOGIN`,
+		},
+		"drop role": {
+			Query:       psql.DropRole("tenant_1_app", "tenant_1"),
+			ExpectedSQL: `DROP ROLE IF EXISTS "tenant_1_app", "tenant_1"`,
+		},
+		"grant role": {
+			Query:       psql.GrantRole("tenant_1", "tenant_1_app"),
+			ExpectedSQL: `GRANT "tenant_1" TO "tenant_1_app"`,
+		},
+		"revoke role": {
+			Query:       psql.RevokeRole("tenant_1", "tenant_1_app"),
+			ExpectedSQL: `REVOKE "tenant_1" FROM "tenant_1_app"`,
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+
+	invalid := map[string]bob.Query{
+		"privilege": psql.Grant("SELECT; DROP TABLE users").On("TABLE", "users").To("app"),
+		"kind":      psql.Grant("SELECT").On("VIEW", "users").To("app"),
+		"no roles":  psql.Grant("SELECT").On("TABLE", "users").To(),
+		"from":      psql.Grant("SELECT").On("TABLE", "users").From("app"),
+	}
+	for name, q := range invalid {
+		if _, _, err := bob.Build(q); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
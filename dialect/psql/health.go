@@ -0,0 +1,51 @@
+package psql
+
+import (
+	"context"
+	"time"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/scan"
+)
+
+// HealthCheck checks that the Postgres server responds, and returns its version
+// and the latency of the probe. See [bob.HealthCheck]
+func HealthCheck(ctx context.Context, exec bob.Executor) (bob.Health, error) {
+	return bob.HealthCheck(ctx, exec, "SELECT current_setting('server_version')")
+}
+
+// IsPrimary reports if the Postgres server accepts writes, i.e. it is not
+// a standby in recovery. Use it as the probe of a [bob.Failover]
+func IsPrimary(ctx context.Context, exec bob.Executor) (bool, error) {
+	return scan.One(ctx, exec, scan.SingleColumnMapper[bool], "SELECT NOT pg_is_in_recovery()")
+}
+
+// ReplicationLag returns how far a standby lags behind the primary, which is
+// 0 for the primary and for a standby that has replayed all it received.
+// Use it as the Lag of a [bob.ReplicaRouter]
+func ReplicationLag(ctx context.Context, exec bob.Executor) (time.Duration, error) {
+	seconds, err := scan.One(ctx, exec, scan.SingleColumnMapper[float64], `SELECT CASE
+		WHEN NOT pg_is_in_recovery() OR pg_last_wal_receive_lsn() = pg_last_wal_replay_lsn() THEN 0
+		ELSE COALESCE(EXTRACT(EPOCH FROM now() - pg_last_xact_replay_timestamp())::float8, 0)
+	END`)
+	if err != nil {
+		return 0, err
+	}
+
+	return time.Duration(seconds * float64(time.Second)), nil
+}
+
+// CurrentLSN returns the position of the server in the write-ahead log.
+// Use it as the Token of a [bob.ReplicaRouter], with [ReplayedLSN] as CaughtUp
+func CurrentLSN(ctx context.Context, exec bob.Executor) (string, error) {
+	return scan.One(ctx, exec, scan.SingleColumnMapper[string], "SELECT pg_current_wal_lsn()::text")
+}
+
+// ReplayedLSN reports if a standby has replayed the write-ahead log up to the LSN,
+// which the primary always has. Use it as the CaughtUp of a [bob.ReplicaRouter]
+func ReplayedLSN(ctx context.Context, exec bob.Executor, lsn string) (bool, error) {
+	return scan.One(ctx, exec, scan.SingleColumnMapper[bool], `SELECT CASE
+		WHEN NOT pg_is_in_recovery() THEN true
+		ELSE COALESCE(pg_last_wal_replay_lsn() >= $1::pg_lsn, false)
+	END`, lsn)
+}
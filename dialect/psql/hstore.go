@@ -0,0 +1,13 @@
+package psql
+
+import (
+	"github.com/stephenafamo/bob/types"
+)
+
+// HStore sends the map as an hstore literal arg
+//
+//	SQL: $1::hstore
+//	Go: psql.HStore(map[string]string{"a": "b"})
+func HStore(m map[string]string) Expression {
+	return Raw("?::hstore", types.NewHStore(m))
+}
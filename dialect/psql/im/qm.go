@@ -88,6 +88,14 @@ func Returning(clauses ...any) bob.Mod[*dialect.InsertQuery] {
 	return mods.Returning[*dialect.InsertQuery](clauses)
 }
 
+// ReturningNothing is RETURNING NOTHING, as supported by CockroachDB.
+// It replaces the other returned columns
+func ReturningNothing() bob.Mod[*dialect.InsertQuery] {
+	return mods.QueryModFunc[*dialect.InsertQuery](func(q *dialect.InsertQuery) {
+		q.Returning.Expressions = []any{"NOTHING"}
+	})
+}
+
 //========================================
 // For use in ON CONFLICT DO UPDATE SET
 //========================================
@@ -0,0 +1,150 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/scan"
+)
+
+// IndexInfo is an index of a table, see [ListIndexes] and [UnusedIndexes]
+type IndexInfo struct {
+	Schema string `db:"schema"`
+	Table  string `db:"table"`
+	Name   string `db:"name"`
+	// The columns of the index. The columns of an expression index are the expressions
+	Columns pq.StringArray `db:"columns"`
+	// The CREATE INDEX statement of the index
+	Definition string `db:"definition"`
+	Unique     bool   `db:"unique"`
+	Primary    bool   `db:"primary"`
+	// The size of the index on disk
+	Bytes int64 `db:"bytes"`
+	// The number of index scans since the statistics were last reset
+	Scans int64 `db:"scans"`
+}
+
+const indexInfoColumns = `n.nspname AS "schema",
+	t.relname AS "table",
+	i.relname AS "name",
+	ARRAY(
+		SELECT pg_catalog.pg_get_indexdef(ix.indexrelid, k, true)
+		FROM generate_series(1, ix.indnkeyatts) AS k
+		ORDER BY k
+	) AS "columns",
+	pg_catalog.pg_get_indexdef(ix.indexrelid) AS "definition",
+	ix.indisunique AS "unique",
+	ix.indisprimary AS "primary",
+	pg_catalog.pg_relation_size(ix.indexrelid) AS "bytes",
+	COALESCE(s.idx_scan, 0) AS "scans"
+FROM pg_catalog.pg_index ix
+INNER JOIN pg_catalog.pg_class i ON i.oid = ix.indexrelid
+INNER JOIN pg_catalog.pg_class t ON t.oid = ix.indrelid
+INNER JOIN pg_catalog.pg_namespace n ON n.oid = t.relnamespace
+LEFT JOIN pg_catalog.pg_stat_all_indexes s ON s.indexrelid = ix.indexrelid`
+
+// ListIndexes returns the indexes of the table, which can be qualified with the schema.
+// Without a schema, the table is looked up in the search path
+//
+//	indexes, err := psql.ListIndexes(ctx, db, "public.users")
+func ListIndexes(ctx context.Context, exec bob.Executor, table string) ([]IndexInfo, error) {
+	return bob.All(ctx, exec, RawQuery(`SELECT `+indexInfoColumns+`
+	WHERE ix.indrelid = ?::regclass
+	ORDER BY "name"`, table), scan.StructMapper[IndexInfo]())
+}
+
+// UnusedIndexes returns the indexes that have not been scanned since the statistics
+// were last reset, largest first. The indexes of primary keys and unique constraints
+// are left out, since they are needed even if no query uses them.
+// The statistics are per server, so check the standbys too before dropping an index
+func UnusedIndexes(ctx context.Context, exec bob.Executor) ([]IndexInfo, error) {
+	return bob.All(ctx, exec, RawQuery(`SELECT `+indexInfoColumns+`
+	WHERE n.nspname NOT IN ('pg_catalog', 'information_schema', 'pg_toast')
+		AND COALESCE(s.idx_scan, 0) = 0
+		AND NOT ix.indisunique
+		AND NOT ix.indisprimary
+	ORDER BY "bytes" DESC, "schema", "table", "name"`), scan.StructMapper[IndexInfo]())
+}
+
+// TableSize is the size of a table on disk, see [TableSizes]
+type TableSize struct {
+	Schema string `db:"schema"`
+	Table  string `db:"table"`
+	// The estimated number of rows, as of the last VACUUM or ANALYZE
+	Rows int64 `db:"rows"`
+	// The size of the table, with its TOAST table
+	TableBytes int64 `db:"table_bytes"`
+	// The size of the indexes of the table
+	IndexBytes int64 `db:"index_bytes"`
+	// The total size of the table, its TOAST table and its indexes
+	TotalBytes int64 `db:"total_bytes"`
+}
+
+// TableSizes returns the sizes of the tables in the schemas, largest first.
+// Without schemas, the tables of all schemas except the system schemas are returned
+func TableSizes(ctx context.Context, exec bob.Executor, schemas ...string) ([]TableSize, error) {
+	return bob.All(ctx, exec, RawQuery(`SELECT
+		n.nspname AS "schema",
+		c.relname AS "table",
+		GREATEST(c.reltuples, 0)::bigint AS "rows",
+		pg_catalog.pg_table_size(c.oid) AS "table_bytes",
+		pg_catalog.pg_indexes_size(c.oid) AS "index_bytes",
+		pg_catalog.pg_total_relation_size(c.oid) AS "total_bytes"
+	FROM pg_catalog.pg_class c
+	INNER JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+	WHERE c.relkind IN ('r', 'm', 'p')
+		AND n.nspname NOT IN ('pg_catalog', 'information_schema', 'pg_toast')
+		AND (COALESCE(cardinality(?::text[]), 0) = 0 OR n.nspname = ANY (?::text[]))
+	ORDER BY "total_bytes" DESC, "schema", "table"`, pq.StringArray(schemas), pq.StringArray(schemas)),
+		scan.StructMapper[TableSize]())
+}
+
+// TableBloat is the estimated bloat of a table, see [TablesBloat]
+type TableBloat struct {
+	Schema string `db:"schema"`
+	Table  string `db:"table"`
+	// The size of the table, without its TOAST table
+	TableBytes int64 `db:"table_bytes"`
+	// The estimated space taken by dead rows and free space, that
+	// VACUUM FULL or pg_repack would give back
+	WastedBytes int64 `db:"wasted_bytes"`
+	// The number of dead rows that autovacuum has not removed yet
+	DeadRows int64 `db:"dead_rows"`
+}
+
+// TablesBloat estimates the bloat of the tables from their statistics, most wasted space first.
+// The estimate compares the pages of each table with the pages its rows would need
+// with their average width, so it is only as good as the last ANALYZE,
+// and tables with a low fillfactor look bloated. Use the pgstattuple extension for exact numbers
+func TablesBloat(ctx context.Context, exec bob.Executor) ([]TableBloat, error) {
+	return bob.All(ctx, exec, RawQuery(`WITH widths AS (
+		SELECT schemaname, tablename, SUM((1 - null_frac) * avg_width) AS width
+		FROM pg_catalog.pg_stats
+		GROUP BY schemaname, tablename
+	), tables AS (
+		SELECT
+			n.nspname AS "schema",
+			c.relname AS "table",
+			c.relpages::bigint AS pages,
+			GREATEST(c.reltuples, 0) AS tuples,
+			COALESCE(w.width, 0) AS width,
+			current_setting('block_size')::bigint AS block_size,
+			COALESCE(s.n_dead_tup, 0) AS dead_rows
+		FROM pg_catalog.pg_class c
+		INNER JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		LEFT JOIN widths w ON w.schemaname = n.nspname AND w.tablename = c.relname
+		LEFT JOIN pg_catalog.pg_stat_all_tables s ON s.relid = c.oid
+		WHERE c.relkind IN ('r', 'm')
+			AND n.nspname NOT IN ('pg_catalog', 'information_schema', 'pg_toast')
+	)
+	SELECT
+		"schema",
+		"table",
+		pages * block_size AS "table_bytes",
+		-- 24 bytes of page header, 24 bytes of tuple header and 4 bytes of line pointer
+		GREATEST(pages - CEIL(tuples * (width + 28) / (block_size - 24)), 0)::bigint * block_size AS "wasted_bytes",
+		dead_rows AS "dead_rows"
+	FROM tables
+	ORDER BY "wasted_bytes" DESC, "schema", "table"`), scan.StructMapper[TableBloat]())
+}
@@ -0,0 +1,224 @@
+package psql
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql/dialect"
+	"github.com/stephenafamo/bob/mods"
+	"github.com/stephenafamo/scan"
+)
+
+// PartitionRange is a range of values of the partition key, from From (inclusive)
+// to To (exclusive), the same as the bounds of a range partition.
+// A nil From or To leaves that side of the range open, like MINVALUE and MAXVALUE
+type PartitionRange struct {
+	From, To any
+}
+
+// InPartition filters the rows whose partition key is in the range.
+// The range is written as plain comparisons of the key, which Postgres uses
+// to skip the partitions outside of it, also when the bounds are arguments.
+// A range open on both sides adds no condition
+//
+//	SQL: "created_at" >= $1 AND "created_at" < $2
+//	Go: psql.InPartition[*dialect.SelectQuery](psql.Quote("created_at"), psql.PartitionRange{From: start, To: end})
+func InPartition[Q Filterable](key Expression, r PartitionRange) bob.Mod[Q] {
+	var preds []bob.Expression
+	if r.From != nil {
+		preds = append(preds, key.GTE(Arg(r.From)))
+	}
+	if r.To != nil {
+		preds = append(preds, key.LT(Arg(r.To)))
+	}
+
+	switch len(preds) {
+	case 0:
+		return mods.QueryMods[Q]{}
+	case 1:
+		return mods.Where[Q]{E: preds[0]}
+	default:
+		return mods.Where[Q]{E: And(preds...)}
+	}
+}
+
+// Partition is a partition of a table, see [ListPartitions]
+type Partition struct {
+	Schema string `db:"schema"`
+	Name   string `db:"name"`
+	// The bound as Postgres writes it, e.g. FOR VALUES FROM ('2024-01-01') TO ('2024-02-01')
+	Bound string `db:"bound"`
+}
+
+// ListPartitions returns the partitions of the table, ordered by name.
+// The name can be qualified with the schema
+//
+//	partitions, err := psql.ListPartitions(ctx, db, "events")
+func ListPartitions(ctx context.Context, exec bob.Executor, table string) ([]Partition, error) {
+	return bob.All(ctx, exec, RawQuery(`SELECT n.nspname AS "schema", c.relname AS "name",
+	pg_get_expr(c.relpartbound, c.oid) AS "bound"
+	FROM pg_inherits i
+	INNER JOIN pg_class c ON c.oid = i.inhrelid
+	INNER JOIN pg_namespace n ON n.oid = c.relnamespace
+	WHERE i.inhparent = ?::regclass
+	ORDER BY c.relname`, table), scan.StructMapper[Partition]())
+}
+
+// CreatePartition creates a range partition of the table, if it does not exist.
+// The bounds are written as literals, since DDL statements do not take parameters.
+// A nil bound is written as MINVALUE or MAXVALUE
+//
+//	SQL: CREATE TABLE IF NOT EXISTS "events_p20240101" PARTITION OF "events" FOR VALUES FROM ('2024-01-01 00:00:00+00:00') TO ('2024-02-01 00:00:00+00:00')
+//	Go: psql.CreatePartition("events", "events_p20240101", psql.PartitionRange{From: jan, To: feb})
+func CreatePartition(table, partition string, r PartitionRange) bob.BaseQuery[bob.Expression] {
+	return bob.BaseQuery[bob.Expression]{
+		Expression: bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+			from, err := partitionBound(r.From, "MINVALUE")
+			if err != nil {
+				return nil, err
+			}
+			to, err := partitionBound(r.To, "MAXVALUE")
+			if err != nil {
+				return nil, err
+			}
+
+			w.Write([]byte("CREATE TABLE IF NOT EXISTS "))
+			if _, err := bob.Express(w, d, start, qualified(partition)); err != nil {
+				return nil, err
+			}
+			w.Write([]byte(" PARTITION OF "))
+			if _, err := bob.Express(w, d, start, qualified(table)); err != nil {
+				return nil, err
+			}
+			fmt.Fprintf(w, " FOR VALUES FROM (%s) TO (%s)", from, to)
+
+			return nil, nil
+		}),
+		Dialect: dialect.Dialect,
+	}
+}
+
+// DetachPartition detaches the partition from the table, so that it is a table of its own.
+// With concurrently, it does not block queries on the table, but cannot run in a transaction
+//
+//	SQL: ALTER TABLE "events" DETACH PARTITION "events_p20240101"
+//	Go: psql.DetachPartition("events", "events_p20240101", false)
+func DetachPartition(table, partition string, concurrently bool) bob.BaseQuery[bob.Expression] {
+	return bob.BaseQuery[bob.Expression]{
+		Expression: bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+			w.Write([]byte("ALTER TABLE "))
+			if _, err := bob.Express(w, d, start, qualified(table)); err != nil {
+				return nil, err
+			}
+			w.Write([]byte(" DETACH PARTITION "))
+			if _, err := bob.Express(w, d, start, qualified(partition)); err != nil {
+				return nil, err
+			}
+			if concurrently {
+				w.Write([]byte(" CONCURRENTLY"))
+			}
+
+			return nil, nil
+		}),
+		Dialect: dialect.Dialect,
+	}
+}
+
+// DropPartition drops the partition with its rows
+//
+//	SQL: DROP TABLE IF EXISTS "events_p20240101"
+//	Go: psql.DropPartition("events_p20240101")
+func DropPartition(partition string) bob.BaseQuery[bob.Expression] {
+	return bob.BaseQuery[bob.Expression]{
+		Expression: bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+			w.Write([]byte("DROP TABLE IF EXISTS "))
+			return bob.Express(w, d, start, qualified(partition))
+		}),
+		Dialect: dialect.Dialect,
+	}
+}
+
+// ApplyPartitionPolicy creates the partitions of the policy that are missing from the table,
+// which has to be partitioned by RANGE on a date or timestamp column, and drops or detaches
+// the expired ones. The partitions are named after the table, such as events_p20240101,
+// in the schema of the table.
+//
+// It is meant to run regularly, e.g. daily, and does nothing if the partitions are up to date
+func ApplyPartitionPolicy(ctx context.Context, exec bob.Executor, table string, policy bob.PartitionPolicy, now time.Time) (bob.PartitionChanges, error) {
+	var changes bob.PartitionChanges
+	if err := policy.Validate(); err != nil {
+		return changes, err
+	}
+
+	partitions, err := ListPartitions(ctx, exec, table)
+	if err != nil {
+		return changes, fmt.Errorf("list partitions of %s: %w", table, err)
+	}
+
+	schema, name := "", table
+	if i := strings.LastIndex(table, "."); i >= 0 {
+		schema, name = table[:i+1], table[i+1:]
+	}
+	prefix := name + "_"
+
+	existing := make(map[string]bool, len(partitions))
+	var names []string
+	for _, p := range partitions {
+		existing[p.Name] = true
+		if strings.HasPrefix(p.Name, prefix) {
+			names = append(names, strings.TrimPrefix(p.Name, prefix))
+		}
+	}
+
+	for _, bound := range policy.Wanted(now) {
+		partition := prefix + bound.Name
+		if existing[partition] {
+			continue
+		}
+
+		q := CreatePartition(table, schema+partition, PartitionRange{From: bound.From, To: bound.To})
+		if _, err := bob.Exec(ctx, exec, q); err != nil {
+			return changes, fmt.Errorf("create partition %s: %w", partition, err)
+		}
+		changes.Created = append(changes.Created, partition)
+	}
+
+	for _, expired := range policy.Expired(names, now) {
+		partition := prefix + expired
+
+		if _, err := bob.Exec(ctx, exec, DetachPartition(table, schema+partition, false)); err != nil {
+			return changes, fmt.Errorf("detach partition %s: %w", partition, err)
+		}
+		if policy.Detach {
+			changes.Detached = append(changes.Detached, partition)
+			continue
+		}
+
+		if _, err := bob.Exec(ctx, exec, DropPartition(schema+partition)); err != nil {
+			return changes, fmt.Errorf("drop partition %s: %w", partition, err)
+		}
+		changes.Dropped = append(changes.Dropped, partition)
+	}
+
+	return changes, nil
+}
+
+// partitionBound writes a bound of a range partition as a literal
+func partitionBound(v any, open string) (string, error) {
+	switch v := v.(type) {
+	case nil:
+		return open, nil
+	case time.Time:
+		return "'" + v.Format("2006-01-02 15:04:05-07:00") + "'", nil
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'", nil
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(v), nil
+	default:
+		return "", fmt.Errorf("create partition: unsupported bound of type %T", v)
+	}
+}
@@ -0,0 +1,36 @@
+package psql_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stephenafamo/bob/dialect/psql"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+func TestPartitionStatements(t *testing.T) {
+	jan := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	feb := time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)
+
+	examples := testutils.Testcases{
+		"create": {
+			Query: psql.CreatePartition("app.events", "app.events_p20240101", psql.PartitionRange{From: jan, To: feb}),
+			ExpectedSQL: `CREATE TABLE IF NOT EXISTS "app"."events_p20240101" PARTITION OF "app"."events"
+				FOR VALUES FROM ('2024-01-01 00:00:00+00:00') TO ('2024-02-01 00:00:00+00:00')`,
+		},
+		"create open": {
+			Query:       psql.CreatePartition("measures", "measures_low", psql.PartitionRange{To: 100}),
+			ExpectedSQL: `CREATE TABLE IF NOT EXISTS "measures_low" PARTITION OF "measures" FOR VALUES FROM (MINVALUE) TO (100)`,
+		},
+		"detach": {
+			Query:       psql.DetachPartition("events", "events_p20240101", true),
+			ExpectedSQL: `ALTER TABLE "events" DETACH PARTITION "events_p20240101" CONCURRENTLY`,
+		},
+		"drop": {
+			Query:       psql.DropPartition("events_p20240101"),
+			ExpectedSQL: `DROP TABLE IF EXISTS "events_p20240101"`,
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+}
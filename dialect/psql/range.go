@@ -0,0 +1,38 @@
+package psql
+
+import (
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql/dialect"
+)
+
+// Range constructs a range of the given type with the bound inclusivity flags
+//
+//	SQL: int4range($1, $2, '[)')
+//	Go: psql.Range("int4range", psql.Arg(1), psql.Arg(10), true, false)
+func Range(typ string, lower, upper bob.Expression, lowerInc, upperInc bool) *dialect.Function {
+	bounds := []byte("()")
+	if lowerInc {
+		bounds[0] = '['
+	}
+	if upperInc {
+		bounds[1] = ']'
+	}
+
+	return F(typ, lower, upper, S(string(bounds)))
+}
+
+// Lower returns the lower bound of a range
+//
+//	SQL: lower(during)
+//	Go: psql.Lower(psql.Quote("during"))
+func Lower(exp bob.Expression) *dialect.Function {
+	return F("lower", exp)
+}
+
+// Upper returns the upper bound of a range
+//
+//	SQL: upper(during)
+//	Go: psql.Upper(psql.Quote("during"))
+func Upper(exp bob.Expression) *dialect.Function {
+	return F("upper", exp)
+}
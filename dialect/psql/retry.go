@@ -0,0 +1,36 @@
+package psql
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/stephenafamo/bob"
+)
+
+// IsConnError reports if the error means that the connection to the server was
+// lost, such as the errors of [bob.IsConnError], the errors of class 08
+// (connection exception) and the errors of a server that is shutting down
+// or still starting up. Use it with [bob.RetryReads]
+func IsConnError(err error) bool {
+	if bob.IsConnError(err) {
+		return true
+	}
+
+	// Implemented by the errors of pgx and lib/pq
+	var coded interface{ SQLState() string }
+	if !errors.As(err, &coded) {
+		return false
+	}
+
+	code := coded.SQLState()
+	switch {
+	case strings.HasPrefix(code, "08"):
+		return true
+	case code == "57P01", // admin_shutdown
+		code == "57P02", // crash_shutdown
+		code == "57P03": // cannot_connect_now
+		return true
+	default:
+		return false
+	}
+}
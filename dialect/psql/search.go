@@ -0,0 +1,73 @@
+package psql
+
+import (
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql/dialect"
+)
+
+// WeightedText is a part of a search document with its weight (A, B, C or D)
+type WeightedText struct {
+	Weight string
+	Text   bob.Expression
+}
+
+// ToTSVector converts the document to a tsvector using the given text search config
+//
+//	SQL: to_tsvector('english', body)
+//	Go: psql.ToTSVector("english", psql.Quote("body"))
+func ToTSVector(config string, doc bob.Expression) *dialect.Function {
+	return F("to_tsvector", S(config), doc)
+}
+
+// SetWeight labels the entries of the tsvector with the given weight
+//
+//	SQL: setweight(to_tsvector('english', title), 'A')
+//	Go: psql.SetWeight(psql.ToTSVector("english", psql.Quote("title")), "A")
+func SetWeight(vector bob.Expression, weight string) *dialect.Function {
+	return F("setweight", vector, S(weight))
+}
+
+// SearchVector builds a weighted tsvector from the given parts.
+// Each part is wrapped in COALESCE so that a NULL part does not
+// make the whole document NULL.
+// The result can be used to keep a tsvector column up to date in
+// inserts and updates without a trigger
+//
+//	SQL: setweight(to_tsvector('english', COALESCE(title, '')), 'A') || setweight(to_tsvector('english', COALESCE(body, '')), 'B')
+//	Go: psql.SearchVector("english",
+//		psql.WeightedText{Weight: "A", Text: psql.Quote("title")},
+//		psql.WeightedText{Weight: "B", Text: psql.Quote("body")},
+//	)
+func SearchVector(config string, parts ...WeightedText) Expression {
+	vectors := make([]bob.Expression, len(parts))
+	for i, part := range parts {
+		text := F("COALESCE", part.Text, S(""))
+		vectors[i] = SetWeight(ToTSVector(config, text), part.Weight)
+	}
+
+	return Concat(vectors...)
+}
+
+// ToTSQuery converts the query text to a tsquery
+//
+//	SQL: to_tsquery('english', $1)
+//	Go: psql.ToTSQuery("english", psql.Arg("cat & rat"))
+func ToTSQuery(config string, query bob.Expression) *dialect.Function {
+	return F("to_tsquery", S(config), query)
+}
+
+// PlainToTSQuery converts plain text to a tsquery, ignoring punctuation
+//
+//	SQL: plainto_tsquery('english', $1)
+//	Go: psql.PlainToTSQuery("english", psql.Arg("fat rats"))
+func PlainToTSQuery(config string, query bob.Expression) *dialect.Function {
+	return F("plainto_tsquery", S(config), query)
+}
+
+// WebSearchToTSQuery converts text in web search syntax to a tsquery
+//
+//	SQL: websearch_to_tsquery('english', $1)
+//	Go: psql.WebSearchToTSQuery("english", psql.Arg(`"fat rat" -cat`))
+func WebSearchToTSQuery(config string, query bob.Expression) *dialect.Function {
+	return F("websearch_to_tsquery", S(config), query)
+}
@@ -4,8 +4,10 @@ import (
 	"testing"
 
 	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/bob/dialect/psql/dialect"
 	"github.com/stephenafamo/bob/dialect/psql/sm"
 	testutils "github.com/stephenafamo/bob/test_utils"
+	"github.com/stephenafamo/bob/types"
 	pg_query "github.com/wasilibs/go-pgquery"
 )
 
@@ -21,6 +23,16 @@ func TestSelect(t *testing.T) {
 				sm.Where(psql.Quote("id").In(psql.Arg(100, 200, 300))),
 			),
 		},
+		"select in slice": {
+			ExpectedSQL:  "SELECT id, name FROM users WHERE (id = ANY($1)) AND (status <> ALL($2))",
+			ExpectedArgs: []any{[]int64{100, 200, 300}, []string{"deleted"}},
+			Query: psql.Select(
+				sm.Columns("id", "name"),
+				sm.From("users"),
+				sm.Where(psql.Quote("id").InSlice([]int64{100, 200, 300})),
+				sm.Where(psql.Quote("status").NotInSlice([]string{"deleted"})),
+			),
+		},
 		"select distinct": {
 			ExpectedSQL:  "SELECT DISTINCT id, name FROM users WHERE (id IN ($1, $2, $3))",
 			ExpectedArgs: []any{100, 200, 300},
@@ -41,6 +53,56 @@ func TestSelect(t *testing.T) {
 				sm.Where(psql.Quote("id").In(psql.Arg(100, 200, 300))),
 			),
 		},
+		"select with range": {
+			Query: psql.Select(
+				sm.Columns(psql.Upper(psql.Quote("during"))),
+				sm.From("reservations"),
+				sm.Where(psql.Quote("during").Overlaps(
+					psql.Range("tstzrange", psql.Arg("2024-01-01"), psql.Arg("2024-02-01"), true, false),
+				)),
+				sm.Where(psql.Quote("during").Contains(psql.Raw("now()"))),
+			),
+			ExpectedSQL: `SELECT upper("during") FROM reservations
+				WHERE ("during" && tstzrange($1, $2, '[)'))
+				AND ("during" @> now())`,
+			ExpectedArgs: []any{"2024-01-01", "2024-02-01"},
+		},
+		"select by subnet": {
+			Query: psql.Select(
+				sm.From("hosts"),
+				sm.Where(psql.Quote("address").SubnetOfOrEqual(psql.Arg("10.0.0.0/8"))),
+			),
+			ExpectedSQL:  `SELECT * FROM hosts WHERE ("address" <<= $1)`,
+			ExpectedArgs: []any{"10.0.0.0/8"},
+		},
+		"select with hstore": {
+			Query: psql.Select(
+				sm.Columns(psql.Quote("attributes").GetKey(psql.S("color"))),
+				sm.From("products"),
+				sm.Where(psql.Quote("attributes").HasKey(psql.S("size"))),
+				sm.Where(psql.Quote("attributes").HasAnyKey(psql.Raw("ARRAY['brand', 'maker']"))),
+			),
+			ExpectedSQL: `SELECT "attributes" -> 'color' FROM products
+				WHERE ("attributes" ? 'size')
+				AND ("attributes" ?| ARRAY['brand', 'maker'])`,
+		},
+		"select nearest neighbours": {
+			Query: psql.Select(
+				sm.From("items"),
+				sm.OrderBy(psql.Quote("embedding").L2Distance(psql.Vector([]float32{1, 2, 3}))),
+				sm.Limit(5),
+			),
+			ExpectedSQL:  `SELECT * FROM items ORDER BY "embedding" <-> $1::vector LIMIT 5`,
+			ExpectedArgs: []any{types.Vector{1, 2, 3}},
+		},
+		"full text search": {
+			Query: psql.Select(
+				sm.From("posts"),
+				sm.Where(psql.Quote("search").Matches(psql.WebSearchToTSQuery("english", psql.Arg("fat rat")))),
+			),
+			ExpectedSQL:  `SELECT * FROM posts WHERE ("search" @@ websearch_to_tsquery('english', $1))`,
+			ExpectedArgs: []any{"fat rat"},
+		},
 		"select from function": {
 			Query: psql.Select(
 				sm.From(psql.F("generate_series", 1, 3)).As("x", "p", "q", "s"),
@@ -140,6 +202,49 @@ func TestSelect(t *testing.T) {
 				sm.From("c"),
 			),
 		},
+		"select in partition": {
+			ExpectedSQL:  `SELECT id FROM events WHERE (("created_at" >= $1) AND ("created_at" < $2))`,
+			ExpectedArgs: []any{"2024-01-01", "2024-02-01"},
+			Query: psql.Select(
+				sm.Columns("id"),
+				sm.From("events"),
+				psql.InPartition[*dialect.SelectQuery](psql.Quote("created_at"), psql.PartitionRange{
+					From: "2024-01-01",
+					To:   "2024-02-01",
+				}),
+			),
+		},
+		"select in an open partition range": {
+			ExpectedSQL: `SELECT id FROM events`,
+			Query: psql.Select(
+				sm.Columns("id"),
+				sm.From("events"),
+				psql.InPartition[*dialect.SelectQuery](psql.Quote("created_at"), psql.PartitionRange{}),
+			),
+		},
+		"select next values of a sequence": {
+			ExpectedSQL:  `SELECT nextval('users_id_seq') FROM generate_series($1, $2)`,
+			ExpectedArgs: []any{1, 10},
+			Query: psql.Select(
+				sm.Columns(psql.NextVal("users_id_seq")),
+				sm.From(psql.F("generate_series", psql.Arg(1), psql.Arg(10))),
+			),
+		},
+		"select setval": {
+			ExpectedSQL:  `SELECT setval('users_id_seq', $1, $2), currval('users_id_seq')`,
+			ExpectedArgs: []any{int64(1000), false},
+			Query: psql.Select(
+				sm.Columns(psql.SetVal("users_id_seq", 1000, false), psql.CurrVal("users_id_seq")),
+			),
+		},
+		"select only the parent table": {
+			ExpectedSQL: `SELECT id FROM ONLY animals AS "a"`,
+			Query: psql.Select(
+				sm.Columns("id"),
+				sm.From("animals").As("a"),
+				sm.Only(),
+			),
+		},
 	}
 
 	testutils.RunTests(t, examples, formatter)
@@ -0,0 +1,52 @@
+package psql
+
+import (
+	"context"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql/dialect"
+	"github.com/stephenafamo/bob/dialect/psql/sm"
+	"github.com/stephenafamo/scan"
+)
+
+// NextVal advances the sequence and returns its new value.
+// The name can be qualified with the schema, and is folded to lower case
+// unless it is double-quoted, the same as in SQL
+//
+//	SQL: nextval('users_id_seq')
+//	Go: psql.NextVal("users_id_seq")
+func NextVal(sequence string) *dialect.Function {
+	return F("nextval", S(sequence))
+}
+
+// CurrVal returns the value that nextval last returned for the sequence in the current session
+//
+//	SQL: currval('users_id_seq')
+//	Go: psql.CurrVal("users_id_seq")
+func CurrVal(sequence string) *dialect.Function {
+	return F("currval", S(sequence))
+}
+
+// SetVal sets the current value of the sequence.
+// If isCalled is false, the next nextval returns the value itself instead of the one after it
+//
+//	SQL: setval('users_id_seq', $1, true)
+//	Go: psql.SetVal("users_id_seq", 1000, true)
+func SetVal(sequence string, value int64, isCalled bool) *dialect.Function {
+	return F("setval", S(sequence), Arg(value), Arg(isCalled))
+}
+
+// AllocateIDs takes n values from the sequence with a single query,
+// e.g. to assign the ids of rows before they are inserted.
+// The values are unique but, with concurrent sessions or a CACHE on the
+// sequence, not always consecutive
+func AllocateIDs(ctx context.Context, exec bob.Executor, sequence string, n int) ([]int64, error) {
+	if n < 1 {
+		return nil, nil
+	}
+
+	return bob.All(ctx, exec, Select(
+		sm.Columns(NextVal(sequence)),
+		sm.From(F("generate_series", Arg(1), Arg(n))),
+	), scan.SingleColumnMapper[int64])
+}
@@ -1,6 +1,8 @@
 package sm
 
 import (
+	"time"
+
 	"github.com/stephenafamo/bob"
 	"github.com/stephenafamo/bob/clause"
 	"github.com/stephenafamo/bob/dialect/psql/dialect"
@@ -33,6 +35,48 @@ func From(table any) dialect.FromChain[*dialect.SelectQuery] {
 	return dialect.From[*dialect.SelectQuery](table)
 }
 
+// Only adds ONLY to the table of the FROM clause, so that the rows of the tables
+// that inherit from it are left out. Use it with the queries of models, which already have a FROM clause
+//
+//	SQL: SELECT * FROM ONLY animals
+//	Go: models.Animals.Query(ctx, db, sm.Only())
+func Only() bob.Mod[*dialect.SelectQuery] {
+	return mods.QueryModFunc[*dialect.SelectQuery](func(q *dialect.SelectQuery) {
+		q.SetOnly(true)
+	})
+}
+
+// AsOf reads the data as it was at the given time with AS OF SYSTEM TIME, as supported
+// by CockroachDB. Postgres itself has no time travel queries and rejects it
+//
+//	SQL: SELECT * FROM users AS OF SYSTEM TIME '2024-01-02 03:04:05+00:00'
+//	Go: models.Users.Query(ctx, db, sm.AsOf(t))
+func AsOf(t time.Time) bob.Mod[*dialect.SelectQuery] {
+	return mods.QueryModFunc[*dialect.SelectQuery](func(q *dialect.SelectQuery) {
+		q.AsOf = t
+	})
+}
+
+// AsOfSystemTime is AS OF SYSTEM TIME with an expression instead of a fixed time,
+// as supported by CockroachDB. Arguments in the expression are numbered with the rest of the query
+//
+//	SQL: SELECT * FROM users AS OF SYSTEM TIME '-10s'
+//	Go: models.Users.Query(ctx, db, sm.AsOfSystemTime(psql.S("-10s")))
+func AsOfSystemTime(e any) bob.Mod[*dialect.SelectQuery] {
+	return mods.QueryModFunc[*dialect.SelectQuery](func(q *dialect.SelectQuery) {
+		q.AsOfSystemTime = e
+	})
+}
+
+// FollowerRead reads from the closest replica with AS OF SYSTEM TIME follower_read_timestamp(),
+// as supported by CockroachDB. The data is a few seconds old
+//
+//	SQL: SELECT * FROM users AS OF SYSTEM TIME follower_read_timestamp()
+//	Go: models.Users.Query(ctx, db, sm.FollowerRead())
+func FollowerRead() bob.Mod[*dialect.SelectQuery] {
+	return AsOfSystemTime("follower_read_timestamp()")
+}
+
 func FromFunction(funcs ...*dialect.Function) dialect.FromChain[*dialect.SelectQuery] {
 	var table any
 
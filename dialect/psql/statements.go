@@ -0,0 +1,122 @@
+package psql
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/scan"
+)
+
+// HotQuery is a statement from pg_stat_statements, see [HotQueries]
+type HotQuery struct {
+	// The name of the query the statement was matched to, empty if none matched
+	Name    string
+	QueryID int64
+	// The normalized text of the statement, with $1, $2 etc. for the constants
+	Query string
+	Calls int64
+	// The number of rows returned or affected
+	Rows      int64
+	TotalTime time.Duration
+	MeanTime  time.Duration
+	// The share of the total execution time of all the statements of the database, from 0 to 1
+	Share float64
+	// The number of shared blocks that were found in the buffer cache or read
+	SharedBlocksHit  int64
+	SharedBlocksRead int64
+}
+
+type statementRow struct {
+	QueryID          int64   `db:"queryid"`
+	Query            string  `db:"query"`
+	Calls            int64   `db:"calls"`
+	Rows             int64   `db:"rows"`
+	TotalTime        float64 `db:"total_exec_time"`
+	MeanTime         float64 `db:"mean_exec_time"`
+	Share            float64 `db:"share"`
+	SharedBlocksHit  int64   `db:"shared_blks_hit"`
+	SharedBlocksRead int64   `db:"shared_blks_read"`
+}
+
+// HotQueries returns the statements of the current database from pg_stat_statements,
+// ranked by their total execution time. A limit of 0 returns all the statements.
+//
+// The statements are matched to the names of the queries in the code by their
+// [bob.Fingerprint]. The names map the name of each query to its SQL, e.g. from
+// [QueryNames], or from [bob.Build] for the queries built with bob.
+// If several names have the same fingerprint, the first name in sorted order is used.
+// It needs the pg_stat_statements extension and Postgres 13 or later
+func HotQueries(ctx context.Context, exec bob.Executor, names map[string]string, limit int) ([]HotQuery, error) {
+	var limitArg any
+	if limit > 0 {
+		limitArg = limit
+	}
+
+	rows, err := bob.All(ctx, exec, RawQuery(`SELECT
+		COALESCE(queryid, 0) AS queryid,
+		COALESCE(query, '') AS query,
+		calls,
+		rows,
+		total_exec_time,
+		mean_exec_time,
+		COALESCE(total_exec_time / NULLIF(SUM(total_exec_time) OVER (), 0), 0) AS share,
+		shared_blks_hit,
+		shared_blks_read
+	FROM pg_stat_statements
+	WHERE dbid = (SELECT oid FROM pg_catalog.pg_database WHERE datname = current_database())
+	ORDER BY total_exec_time DESC
+	LIMIT ?::bigint`, limitArg), scan.StructMapper[statementRow]())
+	if err != nil {
+		return nil, err
+	}
+
+	byFingerprint := make(map[string]string, len(names))
+	for name, sql := range names {
+		fingerprint := statementFingerprint(sql)
+		if other, ok := byFingerprint[fingerprint]; ok && other < name {
+			continue
+		}
+		byFingerprint[fingerprint] = name
+	}
+
+	queries := make([]HotQuery, len(rows))
+	for i, row := range rows {
+		queries[i] = HotQuery{
+			Name:             byFingerprint[statementFingerprint(row.Query)],
+			QueryID:          row.QueryID,
+			Query:            row.Query,
+			Calls:            row.Calls,
+			Rows:             row.Rows,
+			TotalTime:        milliseconds(row.TotalTime),
+			MeanTime:         milliseconds(row.MeanTime),
+			Share:            row.Share,
+			SharedBlocksHit:  row.SharedBlocksHit,
+			SharedBlocksRead: row.SharedBlocksRead,
+		}
+	}
+
+	return queries, nil
+}
+
+// QueryNames returns the SQL of the named queries by name, for [HotQueries]
+func QueryNames(queries *bob.Queries) map[string]string {
+	names := make(map[string]string)
+	for _, name := range queries.Names() {
+		q, _ := queries.Get(name)
+		names[name] = q.SQL
+	}
+
+	return names
+}
+
+// statementFingerprint is the fingerprint of a statement without the trailing
+// semicolon, which pg_stat_statements keeps only if the client sent it
+func statementFingerprint(sql string) string {
+	return strings.TrimRight(bob.Fingerprint(sql), "; ")
+}
+
+func milliseconds(ms float64) time.Duration {
+	return time.Duration(ms * float64(time.Millisecond))
+}
@@ -0,0 +1,79 @@
+package psql_test
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stephenafamo/bob/dialect/psql"
+)
+
+func TestHotQueries(t *testing.T) {
+	exec := &activityExec{
+		columns: []string{
+			"queryid", "query", "calls", "rows", "total_exec_time", "mean_exec_time",
+			"share", "shared_blks_hit", "shared_blks_read",
+		},
+		rows: [][]any{
+			{int64(1), "SELECT * FROM users WHERE id = $1", int64(100), int64(100), 250.0, 2.5, 0.75, int64(900), int64(10)},
+			{int64(2), "UPDATE users SET name = $1 WHERE id = $2", int64(10), int64(10), 80.0, 8.0, 0.24, int64(50), int64(5)},
+			{int64(3), "SELECT now()", int64(1), int64(1), 1.0, 1.0, 0.01, int64(0), int64(0)},
+		},
+	}
+
+	// Both user queries have the same fingerprint
+	names := map[string]string{
+		"user_by_id":  "SELECT * FROM users WHERE id = $1;",
+		"find_user":   "select *  from users where id = 5",
+		"rename_user": "UPDATE users SET name = $1 WHERE id = $2",
+	}
+
+	for i := 0; i < 20; i++ {
+		exec.queries, exec.args = nil, nil
+
+		queries, err := psql.HotQueries(context.Background(), exec, names, 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if !strings.Contains(exec.queries[0], "ORDER BY total_exec_time DESC\n\tLIMIT $1::bigint") {
+			t.Fatalf("unexpected query:\n%s", exec.queries[0])
+		}
+		if !reflect.DeepEqual(exec.args[0], []any{10}) {
+			t.Fatalf("expected the limit as the argument, got %v", exec.args[0])
+		}
+
+		expected := []psql.HotQuery{
+			{
+				Name: "find_user", QueryID: 1, Query: "SELECT * FROM users WHERE id = $1",
+				Calls: 100, Rows: 100, TotalTime: 250 * time.Millisecond, MeanTime: 2500 * time.Microsecond,
+				Share: 0.75, SharedBlocksHit: 900, SharedBlocksRead: 10,
+			},
+			{
+				Name: "rename_user", QueryID: 2, Query: "UPDATE users SET name = $1 WHERE id = $2",
+				Calls: 10, Rows: 10, TotalTime: 80 * time.Millisecond, MeanTime: 8 * time.Millisecond,
+				Share: 0.24, SharedBlocksHit: 50, SharedBlocksRead: 5,
+			},
+			{
+				QueryID: 3, Query: "SELECT now()", Calls: 1, Rows: 1,
+				TotalTime: time.Millisecond, MeanTime: time.Millisecond, Share: 0.01,
+			},
+		}
+		if !reflect.DeepEqual(queries, expected) {
+			t.Fatalf("got %+v, expected %+v", queries, expected)
+		}
+	}
+}
+
+func TestHotQueriesWithoutLimit(t *testing.T) {
+	exec := &activityExec{}
+
+	if _, err := psql.HotQueries(context.Background(), exec, nil, 0); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(exec.args[0], []any{nil}) {
+		t.Fatalf("expected a NULL limit, got %v", exec.args[0])
+	}
+}
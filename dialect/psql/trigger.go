@@ -0,0 +1,215 @@
+package psql
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql/dialect"
+	"github.com/stephenafamo/bob/expr"
+)
+
+// Function is a function written in SQL or a procedural language, such as
+// the function of a trigger. See [CreateFunction]
+type Function struct {
+	// The name of the function, which can be qualified with the schema
+	Name string
+	// The arguments, as written in SQL, e.g. "user_id bigint"
+	Args []string
+	// The return type, e.g. trigger
+	Returns string
+	// The language of the body, plpgsql by default
+	Language string
+	// The body of the function, which is dollar-quoted
+	Body string
+	// Use CREATE OR REPLACE
+	Replace bool
+}
+
+// CreateFunction builds a CREATE FUNCTION statement.
+// The body is dollar-quoted with a tag that does not occur in it, so it is written as is
+//
+//	SQL: CREATE OR REPLACE FUNCTION "audit_users"() RETURNS trigger LANGUAGE plpgsql AS $body$ BEGIN ... END $body$
+//	Go: psql.CreateFunction(psql.Function{Name: "audit_users", Returns: "trigger", Body: "BEGIN ... END", Replace: true})
+func CreateFunction(f Function) bob.BaseQuery[bob.Expression] {
+	return bob.BaseQuery[bob.Expression]{
+		Expression: bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+			if f.Name == "" || f.Returns == "" {
+				return nil, fmt.Errorf("create function: the name and the return type are required")
+			}
+
+			language := f.Language
+			if language == "" {
+				language = "plpgsql"
+			}
+
+			w.Write([]byte("CREATE "))
+			if f.Replace {
+				w.Write([]byte("OR REPLACE "))
+			}
+			w.Write([]byte("FUNCTION "))
+			if _, err := bob.Express(w, d, start, qualified(f.Name)); err != nil {
+				return nil, err
+			}
+			fmt.Fprintf(w, "(%s) RETURNS %s LANGUAGE %s AS %s",
+				strings.Join(f.Args, ", "), f.Returns, language, DollarQuote(f.Body))
+
+			return nil, nil
+		}),
+		Dialect: dialect.Dialect,
+	}
+}
+
+// DropFunction builds a DROP FUNCTION IF EXISTS statement. The argument types are
+// only needed if there are several functions with the same name
+//
+//	SQL: DROP FUNCTION IF EXISTS "audit_users"
+//	Go: psql.DropFunction("audit_users")
+func DropFunction(name string, argTypes ...string) bob.BaseQuery[bob.Expression] {
+	return bob.BaseQuery[bob.Expression]{
+		Expression: bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+			w.Write([]byte("DROP FUNCTION IF EXISTS "))
+			if _, err := bob.Express(w, d, start, qualified(name)); err != nil {
+				return nil, err
+			}
+			if len(argTypes) > 0 {
+				fmt.Fprintf(w, "(%s)", strings.Join(argTypes, ", "))
+			}
+
+			return nil, nil
+		}),
+		Dialect: dialect.Dialect,
+	}
+}
+
+// Trigger is a trigger that calls a function. See [CreateTrigger]
+type Trigger struct {
+	Name string
+	// The table of the trigger, which can be qualified with the schema
+	Table string
+	// BEFORE, AFTER or INSTEAD OF
+	Timing string
+	// INSERT, UPDATE, DELETE or TRUNCATE. An update of some columns is written as "UPDATE OF name, email"
+	Events []string
+	// Call the function once per statement instead of once per row
+	ForEachStatement bool
+	// The condition of the WHEN clause, as written in SQL, e.g. "OLD.* IS DISTINCT FROM NEW.*"
+	When string
+	// The name of the function, which can be qualified with the schema
+	Function string
+	// The arguments passed to the function in TG_ARGV, written as string literals
+	Args []string
+	// Use CREATE OR REPLACE, which needs Postgres 14 or later
+	Replace bool
+}
+
+// CreateTrigger builds a CREATE TRIGGER statement
+//
+//	SQL: CREATE TRIGGER "users_audit" AFTER INSERT OR UPDATE ON "users" FOR EACH ROW EXECUTE FUNCTION "audit_users"()
+//	Go: psql.CreateTrigger(psql.Trigger{Name: "users_audit", Table: "users", Timing: "AFTER", Events: []string{"INSERT", "UPDATE"}, Function: "audit_users"})
+func CreateTrigger(t Trigger) bob.BaseQuery[bob.Expression] {
+	return bob.BaseQuery[bob.Expression]{
+		Expression: bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+			if err := checkTrigger(t); err != nil {
+				return nil, err
+			}
+
+			w.Write([]byte("CREATE "))
+			if t.Replace {
+				w.Write([]byte("OR REPLACE "))
+			}
+			w.Write([]byte("TRIGGER "))
+			d.WriteQuoted(w, t.Name)
+			fmt.Fprintf(w, " %s %s ON ", strings.ToUpper(t.Timing), strings.Join(t.Events, " OR "))
+			if _, err := bob.Express(w, d, start, qualified(t.Table)); err != nil {
+				return nil, err
+			}
+
+			if t.ForEachStatement {
+				w.Write([]byte(" FOR EACH STATEMENT"))
+			} else {
+				w.Write([]byte(" FOR EACH ROW"))
+			}
+
+			if t.When != "" {
+				fmt.Fprintf(w, " WHEN (%s)", t.When)
+			}
+
+			w.Write([]byte(" EXECUTE FUNCTION "))
+			if _, err := bob.Express(w, d, start, qualified(t.Function)); err != nil {
+				return nil, err
+			}
+
+			args := make([]string, len(t.Args))
+			for i, arg := range t.Args {
+				args[i] = "'" + strings.ReplaceAll(arg, "'", "''") + "'"
+			}
+			fmt.Fprintf(w, "(%s)", strings.Join(args, ", "))
+
+			return nil, nil
+		}),
+		Dialect: dialect.Dialect,
+	}
+}
+
+func checkTrigger(t Trigger) error {
+	if t.Name == "" || t.Table == "" || t.Function == "" {
+		return fmt.Errorf("create trigger: the name, table and function are required")
+	}
+
+	switch strings.ToUpper(t.Timing) {
+	case "BEFORE", "AFTER", "INSTEAD OF":
+	default:
+		return fmt.Errorf("create trigger %s: invalid timing %q", t.Name, t.Timing)
+	}
+
+	if len(t.Events) == 0 {
+		return fmt.Errorf("create trigger %s: no events", t.Name)
+	}
+
+	for _, event := range t.Events {
+		switch word, _, _ := strings.Cut(strings.TrimSpace(event), " "); strings.ToUpper(word) {
+		case "INSERT", "UPDATE", "DELETE", "TRUNCATE":
+		default:
+			return fmt.Errorf("create trigger %s: invalid event %q", t.Name, event)
+		}
+	}
+
+	return nil
+}
+
+// DropTrigger builds a DROP TRIGGER IF EXISTS statement
+//
+//	SQL: DROP TRIGGER IF EXISTS "users_audit" ON "users"
+//	Go: psql.DropTrigger("users_audit", "users")
+func DropTrigger(name, table string) bob.BaseQuery[bob.Expression] {
+	return bob.BaseQuery[bob.Expression]{
+		Expression: bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+			w.Write([]byte("DROP TRIGGER IF EXISTS "))
+			d.WriteQuoted(w, name)
+			w.Write([]byte(" ON "))
+			return bob.Express(w, d, start, qualified(table))
+		}),
+		Dialect: dialect.Dialect,
+	}
+}
+
+// DollarQuote quotes the string with dollar quotes, using a tag that does not occur in it,
+// also not with the closing tag when the string ends with the start of the tag
+//
+//	DollarQuote("SELECT 'a'") == "$body$SELECT 'a'$body$"
+func DollarQuote(s string) string {
+	tag := "$body$"
+	for i := 1; strings.Contains(s+"$", tag); i++ {
+		tag = "$body" + strconv.Itoa(i) + "$"
+	}
+
+	return tag + s + tag
+}
+
+// qualified quotes a name that can be qualified with the schema
+func qualified(name string) bob.Expression {
+	return expr.Quote(strings.Split(name, ".")...)
+}
@@ -0,0 +1,80 @@
+package psql_test
+
+import (
+	"testing"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+func TestTrigger(t *testing.T) {
+	body := `BEGIN
+	INSERT INTO audit.log (table_name, row_data) VALUES (TG_TABLE_NAME, to_jsonb(NEW));
+	RETURN NEW;
+END`
+
+	examples := testutils.Testcases{
+		"create function": {
+			Query: psql.CreateFunction(psql.Function{
+				Name:    "audit.log_change",
+				Returns: "trigger",
+				Body:    body,
+				Replace: true,
+			}),
+			ExpectedSQL: `CREATE OR REPLACE FUNCTION "audit"."log_change"() RETURNS trigger LANGUAGE plpgsql AS $body$` + body + `$body$`,
+		},
+		"dollar quote in body": {
+			Query: psql.CreateFunction(psql.Function{
+				Name:     "add",
+				Args:     []string{"a integer", "b integer"},
+				Returns:  "integer",
+				Language: "sql",
+				Body:     "SELECT a + b -- not $body$",
+			}),
+			ExpectedSQL: `CREATE FUNCTION "add"(a integer, b integer) RETURNS integer LANGUAGE sql AS $body1$SELECT a + b -- not $body$$body1$`,
+		},
+		"drop function": {
+			Query:       psql.DropFunction("audit.log_change"),
+			ExpectedSQL: `DROP FUNCTION IF EXISTS "audit"."log_change"`,
+		},
+		"create trigger": {
+			Query: psql.CreateTrigger(psql.Trigger{
+				Name:     "users_audit",
+				Table:    "public.users",
+				Timing:   "AFTER",
+				Events:   []string{"INSERT", "UPDATE OF name, email"},
+				When:     "NEW.active",
+				Function: "audit.log_change",
+				Args:     []string{"it's"},
+			}),
+			ExpectedSQL: `CREATE TRIGGER "users_audit" AFTER INSERT OR UPDATE OF name, email ON "public"."users"
+				FOR EACH ROW WHEN (NEW.active) EXECUTE FUNCTION "audit"."log_change"('it''s')`,
+		},
+		"drop trigger": {
+			Query:       psql.DropTrigger("users_audit", "public.users"),
+			ExpectedSQL: `DROP TRIGGER IF EXISTS "users_audit" ON "public"."users"`,
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+
+	if _, _, err := bob.Build(psql.CreateTrigger(psql.Trigger{Name: "t", Table: "users", Timing: "AFTER", Events: []string{"SELECT"}, Function: "f"})); err == nil {
+		t.Fatal("expected an error for an invalid event")
+	}
+}
+
+func TestDollarQuote(t *testing.T) {
+	cases := map[string]string{
+		"SELECT 'a'":       "$body$SELECT 'a'$body$",
+		"a $body$ b":       "$body1$a $body$ b$body1$",
+		"ends with $body":  "$body1$ends with $body$body1$",
+		"ends with $body1": "$body$ends with $body1$body$",
+	}
+
+	for s, expected := range cases {
+		if got := psql.DollarQuote(s); got != expected {
+			t.Errorf("DollarQuote(%q) = %q, expected %q", s, got, expected)
+		}
+	}
+}
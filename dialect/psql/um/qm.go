@@ -91,6 +91,20 @@ func Where(e bob.Expression) mods.Where[*dialect.UpdateQuery] {
 	return mods.Where[*dialect.UpdateQuery]{E: e}
 }
 
+// AllRows allows the query to have no WHERE clause, which would otherwise
+// return [bob.ErrMissingWhere] to prevent changing every row by accident
+func AllRows() mods.AllRows[*dialect.UpdateQuery] {
+	return mods.AllRows[*dialect.UpdateQuery]{}
+}
+
 func Returning(clauses ...any) bob.Mod[*dialect.UpdateQuery] {
 	return mods.Returning[*dialect.UpdateQuery](clauses)
 }
+
+// ReturningNothing is RETURNING NOTHING, as supported by CockroachDB.
+// It replaces the other returned columns
+func ReturningNothing() bob.Mod[*dialect.UpdateQuery] {
+	return mods.QueryModFunc[*dialect.UpdateQuery](func(q *dialect.UpdateQuery) {
+		q.Returning.Expressions = []any{"NOTHING"}
+	})
+}
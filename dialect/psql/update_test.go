@@ -20,6 +20,21 @@ func TestUpdate(t *testing.T) {
 			ExpectedSQL:  `UPDATE films SET "kind" = $1 WHERE (kind = $2)`,
 			ExpectedArgs: []any{"Dramatic", "Drama"},
 		},
+		"search document": {
+			Query: psql.Update(
+				um.Table("posts"),
+				um.SetCol("search").To(psql.SearchVector("english",
+					psql.WeightedText{Weight: "A", Text: psql.Quote("title")},
+					psql.WeightedText{Weight: "B", Text: psql.Quote("body")},
+				)),
+				um.Where(psql.Quote("id").EQ(psql.Arg(1))),
+			),
+			ExpectedSQL: `UPDATE posts SET "search" =
+				setweight(to_tsvector('english', COALESCE("title", '')), 'A')
+				|| setweight(to_tsvector('english', COALESCE("body", '')), 'B')
+				WHERE ("id" = $1)`,
+			ExpectedArgs: []any{1},
+		},
 		"with from": {
 			Query: psql.Update(
 				um.Table("employees"),
@@ -0,0 +1,13 @@
+package psql
+
+import (
+	"github.com/stephenafamo/bob/types"
+)
+
+// Vector sends the values as a pgvector arg
+//
+//	SQL: $1::vector
+//	Go: psql.Vector([]float32{1, 2, 3})
+func Vector(v []float32) Expression {
+	return Raw("?::vector", types.Vector(v))
+}
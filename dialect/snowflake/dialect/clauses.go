@@ -0,0 +1,220 @@
+package dialect
+
+import (
+	"io"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/clause"
+)
+
+// FromClause is the FROM clause of Snowflake, where the table can be followed
+// by AT or BEFORE, MATCH_RECOGNIZE and SAMPLE
+type FromClause struct {
+	clause.From
+	TimeTravel     *TimeTravel
+	MatchRecognize *MatchRecognize
+	Sample         *Sample
+}
+
+func (f *FromClause) SetTimeTravel(t TimeTravel) {
+	f.TimeTravel = &t
+}
+
+func (f *FromClause) SetMatchRecognize(m MatchRecognize) {
+	f.MatchRecognize = &m
+}
+
+func (f *FromClause) SetSample(s Sample) {
+	f.Sample = &s
+}
+
+func (f FromClause) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	if f.Table == nil {
+		return nil, nil
+	}
+
+	args, err := bob.Express(w, d, start, f.Table)
+	if err != nil {
+		return nil, err
+	}
+
+	travelArgs, err := bob.ExpressIf(w, d, start+len(args), f.TimeTravel, f.TimeTravel != nil, " ", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, travelArgs...)
+
+	matchArgs, err := bob.ExpressIf(w, d, start+len(args), f.MatchRecognize, f.MatchRecognize != nil, " ", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, matchArgs...)
+
+	if f.Alias != "" {
+		w.Write([]byte(" AS "))
+		d.WriteQuoted(w, f.Alias)
+	}
+
+	sampleArgs, err := bob.ExpressIf(w, d, start+len(args), f.Sample, f.Sample != nil, " ", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, sampleArgs...)
+
+	joinArgs, err := bob.ExpressSlice(w, d, start+len(args), f.Joins, "\n", "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, joinArgs...)
+
+	return args, nil
+}
+
+// TimeTravel reads the table as it was at a point, or right before it.
+// The point is TIMESTAMP, OFFSET (in seconds from now) or STATEMENT (a query ID)
+type TimeTravel struct {
+	Before bool
+	Point  string
+	Value  any
+}
+
+func (t TimeTravel) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	if t.Before {
+		w.Write([]byte("BEFORE("))
+	} else {
+		w.Write([]byte("AT("))
+	}
+
+	w.Write([]byte(t.Point))
+	w.Write([]byte(" => "))
+
+	args, err := bob.Express(w, d, start, t.Value)
+	if err != nil {
+		return nil, err
+	}
+
+	w.Write([]byte(")"))
+	return args, nil
+}
+
+// Sample reads a part of the rows of a table, as a percentage such as 10,
+// or a number of rows
+type Sample struct {
+	// BERNOULLI (or ROW) samples rows, SYSTEM (or BLOCK) samples blocks of rows
+	Method string
+	Size   any
+	Rows   bool
+	// Samples with the same seed return the same rows, as long as the table is not changed
+	Seed any
+}
+
+func (s Sample) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	w.Write([]byte("SAMPLE "))
+	if s.Method != "" {
+		w.Write([]byte(s.Method))
+		w.Write([]byte(" "))
+	}
+
+	w.Write([]byte("("))
+	args, err := bob.Express(w, d, start, s.Size)
+	if err != nil {
+		return nil, err
+	}
+	if s.Rows {
+		w.Write([]byte(" ROWS"))
+	}
+	w.Write([]byte(")"))
+
+	seedArgs, err := bob.ExpressIf(w, d, start+len(args), s.Seed, s.Seed != nil, " SEED (", ")")
+	if err != nil {
+		return nil, err
+	}
+
+	return append(args, seedArgs...), nil
+}
+
+// MatchRecognize finds sequences of rows that match a pattern of symbols,
+// each defined by a condition on the row
+type MatchRecognize struct {
+	PartitionBy []any
+	OrderBy     clause.OrderBy
+	Measures    []any
+	// ONE ROW PER MATCH or ALL ROWS PER MATCH
+	RowsPerMatch string
+	// Where the next match starts, such as PAST LAST ROW or TO NEXT ROW
+	AfterMatchSkip string
+	Pattern        string
+	Defines        []MatchDefine
+}
+
+func (m MatchRecognize) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	w.Write([]byte("MATCH_RECOGNIZE ("))
+
+	args, err := bob.ExpressSlice(w, d, start, m.PartitionBy, "\nPARTITION BY ", ", ", "")
+	if err != nil {
+		return nil, err
+	}
+
+	orderArgs, err := bob.ExpressIf(w, d, start+len(args), m.OrderBy,
+		len(m.OrderBy.Expressions) > 0, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, orderArgs...)
+
+	measureArgs, err := bob.ExpressSlice(w, d, start+len(args), m.Measures, "\nMEASURES ", ", ", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, measureArgs...)
+
+	if m.RowsPerMatch != "" {
+		w.Write([]byte("\n"))
+		w.Write([]byte(m.RowsPerMatch))
+	}
+
+	if m.AfterMatchSkip != "" {
+		w.Write([]byte("\nAFTER MATCH SKIP "))
+		w.Write([]byte(m.AfterMatchSkip))
+	}
+
+	w.Write([]byte("\nPATTERN ("))
+	w.Write([]byte(m.Pattern))
+	w.Write([]byte(")"))
+
+	defineArgs, err := bob.ExpressSlice(w, d, start+len(args), m.Defines, "\nDEFINE ", ", ", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, defineArgs...)
+
+	w.Write([]byte("\n)"))
+	return args, nil
+}
+
+// MatchDefine is the condition of a symbol of a MATCH_RECOGNIZE pattern
+type MatchDefine struct {
+	Symbol    string
+	Condition any
+}
+
+func (m MatchDefine) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	w.Write([]byte(m.Symbol))
+	w.Write([]byte(" AS "))
+
+	return bob.Express(w, d, start, m.Condition)
+}
+
+// Qualify filters the rows on the results of window functions,
+// like HAVING does for aggregates
+type Qualify struct {
+	Conditions []any
+}
+
+func (q *Qualify) AppendQualify(e ...any) {
+	q.Conditions = append(q.Conditions, e...)
+}
+
+func (q Qualify) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	return bob.ExpressSlice(w, d, start, q.Conditions, "QUALIFY ", " AND ", "")
+}
@@ -0,0 +1,34 @@
+package dialect
+
+import (
+	"io"
+
+	"github.com/stephenafamo/bob"
+)
+
+//nolint:gochecknoglobals
+var (
+	Dialect      dialect
+	questionMark = []byte("?")
+	doubleQuote  = []byte(`"`)
+)
+
+//nolint:gochecknoinits
+func init() {
+	bob.RegisterDialect("snowflake", Dialect)
+}
+
+type dialect struct{}
+
+// WriteArg writes a positional ? placeholder, which the Snowflake driver binds in order
+func (d dialect) WriteArg(w io.Writer, position int) {
+	w.Write(questionMark)
+}
+
+// WriteQuoted writes a quoted identifier, which Snowflake matches case-sensitively.
+// Unquoted identifiers are stored in upper case
+func (d dialect) WriteQuoted(w io.Writer, s string) {
+	w.Write(doubleQuote)
+	w.Write([]byte(s))
+	w.Write(doubleQuote)
+}
@@ -0,0 +1,323 @@
+package dialect
+
+import (
+	"io"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/clause"
+	"github.com/stephenafamo/bob/expr"
+	"github.com/stephenafamo/bob/mods"
+)
+
+func With[Q interface{ AppendWith(clause.CTE) }](name string, columns ...string) CTEChain[Q] {
+	return CTEChain[Q](func() clause.CTE {
+		return clause.CTE{
+			Name:    name,
+			Columns: columns,
+		}
+	})
+}
+
+type CTEChain[Q interface{ AppendWith(clause.CTE) }] func() clause.CTE
+
+func (c CTEChain[Q]) Apply(q Q) {
+	q.AppendWith(c())
+}
+
+func (c CTEChain[Q]) As(q bob.Query) CTEChain[Q] {
+	cte := c()
+	cte.Query = q
+	return CTEChain[Q](func() clause.CTE {
+		return cte
+	})
+}
+
+type fromable interface {
+	SetTable(any)
+	SetTableAlias(alias string, columns ...string)
+	SetTimeTravel(TimeTravel)
+}
+
+func From[Q fromable](table any) FromChain[Q] {
+	return FromChain[Q](func() FromClause {
+		return FromClause{
+			From: clause.From{Table: table},
+		}
+	})
+}
+
+type FromChain[Q fromable] func() FromClause
+
+func (f FromChain[Q]) Apply(q Q) {
+	from := f()
+
+	q.SetTable(from.Table)
+	if from.Alias != "" {
+		q.SetTableAlias(from.Alias)
+	}
+	if from.TimeTravel != nil {
+		q.SetTimeTravel(*from.TimeTravel)
+	}
+}
+
+func (f FromChain[Q]) As(alias string) FromChain[Q] {
+	fr := f()
+	fr.Alias = alias
+
+	return FromChain[Q](func() FromClause {
+		return fr
+	})
+}
+
+// At reads the table as it was at the point, which is TIMESTAMP, OFFSET
+// (in seconds from now, such as -60) or STATEMENT (a query ID)
+func (f FromChain[Q]) At(point string, value any) FromChain[Q] {
+	fr := f()
+	fr.TimeTravel = &TimeTravel{Point: point, Value: value}
+
+	return FromChain[Q](func() FromClause {
+		return fr
+	})
+}
+
+// Before reads the table as it was right before the point, see [FromChain.At].
+// With a STATEMENT, it reads the table without the changes of the query
+func (f FromChain[Q]) Before(point string, value any) FromChain[Q] {
+	fr := f()
+	fr.TimeTravel = &TimeTravel{Before: true, Point: point, Value: value}
+
+	return FromChain[Q](func() FromClause {
+		return fr
+	})
+}
+
+// SampleChain is a SAMPLE clause that is being built
+type SampleChain[Q interface{ SetSample(Sample) }] func() Sample
+
+func (s SampleChain[Q]) Apply(q Q) {
+	q.SetSample(s())
+}
+
+// Rows samples a number of rows instead of a percentage
+func (s SampleChain[Q]) Rows() SampleChain[Q] {
+	sample := s()
+	sample.Rows = true
+
+	return SampleChain[Q](func() Sample {
+		return sample
+	})
+}
+
+// Method sets the sampling method, BERNOULLI for rows or SYSTEM for blocks of rows
+func (s SampleChain[Q]) Method(method string) SampleChain[Q] {
+	sample := s()
+	sample.Method = method
+
+	return SampleChain[Q](func() Sample {
+		return sample
+	})
+}
+
+// Seed returns the same rows for the same seed, as long as the table is not changed
+func (s SampleChain[Q]) Seed(seed any) SampleChain[Q] {
+	sample := s()
+	sample.Seed = seed
+
+	return SampleChain[Q](func() Sample {
+		return sample
+	})
+}
+
+// MatchRecognizeChain is a MATCH_RECOGNIZE clause that is being built
+type MatchRecognizeChain[Q interface{ SetMatchRecognize(MatchRecognize) }] func() MatchRecognize
+
+func (m MatchRecognizeChain[Q]) Apply(q Q) {
+	q.SetMatchRecognize(m())
+}
+
+func (m MatchRecognizeChain[Q]) with(f func(*MatchRecognize)) MatchRecognizeChain[Q] {
+	match := m()
+	f(&match)
+
+	return MatchRecognizeChain[Q](func() MatchRecognize {
+		return match
+	})
+}
+
+// PartitionBy looks for the pattern in each partition of the rows
+func (m MatchRecognizeChain[Q]) PartitionBy(e ...any) MatchRecognizeChain[Q] {
+	return m.with(func(match *MatchRecognize) {
+		match.PartitionBy = append(match.PartitionBy[:len(match.PartitionBy):len(match.PartitionBy)], e...)
+	})
+}
+
+// OrderBy is the order of the rows in which the pattern is looked for
+func (m MatchRecognizeChain[Q]) OrderBy(e ...any) MatchRecognizeChain[Q] {
+	return m.with(func(match *MatchRecognize) {
+		orders := match.OrderBy.Expressions[:len(match.OrderBy.Expressions):len(match.OrderBy.Expressions)]
+		for _, o := range e {
+			orders = append(orders, clause.OrderDef{Expression: o})
+		}
+		match.OrderBy.Expressions = orders
+	})
+}
+
+// Measure adds a column to the result of each match
+func (m MatchRecognizeChain[Q]) Measure(e any, alias string) MatchRecognizeChain[Q] {
+	measure := bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+		args, err := bob.Express(w, d, start, e)
+		if err != nil {
+			return nil, err
+		}
+
+		w.Write([]byte(" AS "))
+		d.WriteQuoted(w, alias)
+		return args, nil
+	})
+
+	return m.with(func(match *MatchRecognize) {
+		match.Measures = append(match.Measures[:len(match.Measures):len(match.Measures)], measure)
+	})
+}
+
+// OneRowPerMatch returns a row for each match, which is the default
+func (m MatchRecognizeChain[Q]) OneRowPerMatch() MatchRecognizeChain[Q] {
+	return m.with(func(match *MatchRecognize) {
+		match.RowsPerMatch = "ONE ROW PER MATCH"
+	})
+}
+
+// AllRowsPerMatch returns all the rows of each match
+func (m MatchRecognizeChain[Q]) AllRowsPerMatch() MatchRecognizeChain[Q] {
+	return m.with(func(match *MatchRecognize) {
+		match.RowsPerMatch = "ALL ROWS PER MATCH"
+	})
+}
+
+// AfterMatchSkip sets where the next match starts, such as PAST LAST ROW or TO NEXT ROW
+func (m MatchRecognizeChain[Q]) AfterMatchSkip(to string) MatchRecognizeChain[Q] {
+	return m.with(func(match *MatchRecognize) {
+		match.AfterMatchSkip = to
+	})
+}
+
+// Define sets the condition of a symbol of the pattern.
+// Symbols without a condition match every row
+func (m MatchRecognizeChain[Q]) Define(symbol string, condition any) MatchRecognizeChain[Q] {
+	return m.with(func(match *MatchRecognize) {
+		match.Defines = append(match.Defines[:len(match.Defines):len(match.Defines)], MatchDefine{
+			Symbol:    symbol,
+			Condition: condition,
+		})
+	})
+}
+
+type JoinChain[Q interface{ AppendJoin(clause.Join) }] func() clause.Join
+
+func (j JoinChain[Q]) Apply(q Q) {
+	q.AppendJoin(j())
+}
+
+func (j JoinChain[Q]) As(alias string) JoinChain[Q] {
+	jo := j()
+	jo.To.Alias = alias
+
+	return JoinChain[Q](func() clause.Join {
+		return jo
+	})
+}
+
+func (j JoinChain[Q]) On(on ...bob.Expression) bob.Mod[Q] {
+	jo := j()
+	jo.On = append(jo.On, on...)
+
+	return mods.Join[Q](jo)
+}
+
+func (j JoinChain[Q]) OnEQ(a, b bob.Expression) bob.Mod[Q] {
+	jo := j()
+	jo.On = append(jo.On, expr.X[Expression, Expression](a).EQ(b))
+
+	return mods.Join[Q](jo)
+}
+
+func (j JoinChain[Q]) Using(using ...string) bob.Mod[Q] {
+	jo := j()
+	jo.Using = append(jo.Using, using...)
+
+	return mods.Join[Q](jo)
+}
+
+type Joinable interface{ AppendJoin(clause.Join) }
+
+// Join is a join of the given type, such as "LEFT OUTER JOIN"
+func Join[Q Joinable](typ string, e any) JoinChain[Q] {
+	return JoinChain[Q](func() clause.Join {
+		return clause.Join{
+			Type: typ,
+			To:   clause.From{Table: e},
+		}
+	})
+}
+
+func InnerJoin[Q Joinable](e any) JoinChain[Q] {
+	return Join[Q](clause.InnerJoin, e)
+}
+
+func LeftJoin[Q Joinable](e any) JoinChain[Q] {
+	return Join[Q](clause.LeftJoin, e)
+}
+
+func RightJoin[Q Joinable](e any) JoinChain[Q] {
+	return Join[Q](clause.RightJoin, e)
+}
+
+func FullJoin[Q Joinable](e any) JoinChain[Q] {
+	return Join[Q](clause.FullJoin, e)
+}
+
+func CrossJoin[Q Joinable](e any) bob.Mod[Q] {
+	return Join[Q](clause.CrossJoin, e)
+}
+
+type OrderBy[Q interface{ AppendOrder(clause.OrderDef) }] func() clause.OrderDef
+
+func (s OrderBy[Q]) Apply(q Q) {
+	q.AppendOrder(s())
+}
+
+func (o OrderBy[Q]) Asc() OrderBy[Q] {
+	order := o()
+	order.Direction = "ASC"
+
+	return OrderBy[Q](func() clause.OrderDef {
+		return order
+	})
+}
+
+func (o OrderBy[Q]) Desc() OrderBy[Q] {
+	order := o()
+	order.Direction = "DESC"
+
+	return OrderBy[Q](func() clause.OrderDef {
+		return order
+	})
+}
+
+func (o OrderBy[Q]) NullsFirst() OrderBy[Q] {
+	order := o()
+	order.Nulls = "FIRST"
+
+	return OrderBy[Q](func() clause.OrderDef {
+		return order
+	})
+}
+
+func (o OrderBy[Q]) NullsLast() OrderBy[Q] {
+	order := o()
+	order.Nulls = "LAST"
+
+	return OrderBy[Q](func() clause.OrderDef {
+		return order
+	})
+}
@@ -0,0 +1,115 @@
+package dialect
+
+import (
+	"io"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/clause"
+)
+
+// Trying to represent the select query structure as documented in
+// https://docs.snowflake.com/en/sql-reference/sql/select
+type SelectQuery struct {
+	clause.With
+	Distinct bool
+	clause.SelectList
+	FromClause
+	clause.Where
+	clause.GroupBy
+	clause.Having
+	Qualify
+	clause.OrderBy
+	clause.Limit
+	clause.Offset
+	clause.Combine
+	bob.Load[*SelectQuery]
+}
+
+func (s SelectQuery) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	var args []any
+
+	withArgs, err := bob.ExpressIf(w, d, start+len(args), s.With,
+		len(s.With.CTEs) > 0, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, withArgs...)
+
+	w.Write([]byte("SELECT "))
+
+	if s.Distinct {
+		w.Write([]byte("DISTINCT "))
+	}
+
+	selArgs, err := bob.ExpressIf(w, d, start+len(args), s.SelectList, true, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, selArgs...)
+
+	fromArgs, err := bob.ExpressIf(w, d, start+len(args), s.FromClause,
+		s.FromClause.Table != nil, "\nFROM ", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, fromArgs...)
+
+	whereArgs, err := bob.ExpressIf(w, d, start+len(args), s.Where,
+		len(s.Where.Conditions) > 0, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, whereArgs...)
+
+	groupByArgs, err := bob.ExpressIf(w, d, start+len(args), s.GroupBy,
+		len(s.GroupBy.Groups) > 0, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, groupByArgs...)
+
+	havingArgs, err := bob.ExpressIf(w, d, start+len(args), s.Having,
+		len(s.Having.Conditions) > 0, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, havingArgs...)
+
+	qualifyArgs, err := bob.ExpressIf(w, d, start+len(args), s.Qualify,
+		len(s.Qualify.Conditions) > 0, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, qualifyArgs...)
+
+	orderArgs, err := bob.ExpressIf(w, d, start+len(args), s.OrderBy,
+		len(s.OrderBy.Expressions) > 0, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, orderArgs...)
+
+	limitArgs, err := bob.ExpressIf(w, d, start+len(args), s.Limit,
+		s.Limit.Count != nil, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, limitArgs...)
+
+	offsetArgs, err := bob.ExpressIf(w, d, start+len(args), s.Offset,
+		s.Offset.Count != nil, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, offsetArgs...)
+
+	combineArgs, err := bob.ExpressIf(w, d, start+len(args), s.Combine,
+		s.Combine.Query != nil, "\n", "")
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, combineArgs...)
+
+	w.Write([]byte("\n"))
+	return args, nil
+}
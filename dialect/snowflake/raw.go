@@ -0,0 +1,26 @@
+package snowflake
+
+import (
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/snowflake/dialect"
+	"github.com/stephenafamo/bob/expr"
+)
+
+func RawQuery(q string, args ...any) bob.BaseQuery[expr.Clause] {
+	return expr.RawQuery(dialect.Dialect, q, args...)
+}
+
+// TemplateQuery executes the query template with the data.
+// See [expr.Template] for the quoting of identifiers and parameters
+func TemplateQuery(t expr.Template, data any) bob.BaseQuery[bob.Expression] {
+	return expr.TemplateQuery(dialect.Dialect, t, data)
+}
+
+// InsertFromStructs builds a multi-row INSERT from a slice of structs.
+// See [bob.InsertFromStructs] for the mapping of the columns
+func InsertFromStructs[T any](table string, rows []T, opts ...bob.StructInsertOption) bob.BaseQuery[bob.Expression] {
+	return bob.BaseQuery[bob.Expression]{
+		Expression: bob.InsertFromStructs(table, rows, opts...),
+		Dialect:    dialect.Dialect,
+	}
+}
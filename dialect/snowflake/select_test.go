@@ -0,0 +1,97 @@
+package snowflake_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stephenafamo/bob/dialect/snowflake"
+	"github.com/stephenafamo/bob/dialect/snowflake/sm"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+func TestSelect(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	examples := testutils.Testcases{
+		"simple select": {
+			ExpectedSQL:  `SELECT id, name FROM "db"."public"."users" WHERE ("id" IN (?, ?, ?))`,
+			ExpectedArgs: []any{100, 200, 300},
+			Query: snowflake.Select(
+				sm.Columns("id", "name"),
+				sm.From(snowflake.Quote("db", "public", "users")),
+				sm.Where(snowflake.Quote("id").In(snowflake.Arg(100, 200, 300))),
+			),
+		},
+		"qualify": {
+			ExpectedSQL:  `SELECT * FROM "events" WHERE ("type" = ?) QUALIFY ROW_NUMBER() OVER (PARTITION BY "user_id" ORDER BY "at" DESC) = 1 ORDER BY "at" DESC LIMIT 10`,
+			ExpectedArgs: []any{"click"},
+			Query: snowflake.Select(
+				sm.From(snowflake.Quote("events")),
+				sm.Where(snowflake.Quote("type").EQ(snowflake.Arg("click"))),
+				sm.Qualify(snowflake.Raw(`ROW_NUMBER() OVER (PARTITION BY "user_id" ORDER BY "at" DESC) = 1`)),
+				sm.OrderBy(snowflake.Quote("at")).Desc(),
+				sm.Limit(10),
+			),
+		},
+		"time travel and sample": {
+			ExpectedSQL:  `SELECT * FROM "orders" BEFORE(STATEMENT => ?) AS "o" SAMPLE BERNOULLI (100 ROWS) SEED (42) INNER JOIN "users" AS "u" ON ("u"."id" = "o"."user_id")`,
+			ExpectedArgs: []any{"01b2c3d4-0000-0000-0000-000000000000"},
+			Query: snowflake.Select(
+				sm.From(snowflake.Quote("orders")).As("o").Before("STATEMENT", snowflake.Arg("01b2c3d4-0000-0000-0000-000000000000")),
+				sm.Sample(100).Rows().Method("BERNOULLI").Seed(42),
+				sm.InnerJoin(snowflake.Quote("users")).As("u").OnEQ(snowflake.Quote("u", "id"), snowflake.Quote("o", "user_id")),
+			),
+		},
+		"as of": {
+			ExpectedSQL:  `SELECT * FROM "users" AT(TIMESTAMP => ?) WHERE ("id" = ?)`,
+			ExpectedArgs: []any{at, 1},
+			Query: snowflake.Select(
+				sm.From(snowflake.Quote("users")),
+				sm.AsOf(at),
+				sm.Where(snowflake.Quote("id").EQ(snowflake.Arg(1))),
+			),
+		},
+		"match recognize": {
+			ExpectedSQL: `SELECT * FROM "prices" MATCH_RECOGNIZE (
+				PARTITION BY "symbol"
+				ORDER BY "day"
+				MEASURES FIRST("day") AS "start", COUNT(*) AS "days"
+				ONE ROW PER MATCH
+				AFTER MATCH SKIP PAST LAST ROW
+				PATTERN (DOWN+ UP+)
+				DEFINE DOWN AS ("price" < LAG("price")), UP AS ("price" > ?)
+			) AS "m"`,
+			ExpectedArgs: []any{100},
+			Query: snowflake.Select(
+				sm.From(snowflake.Quote("prices")).As("m"),
+				sm.MatchRecognize("DOWN+ UP+").
+					PartitionBy(snowflake.Quote("symbol")).
+					OrderBy(snowflake.Quote("day")).
+					Measure(snowflake.Raw(`FIRST("day")`), "start").
+					Measure("COUNT(*)", "days").
+					OneRowPerMatch().
+					AfterMatchSkip("PAST LAST ROW").
+					Define("DOWN", snowflake.Quote("price").LT(snowflake.Raw(`LAG("price")`))).
+					Define("UP", snowflake.Quote("price").GT(snowflake.Arg(100))),
+			),
+		},
+		"with and union": {
+			ExpectedSQL:  `WITH recent AS (SELECT * FROM "events" WHERE ("at" > ?)) SELECT "id" FROM "recent" UNION (SELECT "id" FROM "archive")`,
+			ExpectedArgs: []any{"2024-01-01"},
+			Query: snowflake.Select(
+				sm.With("recent").As(snowflake.Select(
+					sm.From(snowflake.Quote("events")),
+					sm.Where(snowflake.Quote("at").GT(snowflake.Arg("2024-01-01"))),
+				)),
+				sm.Columns(snowflake.Quote("id")),
+				sm.From(snowflake.Quote("recent")),
+				sm.Union(snowflake.Select(
+					sm.Columns(snowflake.Quote("id")),
+					sm.From(snowflake.Quote("archive")),
+				)),
+			),
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+}
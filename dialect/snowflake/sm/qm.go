@@ -0,0 +1,168 @@
+package sm
+
+import (
+	"time"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/clause"
+	"github.com/stephenafamo/bob/dialect/snowflake/dialect"
+	"github.com/stephenafamo/bob/expr"
+	"github.com/stephenafamo/bob/mods"
+)
+
+func With(name string, columns ...string) dialect.CTEChain[*dialect.SelectQuery] {
+	return dialect.With[*dialect.SelectQuery](name, columns...)
+}
+
+func Recursive(r bool) bob.Mod[*dialect.SelectQuery] {
+	return mods.Recursive[*dialect.SelectQuery](r)
+}
+
+func Distinct() bob.Mod[*dialect.SelectQuery] {
+	return mods.QueryModFunc[*dialect.SelectQuery](func(q *dialect.SelectQuery) {
+		q.Distinct = true
+	})
+}
+
+func Columns(clauses ...any) bob.Mod[*dialect.SelectQuery] {
+	return mods.Select[*dialect.SelectQuery](clauses)
+}
+
+func From(table any) dialect.FromChain[*dialect.SelectQuery] {
+	return dialect.From[*dialect.SelectQuery](table)
+}
+
+// AsOf reads the table of the FROM clause as it was at the given time.
+// Use it with the queries of models, which already have a FROM clause.
+// Other points in time are set with [dialect.FromChain.At] and [dialect.FromChain.Before]
+//
+//	SQL: SELECT * FROM users AT(TIMESTAMP => ?)
+//	Go: models.Users.Query(ctx, db, sm.AsOf(t))
+func AsOf(t time.Time) bob.Mod[*dialect.SelectQuery] {
+	return mods.QueryModFunc[*dialect.SelectQuery](func(q *dialect.SelectQuery) {
+		q.SetTimeTravel(dialect.TimeTravel{Point: "TIMESTAMP", Value: expr.Arg(t)})
+	})
+}
+
+// Sample reads a part of the rows of the table of the FROM clause,
+// as a percentage such as 10, or a number of rows with Rows
+//
+//	SQL: SELECT * FROM "events" SAMPLE BERNOULLI (10) SEED (42)
+//	Go: snowflake.Select(sm.From(snowflake.Quote("events")), sm.Sample(10).Method("BERNOULLI").Seed(42))
+func Sample(size any) dialect.SampleChain[*dialect.SelectQuery] {
+	return dialect.SampleChain[*dialect.SelectQuery](func() dialect.Sample {
+		return dialect.Sample{Size: size}
+	})
+}
+
+// MatchRecognize finds sequences of rows of the table of the FROM clause that
+// match the pattern. The symbols of the pattern are defined with Define
+//
+//	SQL: SELECT * FROM "prices" MATCH_RECOGNIZE (PARTITION BY "symbol" ORDER BY "day" MEASURES COUNT(*) AS "days" PATTERN (DOWN+ UP+) DEFINE DOWN AS price < LAG(price), UP AS price > LAG(price))
+//	Go: snowflake.Select(sm.From(snowflake.Quote("prices")), sm.MatchRecognize("DOWN+ UP+").PartitionBy(snowflake.Quote("symbol")).OrderBy(snowflake.Quote("day")).Measure("COUNT(*)", "days").Define("DOWN", "price < LAG(price)").Define("UP", "price > LAG(price)"))
+func MatchRecognize(pattern string) dialect.MatchRecognizeChain[*dialect.SelectQuery] {
+	return dialect.MatchRecognizeChain[*dialect.SelectQuery](func() dialect.MatchRecognize {
+		return dialect.MatchRecognize{Pattern: pattern}
+	})
+}
+
+// Join is a join of the given type, such as "LEFT OUTER JOIN"
+func Join(typ string, e any) dialect.JoinChain[*dialect.SelectQuery] {
+	return dialect.Join[*dialect.SelectQuery](typ, e)
+}
+
+func InnerJoin(e any) dialect.JoinChain[*dialect.SelectQuery] {
+	return dialect.InnerJoin[*dialect.SelectQuery](e)
+}
+
+func LeftJoin(e any) dialect.JoinChain[*dialect.SelectQuery] {
+	return dialect.LeftJoin[*dialect.SelectQuery](e)
+}
+
+func RightJoin(e any) dialect.JoinChain[*dialect.SelectQuery] {
+	return dialect.RightJoin[*dialect.SelectQuery](e)
+}
+
+func FullJoin(e any) dialect.JoinChain[*dialect.SelectQuery] {
+	return dialect.FullJoin[*dialect.SelectQuery](e)
+}
+
+func CrossJoin(e any) bob.Mod[*dialect.SelectQuery] {
+	return dialect.CrossJoin[*dialect.SelectQuery](e)
+}
+
+func Where(e bob.Expression) mods.Where[*dialect.SelectQuery] {
+	return mods.Where[*dialect.SelectQuery]{E: e}
+}
+
+func GroupBy(e any) bob.Mod[*dialect.SelectQuery] {
+	return mods.GroupBy[*dialect.SelectQuery]{
+		E: e,
+	}
+}
+
+func Having(e any) bob.Mod[*dialect.SelectQuery] {
+	return mods.Having[*dialect.SelectQuery]{e}
+}
+
+// Qualify filters the rows on the results of window functions
+//
+//	SQL: SELECT * FROM "events" QUALIFY ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY at DESC) = 1
+//	Go: snowflake.Select(sm.From(snowflake.Quote("events")), sm.Qualify(snowflake.Raw("ROW_NUMBER() OVER (PARTITION BY user_id ORDER BY at DESC) = 1")))
+func Qualify(e any) bob.Mod[*dialect.SelectQuery] {
+	return mods.QueryModFunc[*dialect.SelectQuery](func(q *dialect.SelectQuery) {
+		q.AppendQualify(e)
+	})
+}
+
+func OrderBy(e any) dialect.OrderBy[*dialect.SelectQuery] {
+	return dialect.OrderBy[*dialect.SelectQuery](func() clause.OrderDef {
+		return clause.OrderDef{
+			Expression: e,
+		}
+	})
+}
+
+func Limit(count any) bob.Mod[*dialect.SelectQuery] {
+	return mods.Limit[*dialect.SelectQuery]{
+		Count: count,
+	}
+}
+
+func Offset(count any) bob.Mod[*dialect.SelectQuery] {
+	return mods.Offset[*dialect.SelectQuery]{
+		Count: count,
+	}
+}
+
+func Union(q bob.Query) bob.Mod[*dialect.SelectQuery] {
+	return mods.Combine[*dialect.SelectQuery]{
+		Strategy: clause.Union,
+		Query:    q,
+		All:      false,
+	}
+}
+
+func UnionAll(q bob.Query) bob.Mod[*dialect.SelectQuery] {
+	return mods.Combine[*dialect.SelectQuery]{
+		Strategy: clause.Union,
+		Query:    q,
+		All:      true,
+	}
+}
+
+func Intersect(q bob.Query) bob.Mod[*dialect.SelectQuery] {
+	return mods.Combine[*dialect.SelectQuery]{
+		Strategy: clause.Intersect,
+		Query:    q,
+		All:      false,
+	}
+}
+
+func Except(q bob.Query) bob.Mod[*dialect.SelectQuery] {
+	return mods.Combine[*dialect.SelectQuery]{
+		Strategy: clause.Except,
+		Query:    q,
+		All:      false,
+	}
+}
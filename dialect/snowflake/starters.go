@@ -0,0 +1,85 @@
+package snowflake
+
+import (
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/snowflake/dialect"
+	"github.com/stephenafamo/bob/expr"
+)
+
+type Expression = dialect.Expression
+
+//nolint:gochecknoglobals
+var bmod = expr.Builder[Expression, Expression]{}
+
+// S creates a string literal
+// SQL: 'a string'
+// Go: snowflake.S("a string")
+func S(s string) Expression {
+	return bmod.S(s)
+}
+
+// SQL: NOT true
+// Go: snowflake.Not("true")
+func Not(exp bob.Expression) Expression {
+	return bmod.Not(exp)
+}
+
+// SQL: a OR b OR c
+// Go: snowflake.Or("a", "b", "c")
+func Or(args ...bob.Expression) Expression {
+	return bmod.Or(args...)
+}
+
+// SQL: a AND b AND c
+// Go: snowflake.And("a", "b", "c")
+func And(args ...bob.Expression) Expression {
+	return bmod.And(args...)
+}
+
+// SQL: a || b || c
+// Go: snowflake.Concat("a", "b", "c")
+func Concat(args ...bob.Expression) Expression {
+	return expr.X[Expression, Expression](expr.Join{Exprs: args, Sep: " || "})
+}
+
+// SQL: ?, ?, ?
+// Go: snowflake.Args("a", "b", "c")
+func Arg(args ...any) Expression {
+	return bmod.Arg(args...)
+}
+
+// SQL: (?, ?, ?)
+// Go: snowflake.ArgGroup("a", "b", "c")
+func ArgGroup(args ...any) Expression {
+	return bmod.ArgGroup(args...)
+}
+
+// SQL: ?, ?, ?
+// Go: snowflake.Placeholder(3)
+func Placeholder(n uint) Expression {
+	return bmod.Placeholder(n)
+}
+
+// SQL: (a, b)
+// Go: snowflake.Group("a", "b")
+func Group(exps ...bob.Expression) Expression {
+	return bmod.Group(exps...)
+}
+
+// SQL: "table"."column"
+// Go: snowflake.Quote("table", "column")
+func Quote(ss ...string) Expression {
+	return bmod.Quote(ss...)
+}
+
+// SQL: where a = ?
+// Go: snowflake.Raw("where a = ?", "something")
+func Raw(query string, args ...any) Expression {
+	return bmod.Raw(query, args...)
+}
+
+// SQL: a as "alias"
+// Go: snowflake.As("a", "alias")
+func As(e Expression, alias string) bob.Expression {
+	return expr.OP("AS", e, expr.Quote(alias))
+}
@@ -0,0 +1,18 @@
+package sqlite_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/sqlite"
+	"github.com/stephenafamo/bob/dialect/sqlite/sm"
+)
+
+func TestAsOf(t *testing.T) {
+	_, _, err := bob.Build(sqlite.Select(sm.From("users"), sm.AsOf(time.Now())))
+	if !errors.Is(err, bob.ErrUnsupported) {
+		t.Fatalf("expected ErrUnsupported, got %v", err)
+	}
+}
@@ -0,0 +1,43 @@
+package sqlite
+
+import (
+	"io"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/sqlite/dialect"
+)
+
+// Attach adds the database file at path to the connection under the alias.
+// Its tables are then qualified with the alias, e.g. with sm.From("users").In(alias).
+// Like pragmas, an attached database only belongs to the connection that attached it,
+// use [Setup] to attach it to every connection of a pool
+//
+//	SQL: ATTACH DATABASE ?1 AS "archive"
+//	Go: sqlite.Attach("archive.db", "archive")
+func Attach(path, alias string) bob.BaseQuery[bob.Expression] {
+	return bob.BaseQuery[bob.Expression]{
+		Expression: bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+			w.Write([]byte("ATTACH DATABASE "))
+			d.WriteArg(w, start)
+			w.Write([]byte(" AS "))
+			d.WriteQuoted(w, alias)
+			return []any{path}, nil
+		}),
+		Dialect: dialect.Dialect,
+	}
+}
+
+// Detach removes a database that was added with [Attach]
+//
+//	SQL: DETACH DATABASE "archive"
+//	Go: sqlite.Detach("archive")
+func Detach(alias string) bob.BaseQuery[bob.Expression] {
+	return bob.BaseQuery[bob.Expression]{
+		Expression: bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+			w.Write([]byte("DETACH DATABASE "))
+			d.WriteQuoted(w, alias)
+			return nil, nil
+		}),
+		Dialect: dialect.Dialect,
+	}
+}
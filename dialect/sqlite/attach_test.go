@@ -0,0 +1,86 @@
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/sqlite"
+	"github.com/stephenafamo/bob/dialect/sqlite/sm"
+	testutils "github.com/stephenafamo/bob/test_utils"
+	"github.com/stephenafamo/scan"
+	msqlite "modernc.org/sqlite"
+)
+
+func TestAttach(t *testing.T) {
+	examples := testutils.Testcases{
+		"attach": {
+			Query:        sqlite.Attach("archive.db", "archive"),
+			ExpectedSQL:  `ATTACH DATABASE ?1 AS "archive"`,
+			ExpectedArgs: []any{"archive.db"},
+		},
+		"detach": {
+			Query:       sqlite.Detach("archive"),
+			ExpectedSQL: `DETACH DATABASE "archive"`,
+		},
+		"join attached database": {
+			Query: sqlite.Select(
+				sm.Columns("u.name", "o.total"),
+				sm.From("users").In("main").As("u"),
+				sm.InnerJoin("orders").In("archive").As("o").On(sqlite.Quote("o", "user_id").EQ(sqlite.Quote("u", "id"))),
+			),
+			ExpectedSQL: `SELECT u.name, o.total FROM "main"."users" AS "u"
+				INNER JOIN "archive"."orders" AS "o" ON ("o"."user_id" = "u"."id")`,
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+}
+
+func TestAttachSetup(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	archive, err := bob.Open("sqlite", filepath.Join(dir, "archive.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := archive.ExecContext(ctx, "CREATE TABLE orders (user_id INTEGER, total INTEGER)"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := archive.ExecContext(ctx, "INSERT INTO orders VALUES (1, 10), (1, 20), (2, 5)"); err != nil {
+		t.Fatal(err)
+	}
+	archive.Close()
+
+	setup := sqlite.Setup{
+		Pragmas: []bob.Query{sqlite.Attach(filepath.Join(dir, "archive.db"), "archive")},
+	}
+	db := bob.OpenDB(setup.Connector(&msqlite.Driver{}, filepath.Join(dir, "main.db")))
+	defer db.Close()
+
+	if _, err := db.ExecContext(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.ExecContext(ctx, "INSERT INTO users VALUES (1, 'Alice'), (2, 'Bob')"); err != nil {
+		t.Fatal(err)
+	}
+
+	total, err := bob.One(ctx, db, sqlite.Select(
+		sm.Columns("sum(o.total)"),
+		sm.From("users").As("u"),
+		sm.InnerJoin("orders").In("archive").As("o").On(sqlite.Quote("o", "user_id").EQ(sqlite.Quote("u", "id"))),
+		sm.Where(sqlite.Quote("u", "name").EQ(sqlite.Arg("Alice"))),
+	), scan.SingleColumnMapper[int])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if total != 30 {
+		t.Fatalf("got total %d, want 30", total)
+	}
+
+	if _, err := bob.Exec(ctx, db, sqlite.Detach("archive")); err != nil {
+		t.Fatal(err)
+	}
+}
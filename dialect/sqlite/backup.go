@@ -0,0 +1,50 @@
+package sqlite
+
+import (
+	"context"
+	"io"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/sqlite/dialect"
+)
+
+// VacuumInto writes a compacted copy of the main database to the file at path.
+// The file must not exist yet, or be empty
+//
+//	SQL: VACUUM INTO ?1
+//	Go: sqlite.VacuumInto("backup.db")
+func VacuumInto(path string) bob.BaseQuery[bob.Expression] {
+	return bob.BaseQuery[bob.Expression]{
+		Expression: bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+			w.Write([]byte("VACUUM INTO "))
+			d.WriteArg(w, start)
+			return []any{path}, nil
+		}),
+		Dialect: dialect.Dialect,
+	}
+}
+
+// BackupFunc copies the main database of the driver connection into the file at path
+// with the online backup API of the driver, such as the Backup method of
+// *sqlite3.SQLiteConn in github.com/mattn/go-sqlite3
+type BackupFunc func(driverConn any, path string) error
+
+// Backup makes a copy of the database in the file at path while it is in use.
+// With a nil backup, the copy is made with [VacuumInto], which works with every driver.
+// Otherwise backup is called with the driver connection of one of the connections of db
+func Backup(ctx context.Context, db bob.DB, path string, backup BackupFunc) error {
+	if backup == nil {
+		_, err := bob.Exec(ctx, db, VacuumInto(path))
+		return err
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return conn.Raw(func(driverConn any) error {
+		return backup(driverConn, path)
+	})
+}
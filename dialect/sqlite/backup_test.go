@@ -0,0 +1,78 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/sqlite"
+	"github.com/stephenafamo/scan"
+)
+
+func TestBackup(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+
+	sql, args, err := bob.Build(sqlite.VacuumInto("backup.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != "VACUUM INTO ?1" || len(args) != 1 || args[0] != "backup.db" {
+		t.Fatalf("got %q %v", sql, args)
+	}
+
+	db, err := bob.Open("sqlite", filepath.Join(dir, "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, err := db.ExecContext(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY)"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.ExecContext(ctx, "INSERT INTO users (id) VALUES (1), (2), (3)"); err != nil {
+		t.Fatal(err)
+	}
+
+	vacuumed := filepath.Join(dir, "vacuumed.db")
+	if err := sqlite.Backup(ctx, db, vacuumed, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	// A backup function of the driver
+	online := filepath.Join(dir, "online.db")
+	err = sqlite.Backup(ctx, db, online, func(driverConn any, path string) error {
+		execer, ok := driverConn.(driver.ExecerContext)
+		if !ok {
+			return errors.New("not an execer")
+		}
+		_, err := execer.ExecContext(ctx, "VACUUM INTO ?", []driver.NamedValue{{Ordinal: 1, Value: path}})
+		return err
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, path := range []string{vacuumed, online} {
+		copied, err := bob.Open("sqlite", path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer copied.Close()
+
+		count, err := scan.One(ctx, copied, scan.SingleColumnMapper[int], "SELECT count(*) FROM users")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if count != 3 {
+			t.Fatalf("%s: got %d users", filepath.Base(path), count)
+		}
+	}
+
+	if err := sqlite.Backup(ctx, db, vacuumed, nil); err == nil {
+		t.Fatal("expected an error for an existing file")
+	}
+}
@@ -0,0 +1,137 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/sqlite"
+	"github.com/stephenafamo/bob/dialect/sqlite/dialect"
+	"github.com/stephenafamo/bob/dialect/sqlite/im"
+	"github.com/stephenafamo/bob/dialect/sqlite/um"
+	"github.com/stephenafamo/bob/orm"
+	_ "modernc.org/sqlite"
+)
+
+type changeUser struct {
+	ID   int    `db:"id,pk"`
+	Name string `db:"name"`
+}
+
+func (u *changeUser) PrimaryKeyVals() bob.Expression {
+	return sqlite.Arg(u.ID)
+}
+
+type changeUserSetter struct {
+	ID   int    `db:"id,pk"`
+	Name string `db:"name"`
+}
+
+func (s *changeUserSetter) SetColumns() []string {
+	return []string{"id", "name"}
+}
+
+func (s *changeUserSetter) Overwrite(u *changeUser) {
+	u.ID, u.Name = s.ID, s.Name
+}
+
+func (s *changeUserSetter) Apply(q *dialect.UpdateQuery) {
+	um.SetCol("name").ToArg(s.Name).Apply(q)
+}
+
+func (s *changeUserSetter) InsertMod() bob.Mod[*dialect.InsertQuery] {
+	return im.Values(sqlite.Arg(s.ID), sqlite.Arg(s.Name))
+}
+
+func TestChanges(t *testing.T) {
+	ctx := context.Background()
+
+	sqlDB, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	sqlDB.SetMaxOpenConns(1)
+
+	db := bob.NewDB(sqlDB)
+	if _, err := db.ExecContext(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL)"); err != nil {
+		t.Fatal(err)
+	}
+
+	var events []orm.ChangeEvent
+	changes := &orm.Changes{}
+	changes.Subscribe(func(_ context.Context, e orm.ChangeEvent) {
+		e.Row = nil
+		events = append(events, e)
+	})
+
+	table := sqlite.NewTable[*changeUser, *changeUserSetter]("", "users")
+	table.Changes = changes
+
+	// Rolled back, never published
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := table.Insert(ctx, tx, &changeUserSetter{ID: 1, Name: "Alice"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Rollback(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Published after the commit
+	tx, err = db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, err := table.Insert(ctx, tx, &changeUserSetter{ID: 2, Name: "Bob"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 0 {
+		t.Fatalf("expected no events before the commit, got %v", events)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Published after the commit of the wrapped transaction
+	tx, err = db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := table.Insert(ctx, bob.ReadOnly(bob.DebugToWriter(tx, io.Discard)), &changeUserSetter{ID: 3, Name: "Carol"}); err == nil {
+		t.Fatal("expected the read-only executor to reject the insert")
+	}
+	if _, err := table.Insert(ctx, bob.DebugToWriter(tx, io.Discard), &changeUserSetter{ID: 3, Name: "Carol"}); err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected no events before the commit of the wrapped transaction, got %v", events)
+	}
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	// Published right away
+	if err := table.Update(ctx, db, &changeUserSetter{Name: "Robert"}, user); err != nil {
+		t.Fatal(err)
+	}
+	if err := table.Delete(ctx, db, user); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []orm.ChangeEvent{
+		{Table: "users", Operation: orm.OpInsert, PK: []any{2}, Columns: []string{"id", "name"}},
+		{Table: "users", Operation: orm.OpInsert, PK: []any{3}, Columns: []string{"id", "name"}},
+		{Table: "users", Operation: orm.OpUpdate, PK: []any{2}, Columns: []string{"id", "name"}},
+		{Table: "users", Operation: orm.OpDelete, PK: []any{2}},
+	}
+	if !reflect.DeepEqual(events, expected) {
+		t.Fatalf("wrong events\nexpected: %#v\n     got: %#v", expected, events)
+	}
+}
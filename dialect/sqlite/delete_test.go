@@ -17,6 +17,20 @@ func TestDelete(t *testing.T) {
 			),
 			ExpectedSQL:  `DELETE FROM films WHERE ("kind" = ?1)`,
 			ExpectedArgs: []any{"Drama"},
+		}, "all rows": {
+			Query: sqlite.Delete(
+				dm.From("films"),
+				dm.AllRows(),
+			),
+			ExpectedSQL: `DELETE FROM films`,
+		}, "returning": {
+			Query: sqlite.Delete(
+				dm.From("films"),
+				dm.Where(sqlite.Quote("kind").EQ(sqlite.Arg("Drama"))),
+				dm.Returning("*"),
+			),
+			ExpectedSQL:  `DELETE FROM films WHERE ("kind" = ?1) RETURNING *`,
+			ExpectedArgs: []any{"Drama"},
 		},
 	}
 
@@ -0,0 +1,65 @@
+package dialect
+
+import "github.com/stephenafamo/bob"
+
+// Supports reports if SQLite supports the construct when transpiling queries
+func (d dialect) Supports(c bob.Construct) bool {
+	switch c {
+	case bob.ConstructDistinctOn, bob.ConstructLocking, bob.ConstructFetch,
+		bob.ConstructOnDuplicateKey, bob.ConstructLateral, bob.ConstructOrderedWrite,
+		bob.ConstructHints, bob.ConstructModifiers, bob.ConstructAsOf:
+		return false
+	}
+
+	return true
+}
+
+func (s SelectQuery) Constructs() []bob.Construct {
+	constructs := s.From.Constructs()
+
+	if !s.AsOf.IsZero() {
+		constructs = append(constructs, bob.ConstructAsOf)
+	}
+
+	return constructs
+}
+
+func (i InsertQuery) Constructs() []bob.Construct {
+	var constructs []bob.Construct
+
+	if i.or.action != "" {
+		constructs = append(constructs, bob.ConstructOrAction)
+	}
+	if i.Conflict.Do != "" {
+		constructs = append(constructs, bob.ConstructOnConflict)
+	}
+	if len(i.Returning.Expressions) > 0 {
+		constructs = append(constructs, bob.ConstructReturning)
+	}
+
+	return constructs
+}
+
+func (u UpdateQuery) Constructs() []bob.Construct {
+	constructs := u.Table.Constructs()
+	constructs = append(constructs, u.From.Constructs()...)
+
+	if u.or.action != "" {
+		constructs = append(constructs, bob.ConstructOrAction)
+	}
+	if len(u.Returning.Expressions) > 0 {
+		constructs = append(constructs, bob.ConstructUpdateReturning)
+	}
+
+	return constructs
+}
+
+func (d DeleteQuery) Constructs() []bob.Construct {
+	constructs := d.From.Constructs()
+
+	if len(d.Returning.Expressions) > 0 {
+		constructs = append(constructs, bob.ConstructReturning)
+	}
+
+	return constructs
+}
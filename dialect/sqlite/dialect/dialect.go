@@ -3,6 +3,8 @@ package dialect
 import (
 	"io"
 	"strconv"
+
+	"github.com/stephenafamo/bob"
 )
 
 //nolint:gochecknoglobals
@@ -13,6 +15,11 @@ var (
 	doubleQuote  = []byte(`"`)
 )
 
+//nolint:gochecknoinits
+func init() {
+	bob.RegisterDialect(bob.ServerSQLite, Dialect)
+}
+
 type dialect struct{}
 
 func (d dialect) WriteArg(w io.Writer, position int) {
@@ -30,3 +37,8 @@ func (d dialect) WriteQuoted(w io.Writer, s string) {
 	w.Write([]byte(s))
 	w.Write(doubleQuote)
 }
+
+// MaxArgs is the largest number of args in a query in SQLite, since 3.32.0
+func (d dialect) MaxArgs() int {
+	return 32766
+}
@@ -0,0 +1,65 @@
+package dialect
+
+import (
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/clause"
+)
+
+// Features of the query builder that older versions of SQLite do not have.
+// Queries using them fail with [bob.ErrUnsupported] on an older version
+// once it is known with [bob.ServerInfo]
+var (
+	FeatureUpsert          = bob.Feature{Name: "ON CONFLICT", Server: bob.ServerSQLite, Since: bob.Version{Major: 3, Minor: 24}}
+	FeatureUpdateFrom      = bob.Feature{Name: "UPDATE FROM", Server: bob.ServerSQLite, Since: bob.Version{Major: 3, Minor: 33}}
+	FeatureReturning       = bob.Feature{Name: "RETURNING", Server: bob.ServerSQLite, Since: bob.Version{Major: 3, Minor: 35}}
+	FeatureMaterializedCTE = bob.Feature{Name: "MATERIALIZED", Server: bob.ServerSQLite, Since: bob.Version{Major: 3, Minor: 35}}
+)
+
+func (s SelectQuery) RequiredFeatures() []bob.Feature {
+	return withFeatures(s.With)
+}
+
+func (i InsertQuery) RequiredFeatures() []bob.Feature {
+	features := withFeatures(i.With)
+	if i.Conflict.Do != "" {
+		features = append(features, FeatureUpsert)
+	}
+
+	return returningFeatures(features, i.Returning)
+}
+
+func (u UpdateQuery) RequiredFeatures() []bob.Feature {
+	features := withFeatures(u.With)
+	if u.From.Table != nil {
+		features = append(features, FeatureUpdateFrom)
+	}
+
+	return returningFeatures(features, u.Returning)
+}
+
+func (d DeleteQuery) RequiredFeatures() []bob.Feature {
+	return returningFeatures(withFeatures(d.With), d.Returning)
+}
+
+func returningFeatures(features []bob.Feature, r clause.Returning) []bob.Feature {
+	if len(r.Expressions) > 0 {
+		features = append(features, FeatureReturning)
+	}
+
+	return features
+}
+
+// withFeatures returns the features used by the CTEs, including their queries
+func withFeatures(with clause.With) []bob.Feature {
+	var features []bob.Feature
+	for _, cte := range with.CTEs {
+		if cte.Materialized != nil {
+			features = append(features, FeatureMaterializedCTE)
+		}
+		if r, ok := cte.Query.(bob.FeatureRequirer); ok {
+			features = append(features, r.RequiredFeatures()...)
+		}
+	}
+
+	return features
+}
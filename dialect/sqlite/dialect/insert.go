@@ -41,7 +41,12 @@ func (i InsertQuery) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, err
 	}
 	args = append(args, tableArgs...)
 
-	valArgs, err := bob.ExpressIf(w, d, start+len(args), i.Values, true, "\n", "")
+	var values bob.Expression = i.Values
+	if i.Query != nil && i.Conflict.Do != "" {
+		values = upsertSelect{query: i.Query}
+	}
+
+	valArgs, err := bob.ExpressIf(w, d, start+len(args), values, true, "\n", "")
 	if err != nil {
 		return nil, err
 	}
@@ -64,3 +69,22 @@ func (i InsertQuery) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, err
 	w.Write([]byte("\n"))
 	return args, nil
 }
+
+// upsertSelect is the query of an insert with an upsert clause.
+// SQLite cannot tell if the ON of ON CONFLICT starts the constraint of a join
+// in the query, so the query is selected from with a WHERE clause, which ends it.
+// See https://www.sqlite.org/lang_upsert.html#parsing_ambiguity
+type upsertSelect struct {
+	query bob.Query
+}
+
+func (u upsertSelect) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	w.Write([]byte("SELECT * FROM ("))
+	args, err := u.query.WriteQuery(w, start)
+	if err != nil {
+		return nil, err
+	}
+	w.Write([]byte(") WHERE true"))
+
+	return args, nil
+}
@@ -116,6 +116,20 @@ func (f FromChain[Q]) As(alias string) FromChain[Q] {
 	})
 }
 
+// In qualifies the table with the name of a database, such as one added with ATTACH DATABASE.
+// It only applies to a table given by its name
+//
+//	SQL: FROM "archive"."users"
+//	Go: sm.From("users").In("archive")
+func (f FromChain[Q]) In(database string) FromChain[Q] {
+	fr := f()
+	fr.Table = inDatabase(fr.Table, database)
+
+	return FromChain[Q](func() clause.From {
+		return fr
+	})
+}
+
 func (f FromChain[Q]) NotIndexed() bob.Mod[Q] {
 	i := ""
 	fr := f()
@@ -160,6 +174,25 @@ func (f JoinChain[Q]) IndexedBy(indexName string) bob.Mod[Q] {
 	})
 }
 
+// In qualifies the joined table with the name of a database, such as one added with ATTACH DATABASE.
+// It only applies to a table given by its name
+func (j JoinChain[Q]) In(database string) JoinChain[Q] {
+	jo := j()
+	jo.To.Table = inDatabase(jo.To.Table, database)
+
+	return JoinChain[Q](func() clause.Join {
+		return jo
+	})
+}
+
+func inDatabase(table any, database string) any {
+	if name, ok := table.(string); ok && database != "" {
+		return expr.Quote(database, name)
+	}
+
+	return table
+}
+
 func (j JoinChain[Q]) As(alias string) JoinChain[Q] {
 	jo := j()
 	jo.To.Alias = alias
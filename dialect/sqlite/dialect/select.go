@@ -1,7 +1,9 @@
 package dialect
 
 import (
+	"fmt"
 	"io"
+	"time"
 
 	"github.com/stephenafamo/bob"
 	"github.com/stephenafamo/bob/clause"
@@ -14,6 +16,8 @@ type SelectQuery struct {
 	clause.SelectList
 	Distinct bool
 	clause.From
+	// SQLite has no time travel queries, building a query with AsOf fails
+	AsOf time.Time
 	clause.Where
 	clause.GroupBy
 	clause.Having
@@ -26,6 +30,10 @@ type SelectQuery struct {
 }
 
 func (s SelectQuery) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	if !s.AsOf.IsZero() {
+		return nil, fmt.Errorf("%s: %w", bob.ConstructAsOf, bob.ErrUnsupported)
+	}
+
 	var args []any
 
 	withArgs, err := bob.ExpressIf(w, d, start+len(args), s.With,
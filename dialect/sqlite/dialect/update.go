@@ -20,6 +20,10 @@ type UpdateQuery struct {
 }
 
 func (u UpdateQuery) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	if err := u.Where.CheckAllRows(); err != nil {
+		return nil, err
+	}
+
 	var args []any
 
 	withArgs, err := bob.ExpressIf(w, d, start+len(args), u.With,
@@ -0,0 +1,71 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/sqlite"
+	"github.com/stephenafamo/bob/dialect/sqlite/dialect"
+	"github.com/stephenafamo/bob/dialect/sqlite/im"
+	"github.com/stephenafamo/bob/dialect/sqlite/um"
+	_ "modernc.org/sqlite"
+)
+
+func TestRequiredFeatures(t *testing.T) {
+	q := sqlite.Update(
+		um.Table("users"),
+		um.SetCol("name").ToArg("Bob"),
+		um.From("accounts"),
+		um.Where(sqlite.Quote("users", "id").EQ(sqlite.Quote("accounts", "user_id"))),
+		um.Returning("id"),
+	)
+
+	want := []bob.Feature{dialect.FeatureUpdateFrom, dialect.FeatureReturning}
+	if got := q.RequiredFeatures(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	old := bob.Server{Name: bob.ServerSQLite, Version: bob.Version{Major: 3, Minor: 34}}
+	if err := old.Check(q); !errors.Is(err, bob.ErrUnsupported) {
+		t.Fatalf("expected ErrUnsupported, got %v", err)
+	}
+}
+
+func TestUpsertFeature(t *testing.T) {
+	q := sqlite.Insert(
+		im.Into("users", "name"),
+		im.Values(sqlite.Arg("Bob")),
+		im.OnConflict("name").DoNothing(),
+	)
+
+	want := []bob.Feature{dialect.FeatureUpsert}
+	if got := q.RequiredFeatures(); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	old := bob.Server{Name: bob.ServerSQLite, Version: bob.Version{Major: 3, Minor: 23}}
+	if err := old.Check(q); !errors.Is(err, bob.ErrUnsupported) {
+		t.Fatalf("expected ErrUnsupported, got %v", err)
+	}
+}
+
+func TestServerInfo(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	server, err := bob.ServerInfo(context.Background(), bob.NewDB(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if server.Name != bob.ServerSQLite || !server.Supports(dialect.FeatureReturning) {
+		t.Fatalf("unexpected server %+v", server)
+	}
+}
@@ -0,0 +1,13 @@
+package sqlite
+
+import (
+	"context"
+
+	"github.com/stephenafamo/bob"
+)
+
+// HealthCheck checks that the SQLite server responds, and returns its version
+// and the latency of the probe. See [bob.HealthCheck]
+func HealthCheck(ctx context.Context, exec bob.Executor) (bob.Health, error) {
+	return bob.HealthCheck(ctx, exec, "SELECT sqlite_version()")
+}
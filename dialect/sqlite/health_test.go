@@ -0,0 +1,37 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/sqlite"
+	_ "modernc.org/sqlite"
+)
+
+func TestHealthCheck(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	health, err := sqlite.HealthCheck(context.Background(), bob.NewDB(db))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.HasPrefix(health.Version, "3.") {
+		t.Fatalf("unexpected version %q", health.Version)
+	}
+	if health.Latency <= 0 {
+		t.Fatalf("unexpected latency %s", health.Latency)
+	}
+
+	db.Close()
+	if _, err := sqlite.HealthCheck(context.Background(), bob.NewDB(db)); err == nil {
+		t.Fatal("expected an error from a closed database")
+	}
+}
@@ -0,0 +1,95 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/sqlite"
+	"github.com/stephenafamo/bob/dialect/sqlite/sm"
+	"github.com/stephenafamo/bob/orm"
+	_ "modernc.org/sqlite"
+)
+
+func TestIdentityMap(t *testing.T) {
+	sqlDB, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	sqlDB.SetMaxOpenConns(1)
+
+	db := bob.NewDB(sqlDB)
+	if _, err := db.ExecContext(context.Background(), "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL)"); err != nil {
+		t.Fatal(err)
+	}
+
+	table := sqlite.NewTable[*changeUser, *changeUserSetter]("", "users")
+	ctx := orm.WithIdentityMap(context.Background())
+
+	inserted, err := table.Insert(ctx, db, &changeUserSetter{ID: 1, Name: "Alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byID, err := table.Query(ctx, db, sm.Where(sqlite.Quote("id").EQ(sqlite.Arg(1)))).One()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if byID != inserted {
+		t.Fatal("loading an inserted row returned a different pointer")
+	}
+
+	all, err := table.Query(ctx, db).All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 1 || all[0] != inserted {
+		t.Fatal("loading all rows returned a different pointer")
+	}
+
+	if o, ok := table.Identity(ctx, 1); !ok || o != inserted {
+		t.Fatal("the row is not in the identity map")
+	}
+
+	// Without the identity map, the rows are loaded again
+	other, err := table.Query(context.Background(), db).One()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other == inserted {
+		t.Fatal("the identity map was used without it being in the context")
+	}
+
+	// Partially loaded rows are not added
+	if _, err := db.ExecContext(ctx, "INSERT INTO users (id, name) VALUES (2, 'Bob')"); err != nil {
+		t.Fatal(err)
+	}
+	partial, err := table.Query(ctx, db, sm.Columns("id"), sm.Where(sqlite.Quote("id").EQ(sqlite.Arg(2)))).One()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if partial.Name != "" {
+		t.Fatalf("expected a partial row, got %+v", partial)
+	}
+	if _, ok := table.Identity(ctx, 2); ok {
+		t.Fatal("a partially loaded row was added to the identity map")
+	}
+
+	full, err := table.Query(ctx, db, sm.Where(sqlite.Quote("id").EQ(sqlite.Arg(2)))).One()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if full.Name != "Bob" {
+		t.Fatalf("expected the full row, got %+v", full)
+	}
+
+	// Deleted rows are forgotten
+	if err := table.Delete(ctx, db, inserted); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := table.Identity(ctx, 1); ok {
+		t.Fatal("a deleted row is still in the identity map")
+	}
+}
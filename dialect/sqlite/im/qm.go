@@ -1,10 +1,11 @@
 package im
 
 import (
+	"io"
+
 	"github.com/stephenafamo/bob"
 	"github.com/stephenafamo/bob/clause"
 	"github.com/stephenafamo/bob/dialect/sqlite/dialect"
-	"github.com/stephenafamo/bob/expr"
 	"github.com/stephenafamo/bob/internal"
 	"github.com/stephenafamo/bob/mods"
 )
@@ -105,11 +106,13 @@ func SetExcluded(cols ...string) bob.Mod[*clause.Conflict] {
 		if col == "" {
 			continue
 		}
-		exprs = append(exprs,
-			expr.Join{Exprs: []bob.Expression{
-				expr.Quote(col), expr.Raw("= EXCLUDED."), expr.Quote(col),
-			}},
-		)
+		col := col
+		exprs = append(exprs, bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+			d.WriteQuoted(w, col)
+			w.Write([]byte(" = EXCLUDED."))
+			d.WriteQuoted(w, col)
+			return nil, nil
+		}))
 	}
 
 	return mods.QueryModFunc[*clause.Conflict](func(c *clause.Conflict) {
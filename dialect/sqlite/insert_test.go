@@ -66,10 +66,40 @@ func TestInsert(t *testing.T) {
 			ExpectedSQL: `INSERT INTO distributors AS "d" ("did", "dname")
 				VALUES (?1, ?2), (?3, ?4)
 				ON CONFLICT (did) DO UPDATE
-				SET "dname" = EXCLUDED. "dname"
+				SET "dname" = EXCLUDED."dname"
 				WHERE ("d"."zipcode" <> '21201')`,
 			ExpectedArgs: []any{8, "Anvil Distribution", 9, "Sentry Distribution"},
 		},
+		"upsert with args": {
+			Query: sqlite.Insert(
+				im.Into("counters", "name", "hits"),
+				im.Values(sqlite.Arg("home", 1)),
+				im.OnConflict("name").Where(sqlite.Quote("active")).DoUpdate(
+					im.SetCol("hits").To(sqlite.Raw("hits + ?", 1)),
+					im.Where(sqlite.Quote("hits").LT(sqlite.Arg(100))),
+				),
+			),
+			ExpectedSQL: `INSERT INTO counters ("name", "hits")
+				VALUES (?1, ?2)
+				ON CONFLICT (name) WHERE "active" DO UPDATE
+				SET "hits" = hits + ?3
+				WHERE ("hits" < ?4)`,
+			ExpectedArgs: []any{"home", 1, 1, 100},
+		},
+		"upsert from select": {
+			Query: sqlite.Insert(
+				im.Into("totals", "name", "hits"),
+				im.Query(sqlite.Select(
+					sm.Columns("name", "hits"),
+					sm.From("counters"),
+					sm.InnerJoin("pages").Using("name"),
+				)),
+				im.OnConflict("name").DoNothing(),
+			),
+			ExpectedSQL: `INSERT INTO totals ("name", "hits")
+				SELECT * FROM (SELECT name, hits FROM counters INNER JOIN pages USING ("name")) WHERE true
+				ON CONFLICT (name) DO NOTHING`,
+		},
 		"or replace": {
 			Query: sqlite.Insert(
 				im.OrReplace(),
@@ -81,6 +111,15 @@ func TestInsert(t *testing.T) {
 				VALUES (?1, ?2), (?3, ?4)`,
 			ExpectedArgs: []any{8, "Anvil Distribution", 9, "Sentry Distribution"},
 		},
+		"returning": {
+			Query: sqlite.Insert(
+				im.Into("distributors", "dname"),
+				im.Values(sqlite.Arg("XYZ Widgets")),
+				im.Returning("did", "dname"),
+			),
+			ExpectedSQL:  `INSERT INTO distributors ("dname") VALUES (?1) RETURNING did, dname`,
+			ExpectedArgs: []any{"XYZ Widgets"},
+		},
 	}
 
 	testutils.RunTests(t, examples, formatter)
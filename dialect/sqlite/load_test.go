@@ -0,0 +1,80 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/sqlite/sm"
+	"github.com/stephenafamo/bob/orm"
+	"github.com/stephenafamo/scan"
+	_ "modernc.org/sqlite"
+)
+
+type jsonVideo struct {
+	ID      int64     `db:"id"`
+	Title   string    `db:"title"`
+	Created time.Time `db:"created"`
+}
+
+type jsonUser struct {
+	ID     int64 `db:"id"`
+	Videos []*jsonVideo
+}
+
+func (u *jsonUser) Preload(name string, rel any) error {
+	u.Videos = rel.([]*jsonVideo)
+	return nil
+}
+
+func TestPreloadJSON(t *testing.T) {
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(`CREATE TABLE users (id INTEGER PRIMARY KEY);
+		CREATE TABLE videos (id INTEGER PRIMARY KEY, user_id INTEGER, title TEXT, created DATETIME);
+		INSERT INTO users VALUES (1), (2);
+		INSERT INTO videos VALUES (1, 1, 'first', '2023-01-02 10:00:00'), (2, 1, 'second', '2023-01-03 10:00:00');`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rel := orm.Relationship{
+		Name: "Videos",
+		Sides: []orm.RelSide{{
+			From:        "users",
+			To:          "videos",
+			FromColumns: []string{"id"},
+			ToColumns:   []string{"user_id"},
+			ToExpr:      func(context.Context) bob.Expression { return Quote("videos") },
+		}},
+	}
+
+	q := Select(sm.Columns("id"), sm.From("users"), sm.OrderBy("id"))
+	q.Expression.SetLoadContext(context.Background())
+	q.Apply(PreloadJSON[*jsonVideo, []*jsonVideo](rel, []string{"id", "title", "created"}))
+
+	users, err := bob.All(context.Background(), bob.New(db), q, scan.StructMapper[*jsonUser]())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(users) != 2 {
+		t.Fatalf("expected 2 users, got %d", len(users))
+	}
+
+	if len(users[0].Videos) != 2 || len(users[1].Videos) != 0 {
+		t.Fatalf("wrong number of videos: %d and %d", len(users[0].Videos), len(users[1].Videos))
+	}
+
+	got := *users[0].Videos[1]
+	expected := jsonVideo{ID: 2, Title: "second", Created: time.Date(2023, 1, 3, 10, 0, 0, 0, time.UTC)}
+	if got != expected {
+		t.Fatalf("expected %+v, got %+v", expected, got)
+	}
+}
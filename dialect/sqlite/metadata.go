@@ -0,0 +1,92 @@
+package sqlite
+
+import (
+	"context"
+	"strings"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/scan"
+)
+
+// IndexInfo is an index of a table, see [ListIndexes]
+type IndexInfo struct {
+	Table string
+	Name  string
+	// The columns of the index. The expressions of indexes are left out
+	Columns []string
+	Unique  bool
+	Primary bool
+	// If the index has a WHERE clause
+	Partial bool
+}
+
+type indexRow struct {
+	Name    string  `db:"name"`
+	Columns *string `db:"columns"`
+	Unique  bool    `db:"unique"`
+	Primary bool    `db:"primary"`
+	Partial bool    `db:"partial"`
+}
+
+// ListIndexes returns the indexes of the table. A rowid table has no index for an
+// INTEGER PRIMARY KEY, since the rowid is the primary key
+//
+//	indexes, err := sqlite.ListIndexes(ctx, db, "users")
+func ListIndexes(ctx context.Context, exec bob.Executor, table string) ([]IndexInfo, error) {
+	rows, err := bob.All(ctx, exec, RawQuery(`SELECT
+		il.name AS name,
+		(SELECT group_concat(ii.name, ',') FROM (
+			SELECT name FROM pragma_index_info(il.name) ORDER BY seqno
+		) AS ii) AS columns,
+		il."unique" AS "unique",
+		il.origin = 'pk' AS "primary",
+		il.partial AS partial
+	FROM pragma_index_list(?) AS il
+	ORDER BY il.name`, table), scan.StructMapper[indexRow]())
+	if err != nil {
+		return nil, err
+	}
+
+	indexes := make([]IndexInfo, len(rows))
+	for i, row := range rows {
+		indexes[i] = IndexInfo{
+			Table:   table,
+			Name:    row.Name,
+			Unique:  row.Unique,
+			Primary: row.Primary,
+			Partial: row.Partial,
+		}
+		if row.Columns != nil && *row.Columns != "" {
+			indexes[i].Columns = strings.Split(*row.Columns, ",")
+		}
+	}
+
+	return indexes, nil
+}
+
+// TableSize is the size of a table in the database file, see [TableSizes]
+type TableSize struct {
+	Table string `db:"table"`
+	// The size of the pages of the table
+	TableBytes int64 `db:"table_bytes"`
+	// The size of the pages of the indexes of the table
+	IndexBytes int64 `db:"index_bytes"`
+	// The unused space in the pages of the table and its indexes, which VACUUM gives back
+	FreeBytes int64 `db:"free_bytes"`
+}
+
+// TableSizes returns the sizes of the tables in the main database, largest first.
+// It reads the dbstat virtual table, so SQLite must be compiled with SQLITE_ENABLE_DBSTAT_VTAB.
+// Otherwise, it returns the error of the missing dbstat table
+func TableSizes(ctx context.Context, exec bob.Executor) ([]TableSize, error) {
+	return bob.All(ctx, exec, RawQuery(`SELECT
+		m.tbl_name AS "table",
+		SUM(CASE WHEN m.type = 'table' THEN s.pgsize ELSE 0 END) AS table_bytes,
+		SUM(CASE WHEN m.type = 'index' THEN s.pgsize ELSE 0 END) AS index_bytes,
+		SUM(s.unused) AS free_bytes
+	FROM dbstat AS s
+	INNER JOIN sqlite_schema AS m ON m.name = s.name
+	WHERE m.tbl_name NOT LIKE 'sqlite_%'
+	GROUP BY m.tbl_name
+	ORDER BY table_bytes + index_bytes DESC, m.tbl_name`), scan.StructMapper[TableSize]())
+}
@@ -0,0 +1,62 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/sqlite"
+	_ "modernc.org/sqlite"
+)
+
+func TestMetadata(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	_, err = db.Exec(`
+		CREATE TABLE users (id TEXT PRIMARY KEY, email TEXT NOT NULL UNIQUE, name TEXT);
+		CREATE INDEX users_name ON users (name, email) WHERE name IS NOT NULL;
+		INSERT INTO users VALUES ('1', 'a@example.com', 'A');
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	exec := bob.NewDB(db)
+
+	indexes, err := sqlite.ListIndexes(ctx, exec, "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []sqlite.IndexInfo{
+		{Table: "users", Name: "sqlite_autoindex_users_1", Columns: []string{"id"}, Unique: true, Primary: true},
+		{Table: "users", Name: "sqlite_autoindex_users_2", Columns: []string{"email"}, Unique: true},
+		{Table: "users", Name: "users_name", Columns: []string{"name", "email"}, Partial: true},
+	}
+	if !reflect.DeepEqual(indexes, want) {
+		t.Fatalf("got %+v\nwant %+v", indexes, want)
+	}
+
+	sizes, err := sqlite.TableSizes(ctx, exec)
+	if err != nil && strings.Contains(err.Error(), "dbstat") {
+		// This build of SQLite has no dbstat table
+		return
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(sizes) != 1 || sizes[0].Table != "users" || sizes[0].TableBytes == 0 || sizes[0].IndexBytes == 0 {
+		t.Fatalf("unexpected sizes %+v", sizes)
+	}
+}
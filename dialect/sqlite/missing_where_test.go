@@ -0,0 +1,31 @@
+package sqlite_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/sqlite"
+	"github.com/stephenafamo/bob/dialect/sqlite/dm"
+	"github.com/stephenafamo/bob/dialect/sqlite/um"
+)
+
+func TestMissingWhere(t *testing.T) {
+	queries := map[string]bob.Query{
+		"update": sqlite.Update(
+			um.Table("films"),
+			um.SetCol("kind").ToArg("Drama"),
+		),
+		"delete": sqlite.Delete(
+			dm.From("films"),
+		),
+	}
+
+	for name, q := range queries {
+		t.Run(name, func(t *testing.T) {
+			if _, _, err := bob.Build(q); !errors.Is(err, bob.ErrMissingWhere) {
+				t.Fatalf("expected ErrMissingWhere, got %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,263 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/sqlite/dialect"
+	"github.com/stephenafamo/scan"
+)
+
+// Pragma builds a PRAGMA statement. With a value, the pragma is set.
+// SQLite does not take parameters in pragmas, so the value is written as a literal:
+// strings are quoted, booleans are ON or OFF, and a [time.Duration] is written in milliseconds
+//
+//	SQL: PRAGMA journal_mode = 'WAL'
+//	Go: sqlite.Pragma("journal_mode", "WAL")
+func Pragma(name string, value ...any) bob.BaseQuery[bob.Expression] {
+	return bob.BaseQuery[bob.Expression]{
+		Expression: pragma{name: name, value: value},
+		Dialect:    dialect.Dialect,
+	}
+}
+
+// JournalMode sets the journal mode, such as WAL or DELETE
+func JournalMode(mode string) bob.BaseQuery[bob.Expression] {
+	return Pragma("journal_mode", mode)
+}
+
+// BusyTimeout sets how long to wait for a lock before returning SQLITE_BUSY
+func BusyTimeout(d time.Duration) bob.BaseQuery[bob.Expression] {
+	return Pragma("busy_timeout", d)
+}
+
+// ForeignKeys turns the enforcement of foreign keys on or off
+func ForeignKeys(on bool) bob.BaseQuery[bob.Expression] {
+	return Pragma("foreign_keys", on)
+}
+
+type pragma struct {
+	name  string
+	value []any
+}
+
+func (p pragma) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	if p.name == "" || strings.TrimFunc(p.name, isPragmaNameRune) != "" {
+		return nil, fmt.Errorf("invalid pragma name %q", p.name)
+	}
+
+	if len(p.value) > 1 {
+		return nil, fmt.Errorf("pragma %s: only one value can be set", p.name)
+	}
+
+	w.Write([]byte("PRAGMA "))
+	w.Write([]byte(p.name))
+
+	if len(p.value) == 0 {
+		return nil, nil
+	}
+
+	value, err := pragmaValue(p.value[0])
+	if err != nil {
+		return nil, fmt.Errorf("pragma %s: %w", p.name, err)
+	}
+
+	w.Write([]byte(" = "))
+	w.Write([]byte(value))
+
+	return nil, nil
+}
+
+func isPragmaNameRune(r rune) bool {
+	return r == '_' || r == '.' ||
+		(r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9')
+}
+
+func pragmaValue(v any) (string, error) {
+	switch v := v.(type) {
+	case string:
+		return "'" + strings.ReplaceAll(v, "'", "''") + "'", nil
+	case bool:
+		if v {
+			return "ON", nil
+		}
+		return "OFF", nil
+	case time.Duration:
+		return strconv.FormatInt(v.Milliseconds(), 10), nil
+	case int:
+		return strconv.Itoa(v), nil
+	case int64:
+		return strconv.FormatInt(v, 10), nil
+	case uint:
+		return strconv.FormatUint(uint64(v), 10), nil
+	case uint64:
+		return strconv.FormatUint(v, 10), nil
+	default:
+		return "", fmt.Errorf("unsupported value of type %T", v)
+	}
+}
+
+// Setup holds the pragmas that every connection to a database should have.
+// Most pragmas, such as busy_timeout and foreign_keys, only apply to the connection
+// they are run on, so they have to be set each time the pool opens a connection.
+// Use [Setup.Connector] for that
+type Setup struct {
+	// The journal mode, such as WAL. It is kept in the database file
+	JournalMode string
+	// How long to wait for a lock before returning SQLITE_BUSY
+	BusyTimeout time.Duration
+	// Turns on the enforcement of foreign keys
+	ForeignKeys bool
+	// The synchronous setting, such as NORMAL, which is often used with WAL
+	Synchronous string
+	// Other pragmas or statements, such as [Attach], which are run after the ones above
+	Pragmas []bob.Query
+}
+
+// Queries returns the PRAGMA statements of the setup
+func (s Setup) Queries() []bob.Query {
+	var queries []bob.Query
+	if s.JournalMode != "" {
+		queries = append(queries, JournalMode(s.JournalMode))
+	}
+	if s.BusyTimeout > 0 {
+		queries = append(queries, BusyTimeout(s.BusyTimeout))
+	}
+	if s.ForeignKeys {
+		queries = append(queries, ForeignKeys(true))
+	}
+	if s.Synchronous != "" {
+		queries = append(queries, Pragma("synchronous", s.Synchronous))
+	}
+
+	return append(queries, s.Pragmas...)
+}
+
+// Apply runs the pragmas of the setup with the executor.
+// With a pool of connections, only the connection that runs them is set up
+func (s Setup) Apply(ctx context.Context, exec bob.Executor) error {
+	for _, q := range s.Queries() {
+		if _, err := bob.Exec(ctx, exec, q); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Connector returns a [driver.Connector] that runs the pragmas of the setup on
+// every new connection of the driver. Open it with [bob.OpenDB] or [sql.OpenDB]
+//
+//	db := bob.OpenDB(sqlite.Setup{JournalMode: "WAL"}.Connector(&sqlite3.SQLiteDriver{}, "app.db"))
+func (s Setup) Connector(d driver.Driver, dsn string) driver.Connector {
+	return setupConnector{driver: d, dsn: dsn, queries: s.Queries()}
+}
+
+type setupConnector struct {
+	driver  driver.Driver
+	dsn     string
+	queries []bob.Query
+}
+
+func (c setupConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	var conn driver.Conn
+	var err error
+	if dc, ok := c.driver.(driver.DriverContext); ok {
+		var connector driver.Connector
+		if connector, err = dc.OpenConnector(c.dsn); err == nil {
+			conn, err = connector.Connect(ctx)
+		}
+	} else {
+		conn, err = c.driver.Open(c.dsn)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.setup(ctx, conn); err != nil {
+		conn.Close()
+		return nil, err
+	}
+
+	return conn, nil
+}
+
+func (c setupConnector) setup(ctx context.Context, conn driver.Conn) error {
+	if len(c.queries) == 0 {
+		return nil
+	}
+
+	execer, ok := conn.(driver.ExecerContext)
+	if !ok {
+		return errors.New("sqlite setup: the driver connection cannot execute queries")
+	}
+
+	for _, q := range c.queries {
+		query, args, err := bob.Build(q)
+		if err != nil {
+			return err
+		}
+
+		named := make([]driver.NamedValue, len(args))
+		for i, arg := range args {
+			named[i] = driver.NamedValue{Ordinal: i + 1, Value: arg}
+		}
+
+		if _, err := execer.ExecContext(ctx, query, named); err != nil {
+			return fmt.Errorf("sqlite setup: %s: %w", query, err)
+		}
+	}
+
+	return nil
+}
+
+func (c setupConnector) Driver() driver.Driver {
+	return c.driver
+}
+
+// CheckpointMode is the mode of a WAL checkpoint
+type CheckpointMode string
+
+const (
+	// CheckpointPassive copies as many frames as possible without waiting for readers or writers
+	CheckpointPassive CheckpointMode = "PASSIVE"
+	// CheckpointFull waits for writers, then copies all the frames
+	CheckpointFull CheckpointMode = "FULL"
+	// CheckpointRestart is the same as CheckpointFull and also waits for readers,
+	// so that the next writer starts the WAL file from the beginning
+	CheckpointRestart CheckpointMode = "RESTART"
+	// CheckpointTruncate is the same as CheckpointRestart and also truncates the WAL file
+	CheckpointTruncate CheckpointMode = "TRUNCATE"
+)
+
+// CheckpointResult is the result of a WAL checkpoint
+type CheckpointResult struct {
+	// True if the checkpoint could not finish because of other connections
+	Busy bool `db:"busy"`
+	// The number of frames in the WAL file
+	Log int `db:"log"`
+	// The number of frames that were copied into the database
+	Checkpointed int `db:"checkpointed"`
+}
+
+// Checkpoint copies the content of the WAL file into the database with
+// PRAGMA wal_checkpoint. An empty mode is the same as [CheckpointPassive].
+// For a database that is not in WAL mode, Log and Checkpointed are -1
+func Checkpoint(ctx context.Context, exec bob.Executor, mode CheckpointMode) (CheckpointResult, error) {
+	switch mode {
+	case "":
+		mode = CheckpointPassive
+	case CheckpointPassive, CheckpointFull, CheckpointRestart, CheckpointTruncate:
+	default:
+		return CheckpointResult{}, fmt.Errorf("invalid checkpoint mode %q", mode)
+	}
+
+	return bob.One(ctx, exec, RawQuery("PRAGMA wal_checkpoint("+string(mode)+")"), scan.StructMapper[CheckpointResult]())
+}
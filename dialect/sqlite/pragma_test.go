@@ -0,0 +1,101 @@
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/sqlite"
+	"github.com/stephenafamo/scan"
+	msqlite "modernc.org/sqlite"
+)
+
+func TestPragma(t *testing.T) {
+	cases := map[string]struct {
+		query bob.Query
+		sql   string
+	}{
+		"read":         {sqlite.Pragma("main.journal_mode"), "PRAGMA main.journal_mode"},
+		"journal mode": {sqlite.JournalMode("WAL"), "PRAGMA journal_mode = 'WAL'"},
+		"busy timeout": {sqlite.BusyTimeout(5 * time.Second), "PRAGMA busy_timeout = 5000"},
+		"foreign keys": {sqlite.ForeignKeys(true), "PRAGMA foreign_keys = ON"},
+		"quoted":       {sqlite.Pragma("optimize", "it's"), "PRAGMA optimize = 'it''s'"},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			sql, args, err := bob.Build(tc.query)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if sql != tc.sql || len(args) != 0 {
+				t.Fatalf("got %q %v, want %q", sql, args, tc.sql)
+			}
+		})
+	}
+
+	if _, _, err := bob.Build(sqlite.Pragma("user_version; DROP TABLE users")); err == nil {
+		t.Fatal("expected an error for an invalid name")
+	}
+	if _, _, err := bob.Build(sqlite.Pragma("user_version", 1.5)); err == nil {
+		t.Fatal("expected an error for an unsupported value")
+	}
+}
+
+func TestSetupConnector(t *testing.T) {
+	ctx := context.Background()
+
+	setup := sqlite.Setup{
+		JournalMode: "WAL",
+		BusyTimeout: time.Second,
+		ForeignKeys: true,
+	}
+	db := bob.OpenDB(setup.Connector(&msqlite.Driver{}, filepath.Join(t.TempDir(), "test.db")))
+	defer db.Close()
+
+	// Every connection of the pool is set up
+	for i := 0; i < 2; i++ {
+		conn, err := db.Conn(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer conn.Close()
+
+		timeout, err := bob.One(ctx, conn, sqlite.Pragma("busy_timeout"), scan.SingleColumnMapper[int])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if timeout != 1000 {
+			t.Fatalf("connection %d: got busy timeout %d", i, timeout)
+		}
+
+		fk, err := bob.One(ctx, conn, sqlite.Pragma("foreign_keys"), scan.SingleColumnMapper[bool])
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !fk {
+			t.Fatalf("connection %d: foreign keys are off", i)
+		}
+	}
+
+	if _, err := db.ExecContext(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY)"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.ExecContext(ctx, "INSERT INTO users (id) VALUES (1), (2)"); err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := sqlite.Checkpoint(ctx, db, sqlite.CheckpointTruncate)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Busy || result.Log != 0 || result.Checkpointed != 0 {
+		t.Fatalf("unexpected result after truncating %+v", result)
+	}
+
+	if _, err := sqlite.Checkpoint(ctx, db, "NOW"); err == nil {
+		t.Fatal("expected an error for an invalid mode")
+	}
+}
@@ -0,0 +1,107 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/sqlite"
+	"github.com/stephenafamo/bob/dialect/sqlite/dm"
+	"github.com/stephenafamo/bob/dialect/sqlite/im"
+	"github.com/stephenafamo/bob/dialect/sqlite/um"
+	"github.com/stephenafamo/scan"
+)
+
+func TestReturning(t *testing.T) {
+	ctx := context.Background()
+
+	sqlDB, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	sqlDB.SetMaxOpenConns(1)
+	db := bob.NewDB(sqlDB)
+
+	if _, err := db.ExecContext(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, active BOOLEAN DEFAULT true)"); err != nil {
+		t.Fatal(err)
+	}
+
+	type user struct {
+		ID     int    `db:"id"`
+		Name   string `db:"name"`
+		Active bool   `db:"active"`
+	}
+
+	// Without a RETURNING clause, all the columns are returned
+	insert := sqlite.Insert(im.Into("users", "name"), im.Values(sqlite.Arg("Alice")))
+	alice, err := bob.ReturningOne[user](ctx, db, insert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alice != (user{ID: 1, Name: "Alice", Active: true}) {
+		t.Fatalf("unexpected user %+v", alice)
+	}
+
+	// The query itself is not changed
+	sql, _, err := bob.Build(insert)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(sql, "RETURNING") {
+		t.Fatalf("the query was changed: %q", sql)
+	}
+
+	if _, err := bob.Exec(ctx, db, sqlite.Insert(im.Into("users", "name"), im.Values(sqlite.Arg("Bob")))); err != nil {
+		t.Fatal(err)
+	}
+
+	// With a RETURNING clause
+	renamed, err := bob.ReturningAll[user](ctx, db, sqlite.Update(
+		um.Table("users"),
+		um.SetCol("active").ToArg(false),
+		um.Where(sqlite.Quote("id").GT(sqlite.Arg(0))),
+		um.Returning("id", "active"),
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(renamed) != 2 || renamed[0].Active || renamed[0].Name != "" {
+		t.Fatalf("unexpected users %+v", renamed)
+	}
+
+	deleted, err := bob.ReturningOne[*user](ctx, db, sqlite.Delete(dm.From("users"), dm.Where(sqlite.Quote("id").EQ(sqlite.Arg(2)))))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if deleted.Name != "Bob" {
+		t.Fatalf("unexpected deleted user %+v", deleted)
+	}
+
+	// The returned rows can be mapped with any mapper
+	carol, err := bob.One(ctx, db, sqlite.Insert(
+		im.Into("users", "name"),
+		im.Values(sqlite.Arg("Carol")),
+		im.Returning("id", "name"),
+	), scan.StructMapper[user]())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if carol.ID == 0 || carol.Name != "Carol" {
+		t.Fatalf("unexpected user %+v", carol)
+	}
+
+	names, err := bob.All(ctx, db, sqlite.Delete(
+		dm.From("users"),
+		dm.Where(sqlite.Quote("active").EQ(sqlite.Arg(true))),
+		dm.Returning("name"),
+	), scan.SingleColumnMapper[string])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 1 || names[0] != "Carol" {
+		t.Fatalf("unexpected deleted names %v", names)
+	}
+}
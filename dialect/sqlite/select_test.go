@@ -22,6 +22,15 @@ func TestSelect(t *testing.T) {
 				sm.Where(sqlite.Quote("id").In(sqlite.Arg(100, 200, 300))),
 			),
 		},
+		"select in slice": {
+			ExpectedSQL:  `SELECT id, name FROM users WHERE ("id" IN (?1, ?2, ?3))`,
+			ExpectedArgs: []any{int64(100), int64(200), int64(300)},
+			Query: sqlite.Select(
+				sm.Columns("id", "name"),
+				sm.From("users"),
+				sm.Where(sqlite.Quote("id").InSlice([]int64{100, 200, 300})),
+			),
+		},
 		"select distinct": {
 			ExpectedSQL:  `SELECT DISTINCT id, name FROM users WHERE ("id" IN (?1, ?2, ?3))`,
 			ExpectedArgs: []any{100, 200, 300},
@@ -0,0 +1,70 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/sqlite"
+	"github.com/stephenafamo/bob/orm"
+	_ "modernc.org/sqlite"
+)
+
+type selectedUser struct {
+	ID        int    `db:"id,pk"`
+	Name      string `db:"name"`
+	Bio       string `db:"bio"`
+	selection *orm.Selection
+}
+
+func (u *selectedUser) SetSelection(s orm.Selection) { u.selection = &s }
+
+func TestSelectOnly(t *testing.T) {
+	ctx := context.Background()
+
+	sqlDB, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	sqlDB.SetMaxOpenConns(1)
+	db := bob.NewDB(sqlDB)
+
+	if _, err := db.ExecContext(ctx, `CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT, bio TEXT);
+		INSERT INTO users VALUES (1, 'Alice', 'A very long text')`); err != nil {
+		t.Fatal(err)
+	}
+
+	view := sqlite.NewView[*selectedUser]("", "users")
+
+	only, err := view.Query(ctx, db, view.SelectOnly("name")).One()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if *only != (selectedUser{ID: 1, Name: "Alice", selection: only.selection}) {
+		t.Fatalf("unexpected user: %#v", only)
+	}
+	if !only.selection.Partial() || only.selection.Has("bio") || !only.selection.Has("id") {
+		t.Fatal("the primary key and name should be the only selected columns")
+	}
+
+	except, err := view.Query(ctx, db, view.SelectExcept("bio", "id")).One()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if except.ID != 1 || except.Name != "Alice" || except.Bio != "" {
+		t.Fatalf("unexpected user: %#v", except)
+	}
+	if except.selection.Has("bio") {
+		t.Fatal("bio should not be selected")
+	}
+
+	full, err := view.Query(ctx, db).One()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if full.Bio == "" || full.selection != nil {
+		t.Fatal("a query without a projection should load all the columns")
+	}
+}
@@ -1,6 +1,8 @@
 package sm
 
 import (
+	"time"
+
 	"github.com/stephenafamo/bob"
 	"github.com/stephenafamo/bob/clause"
 	"github.com/stephenafamo/bob/dialect/sqlite/dialect"
@@ -29,6 +31,15 @@ func From(table any) dialect.FromChain[*dialect.SelectQuery] {
 	return dialect.From[*dialect.SelectQuery](table)
 }
 
+// AsOf is not supported by SQLite, which has no time travel queries.
+// Building the query returns an error wrapping [bob.ErrUnsupported],
+// so that code shared with other dialects fails clearly
+func AsOf(t time.Time) bob.Mod[*dialect.SelectQuery] {
+	return mods.QueryModFunc[*dialect.SelectQuery](func(q *dialect.SelectQuery) {
+		q.AsOf = t
+	})
+}
+
 func InnerJoin(e any) dialect.JoinChain[*dialect.SelectQuery] {
 	return dialect.InnerJoin[*dialect.SelectQuery](e)
 }
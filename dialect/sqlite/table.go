@@ -71,6 +71,9 @@ type Table[T orm.Table, Tslice ~[]T, Tset setter[T]] struct {
 	InsertQueryHooks orm.Hooks[*dialect.InsertQuery, orm.SkipQueryHooksKey]
 	UpdateQueryHooks orm.Hooks[*dialect.UpdateQuery, orm.SkipQueryHooksKey]
 	DeleteQueryHooks orm.Hooks[*dialect.DeleteQuery, orm.SkipQueryHooksKey]
+
+	// If set, the rows changed by Insert, Upsert, Update and Delete are published to it
+	Changes *orm.Changes
 }
 
 // Insert inserts a row into the table with only the set columns in Tset
@@ -89,6 +92,28 @@ func (t *Table[T, Tslice, Tset]) Insert(ctx context.Context, exec bob.Executor,
 
 // InsertMany inserts rows into the table with only the set columns in Tset
 func (t *Table[T, Tslice, Tset]) InsertMany(ctx context.Context, exec bob.Executor, rows ...Tset) (Tslice, error) {
+	var vals Tslice
+	err := orm.Savepoint(ctx, exec, func() error {
+		var err error
+		vals, err = t.insertMany(ctx, exec, rows...)
+		return err
+	})
+	if err != nil {
+		return vals, err
+	}
+
+	t.publishChanges(ctx, exec, orm.OpInsert, vals, func(i int) []string {
+		if i < len(rows) {
+			return rows[i].SetColumns()
+		}
+		return nil
+	})
+	t.Identify(ctx, vals...)
+
+	return vals, nil
+}
+
+func (t *Table[T, Tslice, Tset]) insertMany(ctx context.Context, exec bob.Executor, rows ...Tset) (Tslice, error) {
 	if len(rows) == 0 {
 		return nil, nil
 	}
@@ -100,6 +125,10 @@ func (t *Table[T, Tslice, Tset]) InsertMany(ctx context.Context, exec bob.Execut
 		return nil, err
 	}
 
+	if err = orm.ValidateInsert(ctx, rows...); err != nil {
+		return nil, err
+	}
+
 	// Just get the set columns in the first row
 	columns := rows[0].SetColumns()
 
@@ -117,7 +146,7 @@ func (t *Table[T, Tslice, Tset]) InsertMany(ctx context.Context, exec bob.Execut
 		return nil, err
 	}
 
-	vals, err := bob.All(ctx, exec, q, t.scanner)
+	vals, err := bob.All(orm.WithoutIdentityMap(ctx), exec, q, t.scanner)
 	if err != nil {
 		return vals, err
 	}
@@ -134,6 +163,22 @@ func (t *Table[T, Tslice, Tset]) InsertMany(ctx context.Context, exec bob.Execut
 // if columns is nil, every non-primary-key column is updated
 // NOTE: values from the DB are not refreshed into the model
 func (t *Table[T, Tslice, Tset]) Update(ctx context.Context, exec bob.Executor, vals Tset, rows ...T) error {
+	err := orm.Savepoint(ctx, exec, func() error {
+		return t.update(ctx, exec, vals, rows...)
+	})
+	if err != nil {
+		return err
+	}
+
+	columns := vals.SetColumns()
+	t.publishChanges(ctx, exec, orm.OpUpdate, rows, func(int) []string {
+		return columns
+	})
+
+	return nil
+}
+
+func (t *Table[T, Tslice, Tset]) update(ctx context.Context, exec bob.Executor, vals Tset, rows ...T) error {
 	if len(rows) == 0 {
 		return nil
 	}
@@ -143,6 +188,10 @@ func (t *Table[T, Tslice, Tset]) Update(ctx context.Context, exec bob.Executor,
 		return err
 	}
 
+	if err = orm.ValidateUpdate(ctx, vals); err != nil {
+		return err
+	}
+
 	pkPairs := make([]bob.Expression, len(rows))
 	for i, row := range rows {
 		pkPairs[i] = row.PrimaryKeyVals()
@@ -188,6 +237,28 @@ func (t *Table[T, Tslice, Tset]) Upsert(ctx context.Context, exec bob.Executor,
 // If updateCols is nil, it updates all the columns set in Tset
 // if no column is set in Tset (i.e. INSERT DEFAULT VALUES), then it upserts all NonPK columns
 func (t *Table[T, Tslice, Tset]) UpsertMany(ctx context.Context, exec bob.Executor, updateOnConflict bool, conflictCols, updateCols []string, rows ...Tset) (Tslice, error) {
+	var vals Tslice
+	err := orm.Savepoint(ctx, exec, func() error {
+		var err error
+		vals, err = t.upsertMany(ctx, exec, updateOnConflict, conflictCols, updateCols, rows...)
+		return err
+	})
+	if err != nil {
+		return vals, err
+	}
+
+	t.publishChanges(ctx, exec, orm.OpUpsert, vals, func(i int) []string {
+		if i < len(rows) {
+			return rows[i].SetColumns()
+		}
+		return nil
+	})
+	t.Identify(ctx, vals...)
+
+	return vals, nil
+}
+
+func (t *Table[T, Tslice, Tset]) upsertMany(ctx context.Context, exec bob.Executor, updateOnConflict bool, conflictCols, updateCols []string, rows ...Tset) (Tslice, error) {
 	if len(rows) == 0 {
 		return nil, nil
 	}
@@ -199,6 +270,10 @@ func (t *Table[T, Tslice, Tset]) UpsertMany(ctx context.Context, exec bob.Execut
 		return nil, err
 	}
 
+	if err = orm.ValidateInsert(ctx, rows...); err != nil {
+		return nil, err
+	}
+
 	// Just get the set columns in the first row
 	columns := rows[0].SetColumns()
 
@@ -238,7 +313,7 @@ func (t *Table[T, Tslice, Tset]) UpsertMany(ctx context.Context, exec bob.Execut
 		return nil, err
 	}
 
-	vals, err := bob.All(ctx, exec, q, t.scanner)
+	vals, err := bob.All(orm.WithoutIdentityMap(ctx), exec, q, t.scanner)
 	if err != nil {
 		return vals, err
 	}
@@ -254,6 +329,69 @@ func (t *Table[T, Tslice, Tset]) UpsertMany(ctx context.Context, exec bob.Execut
 // Deletes the given model
 // if columns is nil, every column is deleted
 func (t *Table[T, Tslice, Tset]) Delete(ctx context.Context, exec bob.Executor, rows ...T) error {
+	err := orm.Savepoint(ctx, exec, func() error {
+		return t.delete(ctx, exec, rows...)
+	})
+	if err != nil {
+		return err
+	}
+
+	t.publishChanges(ctx, exec, orm.OpDelete, rows, nil)
+	t.forget(ctx, rows)
+
+	return nil
+}
+
+// UnitInsert adds rows to insert into the table when the unit of work is flushed
+func (t *Table[T, Tslice, Tset]) UnitInsert(u *bob.UnitOfWork, rows ...Tset) *bob.Pending[Tslice] {
+	return bob.UnitInsert[T, Tslice, Tset](u, t.alias, t, rows...)
+}
+
+// UnitUpdate adds an update of the rows with vals when the unit of work is flushed
+func (t *Table[T, Tslice, Tset]) UnitUpdate(u *bob.UnitOfWork, vals Tset, rows ...T) {
+	bob.UnitUpdate[T, Tslice, Tset](u, t.alias, t, vals, rows...)
+}
+
+// UnitDelete adds rows to delete from the table when the unit of work is flushed
+func (t *Table[T, Tslice, Tset]) UnitDelete(u *bob.UnitOfWork, rows ...T) {
+	bob.UnitDelete[T, Tslice, Tset](u, t.alias, t, rows...)
+}
+
+// forget removes the rows from the identity map of the context, if it has one
+func (t *Table[T, Tslice, Tset]) forget(ctx context.Context, rows []T) {
+	identities := orm.IdentityMapFrom(ctx)
+	if identities == nil || len(t.pkIndexes) == 0 {
+		return
+	}
+
+	for _, row := range rows {
+		identities.Forget(t.alias, internal.FieldValues(row, t.pkIndexes)...)
+	}
+}
+
+// publishChanges publishes an event for each row if Changes is set
+func (t *Table[T, Tslice, Tset]) publishChanges(ctx context.Context, exec bob.Executor, op orm.Operation, rows []T, columns func(int) []string) {
+	if t.Changes == nil || len(rows) == 0 {
+		return
+	}
+
+	events := make([]orm.ChangeEvent, len(rows))
+	for i, row := range rows {
+		events[i] = orm.ChangeEvent{
+			Table:     t.alias,
+			Operation: op,
+			PK:        internal.FieldValues(row, t.pkIndexes),
+			Row:       row,
+		}
+		if columns != nil {
+			events[i].Columns = columns(i)
+		}
+	}
+
+	t.Changes.Publish(ctx, exec, events...)
+}
+
+func (t *Table[T, Tslice, Tset]) delete(ctx context.Context, exec bob.Executor, rows ...T) error {
 	if len(rows) == 0 {
 		return nil
 	}
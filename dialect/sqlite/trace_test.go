@@ -0,0 +1,81 @@
+package sqlite_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/sqlite"
+	"github.com/stephenafamo/bob/dialect/sqlite/dialect"
+	"github.com/stephenafamo/bob/dialect/sqlite/sm"
+)
+
+func TestTraced(t *testing.T) {
+	q := bob.Traced(sqlite.Select,
+		sm.Columns("id", "name"),
+		sm.From("users"),
+		bob.TraceMod[*dialect.SelectQuery](sm.Where(sqlite.Quote("id").EQ(sqlite.Arg(100)))), // the line is checked below
+		sm.Limit(10),
+		sm.Where(sqlite.Quote("name").EQ(sqlite.Arg("Stephen"))),
+	)
+	q.Apply(sm.OrderBy("name"))
+
+	trace := q.Trace()
+	if trace == nil {
+		t.Fatal("no trace recorded")
+	}
+
+	if len(trace.Steps) != 6 {
+		t.Fatalf("expected 6 steps, got %d:\n%s", len(trace.Steps), trace)
+	}
+
+	where := trace.For("Where")
+	if len(where) != 2 {
+		t.Fatalf("expected 2 steps to change the where clause, got %d:\n%s", len(where), trace)
+	}
+
+	first := where[0]
+	if first.Mod != "mods.Where" {
+		t.Errorf("expected the mod to be mods.Where, got %q", first.Mod)
+	}
+	if first.Caller != "trace_test.go:18" {
+		t.Errorf("expected the caller to be where the mod was created, got %q", first.Caller)
+	}
+	if !reflect.DeepEqual(first.Changes[0].Args, []any{100}) {
+		t.Errorf("expected the args of the first where to be [100], got %v", first.Changes[0].Args)
+	}
+
+	second := where[1].Changes[0]
+	if !reflect.DeepEqual(second.Args, []any{"Stephen"}) {
+		t.Errorf("expected the args of the second where to be [Stephen], got %v", second.Args)
+	}
+	if !strings.Contains(second.Before, `"id" = ?1`) || !strings.Contains(second.After, `"name" = ?2`) {
+		t.Errorf("wrong where clause change: %#v", second)
+	}
+
+	if got := trace.Steps[0].Caller; got != "trace_test.go:15" {
+		t.Errorf("expected the caller of untraced mods to be the Traced call, got %q", got)
+	}
+	if got := trace.Steps[5]; got.Changes[0].Clause != "OrderBy" || got.Caller != "trace_test.go:22" {
+		t.Errorf("expected the applied mod to be recorded, got %#v", got)
+	}
+
+	clone := q.Clone()
+	clone.Apply(sm.Offset(5))
+	if len(clone.Trace().Steps) != 7 || len(q.Trace().Steps) != 6 {
+		t.Error("the trace of a clone should be independent")
+	}
+
+	transpiled, err := bob.Transpile(q, dialect.Dialect)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if transpiled.(bob.BaseQuery[*dialect.SelectQuery]).Trace() != trace {
+		t.Error("the trace should be kept when transpiling")
+	}
+
+	if sqlite.Select(sm.From("users")).Trace() != nil {
+		t.Error("untraced queries should not record a trace")
+	}
+}
@@ -0,0 +1,170 @@
+package sqlite_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/mysql"
+	mysqldialect "github.com/stephenafamo/bob/dialect/mysql/dialect"
+	"github.com/stephenafamo/bob/dialect/mysql/um"
+	"github.com/stephenafamo/bob/dialect/psql"
+	psqldialect "github.com/stephenafamo/bob/dialect/psql/dialect"
+	psqlim "github.com/stephenafamo/bob/dialect/psql/im"
+	psqlsm "github.com/stephenafamo/bob/dialect/psql/sm"
+	"github.com/stephenafamo/bob/dialect/sqlite"
+	"github.com/stephenafamo/bob/dialect/sqlite/dialect"
+	"github.com/stephenafamo/bob/dialect/sqlite/im"
+	"github.com/stephenafamo/bob/dialect/sqlite/sm"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+func TestTranspile(t *testing.T) {
+	examples := map[string]struct {
+		query        bob.Query
+		target       bob.Dialect
+		expectedSQL  string
+		expectedArgs []any
+	}{
+		"psql to sqlite": {
+			query: psql.Select(
+				psqlsm.Columns("id", psql.Quote("users", "name")),
+				psqlsm.Distinct(),
+				psqlsm.From("users"),
+				psqlsm.LeftJoin("jets").On(psql.Quote("jets", "pilot_id").EQ(psql.Quote("users", "id"))),
+				psqlsm.Where(psql.Quote("id").In(psql.Arg(100, 200))),
+				psqlsm.Limit(10),
+			),
+			target:       dialect.Dialect,
+			expectedSQL:  `SELECT DISTINCT id, "users"."name" FROM users LEFT JOIN jets ON ("jets"."pilot_id" = "users"."id") WHERE ("id" IN (?1, ?2)) LIMIT 10`,
+			expectedArgs: []any{100, 200},
+		},
+		"psql insert with returning to sqlite": {
+			query: psql.Insert(
+				psqlim.Into("users", "name"),
+				psqlim.Values(psql.Arg("Stephen")),
+				psqlim.Returning("id"),
+			),
+			target:       dialect.Dialect,
+			expectedSQL:  `INSERT INTO users ("name") VALUES (?1) RETURNING id`,
+			expectedArgs: []any{"Stephen"},
+		},
+		"sqlite to psql": {
+			query: sqlite.Insert(
+				im.Into("users", "name", "age"),
+				im.Values(sqlite.Arg("Stephen", 30)),
+				im.OnConflict("name").DoNothing(),
+			),
+			target:       psqldialect.Dialect,
+			expectedSQL:  `INSERT INTO users ("name", "age") VALUES ($1, $2) ON CONFLICT (name) DO NOTHING`,
+			expectedArgs: []any{"Stephen", 30},
+		},
+		"sqlite to mysql": {
+			query: sqlite.Select(
+				sm.Columns("id"),
+				sm.From("users"),
+				sm.Where(sqlite.Quote("name").EQ(sqlite.Arg("Stephen"))),
+			),
+			target:       mysqldialect.Dialect,
+			expectedSQL:  "SELECT id FROM users WHERE (`name` = ?)",
+			expectedArgs: []any{"Stephen"},
+		},
+	}
+
+	for name, tc := range examples {
+		t.Run(name, func(t *testing.T) {
+			q, err := bob.Transpile(tc.query, tc.target)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sql, args, err := bob.Build(q)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff, err := testutils.QueryDiff(tc.expectedSQL, sql, nil); err != nil {
+				t.Fatal(err)
+			} else if diff != "" {
+				t.Fatalf("diff: %s", diff)
+			}
+			if !reflect.DeepEqual(tc.expectedArgs, args) {
+				t.Fatalf("expected args %v, got %v", tc.expectedArgs, args)
+			}
+		})
+	}
+}
+
+func TestTranspileUnsupported(t *testing.T) {
+	examples := map[string]struct {
+		query    bob.Query
+		target   bob.Dialect
+		expected []bob.Construct
+	}{
+		"psql select to sqlite": {
+			query: psql.Select(
+				psqlsm.Distinct("id"),
+				psqlsm.From("users"),
+				psqlsm.ForUpdate(),
+			),
+			target:   dialect.Dialect,
+			expected: []bob.Construct{bob.ConstructDistinctOn, bob.ConstructLocking},
+		},
+		"sqlite insert to mysql": {
+			query: sqlite.Insert(
+				im.Into("users"),
+				im.Values(sqlite.Arg("Stephen")),
+				im.Returning("id"),
+			),
+			target:   mysqldialect.Dialect,
+			expected: []bob.Construct{bob.ConstructReturning},
+		},
+		"mysql update to sqlite": {
+			query: mysql.Update(
+				um.Table("users"),
+				um.SetCol("name").ToArg("Stephen"),
+				um.Limit(1),
+			),
+			target:   dialect.Dialect,
+			expected: []bob.Construct{bob.ConstructOrderedWrite},
+		},
+	}
+
+	for name, tc := range examples {
+		t.Run(name, func(t *testing.T) {
+			_, err := bob.Transpile(tc.query, tc.target)
+
+			var unsupported bob.UnsupportedError
+			if !errors.As(err, &unsupported) {
+				t.Fatalf("expected an UnsupportedError, got %v", err)
+			}
+			if !reflect.DeepEqual(tc.expected, unsupported.Constructs) {
+				t.Fatalf("expected %v, got %v", tc.expected, unsupported.Constructs)
+			}
+		})
+	}
+}
+
+func TestTranspileTo(t *testing.T) {
+	q := psql.Select(psqlsm.From("users"), psqlsm.Where(psql.Quote("id").EQ(psql.Arg(1))))
+
+	for name, want := range map[string]string{
+		bob.ServerSQLite:   `SELECT * FROM users WHERE ("id" = ?1)`,
+		bob.ServerPostgres: `SELECT * FROM users WHERE ("id" = $1)`,
+		bob.ServerMySQL:    "SELECT * FROM users WHERE (`id` = ?)",
+	} {
+		transpiled, err := bob.TranspileTo(q, name)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+
+		sql, _, err := bob.Build(transpiled)
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if diff, err := testutils.QueryDiff(want, sql, nil); err != nil || diff != "" {
+			t.Fatalf("%s: diff: %s %v", name, diff, err)
+		}
+	}
+}
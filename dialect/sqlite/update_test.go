@@ -50,6 +50,16 @@ func TestUpdate(t *testing.T) {
 				))),
 			),
 		},
+		"returning": {
+			Query: sqlite.Update(
+				um.Table("films"),
+				um.SetCol("kind").ToArg("Dramatic"),
+				um.Where(sqlite.Quote("kind").EQ(sqlite.Arg("Drama"))),
+				um.Returning("code", "kind"),
+			),
+			ExpectedSQL:  `UPDATE films SET "kind" = ?1 WHERE ("kind" = ?2) RETURNING code, kind`,
+			ExpectedArgs: []any{"Dramatic", "Drama"},
+		},
 	}
 
 	testutils.RunTests(t, examples, formatter)
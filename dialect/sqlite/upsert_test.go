@@ -0,0 +1,91 @@
+package sqlite_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/sqlite"
+	"github.com/stephenafamo/bob/dialect/sqlite/im"
+	"github.com/stephenafamo/bob/dialect/sqlite/sm"
+)
+
+func TestUpsert(t *testing.T) {
+	ctx := context.Background()
+
+	sqlDB, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	sqlDB.SetMaxOpenConns(1)
+	db := bob.NewDB(sqlDB)
+
+	if _, err := db.ExecContext(ctx, `CREATE TABLE counters (name TEXT PRIMARY KEY, hits INTEGER);
+		CREATE TABLE pages (name TEXT)`); err != nil {
+		t.Fatal(err)
+	}
+
+	hit := func(name string, n int) {
+		t.Helper()
+
+		_, err := bob.Exec(ctx, db, sqlite.Insert(
+			im.Into("counters", "name", "hits"),
+			im.Values(sqlite.Arg(name, n)),
+			im.OnConflict("name").DoUpdate(
+				im.SetCol("hits").To(sqlite.Raw("hits + ?", n)),
+				im.Where(sqlite.Quote("hits").LT(sqlite.Arg(10))),
+			),
+		))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	hit("home", 4)
+	hit("home", 7)
+	hit("home", 1) // not updated, there are already 10 or more hits
+	hit("about", 2)
+
+	// A join in the inserted query is not confused with the ON CONFLICT clause
+	if _, err := db.ExecContext(ctx, "INSERT INTO pages VALUES ('home'), ('contact')"); err != nil {
+		t.Fatal(err)
+	}
+	_, err = bob.Exec(ctx, db, sqlite.Insert(
+		im.Into("counters", "name", "hits"),
+		im.Query(sqlite.Select(
+			sm.Columns(sqlite.Quote("pages", "name"), sqlite.Arg(0)),
+			sm.From("pages"),
+			sm.LeftJoin("counters").Using("name"),
+		)),
+		im.OnConflict("name").DoNothing(),
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	rows, err := db.QueryContext(ctx, "SELECT name || ':' || hits FROM counters ORDER BY name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var s string
+		if err := rows.Scan(&s); err != nil {
+			t.Fatal(err)
+		}
+		got = append(got, s)
+	}
+
+	want := []string{"about:2", "contact:0", "home:11"}
+	if len(got) != len(want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("got %v, want %v", got, want)
+		}
+	}
+}
@@ -41,12 +41,26 @@ func newView[T any, Tslice ~[]T](schema, tableName string) (*View[T, Tslice], ma
 
 	allCols := internal.MappingCols(mappings, alias)
 
+	var pkIndexes []int
+	for i, col := range mappings.PKs {
+		if col != "" {
+			pkIndexes = append(pkIndexes, i)
+		}
+	}
+
+	pk := func(row T) []any {
+		return internal.FieldValues(row, pkIndexes)
+	}
+
 	return &View[T, Tslice]{
-		schema:  schema,
-		name:    tableName,
-		alias:   alias,
-		allCols: allCols,
-		scanner: scan.StructMapper[T](),
+		schema:    schema,
+		name:      tableName,
+		alias:     alias,
+		allCols:   allCols,
+		pkIndexes: pkIndexes,
+		pkCols:    internal.FilterNonZero(mappings.PKs),
+		scanner: orm.IdentityMapper(alias, internal.FilterNonZero(mappings.All), pk,
+			orm.SelectionMapper(internal.FilterNonZero(mappings.All), orm.StructMapper[T]())),
 	}, mappings
 }
 
@@ -55,8 +69,10 @@ type View[T any, Tslice ~[]T] struct {
 	name   string
 	alias  string
 
-	allCols orm.Columns
-	scanner scan.Mapper[T]
+	allCols   orm.Columns
+	pkIndexes []int
+	pkCols    []string
+	scanner   scan.Mapper[T]
 
 	AfterSelectHooks orm.Hooks[Tslice, orm.SkipModelHooksKey]
 	SelectQueryHooks orm.Hooks[*dialect.SelectQuery, orm.SkipQueryHooksKey]
@@ -82,6 +98,45 @@ func (v *View[T, Tslice]) Columns() orm.Columns {
 	return v.allCols
 }
 
+// SelectOnly returns a mod that selects only the given columns and the primary key,
+// which the loaders and the identity map need. The fields of the other columns are
+// not loaded, and models that implement [orm.SelectionSetter] record which ones they are
+func (v *View[T, Tslice]) SelectOnly(cols ...string) bob.Mod[*dialect.SelectQuery] {
+	only := append(append([]string(nil), v.pkCols...), cols...)
+	return sm.Columns(v.allCols.Only(only...))
+}
+
+// SelectExcept returns a mod that selects all the columns except the given ones,
+// e.g. to leave out large blobs. The primary key is always selected
+func (v *View[T, Tslice]) SelectExcept(cols ...string) bob.Mod[*dialect.SelectQuery] {
+	return sm.Columns(v.allCols.Except(orm.Except(cols, v.pkCols...)...))
+}
+
+// Identity returns the row with the given primary key if it was already
+// loaded with the identity map of the context. See [orm.WithIdentityMap]
+func (v *View[T, Tslice]) Identity(ctx context.Context, pk ...any) (T, bool) {
+	row, ok := orm.IdentityMapFrom(ctx).Get(v.alias, pk...)
+	if !ok {
+		return *new(T), false
+	}
+
+	t, ok := row.(T)
+	return t, ok
+}
+
+// Identify adds the rows to the identity map of the context, if it has one,
+// replacing the rows kept with the same primary key
+func (v *View[T, Tslice]) Identify(ctx context.Context, rows ...T) {
+	identities := orm.IdentityMapFrom(ctx)
+	if identities == nil || len(v.pkIndexes) == 0 {
+		return
+	}
+
+	for _, row := range rows {
+		identities.Put(v.alias, row, internal.FieldValues(row, v.pkIndexes)...)
+	}
+}
+
 // Adds table name et al
 func (v *View[T, Tslice]) Query(ctx context.Context, exec bob.Executor, queryMods ...bob.Mod[*dialect.SelectQuery]) *ViewQuery[T, Tslice] {
 	q := &ViewQuery[T, Tslice]{
@@ -104,7 +159,7 @@ func (v *View[T, Tslice]) Prepare(ctx context.Context, exec bob.Preparer, queryM
 
 // Prepare a statement from an existing query that will be mapped to the view's type
 func (v *View[T, Tslice]) PrepareQuery(ctx context.Context, exec bob.Preparer, q bob.Query) (bob.QueryStmt[T, Tslice], error) {
-	return bob.PrepareQueryx[T, Tslice](ctx, exec, q, scan.StructMapper[T](), v.afterSelect(ctx, exec))
+	return bob.PrepareQueryx[T, Tslice](ctx, exec, q, orm.StructMapper[T](), v.afterSelect(ctx, exec))
 }
 
 func (v *View[T, Ts]) afterSelect(ctx context.Context, exec bob.Executor) bob.ExecOption[T] {
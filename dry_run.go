@@ -0,0 +1,132 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/stephenafamo/scan"
+)
+
+// DryRunValidation is how a dry run checks the queries with the database
+type DryRunValidation int
+
+const (
+	// The queries are only rendered, the database is not used
+	DryRunNoValidation DryRunValidation = iota
+	// The queries are validated by running EXPLAIN, which plans them without executing them
+	DryRunExplain
+	// The queries are validated by preparing them on the server, e.g. with PREPARE
+	// in Postgres. The executor has to be a [Preparer], and the driver has to
+	// prepare statements on the server for this to catch errors
+	DryRunPrepare
+)
+
+// DryRunOptions configure the executor returned by [DryRun]
+type DryRunOptions struct {
+	Validation DryRunValidation
+	// If set, the queries that would have been executed are printed
+	Printer DebugPrinter
+	// Run SELECT queries normally, since they do not change any data.
+	// By default they return no rows
+	AllowReads bool
+}
+
+// DryRun wraps an [Executor] so that queries are rendered and optionally
+// validated, but never executed. This is useful for "plan" modes in tools
+// that show what would be changed.
+//
+// Exec returns a result with no affected rows, and queries return no rows,
+// so [One] returns [sql.ErrNoRows]
+func DryRun(exec Executor, opts DryRunOptions) Executor {
+	return dryRunExecutor{exec: exec, opts: opts}
+}
+
+type dryRunExecutor struct {
+	exec Executor
+	opts DryRunOptions
+}
+
+func (d dryRunExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if err := d.check(ctx, query, args); err != nil {
+		return nil, err
+	}
+
+	return dryRunResult{}, nil
+}
+
+func (d dryRunExecutor) QueryContext(ctx context.Context, query string, args ...any) (scan.Rows, error) {
+	if d.opts.AllowReads && isRead(query) {
+		return d.exec.QueryContext(ctx, query, args...)
+	}
+
+	if err := d.check(ctx, query, args); err != nil {
+		return nil, err
+	}
+
+	return emptyRows{}, nil
+}
+
+func (d dryRunExecutor) check(ctx context.Context, query string, args []any) error {
+	if d.opts.Printer != nil {
+		d.opts.Printer.PrintQuery(query, args...)
+	}
+
+	switch d.opts.Validation {
+	case DryRunExplain:
+		rows, err := d.exec.QueryContext(ctx, "EXPLAIN "+query, args...)
+		if err != nil {
+			return fmt.Errorf("dry run: %w", err)
+		}
+		return rows.Close()
+
+	case DryRunPrepare:
+		p, ok := d.exec.(Preparer)
+		if !ok {
+			return errors.New("dry run: the executor cannot prepare statements")
+		}
+
+		stmt, err := p.PrepareContext(ctx, query)
+		if err != nil {
+			return fmt.Errorf("dry run: %w", err)
+		}
+
+		if c, ok := stmt.(io.Closer); ok {
+			return c.Close()
+		}
+	}
+
+	return nil
+}
+
+// isRead returns true for a SELECT query.
+// WITH is not included since CTEs can modify data in Postgres
+func isRead(query string) bool {
+	fields := strings.Fields(query)
+	if len(fields) == 0 {
+		return false
+	}
+
+	return strings.EqualFold(fields[0], "SELECT")
+}
+
+type dryRunResult struct{}
+
+func (dryRunResult) LastInsertId() (int64, error) { return 0, nil }
+
+func (dryRunResult) RowsAffected() (int64, error) { return 0, nil }
+
+type emptyRows struct{}
+
+func (emptyRows) Scan(...any) error { return sql.ErrNoRows }
+
+func (emptyRows) Columns() ([]string, error) { return nil, nil }
+
+func (emptyRows) Next() bool { return false }
+
+func (emptyRows) Close() error { return nil }
+
+func (emptyRows) Err() error { return nil }
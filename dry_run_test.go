@@ -0,0 +1,93 @@
+package bob
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stephenafamo/scan"
+	_ "modernc.org/sqlite"
+)
+
+func TestDryRun(t *testing.T) {
+	ctx := context.Background()
+
+	sqlDB, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	sqlDB.SetMaxOpenConns(1)
+
+	db := NewDB(sqlDB)
+	if _, err := db.ExecContext(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL)"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.ExecContext(ctx, "INSERT INTO users (name) VALUES ('Stephen')"); err != nil {
+		t.Fatal(err)
+	}
+
+	count := func() int {
+		t.Helper()
+		n, err := scan.One(ctx, db, scan.SingleColumnMapper[int], "SELECT count(*) FROM users")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	for _, validation := range []DryRunValidation{DryRunNoValidation, DryRunExplain, DryRunPrepare} {
+		buf := &bytes.Buffer{}
+		dry := DryRun(db, DryRunOptions{Validation: validation, Printer: writerPrinter{buf}})
+
+		result, err := dry.ExecContext(ctx, "INSERT INTO users (name) VALUES (?)", "Bob")
+		if err != nil {
+			t.Fatalf("%d: %v", validation, err)
+		}
+		if affected, _ := result.RowsAffected(); affected != 0 {
+			t.Fatalf("%d: expected no affected rows, got %d", validation, affected)
+		}
+
+		if _, err := dry.QueryContext(ctx, "DELETE FROM users RETURNING id"); err != nil {
+			t.Fatalf("%d: %v", validation, err)
+		}
+
+		_, err = scan.One(ctx, dry, scan.SingleColumnMapper[int], "SELECT id FROM users")
+		if !errors.Is(err, sql.ErrNoRows) {
+			t.Fatalf("%d: expected no rows, got %v", validation, err)
+		}
+
+		if buf.Len() == 0 {
+			t.Fatalf("%d: the queries were not printed", validation)
+		}
+
+		_, err = dry.ExecContext(ctx, "INSERT INTO missing (name) VALUES ('Bob')")
+		if validation == DryRunNoValidation && err != nil {
+			t.Fatalf("expected no validation, got %v", err)
+		}
+		// modernc.org/sqlite only compiles the statement when it is executed
+		if validation == DryRunExplain && err == nil {
+			t.Fatalf("%d: expected an error for a missing table", validation)
+		}
+	}
+
+	if n := count(); n != 1 {
+		t.Fatalf("expected the data to be unchanged, got %d rows", n)
+	}
+
+	dry := DryRun(db, DryRunOptions{AllowReads: true})
+	name, err := scan.One(ctx, dry, scan.SingleColumnMapper[string], "SELECT name FROM users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "Stephen" {
+		t.Fatalf("expected the read to run, got %q", name)
+	}
+
+	_, err = DryRun(NoopExecutor{}, DryRunOptions{Validation: DryRunPrepare}).ExecContext(ctx, "SELECT 1")
+	if err == nil {
+		t.Fatal("expected an error for an executor that cannot prepare")
+	}
+}
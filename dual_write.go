@@ -0,0 +1,222 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/stephenafamo/scan"
+)
+
+// ErrMirrorQueueFull is sent to the error sink of a [DualWriter] for a write
+// that was not mirrored because too many writes were waiting
+var ErrMirrorQueueFull = errors.New("mirror queue is full")
+
+// ErrDualWriterClosed is returned by a [DualWriter] after it was closed
+var ErrDualWriterClosed = errors.New("dual writer is closed")
+
+// MirrorError is a write that succeeded on the primary but not on the secondary
+// of a [DualWriter]
+type MirrorError struct {
+	Query string
+	Args  []any
+	Err   error
+}
+
+func (e MirrorError) Error() string {
+	return "mirror write: " + e.Err.Error()
+}
+
+func (e MirrorError) Unwrap() error {
+	return e.Err
+}
+
+// DualWriteOptions are the options of a [DualWriter]
+type DualWriteOptions struct {
+	// The number of writes that can wait to be mirrored, 1000 if zero.
+	// Once it is full, the writes are not mirrored and are sent to OnError
+	// with [ErrMirrorQueueFull], so that the primary is never slowed down
+	QueueSize int
+	// The time a write can take on the secondary, no limit if zero
+	Timeout time.Duration
+	// OnError is the error sink, called with a [MirrorError] for each
+	// write that was not mirrored
+	OnError func(MirrorError)
+	// OnLag is called after each mirrored write with the time since it was
+	// done on the primary, e.g. to record it in a histogram
+	OnLag func(time.Duration)
+}
+
+type mirrorWrite struct {
+	query string
+	args  []any
+	at    time.Time
+}
+
+// DualWriter is an [Executor] that sends all queries to the primary and mirrors
+// the writes that succeeded to the secondary, such as while moving to a new database.
+// A query is a write if it is not allowed by [ReadOnly]. Reads only go to the primary.
+//
+// The writes are mirrored in the background, one at a time and in the order they finished
+// on the primary, without the values of their context. A write sent with QueryContext is
+// mirrored once its rows are closed without an error. Writes in transactions
+// begun on the primary are not mirrored.
+//
+// The order is only the order of the primary for writes that do not run at the same time,
+// since two writes can finish in another order than they were committed. The secondary also
+// runs the same SQL, not the same values, so anything the database computes can differ,
+// such as now(), random() and the ids of serial or AUTO_INCREMENT columns.
+// Set these values with args, or compare the databases with [CompareResults].
+//
+// Call [DualWriter.Close] to wait for the waiting writes to be mirrored
+type DualWriter struct {
+	primary   Executor
+	secondary Executor
+	opts      DualWriteOptions
+
+	queue chan mirrorWrite
+	done  chan struct{}
+
+	mu     sync.RWMutex
+	closed bool
+
+	lagMu sync.Mutex
+	lag   time.Duration
+}
+
+// DualWrite returns a [DualWriter] that mirrors the writes on primary to secondary
+func DualWrite(primary, secondary Executor, opts DualWriteOptions) *DualWriter {
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 1000
+	}
+
+	d := &DualWriter{
+		primary:   primary,
+		secondary: secondary,
+		opts:      opts,
+		queue:     make(chan mirrorWrite, opts.QueueSize),
+		done:      make(chan struct{}),
+	}
+	go d.mirror()
+
+	return d
+}
+
+func (d *DualWriter) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	result, err := d.primary.ExecContext(ctx, query, args...)
+	if err == nil && checkReadOnly(query) != nil {
+		d.enqueue(query, args)
+	}
+
+	return result, err
+}
+
+func (d *DualWriter) QueryContext(ctx context.Context, query string, args ...any) (scan.Rows, error) {
+	rows, err := d.primary.QueryContext(ctx, query, args...)
+	if err != nil || checkReadOnly(query) == nil {
+		return rows, err
+	}
+
+	return &mirroredRows{Rows: rows, done: func() { d.enqueue(query, args) }}, nil
+}
+
+// mirroredRows mirrors the write of its query once the rows are closed,
+// if reading them did not fail
+type mirroredRows struct {
+	scan.Rows
+	once sync.Once
+	done func()
+}
+
+func (r *mirroredRows) Close() error {
+	err := r.Rows.Close()
+	r.once.Do(func() {
+		if err == nil && r.Rows.Err() == nil {
+			r.done()
+		}
+	})
+
+	return err
+}
+
+// Pending returns the number of writes waiting to be mirrored
+func (d *DualWriter) Pending() int {
+	return len(d.queue)
+}
+
+// Lag returns the time between the last mirrored write and when it was done on the primary
+func (d *DualWriter) Lag() time.Duration {
+	d.lagMu.Lock()
+	defer d.lagMu.Unlock()
+
+	return d.lag
+}
+
+// Close stops mirroring new writes and waits until the waiting writes are mirrored,
+// or the context is done
+func (d *DualWriter) Close(ctx context.Context) error {
+	d.mu.Lock()
+	if !d.closed {
+		d.closed = true
+		close(d.queue)
+	}
+	d.mu.Unlock()
+
+	select {
+	case <-d.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (d *DualWriter) enqueue(query string, args []any) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	if d.closed {
+		d.sink(query, args, ErrDualWriterClosed)
+		return
+	}
+
+	select {
+	case d.queue <- mirrorWrite{query: query, args: args, at: time.Now()}:
+	default:
+		d.sink(query, args, ErrMirrorQueueFull)
+	}
+}
+
+func (d *DualWriter) mirror() {
+	defer close(d.done)
+
+	for w := range d.queue {
+		ctx, cancel := context.Background(), context.CancelFunc(func() {})
+		if d.opts.Timeout > 0 {
+			ctx, cancel = context.WithTimeout(ctx, d.opts.Timeout)
+		}
+
+		_, err := d.secondary.ExecContext(ctx, w.query, w.args...)
+		cancel()
+		if err != nil {
+			d.sink(w.query, w.args, err)
+			continue
+		}
+
+		lag := time.Since(w.at)
+		d.lagMu.Lock()
+		d.lag = lag
+		d.lagMu.Unlock()
+
+		if d.opts.OnLag != nil {
+			d.opts.OnLag(lag)
+		}
+	}
+}
+
+func (d *DualWriter) sink(query string, args []any, err error) {
+	if d.opts.OnError != nil {
+		d.opts.OnError(MirrorError{Query: query, Args: args, Err: err})
+	}
+}
@@ -0,0 +1,186 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stephenafamo/scan"
+	_ "modernc.org/sqlite"
+)
+
+// blockedExecutor waits for release before each query
+type blockedExecutor struct {
+	Executor
+	release chan struct{}
+}
+
+func (b blockedExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	<-b.release
+	return b.Executor.ExecContext(ctx, query, args...)
+}
+
+// failingRowsExecutor returns rows that fail while they are read
+type failingRowsExecutor struct {
+	Executor
+}
+
+func (failingRowsExecutor) QueryContext(ctx context.Context, query string, args ...any) (scan.Rows, error) {
+	return failingRows{}, nil
+}
+
+type failingRows struct {
+	emptyRows
+}
+
+func (failingRows) Err() error { return errors.New("constraint failed") }
+
+func TestDualWrite(t *testing.T) {
+	ctx := context.Background()
+
+	open := func() Executor {
+		t.Helper()
+		sqlDB, err := sql.Open("sqlite", ":memory:")
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { sqlDB.Close() })
+		sqlDB.SetMaxOpenConns(1)
+
+		if _, err := sqlDB.ExecContext(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT NOT NULL)"); err != nil {
+			t.Fatal(err)
+		}
+		return NewDB(sqlDB)
+	}
+
+	count := func(exec Executor) int {
+		t.Helper()
+		n, err := scan.One(ctx, exec, scan.SingleColumnMapper[int], "SELECT count(*) FROM users")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	t.Run("mirrors writes", func(t *testing.T) {
+		primary, secondary := open(), open()
+
+		var lags []time.Duration
+		dual := DualWrite(primary, secondary, DualWriteOptions{
+			OnLag: func(lag time.Duration) { lags = append(lags, lag) },
+		})
+
+		if _, err := dual.ExecContext(ctx, "INSERT INTO users (name) VALUES (?)", "Stephen"); err != nil {
+			t.Fatal(err)
+		}
+		rows, err := dual.QueryContext(ctx, "INSERT INTO users (name) VALUES (?) RETURNING id", "Bob")
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows.Close()
+		if count(dual) != 2 {
+			t.Fatal("expected the reads to go to the primary")
+		}
+
+		if err := dual.Close(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		if n := count(secondary); n != 2 {
+			t.Fatalf("expected 2 rows on the secondary, got %d", n)
+		}
+		if len(lags) != 2 {
+			t.Fatalf("expected the lag of 2 writes, got %d", len(lags))
+		}
+
+		if _, err := dual.ExecContext(ctx, "DELETE FROM users"); err != nil {
+			t.Fatal(err)
+		}
+		if n := count(secondary); n != 2 {
+			t.Fatal("expected the writes after closing not to be mirrored")
+		}
+	})
+
+	t.Run("failed rows", func(t *testing.T) {
+		secondary := open()
+		dual := DualWrite(failingRowsExecutor{Executor: open()}, secondary, DualWriteOptions{})
+
+		rows, err := dual.QueryContext(ctx, "INSERT INTO users (name) VALUES (?) RETURNING id", "Bob")
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows.Close()
+
+		if err := dual.Close(ctx); err != nil {
+			t.Fatal(err)
+		}
+		if n := count(secondary); n != 0 {
+			t.Fatal("expected a write whose rows failed not to be mirrored")
+		}
+	})
+
+	t.Run("error sink", func(t *testing.T) {
+		primary, secondary := open(), open()
+		if _, err := secondary.ExecContext(ctx, "DROP TABLE users"); err != nil {
+			t.Fatal(err)
+		}
+
+		var errs []MirrorError
+		dual := DualWrite(primary, secondary, DualWriteOptions{
+			OnError: func(err MirrorError) { errs = append(errs, err) },
+		})
+
+		if _, err := dual.ExecContext(ctx, "INSERT INTO users (name) VALUES (?)", "Stephen"); err != nil {
+			t.Fatalf("expected the write on the primary to succeed, got %v", err)
+		}
+		if err := dual.Close(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(errs) != 1 || errs[0].Query != "INSERT INTO users (name) VALUES (?)" {
+			t.Fatalf("expected the failed write in the sink, got %v", errs)
+		}
+	})
+
+	t.Run("full queue", func(t *testing.T) {
+		release := make(chan struct{})
+		secondary := blockedExecutor{Executor: open(), release: release}
+
+		var mu sync.Mutex
+		var errs []error
+		dual := DualWrite(open(), secondary, DualWriteOptions{
+			QueueSize: 1,
+			OnError: func(err MirrorError) {
+				mu.Lock()
+				errs = append(errs, err)
+				mu.Unlock()
+			},
+		})
+
+		// The first write is taken by the mirror, the second waits
+		// and the third does not fit in the queue
+		for i := 0; i < 3; i++ {
+			if _, err := dual.ExecContext(ctx, "INSERT INTO users (name) VALUES (?)", "Stephen"); err != nil {
+				t.Fatal(err)
+			}
+			for i == 0 && dual.Pending() > 0 {
+				time.Sleep(time.Millisecond)
+			}
+		}
+
+		close(release)
+		if err := dual.Close(ctx); err != nil {
+			t.Fatal(err)
+		}
+
+		if len(errs) != 1 || !errors.Is(errs[0], ErrMirrorQueueFull) {
+			t.Fatalf("expected one write to be dropped, got %v", errs)
+		}
+		if n := count(secondary.Executor); n != 2 {
+			t.Fatalf("expected 2 rows on the secondary, got %d", n)
+		}
+	})
+}
@@ -3,6 +3,7 @@ package bob
 import (
 	"context"
 	"database/sql"
+	"sync"
 
 	"github.com/stephenafamo/scan"
 )
@@ -14,6 +15,10 @@ type (
 
 	ExecSettings[T any] struct {
 		AfterSelect func(ctx context.Context, retrieved []T) error
+		// The number of rows to preallocate the result slice for
+		CapacityHint int
+		// A pool of *[]T to draw the result slice from
+		SlicePool *sync.Pool
 	}
 
 	ExecOption[T any] func(*ExecSettings[T])
@@ -25,6 +30,12 @@ type Executor interface {
 }
 
 func Exec(ctx context.Context, exec Executor, q Query) (sql.Result, error) {
+	if err := checkFeatures(exec, q); err != nil {
+		return nil, err
+	}
+
+	exec = withDefaultTimeZone(exec)
+
 	sql, args, err := Build(q)
 	if err != nil {
 		return nil, err
@@ -47,6 +58,12 @@ func Exec(ctx context.Context, exec Executor, q Query) (sql.Result, error) {
 }
 
 func One[T any](ctx context.Context, exec Executor, q Query, m scan.Mapper[T], opts ...ExecOption[T]) (T, error) {
+	if err := checkFeatures(exec, q); err != nil {
+		return *new(T), err
+	}
+
+	exec = withDefaultTimeZone(exec)
+
 	settings := ExecSettings[T]{}
 	for _, opt := range opts {
 		opt(&settings)
@@ -95,6 +112,12 @@ func All[T any](ctx context.Context, exec Executor, q Query, m scan.Mapper[T], o
 // this is especially useful for when the the [Query] is [Loadable] and the loader depends on the
 // return value implementing an interface
 func Allx[T any, Ts ~[]T](ctx context.Context, exec Executor, q Query, m scan.Mapper[T], opts ...ExecOption[T]) (Ts, error) {
+	if err := checkFeatures(exec, q); err != nil {
+		return nil, err
+	}
+
+	exec = withDefaultTimeZone(exec)
+
 	settings := ExecSettings[T]{}
 	for _, opt := range opts {
 		opt(&settings)
@@ -111,7 +134,7 @@ func Allx[T any, Ts ~[]T](ctx context.Context, exec Executor, q Query, m scan.Ma
 		}
 	}
 
-	rawSlice, err := scan.All(ctx, exec, m, sql, args...)
+	rawSlice, err := scanAll(ctx, exec, m, settings, sql, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -137,6 +160,12 @@ func Allx[T any, Ts ~[]T](ctx context.Context, exec Executor, q Query, m scan.Ma
 
 // Cursor returns a cursor that works similar to *sql.Rows
 func Cursor[T any](ctx context.Context, exec Executor, q Query, m scan.Mapper[T], opts ...ExecOption[T]) (scan.ICursor[T], error) {
+	if err := checkFeatures(exec, q); err != nil {
+		return nil, err
+	}
+
+	exec = withDefaultTimeZone(exec)
+
 	settings := ExecSettings[T]{}
 	for _, opt := range opts {
 		opt(&settings)
@@ -0,0 +1,55 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNoRowsAffected is returned by [ExecExpectOne] and [ExecExpectN]
+	// when the query did not change any row
+	ErrNoRowsAffected = errors.New("no rows affected")
+	// ErrTooFewRowsAffected is returned by [ExecExpectN] when the query changed
+	// some rows, but fewer than expected
+	ErrTooFewRowsAffected = errors.New("too few rows affected")
+	// ErrTooManyRowsAffected is returned by [ExecExpectOne] and [ExecExpectN]
+	// when the query changed more rows than expected
+	ErrTooManyRowsAffected = errors.New("too many rows affected")
+)
+
+// ExecExpectOne executes the query and checks that it changed exactly one row,
+// such as an update with an optimistic lock on the version of the row.
+// The changes are already made when too many rows are affected, so run the query
+// in a transaction that is rolled back on error if that has to be undone
+func ExecExpectOne(ctx context.Context, exec Executor, q Query) (sql.Result, error) {
+	return ExecExpectN(ctx, exec, q, 1)
+}
+
+// ExecExpectN executes the query and checks that it changed exactly n rows.
+// The error wraps [ErrNoRowsAffected], [ErrTooFewRowsAffected] or [ErrTooManyRowsAffected].
+// Note that MySQL only counts the rows whose values changed, unless the
+// connection is made with the CLIENT_FOUND_ROWS flag
+func ExecExpectN(ctx context.Context, exec Executor, q Query, n int64) (sql.Result, error) {
+	result, err := Exec(ctx, exec, q)
+	if err != nil {
+		return nil, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return result, err
+	}
+
+	switch {
+	case affected == n:
+		return result, nil
+	case affected == 0:
+		return result, fmt.Errorf("%w: expected %d", ErrNoRowsAffected, n)
+	case affected < n:
+		return result, fmt.Errorf("%w: expected %d, got %d", ErrTooFewRowsAffected, n, affected)
+	default:
+		return result, fmt.Errorf("%w: expected %d, got %d", ErrTooManyRowsAffected, n, affected)
+	}
+}
@@ -0,0 +1,56 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestExecExpect(t *testing.T) {
+	ctx := context.Background()
+
+	sqlDB, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	sqlDB.SetMaxOpenConns(1)
+	db := NewDB(sqlDB)
+
+	if _, err := db.ExecContext(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, version INTEGER)"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.ExecContext(ctx, "INSERT INTO users (id, version) VALUES (1, 1), (2, 1), (3, 1)"); err != nil {
+		t.Fatal(err)
+	}
+
+	update := rawQuery(d, "UPDATE users SET version = version + 1 WHERE id = # AND version = #", 1, 1)
+	if _, err := ExecExpectOne(ctx, db, update); err != nil {
+		t.Fatal(err)
+	}
+
+	// The version has changed
+	if _, err := ExecExpectOne(ctx, db, update); !errors.Is(err, ErrNoRowsAffected) {
+		t.Fatalf("expected ErrNoRowsAffected, got %v", err)
+	}
+
+	all := rawQuery(d, "UPDATE users SET version = version + 1 WHERE id > #", 0)
+	if _, err := ExecExpectOne(ctx, db, all); !errors.Is(err, ErrTooManyRowsAffected) {
+		t.Fatalf("expected ErrTooManyRowsAffected, got %v", err)
+	}
+
+	if _, err := ExecExpectN(ctx, db, all, 4); !errors.Is(err, ErrTooFewRowsAffected) {
+		t.Fatalf("expected ErrTooFewRowsAffected, got %v", err)
+	}
+
+	result, err := ExecExpectN(ctx, db, all, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := result.RowsAffected(); n != 3 {
+		t.Fatalf("got %d rows affected", n)
+	}
+}
@@ -80,6 +80,18 @@ func (x Chain[T, B]) NotIn(vals ...bob.Expression) T {
 	return X[T, B](leftRight{left: x.Base, right: group(vals), operator: "NOT IN"})
 }
 
+// IN with the elements of a slice, e.g. []int64, []string or []uuid.UUID.
+// Dialects with arrays bind the slice as a single argument, see [bob.SliceArgWriter],
+// and the others get a placeholder for each element
+func (x Chain[T, B]) InSlice(slice any) T {
+	return X[T, B](inSlice{left: x.Base, slice: slice})
+}
+
+// NOT IN with the elements of a slice, see [Chain.InSlice]
+func (x Chain[T, B]) NotInSlice(slice any) T {
+	return X[T, B](inSlice{left: x.Base, slice: slice, not: true})
+}
+
 // OR
 func (x Chain[T, B]) Or(targets ...bob.Expression) T {
 	return X[T, B](Join{Exprs: append([]bob.Expression{x.Base}, targets...), Sep: " OR "})
@@ -0,0 +1,82 @@
+package expr
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/stephenafamo/bob"
+)
+
+// inSlice is IN or NOT IN with the elements of a slice
+type inSlice struct {
+	left  any
+	slice any
+	not   bool
+}
+
+func (s inSlice) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	// IN () is not valid SQL, and no value is in an empty list.
+	// A nil slice could also be bound as NULL, which matches nothing with NOT IN
+	if isEmptySlice(s.slice) {
+		if s.not {
+			w.Write([]byte("1 = 1"))
+		} else {
+			w.Write([]byte("1 = 0"))
+		}
+		return nil, nil
+	}
+
+	if sw, ok := d.(bob.SliceArgWriter); ok {
+		largs, err := bob.Express(w, d, start, s.left)
+		if err != nil {
+			return nil, err
+		}
+
+		rargs, err := sw.WriteInSlice(w, start+len(largs), s.slice, s.not)
+		if err != nil {
+			return nil, err
+		}
+
+		return append(largs, rargs...), nil
+	}
+
+	vals, err := sliceElements(s.slice)
+	if err != nil {
+		return nil, err
+	}
+
+	operator := "IN"
+	if s.not {
+		operator = "NOT IN"
+	}
+
+	return leftRight{left: s.left, right: ArgGroup(vals...), operator: operator}.WriteSQL(w, d, start)
+}
+
+func isEmptySlice(slice any) bool {
+	if _, ok := slice.(driver.Valuer); ok {
+		return false
+	}
+
+	v := reflect.ValueOf(slice)
+	return v.Kind() == reflect.Slice && v.Type().Elem().Kind() != reflect.Uint8 && v.Len() == 0
+}
+
+// sliceElements returns the elements of a slice or array, so that each
+// element is bound to its own placeholder
+func sliceElements(slice any) ([]any, error) {
+	v := reflect.ValueOf(slice)
+	_, isValuer := slice.(driver.Valuer)
+	if isValuer || (v.Kind() != reflect.Slice && v.Kind() != reflect.Array) || v.Type().Elem().Kind() == reflect.Uint8 {
+		return nil, fmt.Errorf("in slice: expected a slice, got %T", slice)
+	}
+
+	vals := make([]any, v.Len())
+	for i := range vals {
+		vals[i] = v.Index(i).Interface()
+	}
+
+	return vals, nil
+}
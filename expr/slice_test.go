@@ -0,0 +1,60 @@
+package expr
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stephenafamo/bob"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+type arrayDialect struct{ dialect }
+
+func (d arrayDialect) WriteInSlice(w io.Writer, start int, slice any, not bool) ([]any, error) {
+	fmt.Fprintf(w, " = ANY(?%d)", start)
+	return []any{slice}, nil
+}
+
+func TestInSlice(t *testing.T) {
+	testutils.RunExpressionTests(t, dialect{}, testutils.ExpressionTestcases{
+		"ints": {
+			Expression:   inSlice{left: Quote("id"), slice: []int64{1, 2, 3}},
+			ExpectedSQL:  `"id" IN (?1, ?2, ?3)`,
+			ExpectedArgs: []any{int64(1), int64(2), int64(3)},
+		},
+		"not in strings": {
+			Expression:   inSlice{left: Quote("status"), slice: []string{"a", "b"}, not: true},
+			ExpectedSQL:  `"status" NOT IN (?1, ?2)`,
+			ExpectedArgs: []any{"a", "b"},
+		},
+		"empty": {
+			Expression:  inSlice{left: Quote("id"), slice: []int64{}},
+			ExpectedSQL: `1 = 0`,
+		},
+		"empty not in": {
+			Expression:  inSlice{left: Quote("id"), slice: []string(nil), not: true},
+			ExpectedSQL: `1 = 1`,
+		},
+	})
+
+	testutils.RunExpressionTests(t, arrayDialect{}, testutils.ExpressionTestcases{
+		"array": {
+			Expression:   Clause{query: "a = ? AND ?", args: []any{1, inSlice{left: Quote("id"), slice: []int64{1, 2}}}},
+			ExpectedSQL:  `a = ?1 AND "id" = ANY(?2)`,
+			ExpectedArgs: []any{1, []int64{1, 2}},
+		},
+		"empty array": {
+			Expression:  inSlice{left: Quote("id"), slice: []int64(nil), not: true},
+			ExpectedSQL: `1 = 1`,
+		},
+	})
+
+	for _, slice := range []any{5, []byte("ab")} {
+		_, err := bob.Express(&strings.Builder{}, dialect{}, 1, inSlice{left: Quote("id"), slice: slice})
+		if err == nil {
+			t.Fatalf("expected an error for %T", slice)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+// Package st contains expressions for common spatial functions.
+//
+// The functions are written for the dialect the query is built with:
+// PostGIS for psql, the built-in spatial functions for mysql and
+// SpatiaLite for sqlite. Any other dialect gets the standard ST_ names.
+package st
+
+import (
+	"io"
+	"reflect"
+	"strconv"
+
+	"github.com/stephenafamo/bob"
+	mysql "github.com/stephenafamo/bob/dialect/mysql/dialect"
+	psql "github.com/stephenafamo/bob/dialect/psql/dialect"
+	sqlite "github.com/stephenafamo/bob/dialect/sqlite/dialect"
+	"github.com/stephenafamo/bob/expr"
+)
+
+// WGS84 is the SRID of latitude/longitude coordinates as used by GPS
+const WGS84 = 4326
+
+type flavor int
+
+const (
+	standard flavor = iota
+	postgis
+	mysqlSpatial
+	spatialite
+)
+
+// The types are compared, since a dialect can target
+// an older version of the server
+func flavorOf(d bob.Dialect) flavor {
+	switch reflect.TypeOf(d) {
+	case reflect.TypeOf(psql.Dialect):
+		return postgis
+	case reflect.TypeOf(mysql.Dialect):
+		return mysqlSpatial
+	case reflect.TypeOf(sqlite.Dialect):
+		return spatialite
+	default:
+		return standard
+	}
+}
+
+// call writes name(args...)
+func call(w io.Writer, d bob.Dialect, start int, name string, args ...any) ([]any, error) {
+	return bob.ExpressSlice(w, d, start, args, name+"(", ", ", ")")
+}
+
+// function is a spatial function with the same arguments in every dialect
+// but a name that may differ
+type function struct {
+	name  string
+	names map[flavor]string
+	args  []any
+}
+
+func (f function) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	name := f.name
+	if n, ok := f.names[flavorOf(d)]; ok {
+		name = n
+	}
+
+	return call(w, d, start, name, f.args...)
+}
+
+func fn(name string, args ...any) bob.Expression {
+	return function{name: name, args: args}
+}
+
+// Point constructs a point from the given longitude (x) and latitude (y)
+// with the given SRID
+func Point(lng, lat, srid any) bob.Expression {
+	return bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+		switch flavorOf(d) {
+		case mysqlSpatial:
+			return call(w, d, start, "ST_SRID", fn("POINT", lng, lat), srid)
+		case spatialite:
+			return call(w, d, start, "MakePoint", lng, lat, srid)
+		default:
+			return call(w, d, start, "ST_SetSRID", fn("ST_MakePoint", lng, lat), srid)
+		}
+	})
+}
+
+// LatLng constructs a WGS84 point from latitude and longitude
+// which are sent as args
+func LatLng(lat, lng float64) bob.Expression {
+	return Point(expr.Arg(lng), expr.Arg(lat), expr.Raw(strconv.Itoa(WGS84)))
+}
+
+// GeomFromText constructs a geometry from its WKT representation
+func GeomFromText(wkt, srid any) bob.Expression {
+	return function{
+		name:  "ST_GeomFromText",
+		names: map[flavor]string{spatialite: "GeomFromText"},
+		args:  []any{wkt, srid},
+	}
+}
+
+// GeomFromWKB constructs a geometry from its WKB representation
+func GeomFromWKB(wkb, srid any) bob.Expression {
+	return function{
+		name:  "ST_GeomFromWKB",
+		names: map[flavor]string{spatialite: "GeomFromWKB"},
+		args:  []any{wkb, srid},
+	}
+}
+
+// AsGeoJSON returns the geometry as a GeoJSON document
+func AsGeoJSON(g any) bob.Expression {
+	return function{
+		name:  "ST_AsGeoJSON",
+		names: map[flavor]string{spatialite: "AsGeoJSON"},
+		args:  []any{g},
+	}
+}
+
+// AsText returns the WKT representation of the geometry
+func AsText(g any) bob.Expression {
+	return fn("ST_AsText", g)
+}
+
+// Contains is true if no point of b lies outside a
+func Contains(a, b any) bob.Expression {
+	return fn("ST_Contains", a, b)
+}
+
+// Within is true if a is completely inside b
+func Within(a, b any) bob.Expression {
+	return fn("ST_Within", a, b)
+}
+
+// Intersects is true if a and b share any point
+func Intersects(a, b any) bob.Expression {
+	return fn("ST_Intersects", a, b)
+}
+
+// Distance returns the distance between a and b
+func Distance(a, b any) bob.Expression {
+	return fn("ST_Distance", a, b)
+}
+
+// DWithin is true if a and b are within the given distance of each other.
+// MySQL and SpatiaLite have no ST_DWithin, so it is written as a
+// comparison on ST_Distance, which cannot use a spatial index
+func DWithin(a, b, distance any) bob.Expression {
+	return bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+		switch flavorOf(d) {
+		case mysqlSpatial, spatialite:
+			return bob.ExpressSlice(w, d, start, []any{Distance(a, b), distance}, "(", " <= ", ")")
+		default:
+			return call(w, d, start, "ST_DWithin", a, b, distance)
+		}
+	})
+}
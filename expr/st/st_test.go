@@ -0,0 +1,69 @@
+package st_test
+
+import (
+	"testing"
+
+	mysql "github.com/stephenafamo/bob/dialect/mysql/dialect"
+	psql "github.com/stephenafamo/bob/dialect/psql/dialect"
+	sqlite "github.com/stephenafamo/bob/dialect/sqlite/dialect"
+	"github.com/stephenafamo/bob/expr"
+	"github.com/stephenafamo/bob/expr/st"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+func TestPostGIS(t *testing.T) {
+	testutils.RunExpressionTests(t, psql.Dialect, testutils.ExpressionTestcases{
+		"lat lng": {
+			Expression:   st.LatLng(52.52, 13.405),
+			ExpectedSQL:  "ST_SetSRID(ST_MakePoint($1, $2), 4326)",
+			ExpectedArgs: []any{13.405, 52.52},
+		},
+		"dwithin": {
+			Expression:   st.DWithin(expr.Quote("location"), st.LatLng(52.52, 13.405), expr.Arg(1000)),
+			ExpectedSQL:  `ST_DWithin("location", ST_SetSRID(ST_MakePoint($1, $2), 4326), $3)`,
+			ExpectedArgs: []any{13.405, 52.52, 1000},
+		},
+		"geojson": {
+			Expression:  st.AsGeoJSON(expr.Quote("area")),
+			ExpectedSQL: `ST_AsGeoJSON("area")`,
+		},
+	})
+}
+
+func TestMySQL(t *testing.T) {
+	testutils.RunExpressionTests(t, mysql.Dialect, testutils.ExpressionTestcases{
+		"lat lng": {
+			Expression:   st.LatLng(52.52, 13.405),
+			ExpectedSQL:  "ST_SRID(POINT(?, ?), 4326)",
+			ExpectedArgs: []any{13.405, 52.52},
+		},
+		"dwithin": {
+			Expression:   st.DWithin(expr.Quote("location"), st.LatLng(52.52, 13.405), expr.Arg(1000)),
+			ExpectedSQL:  "(ST_Distance(`location`, ST_SRID(POINT(?, ?), 4326)) <= ?)",
+			ExpectedArgs: []any{13.405, 52.52, 1000},
+		},
+		"contains": {
+			Expression:  st.Contains(expr.Quote("area"), expr.Quote("location")),
+			ExpectedSQL: "ST_Contains(`area`, `location`)",
+		},
+	})
+}
+
+func TestSpatiaLite(t *testing.T) {
+	testutils.RunExpressionTests(t, sqlite.Dialect, testutils.ExpressionTestcases{
+		"lat lng": {
+			Expression:   st.LatLng(52.52, 13.405),
+			ExpectedSQL:  "MakePoint(?1, ?2, 4326)",
+			ExpectedArgs: []any{13.405, 52.52},
+		},
+		"geojson": {
+			Expression:  st.AsGeoJSON(expr.Quote("area")),
+			ExpectedSQL: `AsGeoJSON("area")`,
+		},
+		"from wkb": {
+			Expression:   st.GeomFromWKB(expr.Arg([]byte{1}), expr.Arg(st.WGS84)),
+			ExpectedSQL:  `GeomFromWKB(?1, ?2)`,
+			ExpectedArgs: []any{[]byte{1}, 4326},
+		},
+	})
+}
@@ -0,0 +1,205 @@
+package expr
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"text/template"
+	"text/template/parse"
+
+	"github.com/stephenafamo/bob"
+)
+
+var validIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_$]*$`)
+
+// Template is a query template with the syntax of text/template.
+// Values written with {{.Name}} are identifiers, they are validated
+// and quoted by the dialect, and values written with {{arg .Value}}
+// are bound parameters. This makes it safe to choose joins and clauses
+// at runtime, e.g.
+//
+//	SELECT * FROM {{.Table}}
+//	{{if .PilotID}}JOIN pilots ON pilots.id = {{.Table}}.pilot_id{{end}}
+//	WHERE {{.Column}} = {{arg .Value}}
+//
+// An identifier can be a string, which is split on dots for qualified names,
+// a []string which is written as a comma separated list, or a [bob.Expression]
+type Template struct {
+	tmpl *template.Template
+}
+
+// NewTemplate parses the query template
+func NewTemplate(text string) (Template, error) {
+	tmpl, err := template.New("query").
+		Option("missingkey=error").
+		Funcs(template.FuncMap{
+			"arg":   func(any) (string, error) { return "", nil },
+			"ident": func(any) (string, error) { return "", nil },
+		}).
+		Parse(text)
+	if err != nil {
+		return Template{}, err
+	}
+
+	for _, t := range tmpl.Templates() {
+		if t.Tree != nil {
+			escapeNode(t.Tree, t.Tree.Root)
+		}
+	}
+
+	return Template{tmpl: tmpl}, nil
+}
+
+// MustTemplate is like [NewTemplate] but panics on error.
+// Useful for initializing templates in package level variables
+func MustTemplate(text string) Template {
+	t, err := NewTemplate(text)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// With returns the template executed with the data as an expression
+func (t Template) With(data any) bob.Expression {
+	return templateClause{tmpl: t.tmpl, data: data}
+}
+
+// TemplateQuery returns a query from the template executed with the data
+func TemplateQuery(d bob.Dialect, t Template, data any) bob.BaseQuery[bob.Expression] {
+	return bob.BaseQuery[bob.Expression]{
+		Expression: t.With(data),
+		Dialect:    d,
+	}
+}
+
+// escapeNode makes every action that writes a value end with ident
+// unless it already ends with arg or ident, the same way html/template
+// adds its escapers
+func escapeNode(t *parse.Tree, node parse.Node) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			escapeNode(t, child)
+		}
+
+	case *parse.IfNode:
+		escapeNode(t, n.List)
+		escapeNode(t, n.ElseList)
+
+	case *parse.RangeNode:
+		escapeNode(t, n.List)
+		escapeNode(t, n.ElseList)
+
+	case *parse.WithNode:
+		escapeNode(t, n.List)
+		escapeNode(t, n.ElseList)
+
+	case *parse.ActionNode:
+		// Variable declarations do not write anything
+		if len(n.Pipe.Decl) > 0 {
+			return
+		}
+
+		last := n.Pipe.Cmds[len(n.Pipe.Cmds)-1]
+		if id, ok := last.Args[0].(*parse.IdentifierNode); ok {
+			if id.Ident == "arg" || id.Ident == "ident" {
+				return
+			}
+		}
+
+		n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{
+			NodeType: parse.NodeCommand,
+			Pos:      n.Pos,
+			Args:     []parse.Node{parse.NewIdentifier("ident").SetTree(t).SetPos(n.Pos)},
+		})
+	}
+}
+
+type templateClause struct {
+	tmpl *template.Template
+	data any
+}
+
+func (c templateClause) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+	var args []any
+
+	// writes an expression and keeps its args
+	express := func(e bob.Expression) (string, error) {
+		var buf bytes.Buffer
+		eargs, err := e.WriteSQL(&buf, d, start+len(args))
+		if err != nil {
+			return "", err
+		}
+		args = append(args, eargs...)
+		return buf.String(), nil
+	}
+
+	// The functions write to the args of this execution
+	tmpl, err := c.tmpl.Clone()
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl.Funcs(template.FuncMap{
+		"arg": func(v any) (string, error) {
+			if e, ok := v.(bob.Expression); ok {
+				return express(e)
+			}
+
+			var buf bytes.Buffer
+			d.WriteArg(&buf, start+len(args))
+			args = append(args, v)
+			return buf.String(), nil
+		},
+		"ident": func(v any) (string, error) {
+			switch v := v.(type) {
+			case bob.Expression:
+				return express(v)
+			case string:
+				return quoteIdentifier(d, v)
+			case []string:
+				quoted := make([]string, len(v))
+				for i, s := range v {
+					q, err := quoteIdentifier(d, s)
+					if err != nil {
+						return "", err
+					}
+					quoted[i] = q
+				}
+				return strings.Join(quoted, ", "), nil
+			default:
+				return "", fmt.Errorf("cannot write %T as an identifier, use arg to make it a parameter", v)
+			}
+		},
+	})
+
+	if err := tmpl.Execute(w, c.data); err != nil {
+		return nil, err
+	}
+
+	return args, nil
+}
+
+func quoteIdentifier(d bob.Dialect, s string) (string, error) {
+	var buf bytes.Buffer
+
+	for i, part := range strings.Split(s, ".") {
+		if !validIdentifier.MatchString(part) {
+			return "", fmt.Errorf("invalid identifier %q", s)
+		}
+
+		if i > 0 {
+			buf.WriteString(".")
+		}
+		d.WriteQuoted(&buf, part)
+	}
+
+	return buf.String(), nil
+}
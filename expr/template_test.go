@@ -0,0 +1,95 @@
+package expr
+
+import (
+	"strings"
+	"testing"
+
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+func TestTemplate(t *testing.T) {
+	tmpl := MustTemplate(`SELECT {{.Columns}} FROM {{.Table}}
+{{- if .Join}} JOIN pilots ON pilots.id = {{.Table}}.pilot_id{{end}}
+WHERE {{.Column}} = {{arg .Value}}
+{{- range .Extra}} AND {{.}} IS NOT NULL{{end}}`)
+
+	examples := testutils.ExpressionTestcases{
+		"identifiers and args": {
+			Expression: tmpl.With(map[string]any{
+				"Columns": []string{"id", "jets.name"},
+				"Table":   "jets",
+				"Join":    false,
+				"Column":  "color",
+				"Value":   "red",
+				"Extra":   nil,
+			}),
+			ExpectedSQL:  `SELECT "id", "jets"."name" FROM "jets" WHERE "color" = ?1`,
+			ExpectedArgs: []any{"red"},
+		},
+		"optional clauses": {
+			Expression: tmpl.With(map[string]any{
+				"Columns": Raw("*"),
+				"Table":   "public.jets",
+				"Join":    true,
+				"Column":  "pilots.name",
+				"Value":   "Stephen",
+				"Extra":   []string{"color", "uuid"},
+			}),
+			ExpectedSQL:  `SELECT * FROM "public"."jets" JOIN pilots ON pilots.id = "public"."jets".pilot_id WHERE "pilots"."name" = ?1 AND "color" IS NOT NULL AND "uuid" IS NOT NULL`,
+			ExpectedArgs: []any{"Stephen"},
+		},
+		"expressions": {
+			Expression: MustTemplate(`SELECT * FROM jets WHERE id IN {{arg .IDs}} AND {{.Where}} AND name = {{arg .Name}}`).With(map[string]any{
+				"IDs":   ArgGroup(1, 2),
+				"Where": Clause{query: "color = ?", args: []any{"red"}},
+				"Name":  "Concorde",
+			}),
+			ExpectedSQL:  `SELECT * FROM jets WHERE id IN (?1, ?2) AND color = ?3 AND name = ?4`,
+			ExpectedArgs: []any{1, 2, "red", "Concorde"},
+		},
+	}
+
+	testutils.RunExpressionTests(t, dialect{}, examples)
+}
+
+func TestTemplateErrors(t *testing.T) {
+	cases := map[string]struct {
+		tmpl string
+		data any
+		err  string
+	}{
+		"injection": {
+			tmpl: `SELECT * FROM {{.Table}}`,
+			data: map[string]any{"Table": "jets; DROP TABLE jets"},
+			err:  `invalid identifier "jets; DROP TABLE jets"`,
+		},
+		"quote in identifier": {
+			tmpl: `SELECT {{.Column}} FROM jets`,
+			data: map[string]any{"Column": `name"`},
+			err:  `invalid identifier "name\""`,
+		},
+		"value without arg": {
+			tmpl: `SELECT * FROM jets WHERE id = {{.ID}}`,
+			data: map[string]any{"ID": 1},
+			err:  "cannot write int as an identifier",
+		},
+		"missing key": {
+			tmpl: `SELECT * FROM {{.Table}}`,
+			data: map[string]any{},
+			err:  `map has no entry for key "Table"`,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := MustTemplate(tc.tmpl).With(tc.data).WriteSQL(&strings.Builder{}, dialect{}, 1)
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+
+	if _, err := NewTemplate(`SELECT {{.Table`); err == nil {
+		t.Fatal("expected a parse error")
+	}
+}
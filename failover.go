@@ -0,0 +1,221 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/stephenafamo/scan"
+)
+
+// ErrNoPrimary is returned by a [Failover] when none of its hosts is a primary
+var ErrNoPrimary = errors.New("no primary host")
+
+// FailoverHost is one of the databases of a [Failover]
+type FailoverHost struct {
+	// The name of the host in the state, e.g. its address
+	Name string
+	DB   DB
+}
+
+// HostState is the state of a host after the last check of a [Failover]
+type HostState struct {
+	Name string
+	// True if the host accepts writes
+	Primary bool
+	// The error of the probe, if the host could not be reached
+	Err error
+	// The time the probe took
+	Latency time.Duration
+}
+
+// FailoverState is the state of the hosts of a [Failover]
+type FailoverState struct {
+	// The name of the host that queries are sent to, empty if there is no primary
+	Primary string
+	Hosts   []HostState
+	Checked time.Time
+}
+
+// Failover is an [Executor] and [Transactor] that sends all queries to the primary of
+// several hosts, e.g. a Postgres primary and its standbys.
+// The hosts are probed with [Failover.Check], or regularly with [Failover.Watch],
+// and once a standby is promoted, the queries go to the new primary.
+// The hosts are checked before the first query if they were not checked yet,
+// and again when a query on the primary fails with a lost connection (see [IsConnError]).
+// Failed queries are not retried.
+//
+// The fields must not be changed once the failover is used
+type Failover struct {
+	Hosts []FailoverHost
+	// IsPrimary probes a host and reports if it accepts writes,
+	// such as psql.IsPrimary or mysql.IsPrimary
+	IsPrimary func(ctx context.Context, exec Executor) (bool, error)
+	// OnChange is called after a check that changed the primary, or
+	// the primary or reachable status of any host
+	OnChange func(prev, next FailoverState)
+
+	checking sync.Mutex
+	mu       sync.RWMutex
+	state    FailoverState
+	primary  *DB
+}
+
+func (f *Failover) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	db, err := f.primaryDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := db.ExecContext(ctx, query, args...)
+	f.checkOnError(ctx, err)
+	return result, err
+}
+
+func (f *Failover) QueryContext(ctx context.Context, query string, args ...any) (scan.Rows, error) {
+	db, err := f.primaryDB(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	f.checkOnError(ctx, err)
+	return rows, err
+}
+
+func (f *Failover) BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error) {
+	db, err := f.primaryDB(ctx)
+	if err != nil {
+		return Tx{}, err
+	}
+
+	tx, err := db.BeginTx(ctx, opts)
+	f.checkOnError(ctx, err)
+	return tx, err
+}
+
+// State returns the state of the hosts after the last check
+func (f *Failover) State() FailoverState {
+	f.mu.RLock()
+	defer f.mu.RUnlock()
+
+	return f.state
+}
+
+// Check probes all the hosts at the same time and sends the next queries to the primary.
+// If several hosts are primaries, the current primary is kept, or else the first one of Hosts.
+// It returns an error wrapping [ErrNoPrimary] if none of the hosts is a primary
+func (f *Failover) Check(ctx context.Context) error {
+	f.checking.Lock()
+	defer f.checking.Unlock()
+
+	if f.IsPrimary == nil {
+		return errors.New("failover: no IsPrimary probe")
+	}
+
+	hosts := make([]HostState, len(f.Hosts))
+	var wg sync.WaitGroup
+	for i, host := range f.Hosts {
+		wg.Add(1)
+		go func(i int, host FailoverHost) {
+			defer wg.Done()
+			start := time.Now()
+			primary, err := f.IsPrimary(ctx, host.DB)
+			hosts[i] = HostState{Name: host.Name, Primary: primary && err == nil, Err: err, Latency: time.Since(start)}
+		}(i, host)
+	}
+	wg.Wait()
+
+	prev := f.State()
+	next := FailoverState{Hosts: hosts, Checked: time.Now()}
+	primary := -1
+	for i, h := range hosts {
+		if !h.Primary {
+			continue
+		}
+		if primary == -1 || h.Name == prev.Primary {
+			primary = i
+		}
+	}
+
+	f.mu.Lock()
+	f.primary = nil
+	if primary >= 0 {
+		next.Primary = hosts[primary].Name
+		f.primary = &f.Hosts[primary].DB
+	}
+	f.state = next
+	f.mu.Unlock()
+
+	if f.OnChange != nil && stateChanged(prev, next) {
+		f.OnChange(prev, next)
+	}
+
+	if primary == -1 {
+		return fmt.Errorf("failover: %w", ErrNoPrimary)
+	}
+
+	return nil
+}
+
+// Watch checks the hosts every interval until the context is done
+func (f *Failover) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		_ = f.Check(ctx)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (f *Failover) primaryDB(ctx context.Context) (*DB, error) {
+	f.mu.RLock()
+	db, checked := f.primary, !f.state.Checked.IsZero()
+	f.mu.RUnlock()
+
+	if !checked {
+		if err := f.Check(ctx); err != nil {
+			return nil, err
+		}
+		f.mu.RLock()
+		db = f.primary
+		f.mu.RUnlock()
+	}
+
+	if db == nil {
+		return nil, fmt.Errorf("failover: %w", ErrNoPrimary)
+	}
+
+	return db, nil
+}
+
+// checkOnError checks the hosts again if the primary could not be reached
+func (f *Failover) checkOnError(ctx context.Context, err error) {
+	if err != nil && ctx.Err() == nil && IsConnError(err) {
+		_ = f.Check(ctx)
+	}
+}
+
+func stateChanged(prev, next FailoverState) bool {
+	if prev.Primary != next.Primary || len(prev.Hosts) != len(next.Hosts) {
+		return true
+	}
+
+	for i := range prev.Hosts {
+		p, n := prev.Hosts[i], next.Hosts[i]
+		if p.Primary != n.Primary || (p.Err == nil) != (n.Err == nil) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,101 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stephenafamo/scan"
+	_ "modernc.org/sqlite"
+)
+
+// queryOnlyIsStandby treats a SQLite connection with query_only as a standby
+func queryOnlyIsStandby(ctx context.Context, exec Executor) (bool, error) {
+	return scan.One(ctx, exec, scan.SingleColumnMapper[bool], "SELECT NOT query_only FROM pragma_query_only")
+}
+
+func TestFailover(t *testing.T) {
+	ctx := context.Background()
+
+	hosts := make([]FailoverHost, 2)
+	for i, name := range []string{"a", "b"} {
+		sqlDB, err := sql.Open("sqlite", ":memory:")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer sqlDB.Close()
+		sqlDB.SetMaxOpenConns(1)
+
+		hosts[i] = FailoverHost{Name: name, DB: NewDB(sqlDB)}
+		if _, err := hosts[i].DB.ExecContext(ctx, "CREATE TABLE users (name TEXT)"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if _, err := hosts[1].DB.ExecContext(ctx, "PRAGMA query_only = ON"); err != nil {
+		t.Fatal(err)
+	}
+
+	var changes []FailoverState
+	f := &Failover{
+		Hosts:     hosts,
+		IsPrimary: queryOnlyIsStandby,
+		OnChange:  func(_, next FailoverState) { changes = append(changes, next) },
+	}
+
+	// The hosts are checked before the first query
+	if _, err := f.ExecContext(ctx, "INSERT INTO users VALUES ('Alice')"); err != nil {
+		t.Fatal(err)
+	}
+	if state := f.State(); state.Primary != "a" || len(changes) != 1 {
+		t.Fatalf("unexpected state %+v after %d changes", state, len(changes))
+	}
+
+	// Nothing changed
+	if err := f.Check(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 1 {
+		t.Fatalf("got %d changes", len(changes))
+	}
+
+	// b is promoted
+	if _, err := hosts[0].DB.ExecContext(ctx, "PRAGMA query_only = ON"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := hosts[1].DB.ExecContext(ctx, "PRAGMA query_only = OFF"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Check(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(changes) != 2 || changes[1].Primary != "b" {
+		t.Fatalf("unexpected changes %+v", changes)
+	}
+
+	if _, err := f.ExecContext(ctx, "INSERT INTO users VALUES ('Bob')"); err != nil {
+		t.Fatal(err)
+	}
+	name, err := scan.One(ctx, f, scan.SingleColumnMapper[string], "SELECT name FROM users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if name != "Bob" {
+		t.Fatalf("read %q from the old primary", name)
+	}
+
+	// No primary
+	if _, err := hosts[1].DB.ExecContext(ctx, "PRAGMA query_only = ON"); err != nil {
+		t.Fatal(err)
+	}
+	if err := f.Check(ctx); !errors.Is(err, ErrNoPrimary) {
+		t.Fatalf("expected ErrNoPrimary, got %v", err)
+	}
+	if _, err := f.BeginTx(ctx, nil); !errors.Is(err, ErrNoPrimary) {
+		t.Fatalf("expected ErrNoPrimary, got %v", err)
+	}
+	if len(changes) != 3 || changes[2].Primary != "" {
+		t.Fatalf("unexpected changes %+v", changes)
+	}
+}
@@ -0,0 +1,330 @@
+// Package filter converts a declarative filter, such as the filter input of a
+// GraphQL or REST API, into query mods.
+//
+// Only the fields in the given [Columns] can be filtered on, and every value is
+// converted to the Go type of its column before it is sent as an argument.
+package filter
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/expr"
+	"github.com/stephenafamo/bob/mods"
+)
+
+//nolint:gochecknoglobals
+var (
+	ErrUnknownField  = errors.New("unknown filter field")
+	ErrUnsupportedOp = errors.New("unsupported filter operator")
+	ErrInvalidValue  = errors.New("invalid filter value")
+	ErrTooDeep       = errors.New("filter is nested too deeply")
+)
+
+// MaxDepth is the number of levels of a filter tree, counting each And, Or and Not,
+// beyond which a filter is rejected with [ErrTooDeep]
+const MaxDepth = 10
+
+// Op is a filter operator
+type Op string
+
+const (
+	EQ     Op = "eq"
+	NE     Op = "ne"
+	LT     Op = "lt"
+	LTE    Op = "lte"
+	GT     Op = "gt"
+	GTE    Op = "gte"
+	In     Op = "in"
+	NotIn  Op = "nin"
+	Like   Op = "like"
+	IsNull Op = "is_null" // the value is a bool, false means IS NOT NULL
+)
+
+// Filter is a node of the filter tree.
+// A node with a Field is a condition on that field.
+// And, Or and Not combine other nodes. If a node has several of these,
+// they all have to match
+type Filter struct {
+	Field string   `json:"field,omitempty"`
+	Op    Op       `json:"op,omitempty"`
+	Value any      `json:"value,omitempty"`
+	And   []Filter `json:"and,omitempty"`
+	Or    []Filter `json:"or,omitempty"`
+	Not   *Filter  `json:"not,omitempty"`
+}
+
+// Column is a column that can be filtered on
+type Column struct {
+	expr    bob.Expression
+	convert func(any) (any, error)
+	ops     []Op
+}
+
+// Col returns a filterable column with values of type T.
+// Like is only allowed for string types, and IsNull only for nullable columns
+func Col[T any](e bob.Expression, nullable bool) Column {
+	ops := []Op{EQ, NE, LT, LTE, GT, GTE, In, NotIn}
+	if reflect.TypeOf((*T)(nil)).Elem().Kind() == reflect.String {
+		ops = append(ops, Like)
+	}
+	if nullable {
+		ops = append(ops, IsNull)
+	}
+
+	return Column{expr: e, convert: convertTo[T], ops: ops}
+}
+
+// WithOps limits the operators that can be used with the column
+func (c Column) WithOps(ops ...Op) Column {
+	allowed := make([]Op, 0, len(ops))
+	for _, op := range ops {
+		if c.allows(op) {
+			allowed = append(allowed, op)
+		}
+	}
+
+	c.ops = allowed
+	return c
+}
+
+func (c Column) allows(op Op) bool {
+	for _, o := range c.ops {
+		if o == op {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Columns is the allowlist of filterable columns, keyed by field name
+type Columns map[string]Column
+
+// Only returns the columns with the given field names
+func (c Columns) Only(fields ...string) Columns {
+	only := make(Columns, len(fields))
+	for _, f := range fields {
+		if col, ok := c[f]; ok {
+			only[f] = col
+		}
+	}
+
+	return only
+}
+
+// Except returns the columns without the given field names
+func (c Columns) Except(fields ...string) Columns {
+	except := make(Columns, len(c))
+	for name, col := range c {
+		except[name] = col
+	}
+
+	for _, f := range fields {
+		delete(except, f)
+	}
+
+	return except
+}
+
+// Where converts the filter into a where mod.
+// An empty filter returns a mod that does nothing
+func Where[Q interface{ AppendWhere(e ...any) }](cols Columns, f Filter) (bob.Mod[Q], error) {
+	e, err := cols.Expression(f)
+	if err != nil {
+		return nil, err
+	}
+
+	if e == nil {
+		return mods.QueryMods[Q]{}, nil
+	}
+
+	return mods.Where[Q]{E: e}, nil
+}
+
+// Expression converts the filter into a boolean expression.
+// It returns nil for an empty filter
+func (c Columns) Expression(f Filter) (bob.Expression, error) {
+	return c.expression(f, 1)
+}
+
+func (c Columns) expression(f Filter, depth int) (bob.Expression, error) {
+	if depth > MaxDepth {
+		return nil, fmt.Errorf("%w: more than %d levels", ErrTooDeep, MaxDepth)
+	}
+
+	var exprs []bob.Expression
+
+	if f.Field != "" {
+		e, err := c.condition(f)
+		if err != nil {
+			return nil, err
+		}
+		exprs = append(exprs, e)
+	}
+
+	and, err := c.expressions(f.And, depth+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(and) > 0 {
+		exprs = append(exprs, expr.X[expression, expression](expr.Join{Exprs: and, Sep: " AND "}))
+	}
+
+	or, err := c.expressions(f.Or, depth+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(or) > 0 {
+		exprs = append(exprs, expr.X[expression, expression](expr.Join{Exprs: or, Sep: " OR "}))
+	}
+
+	if f.Not != nil {
+		not, err := c.expression(*f.Not, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		if not != nil {
+			exprs = append(exprs, expr.Not[expression, expression](not))
+		}
+	}
+
+	switch len(exprs) {
+	case 0:
+		return nil, nil
+	case 1:
+		return exprs[0], nil
+	default:
+		return expr.X[expression, expression](expr.Join{Exprs: exprs, Sep: " AND "}), nil
+	}
+}
+
+func (c Columns) expressions(filters []Filter, depth int) ([]bob.Expression, error) {
+	exprs := make([]bob.Expression, 0, len(filters))
+	for _, f := range filters {
+		e, err := c.expression(f, depth)
+		if err != nil {
+			return nil, err
+		}
+
+		if e != nil {
+			exprs = append(exprs, e)
+		}
+	}
+
+	return exprs, nil
+}
+
+func (c Columns) condition(f Filter) (bob.Expression, error) {
+	col, ok := c[f.Field]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownField, f.Field)
+	}
+
+	if !col.allows(f.Op) {
+		return nil, fmt.Errorf("%w: %q for %q", ErrUnsupportedOp, f.Op, f.Field)
+	}
+
+	x := expression{}.New(col.expr)
+
+	switch f.Op {
+	case IsNull:
+		isNull, ok := f.Value.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%w: %s of %q must be a bool", ErrInvalidValue, f.Op, f.Field)
+		}
+		if isNull {
+			return x.IsNull(), nil
+		}
+		return x.IsNotNull(), nil
+
+	case In, NotIn:
+		vals := reflect.ValueOf(f.Value)
+		if vals.Kind() != reflect.Slice || vals.Len() == 0 {
+			return nil, fmt.Errorf("%w: %s of %q must be a non-empty list", ErrInvalidValue, f.Op, f.Field)
+		}
+
+		args := make([]any, vals.Len())
+		for i := range args {
+			val, err := col.convert(vals.Index(i).Interface())
+			if err != nil {
+				return nil, fmt.Errorf("%w: %q: %v", ErrInvalidValue, f.Field, err)
+			}
+			args[i] = val
+		}
+
+		if f.Op == In {
+			return x.In(expr.Arg(args...)), nil
+		}
+		return x.NotIn(expr.Arg(args...)), nil
+	}
+
+	val, err := col.convert(f.Value)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %q: %v", ErrInvalidValue, f.Field, err)
+	}
+	arg := expr.Arg(val)
+
+	switch f.Op {
+	case EQ:
+		return x.EQ(arg), nil
+	case NE:
+		return x.NE(arg), nil
+	case LT:
+		return x.LT(arg), nil
+	case LTE:
+		return x.LTE(arg), nil
+	case GT:
+		return x.GT(arg), nil
+	case GTE:
+		return x.GTE(arg), nil
+	case Like:
+		return x.Like(arg), nil
+	}
+
+	return nil, fmt.Errorf("%w: %q for %q", ErrUnsupportedOp, f.Op, f.Field)
+}
+
+// convertTo converts a decoded value to T.
+// Values are converted through their JSON representation, so a value can be
+// given the way it would be in a JSON body, and strings from query parameters
+// are also parsed as JSON if needed, e.g. "5" for an int
+func convertTo[T any](value any) (any, error) {
+	if value == nil {
+		return nil, errors.New("value is null")
+	}
+
+	if t, ok := value.(T); ok {
+		return t, nil
+	}
+
+	var t T
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+
+	err = json.Unmarshal(data, &t)
+	if err == nil {
+		return t, nil
+	}
+
+	if s, ok := value.(string); ok && json.Unmarshal([]byte(s), &t) == nil {
+		return t, nil
+	}
+
+	return nil, fmt.Errorf("cannot use %v as %T", value, t)
+}
+
+type expression struct {
+	expr.Chain[expression, expression]
+}
+
+func (expression) New(exp bob.Expression) expression {
+	var b expression
+	b.Base = exp
+	return b
+}
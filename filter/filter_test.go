@@ -0,0 +1,154 @@
+package filter_test
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/sqlite"
+	"github.com/stephenafamo/bob/dialect/sqlite/dialect"
+	"github.com/stephenafamo/bob/dialect/sqlite/sm"
+	"github.com/stephenafamo/bob/filter"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+//nolint:gochecknoglobals
+var userFilters = filter.Columns{
+	"id":         filter.Col[int](sqlite.Quote("users", "id"), false),
+	"name":       filter.Col[string](sqlite.Quote("users", "name"), false),
+	"deleted_at": filter.Col[time.Time](sqlite.Quote("users", "deleted_at"), true),
+}
+
+func parse(t *testing.T, s string) filter.Filter {
+	t.Helper()
+
+	var f filter.Filter
+	if err := json.Unmarshal([]byte(s), &f); err != nil {
+		t.Fatal(err)
+	}
+
+	return f
+}
+
+func whereMod(t *testing.T, s string) bob.Mod[*dialect.SelectQuery] {
+	t.Helper()
+
+	mod, err := filter.Where[*dialect.SelectQuery](userFilters, parse(t, s))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return mod
+}
+
+func TestWhere(t *testing.T) {
+	examples := testutils.Testcases{
+		"condition": {
+			Query:        sqlite.Select(sm.From("users"), whereMod(t, `{"field": "id", "op": "eq", "value": 5}`)),
+			ExpectedSQL:  `SELECT * FROM users WHERE ("users"."id" = ?1)`,
+			ExpectedArgs: []any{5},
+		},
+		"nested": {
+			Query: sqlite.Select(sm.From("users"), whereMod(t, `{
+				"or": [
+					{"field": "name", "op": "like", "value": "a%"},
+					{"field": "id", "op": "in", "value": ["1", 2]}
+				],
+				"not": {"field": "deleted_at", "op": "is_null", "value": false}
+			}`)),
+			ExpectedSQL:  `SELECT * FROM users WHERE ((("users"."name" LIKE ?1) OR ("users"."id" IN (?2, ?3))) AND NOT ("users"."deleted_at" IS NOT NULL))`,
+			ExpectedArgs: []any{"a%", 1, 2},
+		},
+		"time value": {
+			Query:        sqlite.Select(sm.From("users"), whereMod(t, `{"field": "deleted_at", "op": "gt", "value": "2023-01-02T00:00:00Z"}`)),
+			ExpectedSQL:  `SELECT * FROM users WHERE ("users"."deleted_at" > ?1)`,
+			ExpectedArgs: []any{time.Date(2023, 1, 2, 0, 0, 0, 0, time.UTC)},
+		},
+		"empty": {
+			Query:       sqlite.Select(sm.From("users"), whereMod(t, `{}`)),
+			ExpectedSQL: `SELECT * FROM users`,
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+}
+
+func TestWhereErrors(t *testing.T) {
+	cases := map[string]struct {
+		cols   filter.Columns
+		filter string
+		err    error
+	}{
+		"unknown field": {
+			cols:   userFilters,
+			filter: `{"field": "password", "op": "eq", "value": "x"}`,
+			err:    filter.ErrUnknownField,
+		},
+		"not in allowlist": {
+			cols:   userFilters.Only("name"),
+			filter: `{"and": [{"field": "id", "op": "eq", "value": 1}]}`,
+			err:    filter.ErrUnknownField,
+		},
+		"like on int": {
+			cols:   userFilters,
+			filter: `{"field": "id", "op": "like", "value": "1%"}`,
+			err:    filter.ErrUnsupportedOp,
+		},
+		"null check on non-null column": {
+			cols:   userFilters,
+			filter: `{"field": "id", "op": "is_null", "value": true}`,
+			err:    filter.ErrUnsupportedOp,
+		},
+		"limited ops": {
+			cols:   filter.Columns{"name": userFilters["name"].WithOps(filter.EQ)},
+			filter: `{"field": "name", "op": "ne", "value": "x"}`,
+			err:    filter.ErrUnsupportedOp,
+		},
+		"wrong type": {
+			cols:   userFilters,
+			filter: `{"field": "id", "op": "eq", "value": "abc"}`,
+			err:    filter.ErrInvalidValue,
+		},
+		"empty list": {
+			cols:   userFilters,
+			filter: `{"field": "id", "op": "in", "value": []}`,
+			err:    filter.ErrInvalidValue,
+		},
+		"no columns": {
+			cols:   userFilters.Only(),
+			filter: `{"field": "id", "op": "eq", "value": 1}`,
+			err:    filter.ErrUnknownField,
+		},
+		"too deep": {
+			cols:   userFilters,
+			filter: strings.Repeat(`{"not": `, filter.MaxDepth) + `{"field": "id", "op": "eq", "value": 1}` + strings.Repeat("}", filter.MaxDepth),
+			err:    filter.ErrTooDeep,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := filter.Where[*dialect.SelectQuery](tc.cols, parse(t, tc.filter))
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestMaxDepth(t *testing.T) {
+	nested := func(levels int) string {
+		return strings.Repeat(`{"and": [`, levels-1) + `{"field": "id", "op": "eq", "value": 1}` + strings.Repeat("]}", levels-1)
+	}
+
+	if _, err := userFilters.Expression(parse(t, nested(filter.MaxDepth))); err != nil {
+		t.Fatalf("a filter of %d levels should be allowed: %v", filter.MaxDepth, err)
+	}
+
+	if _, err := userFilters.Expression(parse(t, nested(filter.MaxDepth+1))); !errors.Is(err, filter.ErrTooDeep) {
+		t.Fatalf("expected ErrTooDeep, got %v", err)
+	}
+}
@@ -0,0 +1,69 @@
+package filter
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+)
+
+// Cond returns a filter with a condition on the field
+func Cond(field string, op Op, value any) Filter {
+	return Filter{Field: field, Op: op, Value: value}
+}
+
+// And returns a filter that matches if all the filters match
+func And(filters ...Filter) Filter {
+	return Filter{And: filters}
+}
+
+// Or returns a filter that matches if any of the filters match
+func Or(filters ...Filter) Filter {
+	return Filter{Or: filters}
+}
+
+// Not returns a filter that matches if the filter does not match
+func Not(f Filter) Filter {
+	return Filter{Not: &f}
+}
+
+// Validate checks that the filter only uses the fields and operators of the columns,
+// with values of the right types
+func (c Columns) Validate(f Filter) error {
+	_, err := c.Expression(f)
+	return err
+}
+
+// Marshal validates the filter and encodes it as JSON,
+// so it can be stored, e.g. as a saved search, and replayed with [Columns.Unmarshal]
+func (c Columns) Marshal(f Filter) ([]byte, error) {
+	if err := c.Validate(f); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(f)
+}
+
+// Unmarshal decodes a filter encoded by [Columns.Marshal] and validates it.
+// Unknown keys are an error instead of being dropped, since a dropped key
+// such as a misspelled "and" would match more rows than the stored filter.
+// Numbers are kept as [json.Number] so large integers do not lose precision
+func (c Columns) Unmarshal(data []byte) (Filter, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	dec.UseNumber()
+
+	var f Filter
+	if err := dec.Decode(&f); err != nil {
+		return Filter{}, fmt.Errorf("%w: %v", ErrInvalidValue, err)
+	}
+
+	if dec.More() {
+		return Filter{}, fmt.Errorf("%w: data after the filter", ErrInvalidValue)
+	}
+
+	if err := c.Validate(f); err != nil {
+		return Filter{}, err
+	}
+
+	return f, nil
+}
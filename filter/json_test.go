@@ -0,0 +1,83 @@
+package filter_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stephenafamo/bob/dialect/sqlite"
+	"github.com/stephenafamo/bob/dialect/sqlite/dialect"
+	"github.com/stephenafamo/bob/dialect/sqlite/sm"
+	"github.com/stephenafamo/bob/filter"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+func TestRoundTrip(t *testing.T) {
+	saved := filter.And(
+		filter.Or(
+			filter.Cond("name", filter.Like, "a%"),
+			filter.Cond("id", filter.In, []int{1, 9007199254740993}),
+		),
+		filter.Not(filter.Cond("deleted_at", filter.IsNull, false)),
+	)
+
+	data, err := userFilters.Marshal(saved)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := userFilters.Unmarshal(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	mod, err := filter.Where[*dialect.SelectQuery](userFilters, f)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testutils.RunTests(t, testutils.Testcases{
+		"replayed": {
+			Query:        sqlite.Select(sm.From("users"), mod),
+			ExpectedSQL:  `SELECT * FROM users WHERE ((("users"."name" LIKE ?1) OR ("users"."id" IN (?2, ?3))) AND NOT ("users"."deleted_at" IS NOT NULL))`,
+			ExpectedArgs: []any{"a%", 1, 9007199254740993},
+		},
+	}, nil)
+}
+
+func TestMarshalErrors(t *testing.T) {
+	_, err := userFilters.Only("name").Marshal(filter.Cond("id", filter.EQ, 1))
+	if !errors.Is(err, filter.ErrUnknownField) {
+		t.Fatalf("expected ErrUnknownField, got %v", err)
+	}
+
+	cases := map[string]struct {
+		data string
+		err  error
+	}{
+		"unknown key": {
+			data: `{"adn": [{"field": "id", "op": "eq", "value": 1}]}`,
+			err:  filter.ErrInvalidValue,
+		},
+		"trailing data": {
+			data: `{"field": "id", "op": "eq", "value": 1} {}`,
+			err:  filter.ErrInvalidValue,
+		},
+		"unknown field": {
+			data: `{"not": {"field": "password", "op": "eq", "value": "x"}}`,
+			err:  filter.ErrUnknownField,
+		},
+		"unsupported op": {
+			data: `{"field": "id", "op": "like", "value": "1%"}`,
+			err:  filter.ErrUnsupportedOp,
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := userFilters.Unmarshal([]byte(tc.data))
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("expected %v, got %v", tc.err, err)
+			}
+		})
+	}
+}
@@ -22,6 +22,12 @@ type TableAlias struct {
 
 	Columns       map[string]string `yaml:"columns,omitempty" toml:"columns,omitempty" json:"columns,omitempty"`
 	Relationships map[string]string `yaml:"relationships,omitempty" toml:"relationships,omitempty" json:"relationships,omitempty"`
+
+	// Set from the embeds config.
+	// Fields holds the paths of embedded columns, e.g. Billing.Street
+	// and Embeds is keyed by the first column of each embed
+	Fields map[string]string `yaml:"-" toml:"-" json:"-"`
+	Embeds map[string]Embed  `yaml:"-" toml:"-" json:"-"`
 }
 
 // initAliases takes the table information from the driver
@@ -97,6 +103,32 @@ func (t TableAlias) Column(column string) string {
 	return c
 }
 
+// Field gets the path of a column's field in the model and setter.
+// This is the column alias unless the column is embedded
+func (t TableAlias) Field(column string) string {
+	if f, ok := t.Fields[column]; ok {
+		return f
+	}
+
+	return t.Column(column)
+}
+
+// InEmbed returns true if the column is part of an embed
+func (t TableAlias) InEmbed(column string) bool {
+	_, ok := t.Fields[column]
+	return ok
+}
+
+// EmbedAt returns the embed that starts at the given column, or nil
+func (t TableAlias) EmbedAt(column string) *Embed {
+	e, ok := t.Embeds[column]
+	if !ok {
+		return nil
+	}
+
+	return &e
+}
+
 // Relationship looks up a relationship, panics if not found.
 func (t TableAlias) Relationship(fkey string) string {
 	r, ok := t.Relationships[fkey]
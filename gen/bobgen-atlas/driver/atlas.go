@@ -176,6 +176,7 @@ func (d *driver) tables(realm *schema.Realm) []drivers.Table {
 					Foreign: fks,
 				},
 				Columns: d.tableColumns(atlasTable, colFilter),
+				Comment: d.tableComment(atlasTable),
 			}
 			tables = append(tables, table)
 		}
@@ -184,6 +185,21 @@ func (d *driver) tables(realm *schema.Realm) []drivers.Table {
 	return tables
 }
 
+// tableComment returns the comment of the table.
+// MySQL gives views the comment "VIEW", which is skipped like the mysql driver does
+func (d *driver) tableComment(table *schema.Table) string {
+	for _, a := range table.Attrs {
+		if attr, ok := a.(*schema.Comment); ok && attr != nil {
+			if d.config.Dialect == "mysql" && attr.Text == "VIEW" {
+				return ""
+			}
+			return attr.Text
+		}
+	}
+
+	return ""
+}
+
 func (d *driver) tableNames(realm *schema.Realm, tableFilter drivers.Filter) []string {
 	names := make([]string, 0, len(realm.Schemas))
 
@@ -1,6 +1,7 @@
 package helpers
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"io/fs"
@@ -17,6 +18,7 @@ import (
 	"github.com/stephenafamo/bob/gen"
 	"github.com/stephenafamo/bob/gen/drivers"
 	"github.com/stephenafamo/bob/gen/importers"
+	"github.com/stephenafamo/bob/gen/migrate"
 )
 
 const DefaultConfigPath = "./bobgen.yaml"
@@ -199,6 +201,24 @@ func Types() drivers.Types {
 			RandomExpr: `addr, _ := net.ParseMAC(f.Internet().MacAddress())
                 return any(addr).(T)`,
 		},
+		"types.Prefix": {
+			Imports: importers.List{
+				`"net/netip"`,
+				`"github.com/stephenafamo/bob/types"`,
+			},
+			RandomExpr: `var addr [4]byte
+                rand.Read(addr[:])
+                return any(types.Prefix{Prefix: netip.PrefixFrom(netip.AddrFrom4(addr), 32)}).(T)`,
+			RandomExprImports: importers.List{`"crypto/rand"`},
+		},
+		"types.HardwareAddr": {
+			Imports: importers.List{
+				`"net"`,
+				`"github.com/stephenafamo/bob/types"`,
+			},
+			RandomExpr: `addr, _ := net.ParseMAC(f.Internet().MacAddress())
+                return any(types.HardwareAddr{HardwareAddr: addr}).(T)`,
+		},
 		"pq.BoolArray": {
 			Imports: importers.List{`"github.com/lib/pq"`},
 			RandomExpr: `arr := make(pq.BoolArray, f.IntBetween(1, 5))
@@ -280,6 +300,45 @@ func Types() drivers.Types {
                 }
                 return any(hs).(T)`,
 		},
+		"types.Geometry": {
+			Imports:    importers.List{`"github.com/stephenafamo/bob/types"`},
+			RandomExpr: `return any(types.NewPoint(f.Float64(6, -180, 180), f.Float64(6, -90, 90), 4326)).(T)`,
+		},
+		"types.Range[int32]": {
+			Imports: importers.List{`"github.com/stephenafamo/bob/types"`},
+			RandomExpr: `lower := f.Int32Between(0, 1000)
+                return any(types.NewRange(lower, lower+f.Int32Between(1, 1000), true, false)).(T)`,
+		},
+		"types.Range[int64]": {
+			Imports: importers.List{`"github.com/stephenafamo/bob/types"`},
+			RandomExpr: `lower := f.Int64Between(0, 1000)
+                return any(types.NewRange(lower, lower+f.Int64Between(1, 1000), true, false)).(T)`,
+		},
+		"types.Range[decimal.Decimal]": {
+			Imports: importers.List{
+				`"github.com/shopspring/decimal"`,
+				`"github.com/stephenafamo/bob/types"`,
+			},
+			RandomExpr: `lower := f.Int64Between(0, 1000)
+                upper := lower + f.Int64Between(1, 1000)
+                return any(types.NewRange(decimal.New(lower, 0), decimal.New(upper, 0), true, false)).(T)`,
+		},
+		"types.Range[time.Time]": {
+			Imports: importers.List{
+				`"time"`,
+				`"github.com/stephenafamo/bob/types"`,
+			},
+			RandomExpr: `lower := f.Time().Time(time.Now())
+                return any(types.NewRange(lower, lower.Add(24*time.Hour), true, false)).(T)`,
+		},
+		"types.Vector": {
+			Imports: importers.List{`"github.com/stephenafamo/bob/types"`},
+			RandomExpr: `vec := make(types.Vector, 3)
+                for i := range vec {
+                    vec[i] = f.Float32(4, -1, 1)
+                }
+                return any(vec).(T)`,
+		},
 		"types.JSON[json.RawMessage]": {
 			Imports: importers.List{
 				`"encoding/json"`,
@@ -299,3 +358,73 @@ func Types() drivers.Types {
 		},
 	}
 }
+
+// WithMigrations calls generate with a throwaway database if migrations are
+// configured. The migrations are applied to the database, and dsn is set to
+// connect to it before generate is called.
+// If the cache is enabled, the generation is skipped when the migrations and
+// the configuration file have not changed since the last generation
+func WithMigrations(ctx context.Context, configPath, dialect string, config migrate.Config, outputs []*gen.Output, dsn *string, generate func() error) error {
+	if config.Dir == "" {
+		return generate()
+	}
+
+	var sum string
+	if config.Cache && len(outputs) > 0 {
+		configFile, err := os.ReadFile(configPath)
+		if err != nil && !errors.Is(err, os.ErrNotExist) {
+			return err
+		}
+
+		sum, err = migrate.Checksum(os.DirFS(config.Dir), configFile, []byte(Version()))
+		if err != nil {
+			return fmt.Errorf("computing the checksum of the migrations: %w", err)
+		}
+
+		upToDate, err := migrate.UpToDate(outputs[0].OutFolder, sum)
+		if err != nil {
+			return err
+		}
+
+		if upToDate {
+			fmt.Fprintln(os.Stderr, "migrations have not changed, skipping generation")
+			return nil
+		}
+	}
+
+	db, err := migrate.NewThrowaway(ctx, dialect, config)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	*dsn = db.DSN
+	if err := generate(); err != nil {
+		return err
+	}
+
+	if sum == "" {
+		return nil
+	}
+
+	return migrate.SaveChecksum(outputs[0].OutFolder, sum)
+}
+
+// Generate runs the generation. If check is true, nothing is written and an
+// error with the diff is returned if the generated code on disk is out of date
+func Generate[T any](ctx context.Context, state *gen.State, driver drivers.Interface[T], check bool) error {
+	if !check {
+		return gen.Run(ctx, state, driver)
+	}
+
+	diff, err := gen.Check(ctx, state, driver)
+	if err != nil {
+		return err
+	}
+
+	if diff != "" {
+		return fmt.Errorf("the generated code is out of date:\n%s", diff)
+	}
+
+	return nil
+}
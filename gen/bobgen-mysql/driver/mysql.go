@@ -103,6 +103,14 @@ func (d *driver) Assemble(ctx context.Context) (*DBInfo, error) {
 		return nil, err
 	}
 
+	if err := d.indexes(ctx, dbinfo.Tables); err != nil {
+		return nil, err
+	}
+
+	if err := d.checks(ctx, dbinfo.Tables); err != nil {
+		return nil, err
+	}
+
 	dbinfo.Enums = d.enums
 	sort.Slice(dbinfo.Enums, func(i, j int) bool {
 		return dbinfo.Enums[i].Type < dbinfo.Enums[j].Type
@@ -111,11 +119,105 @@ func (d *driver) Assemble(ctx context.Context) (*DBInfo, error) {
 	return dbinfo, err
 }
 
+// indexes sets the indexes of the tables, except the primary keys.
+// The parts of functional indexes are left out
+func (d *driver) indexes(ctx context.Context, tables []drivers.Table) error {
+	query := `SELECT table_name, index_name, non_unique, column_name
+	FROM information_schema.statistics
+	WHERE table_schema = ? AND index_name <> 'PRIMARY'
+	ORDER BY table_name, index_name, seq_in_index`
+
+	rows, err := d.conn.QueryContext(ctx, query, d.dbName)
+	if err != nil {
+		return fmt.Errorf("unable to load indexes: %w", err)
+	}
+	defer rows.Close()
+
+	position := make(map[string]int, len(tables))
+	for i, t := range tables {
+		position[t.Key] = i
+	}
+
+	for rows.Next() {
+		var table, name string
+		var nonUnique bool
+		var column sql.NullString
+		if err := rows.Scan(&table, &name, &nonUnique, &column); err != nil {
+			return fmt.Errorf("unable to load indexes: %w", err)
+		}
+
+		i, ok := position[table]
+		if !ok {
+			continue
+		}
+
+		indexes := tables[i].Indexes
+		if len(indexes) == 0 || indexes[len(indexes)-1].Name != name {
+			indexes = append(indexes, drivers.Index{Name: name, Unique: !nonUnique})
+		}
+		if column.Valid {
+			last := &indexes[len(indexes)-1]
+			last.Columns = append(last.Columns, column.String)
+		}
+		tables[i].Indexes = indexes
+	}
+
+	return rows.Err()
+}
+
+// checks sets the check constraints of the tables.
+// They are only reported by MySQL 8.0.16 and later and by MariaDB
+func (d *driver) checks(ctx context.Context, tables []drivers.Table) error {
+	var supported bool
+	if err := d.conn.QueryRowContext(ctx, `SELECT COUNT(*) > 0 FROM information_schema.tables
+		WHERE table_schema = 'information_schema' AND table_name = 'CHECK_CONSTRAINTS'`).Scan(&supported); err != nil {
+		return fmt.Errorf("unable to load check constraints: %w", err)
+	}
+	if !supported {
+		return nil
+	}
+
+	query := `SELECT tc.table_name, cc.constraint_name, cc.check_clause
+	FROM information_schema.check_constraints cc
+	INNER JOIN information_schema.table_constraints tc
+		ON tc.constraint_schema = cc.constraint_schema
+		AND tc.constraint_name = cc.constraint_name
+		AND tc.constraint_type = 'CHECK'
+	WHERE cc.constraint_schema = ?
+	ORDER BY tc.table_name, cc.constraint_name`
+
+	rows, err := d.conn.QueryContext(ctx, query, d.dbName)
+	if err != nil {
+		return fmt.Errorf("unable to load check constraints: %w", err)
+	}
+	defer rows.Close()
+
+	position := make(map[string]int, len(tables))
+	for i, t := range tables {
+		position[t.Key] = i
+	}
+
+	for rows.Next() {
+		var table, name, expression string
+		if err := rows.Scan(&table, &name, &expression); err != nil {
+			return fmt.Errorf("unable to load check constraints: %w", err)
+		}
+
+		i, ok := position[table]
+		if !ok {
+			continue
+		}
+		tables[i].Checks = append(tables[i].Checks, drivers.Check{Name: name, Expression: expression})
+	}
+
+	return rows.Err()
+}
+
 // TableNames connects to the postgres database and
 // retrieves all table names from the information_schema where the
 // table schema is schema. It uses a whitelist and blacklist.
 func (d *driver) TablesInfo(ctx context.Context, tableFilter drivers.Filter) (drivers.TablesInfo, error) {
-	query := "SELECT table_name as `key`, table_name as name FROM information_schema.tables WHERE table_schema = ?"
+	query := "SELECT table_name as `key`, table_name as name, IF(table_type = 'VIEW', '', table_comment) as comment FROM information_schema.tables WHERE table_schema = ?"
 	args := []any{d.dbName}
 
 	include := tableFilter.Only
@@ -155,6 +257,9 @@ func (d *driver) TableDetails(ctx context.Context, info drivers.TableInfo, colFi
 	c.column_comment,
 	c.data_type,
 	c.column_default,
+	(CASE WHEN c.data_type IN ('char', 'varchar') THEN c.character_maximum_length ELSE 0 END) AS max_length,
+	(CASE WHEN c.data_type = 'decimal' THEN c.numeric_precision ELSE 0 END) AS numeric_precision,
+	(CASE WHEN c.data_type = 'decimal' THEN c.numeric_scale ELSE 0 END) AS numeric_scale,
 	c.extra = 'auto_increment' AS autoincr,
 	c.is_nullable = 'YES' AS nullable,
 	(c.extra = 'STORED GENERATED' OR c.extra = 'VIRTUAL GENERATED') is_generated
@@ -185,9 +290,10 @@ func (d *driver) TableDetails(ctx context.Context, info drivers.TableInfo, colFi
 
 	for rows.Next() {
 		var colName, colFullType, colComment, colType string
+		var maxLength, precision, scale int
 		var autoIncr, nullable, generated bool
 		var defaultValue *string
-		if err := rows.Scan(&colName, &colFullType, &colComment, &colType, &defaultValue, &autoIncr, &nullable, &generated); err != nil {
+		if err := rows.Scan(&colName, &colFullType, &colComment, &colType, &defaultValue, &maxLength, &precision, &scale, &autoIncr, &nullable, &generated); err != nil {
 			return "", "", nil, fmt.Errorf("unable to scan for table %s: %w", tableName, err)
 		}
 
@@ -202,6 +308,9 @@ func (d *driver) TableDetails(ctx context.Context, info drivers.TableInfo, colFi
 			Nullable:  nullable,
 			Generated: generated,
 			AutoIncr:  autoIncr,
+			MaxLength: maxLength,
+			Precision: precision,
+			Scale:     scale,
 		}
 
 		if defaultValue != nil {
@@ -294,6 +403,9 @@ func (*driver) translateColumnType(c drivers.Column, fullType string) drivers.Co
 		c.Type = "decimal.Decimal"
 	case "json":
 		c.Type = "types.JSON[json.RawMessage]"
+	case "geometry", "point", "linestring", "polygon",
+		"multipoint", "multilinestring", "multipolygon", "geometrycollection", "geomcollection":
+		c.Type = "types.Geometry"
 	default:
 		c.Type = "string"
 	}
@@ -181,7 +181,9 @@ create table type_monsters (
     generated_nnull text GENERATED ALWAYS AS (UPPER(text_nnull)) STORED NOT NULL,
     generated_null text GENERATED ALWAYS AS (UPPER(text_null)) STORED,
 
-    INDEX(int_one, int_two)
+    INDEX(int_one, int_two),
+
+    CONSTRAINT int_six_positive CHECK (int_six >= 0)
 );
 
 create view user_videos as
@@ -11,6 +11,8 @@ import (
 	"github.com/stephenafamo/bob/gen"
 	helpers "github.com/stephenafamo/bob/gen/bobgen-helpers"
 	"github.com/stephenafamo/bob/gen/bobgen-mysql/driver"
+	"github.com/stephenafamo/bob/gen/drivers"
+	"github.com/stephenafamo/bob/gen/migrate"
 	"github.com/urfave/cli/v2"
 )
 
@@ -25,7 +27,7 @@ func main() {
 	app := &cli.App{
 		Name:      "bobgen-mysql",
 		Usage:     "Generate models and factories from your PostgreSQL database",
-		UsageText: "bobgen-mysql [-c FILE]",
+		UsageText: "bobgen-mysql [-c FILE] [--check]",
 		Version:   helpers.Version(),
 		Flags: []cli.Flag{
 			&cli.StringFlag{
@@ -34,8 +36,15 @@ func main() {
 				Value:   helpers.DefaultConfigPath,
 				Usage:   "Load configuration from `FILE`",
 			},
+			&cli.BoolFlag{
+				Name:  "check",
+				Usage: "Fail with a diff if the generated code on disk is out of date, without writing anything",
+			},
 		},
 		Action: run,
+		Commands: []*cli.Command{
+			migrate.Command(getDriver),
+		},
 	}
 
 	if err := app.RunContext(ctx, os.Args); err != nil {
@@ -49,16 +58,32 @@ func run(c *cli.Context) error {
 		return err
 	}
 
-	d := driver.New(driverConfig)
 	outputs := helpers.DefaultOutputs(
 		driverConfig.Output, driverConfig.Pkgname, config.NoFactory,
 		&helpers.Templates{Models: []fs.FS{gen.MySQLModelTemplates}},
 	)
 
-	state := &gen.State{
-		Config:  config,
-		Outputs: outputs,
+	// The cache would skip the comparison
+	if c.Bool("check") {
+		config.Migrations.Cache = false
+	}
+
+	return helpers.WithMigrations(c.Context, c.String("config"), "mysql", config.Migrations, outputs, &driverConfig.Dsn, func() error {
+		d := driver.New(driverConfig)
+		state := &gen.State{
+			Config:  config,
+			Outputs: outputs,
+		}
+
+		return helpers.Generate(c.Context, state, d, c.Bool("check"))
+	})
+}
+
+func getDriver(configPath string) (drivers.Interface[any], error) {
+	_, driverConfig, err := helpers.GetConfigFromFile[driver.Config](configPath, "mysql")
+	if err != nil {
+		return nil, err
 	}
 
-	return gen.Run(c.Context, state, d)
+	return driver.New(driverConfig), nil
 }
@@ -117,6 +117,7 @@ func (d *driver) tables() []drivers.Table {
 			Key:     model.TableName(),
 			Name:    model.TableName(),
 			Columns: d.tableColumns(model, colFilter),
+			Comment: model.Documentation,
 			Constraints: drivers.Constraints{
 				Primary: pk,
 				Uniques: uniques,
@@ -55,6 +55,7 @@ type Model struct {
 	Fields        []Field       `json:"fields"`
 	UniqueIndexes []UniqueIndex `json:"uniqueIndexes"`
 	PrimaryKey    PrimaryKey    `json:"primaryKey"`
+	Documentation string        `json:"documentation"`
 }
 
 func (m Model) TableName() string {
@@ -141,6 +141,18 @@ func (d *driver) Assemble(ctx context.Context) (*DBInfo, error) {
 		return nil, err
 	}
 
+	if err := d.inherits(ctx, dbinfo.Tables); err != nil {
+		return nil, err
+	}
+
+	if err := d.indexes(ctx, dbinfo.Tables); err != nil {
+		return nil, err
+	}
+
+	if err := d.checks(ctx, dbinfo.Tables); err != nil {
+		return nil, err
+	}
+
 	dbinfo.Enums = make([]drivers.Enum, len(d.enums))
 	for i, e := range d.enums {
 		dbinfo.Enums[i] = drivers.Enum{
@@ -165,7 +177,8 @@ func (d *driver) TablesInfo(ctx context.Context, tableFilter drivers.Filter) (dr
 	query := fmt.Sprintf(`SELECT
 	  %s AS "key" ,
 	  table_schema AS "schema",
-	  table_name AS "name"
+	  table_name AS "name",
+	  coalesce(obj_description((quote_ident(table_schema) || '.' || quote_ident(table_name))::regclass, 'pg_class'), '') AS "comment"
 	FROM (
 	  SELECT
 		table_name,
@@ -179,7 +192,16 @@ func (d *driver) TablesInfo(ctx context.Context, tableFilter drivers.Filter) (dr
 	  FROM
 		pg_matviews) AS v
 	WHERE
-	  v.table_schema = ANY ($2)`, keyClause)
+	  v.table_schema = ANY ($2)
+	  -- Partitions are queried through the partitioned table
+	  AND NOT EXISTS (
+		SELECT 1
+		FROM pg_catalog.pg_class c
+		INNER JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+		WHERE c.relispartition
+		  AND n.nspname = v.table_schema
+		  AND c.relname = v.table_name
+	  )`, keyClause)
 	args := []any{d.config.SharedSchema, d.config.Schemas}
 
 	include := tableFilter.Only
@@ -231,6 +253,9 @@ func (d *driver) TableDetails(ctx context.Context, info drivers.TableInfo, colFi
 	c.domain_name,
 	c.column_default,
 	coalesce(col_description(('"' || c.table_schema || '"."' || c.table_name || '"')::regclass::oid, ordinal_position), '') AS column_comment,
+	coalesce(c.character_maximum_length, 0) AS max_length,
+	(CASE WHEN c.data_type = 'numeric' THEN coalesce(c.numeric_precision, 0) ELSE 0 END) AS numeric_precision,
+	(CASE WHEN c.data_type = 'numeric' THEN coalesce(c.numeric_scale, 0) ELSE 0 END) AS numeric_scale,
 	c.is_nullable = 'YES' AS is_nullable,
 	(
 		CASE WHEN c.is_generated = 'ALWAYS'
@@ -286,6 +311,9 @@ func (d *driver) TableDetails(ctx context.Context, info drivers.TableInfo, colFi
 		domain_name,
 		column_default,
 		column_comment,
+		max_length,
+		numeric_precision,
+		numeric_scale,
 		is_nullable,
 		is_generated,
 		is_identity
@@ -324,8 +352,9 @@ func (d *driver) TableDetails(ctx context.Context, info drivers.TableInfo, colFi
 	for rows.Next() {
 		var colName, colType, udtSchema, udtName, comment string
 		var defaultValue, arrayType, domainName *string
+		var maxLength, precision, scale int
 		var nullable, generated, identity bool
-		if err := rows.Scan(&colName, &colType, &udtSchema, &udtName, &arrayType, &domainName, &defaultValue, &comment, &nullable, &generated, &identity); err != nil {
+		if err := rows.Scan(&colName, &colType, &udtSchema, &udtName, &arrayType, &domainName, &defaultValue, &comment, &maxLength, &precision, &scale, &nullable, &generated, &identity); err != nil {
 			return "", "", nil, fmt.Errorf("unable to scan for table %s: %w", info.Key, err)
 		}
 
@@ -335,6 +364,9 @@ func (d *driver) TableDetails(ctx context.Context, info drivers.TableInfo, colFi
 			Comment:   comment,
 			Nullable:  nullable,
 			Generated: generated,
+			MaxLength: maxLength,
+			Precision: precision,
+			Scale:     scale,
 		}
 		info := colInfo{
 			UDTSchema: udtSchema,
@@ -370,6 +402,23 @@ func (d *driver) TableDetails(ctx context.Context, info drivers.TableInfo, colFi
 		columns = append(columns, d.translateColumnType(column, info))
 	}
 
+	partitionKey, err := d.partitionKey(ctx, info)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	sequences, err := d.sequences(ctx, info)
+	if err != nil {
+		return "", "", nil, err
+	}
+
+	for i, col := range columns {
+		if partitionKey[col.Name] {
+			columns[i].PartitionKey = true
+		}
+		columns[i].Sequence = sequences[col.Name]
+	}
+
 	schema := info.Schema
 	if schema == d.config.SharedSchema {
 		schema = ""
@@ -378,6 +427,206 @@ func (d *driver) TableDetails(ctx context.Context, info drivers.TableInfo, colFi
 	return schema, info.Name, columns, nil
 }
 
+// partitionKey returns the columns a declaratively partitioned table is partitioned by.
+// Expressions in the partition key are left out
+func (d *driver) partitionKey(ctx context.Context, info drivers.TableInfo) (map[string]bool, error) {
+	query := `SELECT a.attname
+	FROM pg_catalog.pg_partitioned_table pt
+	INNER JOIN pg_catalog.pg_class c ON c.oid = pt.partrelid
+	INNER JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+	INNER JOIN pg_catalog.pg_attribute a ON a.attrelid = c.oid
+		AND a.attnum = ANY (pt.partattrs::int2[])
+	WHERE n.nspname = $1 AND c.relname = $2`
+
+	cols, err := stdscan.All(ctx, d.conn, scan.SingleColumnMapper[string], query, info.Schema, info.Name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the partition key of %s: %w", info.Key, err)
+	}
+
+	key := make(map[string]bool, len(cols))
+	for _, col := range cols {
+		key[col] = true
+	}
+
+	return key, nil
+}
+
+// sequences returns the sequences owned by the columns of the table, such as
+// the sequences of serial and identity columns, keyed by column
+func (d *driver) sequences(ctx context.Context, info drivers.TableInfo) (map[string]string, error) {
+	query := `SELECT a.attname AS column_name, quote_ident(sn.nspname) || '.' || quote_ident(s.relname) AS sequence
+	FROM pg_catalog.pg_depend dep
+	INNER JOIN pg_catalog.pg_class s ON s.oid = dep.objid AND s.relkind = 'S'
+	INNER JOIN pg_catalog.pg_namespace sn ON sn.oid = s.relnamespace
+	INNER JOIN pg_catalog.pg_class c ON c.oid = dep.refobjid
+	INNER JOIN pg_catalog.pg_namespace n ON n.oid = c.relnamespace
+	INNER JOIN pg_catalog.pg_attribute a ON a.attrelid = c.oid AND a.attnum = dep.refobjsubid
+	WHERE dep.classid = 'pg_catalog.pg_class'::regclass
+		AND dep.deptype IN ('a', 'i')
+		AND n.nspname = $1 AND c.relname = $2`
+
+	type owned struct {
+		ColumnName string
+		Sequence   string
+	}
+
+	rows, err := stdscan.All(ctx, d.conn, scan.StructMapper[owned](), query, info.Schema, info.Name)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the sequences of %s: %w", info.Key, err)
+	}
+
+	sequences := make(map[string]string, len(rows))
+	for _, row := range rows {
+		sequences[row.ColumnName] = row.Sequence
+	}
+
+	return sequences, nil
+}
+
+// inherits sets the parents of the tables that use table inheritance.
+// Partitions also inherit from their partitioned table, but are not generated
+func (d *driver) inherits(ctx context.Context, tables []drivers.Table) error {
+	query := `SELECT
+		(CASE WHEN cn.nspname <> $1 THEN cn.nspname || '.' ELSE '' END || c.relname) AS child,
+		(CASE WHEN pn.nspname <> $1 THEN pn.nspname || '.' ELSE '' END || p.relname) AS parent
+	FROM pg_catalog.pg_inherits i
+	INNER JOIN pg_catalog.pg_class c ON c.oid = i.inhrelid
+	INNER JOIN pg_catalog.pg_namespace cn ON cn.oid = c.relnamespace
+	INNER JOIN pg_catalog.pg_class p ON p.oid = i.inhparent
+	INNER JOIN pg_catalog.pg_namespace pn ON pn.oid = p.relnamespace
+	WHERE NOT c.relispartition
+	ORDER BY child, i.inhseqno`
+
+	type inheritance struct {
+		Child  string
+		Parent string
+	}
+
+	rows, err := stdscan.All(ctx, d.conn, scan.StructMapper[inheritance](), query, d.config.SharedSchema)
+	if err != nil {
+		return fmt.Errorf("unable to load table inheritance: %w", err)
+	}
+
+	index := make(map[string]int, len(tables))
+	for i, t := range tables {
+		index[t.Key] = i
+	}
+
+	for _, row := range rows {
+		child, ok := index[row.Child]
+		if !ok {
+			continue
+		}
+		if _, ok := index[row.Parent]; !ok {
+			continue
+		}
+		tables[child].Inherits = append(tables[child].Inherits, row.Parent)
+	}
+
+	return nil
+}
+
+// indexes sets the indexes of the tables, except the indexes of primary keys.
+// The columns of expression indexes are the expressions
+func (d *driver) indexes(ctx context.Context, tables []drivers.Table) error {
+	query := `SELECT
+		(CASE WHEN n.nspname <> $1 THEN n.nspname || '.' ELSE '' END || t.relname) AS "table",
+		i.relname AS "name",
+		ix.indisunique AS "unique",
+		ARRAY(
+			SELECT pg_catalog.pg_get_indexdef(ix.indexrelid, k, true)
+			FROM generate_series(1, ix.indnkeyatts) AS k
+			ORDER BY k
+		) AS "columns"
+	FROM pg_catalog.pg_index ix
+	INNER JOIN pg_catalog.pg_class i ON i.oid = ix.indexrelid
+	INNER JOIN pg_catalog.pg_class t ON t.oid = ix.indrelid
+	INNER JOIN pg_catalog.pg_namespace n ON n.oid = t.relnamespace
+	WHERE n.nspname = ANY ($2) AND NOT ix.indisprimary
+	ORDER BY "table", "name"`
+
+	type index struct {
+		Table   string
+		Name    string
+		Unique  bool
+		Columns pq.StringArray
+	}
+
+	rows, err := stdscan.All(ctx, d.conn, scan.StructMapper[index](), query, d.config.SharedSchema, d.config.Schemas)
+	if err != nil {
+		return fmt.Errorf("unable to load indexes: %w", err)
+	}
+
+	position := make(map[string]int, len(tables))
+	for i, t := range tables {
+		position[t.Key] = i
+	}
+
+	for _, row := range rows {
+		i, ok := position[row.Table]
+		if !ok {
+			continue
+		}
+		tables[i].Indexes = append(tables[i].Indexes, drivers.Index{
+			Name:    row.Name,
+			Columns: row.Columns,
+			Unique:  row.Unique,
+		})
+	}
+
+	return nil
+}
+
+// checks sets the check constraints of the tables
+func (d *driver) checks(ctx context.Context, tables []drivers.Table) error {
+	query := `SELECT
+		(CASE WHEN n.nspname <> $1 THEN n.nspname || '.' ELSE '' END || t.relname) AS "table",
+		con.conname AS "name",
+		ARRAY(
+			SELECT a.attname
+			FROM pg_catalog.pg_attribute a
+			WHERE a.attrelid = con.conrelid AND a.attnum = ANY (con.conkey)
+			ORDER BY a.attnum
+		) AS "columns",
+		pg_catalog.pg_get_expr(con.conbin, con.conrelid) AS "expression"
+	FROM pg_catalog.pg_constraint con
+	INNER JOIN pg_catalog.pg_class t ON t.oid = con.conrelid
+	INNER JOIN pg_catalog.pg_namespace n ON n.oid = t.relnamespace
+	WHERE n.nspname = ANY ($2) AND con.contype = 'c'
+	ORDER BY "table", "name"`
+
+	type check struct {
+		Table      string
+		Name       string
+		Columns    pq.StringArray
+		Expression string
+	}
+
+	rows, err := stdscan.All(ctx, d.conn, scan.StructMapper[check](), query, d.config.SharedSchema, d.config.Schemas)
+	if err != nil {
+		return fmt.Errorf("unable to load check constraints: %w", err)
+	}
+
+	position := make(map[string]int, len(tables))
+	for i, t := range tables {
+		position[t.Key] = i
+	}
+
+	for _, row := range rows {
+		i, ok := position[row.Table]
+		if !ok {
+			continue
+		}
+		tables[i].Checks = append(tables[i].Checks, drivers.Check{
+			Name:       row.Name,
+			Columns:    row.Columns,
+			Expression: row.Expression,
+		})
+	}
+
+	return nil
+}
+
 func (d *driver) loadEnums(ctx context.Context) error {
 	if d.enums != nil {
 		return nil
@@ -11,6 +11,7 @@ import (
 
 	"github.com/jackc/pgx/v5"
 	_ "github.com/jackc/pgx/v5/stdlib"
+	"github.com/stephenafamo/bob/gen"
 	"github.com/stephenafamo/bob/gen/drivers"
 	testutils "github.com/stephenafamo/bob/test_utils"
 )
@@ -102,6 +103,14 @@ func TestDriver(t *testing.T) {
 				},
 				GoldenFile:      tt.goldenJson,
 				OverwriteGolden: *flagOverwriteGolden,
+				Config: gen.Config{
+					// compile the aggregate and tree helpers
+					Aggregates: true,
+					Trees: gen.Trees{
+						"users":         {Strategy: gen.TreeRecursive, Parent: "parent_id"},
+						"type_monsters": {Strategy: gen.TreePath, Path: "string_seven"},
+					},
+				},
 			})
 		})
 	}
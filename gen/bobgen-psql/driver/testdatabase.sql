@@ -263,7 +263,9 @@ create table type_monsters (
     base text null,
 
     generated_nnull text NOT NULL GENERATED ALWAYS AS (UPPER(base)) STORED,
-    generated_null text NULL GENERATED ALWAYS AS (UPPER(base)) STORED
+    generated_null text NULL GENERATED ALWAYS AS (UPPER(base)) STORED,
+
+    constraint int_six_positive check (int_six >= 0)
 );
 
 create view user_videos as 
@@ -71,10 +71,18 @@ func (d *driver) translateColumnType(c drivers.Column, info colInfo) drivers.Col
 		c.Type = "pgeo.Polygon"
 	case "uuid":
 		c.Type = "uuid.UUID"
+	case "int4range":
+		c.Type = "types.Range[int32]"
+	case "int8range":
+		c.Type = "types.Range[int64]"
+	case "numrange":
+		c.Type = "types.Range[decimal.Decimal]"
+	case "tsrange", "tstzrange", "daterange":
+		c.Type = "types.Range[time.Time]"
 	case "inet", "cidr":
-		c.Type = "netip.Addr"
-	case "macaddr":
-		c.Type = "net.HardwareAddr"
+		c.Type = "types.Prefix"
+	case "macaddr", "macaddr8":
+		c.Type = "types.HardwareAddr"
 	case "ENUM":
 		c.Type = "string"
 		for _, e := range d.enums {
@@ -94,6 +102,10 @@ func (d *driver) translateColumnType(c drivers.Column, info colInfo) drivers.Col
 			c.DBType = "hstore"
 		case "citext":
 			c.Type = "string"
+		case "geometry", "geography":
+			c.Type = "types.Geometry"
+		case "vector":
+			c.Type = "types.Vector"
 		default:
 			c.Type = "string"
 			fmt.Fprintf(os.Stderr, "warning: incompatible data type detected: %s\n", info.UDTName)
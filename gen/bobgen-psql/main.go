@@ -10,6 +10,8 @@ import (
 	"github.com/stephenafamo/bob/gen"
 	helpers "github.com/stephenafamo/bob/gen/bobgen-helpers"
 	"github.com/stephenafamo/bob/gen/bobgen-psql/driver"
+	"github.com/stephenafamo/bob/gen/drivers"
+	"github.com/stephenafamo/bob/gen/migrate"
 	"github.com/urfave/cli/v2"
 )
 
@@ -24,7 +26,7 @@ func main() {
 	app := &cli.App{
 		Name:      "bobgen-psql",
 		Usage:     "Generate models and factories from your PostgreSQL database",
-		UsageText: "bobgen-psql [-c FILE]",
+		UsageText: "bobgen-psql [-c FILE] [--check]",
 		Version:   helpers.Version(),
 		Flags: []cli.Flag{
 			&cli.StringFlag{
@@ -33,8 +35,15 @@ func main() {
 				Value:   helpers.DefaultConfigPath,
 				Usage:   "Load configuration from `FILE`",
 			},
+			&cli.BoolFlag{
+				Name:  "check",
+				Usage: "Fail with a diff if the generated code on disk is out of date, without writing anything",
+			},
 		},
 		Action: run,
+		Commands: []*cli.Command{
+			migrate.Command(getDriver),
+		},
 	}
 
 	if err := app.RunContext(ctx, os.Args); err != nil {
@@ -48,13 +57,29 @@ func run(c *cli.Context) error {
 		return err
 	}
 
-	d := driver.New(driverConfig)
 	outputs := helpers.DefaultOutputs(driverConfig.Output, driverConfig.Pkgname, config.NoFactory, nil)
 
-	state := &gen.State{
-		Config:  config,
-		Outputs: outputs,
+	// The cache would skip the comparison
+	if c.Bool("check") {
+		config.Migrations.Cache = false
+	}
+
+	return helpers.WithMigrations(c.Context, c.String("config"), "psql", config.Migrations, outputs, &driverConfig.Dsn, func() error {
+		d := driver.New(driverConfig)
+		state := &gen.State{
+			Config:  config,
+			Outputs: outputs,
+		}
+
+		return helpers.Generate(c.Context, state, d, c.Bool("check"))
+	})
+}
+
+func getDriver(configPath string) (drivers.Interface[any], error) {
+	_, driverConfig, err := helpers.GetConfigFromFile[driver.Config](configPath, "psql")
+	if err != nil {
+		return nil, err
 	}
 
-	return gen.Run(c.Context, state, d)
+	return driver.New(driverConfig), nil
 }
@@ -211,6 +211,11 @@ func (d driver) getTable(ctx context.Context, schema, name string) (drivers.Tabl
 		return table, err
 	}
 
+	table.Indexes, err = d.indexes(ctx, schema, name)
+	if err != nil {
+		return table, err
+	}
+
 	return table, nil
 }
 
@@ -452,6 +457,52 @@ func (d driver) uniques(ctx context.Context, schema, tableName string) ([]driver
 	return uniques, nil
 }
 
+// indexes retrieves the indexes of a table, except the index of the primary key.
+// The expressions of indexes are left out
+func (d driver) indexes(ctx context.Context, schema, tableName string) ([]drivers.Index, error) {
+	rows, err := d.conn.QueryContext(ctx, fmt.Sprintf("PRAGMA '%s'.index_list('%s')", schema, tableName))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var indexes []drivers.Index
+	for rows.Next() {
+		var seq, unique, partial int
+		var name, origin string
+
+		err = rows.Scan(&seq, &name, &unique, &origin, &partial)
+		if err != nil {
+			return nil, err
+		}
+
+		if origin == "pk" {
+			continue
+		}
+
+		indexes = append(indexes, drivers.Index{Name: name, Unique: unique == 1})
+	}
+	rows.Close()
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for i, index := range indexes {
+		columns, err := d.getUniqueIndex(ctx, schema, index.Name)
+		if err != nil {
+			return nil, err
+		}
+		indexes[i].Columns = columns.Columns
+	}
+
+	sort.Slice(indexes, func(i, j int) bool {
+		return indexes[i].Name < indexes[j].Name
+	})
+
+	return indexes, nil
+}
+
 func (d driver) getUniqueIndex(ctx context.Context, schema, index string) (drivers.Constraint, error) {
 	unique := drivers.Constraint{Name: index}
 
@@ -109,6 +109,8 @@ func TestAssemble(t *testing.T) {
 				GoldenFile:      tt.goldenJson,
 				OverwriteGolden: *flagOverwriteGolden,
 				Templates:       &helpers.Templates{Models: []fs.FS{gen.SQLiteModelTemplates}},
+				// compile the aggregate helpers
+				Config: gen.Config{Aggregates: true},
 			})
 		})
 	}
@@ -12,7 +12,7 @@ var (
 func (s {{$tAlias.UpSingular}}Setter) InsertMod() bob.Mod[*dialect.InsertQuery] {
   vals := make([]bob.Expression, 0, {{len $table.NonGeneratedColumns}})
 	{{range $column := $table.NonGeneratedColumns -}}
-		{{$colAlias := $tAlias.Column $column.Name -}}
+		{{$colAlias := $tAlias.Field $column.Name -}}
 		if !s.{{$colAlias}}.IsUnset() {
 			vals = append(vals, {{$.Dialect}}.Arg(s.{{$colAlias}}))
 		}
@@ -0,0 +1,210 @@
+package gen
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/stephenafamo/bob/gen/drivers"
+)
+
+// Check generates the code in memory and compares it with the files in the
+// output folders, without writing anything.
+// It returns a readable diff of the files that are different or missing, and
+// if Config.Wipe is set, of the files that are no longer generated.
+// An empty diff means the files on disk are up to date
+func Check[T any](ctx context.Context, s *State, driver drivers.Interface[T], plugins ...Plugin) (string, error) {
+	s.MemFS = map[string][]byte{}
+	if err := Run(ctx, s, driver, plugins...); err != nil {
+		return "", err
+	}
+
+	return compareOutput(s.MemFS, s.Outputs, s.Config.Wipe)
+}
+
+func compareOutput(memFS map[string][]byte, outputs []*Output, wipe bool) (string, error) {
+	var sb strings.Builder
+
+	names := make([]string, 0, len(memFS))
+	for name := range memFS {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		generated := memFS[name]
+
+		onDisk, err := os.ReadFile(filepath.FromSlash(name))
+		if errors.Is(err, fs.ErrNotExist) {
+			fmt.Fprintf(&sb, "%s: missing\n", name)
+			continue
+		}
+		if err != nil {
+			return "", err
+		}
+
+		if !bytes.Equal(onDisk, generated) {
+			fmt.Fprintf(&sb, "%s: different (-on disk +generated)\n%s\n", name, lineDiff(string(onDisk), string(generated)))
+		}
+	}
+
+	if !wipe {
+		return sb.String(), nil
+	}
+
+	// The output folders can be nested
+	seen := make(map[string]struct{})
+	var extra []string
+	for _, o := range outputs {
+		err := filepath.WalkDir(o.OutFolder, func(path string, d fs.DirEntry, err error) error {
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			if err != nil || d.IsDir() {
+				return err
+			}
+
+			name := filepath.ToSlash(path)
+			if _, ok := seen[name]; ok {
+				return nil
+			}
+			seen[name] = struct{}{}
+
+			if _, ok := memFS[name]; !ok {
+				extra = append(extra, name)
+			}
+			return nil
+		})
+		if err != nil {
+			return "", err
+		}
+	}
+
+	sort.Strings(extra)
+	for _, name := range extra {
+		fmt.Fprintf(&sb, "%s: no longer generated\n", name)
+	}
+
+	return sb.String(), nil
+}
+
+// lines of context around the changes in lineDiff
+const diffContext = 3
+
+// lineDiff returns the changed lines between a and b with some context.
+// Removed lines start with "-" and added lines with "+"
+func lineDiff(a, b string) string {
+	x, y := strings.SplitAfter(a, "\n"), strings.SplitAfter(b, "\n")
+
+	// Only the lines between the common prefix and suffix are diffed
+	pre := 0
+	for pre < len(x) && pre < len(y) && x[pre] == y[pre] {
+		pre++
+	}
+	suf := 0
+	for suf < len(x)-pre && suf < len(y)-pre && x[len(x)-1-suf] == y[len(y)-1-suf] {
+		suf++
+	}
+
+	ops := make([]diffOp, 0, len(x)+len(y))
+	for _, l := range x[:pre] {
+		ops = append(ops, diffOp{' ', l})
+	}
+	ops = append(ops, diffLines(x[pre:len(x)-suf], y[pre:len(y)-suf])...)
+	for _, l := range x[len(x)-suf:] {
+		ops = append(ops, diffOp{' ', l})
+	}
+
+	// Print the changes and the lines close to them
+	var sb strings.Builder
+	last := -1
+	for i, op := range ops {
+		near := false
+		for j := i - diffContext; j <= i+diffContext; j++ {
+			if j >= 0 && j < len(ops) && ops[j].kind != ' ' {
+				near = true
+				break
+			}
+		}
+		if !near {
+			continue
+		}
+
+		if last >= 0 && i > last+1 {
+			sb.WriteString("...\n")
+		}
+		last = i
+
+		fmt.Fprintf(&sb, "%c %s\n", op.kind, strings.TrimSuffix(op.line, "\n"))
+	}
+
+	return sb.String()
+}
+
+type diffOp struct {
+	kind byte
+	line string
+}
+
+// diffLines uses the longest common subsequence of the lines.
+// Very large changes are shown as removing all the lines and adding the new ones
+func diffLines(x, y []string) []diffOp {
+	ops := make([]diffOp, 0, len(x)+len(y))
+
+	if len(x)*len(y) > 4_000_000 {
+		for _, l := range x {
+			ops = append(ops, diffOp{'-', l})
+		}
+		for _, l := range y {
+			ops = append(ops, diffOp{'+', l})
+		}
+		return ops
+	}
+
+	// lcs[i][j] is the length of the LCS of x[i:] and y[j:]
+	lcs := make([][]int, len(x)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(y)+1)
+	}
+	for i := len(x) - 1; i >= 0; i-- {
+		for j := len(y) - 1; j >= 0; j-- {
+			if x[i] == y[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else if lcs[i+1][j] >= lcs[i][j+1] {
+				lcs[i][j] = lcs[i+1][j]
+			} else {
+				lcs[i][j] = lcs[i][j+1]
+			}
+		}
+	}
+
+	i, j := 0, 0
+	for i < len(x) && j < len(y) {
+		switch {
+		case x[i] == y[j]:
+			ops = append(ops, diffOp{' ', x[i]})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			ops = append(ops, diffOp{'-', x[i]})
+			i++
+		default:
+			ops = append(ops, diffOp{'+', y[j]})
+			j++
+		}
+	}
+	for ; i < len(x); i++ {
+		ops = append(ops, diffOp{'-', x[i]})
+	}
+	for ; j < len(y); j++ {
+		ops = append(ops, diffOp{'+', y[j]})
+	}
+
+	return ops
+}
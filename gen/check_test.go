@@ -0,0 +1,78 @@
+package gen
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestCompareOutput(t *testing.T) {
+	dir := t.TempDir()
+	models := filepath.Join(dir, "models")
+	if err := os.MkdirAll(filepath.Join(models, "factory"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	files := map[string]string{
+		"users.go":              "package models\n\ntype User struct{}\n",
+		"videos.go":             "package models\n\ntype Video struct{}\n",
+		"old.go":                "package models\n",
+		"factory/bobfactory.go": "package factory\n",
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(models, name), []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	key := func(name string) string { return filepath.ToSlash(filepath.Join(models, name)) }
+	memFS := map[string][]byte{
+		key("users.go"):              []byte(files["users.go"]),
+		key("videos.go"):             []byte("package models\n\ntype Video struct {\n\tID int\n}\n"),
+		key("tags.go"):               []byte("package models\n"),
+		key("factory/bobfactory.go"): []byte(files["factory/bobfactory.go"]),
+	}
+	outputs := []*Output{{OutFolder: models}, {OutFolder: filepath.Join(models, "factory")}}
+
+	diff, err := compareOutput(memFS, outputs, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, expected := range []string{
+		key("videos.go") + ": different",
+		"+ \tID int",
+		key("tags.go") + ": missing",
+		key("old.go") + ": no longer generated",
+	} {
+		if !strings.Contains(diff, expected) {
+			t.Errorf("expected the diff to contain %q, got:\n%s", expected, diff)
+		}
+	}
+
+	for _, unexpected := range []string{key("users.go"), key("factory/bobfactory.go")} {
+		if strings.Contains(diff, unexpected) {
+			t.Errorf("expected the diff not to contain %q, got:\n%s", unexpected, diff)
+		}
+	}
+
+	diff, err = compareOutput(memFS, outputs, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if strings.Contains(diff, "no longer generated") {
+		t.Errorf("expected extra files to be ignored without wipe, got:\n%s", diff)
+	}
+}
+
+func TestLineDiff(t *testing.T) {
+	a := "1\n2\n3\n4\n5\n6\n7\n8\n9\n10\n"
+	b := "1\n2\n3\n4\n5\nsix\n7\n8\n9\n10\n11\n"
+
+	expected := "  3\n  4\n  5\n- 6\n+ six\n  7\n  8\n  9\n  10\n+ 11\n  \n"
+	if got := lineDiff(a, b); got != expected {
+		t.Errorf("expected:\n%s\ngot:\n%s", expected, got)
+	}
+}
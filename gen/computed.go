@@ -0,0 +1,63 @@
+package gen
+
+import (
+	"fmt"
+
+	"github.com/stephenafamo/bob/gen/drivers"
+)
+
+// Computed declares computed fields, keyed by table
+type Computed map[string][]ComputedField
+
+// ComputedField is a read-only field of a model that is read from an SQL expression,
+// e.g. first_name || ' ' || last_name. It is selected with the columns of the table
+// and can be used in the where mods and filters, but it is never inserted or updated
+type ComputedField struct {
+	// The name of the field, used like a column name
+	Name string `yaml:"name"`
+	// The SQL expression, written as is in the query
+	Expression string `yaml:"expression"`
+	// The Go type of the value
+	Type string `yaml:"type"`
+	// If the expression can be NULL
+	Nullable bool   `yaml:"nullable"`
+	Comment  string `yaml:"comment"`
+}
+
+// processComputed adds the computed fields to their tables as generated columns
+func processComputed(tables []drivers.Table, computed Computed) error {
+	for key, fields := range computed {
+		t, ok := findTable(tables, key)
+		if !ok {
+			return fmt.Errorf("computed: table %s does not exist", key)
+		}
+
+		for _, f := range fields {
+			switch {
+			case f.Name == "":
+				return fmt.Errorf("computed field in %s has no name", key)
+			case f.Expression == "":
+				return fmt.Errorf("computed field %s.%s has no expression", key, f.Name)
+			case f.Type == "":
+				return fmt.Errorf("computed field %s.%s has no type", key, f.Name)
+			}
+
+			for _, c := range t.Columns {
+				if c.Name == f.Name {
+					return fmt.Errorf("computed field %s.%s has the name of a column", key, f.Name)
+				}
+			}
+
+			t.Columns = append(t.Columns, drivers.Column{
+				Name:      f.Name,
+				Comment:   f.Comment,
+				Nullable:  f.Nullable,
+				Generated: true,
+				Type:      f.Type,
+				Computed:  f.Expression,
+			})
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,56 @@
+package gen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stephenafamo/bob/gen/drivers"
+)
+
+func TestProcessComputed(t *testing.T) {
+	tables := []drivers.Table{
+		{
+			Key: "users",
+			Columns: []drivers.Column{
+				{Name: "id", Type: "int64"},
+				{Name: "first_name", Type: "string"},
+				{Name: "last_name", Type: "string"},
+			},
+		},
+	}
+
+	computed := Computed{"users": {{
+		Name:       "full_name",
+		Expression: "first_name || ' ' || last_name",
+		Type:       "string",
+	}}}
+	if err := processComputed(tables, computed); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := drivers.Column{
+		Name:      "full_name",
+		Type:      "string",
+		Generated: true,
+		Computed:  "first_name || ' ' || last_name",
+	}
+	if diff := cmp.Diff(expected, tables[0].Columns[3]); diff != "" {
+		t.Fatal(diff)
+	}
+
+	errs := map[string]Computed{
+		"table posts does not exist": {"posts": {{Name: "x", Expression: "1", Type: "int"}}},
+		"has no name":                {"users": {{Expression: "1", Type: "int"}}},
+		"has no expression":          {"users": {{Name: "x", Type: "int"}}},
+		"has no type":                {"users": {{Name: "x", Expression: "1"}}},
+		"has the name of a column":   {"users": {{Name: "full_name", Expression: "1", Type: "int"}}},
+	}
+
+	for msg, computed := range errs {
+		err := processComputed(tables, computed)
+		if err == nil || !strings.Contains(err.Error(), msg) {
+			t.Errorf("expected an error with %q, got %v", msg, err)
+		}
+	}
+}
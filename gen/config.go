@@ -2,6 +2,7 @@ package gen
 
 import (
 	"github.com/stephenafamo/bob/gen/drivers"
+	"github.com/stephenafamo/bob/gen/migrate"
 )
 
 // Config for the running of the commands
@@ -22,11 +23,27 @@ type Config struct {
 	RelationTag string `yaml:"relation_tag"`
 	// List of column names that should have tags values set to '-' (ignored during parsing)
 	TagIgnore []string `yaml:"tag_ignore"`
+	// How nullable columns are represented in the models. null, pointer or database/sql (default null)
+	// Can be changed for individual columns with replacements
+	NullType string `yaml:"null_type"`
+	// Also generate the schemas of the models, to keep API types in sync with the database.
+	// jsonschema or openapi. Written to bob_schema.json in the models folder
+	ModelSchema string `yaml:"model_schema"`
+	// Generate CountBy, GroupedCount and SumOf helpers that aggregate
+	// a column of each table with typed results
+	Aggregates bool `yaml:"aggregates"`
+	// Generate from a throwaway database with the migrations applied
+	// instead of the configured database
+	Migrations migrate.Config `yaml:"migrations"`
 
 	Types         drivers.Types `yaml:"types"`         // register custom types
 	Aliases       Aliases       `yaml:"aliases"`       // customize aliases
 	Constraints   Constraints   `yaml:"constraints"`   // define additional constraints
 	Relationships Relationships `yaml:"relationships"` // define additional relationships
+	Embeds        Embeds        `yaml:"embeds"`        // group prefixed columns into structs
+	Renames       Renames       `yaml:"renames"`       // columns that are being renamed
+	Trees         Trees         `yaml:"trees"`         // tables that store a tree
+	Computed      Computed      `yaml:"computed"`      // read-only fields read from SQL expressions
 
 	Replacements []Replace   `yaml:"replacements"`
 	Inflections  Inflections `yaml:"inflections"`
@@ -42,8 +59,20 @@ type Replace struct {
 	Tables  []string       `yaml:"tables"`
 	Match   drivers.Column `yaml:"match"`
 	Replace string         `yaml:"replace"`
+	// Change how the matched columns are represented if they are nullable
+	NullType string `yaml:"null_type"`
 }
 
+// The possible representations of nullable columns in the models
+const (
+	// NullTypeNull uses null.Val[T] from github.com/aarondl/opt/null
+	NullTypeNull = "null"
+	// NullTypePointer uses *T
+	NullTypePointer = "pointer"
+	// NullTypeSQL uses sql.Null[T] which requires Go 1.22
+	NullTypeSQL = "database/sql"
+)
+
 type Inflections struct {
 	Plural        map[string]string `yaml:"plural"`
 	PluralExact   map[string]string `yaml:"plural_exact"`
@@ -17,11 +17,36 @@ type Column struct {
 	Generated bool   `json:"generated" yaml:"generated" toml:"generated"`
 	AutoIncr  bool   `json:"autoincr" yaml:"autoincr" toml:"autoincr"`
 
+	// PartitionKey is true for the columns a partitioned table is partitioned by
+	PartitionKey bool `json:"partition_key,omitempty" yaml:"partition_key" toml:"partition_key"`
+
+	// Sequence is the schema-qualified name of the sequence owned by the column,
+	// such as the sequence of a serial or identity column
+	Sequence string `json:"sequence,omitempty" yaml:"sequence" toml:"sequence"`
+
+	// MaxLength is the maximum number of characters of a character column,
+	// and Precision and Scale are the digits of a numeric column. 0 if there is no limit
+	MaxLength int `json:"max_length,omitempty" yaml:"max_length" toml:"max_length"`
+	Precision int `json:"precision,omitempty" yaml:"precision" toml:"precision"`
+	Scale     int `json:"scale,omitempty" yaml:"scale" toml:"scale"`
+
 	// DomainName is the domain type name associated to the column. See here:
 	// https://www.postgresql.org/docs/16/extend-type-system.html
 	DomainName string `json:"domain_name" yaml:"domain_name" toml:"domain_name"`
 
 	Type string `json:"type" yaml:"type" toml:"type"`
+
+	// NullType is how the column is represented in the models if it is nullable.
+	// It is set from the generation config
+	NullType string `json:"null_type,omitempty" yaml:"null_type" toml:"null_type"`
+
+	// RenamedFrom is the old name of a column that is being renamed while both
+	// columns exist. It is set from the generation config
+	RenamedFrom string `json:"renamed_from,omitempty" yaml:"renamed_from" toml:"renamed_from"`
+
+	// Computed is the SQL expression of a computed field, which is not a column of the
+	// table but is read like one. It is set from the generation config
+	Computed string `json:"computed,omitempty" yaml:"computed" toml:"computed"`
 }
 
 // ColumnNames of the columns.
@@ -22,3 +22,19 @@ type ForeignKey struct {
 	ForeignTable   string   `yaml:"foreign_table" json:"foreign_table"`
 	ForeignColumns []string `yaml:"foreign_columns" json:"foreign_columns"`
 }
+
+// Index represents an index of a table, other than the index of the primary key.
+// The columns of an expression index are the expressions, if the database reports them
+type Index struct {
+	Name    string   `yaml:"name" json:"name"`
+	Columns []string `yaml:"columns" json:"columns"`
+	Unique  bool     `yaml:"unique" json:"unique"`
+}
+
+// Check represents a check constraint of a table.
+// The expression is written as the database reports it
+type Check struct {
+	Name       string   `yaml:"name" json:"name"`
+	Columns    []string `yaml:"columns" json:"columns,omitempty"`
+	Expression string   `yaml:"expression" json:"expression"`
+}
@@ -60,6 +60,8 @@ type TableInfo struct {
 	Key    string
 	Schema string
 	Name   string
+	// The comment of the table in the database, if the driver reads it
+	Comment string
 }
 
 func (t TablesInfo) Keys() []string {
@@ -159,7 +161,8 @@ func tables(ctx context.Context, c Constructor, concurrency int, infos TablesInf
 func table(ctx context.Context, c Constructor, info TableInfo, filter ColumnFilter) (Table, error) {
 	var err error
 	t := Table{
-		Key: info.Key,
+		Key:     info.Key,
+		Comment: info.Comment,
 	}
 
 	if t.Schema, t.Name, t.Columns, err = c.TableDetails(ctx, info, filter); err != nil {
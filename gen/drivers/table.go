@@ -12,8 +12,16 @@ type Table struct {
 	Schema  string   `yaml:"schema" json:"schema"`
 	Name    string   `yaml:"name" json:"name"`
 	Columns []Column `yaml:"columns" json:"columns"`
+	// The comment of the table in the database
+	Comment string `yaml:"comment" json:"comment,omitempty"`
+	// The keys of the tables this table inherits from, with Postgres table inheritance
+	Inherits []string `yaml:"inherits" json:"inherits,omitempty"`
 
 	Constraints Constraints `yaml:"constraints" json:"constraints"`
+	// The indexes of the table, other than the index of the primary key
+	Indexes []Index `yaml:"indexes" json:"indexes,omitempty"`
+	// The check constraints of the table
+	Checks []Check `yaml:"checks" json:"checks,omitempty"`
 }
 
 type Constraints struct {
@@ -0,0 +1,165 @@
+package gen
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stephenafamo/bob/gen/drivers"
+	"github.com/volatiletech/strmangle"
+)
+
+// Embeds groups columns with a shared prefix into struct fields, keyed by table
+type Embeds map[string][]Embed
+
+// Embed maps the columns of a table that start with Prefix
+// to a single field of a generated struct type
+type Embed struct {
+	// The prefix shared by the columns, e.g. "billing_"
+	Prefix string `yaml:"prefix"`
+	// The name of the field in the model. Defaults to the title cased prefix
+	Field string `yaml:"field"`
+	// The name of the generated struct type. Defaults to the field name.
+	// Embeds that share a type must have the same columns
+	Type string `yaml:"type"`
+}
+
+// EmbedType is a struct type generated for one or more embeds
+type EmbedType struct {
+	Name   string
+	Fields []EmbedField
+}
+
+// EmbedField is a field of an EmbedType.
+// The name of the column does not include the prefix
+type EmbedField struct {
+	Name   string
+	Column drivers.Column
+}
+
+// processEmbeds validates the embeds config, sets the field paths of
+// the embedded columns in the aliases and returns the struct types to generate
+func processEmbeds(embeds Embeds, tables []drivers.Table, aliases Aliases, rels Relationships) ([]EmbedType, error) {
+	var types []EmbedType
+	typeIndex := map[string]int{}
+
+	for _, t := range tables {
+		tableEmbeds := embeds[t.Key]
+		if len(tableEmbeds) == 0 {
+			continue
+		}
+
+		tableAlias := aliases[t.Key]
+		tableAlias.Fields = make(map[string]string)
+		tableAlias.Embeds = make(map[string]Embed)
+		keyCols := keyColumns(t, rels)
+
+		for _, e := range tableEmbeds {
+			if e.Prefix == "" {
+				return nil, fmt.Errorf("embed in %s has no prefix", t.Key)
+			}
+			if e.Field == "" {
+				e.Field = strmangle.TitleCase(strings.TrimRight(e.Prefix, "_"))
+			}
+			if e.Type == "" {
+				e.Type = e.Field
+			}
+
+			typ := EmbedType{Name: e.Type}
+			for _, c := range t.Columns {
+				if !strings.HasPrefix(c.Name, e.Prefix) || c.Name == e.Prefix {
+					continue
+				}
+
+				if _, ok := keyCols[c.Name]; ok {
+					return nil, fmt.Errorf("cannot embed %s.%s: it is part of the primary key or a relationship", t.Key, c.Name)
+				}
+				if _, ok := tableAlias.Fields[c.Name]; ok {
+					return nil, fmt.Errorf("cannot embed %s.%s: it matches more than one prefix", t.Key, c.Name)
+				}
+
+				field := EmbedField{
+					Name:   strmangle.TitleCase(strings.TrimPrefix(c.Name, e.Prefix)),
+					Column: c,
+				}
+				field.Column.Name = strings.TrimPrefix(c.Name, e.Prefix)
+
+				if len(typ.Fields) == 0 {
+					tableAlias.Embeds[c.Name] = e
+				}
+				tableAlias.Fields[c.Name] = e.Field + "." + field.Name
+				typ.Fields = append(typ.Fields, field)
+			}
+
+			if len(typ.Fields) == 0 {
+				return nil, fmt.Errorf("no columns in %s have the embed prefix %q", t.Key, e.Prefix)
+			}
+
+			index, ok := typeIndex[typ.Name]
+			if !ok {
+				typeIndex[typ.Name] = len(types)
+				types = append(types, typ)
+				continue
+			}
+
+			if !sameEmbedFields(types[index].Fields, typ.Fields) {
+				return nil, fmt.Errorf("embed type %s has different columns in %s", typ.Name, t.Key)
+			}
+		}
+
+		aliases[t.Key] = tableAlias
+	}
+
+	return types, nil
+}
+
+// keyColumns returns the columns of the table that are part of
+// the primary key or used by a relationship
+func keyColumns(t drivers.Table, rels Relationships) map[string]struct{} {
+	cols := make(map[string]struct{})
+	if t.Constraints.Primary != nil {
+		for _, c := range t.Constraints.Primary.Columns {
+			cols[c] = struct{}{}
+		}
+	}
+
+	for _, tableRels := range rels {
+		for _, rel := range tableRels {
+			for _, side := range rel.Sides {
+				if side.From == t.Key {
+					for _, c := range side.FromColumns {
+						cols[c] = struct{}{}
+					}
+					for _, w := range side.FromWhere {
+						cols[w.Column] = struct{}{}
+					}
+				}
+				if side.To == t.Key {
+					for _, c := range side.ToColumns {
+						cols[c] = struct{}{}
+					}
+					for _, w := range side.ToWhere {
+						cols[w.Column] = struct{}{}
+					}
+				}
+			}
+		}
+	}
+
+	return cols
+}
+
+func sameEmbedFields(a, b []EmbedField) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		x, y := a[i].Column, b[i].Column
+		if x.Name != y.Name || x.Type != y.Type || x.Nullable != y.Nullable ||
+			x.NullType != y.NullType || x.Generated != y.Generated {
+			return false
+		}
+	}
+
+	return true
+}
@@ -30,6 +30,10 @@ type State struct {
 	Config              Config
 	Outputs             []*Output
 	CustomTemplateFuncs template.FuncMap
+	// If set, the generated files are written to MemFS instead of the disk,
+	// keyed by their slash separated path in the output folders.
+	// Used by Check to compare the generated code with the files on disk
+	MemFS map[string][]byte
 }
 
 // Run executes the templates and outputs them to files based on the
@@ -90,7 +94,16 @@ func Run[T any](ctx context.Context, s *State, driver drivers.Interface[T], plug
 
 	initInflections(s.Config.Inflections)
 	processConstraintConfig(dbInfo.Tables, s.Config.Constraints)
+	if err := processRenames(dbInfo.Tables, s.Config.Renames); err != nil {
+		return fmt.Errorf("processing renames: %w", err)
+	}
+	if err := processComputed(dbInfo.Tables, s.Config.Computed); err != nil {
+		return fmt.Errorf("processing computed fields: %w", err)
+	}
 	processTypeReplacements(types, s.Config.Replacements, dbInfo.Tables)
+	if err := processNullTypes(s.Config.NullType, dbInfo.Tables); err != nil {
+		return fmt.Errorf("processing null types: %w", err)
+	}
 
 	relationships := buildRelationships(dbInfo.Tables)
 	if err := processRelationshipConfig(&s.Config, dbInfo.Tables, relationships); err != nil {
@@ -104,9 +117,22 @@ func Run[T any](ctx context.Context, s *State, driver drivers.Interface[T], plug
 		s.Config.Aliases = make(map[string]TableAlias)
 	}
 	initAliases(s.Config.Aliases, dbInfo.Tables, relationships)
+	embedTypes, err := processEmbeds(s.Config.Embeds, dbInfo.Tables, s.Config.Aliases, relationships)
+	if err != nil {
+		return fmt.Errorf("processing embeds: %w", err)
+	}
+	trees, err := processTrees(s.Config.Trees, dbInfo.Tables)
+	if err != nil {
+		return fmt.Errorf("processing trees: %w", err)
+	}
 	if err = s.initTags(); err != nil {
 		return fmt.Errorf("unable to initialize struct tags: %w", err)
 	}
+	switch s.Config.ModelSchema {
+	case "", ModelSchemaJSONSchema, ModelSchemaOpenAPI:
+	default:
+		return fmt.Errorf("unknown model_schema %q, must be %q or %q", s.Config.ModelSchema, ModelSchemaJSONSchema, ModelSchemaOpenAPI)
+	}
 
 	data := &TemplateData[T]{
 		Dialect:           driver.Dialect(),
@@ -116,8 +142,12 @@ func Run[T any](ctx context.Context, s *State, driver drivers.Interface[T], plug
 		Aliases:           s.Config.Aliases,
 		Types:             types,
 		Relationships:     relationships,
+		EmbedTypes:        embedTypes,
+		Trees:             trees,
 		NoTests:           s.Config.NoTests,
 		NoBackReferencing: s.Config.NoBackReferencing,
+		ModelSchema:       s.Config.ModelSchema,
+		Aggregates:        s.Config.Aggregates,
 		StructTagCasing:   s.Config.StructTagCasing,
 		TagIgnore:         make(map[string]struct{}),
 		Tags:              s.Config.Tags,
@@ -160,6 +190,7 @@ func generate[T any](s *State, data *TemplateData[T], goVersion string) error {
 
 		// set the package name for this output
 		data.PkgName = o.PkgName
+		o.memFS = s.MemFS
 
 		templates, err := o.initTemplates(s.CustomTemplateFuncs, s.Config.NoTests)
 		if err != nil {
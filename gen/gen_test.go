@@ -1,8 +1,11 @@
 package gen
 
 import (
+	"encoding/json"
+	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
 	"github.com/stephenafamo/bob/gen/drivers"
 )
 
@@ -161,3 +164,220 @@ func TestProcessTypeReplacements(t *testing.T) {
 		t.Error("type was wrong:", typ)
 	}
 }
+
+func TestProcessNullTypes(t *testing.T) {
+	tables := []drivers.Table{
+		{
+			Key: "users",
+			Columns: []drivers.Column{
+				{Name: "id", Type: "int"},
+				{Name: "name", Type: "string", Nullable: true},
+				{Name: "bio", Type: "string", Nullable: true},
+			},
+		},
+	}
+
+	replacements := []Replace{
+		{
+			Match:    drivers.Column{Name: "bio", Nullable: true},
+			NullType: NullTypeSQL,
+		},
+	}
+
+	processTypeReplacements(nil, replacements, tables)
+	if err := processNullTypes(NullTypePointer, tables); err != nil {
+		t.Fatal(err)
+	}
+
+	if typ := tables[0].Columns[0].NullType; typ != "" {
+		t.Error("null type was wrong:", typ)
+	}
+
+	if typ := tables[0].Columns[1].NullType; typ != NullTypePointer {
+		t.Error("null type was wrong:", typ)
+	}
+
+	if typ := tables[0].Columns[2].NullType; typ != NullTypeSQL {
+		t.Error("null type was wrong:", typ)
+	}
+
+	if typ := tables[0].Columns[2].Type; typ != "string" {
+		t.Error("type was wrong:", typ)
+	}
+
+	tables[0].Columns[1].NullType = "nope"
+	if err := processNullTypes("", tables); err == nil {
+		t.Error("expected an error for an invalid null type")
+	}
+}
+
+func TestProcessEmbeds(t *testing.T) {
+	tables := []drivers.Table{
+		{
+			Key: "customers",
+			Columns: []drivers.Column{
+				{Name: "id", Type: "int"},
+				{Name: "billing_street", Type: "string"},
+				{Name: "billing_city", Type: "string"},
+				{Name: "shipping_street", Type: "string"},
+				{Name: "shipping_city", Type: "string"},
+			},
+			Constraints: drivers.Constraints{
+				Primary: &drivers.Constraint{Name: "pk", Columns: []string{"id"}},
+			},
+		},
+	}
+
+	aliases := Aliases{}
+	initAliases(aliases, tables, nil)
+
+	embeds := Embeds{"customers": {
+		{Prefix: "billing_", Type: "Address"},
+		{Prefix: "shipping_", Type: "Address"},
+	}}
+
+	types, err := processEmbeds(embeds, tables, aliases, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(types) != 1 || types[0].Name != "Address" || len(types[0].Fields) != 2 {
+		t.Fatalf("wrong embed types: %#v", types)
+	}
+
+	if f := types[0].Fields[0]; f.Name != "Street" || f.Column.Name != "street" {
+		t.Errorf("wrong embed field: %#v", f)
+	}
+
+	alias := aliases["customers"]
+	if f := alias.Field("shipping_city"); f != "Shipping.City" {
+		t.Error("field was wrong:", f)
+	}
+
+	if f := alias.Field("id"); f != "ID" {
+		t.Error("field was wrong:", f)
+	}
+
+	if e := alias.EmbedAt("billing_street"); e == nil || e.Field != "Billing" {
+		t.Errorf("wrong embed at billing_street: %#v", e)
+	}
+
+	if e := alias.EmbedAt("billing_city"); e != nil {
+		t.Errorf("wrong embed at billing_city: %#v", e)
+	}
+
+	embeds["customers"] = []Embed{{Prefix: "i"}}
+	if _, err := processEmbeds(embeds, tables, aliases, nil); err == nil {
+		t.Error("expected an error when embedding a primary key column")
+	}
+}
+
+func TestProcessRenames(t *testing.T) {
+	tables := []drivers.Table{
+		{
+			Key: "users",
+			Columns: []drivers.Column{
+				{Name: "id", Type: "int"},
+				{Name: "email_address", Type: "string"},
+				{Name: "email", Type: "string", Nullable: true},
+				{Name: "name", Type: "string"},
+			},
+			Constraints: drivers.Constraints{
+				Primary: &drivers.Constraint{Name: "pk", Columns: []string{"id"}},
+				Uniques: []drivers.Constraint{{Name: "users_email_key", Columns: []string{"email_address"}}},
+			},
+		},
+	}
+
+	renames := Renames{"users": {"email_address": "email", "nickname": "name"}}
+	if err := processRenames(tables, renames); err != nil {
+		t.Fatal(err)
+	}
+
+	if names := drivers.ColumnNames(tables[0].Columns); strings.Join(names, ",") != "id,email,name" {
+		t.Errorf("wrong columns: %v", names)
+	}
+	if c := tables[0].Columns[1]; c.RenamedFrom != "email_address" {
+		t.Errorf("wrong renamed column: %#v", c)
+	}
+	if u := tables[0].Constraints.Uniques[0]; u.Columns[0] != "email" {
+		t.Errorf("wrong unique constraint: %#v", u)
+	}
+
+	if err := processRenames(tables, Renames{"users": {"name": "full_name"}}); err == nil {
+		t.Error("expected an error when the new column does not exist")
+	}
+	if err := processRenames(tables, Renames{"users": {"id": "name"}}); err == nil {
+		t.Error("expected an error when renaming a primary key column")
+	}
+}
+
+func TestModelSchema(t *testing.T) {
+	tables := []drivers.Table{
+		{
+			Key: "users",
+			Columns: []drivers.Column{
+				{Name: "id", Type: "int64", Generated: true},
+				{Name: "name", Type: "string", DBType: "VARCHAR(100)", Comment: "full name"},
+				{Name: "mood", Type: "UserMood", Nullable: true},
+				{Name: "billing_street", Type: "string"},
+				{Name: "created_at", Type: "time.Time", Nullable: true},
+				{Name: "password", Type: "string"},
+			},
+			Constraints: drivers.Constraints{
+				Primary: &drivers.Constraint{Name: "pk", Columns: []string{"id"}},
+			},
+		},
+	}
+	enums := []drivers.Enum{{Type: "UserMood", Values: []string{"happy", "sad"}}}
+
+	aliases := Aliases{}
+	initAliases(aliases, tables, nil)
+	if _, err := processEmbeds(Embeds{"users": {{Prefix: "billing_"}}}, tables, aliases, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	ignore := map[string]struct{}{"users.password": {}}
+	schema, err := modelSchema(ModelSchemaOpenAPI, []string{"json"}, "camel", ignore, tables, aliases, enums)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := `{
+  "components": {
+    "schemas": {
+      "User": {
+        "type": "object",
+        "properties": {
+          "id": {"type": "integer", "readOnly": true},
+          "name": {"type": "string", "description": "full name", "maxLength": 100},
+          "mood": {"type": ["string", "null"], "enum": ["happy", "sad", null]},
+          "billing": {
+            "type": "object",
+            "properties": {"street": {"type": "string"}},
+            "required": ["street"]
+          },
+          "createdAt": {"type": ["string", "null"], "format": "date-time"}
+        },
+        "required": ["id", "name", "mood", "billing", "createdAt"]
+      }
+    }
+  }
+}`
+
+	var got, want any
+	if err := json.Unmarshal([]byte(schema), &got); err != nil {
+		t.Fatal(err)
+	}
+	if err := json.Unmarshal([]byte(expected), &want); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatal(diff)
+	}
+
+	if _, err := modelSchema("yaml", nil, "", nil, tables, aliases, enums); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
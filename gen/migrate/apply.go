@@ -0,0 +1,120 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	rgxGolangMigrateUp = regexp.MustCompile(`^([0-9]+)_.*\.up\.sql$`)
+	rgxGoose           = regexp.MustCompile(`^([0-9]+)_.*\.sql$`)
+)
+
+type migrationFile struct {
+	name    string
+	version uint64
+	up      string
+}
+
+// Apply runs the up migrations in the folder against the database,
+// in the order of their versions.
+// There is no version table, all the migrations are run, so it is meant for
+// a new database
+func Apply(ctx context.Context, db *sql.DB, fsys fs.FS, format string) error {
+	files, err := readMigrations(fsys, format)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		if strings.TrimSpace(f.up) == "" {
+			continue
+		}
+
+		if _, err := db.ExecContext(ctx, f.up); err != nil {
+			return fmt.Errorf("applying %s: %w", f.name, err)
+		}
+	}
+
+	return nil
+}
+
+// readMigrations returns the up migrations sorted by their version
+func readMigrations(fsys fs.FS, format string) ([]migrationFile, error) {
+	var rgx *regexp.Regexp
+	switch format {
+	case "", FormatGolangMigrate:
+		rgx = rgxGolangMigrateUp
+	case FormatGoose:
+		rgx = rgxGoose
+	default:
+		return nil, fmt.Errorf("unknown migration format %q, must be %q or %q", format, FormatGolangMigrate, FormatGoose)
+	}
+
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+
+	var files []migrationFile
+	for _, e := range entries {
+		match := rgx.FindStringSubmatch(e.Name())
+		if e.IsDir() || match == nil {
+			continue
+		}
+
+		version, err := strconv.ParseUint(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("parsing the version of %s: %w", e.Name(), err)
+		}
+
+		b, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return nil, err
+		}
+
+		up := string(b)
+		if format == FormatGoose {
+			up = gooseUp(up)
+		}
+
+		files = append(files, migrationFile{name: e.Name(), version: version, up: up})
+	}
+
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].version < files[j].version
+	})
+
+	return files, nil
+}
+
+// gooseUp returns the statements between the Up and Down annotations
+func gooseUp(content string) string {
+	var sb strings.Builder
+
+	inUp := false
+	for _, line := range strings.SplitAfter(content, "\n") {
+		switch annotation := strings.TrimSpace(line); {
+		case strings.HasPrefix(annotation, "-- +goose Up"):
+			inUp = true
+			continue
+		case strings.HasPrefix(annotation, "-- +goose Down"):
+			inUp = false
+			continue
+		case strings.HasPrefix(annotation, "-- +goose "):
+			continue
+		}
+
+		if inUp {
+			sb.WriteString(line)
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,67 @@
+package migrate
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// ChecksumName is the name of the file the checksum of the migrations is
+// saved to in the models folder
+const ChecksumName = "bob_migrations.sum"
+
+// Checksum returns a checksum of the migrations in the folder and
+// the extra data, such as the configuration
+func Checksum(fsys fs.FS, extra ...[]byte) (string, error) {
+	h := sha256.New()
+
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return "", err
+	}
+
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".sql") {
+			continue
+		}
+
+		b, err := fs.ReadFile(fsys, e.Name())
+		if err != nil {
+			return "", err
+		}
+
+		h.Write([]byte(e.Name()))
+		h.Write([]byte{0})
+		h.Write(b)
+		h.Write([]byte{0})
+	}
+
+	for _, b := range extra {
+		h.Write(b)
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// UpToDate returns true if the checksum saved in the folder is the same
+func UpToDate(folder, sum string) (bool, error) {
+	b, err := os.ReadFile(filepath.Join(folder, ChecksumName))
+	if errors.Is(err, fs.ErrNotExist) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return strings.TrimSpace(string(b)) == sum, nil
+}
+
+// SaveChecksum saves the checksum in the folder
+func SaveChecksum(folder, sum string) error {
+	return os.WriteFile(filepath.Join(folder, ChecksumName), []byte(sum+"\n"), 0o664)
+}
@@ -0,0 +1,47 @@
+package migrate
+
+import (
+	"github.com/stephenafamo/bob/gen/drivers"
+	"github.com/urfave/cli/v2"
+)
+
+// Command returns the migrate subcommand of a bobgen binary.
+// getDriver is called with the path of the configuration file
+func Command[T any](getDriver func(configPath string) (drivers.Interface[T], error)) *cli.Command {
+	return &cli.Command{
+		Name:      "migrate",
+		Usage:     "Write a migration with the changes in the database since the last migration",
+		UsageText: "migrate [-d DIR] [-f FORMAT] NAME",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:    "dir",
+				Aliases: []string{"d"},
+				Value:   "migrations",
+				Usage:   "Write the migrations to `DIR`",
+			},
+			&cli.StringFlag{
+				Name:    "format",
+				Aliases: []string{"f"},
+				Value:   FormatGolangMigrate,
+				Usage:   "The file layout of the migrations, golang-migrate or goose",
+			},
+			&cli.StringFlag{
+				Name:  "snapshot",
+				Usage: "Compare with the snapshot at `FILE`, defaults to " + DefaultSnapshotName + " in the migrations folder",
+			},
+		},
+		Action: func(c *cli.Context) error {
+			d, err := getDriver(c.String("config"))
+			if err != nil {
+				return err
+			}
+
+			return Run(c.Context, d, Options{
+				Dir:      c.String("dir"),
+				Name:     c.Args().First(),
+				Format:   c.String("format"),
+				Snapshot: c.String("snapshot"),
+			})
+		},
+	}
+}
@@ -0,0 +1,200 @@
+package migrate
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/iancoleman/strcase"
+	"github.com/stephenafamo/bob/gen/drivers"
+)
+
+// dialectWriter writes the SQL for the dialect of a driver
+type dialectWriter string
+
+const (
+	psql   dialectWriter = "psql"
+	mysql  dialectWriter = "mysql"
+	sqlite dialectWriter = "sqlite"
+)
+
+// rgxMySQLLiteral matches MySQL defaults that are not string literals
+var rgxMySQLLiteral = regexp.MustCompile(`(?i)^(-?[0-9]+(\.[0-9]+)?|0x[0-9a-f]*|null|true|false|current_timestamp(\([0-9]*\))?|\(.*\))$`)
+
+func (d dialectWriter) quote(name string) string {
+	if d == mysql {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+func (d dialectWriter) table(t drivers.Table) string {
+	if t.Schema == "" {
+		return d.quote(t.Name)
+	}
+
+	return d.quote(t.Schema) + "." + d.quote(t.Name)
+}
+
+func (d dialectWriter) columns(cols []string) string {
+	quoted := make([]string, len(cols))
+	for i, c := range cols {
+		quoted[i] = d.quote(c)
+	}
+
+	return "(" + strings.Join(quoted, ", ") + ")"
+}
+
+// constraintName removes the table prefix that the psql driver adds to the
+// names of foreign keys
+func (d dialectWriter) constraintName(t drivers.Table, name string) string {
+	if d != psql {
+		return name
+	}
+
+	return strings.TrimPrefix(name, t.Key+".")
+}
+
+// constraint returns the CONSTRAINT clause for a named constraint.
+// The names from the sqlite driver are generated, so they are left out
+func (d dialectWriter) constraint(t drivers.Table, name string) string {
+	if d == sqlite || name == "" || (d == mysql && name == "PRIMARY") {
+		return ""
+	}
+
+	return "CONSTRAINT " + d.quote(d.constraintName(t, name)) + " "
+}
+
+// SQLite cannot add foreign keys to existing tables,
+// so they are written in the CREATE TABLE statement
+func (d dialectWriter) inlineForeignKeys() bool {
+	return d == sqlite
+}
+
+type columnDef struct {
+	name     string
+	typ      string
+	notNull  bool
+	dflt     string
+	extra    string
+	warnings []string
+}
+
+func (c columnDef) String() string {
+	var sb strings.Builder
+	sb.WriteString(c.name)
+	sb.WriteString(" ")
+	sb.WriteString(c.typ)
+
+	if c.notNull {
+		sb.WriteString(" NOT NULL")
+	}
+
+	if c.dflt != "" {
+		sb.WriteString(" DEFAULT ")
+		sb.WriteString(c.dflt)
+	}
+
+	if c.extra != "" {
+		sb.WriteString(" ")
+		sb.WriteString(c.extra)
+	}
+
+	return sb.String()
+}
+
+// column rebuilds the definition of the column from what the driver read
+func (d dialectWriter) column(c drivers.Column, enums []drivers.Enum) columnDef {
+	def := columnDef{
+		name:    d.quote(c.Name),
+		typ:     c.DBType,
+		notNull: !c.Nullable,
+		dflt:    c.Default,
+	}
+
+	if c.Generated {
+		def.dflt = ""
+		def.warnings = append(def.warnings, "the column is generated, add its expression manually")
+	}
+
+	switch strings.ToLower(def.dflt) {
+	case "auto_increment", "auto_generated":
+		def.dflt = ""
+	case "null":
+		if c.Nullable {
+			def.dflt = ""
+		}
+	}
+
+	switch d {
+	case psql:
+		switch {
+		case c.DBType == "ENUM":
+			def.typ = d.quote(enumName(c.Type))
+		case c.DBType == "USER-DEFINED":
+			def.warnings = append(def.warnings, "the type of the column is not known, set it manually")
+		case strings.HasSuffix(c.DBType, "[]"):
+			// array types are read as the name of the element type
+			// with an underscore prefix
+			def.typ = strings.TrimPrefix(c.DBType, "_")
+		}
+
+		if strings.HasPrefix(def.dflt, "nextval(") {
+			switch def.typ {
+			case "smallint":
+				def.typ, def.dflt = "smallserial", ""
+			case "integer":
+				def.typ, def.dflt = "serial", ""
+			case "bigint":
+				def.typ, def.dflt = "bigserial", ""
+			}
+		}
+
+	case mysql:
+		switch c.DBType {
+		case "enum":
+			for _, e := range enums {
+				if e.Type == c.Type {
+					def.typ = "enum(" + quoteValues(e.Values) + ")"
+				}
+			}
+		case "bool":
+			def.typ = "tinyint(1)"
+		case "varchar", "varbinary":
+			def.typ += "(255)"
+			def.warnings = append(def.warnings, "the length of the column is not known, it is set to 255")
+		}
+
+		if strings.HasPrefix(c.Type, "uint") {
+			def.typ += " unsigned"
+		}
+
+		if def.dflt != "" && !rgxMySQLLiteral.MatchString(def.dflt) {
+			def.dflt = quoteValue(def.dflt)
+		}
+
+		if c.AutoIncr {
+			def.extra = "AUTO_INCREMENT"
+		}
+	}
+
+	return def
+}
+
+// enumName returns the name of a Postgres enum from its type in the models
+func enumName(typ string) string {
+	return strcase.ToSnake(typ)
+}
+
+func quoteValue(v string) string {
+	return "'" + strings.ReplaceAll(v, "'", "''") + "'"
+}
+
+func quoteValues(values []string) string {
+	quoted := make([]string, len(values))
+	for i, v := range values {
+		quoted[i] = quoteValue(v)
+	}
+
+	return strings.Join(quoted, ", ")
+}
@@ -0,0 +1,472 @@
+package migrate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stephenafamo/bob/gen/drivers"
+)
+
+// Migration is the list of statements that change one schema to another
+type Migration struct {
+	Statements []string
+	// Changes that could not be written, or may not be correct
+	Warnings []string
+}
+
+// Empty returns true if there is nothing in the migration
+func (m Migration) Empty() bool {
+	return len(m.Statements) == 0 && len(m.Warnings) == 0
+}
+
+// String returns the migration as SQL.
+// The warnings are written as comments before the statements
+func (m Migration) String() string {
+	var sb strings.Builder
+
+	for _, w := range m.Warnings {
+		fmt.Fprintf(&sb, "-- WARNING: %s\n", w)
+	}
+
+	for i, s := range m.Statements {
+		if i > 0 || len(m.Warnings) > 0 {
+			sb.WriteString("\n")
+		}
+		sb.WriteString(s)
+		sb.WriteString(";\n")
+	}
+
+	return sb.String()
+}
+
+// Diff returns the migration that changes the schema in one snapshot to the
+// schema in the other. The reverse migration is the Diff with the arguments
+// swapped.
+// Renamed tables and columns are seen as being dropped and created again
+func Diff(dialect string, from, to Snapshot) (Migration, error) {
+	d := dialectWriter(dialect)
+	switch d {
+	case psql, mysql, sqlite:
+	default:
+		return Migration{}, fmt.Errorf("migrations are not supported for the %q dialect", dialect)
+	}
+
+	m := &differ{d: d, from: from, to: to}
+	m.diff()
+
+	return m.Migration, nil
+}
+
+type differ struct {
+	Migration
+	d        dialectWriter
+	from, to Snapshot
+}
+
+func (m *differ) add(format string, args ...any) {
+	m.Statements = append(m.Statements, fmt.Sprintf(format, args...))
+}
+
+func (m *differ) warn(format string, args ...any) {
+	m.Warnings = append(m.Warnings, fmt.Sprintf(format, args...))
+}
+
+func (m *differ) diff() {
+	fromTables := tablesByKey(m.from.Tables)
+	toTables := tablesByKey(m.to.Tables)
+
+	if m.d == psql {
+		m.createEnums()
+	}
+
+	for _, t := range m.to.Tables {
+		if _, ok := fromTables[t.Key]; !ok {
+			m.createTable(t)
+		}
+	}
+
+	// Constraints are dropped before the columns are changed,
+	// and added after all the columns exist
+	for _, t := range m.to.Tables {
+		if old, ok := fromTables[t.Key]; ok {
+			m.dropConstraints(old, t)
+		}
+	}
+
+	for _, t := range m.to.Tables {
+		if old, ok := fromTables[t.Key]; ok {
+			m.alterColumns(old, t)
+		}
+	}
+
+	for _, t := range m.to.Tables {
+		old, ok := fromTables[t.Key]
+		if !ok {
+			// The constraints of a new table are in the CREATE statement
+			// except for the foreign keys which may reference other new tables
+			if !m.d.inlineForeignKeys() {
+				for _, fk := range t.Constraints.Foreign {
+					m.addForeignKey(t, fk)
+				}
+			}
+			continue
+		}
+		m.addConstraints(old, t)
+	}
+
+	dropped := make([]drivers.Table, 0, len(m.from.Tables))
+	for _, t := range m.from.Tables {
+		if _, ok := toTables[t.Key]; !ok {
+			dropped = append(dropped, t)
+		}
+	}
+
+	if !m.d.inlineForeignKeys() {
+		for _, t := range dropped {
+			for _, fk := range t.Constraints.Foreign {
+				m.dropForeignKey(t, fk)
+			}
+		}
+	}
+
+	for i := len(dropped) - 1; i >= 0; i-- {
+		m.add("DROP TABLE %s", m.d.table(dropped[i]))
+	}
+
+	if m.d == psql {
+		m.dropEnums()
+	}
+}
+
+func (m *differ) createEnums() {
+	fromEnums := make(map[string]drivers.Enum, len(m.from.Enums))
+	for _, e := range m.from.Enums {
+		fromEnums[e.Type] = e
+	}
+
+	for _, e := range m.to.Enums {
+		old, ok := fromEnums[e.Type]
+		if !ok {
+			m.add("CREATE TYPE %s AS ENUM (%s)", m.d.quote(enumName(e.Type)), quoteValues(e.Values))
+			continue
+		}
+
+		for i, v := range e.Values {
+			if contains(old.Values, v) {
+				continue
+			}
+
+			position := ""
+			if i > 0 {
+				position = " AFTER " + quoteValue(e.Values[i-1])
+			} else if len(e.Values) > 1 {
+				position = " BEFORE " + quoteValue(e.Values[1])
+			}
+			m.add("ALTER TYPE %s ADD VALUE %s%s", m.d.quote(enumName(e.Type)), quoteValue(v), position)
+		}
+
+		for _, v := range old.Values {
+			if !contains(e.Values, v) {
+				m.warn("the value %s of the enum %s cannot be removed, recreate the type to remove it", quoteValue(v), enumName(e.Type))
+			}
+		}
+	}
+}
+
+func (m *differ) dropEnums() {
+	toEnums := make(map[string]bool, len(m.to.Enums))
+	for _, e := range m.to.Enums {
+		toEnums[e.Type] = true
+	}
+
+	for _, e := range m.from.Enums {
+		if !toEnums[e.Type] {
+			m.add("DROP TYPE %s", m.d.quote(enumName(e.Type)))
+		}
+	}
+}
+
+func (m *differ) createTable(t drivers.Table) {
+	lines := make([]string, 0, len(t.Columns)+1)
+	for _, c := range t.Columns {
+		lines = append(lines, m.columnDef(t, c))
+	}
+
+	if pk := t.Constraints.Primary; pk != nil {
+		lines = append(lines, m.d.constraint(t, pk.Name)+"PRIMARY KEY "+m.d.columns(pk.Columns))
+	}
+
+	for _, u := range t.Constraints.Uniques {
+		lines = append(lines, m.d.constraint(t, u.Name)+"UNIQUE "+m.d.columns(u.Columns))
+	}
+
+	if m.d.inlineForeignKeys() {
+		for _, fk := range t.Constraints.Foreign {
+			lines = append(lines, m.d.constraint(t, fk.Name)+m.foreignKeyDef(fk))
+		}
+	}
+
+	m.add("CREATE TABLE %s (\n    %s\n)", m.d.table(t), strings.Join(lines, ",\n    "))
+}
+
+func (m *differ) dropConstraints(old, t drivers.Table) {
+	tbl := m.d.table(t)
+
+	for _, fk := range old.Constraints.Foreign {
+		if !containsForeignKey(t.Constraints.Foreign, fk) {
+			m.dropForeignKey(old, fk)
+		}
+	}
+
+	for _, u := range old.Constraints.Uniques {
+		if containsConstraint(t.Constraints.Uniques, u) {
+			continue
+		}
+
+		switch m.d {
+		case psql:
+			m.add("ALTER TABLE %s DROP CONSTRAINT %s", tbl, m.d.quote(m.d.constraintName(old, u.Name)))
+		case mysql:
+			m.add("ALTER TABLE %s DROP INDEX %s", tbl, m.d.quote(u.Name))
+		case sqlite:
+			if strings.HasPrefix(u.Name, "sqlite_autoindex_") {
+				m.warn("the unique constraint on %s%s cannot be dropped without rebuilding the table", t.Key, m.d.columns(u.Columns))
+				continue
+			}
+			m.add("DROP INDEX %s", m.d.quote(u.Name))
+		}
+	}
+
+	if !primaryKeyChanged(old, t) || old.Constraints.Primary == nil {
+		return
+	}
+
+	switch m.d {
+	case psql:
+		m.add("ALTER TABLE %s DROP CONSTRAINT %s", tbl, m.d.quote(old.Constraints.Primary.Name))
+	case mysql:
+		m.add("ALTER TABLE %s DROP PRIMARY KEY", tbl)
+	case sqlite:
+		m.warn("the primary key of %s changed, SQLite cannot change it without rebuilding the table", t.Key)
+	}
+}
+
+func (m *differ) addConstraints(old, t drivers.Table) {
+	tbl := m.d.table(t)
+
+	if pk := t.Constraints.Primary; pk != nil && primaryKeyChanged(old, t) && m.d != sqlite {
+		m.add("ALTER TABLE %s ADD %sPRIMARY KEY %s", tbl, m.d.constraint(t, pk.Name), m.d.columns(pk.Columns))
+	}
+
+	for _, u := range t.Constraints.Uniques {
+		if containsConstraint(old.Constraints.Uniques, u) {
+			continue
+		}
+
+		if m.d != sqlite {
+			m.add("ALTER TABLE %s ADD %sUNIQUE %s", tbl, m.d.constraint(t, u.Name), m.d.columns(u.Columns))
+			continue
+		}
+
+		name := u.Name
+		if strings.HasPrefix(name, "sqlite_autoindex_") {
+			name = t.Name + "_" + strings.Join(u.Columns, "_") + "_key"
+		}
+		m.add("CREATE UNIQUE INDEX %s ON %s %s", m.d.quote(name), tbl, m.d.columns(u.Columns))
+	}
+
+	for _, fk := range t.Constraints.Foreign {
+		if !containsForeignKey(old.Constraints.Foreign, fk) {
+			m.addForeignKey(t, fk)
+		}
+	}
+}
+
+func (m *differ) addForeignKey(t drivers.Table, fk drivers.ForeignKey) {
+	if m.d.inlineForeignKeys() {
+		m.warn("the foreign key %s%s cannot be added without rebuilding the table", t.Key, m.d.columns(fk.Columns))
+		return
+	}
+
+	m.add("ALTER TABLE %s ADD %s%s", m.d.table(t), m.d.constraint(t, fk.Name), m.foreignKeyDef(fk))
+}
+
+func (m *differ) dropForeignKey(t drivers.Table, fk drivers.ForeignKey) {
+	switch m.d {
+	case psql:
+		m.add("ALTER TABLE %s DROP CONSTRAINT %s", m.d.table(t), m.d.quote(m.d.constraintName(t, fk.Name)))
+	case mysql:
+		m.add("ALTER TABLE %s DROP FOREIGN KEY %s", m.d.table(t), m.d.quote(fk.Name))
+	case sqlite:
+		m.warn("the foreign key %s%s cannot be dropped without rebuilding the table", t.Key, m.d.columns(fk.Columns))
+	}
+}
+
+func (m *differ) foreignKeyDef(fk drivers.ForeignKey) string {
+	return fmt.Sprintf("FOREIGN KEY %s REFERENCES %s %s",
+		m.d.columns(fk.Columns), m.foreignTable(fk.ForeignTable), m.d.columns(fk.ForeignColumns))
+}
+
+// foreignTable returns the quoted name of the table with the given key
+func (m *differ) foreignTable(key string) string {
+	for _, tables := range [][]drivers.Table{m.to.Tables, m.from.Tables} {
+		for _, t := range tables {
+			if t.Key == key {
+				return m.d.table(t)
+			}
+		}
+	}
+
+	schema, name, ok := strings.Cut(key, ".")
+	if !ok {
+		return m.d.quote(key)
+	}
+
+	return m.d.quote(schema) + "." + m.d.quote(name)
+}
+
+func (m *differ) alterColumns(old, t drivers.Table) {
+	tbl := m.d.table(t)
+
+	for _, c := range t.Columns {
+		oldCol, ok := findColumn(old.Columns, c.Name)
+		if !ok {
+			m.add("ALTER TABLE %s ADD COLUMN %s", tbl, m.columnDef(t, c))
+			continue
+		}
+
+		m.alterColumn(t, oldCol, c)
+	}
+
+	for _, c := range old.Columns {
+		if _, ok := findColumn(t.Columns, c.Name); !ok {
+			m.add("ALTER TABLE %s DROP COLUMN %s", tbl, m.d.quote(c.Name))
+		}
+	}
+}
+
+func (m *differ) alterColumn(t drivers.Table, old, c drivers.Column) {
+	oldDef := m.d.column(old, m.from.Enums)
+	def := m.d.column(c, m.to.Enums)
+	if oldDef.String() == def.String() {
+		return
+	}
+
+	tbl := m.d.table(t)
+	col := m.d.quote(c.Name)
+
+	switch m.d {
+	case psql:
+		if oldDef.typ != def.typ {
+			m.add("ALTER TABLE %s ALTER COLUMN %s TYPE %s USING %s::%s", tbl, col, def.typ, col, def.typ)
+		}
+
+		if oldDef.notNull != def.notNull {
+			if def.notNull {
+				m.add("ALTER TABLE %s ALTER COLUMN %s SET NOT NULL", tbl, col)
+			} else {
+				m.add("ALTER TABLE %s ALTER COLUMN %s DROP NOT NULL", tbl, col)
+			}
+		}
+
+		if oldDef.dflt != def.dflt {
+			if def.dflt != "" {
+				m.add("ALTER TABLE %s ALTER COLUMN %s SET DEFAULT %s", tbl, col, def.dflt)
+			} else {
+				m.add("ALTER TABLE %s ALTER COLUMN %s DROP DEFAULT", tbl, col)
+			}
+		}
+
+	case mysql:
+		m.add("ALTER TABLE %s MODIFY COLUMN %s", tbl, m.columnDef(t, c))
+
+	case sqlite:
+		m.warn("the column %s.%s changed, SQLite cannot alter it without rebuilding the table", t.Key, c.Name)
+	}
+}
+
+// columnDef returns the definition of the column, and records its warnings
+func (m *differ) columnDef(t drivers.Table, c drivers.Column) string {
+	def := m.d.column(c, m.to.Enums)
+	for _, w := range def.warnings {
+		m.warn("%s.%s: %s", t.Key, c.Name, w)
+	}
+
+	return def.String()
+}
+
+func tablesByKey(tables []drivers.Table) map[string]drivers.Table {
+	byKey := make(map[string]drivers.Table, len(tables))
+	for _, t := range tables {
+		byKey[t.Key] = t
+	}
+
+	return byKey
+}
+
+func findColumn(cols []drivers.Column, name string) (drivers.Column, bool) {
+	for _, c := range cols {
+		if c.Name == name {
+			return c, true
+		}
+	}
+
+	return drivers.Column{}, false
+}
+
+func primaryKeyChanged(old, t drivers.Table) bool {
+	oldPK, pk := old.Constraints.Primary, t.Constraints.Primary
+	if oldPK == nil || pk == nil {
+		return oldPK != pk
+	}
+
+	return !equalStrings(oldPK.Columns, pk.Columns)
+}
+
+// containsConstraint compares the columns of the constraints.
+// The names are not compared since some drivers generate them
+func containsConstraint(list []drivers.Constraint, c drivers.Constraint) bool {
+	for _, l := range list {
+		if equalStrings(l.Columns, c.Columns) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsForeignKey(list []drivers.ForeignKey, fk drivers.ForeignKey) bool {
+	for _, l := range list {
+		if l.ForeignTable == fk.ForeignTable &&
+			equalStrings(l.Columns, fk.Columns) &&
+			equalStrings(l.ForeignColumns, fk.ForeignColumns) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+func contains(list []string, s string) bool {
+	for _, l := range list {
+		if l == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,66 @@
+package migrate
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+var rgxNonWord = regexp.MustCompile(`[^a-z0-9]+`)
+
+// fileName returns the name of the migration without the extension
+func fileName(opts Options) string {
+	name := rgxNonWord.ReplaceAllString(strings.ToLower(opts.Name), "_")
+	name = strings.Trim(name, "_")
+
+	return opts.Now().UTC().Format("20060102150405") + "_" + name
+}
+
+func writeFiles(opts Options, up, down Migration) ([]string, error) {
+	base := filepath.Join(opts.Dir, fileName(opts))
+
+	switch opts.Format {
+	case "", FormatGolangMigrate:
+		upFile, downFile := base+".up.sql", base+".down.sql"
+		if err := writeFile(upFile, up.String()); err != nil {
+			return nil, err
+		}
+		if err := writeFile(downFile, down.String()); err != nil {
+			return nil, err
+		}
+		return []string{upFile, downFile}, nil
+
+	case FormatGoose:
+		var sb strings.Builder
+		sb.WriteString("-- +goose Up\n")
+		sb.WriteString(up.String())
+		sb.WriteString("\n-- +goose Down\n")
+		sb.WriteString(down.String())
+
+		file := base + ".sql"
+		if err := writeFile(file, sb.String()); err != nil {
+			return nil, err
+		}
+		return []string{file}, nil
+
+	default:
+		return nil, fmt.Errorf("unknown migration format %q, must be %q or %q", opts.Format, FormatGolangMigrate, FormatGoose)
+	}
+}
+
+// writeFile writes the file, failing if it already exists
+func writeFile(name, content string) error {
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o664)
+	if err != nil {
+		return err
+	}
+
+	if _, err := f.WriteString(content); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
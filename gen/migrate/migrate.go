@@ -0,0 +1,151 @@
+// Package migrate writes SQL migrations from the changes in a database schema.
+//
+// The schema assembled by a driver is saved as a snapshot next to the
+// migrations. On the next run, the snapshot is compared with the current
+// schema and the differences are written as up and down migrations in the
+// file layout of golang-migrate or goose.
+//
+// The drivers do not read every detail of the schema, such as the length of
+// character columns or the expressions of generated columns, so the written
+// migrations should always be reviewed before they are applied.
+package migrate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/stephenafamo/bob/gen/drivers"
+)
+
+const (
+	// FormatGolangMigrate writes the migrations as a pair of
+	// <version>_<name>.up.sql and <version>_<name>.down.sql files
+	FormatGolangMigrate = "golang-migrate"
+	// FormatGoose writes the migrations as a single <version>_<name>.sql file
+	// with goose annotations
+	FormatGoose = "goose"
+)
+
+// DefaultSnapshotName is the name of the snapshot file in the migrations folder
+const DefaultSnapshotName = "bob_snapshot.json"
+
+// Snapshot is the schema of the database when the last migration was written
+type Snapshot struct {
+	Tables []drivers.Table `json:"tables"`
+	Enums  []drivers.Enum  `json:"enums"`
+}
+
+// ReadSnapshot reads the snapshot at the given path.
+// An empty snapshot is returned if the file does not exist
+func ReadSnapshot(path string) (Snapshot, error) {
+	var s Snapshot
+
+	b, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+
+	if err := json.Unmarshal(b, &s); err != nil {
+		return s, fmt.Errorf("parsing snapshot %s: %w", path, err)
+	}
+
+	return s, nil
+}
+
+// WriteSnapshot writes the snapshot to the given path
+func WriteSnapshot(path string, s Snapshot) error {
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	return os.WriteFile(path, append(b, '\n'), 0o664)
+}
+
+// Options for writing a migration
+type Options struct {
+	// The folder to write the migrations to. Defaults to "migrations"
+	Dir string
+	// The name of the migration, used in the file names
+	Name string
+	// FormatGolangMigrate or FormatGoose. Defaults to FormatGolangMigrate
+	Format string
+	// The path of the snapshot. Defaults to DefaultSnapshotName in Dir
+	Snapshot string
+	// The time used for the version of the migration. Defaults to time.Now
+	Now func() time.Time
+}
+
+// Run assembles the current schema with the driver, compares it with the
+// snapshot and writes the differences as a new migration.
+// The snapshot is updated after the migration is written.
+// Nothing is written if the schema has not changed
+func Run[T any](ctx context.Context, driver drivers.Interface[T], opts Options) error {
+	if opts.Dir == "" {
+		opts.Dir = "migrations"
+	}
+	if opts.Snapshot == "" {
+		opts.Snapshot = filepath.Join(opts.Dir, DefaultSnapshotName)
+	}
+	if opts.Now == nil {
+		opts.Now = time.Now
+	}
+	if opts.Name == "" {
+		return errors.New("the migration needs a name")
+	}
+
+	dbInfo, err := driver.Assemble(ctx)
+	if err != nil {
+		return fmt.Errorf("unable to fetch table data: %w", err)
+	}
+
+	current := Snapshot{Tables: dbInfo.Tables, Enums: dbInfo.Enums}
+	sort.Slice(current.Tables, func(i, j int) bool {
+		return current.Tables[i].Key < current.Tables[j].Key
+	})
+
+	previous, err := ReadSnapshot(opts.Snapshot)
+	if err != nil {
+		return err
+	}
+
+	up, err := Diff(driver.Dialect(), previous, current)
+	if err != nil {
+		return fmt.Errorf("diffing schema: %w", err)
+	}
+
+	if up.Empty() {
+		fmt.Fprintln(os.Stderr, "no changes in the schema, no migration written")
+		return nil
+	}
+
+	down, err := Diff(driver.Dialect(), current, previous)
+	if err != nil {
+		return fmt.Errorf("diffing schema: %w", err)
+	}
+
+	if err := os.MkdirAll(opts.Dir, 0o775); err != nil {
+		return err
+	}
+
+	files, err := writeFiles(opts, up, down)
+	if err != nil {
+		return err
+	}
+
+	for _, f := range files {
+		fmt.Fprintf(os.Stderr, "%-20s %s\n", "== WRITTEN ==", f)
+	}
+
+	return WriteSnapshot(opts.Snapshot, current)
+}
@@ -0,0 +1,427 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stephenafamo/bob/gen/drivers"
+	_ "modernc.org/sqlite"
+)
+
+var usersTable = drivers.Table{
+	Key:  "users",
+	Name: "users",
+	Columns: []drivers.Column{
+		{Name: "id", DBType: "integer", Default: "nextval('users_id_seq'::regclass)", Type: "int32"},
+		{Name: "email", DBType: "text", Type: "string"},
+		{Name: "status", DBType: "ENUM", Type: "UserStatus", Nullable: true},
+	},
+	Constraints: drivers.Constraints{
+		Primary: &drivers.PrimaryKey{Name: "users_pkey", Columns: []string{"id"}},
+		Uniques: []drivers.Constraint{{Name: "users_email_key", Columns: []string{"email"}}},
+	},
+}
+
+var videosTable = drivers.Table{
+	Key:  "videos",
+	Name: "videos",
+	Columns: []drivers.Column{
+		{Name: "id", DBType: "integer", Default: "nextval('videos_id_seq'::regclass)", Type: "int32"},
+		{Name: "user_id", DBType: "integer", Type: "int32"},
+	},
+	Constraints: drivers.Constraints{
+		Primary: &drivers.PrimaryKey{Name: "videos_pkey", Columns: []string{"id"}},
+		Foreign: []drivers.ForeignKey{{
+			Name:           "videos.videos_user_id_fkey",
+			Columns:        []string{"user_id"},
+			ForeignTable:   "users",
+			ForeignColumns: []string{"id"},
+		}},
+	},
+}
+
+var userStatus = drivers.Enum{Type: "UserStatus", Values: []string{"active", "banned"}}
+
+func TestDiffPsql(t *testing.T) {
+	from := Snapshot{}
+	to := Snapshot{
+		Tables: []drivers.Table{usersTable, videosTable},
+		Enums:  []drivers.Enum{userStatus},
+	}
+
+	up, err := Diff("psql", from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedUp := []string{
+		`CREATE TYPE "user_status" AS ENUM ('active', 'banned')`,
+		"CREATE TABLE \"users\" (\n" +
+			"    \"id\" serial NOT NULL,\n" +
+			"    \"email\" text NOT NULL,\n" +
+			"    \"status\" \"user_status\",\n" +
+			"    CONSTRAINT \"users_pkey\" PRIMARY KEY (\"id\"),\n" +
+			"    CONSTRAINT \"users_email_key\" UNIQUE (\"email\")\n" +
+			")",
+		"CREATE TABLE \"videos\" (\n" +
+			"    \"id\" serial NOT NULL,\n" +
+			"    \"user_id\" integer NOT NULL,\n" +
+			"    CONSTRAINT \"videos_pkey\" PRIMARY KEY (\"id\")\n" +
+			")",
+		`ALTER TABLE "videos" ADD CONSTRAINT "videos_user_id_fkey" FOREIGN KEY ("user_id") REFERENCES "users" ("id")`,
+	}
+	if diff := cmp.Diff(expectedUp, up.Statements); diff != "" {
+		t.Fatal(diff)
+	}
+
+	down, err := Diff("psql", to, from)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expectedDown := []string{
+		`ALTER TABLE "videos" DROP CONSTRAINT "videos_user_id_fkey"`,
+		`DROP TABLE "videos"`,
+		`DROP TABLE "users"`,
+		`DROP TYPE "user_status"`,
+	}
+	if diff := cmp.Diff(expectedDown, down.Statements); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestDiffPsqlAlter(t *testing.T) {
+	users := usersTable
+	users.Columns = []drivers.Column{
+		usersTable.Columns[0],
+		{Name: "email", DBType: "text", Type: "string", Nullable: true, Default: "''::text"},
+		usersTable.Columns[2],
+		{Name: "name", DBType: "character varying", Type: "string"},
+	}
+	users.Constraints.Uniques = nil
+
+	from := Snapshot{Tables: []drivers.Table{usersTable}, Enums: []drivers.Enum{userStatus}}
+	to := Snapshot{
+		Tables: []drivers.Table{users},
+		Enums:  []drivers.Enum{{Type: "UserStatus", Values: []string{"pending", "active", "banned"}}},
+	}
+
+	up, err := Diff("psql", from, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		`ALTER TYPE "user_status" ADD VALUE 'pending' BEFORE 'active'`,
+		`ALTER TABLE "users" DROP CONSTRAINT "users_email_key"`,
+		`ALTER TABLE "users" ALTER COLUMN "email" DROP NOT NULL`,
+		`ALTER TABLE "users" ALTER COLUMN "email" SET DEFAULT ''::text`,
+		`ALTER TABLE "users" ADD COLUMN "name" character varying NOT NULL`,
+	}
+	if diff := cmp.Diff(expected, up.Statements); diff != "" {
+		t.Fatal(diff)
+	}
+
+	down, err := Diff("psql", to, from)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(down.Warnings) != 1 {
+		t.Fatalf("expected a warning for the removed enum value, got %v", down.Warnings)
+	}
+}
+
+func TestDiffMySQL(t *testing.T) {
+	table := drivers.Table{
+		Key:  "users",
+		Name: "users",
+		Columns: []drivers.Column{
+			{Name: "id", DBType: "int", Type: "uint", Default: "AUTO_INCREMENT", AutoIncr: true},
+			{Name: "name", DBType: "varchar", Type: "string", Default: "it's"},
+			{Name: "status", DBType: "enum", Type: "UsersStatus"},
+		},
+		Constraints: drivers.Constraints{
+			Primary: &drivers.PrimaryKey{Name: "PRIMARY", Columns: []string{"id"}},
+		},
+	}
+	enums := []drivers.Enum{{Type: "UsersStatus", Values: []string{"a", "b"}}}
+
+	up, err := Diff("mysql", Snapshot{}, Snapshot{Tables: []drivers.Table{table}, Enums: enums})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"CREATE TABLE `users` (\n" +
+			"    `id` int unsigned NOT NULL AUTO_INCREMENT,\n" +
+			"    `name` varchar(255) NOT NULL DEFAULT 'it''s',\n" +
+			"    `status` enum('a', 'b') NOT NULL,\n" +
+			"    PRIMARY KEY (`id`)\n" +
+			")",
+	}
+	if diff := cmp.Diff(expected, up.Statements); diff != "" {
+		t.Fatal(diff)
+	}
+
+	if len(up.Warnings) != 1 {
+		t.Fatalf("expected a warning for the varchar length, got %v", up.Warnings)
+	}
+
+	changed := table
+	changed.Columns = append([]drivers.Column{}, table.Columns...)
+	changed.Columns[1].Nullable = true
+
+	up, err = Diff("mysql", Snapshot{Tables: []drivers.Table{table}, Enums: enums}, Snapshot{Tables: []drivers.Table{changed}, Enums: enums})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected = []string{"ALTER TABLE `users` MODIFY COLUMN `name` varchar(255) DEFAULT 'it''s'"}
+	if diff := cmp.Diff(expected, up.Statements); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestDiffSQLite(t *testing.T) {
+	users := drivers.Table{
+		Key:  "users",
+		Name: "users",
+		Columns: []drivers.Column{
+			{Name: "id", DBType: "INTEGER", Default: "auto_increment"},
+			{Name: "bio", DBType: "TEXT", Nullable: true, Default: "NULL"},
+		},
+		Constraints: drivers.Constraints{
+			Primary: &drivers.PrimaryKey{Name: "pk_main_users", Columns: []string{"id"}},
+		},
+	}
+	videos := drivers.Table{
+		Key:     "videos",
+		Name:    "videos",
+		Columns: []drivers.Column{{Name: "user_id", DBType: "INTEGER"}},
+		Constraints: drivers.Constraints{
+			Foreign: []drivers.ForeignKey{{
+				Name: "fk_videos_0", Columns: []string{"user_id"},
+				ForeignTable: "users", ForeignColumns: []string{"id"},
+			}},
+		},
+	}
+
+	up, err := Diff("sqlite", Snapshot{Tables: []drivers.Table{users}}, Snapshot{Tables: []drivers.Table{users, videos}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{
+		"CREATE TABLE \"videos\" (\n" +
+			"    \"user_id\" INTEGER NOT NULL,\n" +
+			"    FOREIGN KEY (\"user_id\") REFERENCES \"users\" (\"id\")\n" +
+			")",
+	}
+	if diff := cmp.Diff(expected, up.Statements); diff != "" {
+		t.Fatal(diff)
+	}
+
+	changed := users
+	changed.Columns = []drivers.Column{users.Columns[0], {Name: "bio", DBType: "TEXT"}}
+
+	up, err = Diff("sqlite", Snapshot{Tables: []drivers.Table{users}}, Snapshot{Tables: []drivers.Table{changed}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(up.Statements) != 0 || len(up.Warnings) != 1 {
+		t.Fatalf("expected only a warning, got %#v", up)
+	}
+}
+
+func TestDiffUnchanged(t *testing.T) {
+	s := Snapshot{Tables: []drivers.Table{usersTable, videosTable}, Enums: []drivers.Enum{userStatus}}
+
+	m, err := Diff("psql", s, s)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !m.Empty() {
+		t.Fatalf("expected no changes, got %#v", m)
+	}
+}
+
+func TestWriteFiles(t *testing.T) {
+	up := Migration{Statements: []string{"CREATE TABLE a ()"}, Warnings: []string{"check this"}}
+	down := Migration{Statements: []string{"DROP TABLE a"}}
+	now := func() time.Time { return time.Date(2023, 4, 5, 6, 7, 8, 0, time.UTC) }
+
+	cases := map[string]map[string]string{
+		FormatGolangMigrate: {
+			"20230405060708_add_table_a.up.sql":   "-- WARNING: check this\n\nCREATE TABLE a ();\n",
+			"20230405060708_add_table_a.down.sql": "DROP TABLE a;\n",
+		},
+		FormatGoose: {
+			"20230405060708_add_table_a.sql": "-- +goose Up\n-- WARNING: check this\n\nCREATE TABLE a ();\n\n-- +goose Down\nDROP TABLE a;\n",
+		},
+	}
+
+	for format, expected := range cases {
+		t.Run(format, func(t *testing.T) {
+			dir := t.TempDir()
+			_, err := writeFiles(Options{Dir: dir, Name: "Add table A", Format: format, Now: now}, up, down)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := map[string]string{}
+			entries, err := os.ReadDir(dir)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, e := range entries {
+				b, err := os.ReadFile(filepath.Join(dir, e.Name()))
+				if err != nil {
+					t.Fatal(err)
+				}
+				got[e.Name()] = string(b)
+			}
+
+			if diff := cmp.Diff(expected, got); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestSnapshot(t *testing.T) {
+	path := filepath.Join(t.TempDir(), DefaultSnapshotName)
+
+	empty, err := ReadSnapshot(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(empty.Tables) != 0 {
+		t.Fatalf("expected an empty snapshot, got %#v", empty)
+	}
+
+	s := Snapshot{Tables: []drivers.Table{usersTable}, Enums: []drivers.Enum{userStatus}}
+	if err := WriteSnapshot(path, s); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadSnapshot(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(s, got); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestReadMigrations(t *testing.T) {
+	cases := map[string]struct {
+		fsys     fstest.MapFS
+		expected []string
+	}{
+		FormatGolangMigrate: {
+			fsys: fstest.MapFS{
+				"10_second.up.sql":   {Data: []byte("CREATE TABLE b (id INTEGER);")},
+				"10_second.down.sql": {Data: []byte("DROP TABLE b;")},
+				"9_first.up.sql":     {Data: []byte("CREATE TABLE a (id INTEGER);")},
+				"9_first.down.sql":   {Data: []byte("DROP TABLE a;")},
+				"bob_snapshot.json":  {Data: []byte("{}")},
+			},
+			expected: []string{"CREATE TABLE a (id INTEGER);", "CREATE TABLE b (id INTEGER);"},
+		},
+		FormatGoose: {
+			fsys: fstest.MapFS{
+				"00002_second.sql": {Data: []byte("-- +goose Up\nCREATE TABLE b (id INTEGER);\n-- +goose Down\nDROP TABLE b;\n")},
+				"00001_first.sql": {Data: []byte(
+					"-- +goose Up\n-- +goose StatementBegin\nCREATE TABLE a (id INTEGER);\n-- +goose StatementEnd\n\n-- +goose Down\nDROP TABLE a;\n",
+				)},
+			},
+			expected: []string{"CREATE TABLE a (id INTEGER);\n\n", "CREATE TABLE b (id INTEGER);\n"},
+		},
+	}
+
+	for format, c := range cases {
+		t.Run(format, func(t *testing.T) {
+			files, err := readMigrations(c.fsys, format)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got := make([]string, len(files))
+			for i, f := range files {
+				got[i] = f.up
+			}
+
+			if diff := cmp.Diff(c.expected, got); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	ctx := context.Background()
+
+	db, err := sql.Open("sqlite", filepath.Join(t.TempDir(), "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	fsys := fstest.MapFS{
+		"1_users.up.sql":  {Data: []byte("CREATE TABLE users (id INTEGER PRIMARY KEY);\nCREATE TABLE videos (id INTEGER);")},
+		"2_videos.up.sql": {Data: []byte("ALTER TABLE videos ADD COLUMN user_id INTEGER;")},
+	}
+
+	if err := Apply(ctx, db, fsys, FormatGolangMigrate); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := db.ExecContext(ctx, "INSERT INTO videos (id, user_id) VALUES (1, 1)"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestChecksum(t *testing.T) {
+	fsys := fstest.MapFS{
+		"1_users.up.sql":   {Data: []byte("CREATE TABLE users (id INTEGER);")},
+		"1_users.down.sql": {Data: []byte("DROP TABLE users;")},
+	}
+
+	sum, err := Checksum(fsys, []byte("config"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	if ok, err := UpToDate(dir, sum); err != nil || ok {
+		t.Fatalf("expected no saved checksum, got %t, %v", ok, err)
+	}
+
+	if err := SaveChecksum(dir, sum); err != nil {
+		t.Fatal(err)
+	}
+
+	if ok, err := UpToDate(dir, sum); err != nil || !ok {
+		t.Fatalf("expected the checksum to be up to date, got %t, %v", ok, err)
+	}
+
+	fsys["2_videos.up.sql"] = &fstest.MapFile{Data: []byte("CREATE TABLE videos (id INTEGER);")}
+	changed, err := Checksum(fsys, []byte("config"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if ok, err := UpToDate(dir, changed); err != nil || ok {
+		t.Fatalf("expected a new migration to change the checksum, got %t, %v", ok, err)
+	}
+}
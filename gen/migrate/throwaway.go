@@ -0,0 +1,142 @@
+package migrate
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/stephenafamo/bob/internal/container"
+)
+
+// Config to generate from the migrations instead of a running database
+type Config struct {
+	// The folder with the migrations. If set, the migrations are applied to a
+	// throwaway database, and the models are generated from it
+	Dir string `yaml:"dir"`
+	// FormatGolangMigrate or FormatGoose. Defaults to FormatGolangMigrate
+	Format string `yaml:"format"`
+	// The docker image of the throwaway database.
+	// Defaults to postgres:16 and mysql:8. SQLite uses a temporary file
+	Image string `yaml:"image"`
+	// Skip the generation if the migrations and the configuration
+	// have not changed since the last generation
+	Cache bool `yaml:"cache"`
+}
+
+// Throwaway is a temporary database with the migrations applied
+type Throwaway struct {
+	// The DSN to connect to the database
+	DSN   string
+	close func() error
+}
+
+// Close stops and removes the database
+func (t *Throwaway) Close() error {
+	return t.close()
+}
+
+type dbContainer struct {
+	image   string
+	port    string
+	env     []string
+	dsn     string // format string for the host and port
+	sqlName string // the name of the database/sql driver
+}
+
+var containers = map[string]dbContainer{
+	"psql": {
+		image:   "postgres:16",
+		port:    "5432/tcp",
+		env:     []string{"POSTGRES_USER=bob", "POSTGRES_PASSWORD=bob", "POSTGRES_DB=bob"},
+		dsn:     "postgres://bob:bob@%s/bob?sslmode=disable",
+		sqlName: "postgres",
+	},
+	"mysql": {
+		image:   "mysql:8",
+		port:    "3306/tcp",
+		env:     []string{"MYSQL_ROOT_PASSWORD=bob", "MYSQL_DATABASE=bob"},
+		dsn:     "root:bob@tcp(%s)/bob?multiStatements=true",
+		sqlName: "mysql",
+	},
+}
+
+// NewThrowaway creates a temporary database for the dialect and applies the
+// migrations to it.
+// Postgres and MySQL databases are started with docker, so the docker CLI has
+// to be available. The database/sql driver of the dialect has to be registered
+func NewThrowaway(ctx context.Context, dialect string, config Config) (*Throwaway, error) {
+	if config.Dir == "" {
+		return nil, errors.New("no migrations folder given")
+	}
+
+	var t *Throwaway
+	var sqlName string
+	var err error
+
+	if dialect == "sqlite" {
+		t, err = newSQLiteThrowaway()
+		sqlName = "sqlite"
+	} else {
+		c, ok := containers[dialect]
+		if !ok {
+			return nil, fmt.Errorf("throwaway databases are not supported for the %q dialect", dialect)
+		}
+		if config.Image != "" {
+			c.image = config.Image
+		}
+		t, err = startContainer(ctx, c)
+		sqlName = c.sqlName
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := t.apply(ctx, sqlName, config); err != nil {
+		t.Close()
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func (t *Throwaway) apply(ctx context.Context, sqlName string, config Config) error {
+	db, err := sql.Open(sqlName, t.DSN)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	if err := container.WaitForDB(ctx, db, time.Minute); err != nil {
+		return err
+	}
+
+	return Apply(ctx, db, os.DirFS(config.Dir), config.Format)
+}
+
+func newSQLiteThrowaway() (*Throwaway, error) {
+	dir, err := os.MkdirTemp("", "bobgen_migrations_")
+	if err != nil {
+		return nil, err
+	}
+
+	return &Throwaway{
+		DSN:   filepath.Join(dir, "bob.db"),
+		close: func() error { return os.RemoveAll(dir) },
+	}, nil
+}
+
+func startContainer(ctx context.Context, c dbContainer) (*Throwaway, error) {
+	running, err := container.Run(ctx, c.image, c.port, c.env)
+	if err != nil {
+		return nil, fmt.Errorf("starting the throwaway database: %w", err)
+	}
+
+	return &Throwaway{
+		DSN:   fmt.Sprintf(c.dsn, running.Addr),
+		close: running.Stop,
+	}, nil
+}
@@ -63,10 +63,17 @@ type Output struct {
 
 	templates     *templateList
 	testTemplates *templateList
+
+	// set from State.MemFS
+	memFS map[string][]byte
 }
 
 // initOutFolders creates the folders that will hold the generated output.
 func (o *Output) initOutFolders(lazyTemplates []lazyTemplate, wipe bool) error {
+	if o.memFS != nil {
+		return nil
+	}
+
 	if wipe {
 		if err := os.RemoveAll(o.OutFolder); err != nil {
 			return err
@@ -281,7 +288,7 @@ func executeTemplates[T any](e executeTemplateData[T], goVersion string) error {
 				writeImports(headerOut, imps)
 			}
 
-			if err := writeFile(e.output.OutFolder, fName, io.MultiReader(headerOut, out), version); err != nil {
+			if err := writeFile(e.output.OutFolder, fName, io.MultiReader(headerOut, out), version, e.output.memFS); err != nil {
 				return err
 			}
 		}
@@ -335,7 +342,7 @@ func executeSingletonTemplates[T any](e executeTemplateData[T], goVersion string
 			writeImports(headerOut, imps)
 		}
 
-		if err := writeFile(e.output.OutFolder, normalized, io.MultiReader(headerOut, out), version); err != nil {
+		if err := writeFile(e.output.OutFolder, normalized, io.MultiReader(headerOut, out), version, e.output.memFS); err != nil {
 			return err
 		}
 	}
@@ -366,7 +373,8 @@ func writeImports(out *bytes.Buffer, imps importers.List) {
 // writeFile writes to the given folder and filename, formatting the buffer
 // given.
 // If goVersion is empty, the file is not formatted.
-func writeFile(outFolder string, fileName string, input io.Reader, goVersion string) error {
+// If memFS is not nil, the file is written to it instead of the disk
+func writeFile(outFolder string, fileName string, input io.Reader, goVersion string, memFS map[string][]byte) error {
 	var byt []byte
 	var err error
 	if goVersion != "" {
@@ -382,6 +390,11 @@ func writeFile(outFolder string, fileName string, input io.Reader, goVersion str
 	}
 
 	path := filepath.Join(outFolder, fileName)
+	if memFS != nil {
+		memFS[filepath.ToSlash(path)] = byt
+		return nil
+	}
+
 	if err := testHarnessWriteFile(path, byt, 0o664); err != nil {
 		return fmt.Errorf("failed to write output file %s: %w", path, err)
 	}
@@ -0,0 +1,147 @@
+package protobuf
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/stephenafamo/bob/gen/drivers"
+)
+
+// FieldNumbersFile is the file in the output folder that records
+// the field numbers of the messages
+const FieldNumbersFile = "bob_field_numbers.json"
+
+// messageNumbers are the field numbers of the message of a table
+type messageNumbers struct {
+	// The number of each column
+	Fields map[string]int `json:"fields"`
+	// The numbers of dropped columns, which are never used again
+	Reserved []int `json:"reserved,omitempty"`
+}
+
+// numbering assigns stable field numbers, starting from the recorded ones
+type numbering struct {
+	mu       sync.Mutex
+	messages map[string]*messageNumbers
+	assigned map[string]bool
+}
+
+func newNumbering(recorded map[string]*messageNumbers) *numbering {
+	if recorded == nil {
+		recorded = map[string]*messageNumbers{}
+	}
+
+	return &numbering{messages: recorded, assigned: map[string]bool{}}
+}
+
+// loadNumbering reads the recorded field numbers. A missing file records no numbers
+func loadNumbering(path string) (*numbering, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return newNumbering(nil), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var recorded map[string]*messageNumbers
+	if err := json.Unmarshal(data, &recorded); err != nil {
+		return nil, fmt.Errorf("reading %s: %w", path, err)
+	}
+
+	return newNumbering(recorded), nil
+}
+
+// assign returns the field numbers of the message of the table.
+// Columns keep their recorded numbers. New columns are numbered after the highest
+// number ever used by the message, and the numbers of dropped columns are reserved
+func (n *numbering) assign(table drivers.Table) *messageNumbers {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.assigned[table.Key] {
+		return n.messages[table.Key]
+	}
+
+	prev := n.messages[table.Key]
+	if prev == nil {
+		prev = &messageNumbers{}
+	}
+
+	next := 1
+	for _, num := range prev.Fields {
+		if num >= next {
+			next = num + 1
+		}
+	}
+	for _, num := range prev.Reserved {
+		if num >= next {
+			next = num + 1
+		}
+	}
+
+	m := &messageNumbers{
+		Fields:   make(map[string]int, len(table.Columns)),
+		Reserved: append([]int(nil), prev.Reserved...),
+	}
+
+	for _, c := range table.Columns {
+		if num, ok := prev.Fields[c.Name]; ok {
+			m.Fields[c.Name] = num
+			continue
+		}
+
+		m.Fields[c.Name] = next
+		next++
+	}
+
+	for name, num := range prev.Fields {
+		if _, ok := m.Fields[name]; !ok {
+			m.Reserved = append(m.Reserved, num)
+		}
+	}
+	sort.Ints(m.Reserved)
+
+	n.messages[table.Key] = m
+	n.assigned[table.Key] = true
+
+	return m
+}
+
+// reserved returns the reserved numbers of the message of the table, separated by commas
+func (n *numbering) reserved(table drivers.Table) string {
+	nums := n.assign(table).Reserved
+
+	strs := make([]string, len(nums))
+	for i, num := range nums {
+		strs[i] = strconv.Itoa(num)
+	}
+
+	return strings.Join(strs, ", ")
+}
+
+// json returns the content of FieldNumbersFile after numbering the messages of the tables.
+// The numbers of tables that are not generated anymore are kept,
+// in case the table is generated again
+func (n *numbering) json(tables []drivers.Table) (string, error) {
+	for _, t := range tables {
+		n.assign(t)
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	data, err := json.MarshalIndent(n.messages, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(data), nil
+}
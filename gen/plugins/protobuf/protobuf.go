@@ -0,0 +1,293 @@
+// Package protobuf is a code generation plugin that writes protobuf messages
+// matching the generated models, and functions to convert between them.
+//
+// The messages are written to a .proto file which should be compiled with
+// protoc-gen-go into the GoPackage. The converters import both the models and
+// the compiled messages.
+//
+// The field numbers are recorded in bob_field_numbers.json next to the .proto file,
+// which should be committed with it. A column keeps its number when other
+// columns are added or dropped, and the numbers of dropped columns are reserved.
+package protobuf
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"io/fs"
+	"path/filepath"
+	"strings"
+	"text/template"
+
+	"github.com/stephenafamo/bob/gen"
+	"github.com/stephenafamo/bob/gen/drivers"
+)
+
+//go:embed templates
+var templates embed.FS
+
+// Config for the protobuf plugin
+type Config struct {
+	// The folder to write the .proto file and the converters to.
+	// Defaults to "protobuf" in the models folder
+	Output string `yaml:"output"`
+	// The Go package name of the converters. Defaults to the folder name
+	PkgName string `yaml:"pkgname"`
+	// The protobuf package of the messages, defaults to the models package name
+	Package string `yaml:"package"`
+	// The import path of the Go code compiled from the .proto file.
+	// This is used as the go_package option
+	GoPackage string `yaml:"go_package"`
+}
+
+// New returns the plugin. It adds an output, so it should be passed to gen.Run
+func New(config Config) gen.StatePlugin {
+	return plugin{config: config}
+}
+
+type plugin struct {
+	config Config
+}
+
+func (plugin) Name() string {
+	return "protobuf"
+}
+
+func (p plugin) PlugState(s *gen.State) error {
+	if p.config.GoPackage == "" {
+		return errors.New("go_package is required")
+	}
+
+	var models *gen.Output
+	for _, o := range s.Outputs {
+		if o.Key == "models" {
+			models = o
+		}
+	}
+	if models == nil {
+		return errors.New("there is no models output")
+	}
+
+	config := p.config
+	if config.Output == "" {
+		config.Output = filepath.Join(models.OutFolder, "protobuf")
+	}
+	if config.PkgName == "" {
+		config.PkgName = filepath.Base(config.Output)
+	}
+	if config.Package == "" {
+		config.Package = models.PkgName
+	}
+
+	tpls, err := fs.Sub(templates, "templates")
+	if err != nil {
+		return err
+	}
+
+	numbers, err := loadNumbering(filepath.Join(config.Output, FieldNumbersFile))
+	if err != nil {
+		return err
+	}
+
+	s.Outputs = append(s.Outputs, &gen.Output{
+		Key:       "protobuf",
+		OutFolder: config.Output,
+		PkgName:   config.PkgName,
+		Templates: []fs.FS{tpls},
+	})
+
+	if s.CustomTemplateFuncs == nil {
+		s.CustomTemplateFuncs = template.FuncMap{}
+	}
+	s.CustomTemplateFuncs["protoPackage"] = func() string { return config.Package }
+	s.CustomTemplateFuncs["protoGoPackage"] = func() string { return config.GoPackage }
+	s.CustomTemplateFuncs["protoFields"] = numbers.fields
+	s.CustomTemplateFuncs["protoReserved"] = numbers.reserved
+	s.CustomTemplateFuncs["protoFieldNumbers"] = numbers.json
+	s.CustomTemplateFuncs["protoGoName"] = goCamelCase
+	s.CustomTemplateFuncs["protoToField"] = toField
+	s.CustomTemplateFuncs["protoFromField"] = fromField
+	s.CustomTemplateFuncs["protoNeedsTimestamp"] = needsTimestamp
+
+	return nil
+}
+
+// Field is a field of a protobuf message, generated for a column
+type Field struct {
+	// The name in the .proto file
+	Name string
+	// The name of the field in the code generated by protoc-gen-go
+	GoName string
+	// The protobuf type, empty if the column type has no protobuf type
+	Type   string
+	Number int
+	Column drivers.Column
+	// The path of the field in the model
+	ModelField string
+	// The kind of conversion between the model and the message
+	Timestamp bool
+	Enum      bool
+	Cast      string // the Go type of the protobuf field, if it is different
+}
+
+// Optional returns true if the field is an optional scalar
+func (f Field) Optional() bool {
+	return f.Column.Nullable && !f.Timestamp
+}
+
+// Pointer returns true if protoc-gen-go uses a pointer for the field
+func (f Field) Pointer() bool {
+	return f.Optional() && f.Type != "bytes"
+}
+
+// fields returns the message fields of the table, numbered with [numbering.assign]
+func (n *numbering) fields(table drivers.Table, alias gen.TableAlias, enums []drivers.Enum) []Field {
+	isEnum := make(map[string]bool, len(enums))
+	for _, e := range enums {
+		isEnum[e.Type] = true
+	}
+
+	numbers := n.assign(table)
+
+	fields := make([]Field, len(table.Columns))
+	for i, c := range table.Columns {
+		f := Field{
+			Name:       fieldName(c.Name),
+			Number:     numbers.Fields[c.Name],
+			Column:     c,
+			ModelField: alias.Field(c.Name),
+		}
+		f.GoName = goCamelCase(f.Name)
+
+		switch {
+		case isEnum[c.Type]:
+			f.Type, f.Enum = "string", true
+		case c.Type == "time.Time":
+			f.Type, f.Timestamp = "google.protobuf.Timestamp", true
+		default:
+			f.Type, f.Cast = scalarType(c.Type)
+		}
+
+		fields[i] = f
+	}
+
+	return fields
+}
+
+// scalarType returns the protobuf type of a Go type, and the Go type of the
+// protobuf field if a conversion is needed
+func scalarType(typ string) (string, string) {
+	switch typ {
+	case "string", "bool", "int64", "int32", "uint64", "uint32":
+		return typ, ""
+	case "[]byte":
+		return "bytes", ""
+	case "float64":
+		return "double", ""
+	case "float32":
+		return "float", ""
+	case "int":
+		return "int64", "int64"
+	case "int8", "int16":
+		return "int32", "int32"
+	case "uint":
+		return "uint64", "uint64"
+	case "uint8", "uint16":
+		return "uint32", "uint32"
+	default:
+		return "", ""
+	}
+}
+
+// toField converts a non-null model value to the protobuf field
+func toField(f Field, val string) string {
+	switch {
+	case f.Timestamp:
+		return fmt.Sprintf("timestamppb.New(%s)", val)
+	case f.Enum:
+		return fmt.Sprintf("string(%s)", val)
+	case f.Cast != "":
+		return fmt.Sprintf("%s(%s)", f.Cast, val)
+	default:
+		return val
+	}
+}
+
+// fromField converts a protobuf value to the non-null model value
+func fromField(f Field, val string) string {
+	switch {
+	case f.Timestamp:
+		return fmt.Sprintf("%s.AsTime()", val)
+	case f.Enum:
+		return fmt.Sprintf("models.%s(%s)", f.Column.Type, val)
+	case f.Cast != "":
+		return fmt.Sprintf("%s(%s)", f.Column.Type, val)
+	default:
+		return val
+	}
+}
+
+func needsTimestamp(tables []drivers.Table) bool {
+	for _, t := range tables {
+		for _, c := range t.Columns {
+			if c.Type == "time.Time" {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// fieldName makes the column name a valid protobuf identifier
+func fieldName(column string) string {
+	name := []byte(strings.ToLower(column))
+	for i, c := range name {
+		if !isASCIILower(c) && !isASCIIDigit(c) && c != '_' {
+			name[i] = '_'
+		}
+	}
+
+	if len(name) == 0 || isASCIIDigit(name[0]) {
+		return "_" + string(name)
+	}
+
+	return string(name)
+}
+
+// goCamelCase is the name protoc-gen-go uses for the field.
+// Copied from google.golang.org/protobuf/internal/strs
+func goCamelCase(s string) string {
+	var b []byte
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c == '.' && i+1 < len(s) && isASCIILower(s[i+1]):
+		case c == '.':
+			b = append(b, '_')
+		case c == '_' && (i == 0 || s[i-1] == '.'):
+			b = append(b, 'X')
+		case c == '_' && i+1 < len(s) && isASCIILower(s[i+1]):
+		case isASCIIDigit(c):
+			b = append(b, c)
+		default:
+			if isASCIILower(c) {
+				c -= 'a' - 'A'
+			}
+			b = append(b, c)
+			for ; i+1 < len(s) && isASCIILower(s[i+1]); i++ {
+				b = append(b, s[i+1])
+			}
+		}
+	}
+
+	return string(b)
+}
+
+func isASCIILower(c byte) bool {
+	return 'a' <= c && c <= 'z'
+}
+
+func isASCIIDigit(c byte) bool {
+	return '0' <= c && c <= '9'
+}
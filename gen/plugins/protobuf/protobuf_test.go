@@ -0,0 +1,177 @@
+package protobuf
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stephenafamo/bob/gen"
+	"github.com/stephenafamo/bob/gen/drivers"
+)
+
+func TestGoCamelCase(t *testing.T) {
+	cases := map[string]string{
+		"id":            "Id",
+		"user_id":       "UserId",
+		"created_at":    "CreatedAt",
+		"_private":      "XPrivate",
+		"address_1":     "Address_1",
+		"a_b_c":         "ABC",
+		"already_Camel": "Already_Camel",
+	}
+
+	for in, expected := range cases {
+		if got := goCamelCase(in); got != expected {
+			t.Errorf("goCamelCase(%q) = %q, expected %q", in, got, expected)
+		}
+	}
+}
+
+func TestFieldName(t *testing.T) {
+	cases := map[string]string{
+		"id":         "id",
+		"UserID":     "userid",
+		"first name": "first_name",
+		"1st":        "_1st",
+	}
+
+	for in, expected := range cases {
+		if got := fieldName(in); got != expected {
+			t.Errorf("fieldName(%q) = %q, expected %q", in, got, expected)
+		}
+	}
+}
+
+func TestFields(t *testing.T) {
+	table := drivers.Table{
+		Key: "users",
+		Columns: []drivers.Column{
+			{Name: "id", Type: "int"},
+			{Name: "email", Type: "string"},
+			{Name: "status", Type: "UserStatus", Nullable: true},
+			{Name: "avatar", Type: "[]byte", Nullable: true},
+			{Name: "location", Type: "types.Point"},
+			{Name: "created_at", Type: "time.Time", Nullable: true},
+		},
+	}
+
+	alias := gen.TableAlias{
+		UpSingular: "User",
+		Columns: map[string]string{
+			"id":         "ID",
+			"email":      "Email",
+			"status":     "Status",
+			"avatar":     "Avatar",
+			"location":   "Location",
+			"created_at": "CreatedAt",
+		},
+	}
+
+	enums := []drivers.Enum{{Type: "UserStatus", Values: []string{"active", "banned"}}}
+
+	type field struct {
+		Name, GoName, Type string
+		Number             int
+		ModelField         string
+		Optional, Pointer  bool
+		Timestamp, Enum    bool
+		Cast               string
+	}
+
+	expected := []field{
+		{Name: "id", GoName: "Id", Type: "int64", Number: 1, ModelField: "ID", Cast: "int64"},
+		{Name: "email", GoName: "Email", Type: "string", Number: 2, ModelField: "Email"},
+		{Name: "status", GoName: "Status", Type: "string", Number: 3, ModelField: "Status", Optional: true, Pointer: true, Enum: true},
+		{Name: "avatar", GoName: "Avatar", Type: "bytes", Number: 4, ModelField: "Avatar", Optional: true},
+		{Name: "location", GoName: "Location", Number: 5, ModelField: "Location"},
+		{Name: "created_at", GoName: "CreatedAt", Type: "google.protobuf.Timestamp", Number: 6, ModelField: "CreatedAt", Timestamp: true},
+	}
+
+	var got []field
+	for _, f := range newNumbering(nil).fields(table, alias, enums) {
+		got = append(got, field{
+			Name: f.Name, GoName: f.GoName, Type: f.Type,
+			Number: f.Number, ModelField: f.ModelField,
+			Optional: f.Optional(), Pointer: f.Pointer(),
+			Timestamp: f.Timestamp, Enum: f.Enum, Cast: f.Cast,
+		})
+	}
+
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestConversions(t *testing.T) {
+	cases := []struct {
+		field    Field
+		to, from string
+	}{
+		{
+			field: Field{Type: "string", Column: drivers.Column{Type: "string"}},
+			to:    "m.Name",
+			from:  "p.Name",
+		},
+		{
+			field: Field{Type: "int32", Cast: "int32", Column: drivers.Column{Type: "int16"}},
+			to:    "int32(m.Name)",
+			from:  "int16(p.Name)",
+		},
+		{
+			field: Field{Type: "string", Enum: true, Column: drivers.Column{Type: "UserStatus"}},
+			to:    "string(m.Name)",
+			from:  "models.UserStatus(p.Name)",
+		},
+		{
+			field: Field{Type: "google.protobuf.Timestamp", Timestamp: true, Column: drivers.Column{Type: "time.Time"}},
+			to:    "timestamppb.New(m.Name)",
+			from:  "p.Name.AsTime()",
+		},
+	}
+
+	for _, c := range cases {
+		if got := toField(c.field, "m.Name"); got != c.to {
+			t.Errorf("toField(%s) = %q, expected %q", c.field.Column.Type, got, c.to)
+		}
+		if got := fromField(c.field, "p.Name"); got != c.from {
+			t.Errorf("fromField(%s) = %q, expected %q", c.field.Column.Type, got, c.from)
+		}
+	}
+}
+
+func TestStableNumbers(t *testing.T) {
+	columns := func(names ...string) drivers.Table {
+		table := drivers.Table{Key: "users"}
+		for _, name := range names {
+			table.Columns = append(table.Columns, drivers.Column{Name: name, Type: "string"})
+		}
+		return table
+	}
+
+	first := newNumbering(nil)
+	if _, err := first.json([]drivers.Table{columns("id", "email", "name")}); err != nil {
+		t.Fatal(err)
+	}
+
+	// "email" is dropped, "phone" is added in the middle
+	second := newNumbering(first.messages)
+	table := columns("id", "phone", "name")
+	numbers := second.assign(table)
+
+	expected := map[string]int{"id": 1, "name": 3, "phone": 4}
+	if diff := cmp.Diff(expected, numbers.Fields); diff != "" {
+		t.Fatal(diff)
+	}
+	if got := second.reserved(table); got != "2" {
+		t.Fatalf("expected 2 to be reserved, got %q", got)
+	}
+
+	// "email" is added again, but does not get its old number back
+	third := newNumbering(second.messages)
+	numbers = third.assign(columns("id", "phone", "name", "email"))
+	if numbers.Fields["email"] != 5 {
+		t.Fatalf("expected email to get 5, got %d", numbers.Fields["email"])
+	}
+	if diff := cmp.Diff([]int{2}, numbers.Reserved); diff != "" {
+		t.Fatal(diff)
+	}
+}
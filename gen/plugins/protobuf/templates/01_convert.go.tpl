@@ -0,0 +1,65 @@
+{{$.Importer.Import "models" $.ModelsPackage}}
+{{$.Importer.Import "pb" protoGoPackage}}
+{{$table := .Table}}
+{{$tAlias := .Aliases.Table $table.Key -}}
+{{$fields := protoFields $table $tAlias $.Enums -}}
+{{$message := protoGoName $tAlias.UpSingular -}}
+
+// {{$tAlias.UpSingular}}ToProto converts the model to its protobuf message
+// Columns without a protobuf type are not included
+func {{$tAlias.UpSingular}}ToProto(m *models.{{$tAlias.UpSingular}}) *pb.{{$message}} {
+	if m == nil {
+		return nil
+	}
+
+	p := &pb.{{$message}}{
+		{{- range $field := $fields}}
+		{{- if or (not $field.Type) $field.Column.Nullable}}{{continue}}{{end}}
+		{{- if $field.Timestamp}}{{$.Importer.Import "google.golang.org/protobuf/types/known/timestamppb"}}{{end}}
+		{{$field.GoName}}: {{protoToField $field (printf "m.%s" $field.ModelField)}},
+		{{- end}}
+	}
+
+	{{- range $field := $fields}}
+	{{- if or (not $field.Type) (not $field.Column.Nullable)}}{{continue}}{{end}}
+	{{- if $field.Timestamp}}{{$.Importer.Import "google.golang.org/protobuf/types/known/timestamppb"}}{{end}}
+	if v, ok := {{nullToVal $.Importer $field.Column (printf "m.%s" $field.ModelField)}}.Get(); ok {
+		{{- $to := protoToField $field "v"}}
+		{{- if and $field.Pointer (eq $to "v")}}
+		p.{{$field.GoName}} = &v
+		{{- else if $field.Pointer}}
+		val := {{$to}}
+		p.{{$field.GoName}} = &val
+		{{- else}}
+		p.{{$field.GoName}} = {{$to}}
+		{{- end}}
+	}
+	{{- end}}
+
+	return p
+}
+
+// {{$tAlias.UpSingular}}FromProto converts the protobuf message to the model
+// Columns without a protobuf type are left as the zero value
+func {{$tAlias.UpSingular}}FromProto(p *pb.{{$message}}) *models.{{$tAlias.UpSingular}} {
+	if p == nil {
+		return nil
+	}
+
+	m := &models.{{$tAlias.UpSingular}}{}
+	{{- range $field := $fields}}
+	{{- if not $field.Type}}{{continue}}{{end}}
+	{{- $val := printf "p.%s" $field.GoName}}
+	{{- if not $field.Column.Nullable}}
+	m.{{$field.ModelField}} = {{protoFromField $field $val}}
+	{{- else}}
+	{{- $.Importer.Import "github.com/aarondl/opt/null"}}
+	if p.{{$field.GoName}} != nil {
+		{{- if $field.Pointer}}{{$val = printf "*%s" $val}}{{end}}
+		m.{{$field.ModelField}} = {{nullFromVal $.Importer $field.Column (printf "null.From(%s)" (protoFromField $field $val))}}
+	}
+	{{- end}}
+	{{- end}}
+
+	return m
+}
@@ -0,0 +1 @@
+{{protoFieldNumbers $.Tables}}
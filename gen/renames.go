@@ -0,0 +1,102 @@
+package gen
+
+import (
+	"fmt"
+
+	"github.com/stephenafamo/bob/gen/drivers"
+)
+
+// Renames declares columns that are being renamed, keyed by table.
+// Each entry maps the old name of a column to the new name
+type Renames map[string]map[string]string
+
+// processRenames hides the old column of each rename while both columns exist.
+// The new column is read with COALESCE(new, old) and written under its new name,
+// so the models work before and after the rows are copied and the old column is dropped
+func processRenames(tables []drivers.Table, renames Renames) error {
+	for i, t := range tables {
+		for oldName, newName := range renames[t.Key] {
+			oldIndex, newIndex := -1, -1
+			for j, c := range t.Columns {
+				switch c.Name {
+				case oldName:
+					oldIndex = j
+				case newName:
+					newIndex = j
+				}
+			}
+
+			switch {
+			case oldIndex == -1 && newIndex == -1:
+				return fmt.Errorf("rename %s.%s: neither %s nor %s exists", t.Key, oldName, oldName, newName)
+			case oldIndex == -1:
+				// the rename is done and the old column is dropped
+				continue
+			case newIndex == -1:
+				return fmt.Errorf("rename %s.%s: add the column %s before generating", t.Key, oldName, newName)
+			}
+
+			if isKeyColumn(tables, t, oldName) || isKeyColumn(tables, t, newName) {
+				return fmt.Errorf("cannot rename %s.%s: it is part of the primary key or a foreign key", t.Key, oldName)
+			}
+
+			t.Columns[newIndex].RenamedFrom = oldName
+			t.Columns = append(t.Columns[:oldIndex:oldIndex], t.Columns[oldIndex+1:]...)
+
+			// unique constraints and indexes of the old column are expected on the new one
+			for j := range t.Constraints.Uniques {
+				t.Constraints.Uniques[j].Columns = replaceColumn(t.Constraints.Uniques[j].Columns, oldName, newName)
+			}
+			for j := range t.Indexes {
+				t.Indexes[j].Columns = replaceColumn(t.Indexes[j].Columns, oldName, newName)
+			}
+		}
+
+		tables[i] = t
+	}
+
+	return nil
+}
+
+// isKeyColumn returns true if the column is part of the primary key
+// or of a foreign key of the table or of one that references it
+func isKeyColumn(tables []drivers.Table, t drivers.Table, column string) bool {
+	if t.Constraints.Primary != nil && sliceContains(t.Constraints.Primary.Columns, column) {
+		return true
+	}
+
+	for _, other := range tables {
+		for _, fk := range other.Constraints.Foreign {
+			if other.Key == t.Key && sliceContains(fk.Columns, column) {
+				return true
+			}
+			if fk.ForeignTable == t.Key && sliceContains(fk.ForeignColumns, column) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func replaceColumn(columns []string, oldName, newName string) []string {
+	replaced := make([]string, len(columns))
+	for i, c := range columns {
+		if c == oldName {
+			c = newName
+		}
+		replaced[i] = c
+	}
+
+	return replaced
+}
+
+func sliceContains(s []string, v string) bool {
+	for _, x := range s {
+		if x == v {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,269 @@
+package gen
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/stephenafamo/bob/gen/drivers"
+	"github.com/volatiletech/strmangle"
+)
+
+// The formats of the model schemas that can be generated
+const (
+	// ModelSchemaJSONSchema generates a JSON Schema document
+	// with a definition for each model under $defs
+	ModelSchemaJSONSchema = "jsonschema"
+	// ModelSchemaOpenAPI generates the component schemas of an OpenAPI 3.1 document
+	ModelSchemaOpenAPI = "openapi"
+)
+
+//nolint:gochecknoglobals
+var rgxCharLength = regexp.MustCompile(`(?i)char(?:acter)?(?:\s+varying)?\s*\(\s*(\d+)\s*\)`)
+
+// jsonSchema is the subset of JSON Schema used to describe the models
+type jsonSchema struct {
+	Type            any            `json:"type,omitempty"`
+	Format          string         `json:"format,omitempty"`
+	ContentEncoding string         `json:"contentEncoding,omitempty"`
+	Pattern         string         `json:"pattern,omitempty"`
+	Description     string         `json:"description,omitempty"`
+	Enum            []any          `json:"enum,omitempty"`
+	MaxLength       int            `json:"maxLength,omitempty"`
+	ReadOnly        bool           `json:"readOnly,omitempty"`
+	Items           *jsonSchema    `json:"items,omitempty"`
+	Properties      orderedSchemas `json:"properties,omitempty"`
+	Required        []string       `json:"required,omitempty"`
+}
+
+// orderedSchemas keeps the properties in the order of the columns
+type orderedSchemas []namedSchema
+
+type namedSchema struct {
+	name   string
+	schema *jsonSchema
+}
+
+func (o orderedSchemas) MarshalJSON() ([]byte, error) {
+	var b strings.Builder
+	b.WriteByte('{')
+	for i, s := range o {
+		if i > 0 {
+			b.WriteByte(',')
+		}
+
+		name, err := json.Marshal(s.name)
+		if err != nil {
+			return nil, err
+		}
+
+		schema, err := json.Marshal(s.schema)
+		if err != nil {
+			return nil, err
+		}
+
+		b.Write(name)
+		b.WriteByte(':')
+		b.Write(schema)
+	}
+	b.WriteByte('}')
+
+	return []byte(b.String()), nil
+}
+
+// modelSchema returns the schemas of the models in the given format.
+// The property names match the JSON encoding of the models, so they depend
+// on whether a json struct tag is generated
+func modelSchema(format string, tags []string, casing string, tagIgnore map[string]struct{}, tables []drivers.Table, aliases Aliases, enums []drivers.Enum) (string, error) {
+	hasJSONTag := false
+	for _, t := range tags {
+		if t == "json" {
+			hasJSONTag = true
+		}
+	}
+
+	enumValues := make(map[string][]string, len(enums))
+	for _, e := range enums {
+		enumValues[e.Type] = e.Values
+	}
+
+	models := make(orderedSchemas, 0, len(tables))
+	for _, t := range tables {
+		tAlias := aliases[t.Key]
+		model := &jsonSchema{Type: "object"}
+		embeds := make(map[string]*jsonSchema) // keyed by prefix
+
+		for _, c := range t.Columns {
+			if hasJSONTag && strmangle.Ignore(t.Key, c.Name, tagIgnore) {
+				continue
+			}
+
+			if embed := tAlias.EmbedAt(c.Name); embed != nil {
+				embeds[embed.Prefix] = &jsonSchema{Type: "object"}
+				name := propertyName(hasJSONTag, casing, strings.TrimSuffix(embed.Prefix, "_"), embed.Field)
+				model.addProperty(name, embeds[embed.Prefix])
+			}
+
+			if !tAlias.InEmbed(c.Name) {
+				name := propertyName(hasJSONTag, casing, c.Name, tAlias.Column(c.Name))
+				model.addProperty(name, columnSchema(c, enumValues))
+				continue
+			}
+
+			field := strings.SplitN(tAlias.Field(c.Name), ".", 2)[1]
+			for prefix, embed := range embeds {
+				if strings.HasPrefix(c.Name, prefix) {
+					name := propertyName(hasJSONTag, casing, strings.TrimPrefix(c.Name, prefix), field)
+					embed.addProperty(name, columnSchema(c, enumValues))
+				}
+			}
+		}
+
+		models = append(models, namedSchema{name: tAlias.UpSingular, schema: model})
+	}
+
+	var doc any
+	switch format {
+	case ModelSchemaJSONSchema:
+		doc = struct {
+			Schema string         `json:"$schema"`
+			Defs   orderedSchemas `json:"$defs"`
+		}{Schema: "https://json-schema.org/draft/2020-12/schema", Defs: models}
+	case ModelSchemaOpenAPI:
+		type components struct {
+			Schemas orderedSchemas `json:"schemas"`
+		}
+		doc = struct {
+			Components components `json:"components"`
+		}{Components: components{Schemas: models}}
+	default:
+		return "", fmt.Errorf("unknown model schema format %q, must be %q or %q", format, ModelSchemaJSONSchema, ModelSchemaOpenAPI)
+	}
+
+	out, err := json.MarshalIndent(doc, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	return string(out) + "\n", nil
+}
+
+func (s *jsonSchema) addProperty(name string, schema *jsonSchema) {
+	s.Properties = append(s.Properties, namedSchema{name: name, schema: schema})
+	s.Required = append(s.Required, name)
+}
+
+// propertyName is the name of the field in the JSON encoding of the model
+func propertyName(hasJSONTag bool, casing, name, field string) string {
+	if !hasJSONTag {
+		return field
+	}
+
+	switch casing {
+	case "camel":
+		return strmangle.CamelCase(name)
+	case "title":
+		return strmangle.TitleCase(name)
+	case "alias":
+		return field
+	default:
+		return name
+	}
+}
+
+func columnSchema(c drivers.Column, enums map[string][]string) *jsonSchema {
+	schema := goTypeSchema(c.Type, enums)
+	schema.Description = strings.TrimSpace(c.Comment)
+	schema.ReadOnly = c.Generated
+
+	if schema.Type == "string" && schema.Format == "" && schema.Enum == nil {
+		if match := rgxCharLength.FindStringSubmatch(c.DBType); match != nil {
+			schema.MaxLength, _ = strconv.Atoi(match[1])
+		}
+	}
+
+	if !c.Nullable {
+		return schema
+	}
+
+	if c.NullType == NullTypeSQL {
+		// sql.Null[T] has no JSON methods, so it is encoded as a struct
+		return &jsonSchema{
+			Type:        "object",
+			Description: schema.Description,
+			ReadOnly:    schema.ReadOnly,
+			Properties: orderedSchemas{
+				{name: "V", schema: &jsonSchema{Type: schema.Type, Format: schema.Format, Enum: schema.Enum, Items: schema.Items}},
+				{name: "Valid", schema: &jsonSchema{Type: "boolean"}},
+			},
+			Required: []string{"V", "Valid"},
+		}
+	}
+
+	if typ, ok := schema.Type.(string); ok {
+		schema.Type = []string{typ, "null"}
+	}
+	if schema.Enum != nil {
+		schema.Enum = append(schema.Enum, nil)
+	}
+
+	return schema
+}
+
+// goTypeSchema returns the schema of the JSON encoding of a Go type.
+// Unknown types can have any value
+func goTypeSchema(typ string, enums map[string][]string) *jsonSchema {
+	if values, ok := enums[typ]; ok {
+		enum := make([]any, len(values))
+		for i, v := range values {
+			enum[i] = v
+		}
+		return &jsonSchema{Type: "string", Enum: enum}
+	}
+
+	switch typ {
+	case "string":
+		return &jsonSchema{Type: "string"}
+	case "bool":
+		return &jsonSchema{Type: "boolean"}
+	case "int", "int8", "int16", "int32", "int64",
+		"uint", "uint8", "uint16", "uint32", "uint64":
+		return &jsonSchema{Type: "integer"}
+	case "float32", "float64":
+		return &jsonSchema{Type: "number"}
+	case "time.Time":
+		return &jsonSchema{Type: "string", Format: "date-time"}
+	case "[]byte":
+		return &jsonSchema{Type: "string", ContentEncoding: "base64"}
+	case "uuid.UUID":
+		return &jsonSchema{Type: "string", Format: "uuid"}
+	case "decimal.Decimal":
+		return &jsonSchema{Type: "string", Pattern: `^-?[0-9]+(\.[0-9]+)?$`}
+	case "netip.Addr", "types.Prefix":
+		return &jsonSchema{Type: "string"}
+	case "pq.BoolArray":
+		return &jsonSchema{Type: "array", Items: goTypeSchema("bool", enums)}
+	case "pq.Int64Array":
+		return &jsonSchema{Type: "array", Items: goTypeSchema("int64", enums)}
+	case "pq.Float64Array":
+		return &jsonSchema{Type: "array", Items: goTypeSchema("float64", enums)}
+	case "pq.StringArray":
+		return &jsonSchema{Type: "array", Items: goTypeSchema("string", enums)}
+	case "pq.ByteaArray":
+		return &jsonSchema{Type: "array", Items: goTypeSchema("[]byte", enums)}
+	}
+
+	if strings.HasPrefix(typ, "[]") {
+		return &jsonSchema{Type: "array", Items: goTypeSchema(typ[2:], enums)}
+	}
+
+	if strings.HasPrefix(typ, "parray.") {
+		if start, end := strings.IndexByte(typ, '['), strings.LastIndexByte(typ, ']'); start > 0 && end > start {
+			return &jsonSchema{Type: "array", Items: goTypeSchema(typ[start+1:end], enums)}
+		}
+	}
+
+	return &jsonSchema{}
+}
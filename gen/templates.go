@@ -80,6 +80,8 @@ type TemplateData[T any] struct {
 	Aliases       Aliases
 	Types         drivers.Types
 	Relationships Relationships
+	EmbedTypes    []EmbedType
+	Trees         Trees
 
 	// Controls what names are output
 	PkgName string
@@ -90,6 +92,8 @@ type TemplateData[T any] struct {
 	EnumNullPrefix    string
 	NoTests           bool
 	NoBackReferencing bool
+	ModelSchema       string
+	Aggregates        bool
 
 	// Tags control which tags are added to the struct
 	Tags []string
@@ -219,6 +223,7 @@ var templateFunctions = template.FuncMap{
 	"ignore":             strmangle.Ignore,
 	"generateTags":       strmangle.GenerateTags,
 	"generateIgnoreTags": strmangle.GenerateIgnoreTags,
+	"validations":        validations,
 	"dbTag": func(t drivers.Table, c drivers.Column) string {
 		tag := c.Name
 		if t.Constraints.Primary != nil {
@@ -234,6 +239,9 @@ var templateFunctions = template.FuncMap{
 		if c.AutoIncr {
 			tag += ",autoincr"
 		}
+		if c.RenamedFrom != "" {
+			tag += ",renamed_from=" + c.RenamedFrom
+		}
 		return tag
 	},
 	"columnTagName": func(casing, name, alias string) string {
@@ -250,6 +258,9 @@ var templateFunctions = template.FuncMap{
 	},
 	"columnGetter": columnGetter,
 	"getColumn":    getColumn,
+	"nullType":     nullType,
+	"nullToVal":    nullToVal,
+	"nullFromVal":  nullFromVal,
 	"quoteAndJoin": func(s1, s2 string) string {
 		if s1 == "" && s2 == "" {
 			return ""
@@ -277,6 +288,7 @@ var templateFunctions = template.FuncMap{
 	"setFactoryDeps":        setFactoryDeps,
 	"relIsView":             relIsView,
 	"relQueryMethodName":    relQueryMethodName,
+	"modelSchema":           modelSchema,
 }
 
 func getColumn(t []drivers.Table, table string, a TableAlias, column string) drivers.Column {
@@ -291,24 +303,69 @@ func getColumn(t []drivers.Table, table string, a TableAlias, column string) dri
 	panic("unknown table " + table)
 }
 
-func columnGetter(tables []drivers.Table, table string, a TableAlias, column string) string {
+func columnGetter(i Importer, tables []drivers.Table, table string, a TableAlias, column, varName string) string {
 	for _, t := range tables {
 		if t.Key != table {
 			continue
 		}
 
 		col := t.GetColumn(column)
-		colAlias := a.Column(column)
+		colVal := fmt.Sprintf("%s.%s", varName, a.Column(column))
 		if !col.Nullable {
-			return colAlias
+			return colVal
 		}
 
-		return fmt.Sprintf("%s.GetOrZero()", colAlias)
+		return fmt.Sprintf("%s.GetOrZero()", nullToVal(i, col, colVal))
 	}
 
 	panic("unknown table " + table)
 }
 
+// nullType returns the type of the column in the model
+func nullType(i Importer, col drivers.Column) string {
+	if !col.Nullable {
+		return col.Type
+	}
+
+	switch col.NullType {
+	case NullTypePointer:
+		return "*" + col.Type
+	case NullTypeSQL:
+		i.Import("database/sql")
+		return fmt.Sprintf("sql.Null[%s]", col.Type)
+	default:
+		i.Import("github.com/aarondl/opt/null")
+		return fmt.Sprintf("null.Val[%s]", col.Type)
+	}
+}
+
+// nullToVal converts the model value of a nullable column to a null.Val
+func nullToVal(i Importer, col drivers.Column, val string) string {
+	switch col.NullType {
+	case NullTypePointer:
+		i.Import("github.com/aarondl/opt/null")
+		return fmt.Sprintf("null.FromPtr(%s)", val)
+	case NullTypeSQL:
+		i.Import("github.com/stephenafamo/bob/orm")
+		return fmt.Sprintf("orm.NullFromSQL(%s)", val)
+	default:
+		return val
+	}
+}
+
+// nullFromVal converts a null.Val to the model value of a nullable column
+func nullFromVal(i Importer, col drivers.Column, val string) string {
+	switch col.NullType {
+	case NullTypePointer:
+		return fmt.Sprintf("%s.Ptr()", val)
+	case NullTypeSQL:
+		i.Import("github.com/stephenafamo/bob/orm")
+		return fmt.Sprintf("orm.NullToSQL(%s)", val)
+	default:
+		return val
+	}
+}
+
 func columnSetter(i Importer, aliases Aliases, tables []drivers.Table, fromTName, toTName, fromColName, toColName, varName string, fromOpt, toOpt bool) string {
 	fromTable := drivers.GetTable(tables, fromTName)
 	fromCol := fromTable.GetColumn(fromColName)
@@ -317,14 +374,24 @@ func columnSetter(i Importer, aliases Aliases, tables []drivers.Table, fromTName
 	toCol := toTable.GetColumn(toColName)
 	to := fmt.Sprintf("%s.%s", varName, aliases[toTName].Columns[toColName])
 
+	// nullable model values are converted to null.Val before use
+	toVal := to
+	if !toOpt && toCol.Nullable {
+		toVal = nullToVal(i, toCol, to)
+	}
+
 	switch {
 	case (fromOpt == toOpt) && (toCol.Nullable == fromCol.Nullable):
 		// If both type match, return it plainly
-		return to
+		if fromOpt || !fromCol.Nullable || fromCol.NullType == toCol.NullType {
+			return to
+		}
+
+		return nullFromVal(i, fromCol, toVal)
 
 	case !fromOpt && !fromCol.Nullable:
 		// if from is concrete, then use MustGet()
-		return fmt.Sprintf("%s.MustGet()", to)
+		return fmt.Sprintf("%s.MustGet()", toVal)
 
 	case fromOpt && fromCol.Nullable && !toOpt && !toCol.Nullable:
 		i.Import("github.com/aarondl/opt/omitnull")
@@ -332,7 +399,7 @@ func columnSetter(i Importer, aliases Aliases, tables []drivers.Table, fromTName
 
 	case fromOpt && fromCol.Nullable && !toOpt && toCol.Nullable:
 		i.Import("github.com/aarondl/opt/omitnull")
-		return fmt.Sprintf("omitnull.FromNull(%s)", to)
+		return fmt.Sprintf("omitnull.FromNull(%s)", toVal)
 
 	case fromOpt && fromCol.Nullable && toOpt && !toCol.Nullable:
 		i.Import("github.com/aarondl/opt/omitnull")
@@ -347,13 +414,20 @@ func columnSetter(i Importer, aliases Aliases, tables []drivers.Table, fromTName
 
 		i.Import(fmt.Sprintf("github.com/aarondl/opt/%s", val))
 
+		var setter string
 		switch {
 		case !toOpt && !toCol.Nullable:
-			return fmt.Sprintf("%s.From(%s)", val, to)
+			setter = fmt.Sprintf("%s.From(%s)", val, to)
 
 		default:
-			return fmt.Sprintf("%s.FromCond(%s.GetOrZero(), %s.IsSet())", val, to, to)
+			setter = fmt.Sprintf("%s.FromCond(%s.GetOrZero(), %s.IsSet())", val, toVal, toVal)
+		}
+
+		if !fromOpt && fromCol.Nullable {
+			return nullFromVal(i, fromCol, setter)
 		}
+
+		return setter
 	}
 }
 
@@ -531,16 +605,15 @@ func setFactoryDeps(i Importer, tables []drivers.Table, aliases Aliases, r orm.R
 			objVarName := getVarName(aliases, kside.TableName, kside.Start, kside.End, false)
 
 			if mapp.Value != [2]string{} {
-				oGetter := columnGetter(tables, kside.TableName, oalias, mapp.Column)
-
 				if kside.TableName == r.Local() {
+					oGetter := columnGetter(i, tables, kside.TableName, oalias, mapp.Column, objVarName)
 					i.Import("github.com/stephenafamo/bob/orm")
-					mret = append(mret, fmt.Sprintf(`if %s.%s != %s {
+					mret = append(mret, fmt.Sprintf(`if %s != %s {
 								return &orm.RelationshipChainError{
 									Table1: %q, Column1: %q, Value: %q,
 								}
 							}`,
-						objVarName, oGetter, mapp.Value[1],
+						oGetter, mapp.Value[1],
 						kside.TableName, mapp.Column, mapp.Value[1],
 					))
 					continue
@@ -84,7 +84,7 @@ func (o {{$tAlias.UpSingular}}Template) toModel() (*models.{{$tAlias.UpSingular}
     {{range $column := $table.Columns -}}
     {{$colAlias := $tAlias.Column $column.Name -}}
         if o.{{$colAlias}} != nil {
-            m.{{$colAlias}} = o.{{$colAlias}}()
+            m.{{$tAlias.Field $column.Name}} = {{nullFromVal $.Importer $column (printf "o.%s()" $colAlias)}}
         }
     {{end}}
 
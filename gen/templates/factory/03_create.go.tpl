@@ -9,7 +9,7 @@ func ensureCreatable{{$tAlias.UpSingular}}(m *models.{{$tAlias.UpSingular}}Sette
 	{{range $column := $table.Columns -}}
   {{- if $column.Default}}{{continue}}{{end -}}
 	{{- if $column.Generated}}{{continue}}{{end -}}
-	{{$colAlias := $tAlias.Column $column.Name -}}
+	{{$colAlias := $tAlias.Field $column.Name -}}
 		if m.{{$colAlias}}.IsUnset() {
 			{{if $column.Nullable -}}
           m.{{$colAlias}} = omitnull.FromNull(randomNull[{{$column.Type}}](nil))
@@ -1,18 +1,22 @@
 {{$table := .Table}}
 {{$tAlias := .Aliases.Table $table.Key -}}
 {{$.Importer.Import "github.com/stephenafamo/bob"}}
+{{$.Importer.Import "github.com/stephenafamo/bob/orm"}}
 
 // {{$tAlias.UpSingular}} is an object representing the database table.
+{{- if trim $table.Comment}}{{range $table.Comment | trim | splitList "\n"}}
+// {{ . }}
+{{- end}}{{end}}
 type {{$tAlias.UpSingular}} struct {
 	{{- range $column := $table.Columns -}}
+	{{- if $embed := $tAlias.EmbedAt $column.Name}}
+		{{$embed.Field}} {{$embed.Type}} `db:"{{$embed.Prefix}}" {{generateTags $.Tags (columnTagName $.StructTagCasing (trimSuffix "_" $embed.Prefix) $embed.Field) | trim}}`
+	{{- end -}}
+	{{- if $tAlias.InEmbed $column.Name}}{{continue}}{{end -}}
 	{{- $colAlias := $tAlias.Column $column.Name -}}
-	{{- $colTyp := $column.Type -}}
+	{{- $colTyp := nullType $.Importer $column -}}
 	{{- $.Importer.ImportList (index $.Types $column.Type).Imports -}}
 	{{- $orig_col_name := $column.Name -}}
-	{{- if $column.Nullable -}}
-		{{- $colTyp = printf "null.Val[%s]" $column.Type -}}
-		{{ $.Importer.Import "github.com/aarondl/opt/null"}}
-	{{- end -}}
 	{{- if trim $column.Comment}}{{range $column.Comment | splitList "\n"}}
 		// {{ . }}
 	{{- end}}{{end -}}
@@ -26,13 +30,52 @@ type {{$tAlias.UpSingular}} struct {
 	{{- if $.Relationships.Get $table.Key}}
 
 	R {{$tAlias.DownSingular}}R `db:"-" {{generateTags $.Tags $.RelationTag | trim}}`
-	{{end -}}
+	{{- end}}
+
+	// nil when all the columns were selected, a pointer so the model stays comparable
+	selection *orm.Selection
+}
+
+// IsSelected reports if the column was selected when the {{$tAlias.UpSingular}} was loaded.
+// The fields of the columns that were not selected, e.g. with {{$tAlias.UpPlural}}.SelectOnly, are not set
+func (o *{{$tAlias.UpSingular}}) IsSelected(column string) bool {
+	return o.selection == nil || o.selection.Has(column)
+}
+
+// SetSelection is called when the {{$tAlias.UpSingular}} is loaded without all its columns
+func (o *{{$tAlias.UpSingular}}) SetSelection(s orm.Selection) {
+	o.selection = &s
 }
 
 // {{$tAlias.UpSingular}}Slice is an alias for a slice of pointers to {{$tAlias.UpSingular}}.
 // This should almost always be used instead of []*{{$tAlias.UpSingular}}.
 type {{$tAlias.UpSingular}}Slice []*{{$tAlias.UpSingular}}
 
+{{$computed := list -}}
+{{range $column := $table.Columns}}{{if $column.Computed}}{{$computed = append $computed $column}}{{end}}{{end -}}
+{{if $computed -}}
+// ComputedColumns are the SQL expressions of the computed fields of {{$tAlias.UpSingular}},
+// keyed by column name. They are selected instead of a column of the table
+func (*{{$tAlias.UpSingular}}) ComputedColumns() map[string]string {
+	return map[string]string{
+		{{range $computed -}}
+		{{quote .Name}}: {{quote .Computed}},
+		{{end -}}
+	}
+}
+{{- end}}
+
+{{if and $table.Constraints.Primary (gt (len $table.Constraints.Primary.Columns) 1) -}}
+// {{$tAlias.UpSingular}}PK is the composite primary key of {{$tAlias.UpSingular}}
+type {{$tAlias.UpSingular}}PK struct {
+	{{- range $colName := $table.Constraints.Primary.Columns -}}
+	{{- $column := $table.GetColumn $colName -}}
+	{{- $.Importer.ImportList (index $.Types $column.Type).Imports}}
+	{{$tAlias.Column $colName}} {{$column.Type}}
+	{{- end}}
+}
+{{- end}}
+
 {{block "model_and_query" . -}}
 {{$table := .Table}}
 {{$tAlias := .Aliases.Table $table.Key -}}
@@ -52,6 +95,91 @@ type {{$tAlias.UpSingular}}Slice []*{{$tAlias.UpSingular}}
 // {{$tAlias.UpPlural}}Stmt is a prepared statment on {{$table.Name}}
 type {{$tAlias.UpPlural}}Stmt = bob.QueryStmt[*{{$tAlias.UpSingular}}, {{$tAlias.UpSingular}}Slice]
 
+{{$partitionKey := list -}}
+{{range $column := $table.Columns}}{{if $column.PartitionKey}}{{$partitionKey = append $partitionKey $column.Name}}{{end}}{{end -}}
+{{if $partitionKey -}}
+// {{$tAlias.UpPlural}}PartitionKey are the columns the {{$table.Name}} table is partitioned by.
+// Filtering on them, e.g. with {{$.Dialect}}.InPartition, lets the database skip the other partitions
+var {{$tAlias.UpPlural}}PartitionKey = []string{ {{- range $partitionKey}}{{quote .}}, {{end -}} }
+{{- end}}
+
+{{$children := list -}}
+{{range $other := $.Tables}}{{range $other.Inherits}}{{if eq . $table.Key}}{{$children = append $children $other.Key}}{{end}}{{end}}{{end -}}
+{{if $children -}}
+// {{$tAlias.UpPlural}}Children are the tables that inherit from the {{$table.Name}} table.
+// Queries on {{$table.Name}} also return the rows of these tables, unless they use sm.Only()
+var {{$tAlias.UpPlural}}Children = []string{ {{- range $children}}{{quote .}}, {{end -}} }
+{{- end}}
+
+{{$sequences := list -}}
+{{range $column := $table.Columns}}{{if $column.Sequence}}{{$sequences = append $sequences $column}}{{end}}{{end -}}
+{{if $sequences -}}
+// {{$tAlias.UpPlural}}Sequences are the sequences owned by the columns of the {{$table.Name}} table.
+// Use them with {{$.Dialect}}.NextVal or {{$.Dialect}}.AllocateIDs to get ids before inserting
+var {{$tAlias.UpPlural}}Sequences = struct {
+	{{range $sequences -}}
+	{{$tAlias.Column .Name}} string
+	{{end -}}
+}{
+	{{range $sequences -}}
+	{{$tAlias.Column .Name}}: {{quote .Sequence}},
+	{{end -}}
+}
+{{- end}}
+
+{{$hasComments := trim $table.Comment -}}
+{{range $column := $table.Columns}}{{if and (trim $column.Comment) (not $column.Computed)}}{{$hasComments = "true"}}{{end}}{{end -}}
+{{if $hasComments -}}
+// {{$tAlias.UpPlural}}Comments are the comments of the {{$table.Name}} table and its columns
+// in the database, with the columns keyed by name. Columns without a comment are left out
+var {{$tAlias.UpPlural}}Comments = struct {
+	Table   string
+	Columns map[string]string
+}{
+	Table: {{quote (trim $table.Comment)}},
+	Columns: map[string]string{
+		{{range $column := $table.Columns -}}
+		{{if and (trim $column.Comment) (not $column.Computed) -}}
+		{{quote $column.Name}}: {{quote (trim $column.Comment)}},
+		{{end -}}
+		{{end -}}
+	},
+}
+{{- end}}
+
+{{$.Importer.Import "github.com/stephenafamo/bob/orm"}}
+// {{$tAlias.UpPlural}}Meta is the structure of the {{$table.Name}} {{if $table.Constraints.Primary}}table{{else}}view{{end}} when the models were generated
+var {{$tAlias.UpPlural}}Meta = orm.TableMeta{
+	Key: {{quote $table.Key}},
+	Schema: {{quote $table.Schema}},
+	Name: {{quote $table.Name}},
+	Columns: []string{ {{- range $column := $table.Columns}}{{if not $column.Computed}}{{quote $column.Name}}, {{end}}{{end -}} },
+	{{if $table.Constraints.Primary -}}
+	PrimaryKey: []string{ {{- range $table.Constraints.Primary.Columns}}{{quote .}}, {{end -}} },
+	{{end -}}
+	{{if $table.Constraints.Uniques -}}
+	Uniques: []orm.ConstraintMeta{
+		{{range $table.Constraints.Uniques -}}
+		{Name: {{quote .Name}}, Columns: []string{ {{- range .Columns}}{{quote .}}, {{end -}} }},
+		{{end -}}
+	},
+	{{end -}}
+	{{if $table.Indexes -}}
+	Indexes: []orm.IndexMeta{
+		{{range $table.Indexes -}}
+		{Name: {{quote .Name}}, Columns: []string{ {{- range .Columns}}{{quote .}}, {{end -}} }, Unique: {{.Unique}}},
+		{{end -}}
+	},
+	{{end -}}
+	{{if $table.Constraints.Foreign -}}
+	ForeignKeys: []orm.ForeignKeyMeta{
+		{{range $table.Constraints.Foreign -}}
+		{Name: {{quote .Name}}, Columns: []string{ {{- range .Columns}}{{quote .}}, {{end -}} }, ForeignTable: {{quote .ForeignTable}}, ForeignColumns: []string{ {{- range .ForeignColumns}}{{quote .}}, {{end -}} }},
+		{{end -}}
+	},
+	{{end -}}
+}
+
 {{if $.Relationships.Get $table.Key -}}
 {{$.Importer.Import (printf "github.com/stephenafamo/bob/dialect/%s/dialect" $.Dialect)}}
 // {{$tAlias.DownSingular}}R is where relationships are stored.
@@ -73,7 +201,10 @@ type {{$tAlias.DownSingular}}R struct {
 // Generated columns are not included
 type {{$tAlias.UpSingular}}Setter struct {
 	{{- range $column := $table.Columns -}}
-	{{- if $column.Generated}}{{continue}}{{end -}}
+	{{- if $embed := $tAlias.EmbedAt $column.Name -}}
+		{{$embed.Field}} {{$embed.Type}}Setter `db:"{{$embed.Prefix}}"`
+	{{end -}}
+	{{- if or $column.Generated ($tAlias.InEmbed $column.Name)}}{{continue}}{{end -}}
 	{{- $colAlias := $tAlias.Column $column.Name -}}
 	{{- $colTyp := "" -}}
 		{{- if $column.Nullable -}}
@@ -91,7 +222,7 @@ func (s {{$tAlias.UpSingular}}Setter) SetColumns() []string {
   vals := make([]string, 0, {{len $table.NonGeneratedColumns}})
 	{{range $column := $table.Columns -}}
 	{{if $column.Generated}}{{continue}}{{end -}}
-	{{$colAlias := $tAlias.Column $column.Name -}}
+	{{$colAlias := $tAlias.Field $column.Name -}}
 		if !s.{{$colAlias}}.IsUnset() {
 			vals = append(vals, {{printf "%q" $column.Name}})
 		}
@@ -104,17 +235,60 @@ func (s {{$tAlias.UpSingular}}Setter) SetColumns() []string {
 func (s {{$tAlias.UpSingular}}Setter) Overwrite(t *{{$tAlias.UpSingular}}) {
 	{{- range $column := $table.Columns -}}
 	{{if $column.Generated}}{{continue}}{{end -}}
-	{{$colAlias := $tAlias.Column $column.Name -}}
+	{{$colAlias := $tAlias.Field $column.Name -}}
 		if !s.{{$colAlias}}.IsUnset() {
 			{{- if not $column.Nullable -}}
 				t.{{$colAlias}}, _ = s.{{$colAlias}}.Get()
 			{{- else -}}
-				t.{{$colAlias}}, _ = s.{{$colAlias}}.GetNull()
+				t.{{$colAlias}} = {{nullFromVal $.Importer $column (printf "s.%s.MustGetNull()" $colAlias)}}
 			{{- end -}}
 		}
 	{{end -}}
 }
 
+{{$validations := validations $.Dialect $table -}}
+{{$.Importer.Import "github.com/stephenafamo/bob/orm"}}
+// Validate checks the set values against the constraints of the {{$table.Name}} table:
+// the length of character columns, the digits of numeric columns and the
+// check constraints that can be translated to Go. It is called before updates
+func (s {{$tAlias.UpSingular}}Setter) Validate() error {
+	v := orm.NewValidation({{quote $table.Key}})
+	s.validate(v)
+	return v.Err()
+}
+
+// ValidateInsert also checks that the columns without a default that cannot be null are set.
+// It is called before inserts and upserts
+func (s {{$tAlias.UpSingular}}Setter) ValidateInsert() error {
+	v := orm.NewValidation({{quote $table.Key}})
+	{{range $val := $validations -}}
+	{{if $val.Required -}}
+	v.Required({{quote $val.Column.Name}}, !s.{{$tAlias.Field $val.Column.Name}}.IsUnset())
+	{{end -}}
+	{{end -}}
+	s.validate(v)
+	return v.Err()
+}
+
+func (s {{$tAlias.UpSingular}}Setter) validate(v *orm.Validation) {
+	{{- range $val := $validations -}}
+	{{- if or $val.MaxLength $val.Precision $val.Checks}}
+	if val, ok := s.{{$tAlias.Field $val.Column.Name}}.Get(); ok {
+		{{- if $val.MaxLength}}
+		v.MaxLength({{quote $val.Column.Name}}, val, {{$val.MaxLength}})
+		{{- end -}}
+		{{- if $val.Precision}}
+		v.Precision({{quote $val.Column.Name}}, val, {{$val.Precision}}, {{$val.Scale}})
+		{{- end -}}
+		{{- range $check := $val.Checks -}}
+		{{- if contains "utf8." $check.Go}}{{$.Importer.Import "unicode/utf8"}}{{end}}
+		v.Check({{quote $val.Column.Name}}, {{quote $check.Name}}, {{quote $check.Expression}}, {{$check.Go}})
+		{{- end}}
+	}
+	{{- end -}}
+	{{- end}}
+}
+
 {{block "setter_insert_mod" . -}}
 {{$.Importer.Import (printf "github.com/stephenafamo/bob/dialect/%s/im" $.Dialect)}}
 {{$table := .Table}}
@@ -122,7 +296,7 @@ func (s {{$tAlias.UpSingular}}Setter) Overwrite(t *{{$tAlias.UpSingular}}) {
 func (s {{$tAlias.UpSingular}}Setter) InsertMod() bob.Mod[*dialect.InsertQuery] {
   vals := make([]bob.Expression, {{len $table.NonGeneratedColumns}})
 	{{range $index, $column := $table.NonGeneratedColumns -}}
-		{{$colAlias := $tAlias.Column $column.Name -}}
+		{{$colAlias := $tAlias.Field $column.Name -}}
 		if s.{{$colAlias}}.IsUnset() {
 			vals[{{$index}}] = {{$.Dialect}}.Raw("DEFAULT")
 		} else {
@@ -153,12 +327,19 @@ func (s {{$tAlias.UpSingular}}Setter) Expressions(prefix ...string) []bob.Expres
 	{{$.Importer.Import (printf "github.com/stephenafamo/bob/dialect/%s/um" $.Dialect)}}
 	{{range $column := $table.Columns -}}
 	{{if $column.Generated}}{{continue}}{{end -}}
-	{{$colAlias := $tAlias.Column $column.Name -}}
+	{{$colAlias := $tAlias.Field $column.Name -}}
 		if !s.{{$colAlias}}.IsUnset() {
       exprs = append(exprs, expr.Join{Sep: " = ", Exprs: []bob.Expression{
         {{$.Dialect}}.Quote(append(prefix, "{{$column.Name}}")...), 
         {{$.Dialect}}.Arg(s.{{$colAlias}}),
       }})
+      {{- if $column.RenamedFrom}}
+      // also written to the old column, which is read when the new one is NULL
+      exprs = append(exprs, expr.Join{Sep: " = ", Exprs: []bob.Expression{
+        {{$.Dialect}}.Quote(append(prefix, "{{$column.RenamedFrom}}")...), 
+        {{$.Dialect}}.Arg(s.{{$colAlias}}),
+      }})
+      {{- end}}
 		}
 
 	{{end -}}
@@ -214,8 +395,14 @@ var {{$tAlias.UpSingular}}Columns = struct {
 }{
 	{{range $column := $table.Columns -}}
 	{{- $colAlias := $tAlias.Column $column.Name -}}
+	{{if $column.Computed -}}
+	{{$colAlias}}: {{$.Dialect}}.Group({{$.Dialect}}.Raw({{quote $column.Computed}})),
+	{{else if $column.RenamedFrom -}}
+	{{$colAlias}}: {{$.Dialect}}.Group({{$.Dialect}}.F("COALESCE", {{$.Dialect}}.Quote({{quote $table.Key}}, {{quote $column.Name}}), {{$.Dialect}}.Quote({{quote $table.Key}}, {{quote $column.RenamedFrom}}))),
+	{{else -}}
 	{{$colAlias}}: {{$.Dialect}}.Quote({{quote $table.Key}}, {{quote $column.Name}}),
 	{{end -}}
+	{{end -}}
 }
 
 type {{$tAlias.DownSingular}}Where[Q {{$.Dialect}}.Filterable] struct {
@@ -241,3 +428,18 @@ func {{$tAlias.UpSingular}}Where[Q {{$.Dialect}}.Filterable]() {{$tAlias.DownSin
 			{{end -}}
 	}
 }
+
+{{$.Importer.Import "github.com/stephenafamo/bob/filter"}}
+// {{$tAlias.UpSingular}}Filters returns the columns of {{$tAlias.UpSingular}} with the given names,
+// keyed by column name, as the allowlist of a filter.Filter.
+// Without names, no column can be filtered on
+func {{$tAlias.UpSingular}}Filters(columns ...string) filter.Columns {
+	return {{$tAlias.DownSingular}}Filters.Only(columns...)
+}
+
+var {{$tAlias.DownSingular}}Filters = filter.Columns{
+	{{range $column := $table.Columns -}}
+	{{- $colAlias := $tAlias.Column $column.Name -}}
+	{{quote $column.Name}}: filter.Col[{{$column.Type}}]({{$tAlias.UpSingular}}Columns.{{$colAlias}}, {{$column.Nullable}}),
+	{{end -}}
+}
@@ -14,6 +14,14 @@
 // If cols is empty Find will return all columns.
 func Find{{$tAlias.UpSingular}}(ctx context.Context, exec bob.Executor, {{$pkArgs}} cols ...string) (*{{$tAlias.UpSingular}}, error) {
 	if len(cols) == 0 {
+		if o, ok := {{$tAlias.UpPlural}}.Identity(ctx,
+			{{- range $i, $column := $table.Constraints.Primary.Columns -}}
+			{{- if $i}}, {{end}}{{$tAlias.Column $column}}PK
+			{{- end -}}
+		); ok {
+			return o, nil
+		}
+
 		return {{$tAlias.UpPlural}}.Query(
 			ctx, exec,
 			{{range $column := $table.Constraints.Primary.Columns -}}
@@ -33,6 +41,42 @@ func Find{{$tAlias.UpSingular}}(ctx context.Context, exec bob.Executor, {{$pkArg
 	).One()
 }
 
+{{if gt (len $table.Constraints.Primary.Columns) 1 -}}
+// Find{{$tAlias.UpSingular}}ByPK retrieves a single record by its composite primary key
+// If cols is empty Find will return all columns.
+func Find{{$tAlias.UpSingular}}ByPK(ctx context.Context, exec bob.Executor, pk {{$tAlias.UpSingular}}PK, cols ...string) (*{{$tAlias.UpSingular}}, error) {
+	return Find{{$tAlias.UpSingular}}(ctx, exec,
+		{{- range $column := $table.Constraints.Primary.Columns -}}
+		pk.{{$tAlias.Column $column}},
+		{{- end -}}
+		cols...)
+}
+
+// FindMany{{$tAlias.UpPlural}} retrieves the records with the given primary keys
+// with a single query, comparing all the key columns as a row value
+func FindMany{{$tAlias.UpPlural}}(ctx context.Context, exec bob.Executor, pks ...{{$tAlias.UpSingular}}PK) ({{$tAlias.UpSingular}}Slice, error) {
+	if len(pks) == 0 {
+		return nil, nil
+	}
+
+	PKArgs := make([]bob.Expression, len(pks))
+	for i, pk := range pks {
+		PKArgs[i] = {{$.Dialect}}.ArgGroup(
+		{{- range $column := $table.Constraints.Primary.Columns -}}
+			pk.{{$tAlias.Column $column}},
+		{{- end -}})
+	}
+
+	return {{$tAlias.UpPlural}}.Query(
+		ctx, exec,
+		sm.Where({{$.Dialect}}.Group(
+		{{- range $column := $table.Constraints.Primary.Columns -}}
+			{{$tAlias.UpSingular}}Columns.{{$tAlias.Column $column}},
+		{{- end}}).In(PKArgs...)),
+	).All()
+}
+
+{{end -}}
 // {{$tAlias.UpSingular}}Exists checks the presence of a single record by primary key
 func {{$tAlias.UpSingular}}Exists(ctx context.Context, exec bob.Executor, {{$pkArgs}}) (bool, error) {
 	return {{$tAlias.UpPlural}}.Query(
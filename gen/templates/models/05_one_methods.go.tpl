@@ -1,5 +1,6 @@
 {{if .Table.Constraints.Primary -}}
 {{$.Importer.Import "context"}}
+{{$.Importer.Import "github.com/stephenafamo/bob/orm"}}
 {{$.Importer.Import (printf "github.com/stephenafamo/bob/dialect/%s/dialect" $.Dialect)}}
 {{$table := .Table}}
 {{$tAlias := .Aliases.Table $table.Key -}}
@@ -17,6 +18,29 @@ func (o *{{$tAlias.UpSingular}}) PrimaryKeyVals() bob.Expression {
 	{{- end}}
 }
 
+{{if gt (len $table.Constraints.Primary.Columns) 1 -}}
+// PK returns the primary key of the {{$tAlias.UpSingular}}
+func (o *{{$tAlias.UpSingular}}) PK() {{$tAlias.UpSingular}}PK {
+	return {{$tAlias.UpSingular}}PK{
+		{{range $column := $table.Constraints.Primary.Columns -}}
+		{{- $colAlias := $tAlias.Column $column -}}
+		{{$colAlias}}: o.{{$colAlias}},
+		{{end -}}
+	}
+}
+{{- end}}
+
+// CascadeInfo returns the table, primary key and foreign keys that are used
+// to delete the {{$tAlias.UpSingular}} with bob.DeleteCascade
+func (o *{{$tAlias.UpSingular}}) CascadeInfo() bob.CascadeInfo {
+	return bob.CascadeInfo{
+		Dialect:     dialect.Dialect,
+		Table:       {{quote $table.Key}},
+		PrimaryKey:  {{$tAlias.UpPlural}}Meta.PrimaryKey,
+		ForeignKeys: foreignKeyRefs,
+	}
+}
+
 // Update uses an executor to update the {{$tAlias.UpSingular}}
 func (o *{{$tAlias.UpSingular}}) Update(ctx context.Context, exec bob.Executor, s *{{$tAlias.UpSingular}}Setter) error {
 	return {{$tAlias.UpPlural}}.Update(ctx, exec, s, o)
@@ -27,10 +51,11 @@ func (o *{{$tAlias.UpSingular}}) Delete(ctx context.Context, exec bob.Executor)
 	return {{$tAlias.UpPlural}}.Delete(ctx, exec, o)
 }
 
-// Reload refreshes the {{$tAlias.UpSingular}} using the executor
+// Reload refreshes the {{$tAlias.UpSingular}} using the executor.
+// The row is read from the database even if it is in the identity map of the context
 func (o *{{$tAlias.UpSingular}}) Reload(ctx context.Context, exec bob.Executor) error {
 	o2, err := {{$tAlias.UpPlural}}.Query(
-		ctx, exec,
+		orm.WithoutIdentityMap(ctx), exec,
 		{{range $column := $table.Constraints.Primary.Columns -}}
 		{{- $colAlias := $tAlias.Column $column -}}
 		SelectWhere.{{$tAlias.UpPlural}}.{{$colAlias}}.EQ(o.{{$colAlias}}),
@@ -41,6 +66,7 @@ func (o *{{$tAlias.UpSingular}}) Reload(ctx context.Context, exec bob.Executor)
 	}
 	{{if $.Relationships.Get $table.Key}}o2.R = o.R{{end}}
 	*o = *o2
+	{{$tAlias.UpPlural}}.Identify(ctx, o)
 
 	return nil
 }
@@ -1,5 +1,6 @@
 {{if .Table.Constraints.Primary -}}
 {{$.Importer.Import (printf "github.com/stephenafamo/bob/dialect/%s/sm" $.Dialect)}}
+{{$.Importer.Import "github.com/stephenafamo/bob/orm"}}
 {{$table := .Table}}
 {{$tAlias := .Aliases.Table $table.Key -}}
 
@@ -12,6 +13,8 @@ func (o {{$tAlias.UpSingular}}Slice) DeleteAll(ctx context.Context, exec bob.Exe
 }
 
 
+// ReloadAll refreshes the rows using the executor.
+// They are read from the database even if they are in the identity map of the context
 func (o {{$tAlias.UpSingular}}Slice) ReloadAll(ctx context.Context, exec bob.Executor) error {
   var mods []bob.Mod[*dialect.SelectQuery]
 
@@ -35,7 +38,7 @@ func (o {{$tAlias.UpSingular}}Slice) ReloadAll(ctx context.Context, exec bob.Exe
 	{{end}}
 	)
 
-	o2, err := {{$tAlias.UpPlural}}.Query(ctx, exec, mods...).All()
+	o2, err := {{$tAlias.UpPlural}}.Query(orm.WithoutIdentityMap(ctx), exec, mods...).All()
 	if err != nil {
 		return err
 	}
@@ -53,6 +56,7 @@ func (o {{$tAlias.UpSingular}}Slice) ReloadAll(ctx context.Context, exec bob.Exe
 			break
 		}
 	}
+	{{$tAlias.UpPlural}}.Identify(ctx, o...)
 
 	return nil
 }
@@ -73,10 +73,27 @@ func (o *{{$tAlias.UpSingular}}) Preload(name string, retrieved any) error {
 {{- $fAlias := $.Aliases.Table $rel.Foreign -}}
 {{- $relAlias := $tAlias.Relationship $rel.Name -}}
 {{- $invRel := $.Relationships.GetInverse $.Tables . -}}
-{{- if not $rel.IsToMany -}}
+{{- $preload := "Preload" -}}
+{{- if $rel.IsToMany}}{{$preload = "PreloadJSON"}}{{end -}}
+{{- $computed := list -}}
+{{- range (getTable $.Tables $rel.Foreign).Columns}}{{if .Computed}}{{$computed = append $computed .Name}}{{end}}{{end -}}
+{{- /* A to-one relationship on the primary key of the foreign table can be found in the identity map */ -}}
+{{- $fTable := getTable $.Tables $rel.Foreign -}}
+{{- $idSide := index $rel.Sides 0 -}}
+{{- $byIdentity := false -}}
+{{- if and (not $rel.IsToMany) (eq (len $rel.Sides) 1) (not $idSide.FromWhere) (not $idSide.ToWhere) $fTable.Constraints.Primary -}}
+	{{- if eq (join "," $idSide.ToColumns) (join "," $fTable.Constraints.Primary.Columns) -}}
+		{{- $byIdentity = true -}}
+		{{- range $i, $local := $idSide.FromColumns -}}
+			{{- $fromCol := getColumn $.Tables $idSide.From $tAlias $local -}}
+			{{- $toCol := $fTable.GetColumn (index $idSide.ToColumns $i) -}}
+			{{- if or $fromCol.Nullable (ne $fromCol.Type $toCol.Type) -}}{{- $byIdentity = false -}}{{- end -}}
+		{{- end -}}
+	{{- end -}}
+{{- end -}}
 {{$.Importer.Import "github.com/stephenafamo/bob/orm"}}
-func Preload{{$tAlias.UpSingular}}{{$relAlias}}(opts ...{{$.Dialect}}.PreloadOption) {{$.Dialect}}.Preloader {
-	return {{$.Dialect}}.Preload[*{{$fAlias.UpSingular}}, {{$fAlias.UpSingular}}Slice](orm.Relationship{
+func {{$preload}}{{$tAlias.UpSingular}}{{$relAlias}}(opts ...{{$.Dialect}}.PreloadOption) {{$.Dialect}}.Preloader {
+	return {{$.Dialect}}.{{$preload}}[*{{$fAlias.UpSingular}}, {{$fAlias.UpSingular}}Slice](orm.Relationship{
 			Name: "{{$relAlias}}",
 			Sides:  []orm.RelSide{
 				{{- $toTable := $table }}{{/* To be able to access the last one after the loop */}}
@@ -134,9 +151,8 @@ func Preload{{$tAlias.UpSingular}}{{$relAlias}}(opts ...{{$.Dialect}}.PreloadOpt
 				},
 				{{- end}}
 			},
-		}, {{$fAlias.UpPlural}}.Columns().Names(), opts...)
+		}, {{$fAlias.UpPlural}}.Columns(){{if $computed}}.Except({{range $computed}}{{quote .}}, {{end}}){{end}}.Names(), opts...)
 }
-{{- end}}
 
 func ThenLoad{{$tAlias.UpSingular}}{{$relAlias}}(queryMods ...bob.Mod[*dialect.SelectQuery]) {{$.Dialect}}.Loader {
 	return {{$.Dialect}}.Loader(func(ctx context.Context, exec bob.Executor, retrieved any) error {
@@ -167,6 +183,17 @@ func (o *{{$tAlias.UpSingular}}) Load{{$tAlias.UpSingular}}{{$relAlias}}(ctx con
 	// Reset the relationship
 	o.R.{{$relAlias}} = nil
 
+	{{if $byIdentity -}}
+	// Not queried again if it is in the identity map of the context
+	related, ok := {{$fAlias.UpPlural}}.Identity(ctx, {{range $i, $local := $idSide.FromColumns}}{{if $i}}, {{end}}o.{{$tAlias.Column $local}}{{end}})
+	if !ok || len(mods) > 0 {
+		var err error
+		related, err = o.{{relQueryMethodName $tAlias $relAlias}}(ctx, exec, mods...).One()
+		if err != nil {
+			return err
+		}
+	}
+	{{else -}}
 	{{if $rel.IsToMany -}}
 	related, err := o.{{relQueryMethodName $tAlias $relAlias}}(ctx, exec, mods...).All()
 	{{else -}}
@@ -175,6 +202,7 @@ func (o *{{$tAlias.UpSingular}}) Load{{$tAlias.UpSingular}}{{$relAlias}}(ctx con
 	if err != nil {
 		return err
 	}
+	{{- end}}
 
 	{{if and (not $.NoBackReferencing) $invRel.Name -}}
 	{{- $invAlias := $fAlias.Relationship $invRel.Name -}}
@@ -209,6 +237,37 @@ func (os {{$tAlias.UpSingular}}Slice) Load{{$tAlias.UpSingular}}{{$relAlias}}(ct
 	  return nil
 	}
 
+	{{if $byIdentity -}}
+	// Not queried if all of them are in the identity map of the context
+	if len(mods) == 0 {
+		cached := make({{$fAlias.UpSingular}}Slice, 0, len(os))
+		for _, o := range os {
+			rel, ok := {{$fAlias.UpPlural}}.Identity(ctx, {{range $i, $local := $idSide.FromColumns}}{{if $i}}, {{end}}o.{{$tAlias.Column $local}}{{end}})
+			if !ok {
+				break
+			}
+			cached = append(cached, rel)
+		}
+
+		if len(cached) == len(os) {
+			for i, o := range os {
+				rel := cached[i]
+				{{if and (not $.NoBackReferencing) $invRel.Name -}}
+				{{- $invAlias := $fAlias.Relationship $invRel.Name -}}
+					{{if $invRel.IsToMany -}}
+						rel.R.{{$invAlias}} = append(rel.R.{{$invAlias}}, o)
+					{{else -}}
+						rel.R.{{$invAlias}} =  o
+					{{- end}}
+				{{- end}}
+				o.R.{{$relAlias}} = rel
+			}
+
+			return nil
+		}
+	}
+
+	{{end -}}
 	{{$fAlias.DownPlural}}, err := os.{{relQueryMethodName $tAlias $relAlias}}(ctx, exec, mods...).All()
 	if err != nil {
 		return err
@@ -224,9 +283,9 @@ func (os {{$tAlias.UpSingular}}Slice) Load{{$tAlias.UpSingular}}{{$relAlias}}(ct
 		for _, rel := range {{$fAlias.DownPlural}} {
 			{{range $index, $local := $side.FromColumns -}}
 			{{- $foreign := index $side.ToColumns $index -}}
-			{{- $fromColGet := columnGetter $.Tables $side.From $fromAlias $local -}}
-			{{- $toColGet := columnGetter $.Tables $side.To $toAlias $foreign -}}
-			if o.{{$fromColGet}} != rel.{{$toColGet}} {
+			{{- $fromColGet := columnGetter $.Importer $.Tables $side.From $fromAlias $local "o" -}}
+			{{- $toColGet := columnGetter $.Importer $.Tables $side.To $toAlias $foreign "rel" -}}
+			if {{$fromColGet}} != {{$toColGet}} {
 			  continue
 			}
 			{{end}}
@@ -287,7 +346,8 @@ func (os {{$tAlias.UpSingular}}Slice) Load{{$tAlias.UpSingular}}{{$relAlias}}(ct
   {{end}}
 
 	{{$.Importer.Import "github.com/stephenafamo/scan" -}}
-  mapper := scan.Mod(scan.StructMapper[*{{$fAlias.UpSingular}}](), func(ctx context.Context, cols []string) (scan.BeforeFunc, func(any, any) error) {
+	{{$.Importer.Import "github.com/stephenafamo/bob/orm" -}}
+  mapper := scan.Mod(orm.StructMapper[*{{$fAlias.UpSingular}}](), func(ctx context.Context, cols []string) (scan.BeforeFunc, func(any, any) error) {
     return func(row *scan.Row) (any, error) {
       {{range $index, $local := $firstSide.FromColumns -}}
         {{- $fromColAlias := index $firstFrom.Columns $local -}}
@@ -59,6 +59,9 @@
           {{if $rel.NeedsMany .ExtPosition -}}
             {{$colVal = printf "%s%d[i].%s" $a.DownPlural $map.ExtPosition ($a.Column $map.ExternalColumn) -}}
           {{end -}}
+          {{if $c.Nullable -}}
+            {{$colVal = nullToVal $.Importer $c $colVal -}}
+          {{end -}}
           {{if and $sideC.Nullable $c.Nullable }}
             {{$.Importer.Import "github.com/aarondl/opt/omitnull" -}}
             {{$tblName}}.{{$colName}} = omitnull.FromNull({{$colVal}})
@@ -132,6 +135,9 @@
               {{if $rel.NeedsMany .ExtPosition -}}
                 {{$colVal = printf "%s%d[i].%s" $a.DownPlural $map.ExtPosition ($a.Column $map.ExternalColumn) -}}
               {{end -}}
+              {{if $c.Nullable -}}
+                {{$colVal = nullToVal $.Importer $c $colVal -}}
+              {{end -}}
               {{if and $sideC.Nullable $c.Nullable -}}
                 {{$.Importer.Import "github.com/aarondl/opt/omitnull"}}
                 {{$colName}}: omitnull.FromNull({{$colVal}}),
@@ -192,6 +198,9 @@
             {{if $rel.NeedsMany .ExtPosition -}}
               {{$colVal = printf "%s%d[i].%s" $a.DownPlural $map.ExtPosition ($a.Column $map.ExternalColumn) -}}
             {{end -}}
+            {{if $c.Nullable -}}
+              {{$colVal = nullToVal $.Importer $c $colVal -}}
+            {{end -}}
             {{if and $sideC.Nullable $c.Nullable -}}
               {{$.Importer.Import "github.com/aarondl/opt/omitnull"}}
               {{$colName}}: omitnull.FromNull({{$colVal}}),
@@ -0,0 +1,194 @@
+{{$table := .Table}}
+{{$tAlias := .Aliases.Table $table.Key -}}
+{{$tree := index $.Trees $table.Key}}
+{{if $tree.Strategy -}}
+{{$.Importer.Import "context"}}
+{{$.Importer.Import "github.com/stephenafamo/bob"}}
+{{$.Importer.Import (printf "github.com/stephenafamo/bob/dialect/%s" $.Dialect)}}
+{{$.Importer.Import (printf "github.com/stephenafamo/bob/dialect/%s/dialect" $.Dialect)}}
+{{$.Importer.Import (printf "github.com/stephenafamo/bob/dialect/%s/sm" $.Dialect)}}
+{{$pk := index $table.Constraints.Primary.Columns 0}}
+{{$pkAlias := $tAlias.Column $pk}}
+{{/* Subqueries are compared with Raw, because In wraps them in a second pair of
+parentheses, which makes them scalar subqueries that return only their first row */}}
+
+{{if eq $tree.Strategy "recursive" -}}
+{{$parentAlias := $tAlias.Column $tree.Parent}}
+// Descendants starts a query for the {{$tAlias.UpPlural}} below the {{$tAlias.UpSingular}} in the tree,
+// found by following {{$tree.Parent}} with a recursive CTE
+func (o *{{$tAlias.UpSingular}}) Descendants(ctx context.Context, exec bob.Executor, mods ...bob.Mod[*dialect.SelectQuery]) {{$tAlias.UpPlural}}Query {
+	seed := {{$.Dialect}}.Select(
+		sm.Columns({{$tAlias.UpSingular}}Columns.{{$pkAlias}}),
+		sm.From({{$tAlias.UpPlural}}.Name(ctx)),
+		sm.Where({{$tAlias.UpSingular}}Columns.{{$parentAlias}}.EQ({{$.Dialect}}.Arg(o.{{$pkAlias}}))),
+	)
+	step := {{$.Dialect}}.Select(
+		sm.Columns({{$tAlias.UpSingular}}Columns.{{$pkAlias}}),
+		sm.From({{$tAlias.UpPlural}}.Name(ctx)),
+		sm.InnerJoin({{$.Dialect}}.Quote("bob_tree")).On(
+			{{$tAlias.UpSingular}}Columns.{{$parentAlias}}.EQ({{$.Dialect}}.Quote("bob_tree", {{quote $pk}})),
+		),
+	)
+	// the queries of the UNION cannot be in parentheses in SQLite
+	tree := {{$.Dialect}}.RawQuery("? UNION ?", seed.Expression, step.Expression)
+
+	return {{$tAlias.UpPlural}}.Query(ctx, exec, append(mods,
+		sm.With("bob_tree", {{quote $pk}}).As(tree),
+		sm.Recursive(true),
+		sm.Where({{$.Dialect}}.Raw("? IN ?", {{$tAlias.UpSingular}}Columns.{{$pkAlias}}, {{$.Dialect}}.Select(
+			sm.Columns({{$.Dialect}}.Quote({{quote $pk}})),
+			sm.From({{$.Dialect}}.Quote("bob_tree")),
+		))),
+	)...)
+}
+
+// Ancestors starts a query for the {{$tAlias.UpPlural}} above the {{$tAlias.UpSingular}} in the tree,
+// found by following {{$tree.Parent}} with a recursive CTE
+func (o *{{$tAlias.UpSingular}}) Ancestors(ctx context.Context, exec bob.Executor, mods ...bob.Mod[*dialect.SelectQuery]) {{$tAlias.UpPlural}}Query {
+	seed := {{$.Dialect}}.Select(
+		sm.Columns({{$tAlias.UpSingular}}Columns.{{$parentAlias}}),
+		sm.From({{$tAlias.UpPlural}}.Name(ctx)),
+		sm.Where({{$tAlias.UpSingular}}Columns.{{$pkAlias}}.EQ({{$.Dialect}}.Arg(o.{{$pkAlias}}))),
+	)
+	step := {{$.Dialect}}.Select(
+		sm.Columns({{$tAlias.UpSingular}}Columns.{{$parentAlias}}),
+		sm.From({{$tAlias.UpPlural}}.Name(ctx)),
+		sm.InnerJoin({{$.Dialect}}.Quote("bob_tree")).On(
+			{{$tAlias.UpSingular}}Columns.{{$pkAlias}}.EQ({{$.Dialect}}.Quote("bob_tree", {{quote $pk}})),
+		),
+	)
+	// the queries of the UNION cannot be in parentheses in SQLite
+	tree := {{$.Dialect}}.RawQuery("? UNION ?", seed.Expression, step.Expression)
+
+	return {{$tAlias.UpPlural}}.Query(ctx, exec, append(mods,
+		sm.With("bob_tree", {{quote $pk}}).As(tree),
+		sm.Recursive(true),
+		sm.Where({{$.Dialect}}.Raw("? IN ?", {{$tAlias.UpSingular}}Columns.{{$pkAlias}}, {{$.Dialect}}.Select(
+			sm.Columns({{$.Dialect}}.Quote({{quote $pk}})),
+			sm.From({{$.Dialect}}.Quote("bob_tree")),
+		))),
+	)...)
+}
+{{- end}}
+
+{{if eq $tree.Strategy "path" -}}
+{{$.Importer.Import "github.com/stephenafamo/bob/orm"}}
+{{$pathAlias := $tAlias.Column $tree.Path}}
+// Descendants starts a query for the {{$tAlias.UpPlural}} below the {{$tAlias.UpSingular}} in the tree,
+// the rows whose {{$tree.Path}} starts with the {{$tree.Path}} of the {{$tAlias.UpSingular}}
+func (o *{{$tAlias.UpSingular}}) Descendants(ctx context.Context, exec bob.Executor, mods ...bob.Mod[*dialect.SelectQuery]) {{$tAlias.UpPlural}}Query {
+	pattern := orm.PathDescendantsPattern(o.{{$pathAlias}}, {{quote $tree.Separator}})
+
+	return {{$tAlias.UpPlural}}.Query(ctx, exec, append(mods,
+		sm.Where({{$tAlias.UpSingular}}Columns.{{$pathAlias}}.Like({{$.Dialect}}.Raw("? ESCAPE '!'", pattern))),
+	)...)
+}
+
+// Ancestors starts a query for the {{$tAlias.UpPlural}} above the {{$tAlias.UpSingular}} in the tree,
+// the rows whose {{$tree.Path}} is a prefix of the {{$tree.Path}} of the {{$tAlias.UpSingular}}
+func (o *{{$tAlias.UpSingular}}) Ancestors(ctx context.Context, exec bob.Executor, mods ...bob.Mod[*dialect.SelectQuery]) {{$tAlias.UpPlural}}Query {
+	paths := orm.PathAncestors(o.{{$pathAlias}}, {{quote $tree.Separator}})
+
+	return {{$tAlias.UpPlural}}.Query(ctx, exec, append(mods,
+		sm.Where({{$tAlias.UpSingular}}Columns.{{$pathAlias}}.InSlice(paths)),
+	)...)
+}
+{{- end}}
+
+{{if eq $tree.Strategy "closure" -}}
+{{$.Importer.Import (printf "github.com/stephenafamo/bob/dialect/%s/dm" $.Dialect)}}
+{{$.Importer.Import (printf "github.com/stephenafamo/bob/dialect/%s/im" $.Dialect)}}
+{{$cAlias := $.Aliases.Table $tree.ClosureTable}}
+{{$ancestorAlias := $cAlias.Column $tree.Ancestor}}
+{{$descendantAlias := $cAlias.Column $tree.Descendant}}
+{{$depthAlias := $cAlias.Column $tree.Depth}}
+// Descendants starts a query for the {{$tAlias.UpPlural}} below the {{$tAlias.UpSingular}} in the tree,
+// found in {{$tree.ClosureTable}}
+func (o *{{$tAlias.UpSingular}}) Descendants(ctx context.Context, exec bob.Executor, mods ...bob.Mod[*dialect.SelectQuery]) {{$tAlias.UpPlural}}Query {
+	return {{$tAlias.UpPlural}}.Query(ctx, exec, append(mods,
+		sm.Where({{$.Dialect}}.Raw("? IN ?", {{$tAlias.UpSingular}}Columns.{{$pkAlias}}, {{$.Dialect}}.Select(
+			sm.Columns({{$cAlias.UpSingular}}Columns.{{$descendantAlias}}),
+			sm.From({{$cAlias.UpPlural}}.Name(ctx)),
+			sm.Where({{$cAlias.UpSingular}}Columns.{{$ancestorAlias}}.EQ({{$.Dialect}}.Arg(o.{{$pkAlias}}))),
+			sm.Where({{$cAlias.UpSingular}}Columns.{{$depthAlias}}.GT({{$.Dialect}}.Arg(0))),
+		))),
+	)...)
+}
+
+// Ancestors starts a query for the {{$tAlias.UpPlural}} above the {{$tAlias.UpSingular}} in the tree,
+// found in {{$tree.ClosureTable}}
+func (o *{{$tAlias.UpSingular}}) Ancestors(ctx context.Context, exec bob.Executor, mods ...bob.Mod[*dialect.SelectQuery]) {{$tAlias.UpPlural}}Query {
+	return {{$tAlias.UpPlural}}.Query(ctx, exec, append(mods,
+		sm.Where({{$.Dialect}}.Raw("? IN ?", {{$tAlias.UpSingular}}Columns.{{$pkAlias}}, {{$.Dialect}}.Select(
+			sm.Columns({{$cAlias.UpSingular}}Columns.{{$ancestorAlias}}),
+			sm.From({{$cAlias.UpPlural}}.Name(ctx)),
+			sm.Where({{$cAlias.UpSingular}}Columns.{{$descendantAlias}}.EQ({{$.Dialect}}.Arg(o.{{$pkAlias}}))),
+			sm.Where({{$cAlias.UpSingular}}Columns.{{$depthAlias}}.GT({{$.Dialect}}.Arg(0))),
+		))),
+	)...)
+}
+
+// InsertIntoTree adds the rows of a new {{$tAlias.UpSingular}} to {{$tree.ClosureTable}},
+// under the parent or as a root if the parent is nil
+func (o *{{$tAlias.UpSingular}}) InsertIntoTree(ctx context.Context, exec bob.Executor, parent *{{$tAlias.UpSingular}}) error {
+	_, err := bob.Exec(ctx, exec, {{$.Dialect}}.Insert(
+		im.Into({{$cAlias.UpPlural}}.Name(ctx), {{quote $tree.Ancestor}}, {{quote $tree.Descendant}}, {{quote $tree.Depth}}),
+		im.Values({{$.Dialect}}.Arg(o.{{$pkAlias}}, o.{{$pkAlias}}, 0)),
+	))
+	if err != nil || parent == nil {
+		return err
+	}
+
+	return o.linkTree(ctx, exec, parent)
+}
+
+// MoveInTree moves the {{$tAlias.UpSingular}} and its descendants under the parent,
+// or makes it a root if the parent is nil, by replacing their rows in {{$tree.ClosureTable}}.
+// Run it in a transaction
+func (o *{{$tAlias.UpSingular}}) MoveInTree(ctx context.Context, exec bob.Executor, parent *{{$tAlias.UpSingular}}) error {
+	// MySQL cannot select from the table it deletes from, unless it is
+	// in a derived table that is materialized, which DISTINCT forces
+	subtree := {{$.Dialect}}.Select(
+		sm.Columns({{$.Dialect}}.Quote({{quote $tree.Descendant}})),
+		sm.From({{$.Dialect}}.Select(
+			sm.Distinct(),
+			sm.Columns({{$cAlias.UpSingular}}Columns.{{$descendantAlias}}),
+			sm.From({{$cAlias.UpPlural}}.Name(ctx)),
+			sm.Where({{$cAlias.UpSingular}}Columns.{{$ancestorAlias}}.EQ({{$.Dialect}}.Arg(o.{{$pkAlias}}))),
+		)).As("bob_subtree"),
+	)
+
+	// remove the links from the old ancestors to the subtree
+	_, err := bob.Exec(ctx, exec, {{$.Dialect}}.Delete(
+		dm.From({{$cAlias.UpPlural}}.Name(ctx)),
+		dm.Where({{$.Dialect}}.Raw("? IN ?", {{$cAlias.UpSingular}}Columns.{{$descendantAlias}}, subtree)),
+		dm.Where({{$.Dialect}}.Raw("? NOT IN ?", {{$cAlias.UpSingular}}Columns.{{$ancestorAlias}}, subtree)),
+	))
+	if err != nil || parent == nil {
+		return err
+	}
+
+	return o.linkTree(ctx, exec, parent)
+}
+
+// linkTree links the ancestors of the parent and the parent itself
+// to the {{$tAlias.UpSingular}} and its descendants
+func (o *{{$tAlias.UpSingular}}) linkTree(ctx context.Context, exec bob.Executor, parent *{{$tAlias.UpSingular}}) error {
+	_, err := bob.Exec(ctx, exec, {{$.Dialect}}.Insert(
+		im.Into({{$cAlias.UpPlural}}.Name(ctx), {{quote $tree.Ancestor}}, {{quote $tree.Descendant}}, {{quote $tree.Depth}}),
+		im.Query({{$.Dialect}}.Select(
+			sm.Columns(
+				{{$.Dialect}}.Quote("bob_super", {{quote $tree.Ancestor}}),
+				{{$.Dialect}}.Quote("bob_sub", {{quote $tree.Descendant}}),
+				{{$.Dialect}}.Raw("? + ? + 1", {{$.Dialect}}.Quote("bob_super", {{quote $tree.Depth}}), {{$.Dialect}}.Quote("bob_sub", {{quote $tree.Depth}})),
+			),
+			sm.From({{$cAlias.UpPlural}}.Name(ctx)).As("bob_super"),
+			sm.CrossJoin({{$cAlias.UpPlural}}.Name(ctx).As("bob_sub")),
+			sm.Where({{$.Dialect}}.Quote("bob_super", {{quote $tree.Descendant}}).EQ({{$.Dialect}}.Arg(parent.{{$pkAlias}}))),
+			sm.Where({{$.Dialect}}.Quote("bob_sub", {{quote $tree.Ancestor}}).EQ({{$.Dialect}}.Arg(o.{{$pkAlias}}))),
+		)),
+	))
+	return err
+}
+{{- end}}
+{{- end}}
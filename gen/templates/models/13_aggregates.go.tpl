@@ -0,0 +1,74 @@
+{{if .Aggregates -}}
+{{$table := .Table}}
+{{$tAlias := .Aliases.Table $table.Key -}}
+{{$.Importer.Import "context"}}
+{{$.Importer.Import "github.com/stephenafamo/bob"}}
+{{$.Importer.Import "github.com/stephenafamo/bob/orm"}}
+{{$.Importer.Import "github.com/stephenafamo/scan"}}
+{{$.Importer.Import (printf "github.com/stephenafamo/bob/dialect/%s" $.Dialect)}}
+{{$.Importer.Import (printf "github.com/stephenafamo/bob/dialect/%s/dialect" $.Dialect)}}
+{{$.Importer.Import (printf "github.com/stephenafamo/bob/dialect/%s/sm" $.Dialect)}}
+// {{$tAlias.UpSingular}}TypedColumns are the columns of {{$tAlias.UpSingular}} with the types of
+// their values, for {{$tAlias.UpPlural}}CountBy, {{$tAlias.UpPlural}}GroupedCount and {{$tAlias.UpPlural}}SumOf
+var {{$tAlias.UpSingular}}TypedColumns = struct {
+	{{range $column := $table.Columns -}}
+	{{- $colAlias := $tAlias.Column $column.Name -}}
+	{{$colAlias}} orm.TypedColumn[{{nullType $.Importer $column}}]
+	{{end -}}
+}{
+	{{range $column := $table.Columns -}}
+	{{- $colAlias := $tAlias.Column $column.Name -}}
+	{{$colAlias}}: orm.TypedColumn[{{nullType $.Importer $column}}]{Expression: {{$tAlias.UpSingular}}Columns.{{$colAlias}}},
+	{{end -}}
+}
+
+// {{$tAlias.UpPlural}}CountBy returns the number of {{$tAlias.UpPlural}} for each value of the column.
+// The mods filter the rows that are counted
+func {{$tAlias.UpPlural}}CountBy[T comparable](ctx context.Context, exec bob.Executor, column orm.TypedColumn[T], mods ...bob.Mod[*dialect.SelectQuery]) (map[T]int64, error) {
+	groups, err := {{$tAlias.UpPlural}}GroupedCount(ctx, exec, column, mods...)
+	if err != nil {
+		return nil, err
+	}
+
+	return orm.GroupCountMap(groups), nil
+}
+
+// {{$tAlias.UpPlural}}GroupedCount returns the values of the column with the number of {{$tAlias.UpPlural}}
+// that have them, the most common first. The mods filter the rows that are counted,
+// and a limit returns the most common values only
+func {{$tAlias.UpPlural}}GroupedCount[T any](ctx context.Context, exec bob.Executor, column orm.TypedColumn[T], mods ...bob.Mod[*dialect.SelectQuery]) ([]orm.GroupCount[T], error) {
+	q := {{$tAlias.UpPlural}}.Query(ctx, exec, mods...)
+	q.Expression.SelectList.Columns = []any{
+		{{$.Dialect}}.Raw("? AS ?", column, {{$.Dialect}}.Quote("value")),
+		{{$.Dialect}}.Raw("count(*) AS ?", {{$.Dialect}}.Quote("count")),
+	}
+	q.Apply(
+		sm.GroupBy(column),
+		sm.OrderBy({{$.Dialect}}.Raw("count(*)")).Desc(),
+		sm.OrderBy(column),
+	)
+
+	ctx, err := {{$tAlias.UpPlural}}.SelectQueryHooks.Do(ctx, exec, q.Expression)
+	if err != nil {
+		return nil, err
+	}
+
+	return bob.All(ctx, exec, q, scan.StructMapper[orm.GroupCount[T]]())
+}
+
+// {{$tAlias.UpPlural}}SumOf returns the sum of the column over the {{$tAlias.UpPlural}},
+// or zero if there are none. The mods filter the rows that are summed
+func {{$tAlias.UpPlural}}SumOf[T any](ctx context.Context, exec bob.Executor, column orm.TypedColumn[T], mods ...bob.Mod[*dialect.SelectQuery]) (T, error) {
+	q := {{$tAlias.UpPlural}}.Query(ctx, exec, mods...)
+	q.Expression.SelectList.Columns = []any{
+		{{$.Dialect}}.Raw("COALESCE(SUM(?), 0)", column),
+	}
+
+	ctx, err := {{$tAlias.UpPlural}}.SelectQueryHooks.Do(ctx, exec, q.Expression)
+	if err != nil {
+		return *new(T), err
+	}
+
+	return bob.One(ctx, exec, q, scan.SingleColumnMapper[T])
+}
+{{- end}}
@@ -0,0 +1,28 @@
+{{- range $embed := $.EmbedTypes}}
+// {{$embed.Name}} holds a group of columns that share a prefix
+type {{$embed.Name}} struct {
+	{{- range $field := $embed.Fields -}}
+	{{- $column := $field.Column -}}
+	{{- $.Importer.ImportList (index $.Types $column.Type).Imports -}}
+	{{- $tagName := columnTagName $.StructTagCasing $column.Name $field.Name}}
+	{{$field.Name}} {{nullType $.Importer $column}} `db:"{{$column.Name}}" {{generateTags $.Tags $tagName | trim}}`
+	{{- end}}
+}
+
+// {{$embed.Name}}Setter is used to set the columns of {{$embed.Name}}
+// Generated columns are not included
+type {{$embed.Name}}Setter struct {
+	{{- range $field := $embed.Fields -}}
+	{{- $column := $field.Column -}}
+	{{- if $column.Generated}}{{continue}}{{end -}}
+	{{- if $column.Nullable -}}
+		{{- $.Importer.Import "github.com/aarondl/opt/omitnull"}}
+	{{$field.Name}} omitnull.Val[{{$column.Type}}] `db:"{{$column.Name}}"`
+	{{- else -}}
+		{{- $.Importer.Import "github.com/aarondl/opt/omit"}}
+	{{$field.Name}} omit.Val[{{$column.Type}}] `db:"{{$column.Name}}"`
+	{{- end -}}
+	{{- end}}
+}
+
+{{end -}}
@@ -10,6 +10,40 @@ var TableNames = struct {
 	{{end -}}
 }
 
+// TableDependencies maps each table to the tables its foreign keys reference.
+// Tables outside the default schema are prefixed with the schema.
+// It is used to order the changes of a bob.UnitOfWork
+var TableDependencies = map[string][]string{
+	{{range $table := .Tables -}}
+	{{- $deps := list -}}
+	{{- range $fk := $table.Constraints.Foreign}}{{$deps = append $deps $fk.ForeignTable}}{{end -}}
+	{{quote $table.Key}}: { {{- range $dep := uniq $deps}}{{quote $dep}}, {{end -}} },
+	{{end -}}
+}
+
+{{$.Importer.Import "github.com/stephenafamo/bob/orm"}}
+// TablesMeta is the structure of all the tables and views, with the unique
+// constraints, indexes and foreign keys, for code that works with any model
+var TablesMeta = orm.SchemaMeta{
+	Tables: []orm.TableMeta{
+		{{range $table := .Tables -}}
+		{{$tAlias := $.Aliases.Table $table.Key -}}
+		{{$tAlias.UpPlural}}Meta,
+		{{end -}}
+	},
+}
+
+{{$.Importer.Import "github.com/stephenafamo/bob"}}
+// foreignKeyRefs are the foreign keys of all the tables, which are
+// followed by bob.DeleteCascade
+var foreignKeyRefs = []bob.ForeignKeyRef{
+	{{range $table := .Tables -}}
+	{{range $table.Constraints.Foreign -}}
+	{Table: {{quote $table.Key}}, Columns: []string{ {{- range .Columns}}{{quote .}}, {{end -}} }, ForeignTable: {{quote .ForeignTable}}, ForeignColumns: []string{ {{- range .ForeignColumns}}{{quote .}}, {{end -}} }},
+	{{end -}}
+	{{end -}}
+}
+
 var ColumnNames = struct {
 	{{range $table := .Tables -}}
 	{{$tAlias := $.Aliases.Table $table.Key -}}
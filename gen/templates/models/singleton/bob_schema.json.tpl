@@ -0,0 +1,3 @@
+{{- if $.ModelSchema -}}
+{{modelSchema $.ModelSchema $.Tags $.StructTagCasing $.TagIgnore $.Tables $.Aliases $.Enums}}
+{{- end -}}
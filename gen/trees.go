@@ -0,0 +1,114 @@
+package gen
+
+import (
+	"fmt"
+
+	"github.com/stephenafamo/bob/gen/drivers"
+)
+
+// The strategies to store a tree in a table
+const (
+	// TreeRecursive follows a parent column with a recursive CTE
+	TreeRecursive = "recursive"
+	// TreePath matches a column with the materialized path of each row with LIKE
+	TreePath = "path"
+	// TreeClosure uses a closure table with a row for every ancestor of every row
+	TreeClosure = "closure"
+)
+
+// Trees configures the tree helpers generated for a table, keyed by table
+type Trees map[string]Tree
+
+// Tree is how a table stores a tree. The generated models get Descendants and Ancestors
+// query methods, and the methods to maintain the closure table with the closure strategy
+type Tree struct {
+	// recursive, path or closure
+	Strategy string `yaml:"strategy"`
+	// The column that references the parent row, for the recursive strategy
+	Parent string `yaml:"parent"`
+	// The column with the materialized path, for the path strategy.
+	// The path is the primary keys of the row and its ancestors, the root first
+	Path string `yaml:"path"`
+	// The separator of the keys in the path. Defaults to "/"
+	Separator string `yaml:"separator"`
+	// The closure table, for the closure strategy. Every row has a row in the
+	// closure table for each of its ancestors and one for itself with a depth of 0
+	ClosureTable string `yaml:"closure_table"`
+	// The columns of the closure table. Default to ancestor, descendant and depth
+	Ancestor   string `yaml:"ancestor"`
+	Descendant string `yaml:"descendant"`
+	Depth      string `yaml:"depth"`
+}
+
+// processTrees validates the trees config against the tables
+// and returns it with the defaults set
+func processTrees(trees Trees, tables []drivers.Table) (Trees, error) {
+	processed := make(Trees, len(trees))
+
+	for key, tree := range trees {
+		t, ok := findTable(tables, key)
+		if !ok {
+			return nil, fmt.Errorf("tree: table %s does not exist", key)
+		}
+		if t.Constraints.Primary == nil || len(t.Constraints.Primary.Columns) != 1 {
+			return nil, fmt.Errorf("tree %s: the table must have a single column primary key", key)
+		}
+
+		switch tree.Strategy {
+		case TreeRecursive:
+			if _, ok := checkColumn(*t, tree.Parent); !ok {
+				return nil, fmt.Errorf("tree %s: parent column %q does not exist", key, tree.Parent)
+			}
+
+		case TreePath:
+			c, ok := checkColumn(*t, tree.Path)
+			if !ok {
+				return nil, fmt.Errorf("tree %s: path column %q does not exist", key, tree.Path)
+			}
+			if c.Type != "string" || c.Nullable {
+				return nil, fmt.Errorf("tree %s: path column %q must be a string that is not null", key, tree.Path)
+			}
+			if tree.Separator == "" {
+				tree.Separator = "/"
+			}
+
+		case TreeClosure:
+			closure, ok := findTable(tables, tree.ClosureTable)
+			if !ok {
+				return nil, fmt.Errorf("tree %s: closure table %q does not exist", key, tree.ClosureTable)
+			}
+			if tree.Ancestor == "" {
+				tree.Ancestor = "ancestor"
+			}
+			if tree.Descendant == "" {
+				tree.Descendant = "descendant"
+			}
+			if tree.Depth == "" {
+				tree.Depth = "depth"
+			}
+			for _, name := range []string{tree.Ancestor, tree.Descendant, tree.Depth} {
+				if _, ok := checkColumn(*closure, name); !ok {
+					return nil, fmt.Errorf("tree %s: column %q of the closure table does not exist", key, name)
+				}
+			}
+
+		default:
+			return nil, fmt.Errorf("tree %s: unknown strategy %q, must be %q, %q or %q", key, tree.Strategy, TreeRecursive, TreePath, TreeClosure)
+		}
+
+		processed[key] = tree
+	}
+
+	return processed, nil
+}
+
+// findTable returns a pointer to the table with the key, to change it in place
+func findTable(tables []drivers.Table, key string) (*drivers.Table, bool) {
+	for i := range tables {
+		if tables[i].Key == key {
+			return &tables[i], true
+		}
+	}
+
+	return nil, false
+}
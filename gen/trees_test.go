@@ -0,0 +1,75 @@
+package gen
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stephenafamo/bob/gen/drivers"
+)
+
+func TestProcessTrees(t *testing.T) {
+	tables := []drivers.Table{
+		{
+			Key: "categories",
+			Columns: []drivers.Column{
+				{Name: "id", Type: "int32"},
+				{Name: "parent_id", Type: "null.Val[int32]", Nullable: true},
+				{Name: "path", Type: "string"},
+				{Name: "label", Type: "null.Val[string]", Nullable: true},
+			},
+			Constraints: drivers.Constraints{
+				Primary: &drivers.PrimaryKey{Columns: []string{"id"}},
+			},
+		},
+		{
+			Key: "category_paths",
+			Columns: []drivers.Column{
+				{Name: "ancestor", Type: "int32"},
+				{Name: "descendant", Type: "int32"},
+				{Name: "depth", Type: "int32"},
+				{Name: "parent", Type: "int32"},
+			},
+		},
+	}
+
+	processed, err := processTrees(Trees{"categories": {Strategy: TreePath, Path: "path"}}, tables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(Tree{Strategy: TreePath, Path: "path", Separator: "/"}, processed["categories"]); diff != "" {
+		t.Fatal(diff)
+	}
+
+	processed, err = processTrees(Trees{"categories": {Strategy: TreeClosure, ClosureTable: "category_paths", Depth: "parent"}}, tables)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := Tree{
+		Strategy:     TreeClosure,
+		ClosureTable: "category_paths",
+		Ancestor:     "ancestor",
+		Descendant:   "descendant",
+		Depth:        "parent",
+	}
+	if diff := cmp.Diff(expected, processed["categories"]); diff != "" {
+		t.Fatal(diff)
+	}
+
+	errs := map[string]Trees{
+		"does not exist":           {"tags": {Strategy: TreeRecursive, Parent: "parent_id"}},
+		"single column primary":    {"category_paths": {Strategy: TreeRecursive, Parent: "parent"}},
+		"parent column":            {"categories": {Strategy: TreeRecursive, Parent: "parent"}},
+		"must be a string":         {"categories": {Strategy: TreePath, Path: "label"}},
+		"closure table":            {"categories": {Strategy: TreeClosure, ClosureTable: "category_tree"}},
+		"of the closure table":     {"categories": {Strategy: TreeClosure, ClosureTable: "category_paths", Ancestor: "root"}},
+		"unknown strategy \"set\"": {"categories": {Strategy: "set"}},
+	}
+
+	for msg, trees := range errs {
+		_, err := processTrees(trees, tables)
+		if err == nil || !strings.Contains(err.Error(), msg) {
+			t.Errorf("expected an error with %q, got %v", msg, err)
+		}
+	}
+}
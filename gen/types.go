@@ -35,6 +35,14 @@ func processTypeReplacements(types map[string]drivers.Type, replacements []Repla
 				if matchColumn(c, r.Match) {
 					didMatch = true
 
+					if r.NullType != "" {
+						t.Columns[j].NullType = r.NullType
+					}
+
+					if r.Replace == "" {
+						continue
+					}
+
 					if _, ok := types[r.Replace]; !ok && !isPrimitiveType(r.Replace) {
 						fmt.Printf("WARNING: No definition found for replacement: %q\n", r.Replace)
 					}
@@ -52,6 +60,35 @@ func processTypeReplacements(types map[string]drivers.Type, replacements []Repla
 	}
 }
 
+// processNullTypes sets the null type of every nullable column
+// that was not already set by a replacement
+func processNullTypes(defaultType string, tables []drivers.Table) error {
+	if defaultType == "" {
+		defaultType = NullTypeNull
+	}
+
+	for i := range tables {
+		for j := range tables[i].Columns {
+			c := &tables[i].Columns[j]
+			if !c.Nullable {
+				continue
+			}
+
+			if c.NullType == "" {
+				c.NullType = defaultType
+			}
+
+			switch c.NullType {
+			case NullTypeNull, NullTypePointer, NullTypeSQL:
+			default:
+				return fmt.Errorf("invalid null type %q for %s.%s", c.NullType, tables[i].Key, c.Name)
+			}
+		}
+	}
+
+	return nil
+}
+
 // matchColumn checks if a column 'c' matches specifiers in 'm'.
 // Anything defined in m is checked against a's values, the
 // match is a done using logical and (all specifiers must match).
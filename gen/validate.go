@@ -0,0 +1,330 @@
+package gen
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/stephenafamo/bob/gen/drivers"
+)
+
+// ColumnValidation is what the generated Validate method of a setter checks for a column
+type ColumnValidation struct {
+	Column drivers.Column
+	// The column has no default and cannot be null, so it must be set on insert
+	Required  bool
+	MaxLength int
+	Precision int
+	Scale     int
+	Checks    []CheckValidation
+}
+
+// CheckValidation is a check constraint that is translated to Go.
+// The Go expression tests the value of the column in a variable named val
+type CheckValidation struct {
+	Name       string
+	Expression string
+	Go         string
+}
+
+//nolint:gochecknoglobals
+var (
+	rgxCheckCast       = regexp.MustCompile(`::[a-z_]+( [a-z_]+)*(\(\d+(,\s*\d+)?\))?(\[\])?`)
+	rgxCheckIntroducer = regexp.MustCompile(`_[a-z0-9]+'`)
+	rgxCheckParens     = regexp.MustCompile(`(^|[^\w])\((\w+|-?\d+(?:\.\d+)?)\)`)
+	rgxCheckCompare    = regexp.MustCompile(`^(\w+)\s*(>=|<=|<>|!=|=|>|<)\s*(-?\d+(?:\.\d+)?|'(?:[^']|'')*')$`)
+	rgxCheckLength     = regexp.MustCompile(`(?i)^(char_length|character_length|length)\((\w+)\)\s*(>=|<=|<>|!=|=|>|<)\s*(\d+)$`)
+	rgxCheckIn         = regexp.MustCompile(`(?i)^(\w+)\s+(NOT\s+)?IN\s*\((.+)\)$`)
+	rgxCheckAny        = regexp.MustCompile(`(?i)^(\w+)\s*(=\s*ANY|<>\s*ALL)\s*\(\(?ARRAY\[(.+)\]\)?\)$`)
+	rgxCheckBetween    = regexp.MustCompile(`(?i)^(\w+)\s+(NOT\s+)?BETWEEN\s+(-?\d+(?:\.\d+)?|'(?:[^']|'')*')\s+AND\s+(-?\d+(?:\.\d+)?|'(?:[^']|'')*')$`)
+	rgxCheckAnd        = regexp.MustCompile(`(?i)\s+AND\s+`)
+)
+
+// validations returns the validations of the columns of the table that are in the setter.
+// Check constraints are translated if they test a single column of a number type with
+// comparisons, IN lists or BETWEEN, the length of a string column, or a string column of
+// Postgres with =, <> or IN lists. The others are left out
+func validations(dialect string, t drivers.Table) []ColumnValidation {
+	var vals []ColumnValidation
+	index := make(map[string]int)
+
+	for _, c := range t.Columns {
+		if c.Generated {
+			continue
+		}
+
+		v := ColumnValidation{
+			Column:    c,
+			Required:  c.Default == "" && !c.Nullable && !c.AutoIncr,
+			Precision: c.Precision,
+			Scale:     c.Scale,
+		}
+		if c.Type == "string" {
+			v.MaxLength = c.MaxLength
+		}
+
+		index[c.Name] = len(vals)
+		vals = append(vals, v)
+	}
+
+	for _, check := range t.Checks {
+		column, goExpr, ok := translateCheck(dialect, check.Expression, t)
+		if !ok {
+			continue
+		}
+
+		i, ok := index[column]
+		if !ok {
+			continue
+		}
+
+		vals[i].Checks = append(vals[i].Checks, CheckValidation{
+			Name:       check.Name,
+			Expression: normalizeCheck(check.Expression),
+			Go:         goExpr,
+		})
+	}
+
+	return vals
+}
+
+// normalizeCheck removes the casts, quotes and redundant parentheses
+// that databases add to the expressions of check constraints
+func normalizeCheck(expr string) string {
+	expr = rgxCheckCast.ReplaceAllString(expr, "")
+	expr = rgxCheckIntroducer.ReplaceAllString(expr, "'")
+	expr = strings.NewReplacer("`", "", `"`, "").Replace(expr)
+
+	for {
+		unwrapped := rgxCheckParens.ReplaceAllString(expr, "${1}${2}")
+		if unwrapped == expr {
+			break
+		}
+		expr = unwrapped
+	}
+
+	return trimParens(expr)
+}
+
+// trimParens removes the parentheses around the whole expression
+func trimParens(expr string) string {
+	expr = strings.TrimSpace(expr)
+
+	for strings.HasPrefix(expr, "(") && strings.HasSuffix(expr, ")") {
+		depth := 0
+		for i, r := range expr {
+			switch r {
+			case '(':
+				depth++
+			case ')':
+				depth--
+			}
+			if depth == 0 && i < len(expr)-1 {
+				return expr
+			}
+		}
+		expr = strings.TrimSpace(expr[1 : len(expr)-1])
+	}
+
+	return expr
+}
+
+// translateCheck translates a check constraint to a Go expression on the value of a column
+func translateCheck(dialect, expr string, t drivers.Table) (string, string, bool) {
+	expr = normalizeCheck(expr)
+
+	var column string
+	var parts []string
+
+	for _, part := range splitAnd(expr) {
+		col, goExpr, ok := translateCheckPart(dialect, trimParens(part), t)
+		if !ok || (column != "" && col != column) {
+			return "", "", false
+		}
+		column = col
+		parts = append(parts, goExpr)
+	}
+
+	if len(parts) == 0 {
+		return "", "", false
+	}
+
+	return column, strings.Join(parts, " && "), true
+}
+
+// splitAnd splits the expression on the ANDs outside of parentheses,
+// unless it is a single BETWEEN
+func splitAnd(expr string) []string {
+	if rgxCheckBetween.MatchString(expr) {
+		return []string{expr}
+	}
+
+	var parts []string
+	depth, start := 0, 0
+	for i := 0; i < len(expr); i++ {
+		switch expr[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case '\'':
+			if end := strings.IndexByte(expr[i+1:], '\''); end >= 0 {
+				i += end + 1
+			}
+		default:
+			if depth != 0 {
+				continue
+			}
+			if loc := rgxCheckAnd.FindStringIndex(expr[i:]); loc != nil && loc[0] == 0 {
+				parts = append(parts, expr[start:i])
+				i += loc[1] - 1
+				start = i + 1
+			}
+		}
+	}
+
+	return append(parts, expr[start:])
+}
+
+func translateCheckPart(dialect, expr string, t drivers.Table) (string, string, bool) {
+	if m := rgxCheckCompare.FindStringSubmatch(expr); m != nil {
+		c, ok := checkColumn(t, m[1])
+		if !ok {
+			return "", "", false
+		}
+		lit, ok := checkLiteral(dialect, c, m[3], m[2] == "=" || m[2] == "<>" || m[2] == "!=")
+		if !ok {
+			return "", "", false
+		}
+		return c.Name, "val " + goOperator(m[2]) + " " + lit, true
+	}
+
+	if m := rgxCheckLength.FindStringSubmatch(expr); m != nil {
+		c, ok := checkColumn(t, m[2])
+		if !ok || c.Type != "string" {
+			return "", "", false
+		}
+		length := "utf8.RuneCountInString(val)"
+		if dialect == "mysql" && strings.EqualFold(m[1], "length") {
+			// LENGTH counts bytes in MySQL
+			length = "len(val)"
+		}
+		return c.Name, length + " " + goOperator(m[3]) + " " + m[4], true
+	}
+
+	if m := rgxCheckBetween.FindStringSubmatch(expr); m != nil {
+		c, ok := checkColumn(t, m[1])
+		if !ok {
+			return "", "", false
+		}
+		low, lowOK := checkLiteral(dialect, c, m[3], false)
+		high, highOK := checkLiteral(dialect, c, m[4], false)
+		if !lowOK || !highOK {
+			return "", "", false
+		}
+		if m[2] != "" {
+			return c.Name, "(val < " + low + " || val > " + high + ")", true
+		}
+		return c.Name, "(val >= " + low + " && val <= " + high + ")", true
+	}
+
+	var name, list string
+	var not bool
+	if m := rgxCheckIn.FindStringSubmatch(expr); m != nil {
+		name, not, list = m[1], m[2] != "", m[3]
+	} else if m := rgxCheckAny.FindStringSubmatch(expr); m != nil {
+		name, not, list = m[1], strings.Contains(strings.ToUpper(m[2]), "ALL"), m[3]
+	} else {
+		return "", "", false
+	}
+
+	c, ok := checkColumn(t, name)
+	if !ok {
+		return "", "", false
+	}
+
+	operator, join := " == ", " || "
+	if not {
+		operator, join = " != ", " && "
+	}
+
+	var tests []string
+	for _, item := range splitList(list) {
+		lit, ok := checkLiteral(dialect, c, item, true)
+		if !ok {
+			return "", "", false
+		}
+		tests = append(tests, "val"+operator+lit)
+	}
+
+	return c.Name, "(" + strings.Join(tests, join) + ")", true
+}
+
+func checkColumn(t drivers.Table, name string) (drivers.Column, bool) {
+	for _, c := range t.Columns {
+		if c.Name == name && !c.Generated {
+			return c, true
+		}
+	}
+
+	return drivers.Column{}, false
+}
+
+// checkLiteral converts a literal of the check to a Go constant
+// that can be compared with the type of the column.
+// Strings are only compared for equality with Postgres, since the collations of MySQL
+// and SQLite can ignore case and trailing spaces, and no collation orders like Go
+func checkLiteral(dialect string, c drivers.Column, lit string, equality bool) (string, bool) {
+	lit = strings.TrimSpace(lit)
+
+	if strings.HasPrefix(lit, "'") {
+		if dialect != "psql" || !equality {
+			return "", false
+		}
+		if c.Type != "string" || len(lit) < 2 || !strings.HasSuffix(lit, "'") {
+			return "", false
+		}
+		return strconv.Quote(strings.ReplaceAll(lit[1:len(lit)-1], "''", "'")), true
+	}
+
+	if _, err := strconv.ParseFloat(lit, 64); err != nil {
+		return "", false
+	}
+
+	switch c.Type {
+	case "float32", "float64":
+		return lit, true
+	case "int", "int8", "int16", "int32", "int64", "uint", "uint8", "uint16", "uint32", "uint64":
+		return lit, !strings.Contains(lit, ".") && !(strings.HasPrefix(c.Type, "uint") && strings.HasPrefix(lit, "-"))
+	default:
+		return "", false
+	}
+}
+
+// splitList splits a list of literals on the commas outside of quotes
+func splitList(list string) []string {
+	var items []string
+	start, quoted := 0, false
+	for i, r := range list {
+		switch {
+		case r == '\'':
+			quoted = !quoted
+		case r == ',' && !quoted:
+			items = append(items, list[start:i])
+			start = i + 1
+		}
+	}
+
+	return append(items, list[start:])
+}
+
+func goOperator(op string) string {
+	switch op {
+	case "=":
+		return "=="
+	case "<>":
+		return "!="
+	default:
+		return op
+	}
+}
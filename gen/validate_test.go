@@ -0,0 +1,92 @@
+package gen
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stephenafamo/bob/gen/drivers"
+)
+
+func TestTranslateCheck(t *testing.T) {
+	table := drivers.Table{
+		Key: "orders",
+		Columns: []drivers.Column{
+			{Name: "quantity", Type: "int32"},
+			{Name: "discount", Type: "float64"},
+			{Name: "status", Type: "string"},
+			{Name: "note", Type: "string"},
+			{Name: "total", Type: "decimal.Decimal"},
+			{Name: "starts_at", Type: "time.Time"},
+			{Name: "ends_at", Type: "time.Time"},
+		},
+	}
+
+	cases := []struct {
+		dialect, expr, column, goExpr string
+	}{
+		{"psql", "(quantity >= 0)", "quantity", "val >= 0"},
+		{"psql", "((discount > (0)::double precision) AND (discount <= (0.5)::double precision))", "discount", "val > 0 && val <= 0.5"},
+		{"psql", "((status)::text = ANY ((ARRAY['draft'::character varying, 'it''s'::character varying])::text[]))", "status", `(val == "draft" || val == "it's")`},
+		{"psql", "((status)::text <> ALL ((ARRAY['void'::character varying])::text[]))", "status", `(val != "void")`},
+		{"psql", "(char_length((note)::text) <= 50)", "note", "utf8.RuneCountInString(val) <= 50"},
+		{"mysql", "(`quantity` between 1 and 100)", "quantity", "(val >= 1 && val <= 100)"},
+		{"mysql", "(length(`note`) < 100)", "note", "len(val) < 100"},
+		{"sqlite", "length(note) < 100", "note", "utf8.RuneCountInString(val) < 100"},
+	}
+
+	for _, c := range cases {
+		column, goExpr, ok := translateCheck(c.dialect, c.expr, table)
+		if !ok {
+			t.Errorf("could not translate %s", c.expr)
+			continue
+		}
+		if column != c.column || goExpr != c.goExpr {
+			t.Errorf("%s: got %s %s, want %s %s", c.expr, column, goExpr, c.column, c.goExpr)
+		}
+	}
+
+	for _, c := range []struct{ dialect, expr string }{
+		{"psql", "(starts_at < ends_at)"},
+		{"psql", "(total > (0)::numeric)"},
+		{"psql", "(quantity > 0.5)"},
+		{"psql", "((quantity > 0) OR (discount > 0))"},
+		{"psql", "(lower(status) = 'draft')"},
+		// strings are not ordered like Go by the collations
+		{"psql", "(status > 'a'::text)"},
+		{"psql", "(status BETWEEN 'a'::text AND 'm'::text)"},
+		// the collations of MySQL and SQLite can ignore case and trailing spaces
+		{"mysql", "(`status` in (_utf8mb4'draft',_utf8mb4'sent'))"},
+		{"mysql", "(`note` <> _utf8mb4'')"},
+		{"sqlite", "status = 'draft'"},
+	} {
+		if column, goExpr, ok := translateCheck(c.dialect, c.expr, table); ok {
+			t.Errorf("%s should not be translated, got %s %s", c.expr, column, goExpr)
+		}
+	}
+}
+
+func TestValidations(t *testing.T) {
+	table := drivers.Table{
+		Columns: []drivers.Column{
+			{Name: "id", Type: "int64", Default: "IDENTITY"},
+			{Name: "name", Type: "string", MaxLength: 100},
+			{Name: "bio", Type: "string", Nullable: true, Default: "NULL"},
+			{Name: "price", Type: "decimal.Decimal", Precision: 10, Scale: 2, Default: "0"},
+			{Name: "slug", Type: "string", Generated: true, Default: "GENERATED"},
+		},
+		Checks: []drivers.Check{{Name: "name_not_empty", Expression: "(name <> ''::text)"}},
+	}
+
+	want := []ColumnValidation{
+		{Column: table.Columns[0]},
+		{Column: table.Columns[1], Required: true, MaxLength: 100, Checks: []CheckValidation{
+			{Name: "name_not_empty", Expression: "name <> ''", Go: `val != ""`},
+		}},
+		{Column: table.Columns[2]},
+		{Column: table.Columns[3], Precision: 10, Scale: 2},
+	}
+
+	if diff := cmp.Diff(want, validations("psql", table)); diff != "" {
+		t.Fatal(diff)
+	}
+}
@@ -0,0 +1,29 @@
+package bob
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Health is the result of a health check
+type Health struct {
+	// The version of the database server
+	Version string
+	// The time the probe took, including the round trip to the server
+	Latency time.Duration
+}
+
+// HealthCheck runs a query that returns the version of the database server
+// and measures how long it takes, e.g. for readiness endpoints.
+// Each dialect has a HealthCheck function with the query for that database
+func HealthCheck(ctx context.Context, exec Executor, versionQuery string) (Health, error) {
+	start := time.Now()
+
+	version, err := queryVersion(ctx, exec, versionQuery)
+	if err != nil {
+		return Health{}, fmt.Errorf("health check: %w", err)
+	}
+
+	return Health{Version: version, Latency: time.Since(start)}, nil
+}
@@ -0,0 +1,181 @@
+package bob
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/stephenafamo/bob/internal/mappings"
+)
+
+// StructInsertOption configures the columns of [InsertFromStructs]
+type StructInsertOption func(*structInsert)
+
+type structInsert struct {
+	columns  []string
+	skipAuto bool
+	skipZero bool
+}
+
+// InsertColumns only inserts the given columns, in the given order
+func InsertColumns(columns ...string) StructInsertOption {
+	return func(s *structInsert) {
+		s.columns = columns
+	}
+}
+
+// SkipAutoColumns leaves out the columns tagged "autoincr" or "generated",
+// e.g. `db:"id,pk,autoincr"`, so the database sets them
+func SkipAutoColumns() StructInsertOption {
+	return func(s *structInsert) {
+		s.skipAuto = true
+	}
+}
+
+// SkipZeroColumns leaves out the columns that have the zero value in every row,
+// so the database uses their defaults.
+// Since all rows have the same columns, a zero value is inserted as it is
+// if the column has a value in any other row
+func SkipZeroColumns() StructInsertOption {
+	return func(s *structInsert) {
+		s.skipZero = true
+	}
+}
+
+// InsertFromStructs builds a multi-row INSERT from a slice of structs or
+// pointers to structs, for projects that use the query builder without
+// generated models. The columns are mapped with the "db" tags as in scanning,
+// and fields tagged "-" or unexported are left out.
+// The table can include the schema, e.g. "public.users".
+//
+// It is dialect agnostic, see the InsertFromStructs function of each dialect
+// to build a query that can be executed
+func InsertFromStructs[T any](table string, rows []T, opts ...StructInsertOption) Expression {
+	var s structInsert
+	for _, o := range opts {
+		o(&s)
+	}
+
+	return ExpressionFunc(func(w io.Writer, d Dialect, start int) ([]any, error) {
+		if len(rows) == 0 {
+			return nil, errors.New("insert from structs: no rows")
+		}
+
+		values := make([]reflect.Value, len(rows))
+		for i, row := range rows {
+			val := reflect.ValueOf(row)
+			for val.Kind() == reflect.Pointer {
+				if val.IsNil() {
+					return nil, fmt.Errorf("insert from structs: row %d is nil", i)
+				}
+				val = val.Elem()
+			}
+			if val.Kind() != reflect.Struct {
+				return nil, fmt.Errorf("insert from structs: %s is not a struct", val.Type())
+			}
+			if i > 0 && val.Type() != values[0].Type() {
+				return nil, fmt.Errorf("insert from structs: row %d is a %s, not a %s", i, val.Type(), values[0].Type())
+			}
+			values[i] = val
+		}
+
+		fields, err := s.fields(values)
+		if err != nil {
+			return nil, err
+		}
+
+		w.Write([]byte("INSERT INTO "))
+		for i, part := range strings.Split(table, ".") {
+			if i > 0 {
+				w.Write([]byte("."))
+			}
+			d.WriteQuoted(w, part)
+		}
+
+		w.Write([]byte(" ("))
+		for i, f := range fields {
+			if i > 0 {
+				w.Write([]byte(", "))
+			}
+			d.WriteQuoted(w, f.name)
+		}
+		w.Write([]byte(")\nVALUES "))
+
+		args := make([]any, 0, len(rows)*len(fields))
+		for i, val := range values {
+			if i > 0 {
+				w.Write([]byte(", "))
+			}
+
+			w.Write([]byte(openPar))
+			for j, f := range fields {
+				if j > 0 {
+					w.Write([]byte(", "))
+				}
+				d.WriteArg(w, start+len(args))
+				args = append(args, val.Field(f.index).Interface())
+			}
+			w.Write([]byte(closePar))
+		}
+
+		return args, nil
+	})
+}
+
+type structField struct {
+	name  string
+	index int
+}
+
+// fields returns the struct fields to insert
+func (s structInsert) fields(values []reflect.Value) ([]structField, error) {
+	typ := values[0].Type()
+	m := mappings.GetMappings(typ)
+
+	index := make(map[string]int, len(m.All))
+	var fields []structField
+	for i, name := range m.All {
+		if name == "" {
+			continue
+		}
+
+		if s.skipAuto && (m.AutoIncrement[i] != "" || m.Generated[i] != "") {
+			continue
+		}
+
+		index[name] = i
+		fields = append(fields, structField{name: name, index: i})
+	}
+
+	if s.columns != nil {
+		fields = fields[:0]
+		for _, name := range s.columns {
+			i, ok := index[name]
+			if !ok {
+				return nil, fmt.Errorf("insert from structs: %s has no column %q", typ, name)
+			}
+			fields = append(fields, structField{name: name, index: i})
+		}
+	}
+
+	if s.skipZero {
+		kept := fields[:0]
+		for _, f := range fields {
+			for _, val := range values {
+				if !val.Field(f.index).IsZero() {
+					kept = append(kept, f)
+					break
+				}
+			}
+		}
+		fields = kept
+	}
+
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("insert from structs: no columns to insert for %s", typ)
+	}
+
+	return fields, nil
+}
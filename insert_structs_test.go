@@ -0,0 +1,95 @@
+package bob
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type structInsertUser struct {
+	ID         int    `db:"id,pk,autoincr"`
+	Name       string `db:"name"`
+	Email      string
+	Nickname   string `db:"nickname"`
+	Ignored    string `db:"-"`
+	unexported string
+}
+
+func TestInsertFromStructs(t *testing.T) {
+	users := []structInsertUser{
+		{ID: 1, Name: "Alice", Email: "alice@example.com"},
+		{ID: 2, Name: "Bob", Email: "bob@example.com"},
+	}
+
+	tests := map[string]struct {
+		expression   Expression
+		expectedSQL  string
+		expectedArgs []any
+		expectedErr  string
+	}{
+		"all columns": {
+			expression: InsertFromStructs("users", users),
+			expectedSQL: `INSERT INTO "users" ("id", "name", "email", "nickname")
+VALUES ($1, $2, $3, $4), ($5, $6, $7, $8)`,
+			expectedArgs: []any{1, "Alice", "alice@example.com", "", 2, "Bob", "bob@example.com", ""},
+		},
+		"pointers with schema": {
+			expression: InsertFromStructs("public.users", []*structInsertUser{&users[0]}, SkipAutoColumns()),
+			expectedSQL: `INSERT INTO "public"."users" ("name", "email", "nickname")
+VALUES ($1, $2, $3)`,
+			expectedArgs: []any{"Alice", "alice@example.com", ""},
+		},
+		"zero columns": {
+			expression: InsertFromStructs("users", users, SkipAutoColumns(), SkipZeroColumns()),
+			expectedSQL: `INSERT INTO "users" ("name", "email")
+VALUES ($1, $2), ($3, $4)`,
+			expectedArgs: []any{"Alice", "alice@example.com", "Bob", "bob@example.com"},
+		},
+		"given columns": {
+			expression: InsertFromStructs("users", users, InsertColumns("email", "name")),
+			expectedSQL: `INSERT INTO "users" ("email", "name")
+VALUES ($1, $2), ($3, $4)`,
+			expectedArgs: []any{"alice@example.com", "Alice", "bob@example.com", "Bob"},
+		},
+		"unknown column": {
+			expression:  InsertFromStructs("users", users, InsertColumns("ignored")),
+			expectedErr: `has no column "ignored"`,
+		},
+		"no rows": {
+			expression:  InsertFromStructs("users", []structInsertUser{}),
+			expectedErr: "no rows",
+		},
+		"nil row": {
+			expression:  InsertFromStructs("users", []*structInsertUser{nil}),
+			expectedErr: "row 0 is nil",
+		},
+		"not a struct": {
+			expression:  InsertFromStructs("users", []int{1}),
+			expectedErr: "int is not a struct",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var sb strings.Builder
+			args, err := tc.expression.WriteSQL(&sb, d, 1)
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if sb.String() != tc.expectedSQL {
+				t.Fatalf("wrong SQL\nexpected: %s\n     got: %s", tc.expectedSQL, sb.String())
+			}
+
+			if !reflect.DeepEqual(args, tc.expectedArgs) {
+				t.Fatalf("wrong args\nexpected: %#v\n     got: %#v", tc.expectedArgs, args)
+			}
+		})
+	}
+}
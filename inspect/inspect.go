@@ -0,0 +1,151 @@
+// Package inspect reads the structure of a database at runtime, with the same
+// drivers that bobgen uses to generate the models, e.g. to build admin tools
+// or to compare the database with what an application expects
+package inspect
+
+import (
+	"context"
+
+	"github.com/lib/pq"
+	"github.com/stephenafamo/bob/gen/drivers"
+
+	mysqlDriver "github.com/stephenafamo/bob/gen/bobgen-mysql/driver"
+	psqlDriver "github.com/stephenafamo/bob/gen/bobgen-psql/driver"
+	sqliteDriver "github.com/stephenafamo/bob/gen/bobgen-sqlite/driver"
+)
+
+type (
+	// Constraint is a primary key or unique constraint
+	Constraint = drivers.Constraint
+	// ForeignKey is a foreign key constraint
+	ForeignKey = drivers.ForeignKey
+	// Index is an index of a table, other than the index of the primary key
+	Index = drivers.Index
+)
+
+// Schema is the structure of a database
+type Schema struct {
+	// The dialect of the database, psql, mysql or sqlite
+	Dialect string
+	Tables  []Table
+}
+
+// Table returns the table with the key, which includes the schema
+// if it is not the default schema, e.g. "users" or "audit.logins"
+func (s Schema) Table(key string) (Table, bool) {
+	for _, t := range s.Tables {
+		if t.Key == key {
+			return t, true
+		}
+	}
+
+	return Table{}, false
+}
+
+// Table is a table or a view
+type Table struct {
+	// The name of the table, with the schema if it is not the default schema
+	Key string
+	// The schema of the table, empty for the default schema
+	Schema  string
+	Name    string
+	Comment string
+	Columns []Column
+	// The primary key, nil for views and tables without one
+	PrimaryKey  *Constraint
+	ForeignKeys []ForeignKey
+	Uniques     []Constraint
+	Indexes     []Index
+}
+
+// Column returns the column with the name
+func (t Table) Column(name string) (Column, bool) {
+	for _, c := range t.Columns {
+		if c.Name == name {
+			return c, true
+		}
+	}
+
+	return Column{}, false
+}
+
+// Column is a column of a table
+type Column struct {
+	Name string
+	// The type in the database, e.g. INTEGER or character varying
+	DBType string
+	// The default as the driver reports it. Columns without a default can have
+	// a marker such as NULL, IDENTITY or auto_increment
+	Default   string
+	Comment   string
+	Nullable  bool
+	Generated bool
+	AutoIncr  bool
+}
+
+// Postgres reads the tables and views of the schemas, the public schema by default.
+// The keys of the tables in the first schema do not include the schema
+func Postgres(ctx context.Context, dsn string, schemas ...string) (Schema, error) {
+	return Driver(ctx, psqlDriver.New(psqlDriver.Config{
+		Dsn:     dsn,
+		Schemas: pq.StringArray(schemas),
+	}))
+}
+
+// MySQL reads the tables and views of the database of the DSN
+func MySQL(ctx context.Context, dsn string) (Schema, error) {
+	return Driver(ctx, mysqlDriver.New(mysqlDriver.Config{Dsn: dsn}))
+}
+
+// SQLite reads the tables and views of the database, and of the databases
+// attached to it, keyed by schema name
+func SQLite(ctx context.Context, dsn string, attach map[string]string) (Schema, error) {
+	return Driver(ctx, sqliteDriver.New(sqliteDriver.Config{DSN: dsn, Attach: attach}))
+}
+
+// Driver reads the schema with a bobgen driver. The Only and Except settings
+// of the driver decide which tables are read
+func Driver[T any](ctx context.Context, d drivers.Interface[T]) (Schema, error) {
+	info, err := d.Assemble(ctx)
+	if err != nil {
+		return Schema{}, err
+	}
+
+	s := Schema{
+		Dialect: d.Dialect(),
+		Tables:  make([]Table, len(info.Tables)),
+	}
+
+	for i, t := range info.Tables {
+		s.Tables[i] = table(t)
+	}
+
+	return s, nil
+}
+
+func table(t drivers.Table) Table {
+	columns := make([]Column, len(t.Columns))
+	for i, c := range t.Columns {
+		columns[i] = Column{
+			Name:      c.Name,
+			DBType:    c.DBType,
+			Default:   c.Default,
+			Comment:   c.Comment,
+			Nullable:  c.Nullable,
+			Generated: c.Generated,
+			AutoIncr:  c.AutoIncr,
+		}
+	}
+
+	return Table{
+		Key:         t.Key,
+		Schema:      t.Schema,
+		Name:        t.Name,
+		Comment:     t.Comment,
+		Columns:     columns,
+		PrimaryKey:  t.Constraints.Primary,
+		ForeignKeys: t.Constraints.Foreign,
+		Uniques:     t.Constraints.Uniques,
+		Indexes:     t.Indexes,
+	}
+}
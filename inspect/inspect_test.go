@@ -0,0 +1,75 @@
+package inspect
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestSQLite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "inspect.db")
+
+	db, err := sql.Open("sqlite", path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	_, err = db.Exec(`
+		CREATE TABLE users (
+			id INTEGER PRIMARY KEY,
+			email TEXT NOT NULL UNIQUE,
+			name TEXT
+		);
+		CREATE TABLE posts (
+			id INTEGER PRIMARY KEY,
+			user_id INTEGER NOT NULL REFERENCES users (id),
+			title TEXT NOT NULL DEFAULT ''
+		);
+		CREATE INDEX posts_user_title ON posts (user_id, title);
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	schema, err := SQLite(context.Background(), path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if schema.Dialect != "sqlite" {
+		t.Fatalf("dialect is %q", schema.Dialect)
+	}
+
+	posts, ok := schema.Table("posts")
+	if !ok {
+		t.Fatalf("no posts table in %v", schema.Tables)
+	}
+
+	title, ok := posts.Column("title")
+	if !ok || title.DBType != "TEXT" || title.Nullable || title.Default != "''" {
+		t.Fatalf("title column: %+v", title)
+	}
+
+	if posts.PrimaryKey == nil || !reflect.DeepEqual(posts.PrimaryKey.Columns, []string{"id"}) {
+		t.Fatalf("primary key: %+v", posts.PrimaryKey)
+	}
+
+	if len(posts.ForeignKeys) != 1 || posts.ForeignKeys[0].ForeignTable != "users" {
+		t.Fatalf("foreign keys: %+v", posts.ForeignKeys)
+	}
+
+	want := []Index{{Name: "posts_user_title", Columns: []string{"user_id", "title"}}}
+	if !reflect.DeepEqual(posts.Indexes, want) {
+		t.Fatalf("indexes: got %+v, want %+v", posts.Indexes, want)
+	}
+
+	users, _ := schema.Table("users")
+	if len(users.Uniques) != 1 || len(users.Indexes) != 1 || !users.Indexes[0].Unique {
+		t.Fatalf("users: uniques %+v, indexes %+v", users.Uniques, users.Indexes)
+	}
+}
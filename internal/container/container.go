@@ -0,0 +1,90 @@
+// Package container starts throwaway database servers with the docker CLI
+package container
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"fmt"
+	"os/exec"
+	"strings"
+	"time"
+)
+
+// Container is a running docker container
+type Container struct {
+	ID string
+	// The host and port the container port is published on
+	Addr string
+}
+
+// Run starts the image in the background with the port published on
+// localhost. The container is removed when it is stopped
+func Run(ctx context.Context, image, port string, env []string) (*Container, error) {
+	args := []string{"run", "--detach", "--rm", "--publish", "127.0.0.1::" + port}
+	for _, e := range env {
+		args = append(args, "--env", e)
+	}
+	args = append(args, image)
+
+	id, err := docker(ctx, args...)
+	if err != nil {
+		return nil, fmt.Errorf("starting %s: %w", image, err)
+	}
+
+	c := &Container{ID: id}
+
+	ports, err := docker(ctx, "port", id, port)
+	if err != nil {
+		c.Stop()
+		return nil, fmt.Errorf("getting the port of %s: %w", image, err)
+	}
+
+	// There is a line for each address, they are all on the same port
+	addr, _, _ := strings.Cut(ports, "\n")
+	c.Addr = strings.TrimSpace(addr)
+
+	return c, nil
+}
+
+// Stop stops and removes the container
+func (c *Container) Stop() error {
+	// Not the caller's context, the container should be removed
+	// even if it was cancelled
+	_, err := docker(context.Background(), "rm", "--force", c.ID)
+	return err
+}
+
+// WaitForDB pings the database until it accepts connections
+// or the timeout is reached
+func WaitForDB(ctx context.Context, db *sql.DB, timeout time.Duration) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	for {
+		err := db.PingContext(ctx)
+		if err == nil {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for the database: %w", err)
+		case <-time.After(500 * time.Millisecond):
+		}
+	}
+}
+
+func docker(ctx context.Context, args ...string) (string, error) {
+	var stderr bytes.Buffer
+
+	cmd := exec.CommandContext(ctx, "docker", args...)
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("docker %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,243 @@
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/aarondl/opt"
+	"github.com/stephenafamo/scan"
+)
+
+//nolint:gochecknoglobals
+var (
+	jsonUnmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	sqlScannerType      = reflect.TypeOf((*sql.Scanner)(nil)).Elem()
+
+	matchFirstCap = regexp.MustCompile("(.)([A-Z][a-z]+)")
+	matchAllCap   = regexp.MustCompile("([a-z0-9])([A-Z])")
+
+	// timestamps and dates without a time zone, which time.Time cannot unmarshal
+	jsonTimeLayouts = []string{
+		"2006-01-02T15:04:05.999999999",
+		"2006-01-02 15:04:05.999999999",
+		"2006-01-02 15:04:05.999999999Z07:00",
+		"2006-01-02 15:04:05.999999999Z07",
+		"2006-01-02",
+	}
+)
+
+// JSONColumn scans a column that holds a JSON document
+type JSONColumn []byte
+
+// Scan implements the sql.Scanner interface.
+func (j *JSONColumn) Scan(value any) error {
+	switch v := value.(type) {
+	case nil:
+		*j = nil
+	case []byte:
+		*j = append((*j)[:0], v...)
+	case string:
+		*j = JSONColumn(v)
+	default:
+		return fmt.Errorf("cannot scan type %T into a JSON column", value)
+	}
+
+	return nil
+}
+
+// JSONPreloadMapper scans the JSON array in the column with the given alias
+// and preloads it into the retrieved object with the relationship name
+func JSONPreloadMapper[T any, Ts ~[]T](name, alias string, extra *AfterPreloader) scan.MapperMod {
+	return func(context.Context, []string) (scan.BeforeFunc, scan.AfterMod) {
+		return func(row *scan.Row) (any, error) {
+				var data JSONColumn
+				row.ScheduleScan(alias, &data)
+				return &data, nil
+			}, func(link, retrieved any) error {
+				loader, isLoader := retrieved.(Preloadable)
+				if !isLoader {
+					return fmt.Errorf("object %T cannot pre load", retrieved)
+				}
+
+				rows, err := UnmarshalJSONRows[T, Ts](*link.(*JSONColumn))
+				if err != nil {
+					return fmt.Errorf("preloading %s: %w", name, err)
+				}
+
+				for _, row := range rows {
+					if err := extra.Collect(row); err != nil {
+						return err
+					}
+				}
+
+				return loader.Preload(name, rows)
+			}
+	}
+}
+
+// UnmarshalJSONRows decodes a JSON array of objects keyed by column name,
+// as built by an aggregate such as json_agg, into a slice.
+//
+// The fields are matched with the same db tags that are used for scanning.
+// Values that cannot be decoded as JSON are scanned the way a driver value
+// would be, which handles types such as bytea and geometry that
+// are sent as strings.
+func UnmarshalJSONRows[T any, Ts ~[]T](data []byte) (Ts, error) {
+	if len(data) == 0 {
+		return nil, nil
+	}
+
+	var objects []map[string]json.RawMessage
+	if err := json.Unmarshal(data, &objects); err != nil {
+		return nil, err
+	}
+
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+	isPointer := typ.Kind() == reflect.Pointer
+	if isPointer {
+		typ = typ.Elem()
+	}
+
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("cannot unmarshal JSON rows into %s", typ)
+	}
+
+	rows := make(Ts, len(objects))
+	for i, obj := range objects {
+		val := reflect.New(typ)
+		if err := setJSONFields(val.Elem(), "", obj); err != nil {
+			return nil, err
+		}
+
+		if isPointer {
+			rows[i] = val.Interface().(T)
+		} else {
+			rows[i] = val.Elem().Interface().(T)
+		}
+	}
+
+	return rows, nil
+}
+
+func setJSONFields(val reflect.Value, prefix string, obj map[string]json.RawMessage) error {
+	typ := val.Type()
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		tag := strings.Split(field.Tag.Get("db"), ",")[0]
+		if tag == "-" {
+			continue
+		}
+
+		name := tag
+		if name == "" {
+			name = snakeCase(field.Name)
+		}
+
+		fieldVal := val.Field(i)
+		if isJSONStruct(field.Type) {
+			nested := prefix + name
+			if field.Anonymous {
+				nested = prefix
+			}
+
+			if err := setJSONFields(fieldVal, nested, obj); err != nil {
+				return err
+			}
+			continue
+		}
+
+		raw, ok := obj[prefix+name]
+		if !ok {
+			continue
+		}
+
+		if err := setJSONValue(fieldVal.Addr().Interface(), raw); err != nil {
+			return fmt.Errorf("column %q: %w", prefix+name, err)
+		}
+	}
+
+	return nil
+}
+
+// isJSONStruct returns true for struct fields whose fields should be set
+// one by one, instead of decoding the value into the struct directly
+func isJSONStruct(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Struct {
+		return false
+	}
+
+	ptr := reflect.PointerTo(typ)
+	return !ptr.Implements(jsonUnmarshalerType) && !ptr.Implements(sqlScannerType)
+}
+
+func setJSONValue(dest any, raw json.RawMessage) error {
+	err := json.Unmarshal(raw, dest)
+	if err == nil {
+		return nil
+	}
+
+	var value any
+	if json.Unmarshal(raw, &value) != nil {
+		return err
+	}
+
+	switch v := value.(type) {
+	case string:
+		if t, ok := parseJSONTime(v); ok {
+			rfc, _ := json.Marshal(t.Format(time.RFC3339Nano))
+			if json.Unmarshal(rfc, dest) == nil {
+				return nil
+			}
+		}
+
+		// postgres sends bytea as hex
+		if strings.HasPrefix(v, `\x`) {
+			if b, err := hex.DecodeString(v[2:]); err == nil {
+				value = b
+			}
+		}
+	case float64:
+		// drivers send integers, which is what mysql uses for booleans
+		if v == math.Trunc(v) {
+			value = int64(v)
+		}
+	}
+
+	if scanner, ok := dest.(sql.Scanner); ok {
+		return scanner.Scan(value)
+	}
+
+	if opt.ConvertAssign(dest, value) == nil {
+		return nil
+	}
+
+	return err
+}
+
+func parseJSONTime(s string) (time.Time, bool) {
+	for _, layout := range jsonTimeLayouts {
+		if t, err := time.Parse(layout, s); err == nil {
+			return t, true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+func snakeCase(str string) string {
+	snake := matchFirstCap.ReplaceAllString(str, "${1}_${2}")
+	snake = matchAllCap.ReplaceAllString(snake, "${1}_${2}")
+	return strings.ToLower(snake)
+}
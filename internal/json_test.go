@@ -0,0 +1,75 @@
+package internal
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type jsonAddress struct {
+	Street string `db:"street"`
+	City   string `db:"city"`
+}
+
+type jsonRow struct {
+	ID      int
+	Name    string `db:"full_name"`
+	Active  bool
+	Data    []byte
+	Joined  time.Time
+	Ignored string      `db:"-"`
+	Billing jsonAddress `db:"billing_"`
+	Timestamps
+}
+
+func TestUnmarshalJSONRows(t *testing.T) {
+	data := []byte(`[{
+		"id": 1,
+		"full_name": "Stephen",
+		"active": 1,
+		"data": "\\x0102",
+		"joined": "2023-01-02T10:00:00.5",
+		"ignored": "value",
+		"billing_street": "Main",
+		"billing_city": "Lagos",
+		"updated_at": "2023-01-03 10:00:00+01"
+	}, {"id": 2}]`)
+
+	rows, err := UnmarshalJSONRows[*jsonRow, []*jsonRow](data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []*jsonRow{
+		{
+			ID:      1,
+			Name:    "Stephen",
+			Active:  true,
+			Data:    []byte{1, 2},
+			Joined:  time.Date(2023, 1, 2, 10, 0, 0, 5e8, time.UTC),
+			Billing: jsonAddress{Street: "Main", City: "Lagos"},
+			Timestamps: Timestamps{
+				UpdatedAt: time.Date(2023, 1, 3, 9, 0, 0, 0, time.UTC),
+			},
+		},
+		{ID: 2},
+	}
+
+	if diff := cmp.Diff(expected, rows, cmp.Comparer(func(a, b time.Time) bool {
+		return a.Equal(b)
+	})); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestUnmarshalJSONRowsEmpty(t *testing.T) {
+	rows, err := UnmarshalJSONRows[jsonRow, []jsonRow](nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if rows != nil {
+		t.Fatalf("expected no rows, got %v", rows)
+	}
+}
@@ -16,6 +16,7 @@ type colProperties struct {
 	IsPK          bool
 	IsGenerated   bool
 	AutoIncrement bool
+	RenamedFrom   string
 }
 
 func getColProperties(tag string) colProperties {
@@ -35,6 +36,10 @@ func getColProperties(tag string) colProperties {
 			p.IsGenerated = true
 		case "autoincr":
 			p.AutoIncrement = true
+		default:
+			if strings.HasPrefix(part, "renamed_from=") {
+				p.RenamedFrom = strings.TrimPrefix(part, "renamed_from=")
+			}
 		}
 	}
 
@@ -48,6 +53,15 @@ type Mapping struct {
 	Generated     []string
 	NonGenerated  []string
 	AutoIncrement []string
+	// The old names of columns that are being renamed, keyed by the new name
+	RenamedFrom map[string]string
+	// The SQL expressions of the computed fields, keyed by column name.
+	// Set if the type has a ComputedColumns method
+	Computed map[string]string
+}
+
+type computer interface {
+	ComputedColumns() map[string]string
 }
 
 func GetMappings(typ reflect.Type) Mapping {
@@ -104,6 +118,16 @@ func GetMappings(typ reflect.Type) Mapping {
 		if props.AutoIncrement {
 			c.AutoIncrement[field.Index[0]] = props.Name
 		}
+		if props.RenamedFrom != "" {
+			if c.RenamedFrom == nil {
+				c.RenamedFrom = make(map[string]string)
+			}
+			c.RenamedFrom[props.Name] = props.RenamedFrom
+		}
+	}
+
+	if comp, ok := reflect.New(typ).Interface().(computer); ok {
+		c.Computed = comp.ComputedColumns()
 	}
 
 	return c
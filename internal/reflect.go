@@ -27,7 +27,15 @@ func MappingCols(m mappings.Mapping, table ...string) orm.Columns {
 
 	copy(cols, m.All)
 
-	return orm.NewColumns(cols...).WithParent(table...)
+	columns := orm.NewColumns(cols...).WithParent(table...)
+	for col, from := range m.RenamedFrom {
+		columns = columns.WithFallback(col, from)
+	}
+	for col, expression := range m.Computed {
+		columns = columns.WithComputed(col, expression)
+	}
+
+	return columns
 }
 
 // Get the values for non generated columns
@@ -132,3 +140,26 @@ func sliceToMap[T comparable](s []T) map[T]int {
 	}
 	return m
 }
+
+// FieldValues returns the values of the struct fields with the given indexes,
+// following pointers to the struct
+func FieldValues(obj any, indexes []int) []any {
+	val := reflect.ValueOf(obj)
+	for val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			return nil
+		}
+		val = val.Elem()
+	}
+
+	if val.Kind() != reflect.Struct {
+		return nil
+	}
+
+	values := make([]any, len(indexes))
+	for i, index := range indexes {
+		values[i] = val.Field(index).Interface()
+	}
+
+	return values
+}
@@ -49,6 +49,20 @@ type BlogWithTags struct {
 	User        User   `db:"-"`
 }
 
+type RenamedUser struct {
+	ID    int    `db:"id,pk"`
+	Email string `db:"email,renamed_from=email_address"`
+}
+
+type ComputedUser struct {
+	ID       int    `db:"id,pk"`
+	FullName string `db:"full_name,generated"`
+}
+
+func (*ComputedUser) ComputedColumns() map[string]string {
+	return map[string]string{"full_name": "first_name || ' ' || last_name"}
+}
+
 func TestGetColumns(t *testing.T) {
 	testGetColumns[User](t, mappings.Mapping{
 		All:           []string{"id", "first_name", "last_name"},
@@ -94,6 +108,46 @@ func TestGetColumns(t *testing.T) {
 		NonGenerated:  []string{"", "title", "", ""},
 		AutoIncrement: []string{"blog_id", "", "", ""},
 	})
+
+	testGetColumns[RenamedUser](t, mappings.Mapping{
+		All:           []string{"id", "email"},
+		PKs:           []string{"id", ""},
+		NonPKs:        []string{"", "email"},
+		Generated:     make([]string, 2),
+		NonGenerated:  []string{"id", "email"},
+		AutoIncrement: make([]string, 2),
+		RenamedFrom:   map[string]string{"email": "email_address"},
+	})
+
+	testGetColumns[ComputedUser](t, mappings.Mapping{
+		All:           []string{"id", "full_name"},
+		PKs:           []string{"id", ""},
+		NonPKs:        []string{"", "full_name"},
+		Generated:     []string{"", "full_name"},
+		NonGenerated:  []string{"id", ""},
+		AutoIncrement: make([]string, 2),
+		Computed:      map[string]string{"full_name": "first_name || ' ' || last_name"},
+	})
+}
+
+func TestMappingColsRenamed(t *testing.T) {
+	cols := MappingCols(mappings.GetMappings(reflect.TypeOf(RenamedUser{})), "users")
+
+	got := expTransformer(cols).Query
+	want := `"users"."id" AS "id", COALESCE("users"."email", "users"."email_address") AS "email"`
+	if got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestMappingColsComputed(t *testing.T) {
+	cols := MappingCols(mappings.GetMappings(reflect.TypeOf(ComputedUser{})), "users")
+
+	got := expTransformer(cols).Query
+	want := `"users"."id" AS "id", (first_name || ' ' || last_name) AS "full_name"`
+	if got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
 }
 
 func testGetColumns[T any](t *testing.T, expected mappings.Mapping) {
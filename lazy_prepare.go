@@ -0,0 +1,91 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+
+	"github.com/stephenafamo/scan"
+)
+
+// LazyPrepare wraps a [Preparer] so that the statements of [Prepare] and
+// [PrepareQuery] are only prepared when they are first executed, e.g. so that
+// statements can be set up before the database is reachable.
+//
+// The statements are safe to use from several goroutines, and are only prepared
+// once at a time. A statement is not prepared again if its connection is lost,
+// since a [Conn] or [Tx] cannot be used after that, and the statements of
+// a [DB] are already prepared again on each connection of the pool
+func LazyPrepare(exec Preparer) Preparer {
+	return lazyPreparer{exec}
+}
+
+type lazyPreparer struct {
+	Preparer
+}
+
+// Unwrap returns the wrapped executor
+func (l lazyPreparer) Unwrap() Executor {
+	return l.Preparer
+}
+
+func (l lazyPreparer) PrepareContext(_ context.Context, query string) (Statement, error) {
+	return &lazyStatement{exec: l.Preparer, query: query}, nil
+}
+
+type lazyStatement struct {
+	exec  Preparer
+	query string
+
+	mu   sync.Mutex
+	stmt Statement
+}
+
+func (l *lazyStatement) get(ctx context.Context) (Statement, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.stmt != nil {
+		return l.stmt, nil
+	}
+
+	stmt, err := l.exec.PrepareContext(ctx, l.query)
+	if err != nil {
+		return nil, err
+	}
+
+	l.stmt = stmt
+	return stmt, nil
+}
+
+func (l *lazyStatement) ExecContext(ctx context.Context, args ...any) (sql.Result, error) {
+	stmt, err := l.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return stmt.ExecContext(ctx, args...)
+}
+
+func (l *lazyStatement) QueryContext(ctx context.Context, args ...any) (scan.Rows, error) {
+	stmt, err := l.get(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return stmt.QueryContext(ctx, args...)
+}
+
+// Close closes the statement if it was prepared
+func (l *lazyStatement) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.stmt == nil {
+		return nil
+	}
+
+	err := closeStatement(l.stmt)
+	l.stmt = nil
+	return err
+}
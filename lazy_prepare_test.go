@@ -0,0 +1,88 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stephenafamo/scan"
+)
+
+// flakyPreparer prepares statements that fail with a lost connection
+// the first time they are executed
+type flakyPreparer struct {
+	NoopExecutor
+	mu       sync.Mutex
+	prepared int
+	fail     bool
+}
+
+func (f *flakyPreparer) PrepareContext(context.Context, string) (Statement, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.prepared++
+	return flakyStatement{fail: f.fail && f.prepared == 1}, nil
+}
+
+type flakyStatement struct{ fail bool }
+
+func (f flakyStatement) ExecContext(context.Context, ...any) (sql.Result, error) {
+	if f.fail {
+		return nil, driver.ErrBadConn
+	}
+	return driver.RowsAffected(1), nil
+}
+
+func (f flakyStatement) QueryContext(context.Context, ...any) (scan.Rows, error) {
+	if f.fail {
+		return nil, sql.ErrConnDone
+	}
+	return nil, errors.New("no rows")
+}
+
+func TestLazyPrepare(t *testing.T) {
+	ctx := context.Background()
+	q := rawQuery(d, "UPDATE users SET seen = true WHERE id = #", 1)
+
+	exec := &flakyPreparer{}
+	stmt, err := Prepare(ctx, LazyPrepare(exec), q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if exec.prepared != 0 {
+		t.Fatal("the statement was prepared before it was used")
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := stmt.Exec(ctx, 1); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if exec.prepared != 1 {
+		t.Fatalf("prepared %d times", exec.prepared)
+	}
+
+	// The connection is lost, which the statement cannot recover from
+	exec = &flakyPreparer{fail: true}
+	stmt, err = Prepare(ctx, LazyPrepare(exec), q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stmt.Exec(ctx, 1); !errors.Is(err, driver.ErrBadConn) {
+		t.Fatalf("expected the error of the lost connection, got %v", err)
+	}
+	if exec.prepared != 1 {
+		t.Fatalf("prepared %d times, expected it not to be prepared again", exec.prepared)
+	}
+}
@@ -0,0 +1,75 @@
+package bob
+
+import "container/list"
+
+// lru keeps the values of the most recently used keys, up to its size.
+// It is not safe for concurrent use
+type lru[V any] struct {
+	size  int
+	items map[string]*list.Element
+	// of *lruEntry[V], the most recently used at the front
+	order *list.List
+}
+
+type lruEntry[V any] struct {
+	key   string
+	value V
+}
+
+func newLRU[V any](size int) *lru[V] {
+	return &lru[V]{
+		size:  size,
+		items: map[string]*list.Element{},
+		order: list.New(),
+	}
+}
+
+// get returns the value of the key, and marks it as the most recently used
+func (l *lru[V]) get(key string) (V, bool) {
+	el, ok := l.items[key]
+	if !ok {
+		var zero V
+		return zero, false
+	}
+
+	l.order.MoveToFront(el)
+	return el.Value.(*lruEntry[V]).value, true
+}
+
+// add sets the value of the key as the most recently used, and returns
+// the values of the least recently used keys that were removed to stay within the size
+func (l *lru[V]) add(key string, value V) []V {
+	if el, ok := l.items[key]; ok {
+		el.Value.(*lruEntry[V]).value = value
+		l.order.MoveToFront(el)
+		return nil
+	}
+
+	l.items[key] = l.order.PushFront(&lruEntry[V]{key: key, value: value})
+
+	var removed []V
+	for l.order.Len() > l.size {
+		value, _ := l.removeOldest()
+		removed = append(removed, value)
+	}
+
+	return removed
+}
+
+// removeOldest removes the value of the least recently used key
+func (l *lru[V]) removeOldest() (V, bool) {
+	el := l.order.Back()
+	if el == nil {
+		var zero V
+		return zero, false
+	}
+
+	entry := l.order.Remove(el).(*lruEntry[V])
+	delete(l.items, entry.key)
+
+	return entry.value, true
+}
+
+func (l *lru[V]) len() int {
+	return l.order.Len()
+}
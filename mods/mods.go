@@ -58,6 +58,13 @@ func (w Where[Q]) Apply(q Q) {
 	q.AppendWhere(w.E)
 }
 
+// AllRows allows an UPDATE or DELETE query without a WHERE clause
+type AllRows[Q interface{ SetAllRows() }] struct{}
+
+func (AllRows[Q]) Apply(q Q) {
+	q.SetAllRows()
+}
+
 type GroupBy[Q interface{ AppendGroup(any) }] struct {
 	E any
 }
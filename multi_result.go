@@ -0,0 +1,77 @@
+package bob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/stephenafamo/scan"
+)
+
+// ErrMissingResultSet is returned by [MultiResult] when the query
+// returned fewer result sets than there are readers
+var ErrMissingResultSet = errors.New("missing result set")
+
+// resultSetter is implemented by rows with several result sets, such as [*sql.Rows]
+type resultSetter interface {
+	NextResultSet() bool
+}
+
+// ResultSet reads the rows of one of the result sets of a query. See [MultiResult]
+type ResultSet func(ctx context.Context, rows scan.Rows) error
+
+// ResultSetInto returns a [ResultSet] that maps the rows of the result set with m
+// and stores them in dest
+func ResultSetInto[T any](dest *[]T, m scan.Mapper[T]) ResultSet {
+	return func(ctx context.Context, rows scan.Rows) error {
+		var err error
+		*dest, err = scan.AllFromRows(ctx, m, rows)
+		return err
+	}
+}
+
+// MultiResult executes a query that returns several result sets, such as a stored
+// procedure in MSSQL or several statements in MySQL, and reads them in order,
+// each with the matching [ResultSet]. Result sets after the last reader are skipped.
+//
+//	var users []User
+//	var orders []Order
+//	err := bob.MultiResult(ctx, db, q,
+//		bob.ResultSetInto(&users, scan.StructMapper[User]()),
+//		bob.ResultSetInto(&orders, scan.StructMapper[Order]()),
+//	)
+func MultiResult(ctx context.Context, exec Executor, q Query, sets ...ResultSet) error {
+	if err := checkFeatures(exec, q); err != nil {
+		return err
+	}
+
+	exec = withDefaultTimeZone(exec)
+
+	sql, args, err := Build(q)
+	if err != nil {
+		return err
+	}
+
+	rows, err := exec.QueryContext(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	next, _ := rows.(resultSetter)
+
+	for i, set := range sets {
+		if i > 0 && (next == nil || !next.NextResultSet()) {
+			if err := rows.Err(); err != nil {
+				return err
+			}
+			return fmt.Errorf("%w: expected %d, got %d", ErrMissingResultSet, len(sets), i)
+		}
+
+		if err := set(ctx, rows); err != nil {
+			return fmt.Errorf("result set %d: %w", i, err)
+		}
+	}
+
+	return rows.Close()
+}
@@ -0,0 +1,89 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/stephenafamo/scan"
+)
+
+// multiRows are rows with several result sets of one column
+type multiRows struct {
+	sets [][]any
+	set  int
+	row  int
+}
+
+func (m *multiRows) Columns() ([]string, error) { return []string{"value"}, nil }
+func (m *multiRows) Err() error                 { return nil }
+func (m *multiRows) Close() error               { return nil }
+
+func (m *multiRows) Next() bool {
+	m.row++
+	return m.row <= len(m.sets[m.set])
+}
+
+func (m *multiRows) Scan(dest ...any) error {
+	reflect.ValueOf(dest[0]).Elem().Set(reflect.ValueOf(m.sets[m.set][m.row-1]))
+	return nil
+}
+
+func (m *multiRows) NextResultSet() bool {
+	if m.set+1 >= len(m.sets) {
+		return false
+	}
+	m.set++
+	m.row = 0
+	return true
+}
+
+type multiExecutor struct{ sets [][]any }
+
+func (e multiExecutor) QueryContext(context.Context, string, ...any) (scan.Rows, error) {
+	return &multiRows{sets: e.sets}, nil
+}
+
+func (e multiExecutor) ExecContext(context.Context, string, ...any) (sql.Result, error) {
+	return nil, nil
+}
+
+func TestMultiResult(t *testing.T) {
+	ctx := context.Background()
+	exec := multiExecutor{sets: [][]any{{1, 2, 3}, {"a", "b"}}}
+	q := rawQuery(d, "EXEC report")
+
+	var ids []int
+	var names []string
+	err := MultiResult(ctx, exec, q,
+		ResultSetInto(&ids, scan.SingleColumnMapper[int]),
+		ResultSetInto(&names, scan.SingleColumnMapper[string]),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ids, []int{1, 2, 3}) || !reflect.DeepEqual(names, []string{"a", "b"}) {
+		t.Fatalf("got %v and %v", ids, names)
+	}
+
+	// The last result set is skipped
+	ids = nil
+	if err := MultiResult(ctx, exec, q, ResultSetInto(&ids, scan.SingleColumnMapper[int])); err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 3 {
+		t.Fatalf("got %v", ids)
+	}
+
+	var more []string
+	err = MultiResult(ctx, exec, q,
+		ResultSetInto(&ids, scan.SingleColumnMapper[int]),
+		ResultSetInto(&names, scan.SingleColumnMapper[string]),
+		ResultSetInto(&more, scan.SingleColumnMapper[string]),
+	)
+	if !errors.Is(err, ErrMissingResultSet) {
+		t.Fatalf("expected ErrMissingResultSet, got %v", err)
+	}
+}
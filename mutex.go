@@ -0,0 +1,380 @@
+package bob
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrMutexNotLocked is returned when unlocking a [DistributedMutex] that is not locked
+var ErrMutexNotLocked = errors.New("mutex is not locked")
+
+// MutexBackend is the way a [DistributedMutex] is locked in the database
+type MutexBackend int
+
+const (
+	// AdvisoryLock uses the session advisory locks of Postgres
+	AdvisoryLock MutexBackend = iota
+	// GetLock uses GET_LOCK and RELEASE_LOCK of MySQL.
+	// The name of the mutex can be at most 64 characters
+	GetLock
+	// LockTable uses a row of a lock table that expires if it is not renewed,
+	// for databases without named locks such as SQLite and SQL Server.
+	// The table is created with the statement of [LockTableSchema]
+	LockTable
+)
+
+// DefaultLockTable is the name of the lock table if [MutexOptions.Table] is empty
+const DefaultLockTable = "bob_locks"
+
+// MutexOptions configure a [DistributedMutex]
+type MutexOptions struct {
+	Backend MutexBackend
+	// The dialect to write the queries of the LockTable backend with
+	Dialect Dialect
+	// The lock table, DefaultLockTable if empty
+	Table string
+	// How long a lock in the lock table is kept if its holder stops renewing it,
+	// 30 seconds by default. The lock is renewed three times per TTL while it is held.
+	// With the other backends, the connection holding the lock is checked as often
+	TTL time.Duration
+	// How often Lock tries again while the mutex is locked elsewhere,
+	// 100 milliseconds by default
+	RetryInterval time.Duration
+}
+
+// DistributedMutex is a named lock shared by every process using the same database,
+// e.g. to make sure only one instance of a service runs a scheduled job.
+// It is created with [Mutex]
+type DistributedMutex struct {
+	db    DB
+	name  string
+	opts  MutexOptions
+	owner string
+
+	mu   sync.Mutex
+	conn *Conn
+	stop chan struct{}
+	done chan struct{}
+	lost chan struct{}
+}
+
+// Mutex returns a distributed mutex with the given name.
+//
+// With the AdvisoryLock and GetLock backends, the lock belongs to a database
+// session, so a connection of the pool is reserved while the mutex is locked.
+// With the LockTable backend, the lock expires unless it is renewed, which is
+// done automatically while it is held.
+// If the lock cannot be kept, e.g. because the connection was lost,
+// the channel returned by [DistributedMutex.Lost] is closed
+//
+//	m := bob.Mutex(db, "send-newsletter", bob.MutexOptions{Backend: bob.AdvisoryLock})
+//	if err := m.Lock(ctx); err != nil {
+//		return err
+//	}
+//	defer m.Unlock(ctx)
+func Mutex(db DB, name string, opts MutexOptions) *DistributedMutex {
+	if opts.Table == "" {
+		opts.Table = DefaultLockTable
+	}
+	if opts.TTL <= 0 {
+		opts.TTL = 30 * time.Second
+	}
+	if opts.RetryInterval <= 0 {
+		opts.RetryInterval = 100 * time.Millisecond
+	}
+
+	return &DistributedMutex{db: db, name: name, opts: opts}
+}
+
+// LockTableSchema returns the statement that creates the table of the LockTable backend
+func LockTableSchema(d Dialect, table string) string {
+	var b strings.Builder
+	b.WriteString("CREATE TABLE ")
+	d.WriteQuoted(&b, table)
+	b.WriteString(" (\n\tname VARCHAR(255) PRIMARY KEY,\n\towner VARCHAR(255) NOT NULL,\n\texpires_at BIGINT NOT NULL\n)")
+
+	return b.String()
+}
+
+// Lock waits until the mutex is locked, or the context is done
+func (m *DistributedMutex) Lock(ctx context.Context) error {
+	for {
+		ok, err := m.TryLock(ctx)
+		if err != nil || ok {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(m.opts.RetryInterval):
+		}
+	}
+}
+
+// TryLock locks the mutex if it is not locked elsewhere, without waiting.
+// It returns false if the mutex is locked, including by this DistributedMutex
+func (m *DistributedMutex) TryLock(ctx context.Context) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.stop != nil {
+		return false, nil
+	}
+
+	var ok bool
+	var err error
+
+	switch m.opts.Backend {
+	case LockTable:
+		ok, err = m.tryLockTable(ctx)
+	default:
+		ok, err = m.trySessionLock(ctx)
+	}
+	if err != nil || !ok {
+		return false, err
+	}
+
+	m.stop = make(chan struct{})
+	m.done = make(chan struct{})
+	m.lost = make(chan struct{})
+	go m.renew(m.stop, m.done, m.lost)
+
+	return true, nil
+}
+
+// Unlock releases the mutex
+func (m *DistributedMutex) Unlock(ctx context.Context) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.stop == nil {
+		return ErrMutexNotLocked
+	}
+
+	close(m.stop)
+	<-m.done
+	m.stop, m.done = nil, nil
+
+	if m.opts.Backend == LockTable {
+		_, err := m.db.ExecContext(ctx, m.lockTableQuery("DELETE FROM %s WHERE name = ? AND owner = ?"), m.name, m.owner)
+		return err
+	}
+
+	conn := m.conn
+	m.conn = nil
+	defer conn.Close()
+
+	var query string
+	var arg any
+	switch m.opts.Backend {
+	case GetLock:
+		query, arg = "SELECT RELEASE_LOCK(?)", m.name
+	default:
+		query, arg = "SELECT pg_advisory_unlock($1)", m.advisoryKey()
+	}
+
+	_, err := conn.ExecContext(ctx, query, arg)
+	return err
+}
+
+// Lost returns a channel that is closed if the lock could not be kept while
+// it was held, so that the work it protects can be stopped.
+// It returns nil if the mutex is not locked
+func (m *DistributedMutex) Lost() <-chan struct{} {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.stop == nil {
+		return nil
+	}
+
+	return m.lost
+}
+
+// trySessionLock locks the mutex on a reserved connection
+func (m *DistributedMutex) trySessionLock(ctx context.Context) (bool, error) {
+	conn, err := m.db.Conn(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	var query string
+	var arg any
+	switch m.opts.Backend {
+	case GetLock:
+		query, arg = "SELECT GET_LOCK(?, 0)", m.name
+	default:
+		query, arg = "SELECT pg_try_advisory_lock($1)", m.advisoryKey()
+	}
+
+	var ok bool
+	rows, err := conn.QueryContext(ctx, query, arg)
+	if err == nil {
+		if rows.Next() {
+			err = rows.Scan(&ok)
+		}
+		rows.Close()
+		if err == nil {
+			err = rows.Err()
+		}
+	}
+
+	if err != nil || !ok {
+		conn.Close()
+		return false, err
+	}
+
+	m.conn = &conn
+	return true, nil
+}
+
+// advisoryKey is the key of the Postgres advisory lock for the name
+func (m *DistributedMutex) advisoryKey() int64 {
+	h := fnv.New64a()
+	h.Write([]byte(m.name)) //nolint:errcheck
+	return int64(h.Sum64())
+}
+
+// tryLockTable inserts the row of the mutex in the lock table,
+// after deleting it if it expired
+func (m *DistributedMutex) tryLockTable(ctx context.Context) (bool, error) {
+	if m.opts.Dialect == nil {
+		return false, errors.New("mutex: the LockTable backend needs a dialect")
+	}
+
+	if m.owner == "" {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err != nil {
+			return false, err
+		}
+		m.owner = hex.EncodeToString(b)
+	}
+
+	now := time.Now()
+	_, err := m.db.ExecContext(ctx,
+		m.lockTableQuery("DELETE FROM %s WHERE name = ? AND expires_at < ?"),
+		m.name, now.UnixMilli(),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := m.db.ExecContext(ctx,
+		m.lockTableQuery("INSERT INTO %[1]s (name, owner, expires_at) SELECT ?, ?, ? WHERE NOT EXISTS (SELECT 1 FROM %[1]s WHERE name = ?)"),
+		m.name, m.owner, now.Add(m.opts.TTL).UnixMilli(), m.name,
+	)
+	if err != nil {
+		// Another process may have inserted the row at the same time
+		if locked, lockedErr := m.lockedElsewhere(ctx); lockedErr == nil && locked {
+			return false, nil
+		}
+		return false, err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return n == 1, nil
+}
+
+func (m *DistributedMutex) lockedElsewhere(ctx context.Context) (bool, error) {
+	rows, err := m.db.QueryContext(ctx, m.lockTableQuery("SELECT owner FROM %s WHERE name = ?"), m.name)
+	if err != nil {
+		return false, err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		return false, rows.Err()
+	}
+
+	var owner string
+	if err := rows.Scan(&owner); err != nil {
+		return false, err
+	}
+
+	return owner != m.owner, nil
+}
+
+// renew keeps the lock while it is held. It closes lost if it cannot
+func (m *DistributedMutex) renew(stop, done, lost chan struct{}) {
+	defer close(done)
+
+	ticker := time.NewTicker(m.opts.TTL / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), m.opts.TTL/3)
+		err := m.renewOnce(ctx)
+		cancel()
+
+		if err != nil {
+			close(lost)
+			return
+		}
+	}
+}
+
+func (m *DistributedMutex) renewOnce(ctx context.Context) error {
+	if m.opts.Backend != LockTable {
+		return m.conn.PingContext(ctx)
+	}
+
+	res, err := m.db.ExecContext(ctx,
+		m.lockTableQuery("UPDATE %s SET expires_at = ? WHERE name = ? AND owner = ?"),
+		time.Now().Add(m.opts.TTL).UnixMilli(), m.name, m.owner,
+	)
+	if err != nil {
+		return err
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("mutex %q was locked by another owner", m.name)
+	}
+
+	return nil
+}
+
+// lockTableQuery writes the quoted lock table in place of %s,
+// and the placeholders of the dialect in place of ?
+func (m *DistributedMutex) lockTableQuery(format string) string {
+	var table strings.Builder
+	m.opts.Dialect.WriteQuoted(&table, m.opts.Table)
+
+	query := fmt.Sprintf(format, table.String())
+
+	var b strings.Builder
+	arg := 1
+	for _, part := range strings.SplitAfter(query, "?") {
+		if !strings.HasSuffix(part, "?") {
+			b.WriteString(part)
+			continue
+		}
+
+		b.WriteString(part[:len(part)-1])
+		m.opts.Dialect.WriteArg(&b, arg)
+		arg++
+	}
+
+	return b.String()
+}
@@ -0,0 +1,121 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	_ "modernc.org/sqlite"
+)
+
+func TestLockTableQuery(t *testing.T) {
+	m := Mutex(DB{}, "job", MutexOptions{Backend: LockTable, Dialect: d, Table: "locks"})
+
+	got := m.lockTableQuery("UPDATE %s SET expires_at = ? WHERE name = ? AND owner = ?")
+	want := `UPDATE "locks" SET expires_at = $1 WHERE name = $2 AND owner = $3`
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+func TestMutexLockTable(t *testing.T) {
+	ctx := context.Background()
+
+	sqlDB, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	sqlDB.SetMaxOpenConns(1)
+	db := NewDB(sqlDB)
+
+	if _, err := db.ExecContext(ctx, LockTableSchema(d, DefaultLockTable)); err != nil {
+		t.Fatal(err)
+	}
+
+	opts := MutexOptions{
+		Backend:       LockTable,
+		Dialect:       d,
+		TTL:           150 * time.Millisecond,
+		RetryInterval: 10 * time.Millisecond,
+	}
+	m1 := Mutex(db, "job", opts)
+	m2 := Mutex(db, "job", opts)
+
+	if err := m1.Unlock(ctx); !errors.Is(err, ErrMutexNotLocked) {
+		t.Fatalf("expected ErrMutexNotLocked, got %v", err)
+	}
+
+	if err := m1.Lock(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if ok, err := m2.TryLock(ctx); err != nil || ok {
+		t.Fatalf("locked a held mutex: %t, %v", ok, err)
+	}
+
+	// The lock is renewed past its TTL while it is held
+	waitCtx, cancel := context.WithTimeout(ctx, 400*time.Millisecond)
+	err = m2.Lock(waitCtx)
+	cancel()
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected the wait to time out, got %v", err)
+	}
+
+	if err := m1.Unlock(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := m2.Lock(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	// Another process busts the lock, so it cannot be renewed
+	if _, err := db.ExecContext(ctx, `DELETE FROM "bob_locks"`); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case <-m2.Lost():
+	case <-time.After(time.Second):
+		t.Fatal("the lost lock was not reported")
+	}
+
+	if err := m2.Unlock(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestMutexLockTableExpired(t *testing.T) {
+	ctx := context.Background()
+
+	sqlDB, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	sqlDB.SetMaxOpenConns(1)
+	db := NewDB(sqlDB)
+
+	if _, err := db.ExecContext(ctx, LockTableSchema(d, DefaultLockTable)); err != nil {
+		t.Fatal(err)
+	}
+
+	// A process that crashed while holding the lock
+	_, err = db.ExecContext(ctx, `INSERT INTO "bob_locks" (name, owner, expires_at) VALUES ($1, $2, $3)`,
+		"job", "crashed", time.Now().Add(-time.Second).UnixMilli())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	m := Mutex(db, "job", MutexOptions{Backend: LockTable, Dialect: d})
+	if ok, err := m.TryLock(ctx); err != nil || !ok {
+		t.Fatalf("expected the expired lock to be taken over: %t, %v", ok, err)
+	}
+
+	if err := m.Unlock(ctx); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,49 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/stephenafamo/scan"
+)
+
+// NoPrepare wraps an [Executor] so that it can be used with the helpers that
+// prepare statements, such as [Prepare] and [PrepareQuery], without preparing
+// anything on the server. The statements it returns send their query with the
+// args every time they are executed, as a normal query.
+//
+// This is needed with connection poolers in transaction mode, such as pgbouncer,
+// where a statement prepared on one server connection can be executed on another.
+// The driver must also be configured not to prepare normal queries,
+// e.g. with default_query_exec_mode=exec or simple_protocol in pgx.
+//
+// Transactions started from the wrapped executor have to be wrapped as well
+func NoPrepare(exec Executor) Preparer {
+	return noPrepareExecutor{exec}
+}
+
+type noPrepareExecutor struct {
+	Executor
+}
+
+// Unwrap returns the wrapped executor
+func (n noPrepareExecutor) Unwrap() Executor {
+	return n.Executor
+}
+
+func (n noPrepareExecutor) PrepareContext(ctx context.Context, query string) (Statement, error) {
+	return noPrepareStatement{exec: n.Executor, query: query}, nil
+}
+
+type noPrepareStatement struct {
+	exec  Executor
+	query string
+}
+
+func (n noPrepareStatement) ExecContext(ctx context.Context, args ...any) (sql.Result, error) {
+	return n.exec.ExecContext(ctx, n.query, args...)
+}
+
+func (n noPrepareStatement) QueryContext(ctx context.Context, args ...any) (scan.Rows, error) {
+	return n.exec.QueryContext(ctx, n.query, args...)
+}
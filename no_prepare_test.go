@@ -0,0 +1,62 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"reflect"
+	"testing"
+)
+
+type argsExecutor struct {
+	NoopExecutor
+	queries *[]string
+	args    *[][]any
+}
+
+func (a argsExecutor) ExecContext(_ context.Context, query string, args ...any) (sql.Result, error) {
+	*a.queries = append(*a.queries, query)
+	*a.args = append(*a.args, args)
+	return dryRunResult{}, nil
+}
+
+func TestNoPrepare(t *testing.T) {
+	ctx := context.Background()
+
+	var queries []string
+	var args [][]any
+	exec := NoPrepare(argsExecutor{queries: &queries, args: &args})
+
+	q := BaseQuery[Expression]{
+		Expression: ExpressionFunc(func(w io.Writer, d Dialect, start int) ([]any, error) {
+			w.Write([]byte("DELETE FROM users WHERE id = "))
+			d.WriteArg(w, start)
+			return []any{nil}, nil
+		}),
+		Dialect: d,
+	}
+
+	stmt, err := Prepare(ctx, exec, q)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(queries) != 0 {
+		t.Fatalf("expected nothing to be sent when preparing, got %q", queries)
+	}
+
+	for _, id := range []int{1, 2} {
+		if _, err := stmt.Exec(ctx, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expected := []string{"DELETE FROM users WHERE id = $1", "DELETE FROM users WHERE id = $1"}
+	if !reflect.DeepEqual(queries, expected) {
+		t.Fatalf("wrong queries\nexpected: %q\n     got: %q", expected, queries)
+	}
+
+	if !reflect.DeepEqual(args, [][]any{{1}, {2}}) {
+		t.Fatalf("wrong args: %#v", args)
+	}
+}
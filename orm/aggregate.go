@@ -0,0 +1,25 @@
+package orm
+
+import "github.com/stephenafamo/bob"
+
+// TypedColumn is a column with the Go type of its values,
+// so that the results of aggregates over the column are typed
+type TypedColumn[T any] struct {
+	bob.Expression
+}
+
+// GroupCount is the number of rows with a value of a column
+type GroupCount[T any] struct {
+	Value T     `db:"value"`
+	Count int64 `db:"count"`
+}
+
+// GroupCountMap returns the counts keyed by their value
+func GroupCountMap[T comparable](groups []GroupCount[T]) map[T]int64 {
+	m := make(map[T]int64, len(groups))
+	for _, g := range groups {
+		m[g.Value] = g.Count
+	}
+
+	return m
+}
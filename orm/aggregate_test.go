@@ -0,0 +1,18 @@
+package orm
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestGroupCountMap(t *testing.T) {
+	got := GroupCountMap([]GroupCount[string]{
+		{Value: "sent", Count: 12},
+		{Value: "draft", Count: 3},
+	})
+
+	want := map[string]int64{"sent": 12, "draft": 3}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,89 @@
+package orm
+
+import (
+	"context"
+	"sync"
+
+	"github.com/stephenafamo/bob"
+)
+
+// Operation is the kind of change in a [ChangeEvent]
+type Operation string
+
+const (
+	OpInsert Operation = "INSERT"
+	OpUpsert Operation = "UPSERT"
+	OpUpdate Operation = "UPDATE"
+	OpDelete Operation = "DELETE"
+)
+
+// ChangeEvent describes a row changed through a table model
+type ChangeEvent struct {
+	// The name of the table, including the schema if it has one
+	Table     string
+	Operation Operation
+	// The values of the primary key columns of the row
+	PK []any
+	// The columns set by the insert, upsert or update. Empty for deletes
+	Columns []string
+	// The model of the row, e.g. *models.User.
+	// For updates, it is the row given to Update, which the psql and mysql
+	// tables overwrite with the set values. The old values are not kept
+	Row any
+}
+
+// ChangeHandler is called with the changes published to [Changes]
+type ChangeHandler func(context.Context, ChangeEvent)
+
+// Changes notifies handlers of the rows inserted, upserted, updated and deleted
+// through the methods of table models, e.g. for cache invalidation or an outbox.
+// It is opt-in: set it as the Changes field of the tables to watch.
+//
+// If the executor is a transaction that implements [bob.AfterCommitter],
+// such as [bob.Tx], or wraps one, the events are published after the transaction
+// is committed, and never if it is rolled back. Otherwise they are published
+// as soon as the operation and its hooks succeeded.
+// Writes made with query builders, such as UpdateQ and DeleteQ, are not published
+type Changes struct {
+	mu       sync.RWMutex
+	handlers []ChangeHandler
+}
+
+// Subscribe registers a handler for all the published events
+func (c *Changes) Subscribe(handler ChangeHandler) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.handlers = append(c.handlers, handler)
+}
+
+// SubscribeChan sends all the published events to the channel.
+// Publishing blocks while the channel is full
+func (c *Changes) SubscribeChan(ch chan<- ChangeEvent) {
+	c.Subscribe(func(_ context.Context, e ChangeEvent) {
+		ch <- e
+	})
+}
+
+// Publish calls the handlers with the events, after the transaction is
+// committed if exec is in one. See [bob.AfterCommit]
+func (c *Changes) Publish(ctx context.Context, exec bob.Executor, events ...ChangeEvent) {
+	if c == nil || len(events) == 0 {
+		return
+	}
+
+	publish := func() {
+		c.mu.RLock()
+		defer c.mu.RUnlock()
+
+		for _, e := range events {
+			for _, h := range c.handlers {
+				h(ctx, e)
+			}
+		}
+	}
+
+	if err := bob.AfterCommit(exec, publish); err != nil {
+		publish()
+	}
+}
@@ -18,6 +18,10 @@ type Columns struct {
 	names       []string
 	aggFunc     [2]string
 	aliasPrefix string
+	// columns read with COALESCE from a second column, keyed by name
+	fallbacks map[string]string
+	// columns read from an SQL expression instead of a column, keyed by name
+	computed map[string]string
 }
 
 // Names returns the names of the columns
@@ -44,6 +48,31 @@ func (c Columns) WithPrefix(prefix string) Columns {
 	return c
 }
 
+// WithFallback reads the column as COALESCE(column, fallback), e.g. while a column is
+// renamed and the rows that were written to the old column are not yet copied
+func (c Columns) WithFallback(column, fallback string) Columns {
+	fallbacks := make(map[string]string, len(c.fallbacks)+1)
+	for k, v := range c.fallbacks {
+		fallbacks[k] = v
+	}
+	fallbacks[column] = fallback
+	c.fallbacks = fallbacks
+	return c
+}
+
+// WithComputed reads the column from the SQL expression, e.g. for a computed field of a model
+// that is not stored in the table. The expression is written as is, so the columns
+// it uses are not qualified with the parent
+func (c Columns) WithComputed(column, expression string) Columns {
+	computed := make(map[string]string, len(c.computed)+1)
+	for k, v := range c.computed {
+		computed[k] = v
+	}
+	computed[column] = expression
+	c.computed = computed
+	return c
+}
+
 // Only drops other column names from the column set
 func (c Columns) Only(cols ...string) Columns {
 	c.names = Only(c.names, cols...)
@@ -68,15 +97,19 @@ func (c Columns) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error)
 		}
 
 		w.Write([]byte(c.aggFunc[0]))
-		for _, part := range c.parent {
-			if part == "" {
-				continue
-			}
-			d.WriteQuoted(w, part)
-			w.Write([]byte("."))
+		if expression, ok := c.computed[col]; ok {
+			w.Write([]byte("("))
+			w.Write([]byte(expression))
+			w.Write([]byte(")"))
+		} else if fallback, ok := c.fallbacks[col]; ok {
+			w.Write([]byte("COALESCE("))
+			c.writeColumn(w, d, col)
+			w.Write([]byte(", "))
+			c.writeColumn(w, d, fallback)
+			w.Write([]byte(")"))
+		} else {
+			c.writeColumn(w, d, col)
 		}
-
-		d.WriteQuoted(w, col)
 		w.Write([]byte(c.aggFunc[1]))
 
 		w.Write([]byte(" AS "))
@@ -86,6 +119,18 @@ func (c Columns) WriteSQL(w io.Writer, d bob.Dialect, start int) ([]any, error)
 	return nil, nil
 }
 
+func (c Columns) writeColumn(w io.Writer, d bob.Dialect, col string) {
+	for _, part := range c.parent {
+		if part == "" {
+			continue
+		}
+		d.WriteQuoted(w, part)
+		w.Write([]byte("."))
+	}
+
+	d.WriteQuoted(w, col)
+}
+
 // Only drops other column names from the column set
 func Only(cols []string, includes ...string) []string {
 	filtered := make([]string, 0, len(includes)) // max capacity is the only list
@@ -14,4 +14,8 @@ type (
 	SkipQueryHooksKey struct{}
 	// If set to true, model hooks are skipped
 	SkipModelHooksKey struct{}
+	// If set to true, table writes run in a savepoint in transactions
+	SavepointsKey struct{}
+	// If set to true, table writes validate the setters
+	ValidateKey struct{}
 )
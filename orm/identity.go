@@ -0,0 +1,172 @@
+package orm
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/stephenafamo/scan"
+)
+
+type identityMapKey struct{}
+
+// IdentityMap keeps one model per table and primary key, so that loading the
+// same row twice returns the same pointer. It is meant to live as long as a
+// request, and is added to a context with [WithIdentityMap].
+//
+// Rows are kept as they were first loaded, so rows changed in the database
+// in the meantime are not refreshed
+type IdentityMap struct {
+	mu   sync.Mutex
+	rows map[string]any
+}
+
+// WithIdentityMap returns a context with a new identity map.
+// Models loaded by tables and views with this context are added to it, and rows that
+// are already in it are returned as the pointers that were loaded first.
+// This includes the models loaded by the relationship loaders
+func WithIdentityMap(ctx context.Context) context.Context {
+	return context.WithValue(ctx, identityMapKey{}, &IdentityMap{rows: make(map[string]any)})
+}
+
+// IdentityMapFrom returns the identity map of the context, or nil
+func IdentityMapFrom(ctx context.Context) *IdentityMap {
+	m, _ := ctx.Value(identityMapKey{}).(*IdentityMap)
+	return m
+}
+
+// WithoutIdentityMap returns a context that hides the identity map of ctx.
+// It is used to scan the rows returned by inserts and upserts, which must not be
+// replaced by the rows loaded before
+func WithoutIdentityMap(ctx context.Context) context.Context {
+	if IdentityMapFrom(ctx) == nil {
+		return ctx
+	}
+
+	return context.WithValue(ctx, identityMapKey{}, (*IdentityMap)(nil))
+}
+
+// Get returns the model of the table with the given primary key
+func (m *IdentityMap) Get(table string, pk ...any) (any, bool) {
+	if m == nil {
+		return nil, false
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	row, ok := m.rows[identityKey(table, pk)]
+	return row, ok
+}
+
+// Put adds a model of the table, replacing the one with the same primary key
+func (m *IdentityMap) Put(table string, row any, pk ...any) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.rows[identityKey(table, pk)] = row
+}
+
+// Forget removes the model of the table with the given primary key,
+// e.g. after it is deleted
+func (m *IdentityMap) Forget(table string, pk ...any) {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	delete(m.rows, identityKey(table, pk))
+}
+
+// Clear removes every model
+func (m *IdentityMap) Clear() {
+	if m == nil {
+		return
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.rows = make(map[string]any)
+}
+
+// The values are written with their type, so that 1 and "1" are different keys
+func identityKey(table string, pk []any) string {
+	var b strings.Builder
+	b.WriteString(table)
+	for _, v := range pk {
+		fmt.Fprintf(&b, "\x00%#v", v)
+	}
+
+	return b.String()
+}
+
+// IdentityMapper wraps the mapper of a table or view so that the rows are
+// looked up in the identity map of the context, if it has one.
+// pk returns the primary key of a model, or nil if it has none.
+//
+// Only pointer models are kept, and only when all the columns were
+// selected, so that a partially loaded model is never returned for another query
+func IdentityMapper[T any](table string, columns []string, pk func(T) []any, m scan.Mapper[T]) scan.Mapper[T] {
+	if reflect.TypeOf((*T)(nil)).Elem().Kind() != reflect.Pointer {
+		return m
+	}
+
+	return func(ctx context.Context, cols []string) (scan.BeforeFunc, func(any) (T, error)) {
+		before, after := m(ctx, cols)
+
+		identities := IdentityMapFrom(ctx)
+		if identities == nil {
+			return before, after
+		}
+
+		complete := hasAllColumns(cols, columns)
+
+		return before, func(link any) (T, error) {
+			row, err := after(link)
+			if err != nil {
+				return row, err
+			}
+
+			key := pk(row)
+			if len(key) == 0 {
+				return row, nil
+			}
+
+			if existing, ok := identities.Get(table, key...); ok {
+				if t, ok := existing.(T); ok {
+					return t, nil
+				}
+			}
+
+			if complete {
+				identities.Put(table, row, key...)
+			}
+
+			return row, nil
+		}
+	}
+}
+
+func hasAllColumns(cols, columns []string) bool {
+	selected := make(map[string]struct{}, len(cols))
+	for _, c := range cols {
+		selected[c] = struct{}{}
+	}
+
+	for _, c := range columns {
+		if _, ok := selected[c]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,133 @@
+package orm
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+
+	"github.com/aarondl/opt"
+	"github.com/stephenafamo/scan"
+)
+
+// JoinPart maps some of the columns of a joined row into T
+type JoinPart[T any] func(ctx context.Context, cols []string) (scan.BeforeFunc, func(*T, any) error)
+
+// Join returns a part that maps the columns that start with prefix into P
+// with the same rules as [StructMapper], and passes it to set.
+// If all the columns are NULL, as for the missing side of an outer join,
+// set is called with the zero value of P
+func Join[T, P any](prefix string, set func(*T, P)) JoinPart[T] {
+	return func(ctx context.Context, cols []string) (scan.BeforeFunc, func(*T, any) error) {
+		before, after := StructMapper[P](
+			scan.WithStructTagPrefix(prefix),
+			scan.WithTypeConverter(joinTypeConverter{}),
+			scan.WithRowValidator(joinRowValidator),
+		)(ctx, cols)
+
+		return before, func(t *T, link any) error {
+			p, err := after(link)
+			if err != nil {
+				return err
+			}
+
+			set(t, p)
+			return nil
+		}
+	}
+}
+
+// JoinMapper returns a mapper that splits each row of a join query
+// between the given parts, so that a join does not need a flat struct.
+//
+//	type UserPost struct {
+//		User *models.User
+//		Post *models.Post
+//	}
+//
+//	orm.JoinMapper(
+//		orm.Join("users.", func(r *UserPost, u *models.User) { r.User = u }),
+//		orm.Join("posts.", func(r *UserPost, p *models.Post) { r.Post = p }),
+//	)
+//
+// The columns should be selected with a matching prefix,
+// e.g. with models.UserColumns.WithPrefix("users.")
+func JoinMapper[T any](parts ...JoinPart[T]) scan.Mapper[T] {
+	return func(ctx context.Context, cols []string) (scan.BeforeFunc, func(any) (T, error)) {
+		befores := make([]scan.BeforeFunc, len(parts))
+		afters := make([]func(*T, any) error, len(parts))
+		for i, part := range parts {
+			befores[i], afters[i] = part(ctx, cols)
+		}
+
+		return func(row *scan.Row) (any, error) {
+				links := make([]any, len(befores))
+				for i, before := range befores {
+					link, err := before(row)
+					if err != nil {
+						return nil, err
+					}
+					links[i] = link
+				}
+
+				return links, nil
+			}, func(link any) (T, error) {
+				var t T
+				links, _ := link.([]any)
+				for i, after := range afters {
+					if err := after(&t, links[i]); err != nil {
+						return t, err
+					}
+				}
+
+				return t, nil
+			}
+	}
+}
+
+// the part is valid if at least one column is not null
+func joinRowValidator(_ []string, vals []reflect.Value) bool {
+	for _, v := range vals {
+		v, ok := v.Interface().(*joinWrapper)
+		if !ok {
+			return false
+		}
+
+		if !v.IsNull {
+			return true
+		}
+	}
+
+	return false
+}
+
+type joinWrapper struct {
+	IsNull bool
+	V      any
+}
+
+// Scan implements the sql.Scanner interface. If the wrapped type implements
+// sql.Scanner then it will call that.
+func (v *joinWrapper) Scan(value any) error {
+	if value == nil {
+		v.IsNull = true
+		return nil
+	}
+
+	if scanner, ok := v.V.(sql.Scanner); ok {
+		return scanner.Scan(value)
+	}
+
+	return opt.ConvertAssign(v.V, value)
+}
+
+type joinTypeConverter struct{}
+
+func (joinTypeConverter) TypeToDestination(typ reflect.Type) reflect.Value {
+	return reflect.ValueOf(&joinWrapper{
+		V: reflect.New(typ).Interface(),
+	})
+}
+
+func (joinTypeConverter) ValueFromDestination(val reflect.Value) reflect.Value {
+	return val.Elem().FieldByName("V").Elem().Elem()
+}
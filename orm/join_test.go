@@ -0,0 +1,53 @@
+package orm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stephenafamo/scan"
+)
+
+func TestJoinMapper(t *testing.T) {
+	type User struct {
+		ID   int    `db:"id"`
+		Name string `db:"name"`
+	}
+
+	type Post struct {
+		ID     int    `db:"id"`
+		UserID int    `db:"user_id"`
+		Title  string `db:"title"`
+	}
+
+	type UserPost struct {
+		User *User
+		Post *Post
+	}
+
+	rows := &mapperRows{
+		columns: []string{"users.id", "users.name", "posts.id", "posts.user_id", "posts.title"},
+		rows: [][]any{
+			{1, "Stephen", 10, 1, "Hello"},
+			{2, "Bob", nil, nil, nil},
+		},
+	}
+
+	mapper := JoinMapper(
+		Join("users.", func(r *UserPost, u *User) { r.User = u }),
+		Join("posts.", func(r *UserPost, p *Post) { r.Post = p }),
+	)
+
+	results, err := scan.AllFromRows(context.Background(), mapper, rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []UserPost{
+		{User: &User{ID: 1, Name: "Stephen"}, Post: &Post{ID: 10, UserID: 1, Title: "Hello"}},
+		{User: &User{ID: 2, Name: "Bob"}},
+	}
+	if diff := cmp.Diff(expected, results); diff != "" {
+		t.Fatal(diff)
+	}
+}
@@ -0,0 +1,17 @@
+package orm
+
+import "github.com/stephenafamo/scan"
+
+//nolint:gochecknoglobals
+var structMapperSource, _ = scan.NewStructMapperSource(scan.WithColumnSeparator(""))
+
+// StructMapper is the mapper used for models.
+// It is the same as scan.StructMapper except that the tag of a nested struct
+// field is used as the prefix of its columns without a separator.
+//
+// A field `Billing Address` tagged `db:"billing_"` is mapped from the billing_street
+// and billing_city columns. Use a tag such as `db:"user."` to get the
+// dotted columns of scan.StructMapper.
+func StructMapper[T any](opts ...scan.MappingOption) scan.Mapper[T] {
+	return scan.CustomStructMapper[T](structMapperSource, opts...)
+}
@@ -0,0 +1,70 @@
+package orm
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stephenafamo/scan"
+)
+
+type mapperRows struct {
+	columns []string
+	rows    [][]any
+	index   int
+}
+
+func (r *mapperRows) Scan(dest ...any) error {
+	for i, v := range r.rows[r.index-1] {
+		switch d := dest[i].(type) {
+		case sql.Scanner:
+			if err := d.Scan(v); err != nil {
+				return err
+			}
+		case *int:
+			*d = v.(int)
+		case *string:
+			*d = v.(string)
+		}
+	}
+
+	return nil
+}
+
+func (r *mapperRows) Columns() ([]string, error) { return r.columns, nil }
+func (r *mapperRows) Next() bool                 { r.index++; return r.index <= len(r.rows) }
+func (r *mapperRows) Close() error               { return nil }
+func (r *mapperRows) Err() error                 { return nil }
+
+func TestStructMapper(t *testing.T) {
+	type Address struct {
+		Street string `db:"street"`
+		City   string `db:"city"`
+	}
+
+	type Customer struct {
+		ID       int     `db:"id"`
+		Billing  Address `db:"billing_"`
+		Shipping Address `db:"shipping_"`
+	}
+
+	rows := &mapperRows{
+		columns: []string{"id", "billing_street", "billing_city", "shipping_street", "shipping_city"},
+		rows:    [][]any{{1, "1 Main St", "Lagos", "2 High St", "Accra"}},
+	}
+
+	customers, err := scan.AllFromRows(context.Background(), StructMapper[Customer](), rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []Customer{{
+		ID:       1,
+		Billing:  Address{Street: "1 Main St", City: "Lagos"},
+		Shipping: Address{Street: "2 High St", City: "Accra"},
+	}}
+	if diff := cmp.Diff(expected, customers); diff != "" {
+		t.Fatal(diff)
+	}
+}
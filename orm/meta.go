@@ -0,0 +1,201 @@
+package orm
+
+// TableMeta is the structure of a table as it was when the models were generated,
+// so that generic code, e.g. a deduplicator or an admin UI, can use the keys
+// and indexes of a model without reading them from the database
+type TableMeta struct {
+	// The name of the table, with the schema if it is not the default schema
+	Key    string
+	Schema string
+	Name   string
+	// The names of the columns of the model
+	Columns []string
+	// The columns of the primary key, empty for views
+	PrimaryKey  []string
+	Uniques     []ConstraintMeta
+	Indexes     []IndexMeta
+	ForeignKeys []ForeignKeyMeta
+}
+
+// ConstraintMeta is a unique constraint
+type ConstraintMeta struct {
+	Name    string
+	Columns []string
+}
+
+// IndexMeta is an index, other than the index of the primary key.
+// The columns of an expression index are the expressions
+type IndexMeta struct {
+	Name    string
+	Columns []string
+	Unique  bool
+}
+
+// ForeignKeyMeta is a foreign key. The foreign table is a key of a [TableMeta]
+type ForeignKeyMeta struct {
+	Name           string
+	Columns        []string
+	ForeignTable   string
+	ForeignColumns []string
+}
+
+// IsUnique returns true if the columns identify a row, because they are the
+// primary key or have a unique constraint or unique index, in any order
+func (t TableMeta) IsUnique(columns ...string) bool {
+	if len(t.PrimaryKey) > 0 && sameColumns(t.PrimaryKey, columns) {
+		return true
+	}
+
+	for _, u := range t.Uniques {
+		if sameColumns(u.Columns, columns) {
+			return true
+		}
+	}
+
+	for _, i := range t.Indexes {
+		if i.Unique && sameColumns(i.Columns, columns) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// UniqueKeys returns the sets of columns that identify a row:
+// the primary key, the unique constraints and the unique indexes
+func (t TableMeta) UniqueKeys() [][]string {
+	var keys [][]string
+	add := func(columns []string) {
+		for _, key := range keys {
+			if sameColumns(key, columns) {
+				return
+			}
+		}
+		keys = append(keys, columns)
+	}
+
+	if len(t.PrimaryKey) > 0 {
+		add(t.PrimaryKey)
+	}
+	for _, u := range t.Uniques {
+		add(u.Columns)
+	}
+	for _, i := range t.Indexes {
+		if i.Unique {
+			add(i.Columns)
+		}
+	}
+
+	return keys
+}
+
+// SchemaMeta is the structure of the tables of a models package
+type SchemaMeta struct {
+	Tables []TableMeta
+}
+
+// Table returns the table with the key
+func (s SchemaMeta) Table(key string) (TableMeta, bool) {
+	for _, t := range s.Tables {
+		if t.Key == key {
+			return t, true
+		}
+	}
+
+	return TableMeta{}, false
+}
+
+// Reference is a foreign key of a table
+type Reference struct {
+	Table      string
+	ForeignKey ForeignKeyMeta
+}
+
+// ReferencedBy returns the foreign keys that reference the table,
+// e.g. to find the rows to delete before a row of the table is deleted
+func (s SchemaMeta) ReferencedBy(key string) []Reference {
+	var refs []Reference
+	for _, t := range s.Tables {
+		for _, fk := range t.ForeignKeys {
+			if fk.ForeignTable == key {
+				refs = append(refs, Reference{Table: t.Key, ForeignKey: fk})
+			}
+		}
+	}
+
+	return refs
+}
+
+// DependencyOrder returns the keys of the tables ordered so that every table
+// comes after the tables its foreign keys reference, which is an order in which
+// rows can be inserted, and in reverse, deleted. Tables in a cycle are added in
+// the order of s.Tables after the others
+func (s SchemaMeta) DependencyOrder() []string {
+	done := make(map[string]bool, len(s.Tables))
+	order := make([]string, 0, len(s.Tables))
+
+	for len(order) < len(s.Tables) {
+		progress := false
+		for _, t := range s.Tables {
+			if done[t.Key] {
+				continue
+			}
+
+			ready := true
+			for _, fk := range t.ForeignKeys {
+				if fk.ForeignTable != t.Key && !done[fk.ForeignTable] && s.has(fk.ForeignTable) {
+					ready = false
+					break
+				}
+			}
+			if !ready {
+				continue
+			}
+
+			done[t.Key] = true
+			order = append(order, t.Key)
+			progress = true
+		}
+
+		if progress {
+			continue
+		}
+
+		// a cycle, add the first remaining table
+		for _, t := range s.Tables {
+			if !done[t.Key] {
+				done[t.Key] = true
+				order = append(order, t.Key)
+				break
+			}
+		}
+	}
+
+	return order
+}
+
+func (s SchemaMeta) has(key string) bool {
+	_, ok := s.Table(key)
+	return ok
+}
+
+func sameColumns(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+
+	for _, x := range a {
+		found := false
+		for _, y := range b {
+			if x == y {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
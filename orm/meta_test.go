@@ -0,0 +1,114 @@
+package orm
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+var testSchema = SchemaMeta{
+	Tables: []TableMeta{
+		{
+			Key:        "comments",
+			Name:       "comments",
+			Columns:    []string{"id", "post_id", "user_id"},
+			PrimaryKey: []string{"id"},
+			ForeignKeys: []ForeignKeyMeta{
+				{Name: "comments_post_id_fkey", Columns: []string{"post_id"}, ForeignTable: "posts", ForeignColumns: []string{"id"}},
+				{Name: "comments_user_id_fkey", Columns: []string{"user_id"}, ForeignTable: "users", ForeignColumns: []string{"id"}},
+			},
+		},
+		{
+			Key:        "posts",
+			Name:       "posts",
+			Columns:    []string{"id", "user_id", "slug"},
+			PrimaryKey: []string{"id"},
+			Indexes: []IndexMeta{
+				{Name: "posts_user_id_slug_idx", Columns: []string{"user_id", "slug"}, Unique: true},
+				{Name: "posts_slug_idx", Columns: []string{"slug"}},
+			},
+			ForeignKeys: []ForeignKeyMeta{
+				{Name: "posts_user_id_fkey", Columns: []string{"user_id"}, ForeignTable: "users", ForeignColumns: []string{"id"}},
+			},
+		},
+		{
+			Key:        "users",
+			Name:       "users",
+			Columns:    []string{"id", "email", "invited_by"},
+			PrimaryKey: []string{"id"},
+			Uniques:    []ConstraintMeta{{Name: "users_email_key", Columns: []string{"email"}}},
+			Indexes:    []IndexMeta{{Name: "users_email_key", Columns: []string{"email"}, Unique: true}},
+			ForeignKeys: []ForeignKeyMeta{
+				{Name: "users_invited_by_fkey", Columns: []string{"invited_by"}, ForeignTable: "users", ForeignColumns: []string{"id"}},
+			},
+		},
+	},
+}
+
+func TestTableMetaIsUnique(t *testing.T) {
+	posts, ok := testSchema.Table("posts")
+	if !ok {
+		t.Fatal("posts not found")
+	}
+
+	tests := map[string]struct {
+		columns []string
+		unique  bool
+	}{
+		"primary key":     {columns: []string{"id"}, unique: true},
+		"unique index":    {columns: []string{"slug", "user_id"}, unique: true},
+		"index":           {columns: []string{"slug"}, unique: false},
+		"part of a key":   {columns: []string{"user_id"}, unique: false},
+		"more than a key": {columns: []string{"id", "slug"}, unique: false},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := posts.IsUnique(tc.columns...); got != tc.unique {
+				t.Fatalf("IsUnique(%v) = %t, want %t", tc.columns, got, tc.unique)
+			}
+		})
+	}
+}
+
+func TestTableMetaUniqueKeys(t *testing.T) {
+	users, _ := testSchema.Table("users")
+
+	want := [][]string{{"id"}, {"email"}}
+	if diff := cmp.Diff(want, users.UniqueKeys()); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestSchemaMetaReferencedBy(t *testing.T) {
+	var got []string
+	for _, ref := range testSchema.ReferencedBy("users") {
+		got = append(got, ref.Table+"."+ref.ForeignKey.Name)
+	}
+
+	want := []string{
+		"comments.comments_user_id_fkey",
+		"posts.posts_user_id_fkey",
+		"users.users_invited_by_fkey",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Fatal(diff)
+	}
+}
+
+func TestSchemaMetaDependencyOrder(t *testing.T) {
+	want := []string{"users", "posts", "comments"}
+	if diff := cmp.Diff(want, testSchema.DependencyOrder()); diff != "" {
+		t.Fatal(diff)
+	}
+
+	cycle := SchemaMeta{Tables: []TableMeta{
+		{Key: "a", ForeignKeys: []ForeignKeyMeta{{ForeignTable: "b"}}},
+		{Key: "b", ForeignKeys: []ForeignKeyMeta{{ForeignTable: "a"}}},
+		{Key: "c", ForeignKeys: []ForeignKeyMeta{{ForeignTable: "d"}}},
+	}}
+	want = []string{"c", "a", "b"}
+	if diff := cmp.Diff(want, cycle.DependencyOrder()); diff != "" {
+		t.Fatal(diff)
+	}
+}
@@ -0,0 +1,22 @@
+//go:build go1.22
+
+package orm
+
+import (
+	"database/sql"
+
+	"github.com/aarondl/opt/null"
+)
+
+// NullFromSQL converts a sql.Null to a null.Val.
+// It is used by generated models that represent nullable columns with sql.Null
+func NullFromSQL[T any](v sql.Null[T]) null.Val[T] {
+	return null.FromCond(v.V, v.Valid)
+}
+
+// NullToSQL converts a null.Val to a sql.Null.
+// It is used by generated models that represent nullable columns with sql.Null
+func NullToSQL[T any](v null.Val[T]) sql.Null[T] {
+	val, ok := v.Get()
+	return sql.Null[T]{V: val, Valid: ok}
+}
@@ -0,0 +1,75 @@
+package orm
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/stephenafamo/bob"
+)
+
+// transaction is implemented by transactions such as [bob.Tx]
+type transaction interface {
+	Commit() error
+	Rollback() error
+}
+
+// used to give every savepoint a unique name
+var savepointCount int64
+
+// WithSavepoints modifies a context so that the table methods that write rows,
+// such as Insert, Update, Upsert and Delete, run the query and its hooks in
+// a savepoint when the executor is a transaction.
+// If the query or one of its hooks fails, only the changes of that operation
+// are rolled back, and the caller can decide whether to continue the transaction
+func WithSavepoints(ctx context.Context) context.Context {
+	return context.WithValue(ctx, SavepointsKey{}, true)
+}
+
+// Savepoint calls fn in a savepoint if the context was modified with
+// [WithSavepoints] and the executor is a transaction, otherwise it only calls fn.
+// The executor is unwrapped with [bob.ExecutorWrapper] to find the transaction.
+// If fn returns an error, the changes made since the savepoint are rolled back
+// and the error is returned
+func Savepoint(ctx context.Context, exec bob.Executor, fn func() error) error {
+	enabled, _ := ctx.Value(SavepointsKey{}).(bool)
+	if !enabled || !inTransaction(exec) {
+		return fn()
+	}
+
+	name := fmt.Sprintf("bob_savepoint_%d", atomic.AddInt64(&savepointCount, 1))
+	if _, err := exec.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("creating savepoint: %w", err)
+	}
+
+	if err := fn(); err != nil {
+		if _, rbErr := exec.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rbErr != nil {
+			return fmt.Errorf("%w (rolling back to savepoint: %v)", err, rbErr)
+		}
+		return err
+	}
+
+	if _, err := exec.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		return fmt.Errorf("releasing savepoint: %w", err)
+	}
+
+	return nil
+}
+
+// inTransaction reports if the executor is a transaction,
+// or wraps one with [bob.ExecutorWrapper]
+func inTransaction(exec bob.Executor) bool {
+	for exec != nil {
+		if _, ok := exec.(transaction); ok {
+			return true
+		}
+
+		w, ok := exec.(bob.ExecutorWrapper)
+		if !ok {
+			break
+		}
+		exec = w.Unwrap()
+	}
+
+	return false
+}
@@ -0,0 +1,116 @@
+package orm
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/scan"
+	_ "modernc.org/sqlite"
+)
+
+func TestSavepoint(t *testing.T) {
+	t.Run("tx", func(t *testing.T) {
+		testSavepoint(t, func(tx bob.Tx) bob.Executor { return tx })
+	})
+
+	t.Run("wrapped tx", func(t *testing.T) {
+		testSavepoint(t, func(tx bob.Tx) bob.Executor {
+			return bob.InTimeZone(tx, time.UTC)
+		})
+	})
+}
+
+func testSavepoint(t *testing.T, wrap func(bob.Tx) bob.Executor) {
+	t.Helper()
+	ctx := context.Background()
+
+	sqlDB, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	sqlDB.SetMaxOpenConns(1)
+
+	db := bob.NewDB(sqlDB)
+	if _, err := db.ExecContext(ctx, "CREATE TABLE users (name TEXT NOT NULL)"); err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback()
+	exec := wrap(tx)
+
+	insert := func(ctx context.Context, name string, fail bool) error {
+		return Savepoint(ctx, exec, func() error {
+			if _, err := exec.ExecContext(ctx, "INSERT INTO users (name) VALUES (?)", name); err != nil {
+				return err
+			}
+			if fail {
+				return errors.New("hook failed")
+			}
+			return nil
+		})
+	}
+
+	spCtx := WithSavepoints(ctx)
+	if err := insert(spCtx, "Alice", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := insert(spCtx, "Bob", true); err == nil || err.Error() != "hook failed" {
+		t.Fatalf("expected the error of the hook, got %v", err)
+	}
+	// Without savepoints, the failed operation is not rolled back
+	if err := insert(ctx, "Carol", true); err == nil {
+		t.Fatal("expected an error")
+	}
+
+	if err := tx.Commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	names, err := scan.All(ctx, db, scan.SingleColumnMapper[string], "SELECT name FROM users ORDER BY name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != 2 || names[0] != "Alice" || names[1] != "Carol" {
+		t.Fatalf("expected only the failed operation in a savepoint to be rolled back, got %q", names)
+	}
+}
+
+func TestSavepointNotInTransaction(t *testing.T) {
+	var queries []string
+	exec := recordingExec{queries: &queries}
+
+	called := false
+	err := Savepoint(WithSavepoints(context.Background()), exec, func() error {
+		called = true
+		return nil
+	})
+	if err != nil || !called {
+		t.Fatalf("expected fn to be called, got %v", err)
+	}
+	if len(queries) != 0 {
+		t.Fatalf("expected no savepoint outside a transaction, got %q", queries)
+	}
+}
+
+type recordingExec struct {
+	queries *[]string
+}
+
+func (r recordingExec) ExecContext(_ context.Context, query string, _ ...any) (sql.Result, error) {
+	*r.queries = append(*r.queries, query)
+	return nil, nil
+}
+
+func (r recordingExec) QueryContext(_ context.Context, query string, _ ...any) (scan.Rows, error) {
+	*r.queries = append(*r.queries, query)
+	return nil, nil
+}
@@ -0,0 +1,76 @@
+package orm
+
+import (
+	"context"
+	"reflect"
+
+	"github.com/stephenafamo/scan"
+)
+
+// Selection is the set of columns that a model was loaded with.
+// The zero value is a model loaded with all its columns
+type Selection struct {
+	columns map[string]struct{}
+}
+
+// NewSelection returns a selection of the given columns
+func NewSelection(columns ...string) Selection {
+	s := Selection{columns: make(map[string]struct{}, len(columns))}
+	for _, c := range columns {
+		s.columns[c] = struct{}{}
+	}
+
+	return s
+}
+
+// Has reports if the column was selected
+func (s Selection) Has(column string) bool {
+	if s.columns == nil {
+		return true
+	}
+
+	_, ok := s.columns[column]
+	return ok
+}
+
+// Partial reports if some of the columns were not selected
+func (s Selection) Partial() bool {
+	return s.columns != nil
+}
+
+// SelectionSetter is implemented by models that record the columns they were loaded with,
+// so the fields of the columns that were not selected can be told apart from zero values
+type SelectionSetter interface {
+	SetSelection(Selection)
+}
+
+// SelectionMapper wraps the mapper of a table or view so that the rows
+// of a query that did not select all the columns get the selected columns,
+// if they implement [SelectionSetter]
+func SelectionMapper[T any](columns []string, m scan.Mapper[T]) scan.Mapper[T] {
+	if !reflect.TypeOf((*T)(nil)).Elem().Implements(reflect.TypeOf((*SelectionSetter)(nil)).Elem()) {
+		return m
+	}
+
+	return func(ctx context.Context, cols []string) (scan.BeforeFunc, func(any) (T, error)) {
+		before, after := m(ctx, cols)
+		if hasAllColumns(cols, columns) {
+			return before, after
+		}
+
+		selection := NewSelection(cols...)
+
+		return before, func(link any) (T, error) {
+			row, err := after(link)
+			if err != nil {
+				return row, err
+			}
+
+			if s, ok := any(row).(SelectionSetter); ok {
+				s.SetSelection(selection)
+			}
+
+			return row, nil
+		}
+	}
+}
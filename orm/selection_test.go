@@ -0,0 +1,47 @@
+package orm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stephenafamo/scan"
+)
+
+type selectedUser struct {
+	ID        int    `db:"id"`
+	Name      string `db:"name"`
+	Email     string `db:"email"`
+	selection Selection
+}
+
+func (u *selectedUser) SetSelection(s Selection) { u.selection = s }
+
+func TestSelectionMapper(t *testing.T) {
+	columns := []string{"id", "name", "email"}
+	mapper := SelectionMapper(columns, StructMapper[*selectedUser]())
+
+	all, err := scan.OneFromRows(context.Background(), mapper, &mapperRows{
+		columns: []string{"id", "name", "email"},
+		rows:    [][]any{{1, "Alice", "alice@example.com"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if all.selection.Partial() || !all.selection.Has("email") {
+		t.Fatal("a row with all the columns is marked as partial")
+	}
+
+	partial, err := scan.OneFromRows(context.Background(), mapper, &mapperRows{
+		columns: []string{"id", "name"},
+		rows:    [][]any{{1, "Alice"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !partial.selection.Partial() {
+		t.Fatal("a row without all the columns is not marked as partial")
+	}
+	if !partial.selection.Has("name") || partial.selection.Has("email") {
+		t.Fatalf("unexpected selection: %v", partial.selection.columns)
+	}
+}
@@ -0,0 +1,28 @@
+package orm
+
+import "strings"
+
+// pathLikeEscaper escapes the LIKE wildcards of a path, with ! as the escape character,
+// which is the same in every dialect unlike the backslash
+var pathLikeEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_") //nolint:gochecknoglobals
+
+// PathAncestors returns the paths of the ancestors of the node with the materialized path,
+// the root first. The path is the keys of the node and its ancestors joined by the
+// separator, without a leading or trailing separator: "1/4/9" has the ancestors "1" and "1/4"
+func PathAncestors(path, sep string) []string {
+	var ancestors []string
+	for i := 0; i+len(sep) <= len(path); i++ {
+		if path[i:i+len(sep)] == sep {
+			ancestors = append(ancestors, path[:i])
+			i += len(sep) - 1
+		}
+	}
+
+	return ancestors
+}
+
+// PathDescendantsPattern returns the LIKE pattern that matches the paths of the descendants
+// of the node with the materialized path. It must be used with ESCAPE '!'
+func PathDescendantsPattern(path, sep string) string {
+	return pathLikeEscaper.Replace(path+sep) + "%"
+}
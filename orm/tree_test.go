@@ -0,0 +1,46 @@
+package orm
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPathAncestors(t *testing.T) {
+	tests := map[string]struct {
+		path, sep string
+		expected  []string
+	}{
+		"root":      {path: "1", sep: "/"},
+		"nested":    {path: "1/4/9", sep: "/", expected: []string{"1", "1/4"}},
+		"long sep":  {path: "a::b::c", sep: "::", expected: []string{"a", "a::b"}},
+		"dot paths": {path: "1.10.100", sep: ".", expected: []string{"1", "1.10"}},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.expected, PathAncestors(tc.path, tc.sep)); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
+func TestPathDescendantsPattern(t *testing.T) {
+	tests := map[string]struct {
+		path, sep string
+		expected  string
+	}{
+		"plain":     {path: "1/4", sep: "/", expected: "1/4/%"},
+		"wildcards": {path: "a_b/100%", sep: "/", expected: "a!_b/100!%/%"},
+		"escape":    {path: "a!b", sep: ".", expected: "a!!b.%"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := PathDescendantsPattern(tc.path, tc.sep); got != tc.expected {
+				t.Fatalf("expected %q, got %q", tc.expected, got)
+			}
+		})
+	}
+}
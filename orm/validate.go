@@ -0,0 +1,179 @@
+package orm
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validator is implemented by setters that check their values
+// against the constraints of the table before they are written
+type Validator interface {
+	Validate() error
+}
+
+// InsertValidator is implemented by setters that also check
+// that the values without a default are set before an insert
+type InsertValidator interface {
+	ValidateInsert() error
+}
+
+// WithValidation modifies a context so that the table methods that write rows,
+// Insert, Update and Upsert, validate the setters before the query is run.
+// Without it, invalid values are only rejected by the database
+func WithValidation(ctx context.Context) context.Context {
+	return context.WithValue(ctx, ValidateKey{}, true)
+}
+
+// validating reports if the context was modified with [WithValidation],
+// and the model hooks are not skipped
+func validating(ctx context.Context) bool {
+	if skip, ok := ctx.Value(SkipModelHooksKey{}).(bool); skip && ok {
+		return false
+	}
+
+	enabled, _ := ctx.Value(ValidateKey{}).(bool)
+	return enabled
+}
+
+// ValidateInsert validates the setters of an insert or upsert if the context was modified
+// with [WithValidation]. It is skipped like the model hooks, see [SkipModelHooks]
+func ValidateInsert[T any](ctx context.Context, rows ...T) error {
+	if !validating(ctx) {
+		return nil
+	}
+
+	for _, row := range rows {
+		switch v := any(row).(type) {
+		case InsertValidator:
+			if err := v.ValidateInsert(); err != nil {
+				return err
+			}
+		case Validator:
+			if err := v.Validate(); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateUpdate validates the setter of an update if the context was modified
+// with [WithValidation]. It is skipped like the model hooks, see [SkipModelHooks]
+func ValidateUpdate[T any](ctx context.Context, vals T) error {
+	if !validating(ctx) {
+		return nil
+	}
+
+	if v, ok := any(vals).(Validator); ok {
+		return v.Validate()
+	}
+
+	return nil
+}
+
+// FieldError is a value of a column that the database would reject
+type FieldError struct {
+	Column string
+	// The broken rule: required, max_length, precision or the name of a check constraint
+	Rule    string
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Column, e.Message)
+}
+
+// ValidationError is returned by the validation of a setter, with an error for each invalid value
+type ValidationError struct {
+	Table  string
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	msgs := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		msgs[i] = f.Error()
+	}
+
+	return fmt.Sprintf("invalid %s: %s", e.Table, strings.Join(msgs, "; "))
+}
+
+// Validation collects the field errors of a setter. It is used by the generated Validate methods
+type Validation struct {
+	table  string
+	fields []FieldError
+}
+
+// NewValidation starts the validation of a setter of the table
+func NewValidation(table string) *Validation {
+	return &Validation{table: table}
+}
+
+// Required adds an error if a column that has no default and cannot be null is not set
+func (v *Validation) Required(column string, set bool) {
+	if !set {
+		v.fields = append(v.fields, FieldError{Column: column, Rule: "required", Message: "is required"})
+	}
+}
+
+// MaxLength adds an error if the value has more than max characters
+func (v *Validation) MaxLength(column, value string, max int) {
+	if n := utf8.RuneCountInString(value); n > max {
+		v.fields = append(v.fields, FieldError{
+			Column:  column,
+			Rule:    "max_length",
+			Message: fmt.Sprintf("has %d characters, at most %d are allowed", n, max),
+		})
+	}
+}
+
+// Precision adds an error if the number has more digits before the decimal
+// point than a numeric column with the precision and scale allows.
+// The value is formatted with fmt, so decimal types with a String method work.
+// Digits after the scale are rounded by the database, so they are allowed
+func (v *Validation) Precision(column string, value any, precision, scale int) {
+	s := fmt.Sprint(value)
+	if strings.ContainsAny(s, "eE") {
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return
+		}
+		s = strconv.FormatFloat(f, 'f', -1, 64)
+	}
+
+	s = strings.TrimLeft(s, "+-")
+	s, _, _ = strings.Cut(s, ".")
+	s = strings.TrimLeft(s, "0")
+
+	if max := precision - scale; len(s) > max {
+		v.fields = append(v.fields, FieldError{
+			Column:  column,
+			Rule:    "precision",
+			Message: fmt.Sprintf("has %d digits before the decimal point, at most %d are allowed", len(s), max),
+		})
+	}
+}
+
+// Check adds an error if the value of the column does not pass the check constraint
+func (v *Validation) Check(column, constraint, expression string, ok bool) {
+	if !ok {
+		v.fields = append(v.fields, FieldError{
+			Column:  column,
+			Rule:    constraint,
+			Message: fmt.Sprintf("does not pass the check %s", expression),
+		})
+	}
+}
+
+// Err returns a *ValidationError with the field errors, or nil if there are none
+func (v *Validation) Err() error {
+	if len(v.fields) == 0 {
+		return nil
+	}
+
+	return &ValidationError{Table: v.table, Fields: v.fields}
+}
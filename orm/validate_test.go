@@ -0,0 +1,77 @@
+package orm
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type validatedSetter struct {
+	name string
+	set  bool
+}
+
+func (s validatedSetter) Validate() error {
+	v := NewValidation("users")
+	v.MaxLength("name", s.name, 3)
+	return v.Err()
+}
+
+func (s validatedSetter) ValidateInsert() error {
+	v := NewValidation("users")
+	v.Required("name", s.set)
+	v.MaxLength("name", s.name, 3)
+	return v.Err()
+}
+
+func TestValidation(t *testing.T) {
+	v := NewValidation("products")
+	v.Required("sku", true)
+	v.MaxLength("name", "héllo", 5)
+	v.Precision("price", "-123.456", 5, 2)
+	v.Precision("weight", 1e21, 10, 2)
+	v.Check("quantity", "quantity_positive", "quantity > 0", false)
+
+	var verr *ValidationError
+	if !errors.As(v.Err(), &verr) {
+		t.Fatalf("expected a validation error, got %v", v.Err())
+	}
+
+	want := []FieldError{
+		{Column: "weight", Rule: "precision", Message: "has 22 digits before the decimal point, at most 8 are allowed"},
+		{Column: "quantity", Rule: "quantity_positive", Message: "does not pass the check quantity > 0"},
+	}
+	if diff := cmp.Diff(want, verr.Fields); diff != "" {
+		t.Fatal(diff)
+	}
+
+	if NewValidation("products").Err() != nil {
+		t.Fatal("expected no error without field errors")
+	}
+}
+
+func TestValidateInsert(t *testing.T) {
+	if err := ValidateInsert(context.Background(), &validatedSetter{}); err != nil {
+		t.Fatalf("expected no validation without WithValidation, got %v", err)
+	}
+
+	ctx := WithValidation(context.Background())
+
+	if err := ValidateInsert(ctx, &validatedSetter{name: "bob", set: true}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateInsert(ctx, &validatedSetter{}); err == nil {
+		t.Fatal("expected an error for a required column")
+	}
+	if err := ValidateUpdate(ctx, &validatedSetter{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := ValidateUpdate(ctx, &validatedSetter{name: "alice"}); err == nil {
+		t.Fatal("expected an error for a long value")
+	}
+	if err := ValidateInsert(SkipModelHooks(ctx), &validatedSetter{}); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,219 @@
+// Package outbox implements the transactional outbox pattern on Postgres:
+// messages are written to an outbox table in the same transaction as the
+// data they describe, and a [Poller] hands them to a handler afterwards,
+// e.g. to publish them to a message broker.
+package outbox
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/psql"
+	"github.com/stephenafamo/bob/dialect/psql/dialect"
+	"github.com/stephenafamo/bob/dialect/psql/dm"
+	"github.com/stephenafamo/bob/dialect/psql/im"
+	"github.com/stephenafamo/bob/dialect/psql/sm"
+	"github.com/stephenafamo/bob/dialect/psql/um"
+	"github.com/stephenafamo/scan"
+)
+
+// DefaultTable is the name of the outbox table used by [Enqueue]
+const DefaultTable = "bob_outbox"
+
+// Message is a row of the outbox table
+type Message struct {
+	ID        int64     `db:"id"`
+	Topic     string    `db:"topic"`
+	Payload   []byte    `db:"payload"`
+	CreatedAt time.Time `db:"created_at"`
+	// The number of times the handler failed for this message
+	Attempts int `db:"attempts"`
+}
+
+// Outbox is an outbox table
+type Outbox struct {
+	Table string
+}
+
+// Default is the outbox using [DefaultTable]
+var Default = Outbox{Table: DefaultTable}
+
+// Schema returns the statement that creates the outbox table
+func (o Outbox) Schema() string {
+	return fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %q (
+	id BIGSERIAL PRIMARY KEY,
+	topic TEXT NOT NULL,
+	payload BYTEA NOT NULL,
+	created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+	attempts INTEGER NOT NULL DEFAULT 0,
+	last_error TEXT
+)`, o.Table)
+}
+
+// CreateTable creates the outbox table if it does not exist
+func (o Outbox) CreateTable(ctx context.Context, exec bob.Executor) error {
+	_, err := exec.ExecContext(ctx, o.Schema())
+	return err
+}
+
+// Enqueue adds a message to the outbox. The executor should be the
+// transaction that writes the data the message is about, so that the message
+// is only sent if the transaction is committed
+func (o Outbox) Enqueue(ctx context.Context, exec bob.Executor, topic string, payload []byte) error {
+	_, err := bob.Exec(ctx, exec, o.enqueueQuery(topic, payload))
+	return err
+}
+
+// Enqueue adds a message to the [Default] outbox
+func Enqueue(ctx context.Context, exec bob.Executor, topic string, payload []byte) error {
+	return Default.Enqueue(ctx, exec, topic, payload)
+}
+
+func (o Outbox) enqueueQuery(topic string, payload []byte) bob.Query {
+	return psql.Insert(
+		im.Into(psql.Quote(o.Table), "topic", "payload"),
+		im.Values(psql.Arg(topic, payload)),
+	)
+}
+
+// claimQuery locks the next messages, skipping the ones locked by other pollers
+func (o Outbox) claimQuery(limit, maxAttempts int) bob.BaseQuery[*dialect.SelectQuery] {
+	q := psql.Select(
+		sm.Columns("id", "topic", "payload", "created_at", "attempts"),
+		sm.From(psql.Quote(o.Table)),
+		sm.OrderBy("id"),
+		sm.Limit(limit),
+		sm.ForUpdate().SkipLocked(),
+	)
+
+	if maxAttempts > 0 {
+		q.Apply(sm.Where(psql.Quote("attempts").LT(psql.Arg(maxAttempts))))
+	}
+
+	return q
+}
+
+func (o Outbox) deleteQuery(ids []any) bob.Query {
+	return psql.Delete(
+		dm.From(psql.Quote(o.Table)),
+		dm.Where(psql.Quote("id").In(psql.Arg(ids...))),
+	)
+}
+
+func (o Outbox) failQuery(id int64, handlerErr error) bob.Query {
+	return psql.Update(
+		um.Table(psql.Quote(o.Table)),
+		um.SetCol("attempts").To(psql.Raw("attempts + 1")),
+		um.SetCol("last_error").ToArg(handlerErr.Error()),
+		um.Where(psql.Quote("id").EQ(psql.Arg(id))),
+	)
+}
+
+// Beginner starts transactions, such as [bob.DB] and [bob.Conn]
+type Beginner interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (bob.Tx, error)
+}
+
+// Handler is called with each message of the outbox
+type Handler func(ctx context.Context, msg Message) error
+
+// Poller claims messages from the outbox with FOR UPDATE SKIP LOCKED
+// and calls the handler with them, so several pollers can run at once.
+// Messages are deleted once they are handled, and the ones for which
+// the handler returns an error are retried in the next poll
+type Poller struct {
+	DB      Beginner
+	Outbox  Outbox
+	Handler Handler
+	// The number of messages claimed at once, 10 by default
+	BatchSize int
+	// The time to wait after a poll that delivered no messages, 1 second by default
+	Interval time.Duration
+	// Messages are no longer retried after failing this many times,
+	// and stay in the table to be inspected. 0 means they are always retried
+	MaxAttempts int
+}
+
+// Run polls the outbox until the context is canceled.
+// It polls again right away while messages are delivered, and waits for
+// the interval otherwise, so that failing messages are not retried in a busy loop
+func (p Poller) Run(ctx context.Context) error {
+	interval := p.Interval
+	if interval <= 0 {
+		interval = time.Second
+	}
+
+	for {
+		n, err := p.Poll(ctx)
+		if err != nil {
+			return err
+		}
+
+		if n > 0 {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Poll claims one batch of messages in a transaction and handles them.
+// It returns the number of messages delivered, i.e. handled without an error
+func (p Poller) Poll(ctx context.Context) (int, error) {
+	if p.Handler == nil {
+		return 0, errors.New("outbox: no handler")
+	}
+
+	outbox := p.Outbox
+	if outbox.Table == "" {
+		outbox = Default
+	}
+
+	batchSize := p.BatchSize
+	if batchSize <= 0 {
+		batchSize = 10
+	}
+
+	tx, err := p.DB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	messages, err := bob.All(ctx, tx, outbox.claimQuery(batchSize, p.MaxAttempts), scan.StructMapper[Message]())
+	if err != nil {
+		return 0, fmt.Errorf("outbox: claiming messages: %w", err)
+	}
+
+	var done []any
+	for _, msg := range messages {
+		if err := p.Handler(ctx, msg); err != nil {
+			if _, err := bob.Exec(ctx, tx, outbox.failQuery(msg.ID, err)); err != nil {
+				return 0, fmt.Errorf("outbox: recording failure: %w", err)
+			}
+			continue
+		}
+
+		done = append(done, msg.ID)
+	}
+
+	if len(done) > 0 {
+		if _, err := bob.Exec(ctx, tx, outbox.deleteQuery(done)); err != nil {
+			return 0, fmt.Errorf("outbox: deleting messages: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	return len(done), nil
+}
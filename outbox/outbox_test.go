@@ -0,0 +1,43 @@
+package outbox
+
+import (
+	"errors"
+	"testing"
+
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+func TestQueries(t *testing.T) {
+	o := Outbox{Table: "events"}
+
+	examples := testutils.Testcases{
+		"enqueue": {
+			Query:        o.enqueueQuery("user.created", []byte(`{"id":1}`)),
+			ExpectedSQL:  `INSERT INTO "events" ("topic", "payload") VALUES ($1, $2)`,
+			ExpectedArgs: []any{"user.created", []byte(`{"id":1}`)},
+		},
+		"claim": {
+			Query: o.claimQuery(10, 0),
+			ExpectedSQL: `SELECT id, topic, payload, created_at, attempts FROM "events"
+				ORDER BY id LIMIT 10 FOR UPDATE SKIP LOCKED`,
+		},
+		"claim with max attempts": {
+			Query: o.claimQuery(5, 3),
+			ExpectedSQL: `SELECT id, topic, payload, created_at, attempts FROM "events"
+				WHERE ("attempts" < $1) ORDER BY id LIMIT 5 FOR UPDATE SKIP LOCKED`,
+			ExpectedArgs: []any{3},
+		},
+		"delete": {
+			Query:        o.deleteQuery([]any{int64(1), int64(2)}),
+			ExpectedSQL:  `DELETE FROM "events" WHERE ("id" IN ($1, $2))`,
+			ExpectedArgs: []any{int64(1), int64(2)},
+		},
+		"fail": {
+			Query:        o.failQuery(1, errors.New("broker down")),
+			ExpectedSQL:  `UPDATE "events" SET "attempts" = attempts + 1, "last_error" = $1 WHERE ("id" = $2)`,
+			ExpectedArgs: []any{"broker down", int64(1)},
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+}
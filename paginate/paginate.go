@@ -0,0 +1,265 @@
+// Package paginate builds the mods that read a query page by page, with an
+// order that is the same on every page.
+//
+// Rows that sort equally can come back in any order, so a page boundary
+// between them skips or repeats rows. The key columns of the [Order], such as
+// the primary key, are added after the sorts to break these ties. NULLs are
+// sorted with CASE WHEN column IS NULL THEN 1 ELSE 0 END instead of NULLS FIRST
+// or NULLS LAST, so they go to the same end in every dialect, also in
+// SQL Server, which cannot sort by a condition.
+package paginate
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/clause"
+	"github.com/stephenafamo/bob/expr"
+	"github.com/stephenafamo/bob/mods"
+)
+
+//nolint:gochecknoglobals
+var (
+	ErrNoKeys        = errors.New("pagination order has no key columns")
+	ErrInvalidCursor = errors.New("invalid pagination cursor")
+)
+
+//nolint:gochecknoglobals
+var bmod = expr.Builder[expression, expression]{}
+
+// Sort is a column that the pages are sorted by
+type Sort struct {
+	Column bob.Expression
+	Desc   bool
+	// Where the NULLs go if the column can be NULL, FIRST or LAST
+	// in both directions. Empty if the column cannot be NULL
+	Nulls string
+}
+
+// Order is the order of the pages
+type Order struct {
+	Sorts []Sort
+	// The columns that identify a row, usually the primary key.
+	// They are sorted ascending after the sorts, so that no two rows sort equally
+	Keys []bob.Expression
+}
+
+// By returns an order of the sorts, with the keys as the tie-breakers
+func By(keys []bob.Expression, sorts ...Sort) Order {
+	return Order{Sorts: sorts, Keys: keys}
+}
+
+// Asc sorts by the column in ascending order
+func Asc(column bob.Expression) Sort {
+	return Sort{Column: column}
+}
+
+// Desc sorts by the column in descending order
+func Desc(column bob.Expression) Sort {
+	return Sort{Column: column, Desc: true}
+}
+
+// NullsLast marks the column as nullable, with the NULLs after the other values
+func (s Sort) NullsLast() Sort {
+	s.Nulls = "LAST"
+	return s
+}
+
+// NullsFirst marks the column as nullable, with the NULLs before the other values
+func (s Sort) NullsFirst() Sort {
+	s.Nulls = "FIRST"
+	return s
+}
+
+// sorts returns the sorts followed by the keys
+func (o Order) sorts() []Sort {
+	sorts := make([]Sort, 0, len(o.Sorts)+len(o.Keys))
+	sorts = append(sorts, o.Sorts...)
+	for _, key := range o.Keys {
+		sorts = append(sorts, Asc(key))
+	}
+
+	return sorts
+}
+
+// OrderBy returns a mod that sorts the query by the order, ties broken by the keys
+func OrderBy[Q interface{ AppendOrder(clause.OrderDef) }](o Order) (bob.Mod[Q], error) {
+	if len(o.Keys) == 0 {
+		return nil, ErrNoKeys
+	}
+
+	var orders mods.QueryMods[Q]
+	for _, s := range o.sorts() {
+		if s.Nulls != "" {
+			// the NULLs are 1 and the other values 0, so ascending puts the NULLs last
+			nulls := "ASC"
+			if s.Nulls == "FIRST" {
+				nulls = "DESC"
+			}
+			orders = append(orders, mods.OrderBy[Q]{
+				Expression: nullRank(s.Column),
+				Direction:  nulls,
+			})
+		}
+
+		direction := "ASC"
+		if s.Desc {
+			direction = "DESC"
+		}
+		orders = append(orders, mods.OrderBy[Q]{
+			Expression: s.Column,
+			Direction:  direction,
+		})
+	}
+
+	return orders, nil
+}
+
+type pageable interface {
+	AppendOrder(clause.OrderDef)
+	SetLimit(limit any)
+	SetOffset(offset any)
+}
+
+// Page returns the mods for a page of offset pagination. Pages start at 1.
+// Rows that are inserted or deleted before the page shift it,
+// use [After] for pages that do not
+func Page[Q pageable](o Order, page, size int) (bob.Mod[Q], error) {
+	if page < 1 || size < 1 {
+		return nil, fmt.Errorf("page %d of size %d: pages and sizes start at 1", page, size)
+	}
+
+	order, err := OrderBy[Q](o)
+	if err != nil {
+		return nil, err
+	}
+
+	return mods.QueryMods[Q]{
+		order,
+		mods.Limit[Q]{Count: size},
+		mods.Offset[Q]{Count: (page - 1) * size},
+	}, nil
+}
+
+type seekable interface {
+	AppendWhere(e ...any)
+	AppendOrder(clause.OrderDef)
+	SetLimit(limit any)
+}
+
+// After returns the mods for the page after the row with the cursor, for keyset pagination.
+// The cursor has the values of the sorts and then the keys of the last row of the previous page.
+// A nil cursor returns the first page
+func After[Q seekable](o Order, cursor []any, size int) (bob.Mod[Q], error) {
+	if size < 1 {
+		return nil, fmt.Errorf("page size %d: sizes start at 1", size)
+	}
+
+	order, err := OrderBy[Q](o)
+	if err != nil {
+		return nil, err
+	}
+
+	page := mods.QueryMods[Q]{order, mods.Limit[Q]{Count: size}}
+	if cursor == nil {
+		return page, nil
+	}
+
+	where, err := o.after(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	return append(page, mods.Where[Q]{E: where}), nil
+}
+
+// after returns the condition for the rows that sort after the cursor.
+// It is built from the last column to the first: a row is after the cursor if its value
+// of a column sorts after the cursor's, or it is the same and the row is after
+// the cursor on the rest of the columns
+func (o Order) after(cursor []any) (bob.Expression, error) {
+	sorts := o.sorts()
+	if len(cursor) != len(sorts) {
+		return nil, fmt.Errorf("%w: %d values for %d columns", ErrInvalidCursor, len(cursor), len(sorts))
+	}
+
+	var rest bob.Expression
+	for i := len(sorts) - 1; i >= 0; i-- {
+		s, val := sorts[i], cursor[i]
+		col := expression{}.New(s.Column)
+
+		var beyond []bob.Expression
+		var same bob.Expression
+
+		switch {
+		case isNull(val):
+			if s.Nulls == "" {
+				return nil, fmt.Errorf("%w: value %d is NULL but the column is not nullable", ErrInvalidCursor, i+1)
+			}
+			if s.Nulls == "FIRST" {
+				beyond = append(beyond, col.IsNotNull())
+			}
+			same = col.IsNull()
+
+		default:
+			if s.Desc {
+				beyond = append(beyond, col.LT(expr.Arg(val)))
+			} else {
+				beyond = append(beyond, col.GT(expr.Arg(val)))
+			}
+			if s.Nulls == "LAST" {
+				beyond = append(beyond, col.IsNull())
+			}
+			same = col.EQ(expr.Arg(val))
+		}
+
+		if rest != nil {
+			beyond = append(beyond, bmod.And(same, rest))
+		}
+
+		// The last column is a key, which cannot be NULL,
+		// so there is always at least one way to be after the cursor
+		if len(beyond) == 1 {
+			rest = beyond[0]
+		} else {
+			rest = bmod.Or(beyond...)
+		}
+	}
+
+	return rest, nil
+}
+
+// nullRank is 1 for the NULLs of the column and 0 for the other values
+func nullRank(column bob.Expression) bob.Expression {
+	return bob.ExpressionFunc(func(w io.Writer, d bob.Dialect, start int) ([]any, error) {
+		return bob.ExpressIf(w, d, start, column, true, "CASE WHEN ", " IS NULL THEN 1 ELSE 0 END")
+	})
+}
+
+// isNull reports if the value is NULL, including null types that are not set
+func isNull(val any) bool {
+	if val == nil {
+		return true
+	}
+
+	valuer, ok := val.(driver.Valuer)
+	if !ok {
+		return false
+	}
+
+	v, err := valuer.Value()
+	return err == nil && v == nil
+}
+
+type expression struct {
+	expr.Chain[expression, expression]
+}
+
+func (expression) New(exp bob.Expression) expression {
+	var b expression
+	b.Base = exp
+	return b
+}
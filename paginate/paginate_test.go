@@ -0,0 +1,107 @@
+package paginate_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/aarondl/opt/null"
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/dialect/mssql"
+	mssqldialect "github.com/stephenafamo/bob/dialect/mssql/dialect"
+	mssqlsm "github.com/stephenafamo/bob/dialect/mssql/sm"
+	"github.com/stephenafamo/bob/dialect/sqlite"
+	"github.com/stephenafamo/bob/dialect/sqlite/dialect"
+	"github.com/stephenafamo/bob/dialect/sqlite/sm"
+	"github.com/stephenafamo/bob/paginate"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+//nolint:gochecknoglobals
+var byScore = paginate.By(
+	[]bob.Expression{sqlite.Quote("id")},
+	paginate.Desc(sqlite.Quote("score")).NullsLast(),
+	paginate.Asc(sqlite.Quote("name")),
+)
+
+func mod[Q any](m bob.Mod[Q], err error) bob.Mod[Q] {
+	if err != nil {
+		panic(err)
+	}
+
+	return m
+}
+
+func TestPaginate(t *testing.T) {
+	examples := testutils.Testcases{
+		"order": {
+			Query: sqlite.Select(sm.From("players"), mod(paginate.OrderBy[*dialect.SelectQuery](byScore))),
+			ExpectedSQL: `SELECT * FROM players
+				ORDER BY CASE WHEN "score" IS NULL THEN 1 ELSE 0 END ASC, "score" DESC, "name" ASC, "id" ASC`,
+		},
+		"page": {
+			Query: sqlite.Select(sm.From("players"), mod(paginate.Page[*dialect.SelectQuery](byScore, 3, 20))),
+			ExpectedSQL: `SELECT * FROM players
+				ORDER BY CASE WHEN "score" IS NULL THEN 1 ELSE 0 END ASC, "score" DESC, "name" ASC, "id" ASC
+				LIMIT 20 OFFSET 40`,
+		},
+		"first page": {
+			Query: sqlite.Select(sm.From("players"), mod(paginate.After[*dialect.SelectQuery](byScore, nil, 20))),
+			ExpectedSQL: `SELECT * FROM players
+				ORDER BY CASE WHEN "score" IS NULL THEN 1 ELSE 0 END ASC, "score" DESC, "name" ASC, "id" ASC
+				LIMIT 20`,
+		},
+		"after": {
+			Query: sqlite.Select(sm.From("players"), mod(paginate.After[*dialect.SelectQuery](byScore, []any{10, "bob", 7}, 20))),
+			ExpectedSQL: `SELECT * FROM players
+				WHERE (("score" < ?1) OR ("score" IS NULL) OR (("score" = ?2) AND (("name" > ?3) OR (("name" = ?4) AND ("id" > ?5)))))
+				ORDER BY CASE WHEN "score" IS NULL THEN 1 ELSE 0 END ASC, "score" DESC, "name" ASC, "id" ASC
+				LIMIT 20`,
+			ExpectedArgs: []any{10, 10, "bob", "bob", 7},
+		},
+		"after null": {
+			Query: sqlite.Select(sm.From("players"), mod(paginate.After[*dialect.SelectQuery](byScore, []any{null.FromPtr[int](nil), "bob", 7}, 20))),
+			ExpectedSQL: `SELECT * FROM players
+				WHERE (("score" IS NULL) AND (("name" > ?1) OR (("name" = ?2) AND ("id" > ?3))))
+				ORDER BY CASE WHEN "score" IS NULL THEN 1 ELSE 0 END ASC, "score" DESC, "name" ASC, "id" ASC
+				LIMIT 20`,
+			ExpectedArgs: []any{"bob", "bob", 7},
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+}
+
+func TestPaginateMSSQL(t *testing.T) {
+	byScore := paginate.By(
+		[]bob.Expression{mssql.Quote("id")},
+		paginate.Desc(mssql.Quote("score")).NullsLast(),
+	)
+
+	examples := testutils.Testcases{
+		"page": {
+			Query: mssql.Select(mssqlsm.From("players"), mod(paginate.Page[*mssqldialect.SelectQuery](byScore, 3, 20))),
+			ExpectedSQL: `SELECT * FROM players
+				ORDER BY CASE WHEN [score] IS NULL THEN 1 ELSE 0 END ASC, [score] DESC, [id] ASC
+				OFFSET 40 ROWS FETCH NEXT 20 ROWS ONLY`,
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+}
+
+func TestPaginateErrors(t *testing.T) {
+	_, err := paginate.OrderBy[*dialect.SelectQuery](paginate.By(nil, paginate.Asc(sqlite.Quote("name"))))
+	if !errors.Is(err, paginate.ErrNoKeys) {
+		t.Fatalf("got %v, want ErrNoKeys", err)
+	}
+
+	_, err = paginate.After[*dialect.SelectQuery](byScore, []any{10, "bob"}, 20)
+	if !errors.Is(err, paginate.ErrInvalidCursor) {
+		t.Fatalf("got %v, want ErrInvalidCursor for a short cursor", err)
+	}
+
+	_, err = paginate.After[*dialect.SelectQuery](byScore, []any{10, nil, 7}, 20)
+	if !errors.Is(err, paginate.ErrInvalidCursor) {
+		t.Fatalf("got %v, want ErrInvalidCursor for a NULL in a column that is not nullable", err)
+	}
+}
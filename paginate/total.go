@@ -0,0 +1,49 @@
+package paginate
+
+import (
+	"context"
+
+	"github.com/stephenafamo/bob"
+	"github.com/stephenafamo/bob/expr"
+	"github.com/stephenafamo/bob/mods"
+	"github.com/stephenafamo/scan"
+)
+
+// TotalColumn is the name of the column that [WithTotal] adds
+const TotalColumn = "bob_total"
+
+// Rows is a page of rows with the number of rows in all the pages
+type Rows[T any] struct {
+	Rows  []T
+	Total int64
+}
+
+// WithTotal adds COUNT(*) OVER () to the select list, which is the number of rows
+// that match the query before the limit and offset. It saves the second query that
+// counts them, at the cost of counting them for every page. Read the page with [AllWithTotal]
+func WithTotal[Q interface{ AppendPreloadSelect(columns ...any) }]() bob.Mod[Q] {
+	// Added like the columns of preloads, so the default columns are still selected
+	return mods.Preload[Q]{expr.OP("AS", "COUNT(*) OVER ()", expr.Quote(TotalColumn))}
+}
+
+// AllWithTotal runs a query with [WithTotal] and maps the rows with the mapper.
+// A page after the last one has no rows to read the total from, so its Total is 0
+func AllWithTotal[T any](ctx context.Context, exec bob.Executor, q bob.Query, m scan.Mapper[T]) (Rows[T], error) {
+	var total int64
+
+	rows, err := bob.All(ctx, exec, q, scan.Mod(m, func(context.Context, []string) (scan.BeforeFunc, scan.AfterMod) {
+		return func(r *scan.Row) (any, error) {
+				var t int64
+				r.ScheduleScan(TotalColumn, &t)
+				return &t, nil
+			}, func(link any, _ any) error {
+				total = *link.(*int64)
+				return nil
+			}
+	}))
+	if err != nil {
+		return Rows[T]{}, err
+	}
+
+	return Rows[T]{Rows: rows, Total: total}, nil
+}
@@ -0,0 +1,47 @@
+package paginate_test
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stephenafamo/bob/dialect/sqlite"
+	"github.com/stephenafamo/bob/dialect/sqlite/dialect"
+	"github.com/stephenafamo/bob/dialect/sqlite/sm"
+	"github.com/stephenafamo/bob/paginate"
+	testutils "github.com/stephenafamo/bob/test_utils"
+)
+
+type player struct {
+	ID    int           `db:"id"`
+	Score sql.NullInt64 `db:"score"`
+	Name  string        `db:"name"`
+}
+
+func TestWithTotal(t *testing.T) {
+	examples := testutils.Testcases{
+		"columns of the view": {
+			Query: sqlite.NewView[player]("", "players").Query(
+				context.Background(), nil,
+				paginate.WithTotal[*dialect.SelectQuery](),
+				mod(paginate.Page[*dialect.SelectQuery](byScore, 2, 20)),
+			),
+			ExpectedSQL: `SELECT "players"."id" AS "id", "players"."score" AS "score", "players"."name" AS "name",
+				COUNT(*) OVER () AS "bob_total"
+				FROM "players" AS "players"
+				ORDER BY CASE WHEN "score" IS NULL THEN 1 ELSE 0 END ASC, "score" DESC, "name" ASC, "id" ASC
+				LIMIT 20 OFFSET 20`,
+		},
+		"with columns": {
+			Query: sqlite.Select(
+				sm.Columns("id", "name"),
+				sm.From("players"),
+				paginate.WithTotal[*dialect.SelectQuery](),
+				sm.Limit(20),
+			),
+			ExpectedSQL: `SELECT id, name, COUNT(*) OVER () AS "bob_total" FROM players LIMIT 20`,
+		},
+	}
+
+	testutils.RunTests(t, examples, nil)
+}
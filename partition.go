@@ -0,0 +1,168 @@
+package bob
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// PartitionInterval is the span of time of each partition of a [PartitionPolicy]
+type PartitionInterval int
+
+const (
+	PartitionDaily PartitionInterval = iota
+	// Weeks start on Monday
+	PartitionWeekly
+	PartitionMonthly
+	PartitionYearly
+)
+
+// partitionNameLayout is the date of the start of a partition in its name
+const partitionNameLayout = "20060102"
+
+// PartitionPolicy describes the time-based range partitions that a table should have.
+// The psql and mysql dialects apply it with ApplyPartitionPolicy, which creates the
+// missing partitions and removes the expired ones.
+//
+// The partitions are named with the date of their start, such as p20240101.
+// Partitions with other names are never changed, so a default partition
+// or partitions that were created by hand are left alone
+type PartitionPolicy struct {
+	Interval PartitionInterval
+	// The number of partitions to create after the current one,
+	// so that inserts never find a missing partition
+	Premake int
+	// The number of partitions to keep before the current one.
+	// Older partitions are dropped, or detached in Postgres with Detach.
+	// If 0, no partitions are removed
+	Retain int
+	// Detach the expired partitions from the table instead of dropping them,
+	// e.g. to archive them first. Only Postgres can detach partitions
+	Detach bool
+	// The time zone of the bounds of the partitions, UTC if nil
+	Location *time.Location
+}
+
+// PartitionBound is a partition of a [PartitionPolicy], with the rows from From (inclusive)
+// to To (exclusive)
+type PartitionBound struct {
+	Name     string
+	From, To time.Time
+}
+
+// PartitionChanges are the partitions that ApplyPartitionPolicy created, detached and dropped
+type PartitionChanges struct {
+	Created  []string
+	Detached []string
+	Dropped  []string
+}
+
+func (p PartitionPolicy) location() *time.Location {
+	if p.Location == nil {
+		return time.UTC
+	}
+
+	return p.Location
+}
+
+// start returns the start of the partition that contains t
+func (p PartitionPolicy) start(t time.Time) time.Time {
+	t = t.In(p.location())
+	y, m, d := t.Date()
+
+	switch p.Interval {
+	case PartitionWeekly:
+		offset := (int(t.Weekday()) + 6) % 7
+		return time.Date(y, m, d-offset, 0, 0, 0, 0, t.Location())
+	case PartitionMonthly:
+		return time.Date(y, m, 1, 0, 0, 0, 0, t.Location())
+	case PartitionYearly:
+		return time.Date(y, 1, 1, 0, 0, 0, 0, t.Location())
+	default:
+		return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+	}
+}
+
+// add moves the start of a partition by n partitions
+func (p PartitionPolicy) add(start time.Time, n int) time.Time {
+	switch p.Interval {
+	case PartitionWeekly:
+		return start.AddDate(0, 0, 7*n)
+	case PartitionMonthly:
+		return start.AddDate(0, n, 0)
+	case PartitionYearly:
+		return start.AddDate(n, 0, 0)
+	default:
+		return start.AddDate(0, 0, n)
+	}
+}
+
+// Bound returns the partition that contains t
+func (p PartitionPolicy) Bound(t time.Time) PartitionBound {
+	from := p.start(t)
+	return PartitionBound{
+		Name: "p" + from.Format(partitionNameLayout),
+		From: from,
+		To:   p.add(from, 1),
+	}
+}
+
+// Wanted returns the partitions that should exist at the time now: the current
+// one and the ones to premake. Older partitions are kept until they expire
+func (p PartitionPolicy) Wanted(now time.Time) []PartitionBound {
+	bounds := make([]PartitionBound, 0, p.Premake+1)
+	current := p.start(now)
+	for i := 0; i <= p.Premake; i++ {
+		bounds = append(bounds, p.Bound(p.add(current, i)))
+	}
+
+	return bounds
+}
+
+// Expired returns the names of the partitions that are older than the retained ones,
+// oldest first. Names that do not match the names of the policy are left out
+func (p PartitionPolicy) Expired(names []string, now time.Time) []string {
+	if p.Retain <= 0 {
+		return nil
+	}
+
+	cutoff := p.add(p.start(now), -p.Retain)
+
+	var expired []string
+	for _, name := range names {
+		from, ok := p.parseName(name)
+		if ok && !p.add(from, 1).After(cutoff) {
+			expired = append(expired, name)
+		}
+	}
+
+	sort.Strings(expired)
+	return expired
+}
+
+// parseName returns the start of a partition named by the policy
+func (p PartitionPolicy) parseName(name string) (time.Time, bool) {
+	if !strings.HasPrefix(name, "p") {
+		return time.Time{}, false
+	}
+
+	from, err := time.ParseInLocation(partitionNameLayout, name[1:], p.location())
+	if err != nil {
+		return time.Time{}, false
+	}
+
+	return from, true
+}
+
+// Validate returns an error if the policy cannot be applied
+func (p PartitionPolicy) Validate() error {
+	if p.Interval < PartitionDaily || p.Interval > PartitionYearly {
+		return fmt.Errorf("partition policy: invalid interval %d", p.Interval)
+	}
+	if p.Premake < 0 || p.Retain < 0 {
+		return fmt.Errorf("partition policy: premake and retain cannot be negative")
+	}
+
+	return nil
+}
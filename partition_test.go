@@ -0,0 +1,88 @@
+package bob
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestPartitionPolicyWanted(t *testing.T) {
+	now := time.Date(2024, 3, 14, 15, 9, 26, 0, time.UTC) // a Thursday
+
+	tests := map[string]struct {
+		policy PartitionPolicy
+		want   []string
+	}{
+		"daily": {
+			policy: PartitionPolicy{Interval: PartitionDaily, Premake: 2},
+			want:   []string{"p20240314", "p20240315", "p20240316"},
+		},
+		"weekly": {
+			policy: PartitionPolicy{Interval: PartitionWeekly, Premake: 1},
+			want:   []string{"p20240311", "p20240318"},
+		},
+		"monthly": {
+			policy: PartitionPolicy{Interval: PartitionMonthly, Premake: 1},
+			want:   []string{"p20240301", "p20240401"},
+		},
+		"yearly": {
+			policy: PartitionPolicy{Interval: PartitionYearly},
+			want:   []string{"p20240101"},
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			for _, b := range tc.policy.Wanted(now) {
+				got = append(got, b.Name)
+			}
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Fatalf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+
+	b := PartitionPolicy{Interval: PartitionMonthly}.Bound(now)
+	if !b.From.Equal(time.Date(2024, 3, 1, 0, 0, 0, 0, time.UTC)) || !b.To.Equal(time.Date(2024, 4, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("bound from %s to %s", b.From, b.To)
+	}
+}
+
+func TestPartitionPolicyLocation(t *testing.T) {
+	loc := time.FixedZone("UTC+10", 10*60*60)
+	now := time.Date(2024, 3, 14, 20, 0, 0, 0, time.UTC) // the 15th in UTC+10
+
+	b := PartitionPolicy{Interval: PartitionDaily, Location: loc}.Bound(now)
+	if b.Name != "p20240315" {
+		t.Fatalf("got %s, want p20240315", b.Name)
+	}
+	if !b.From.Equal(time.Date(2024, 3, 14, 14, 0, 0, 0, time.UTC)) {
+		t.Fatalf("got from %s", b.From)
+	}
+}
+
+func TestPartitionPolicyExpired(t *testing.T) {
+	now := time.Date(2024, 3, 14, 0, 0, 0, 0, time.UTC)
+	names := []string{"p20240301", "p20231201", "p20240101", "p20240201", "pdefault", "p20231101"}
+
+	policy := PartitionPolicy{Interval: PartitionMonthly, Retain: 2}
+	want := []string{"p20231101", "p20231201"}
+	if got := policy.Expired(names, now); !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %v, want %v", got, want)
+	}
+
+	policy.Retain = 0
+	if got := policy.Expired(names, now); got != nil {
+		t.Fatalf("got %v with no retention", got)
+	}
+}
+
+func TestPartitionPolicyValidate(t *testing.T) {
+	if err := (PartitionPolicy{Interval: PartitionYearly + 1}).Validate(); err == nil {
+		t.Fatal("expected an error for an invalid interval")
+	}
+	if err := (PartitionPolicy{Retain: -1}).Validate(); err == nil {
+		t.Fatal("expected an error for a negative retention")
+	}
+}
@@ -0,0 +1,112 @@
+package bob
+
+import (
+	"context"
+	"sync"
+
+	"github.com/stephenafamo/scan"
+)
+
+// WithCapacityHint preallocates the result slice of [All] and [Allx]
+// (and the matching [QueryStmt] methods) to hold n rows.
+// Useful when the number of rows is known or bounded, e.g. with a LIMIT
+func WithCapacityHint[T any](n int) ExecOption[T] {
+	return func(es *ExecSettings[T]) {
+		es.CapacityHint = n
+	}
+}
+
+// WithSlicePool makes [All] and [Allx] draw the result slice from the given pool
+// instead of allocating a new one for every query.
+// The pool is expected to hold values of type *[]T. If it is empty and has no New func,
+// a new slice is allocated using the capacity hint.
+// Once the caller is done with the results, the slice should be given back with [ReleaseSlice]
+func WithSlicePool[T any](pool *sync.Pool) ExecOption[T] {
+	return func(es *ExecSettings[T]) {
+		es.SlicePool = pool
+	}
+}
+
+// ReleaseSlice clears the given slice and puts it back in the pool
+// so that it can be reused by a later query using [WithSlicePool].
+// The slice must not be used after it is released
+func ReleaseSlice[T any, Ts ~[]T](pool *sync.Pool, s Ts) {
+	if pool == nil || s == nil {
+		return
+	}
+
+	var zero T
+	for i := range s {
+		s[i] = zero // do not hold on to the rows
+	}
+
+	raw := []T(s[:0])
+	pool.Put(&raw)
+}
+
+// newSlice returns an empty slice to collect results in
+func (s ExecSettings[T]) newSlice() []T {
+	if s.SlicePool != nil {
+		if p, ok := s.SlicePool.Get().(*[]T); ok && p != nil {
+			if cap(*p) >= s.CapacityHint {
+				return (*p)[:0]
+			}
+		}
+	}
+
+	if s.CapacityHint > 0 {
+		return make([]T, 0, s.CapacityHint)
+	}
+
+	return nil
+}
+
+// usesPreallocation reports if the settings require the result slice
+// to be created by bob instead of the scan package
+func (s ExecSettings[T]) usesPreallocation() bool {
+	return s.CapacityHint > 0 || s.SlicePool != nil
+}
+
+// allFromCursor reads all the rows of the cursor into a slice created
+// according to the settings
+func allFromCursor[T any](settings ExecSettings[T], c scan.ICursor[T]) ([]T, error) {
+	defer c.Close()
+
+	results := settings.newSlice()
+	for c.Next() {
+		t, err := c.Get()
+		if err != nil {
+			return nil, err
+		}
+
+		results = append(results, t)
+	}
+
+	return results, c.Err()
+}
+
+func scanAll[T any](ctx context.Context, exec Executor, m scan.Mapper[T], settings ExecSettings[T], query string, args ...any) ([]T, error) {
+	if !settings.usesPreallocation() {
+		return scan.All(ctx, exec, m, query, args...)
+	}
+
+	c, err := scan.Cursor(ctx, exec, m, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return allFromCursor(settings, c)
+}
+
+func scanAllFromRows[T any](ctx context.Context, m scan.Mapper[T], settings ExecSettings[T], rows scan.Rows) ([]T, error) {
+	if !settings.usesPreallocation() {
+		return scan.AllFromRows(ctx, m, rows)
+	}
+
+	c, err := scan.CursorFromRows(ctx, m, rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return allFromCursor(settings, c)
+}
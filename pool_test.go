@@ -0,0 +1,111 @@
+package bob
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/stephenafamo/scan"
+)
+
+// intRows is a set of rows with a single integer column
+type intRows struct {
+	vals    []int
+	current int
+	closed  bool
+}
+
+func (r *intRows) Scan(dest ...any) error {
+	*(dest[0].(*int)) = r.vals[r.current-1]
+	return nil
+}
+
+func (r *intRows) Columns() ([]string, error) {
+	return []string{"id"}, nil
+}
+
+func (r *intRows) Next() bool {
+	r.current++
+	return r.current <= len(r.vals)
+}
+
+func (r *intRows) Close() error {
+	r.closed = true
+	return nil
+}
+
+func (r *intRows) Err() error {
+	return nil
+}
+
+type intExecutor struct {
+	NoopExecutor
+	rows *intRows
+}
+
+func (e intExecutor) QueryContext(ctx context.Context, query string, args ...any) (scan.Rows, error) {
+	return e.rows, nil
+}
+
+var selectIDs = BaseQuery[ExpressionFunc]{
+	Expression: func(w io.Writer, d Dialect, start int) ([]any, error) {
+		_, err := w.Write([]byte("SELECT id FROM a"))
+		return nil, err
+	},
+}
+
+func TestWithCapacityHint(t *testing.T) {
+	exec := intExecutor{rows: &intRows{vals: []int{1, 2, 3}}}
+	q := selectIDs
+
+	got, err := All(context.Background(), exec, q, scan.SingleColumnMapper[int], WithCapacityHint[int](10))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff([]int{1, 2, 3}, got); diff != "" {
+		t.Fatal(diff)
+	}
+
+	if cap(got) != 10 {
+		t.Fatalf("expected capacity of 10, got %d", cap(got))
+	}
+
+	if !exec.rows.closed {
+		t.Fatal("rows were not closed")
+	}
+}
+
+func TestWithSlicePool(t *testing.T) {
+	pooled := make([]int, 0, 5)
+	pool := &sync.Pool{}
+	pool.Put(&pooled)
+
+	exec := intExecutor{rows: &intRows{vals: []int{4, 5}}}
+	q := selectIDs
+
+	got, err := All(context.Background(), exec, q, scan.SingleColumnMapper[int], WithSlicePool[int](pool))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff([]int{4, 5}, got); diff != "" {
+		t.Fatal(diff)
+	}
+
+	if &got[:1][0] != &pooled[:1][0] {
+		t.Fatal("result slice was not drawn from the pool")
+	}
+
+	ReleaseSlice(pool, got)
+	released, ok := pool.Get().(*[]int)
+	if !ok {
+		t.Fatal("slice was not released to the pool")
+	}
+
+	if len(*released) != 0 || cap(*released) != 5 {
+		t.Fatalf("released slice has len %d and cap %d", len(*released), cap(*released))
+	}
+}
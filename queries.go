@@ -0,0 +1,340 @@
+package bob
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"path"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/stephenafamo/scan"
+)
+
+// Comments that mark the start of a query and its hints
+const (
+	queryNamePrefix   = "-- name:"
+	queryParamPrefix  = "-- param:"
+	queryResultPrefix = "-- result:"
+)
+
+// NamedQuery is a hand written query loaded with [QueriesFromFS]
+type NamedQuery struct {
+	Name string
+	SQL  string
+	// The parameters declared with "-- param:", in order
+	Params []QueryParam
+	// The result declared with "-- result:", e.g. "one", "many" or "exec"
+	Result string
+	// The file the query was read from
+	File string
+}
+
+// QueryParam is a parameter declared for a [NamedQuery]
+type QueryParam struct {
+	Name string
+	// The type is optional
+	Type string
+}
+
+// Query returns the query with the given args, to use with [Exec], [One], [All] etc.
+// The SQL is used as is, so the placeholders have to match the dialect
+func (q NamedQuery) Query(args ...any) Query {
+	return namedQuery{sql: q.SQL, args: args}
+}
+
+type namedQuery struct {
+	sql  string
+	args []any
+}
+
+func (q namedQuery) WriteSQL(w io.Writer, _ Dialect, _ int) ([]any, error) {
+	if _, err := io.WriteString(w, q.sql); err != nil {
+		return nil, err
+	}
+
+	return q.args, nil
+}
+
+func (q namedQuery) WriteQuery(w io.Writer, start int) ([]any, error) {
+	return q.WriteSQL(w, nil, start)
+}
+
+// Queries is a set of named queries.
+// The statements prepared with it are cached for each database
+type Queries struct {
+	queries map[string]NamedQuery
+
+	mu sync.Mutex
+	// the statement caches of the databases
+	caches map[Preparer]*StmtCache
+}
+
+// pooledPreparer is a database handle such as [DB], which prepares the statements again on
+// each connection of its pool, so they can be used for as long as the database is open
+type pooledPreparer interface {
+	Preparer
+	Conn(context.Context) (Conn, error)
+}
+
+// QueriesFromFS reads the named queries in the .sql files of fsys,
+// which is usually an [embed.FS].
+// Each query starts with a name comment, and can declare its parameters
+// and result with hint comments:
+//
+//	-- name: GetUser
+//	-- param: id int
+//	-- result: one
+//	SELECT * FROM users WHERE id = $1;
+//
+// A file can have several queries, and the names have to be unique
+func QueriesFromFS(fsys fs.FS) (*Queries, error) {
+	q := &Queries{
+		queries: make(map[string]NamedQuery),
+		caches:  make(map[Preparer]*StmtCache),
+	}
+
+	err := fs.WalkDir(fsys, ".", func(name string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || path.Ext(name) != ".sql" {
+			return err
+		}
+
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+
+		parsed, err := parseNamedQueries(name, content)
+		if err != nil {
+			return err
+		}
+
+		for _, nq := range parsed {
+			if prev, ok := q.queries[nq.Name]; ok {
+				return fmt.Errorf("%s: query %q is also defined in %s", name, nq.Name, prev.File)
+			}
+			q.queries[nq.Name] = nq
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+func parseNamedQueries(file string, content []byte) ([]NamedQuery, error) {
+	var queries []NamedQuery
+	var current *NamedQuery
+	var sql strings.Builder
+
+	finish := func() error {
+		if current == nil {
+			return nil
+		}
+
+		current.SQL = strings.TrimSuffix(strings.TrimSpace(sql.String()), ";")
+		if current.SQL == "" {
+			return fmt.Errorf("%s: query %q has no SQL", file, current.Name)
+		}
+
+		queries = append(queries, *current)
+		sql.Reset()
+		return nil
+	}
+
+	s := bufio.NewScanner(bytes.NewReader(content))
+	for lineNo := 1; s.Scan(); lineNo++ {
+		line := s.Text()
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case strings.HasPrefix(trimmed, queryNamePrefix):
+			if err := finish(); err != nil {
+				return nil, err
+			}
+
+			name := strings.TrimSpace(strings.TrimPrefix(trimmed, queryNamePrefix))
+			if name == "" {
+				return nil, fmt.Errorf("%s:%d: missing query name", file, lineNo)
+			}
+			current = &NamedQuery{Name: name, File: file}
+
+		case current == nil:
+			// Comments and blank lines before the first query
+			if trimmed != "" && !strings.HasPrefix(trimmed, "--") {
+				return nil, fmt.Errorf("%s:%d: SQL before the first %q comment", file, lineNo, queryNamePrefix)
+			}
+
+		case strings.HasPrefix(trimmed, queryParamPrefix):
+			fields := strings.Fields(strings.TrimPrefix(trimmed, queryParamPrefix))
+			if len(fields) == 0 {
+				return nil, fmt.Errorf("%s:%d: missing parameter name", file, lineNo)
+			}
+			current.Params = append(current.Params, QueryParam{
+				Name: fields[0],
+				Type: strings.Join(fields[1:], " "),
+			})
+
+		case strings.HasPrefix(trimmed, queryResultPrefix):
+			current.Result = strings.TrimSpace(strings.TrimPrefix(trimmed, queryResultPrefix))
+
+		default:
+			sql.WriteString(line)
+			sql.WriteString("\n")
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+
+	if err := finish(); err != nil {
+		return nil, err
+	}
+
+	return queries, nil
+}
+
+// Get returns the query with the given name
+func (q *Queries) Get(name string) (NamedQuery, bool) {
+	nq, ok := q.queries[name]
+	return nq, ok
+}
+
+// Names returns the names of all the queries, sorted
+func (q *Queries) Names() []string {
+	names := make([]string, 0, len(q.queries))
+	for name := range q.queries {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Query returns the named query with the given args.
+// It returns an error if there is no query with the name
+func (q *Queries) Query(name string, args ...any) (Query, error) {
+	nq, ok := q.queries[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown query %q", name)
+	}
+
+	return nq.Query(args...), nil
+}
+
+// Prepare returns a prepared statement of the named query.
+// With a database, such as [DB], the statement is kept in a [StmtCache] of
+// the database and reused afterwards. With a [StmtCache], it is kept in that cache,
+// which can be shared with [Warmup] and other queries.
+// The statements of transactions and connections are not cached, since they
+// are closed when the transaction or connection ends
+func (q *Queries) Prepare(ctx context.Context, exec Preparer, name string) (Stmt, error) {
+	nq, ok := q.queries[name]
+	if !ok {
+		return Stmt{}, fmt.Errorf("unknown query %q", name)
+	}
+
+	if cache := q.cache(exec); cache != nil {
+		exec = cache
+	}
+
+	exec = withDefaultTimeZonePreparer(exec)
+
+	stmt, err := exec.PrepareContext(ctx, nq.SQL)
+	if err != nil {
+		return Stmt{}, err
+	}
+
+	return Stmt{exec: exec, stmt: stmt, lenArgs: len(nq.Params)}, nil
+}
+
+// cache returns the statement cache to prepare the statements with,
+// or nil if the statements of exec are not cached
+func (q *Queries) cache(exec Preparer) *StmtCache {
+	if cache, ok := exec.(*StmtCache); ok {
+		return cache
+	}
+
+	// Executors that cannot be map keys are not cached
+	if _, ok := exec.(pooledPreparer); !ok || !reflect.TypeOf(exec).Comparable() {
+		return nil
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	cache, ok := q.caches[exec]
+	if !ok {
+		cache = NewStmtCache(exec, 0)
+		q.caches[exec] = cache
+	}
+
+	return cache
+}
+
+// Warmup prepares the named queries, or all of them if no names are given,
+// so that they are cached before they are first used. See [Queries.Prepare]
+func (q *Queries) Warmup(ctx context.Context, exec Preparer, names ...string) error {
+	if len(names) == 0 {
+		names = q.Names()
+	}
+
+	cached := q.cache(exec) != nil
+	for _, name := range names {
+		s, err := q.Prepare(ctx, exec, name)
+		if err != nil {
+			return fmt.Errorf("warmup query %q: %w", name, err)
+		}
+
+		if !cached {
+			closeStatement(s.stmt)
+		}
+	}
+
+	return nil
+}
+
+// Close closes the statements cached for the databases.
+// A [StmtCache] given to Prepare is not closed
+func (q *Queries) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	var firstErr error
+	for exec, cache := range q.caches {
+		if err := cache.Close(); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		delete(q.caches, exec)
+	}
+
+	return firstErr
+}
+
+// PrepareNamed returns a prepared statement of the named query that scans
+// the rows with the mapper. The statement is cached like [Queries.Prepare]
+func PrepareNamed[T any](ctx context.Context, exec Preparer, q *Queries, name string, m scan.Mapper[T], opts ...ExecOption[T]) (QueryStmt[T, []T], error) {
+	s, err := q.Prepare(ctx, exec, name)
+	if err != nil {
+		return QueryStmt[T, []T]{}, err
+	}
+
+	settings := ExecSettings[T]{}
+	for _, opt := range opts {
+		opt(&settings)
+	}
+
+	return QueryStmt[T, []T]{
+		Stmt:     s,
+		mapper:   m,
+		settings: settings,
+	}, nil
+}
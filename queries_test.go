@@ -0,0 +1,236 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stephenafamo/scan"
+)
+
+var queriesFS = fstest.MapFS{
+	"users.sql": {Data: []byte(`-- Queries for the users table
+
+-- name: GetUser
+-- param: id int
+-- result: one
+SELECT * FROM users
+WHERE id = $1;
+
+-- name: ListUsers
+-- result: many
+SELECT * FROM users;
+`)},
+	"nested/jets.sql": {Data: []byte(`-- name: DeleteJet
+-- param: id int
+-- param: pilot_id int
+-- result: exec
+DELETE FROM jets WHERE id = $1 AND pilot_id = $2
+`)},
+	"README.md": {Data: []byte("not a query")},
+}
+
+func TestQueriesFromFS(t *testing.T) {
+	q, err := QueriesFromFS(queriesFS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if names := q.Names(); !reflect.DeepEqual(names, []string{"DeleteJet", "GetUser", "ListUsers"}) {
+		t.Fatalf("wrong names: %v", names)
+	}
+
+	getUser, ok := q.Get("GetUser")
+	if !ok {
+		t.Fatal("GetUser not found")
+	}
+
+	expected := NamedQuery{
+		Name:   "GetUser",
+		SQL:    "SELECT * FROM users\nWHERE id = $1",
+		Params: []QueryParam{{Name: "id", Type: "int"}},
+		Result: "one",
+		File:   "users.sql",
+	}
+	if !reflect.DeepEqual(getUser, expected) {
+		t.Fatalf("expected %#v, got %#v", expected, getUser)
+	}
+
+	deleteJet, _ := q.Get("DeleteJet")
+	if deleteJet.File != "nested/jets.sql" || len(deleteJet.Params) != 2 || deleteJet.Result != "exec" {
+		t.Fatalf("wrong query: %#v", deleteJet)
+	}
+
+	query, err := q.Query("GetUser", 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sql, args, err := Build(query)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != expected.SQL || !reflect.DeepEqual(args, []any{1}) {
+		t.Fatalf("wrong query built: %q %v", sql, args)
+	}
+
+	if _, err := q.Query("Unknown"); err == nil {
+		t.Fatal("expected an error for an unknown query")
+	}
+}
+
+func TestQueriesFromFSErrors(t *testing.T) {
+	cases := map[string]string{
+		"duplicate": "-- name: A\nSELECT 1;\n-- name: A\nSELECT 2;",
+		"no sql":    "-- name: A\n-- result: one\n",
+		"no name":   "-- name:\nSELECT 1;",
+		"outside":   "SELECT 1;\n-- name: A\nSELECT 2;",
+	}
+
+	for name, content := range cases {
+		t.Run(name, func(t *testing.T) {
+			_, err := QueriesFromFS(fstest.MapFS{"q.sql": {Data: []byte(content)}})
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			if !strings.HasPrefix(err.Error(), "q.sql") {
+				t.Fatalf("error does not name the file: %v", err)
+			}
+		})
+	}
+}
+
+type countingPreparer struct {
+	NoopExecutor
+	prepared *[]string
+}
+
+func (c countingPreparer) PrepareContext(_ context.Context, query string) (Statement, error) {
+	*c.prepared = append(*c.prepared, query)
+	return noopStatement{}, nil
+}
+
+var _ pooledPreparer = DB{}
+
+// countingDB is a database handle, whose statements are cached
+type countingDB struct {
+	countingPreparer
+}
+
+func (countingDB) Conn(context.Context) (Conn, error) {
+	return Conn{}, nil
+}
+
+type noopStatement struct{}
+
+func (noopStatement) ExecContext(context.Context, ...any) (sql.Result, error) {
+	return nil, nil
+}
+
+func (noopStatement) QueryContext(context.Context, ...any) (scan.Rows, error) {
+	return nil, nil
+}
+
+func TestQueriesPrepareCache(t *testing.T) {
+	ctx := context.Background()
+
+	q, err := QueriesFromFS(queriesFS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var prepared, otherPrepared []string
+	exec := countingDB{countingPreparer{prepared: &prepared}}
+	other := countingDB{countingPreparer{prepared: &otherPrepared}}
+
+	for i := 0; i < 3; i++ {
+		if _, err := q.Prepare(ctx, exec, "GetUser"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := PrepareNamed(ctx, exec, q, "ListUsers", scan.SingleColumnMapper[int]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := q.Prepare(ctx, other, "GetUser"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(prepared) != 2 {
+		t.Fatalf("expected 2 statements to be prepared, got %d: %v", len(prepared), prepared)
+	}
+	if len(otherPrepared) != 1 {
+		t.Fatalf("expected each executor to prepare its own statement, got %v", otherPrepared)
+	}
+
+	if _, err := q.Prepare(ctx, exec, "Unknown"); err == nil {
+		t.Fatal("expected an error for an unknown query")
+	}
+
+	if err := q.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := q.Prepare(ctx, exec, "GetUser"); err != nil {
+		t.Fatal(err)
+	}
+	if len(prepared) != 3 {
+		t.Fatalf("expected the statement to be prepared again after Close, got %d", len(prepared))
+	}
+}
+
+func TestQueriesPrepareTx(t *testing.T) {
+	ctx := context.Background()
+
+	q, err := QueriesFromFS(queriesFS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var prepared []string
+	tx := countingPreparer{prepared: &prepared}
+
+	for i := 0; i < 3; i++ {
+		if _, err := q.Prepare(ctx, tx, "GetUser"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(prepared) != 3 {
+		t.Fatalf("expected the statement to be prepared each time, got %d", len(prepared))
+	}
+	if len(q.caches) != 0 {
+		t.Fatalf("expected the statements of a transaction not to be cached, got %d", len(q.caches))
+	}
+}
+
+func TestQueriesWarmup(t *testing.T) {
+	ctx := context.Background()
+
+	q, err := QueriesFromFS(queriesFS)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var prepared []string
+	exec := countingDB{countingPreparer{prepared: &prepared}}
+
+	if err := q.Warmup(ctx, exec); err != nil {
+		t.Fatal(err)
+	}
+	if len(prepared) != len(q.Names()) {
+		t.Fatalf("expected all the queries to be prepared, got %v", prepared)
+	}
+
+	if _, err := q.Prepare(ctx, exec, "GetUser"); err != nil {
+		t.Fatal(err)
+	}
+	if len(prepared) != len(q.Names()) {
+		t.Fatalf("expected the warmed statement to be reused, got %v", prepared)
+	}
+
+	if err := q.Warmup(ctx, exec, "Unknown"); err == nil {
+		t.Fatal("expected an error for an unknown query")
+	}
+}
@@ -3,6 +3,7 @@ package bob
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"io"
 
 	"github.com/qdm12/reprint"
@@ -15,6 +16,11 @@ const (
 	closePar = ")"
 )
 
+// ErrMissingWhere is returned when building an UPDATE or DELETE query without
+// a WHERE clause, which would change every row of the table.
+// To do this on purpose, add the AllRows mod of the query
+var ErrMissingWhere = errors.New("UPDATE or DELETE without a WHERE clause, use the AllRows mod to change all rows")
+
 type Query interface {
 	// It should satisfy the Expression interface so that it can be used
 	// in places such as a sub-select
@@ -32,8 +38,9 @@ type Mod[T any] interface {
 }
 
 var (
-	_ Loadable     = BaseQuery[Expression]{}
-	_ MapperModder = BaseQuery[Expression]{}
+	_ Loadable        = BaseQuery[Expression]{}
+	_ MapperModder    = BaseQuery[Expression]{}
+	_ FeatureRequirer = BaseQuery[Expression]{}
 )
 
 // BaseQuery wraps common functionality such as cloning, applying new mods and
@@ -41,19 +48,29 @@ var (
 type BaseQuery[E Expression] struct {
 	Expression E
 	Dialect    Dialect
+
+	// Only set for queries built with Traced
+	trace *QueryTrace
 }
 
 func (b BaseQuery[E]) Clone() BaseQuery[E] {
+	var trace *QueryTrace
+	if b.trace != nil {
+		trace = &QueryTrace{Steps: append([]TraceStep(nil), b.trace.Steps...)}
+	}
+
 	if c, ok := any(b.Expression).(interface{ Clone() E }); ok {
 		return BaseQuery[E]{
 			Expression: c.Clone(),
 			Dialect:    b.Dialect,
+			trace:      trace,
 		}
 	}
 
 	return BaseQuery[E]{
 		Expression: reprint.This(b.Expression).(E),
 		Dialect:    b.Dialect,
+		trace:      trace,
 	}
 }
 
@@ -77,14 +94,48 @@ func (b BaseQuery[E]) GetMapperMods() []scan.MapperMod {
 	return nil
 }
 
+func (b BaseQuery[E]) RequiredFeatures() []Feature {
+	r, ok := any(b.Expression).(FeatureRequirer)
+	if !ok {
+		return nil
+	}
+
+	features := r.RequiredFeatures()
+
+	// The dialect may write them differently for an older server
+	e, ok := b.Dialect.(FeatureEmulator)
+	if !ok {
+		return features
+	}
+
+	var required []Feature
+	for _, f := range features {
+		if !e.Emulates(f) {
+			required = append(required, f)
+		}
+	}
+
+	return required
+}
+
 func (b BaseQuery[E]) Apply(mods ...Mod[E]) {
+	if b.trace != nil {
+		b.applyTraced(callerOf(2), mods)
+		return
+	}
+
 	for _, mod := range mods {
 		mod.Apply(b.Expression)
 	}
 }
 
 func (b BaseQuery[E]) WriteQuery(w io.Writer, start int) ([]any, error) {
-	return b.Expression.WriteSQL(w, b.Dialect, start)
+	args, err := b.Expression.WriteSQL(w, b.Dialect, start)
+	if err != nil {
+		return args, err
+	}
+
+	return args, checkArgs(b.Dialect, args)
 }
 
 // Satisfies the Expression interface, but uses its own dialect instead
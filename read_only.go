@@ -0,0 +1,171 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/stephenafamo/scan"
+)
+
+// ErrReadOnly is returned by a [ReadOnly] executor for queries that can change data
+var ErrReadOnly = errors.New("query is not allowed on a read-only executor")
+
+// ReadOnly wraps an [Executor] so that only queries that read data are sent
+// to the database. Any other query, such as INSERT, UPDATE, DELETE or DDL,
+// returns an error wrapping [ErrReadOnly] before it is sent.
+//
+// SELECT, VALUES, TABLE, SHOW, DESCRIBE and EXPLAIN (without ANALYZE) are allowed,
+// as are WITH queries without data-modifying statements.
+// Queries with several statements, such as "SELECT 1; DELETE FROM users", are not allowed.
+// If the executor is a [Preparer], so is the returned executor, and statements
+// are checked when they are prepared
+func ReadOnly(exec Executor) Executor {
+	if p, ok := exec.(Preparer); ok {
+		return readOnlyPreparer{readOnlyExecutor{p}, p}
+	}
+
+	return readOnlyExecutor{exec}
+}
+
+type readOnlyExecutor struct {
+	exec Executor
+}
+
+// Unwrap returns the wrapped executor
+func (r readOnlyExecutor) Unwrap() Executor {
+	return r.exec
+}
+
+func (r readOnlyExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if err := checkReadOnly(query); err != nil {
+		return nil, err
+	}
+
+	return r.exec.ExecContext(ctx, query, args...)
+}
+
+func (r readOnlyExecutor) QueryContext(ctx context.Context, query string, args ...any) (scan.Rows, error) {
+	if err := checkReadOnly(query); err != nil {
+		return nil, err
+	}
+
+	return r.exec.QueryContext(ctx, query, args...)
+}
+
+type readOnlyPreparer struct {
+	readOnlyExecutor
+	preparer Preparer
+}
+
+func (r readOnlyPreparer) PrepareContext(ctx context.Context, query string) (Statement, error) {
+	if err := checkReadOnly(query); err != nil {
+		return nil, err
+	}
+
+	return r.preparer.PrepareContext(ctx, query)
+}
+
+func checkReadOnly(query string) error {
+	words := sqlKeywords(query)
+	// A trailing semicolon ends the only statement
+	for len(words) > 0 && words[len(words)-1] == ";" {
+		words = words[:len(words)-1]
+	}
+	if len(words) == 0 {
+		return fmt.Errorf("%w: empty query", ErrReadOnly)
+	}
+
+	if containsWord(words, ";") {
+		return fmt.Errorf("%w: multiple statements", ErrReadOnly)
+	}
+
+	switch words[0] {
+	case "SELECT", "VALUES", "TABLE", "SHOW", "DESCRIBE", "DESC":
+		// SELECT ... INTO creates a table in Postgres
+		if words[0] == "SELECT" && containsWord(words, "INTO") {
+			return fmt.Errorf("%w: SELECT INTO", ErrReadOnly)
+		}
+		return nil
+
+	case "EXPLAIN":
+		// EXPLAIN ANALYZE executes the query
+		if containsWord(words, "ANALYZE") || containsWord(words, "ANALYSE") {
+			return fmt.Errorf("%w: EXPLAIN ANALYZE", ErrReadOnly)
+		}
+		return nil
+
+	case "WITH":
+		for _, w := range words {
+			switch w {
+			case "INSERT", "UPDATE", "DELETE", "MERGE", "INTO":
+				return fmt.Errorf("%w: WITH query containing %s", ErrReadOnly, w)
+			}
+		}
+		return nil
+	}
+
+	return fmt.Errorf("%w: %s", ErrReadOnly, words[0])
+}
+
+func containsWord(words []string, word string) bool {
+	for _, w := range words {
+		if w == word {
+			return true
+		}
+	}
+
+	return false
+}
+
+// sqlKeywords returns the upper cased words and the semicolons of the query,
+// leaving out comments, strings and quoted identifiers
+func sqlKeywords(query string) []string {
+	var words []string
+
+	for i := 0; i < len(query); {
+		c := query[i]
+
+		switch {
+		case strings.HasPrefix(query[i:], "--"):
+			end := strings.IndexByte(query[i:], '\n')
+			if end < 0 {
+				return words
+			}
+			i += end + 1
+
+		case strings.HasPrefix(query[i:], "/*"):
+			end := strings.Index(query[i+2:], "*/")
+			if end < 0 {
+				return words
+			}
+			i += end + 4
+
+		case c == '\'' || c == '"' || c == '`':
+			end := strings.IndexByte(query[i+1:], c)
+			if end < 0 {
+				return words
+			}
+			i += end + 2
+
+		case c == '_' || unicode.IsLetter(rune(c)):
+			start := i
+			for i < len(query) && (query[i] == '_' || unicode.IsLetter(rune(query[i])) || unicode.IsDigit(rune(query[i]))) {
+				i++
+			}
+			words = append(words, strings.ToUpper(query[start:i]))
+
+		case c == ';':
+			words = append(words, ";")
+			i++
+
+		default:
+			i++
+		}
+	}
+
+	return words
+}
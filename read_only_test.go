@@ -0,0 +1,81 @@
+package bob
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestReadOnly(t *testing.T) {
+	ctx := context.Background()
+
+	allowed := []string{
+		"SELECT * FROM users",
+		"  select id FROM users WHERE name = 'DELETE'",
+		"-- INSERT\nSELECT 1",
+		"/* UPDATE */ (SELECT 1) UNION (SELECT 2)",
+		"WITH recent AS (SELECT * FROM users) SELECT * FROM recent",
+		"VALUES (1), (2)",
+		"SHOW TABLES",
+		"EXPLAIN SELECT * FROM users",
+		`SELECT "delete" FROM users`,
+		"SELECT 1;",
+		"SELECT ';' FROM users -- ;\n",
+	}
+
+	rejected := []string{
+		"INSERT INTO users (name) VALUES ('Stephen')",
+		"update users SET name = 'Stephen'",
+		"DELETE FROM users",
+		"CREATE TABLE users (id int)",
+		"DROP TABLE users",
+		"TRUNCATE users",
+		"WITH deleted AS (DELETE FROM users RETURNING *) SELECT * FROM deleted",
+		"SELECT * INTO archive FROM users",
+		"EXPLAIN ANALYZE DELETE FROM users",
+		"SELECT 1; DELETE FROM users",
+		"select 1 /* ; */; drop table users;",
+		"-- only a comment",
+		"",
+	}
+
+	exec := ReadOnly(NoopExecutor{})
+
+	for _, q := range allowed {
+		if _, err := exec.ExecContext(ctx, q); err != nil {
+			t.Errorf("%q: %v", q, err)
+		}
+		if _, err := exec.QueryContext(ctx, q); err != nil {
+			t.Errorf("%q: %v", q, err)
+		}
+	}
+
+	for _, q := range rejected {
+		if _, err := exec.ExecContext(ctx, q); !errors.Is(err, ErrReadOnly) {
+			t.Errorf("%q: expected ErrReadOnly, got %v", q, err)
+		}
+		if _, err := exec.QueryContext(ctx, q); !errors.Is(err, ErrReadOnly) {
+			t.Errorf("%q: expected ErrReadOnly, got %v", q, err)
+		}
+	}
+
+	if _, ok := exec.(Preparer); ok {
+		t.Error("the executor should only be a Preparer if the wrapped one is")
+	}
+
+	var prepared []string
+	p, ok := ReadOnly(countingPreparer{prepared: &prepared}).(Preparer)
+	if !ok {
+		t.Fatal("expected the executor to be a Preparer")
+	}
+
+	if _, err := p.PrepareContext(ctx, "DELETE FROM users"); !errors.Is(err, ErrReadOnly) {
+		t.Errorf("expected ErrReadOnly when preparing, got %v", err)
+	}
+	if _, err := p.PrepareContext(ctx, "SELECT * FROM users"); err != nil {
+		t.Error(err)
+	}
+	if len(prepared) != 1 {
+		t.Errorf("expected only the SELECT to be prepared, got %v", prepared)
+	}
+}
@@ -0,0 +1,79 @@
+package bob
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// ErrUnknownDialect is returned when no dialect is registered with a name
+var ErrUnknownDialect = errors.New("unknown dialect")
+
+//nolint:gochecknoglobals
+var (
+	dialectsMu sync.RWMutex
+	dialects   = make(map[string]Dialect)
+)
+
+// RegisterDialect makes a dialect available by name, e.g. to choose it from the configuration.
+// The dialects of Bob register themselves when their package is imported, with the names
+// of the Server constants such as "postgres" and "sqlite".
+// It panics if the name is already registered or the dialect is nil
+func RegisterDialect(name string, d Dialect) {
+	dialectsMu.Lock()
+	defer dialectsMu.Unlock()
+
+	if d == nil {
+		panic("bob: RegisterDialect dialect is nil")
+	}
+	if _, ok := dialects[name]; ok {
+		panic("bob: RegisterDialect called twice for dialect " + name)
+	}
+
+	dialects[name] = d
+}
+
+// LookupDialect returns the dialect registered with the name,
+// or an error wrapping [ErrUnknownDialect]
+func LookupDialect(name string) (Dialect, error) {
+	dialectsMu.RLock()
+	defer dialectsMu.RUnlock()
+
+	d, ok := dialects[name]
+	if !ok {
+		return nil, fmt.Errorf("%w %q", ErrUnknownDialect, name)
+	}
+
+	return d, nil
+}
+
+// Dialects returns the names of the registered dialects, sorted
+func Dialects() []string {
+	dialectsMu.RLock()
+	defer dialectsMu.RUnlock()
+
+	names := make([]string, 0, len(dialects))
+	for name := range dialects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// TranspileTo returns the query written for the dialect registered with the name,
+// so that one query constructor serves several databases that are chosen at runtime:
+//
+//	q := psql.Select(sm.From("users"), sm.Where(psql.Quote("id").EQ(psql.Arg(id))))
+//	transpiled, err := bob.TranspileTo(q, cfg.Database) // "postgres" or "sqlite"
+//
+// See [Transpile] for the constructs that are checked
+func TranspileTo(q Query, dialect string) (Query, error) {
+	d, err := LookupDialect(dialect)
+	if err != nil {
+		return nil, err
+	}
+
+	return Transpile(q, d)
+}
@@ -0,0 +1,41 @@
+package bob
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDialectRegistry(t *testing.T) {
+	RegisterDialect("test-question", questionDialect{})
+
+	found := false
+	for _, name := range Dialects() {
+		found = found || name == "test-question"
+	}
+	if !found {
+		t.Fatalf("expected the dialect in %v", Dialects())
+	}
+
+	q, err := TranspileTo(rawQuery(d, "a = # OR b = #", 1, 2), "test-question")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sql, args, err := Build(q)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sql != "a = ? OR b = ?" || len(args) != 2 {
+		t.Fatalf("got %q %v", sql, args)
+	}
+
+	if _, err := TranspileTo(q, "unknown"); !errors.Is(err, ErrUnknownDialect) {
+		t.Fatalf("expected ErrUnknownDialect, got %v", err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected registering a name twice to panic")
+		}
+	}()
+	RegisterDialect("test-question", questionDialect{})
+}
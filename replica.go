@@ -0,0 +1,243 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"math/rand"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/stephenafamo/scan"
+)
+
+type (
+	routeCtx        struct{}
+	maxStalenessCtx struct{}
+	consistencyCtx  struct{}
+)
+
+// catchUpPoll is how often the replicas are checked while a read waits for one to catch up
+const catchUpPoll = 10 * time.Millisecond
+
+type route int
+
+const (
+	routeAuto route = iota
+	routePrimary
+	routeReplica
+)
+
+// PreferReplica returns a context that sends the queries of a [ReplicaRouter]
+// to a replica, even if they do not look like reads, e.g. a call to a function that only reads.
+// If there are no replicas, the queries go to the primary
+func PreferReplica(ctx context.Context) context.Context {
+	return context.WithValue(ctx, routeCtx{}, routeReplica)
+}
+
+// RequirePrimary returns a context that sends the queries of a [ReplicaRouter]
+// to the primary, e.g. to read data right after it was written
+func RequirePrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, routeCtx{}, routePrimary)
+}
+
+// MaxStaleness returns a context that only sends the reads of a [ReplicaRouter] to a
+// replica that lags behind the primary by at most d. If no replica is recent enough,
+// the queries go to the primary
+func MaxStaleness(ctx context.Context, d time.Duration) context.Context {
+	return context.WithValue(ctx, maxStalenessCtx{}, d)
+}
+
+// WithConsistencyToken returns a context that only sends the reads of a [ReplicaRouter] to
+// a replica that has applied the writes up to the token, e.g. to read the writes of a previous
+// request. The token is returned by [ReplicaRouter.ConsistencyToken] after the writes
+func WithConsistencyToken(ctx context.Context, token string) context.Context {
+	return context.WithValue(ctx, consistencyCtx{}, token)
+}
+
+// ConsistencyTokenFrom returns the token of [WithConsistencyToken], if the context has one
+func ConsistencyTokenFrom(ctx context.Context) (string, bool) {
+	token, ok := ctx.Value(consistencyCtx{}).(string)
+	return token, ok
+}
+
+// ReplicaRouter is an [Executor] that sends reads to the replicas and everything else
+// to the primary. A query is a read if it is allowed by [ReadOnly], and does not lock
+// rows with FOR UPDATE or FOR SHARE, or call a function that writes or depends on
+// the session, such as nextval or pg_advisory_lock.
+// The contexts of [PreferReplica], [RequirePrimary], [MaxStaleness] and [WithConsistencyToken] override this for
+// single queries. Each query goes to a random replica
+type ReplicaRouter struct {
+	Primary  Executor
+	Replicas []Executor
+	// Lag returns how far a replica lags behind the primary, such as psql.ReplicationLag.
+	// Without it, the queries with a [MaxStaleness] go to the primary
+	Lag func(ctx context.Context, exec Executor) (time.Duration, error)
+	// How long the lag of a replica is reused for, so that Lag is not called
+	// for every query. 1 second by default, a negative duration disables the cache
+	LagTTL time.Duration
+	// Token returns the position of the primary, such as psql.CurrentLSN or mysql.CurrentGTID.
+	// It is needed for [ReplicaRouter.ConsistencyToken]
+	Token func(ctx context.Context, exec Executor) (string, error)
+	// CaughtUp reports if a replica has applied the writes up to a token of Token, such as
+	// psql.ReplayedLSN or mysql.ExecutedGTID. Without it, the queries with a
+	// [WithConsistencyToken] go to the primary
+	CaughtUp func(ctx context.Context, exec Executor, token string) (bool, error)
+	// How long a read with a consistency token waits for a replica to catch up.
+	// If none has caught up by then, the read goes to the primary
+	CatchUpTimeout time.Duration
+}
+
+// ConsistencyToken returns the position of the primary, to pass to [WithConsistencyToken]
+// after a write so that the later reads see it
+func (r ReplicaRouter) ConsistencyToken(ctx context.Context) (string, error) {
+	if r.Token == nil {
+		return "", errors.New("replica router: no Token function")
+	}
+
+	return r.Token(ctx, r.Primary)
+}
+
+func (r ReplicaRouter) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return r.route(ctx, query).ExecContext(ctx, query, args...)
+}
+
+func (r ReplicaRouter) QueryContext(ctx context.Context, query string, args ...any) (scan.Rows, error) {
+	return r.route(ctx, query).QueryContext(ctx, query, args...)
+}
+
+func (r ReplicaRouter) route(ctx context.Context, query string) Executor {
+	if len(r.Replicas) == 0 {
+		return r.Primary
+	}
+
+	switch route, _ := ctx.Value(routeCtx{}).(route); route {
+	case routePrimary:
+		return r.Primary
+	case routeAuto:
+		if !isReplicaRead(query) {
+			return r.Primary
+		}
+	}
+
+	maxLag, hasMaxLag := ctx.Value(maxStalenessCtx{}).(time.Duration)
+	token, hasToken := ConsistencyTokenFrom(ctx)
+	if !hasMaxLag && !hasToken {
+		return r.Replicas[rand.Intn(len(r.Replicas))]
+	}
+
+	if (hasMaxLag && r.Lag == nil) || (hasToken && r.CaughtUp == nil) {
+		return r.Primary
+	}
+
+	deadline := time.Now().Add(r.CatchUpTimeout)
+	for {
+		// Starting from a random replica, the first one that is recent enough
+		start := rand.Intn(len(r.Replicas))
+		for i := range r.Replicas {
+			replica := r.Replicas[(start+i)%len(r.Replicas)]
+			if hasMaxLag {
+				if lag, err := r.lag(ctx, replica); err != nil || lag > maxLag {
+					continue
+				}
+			}
+			if hasToken {
+				if ok, err := r.CaughtUp(ctx, replica, token); err != nil || !ok {
+					continue
+				}
+			}
+
+			return replica
+		}
+
+		// Only the reads with a token wait for a replica to catch up
+		wait := time.Until(deadline)
+		if !hasToken || wait <= 0 {
+			return r.Primary
+		}
+		if wait > catchUpPoll {
+			wait = catchUpPoll
+		}
+
+		select {
+		case <-ctx.Done():
+			return r.Primary
+		case <-time.After(wait):
+		}
+	}
+}
+
+// primaryFunctions are the functions that write, or depend on the session,
+// so the queries calling them are sent to the primary
+//
+//nolint:gochecknoglobals
+var primaryFunctions = map[string]bool{
+	"NEXTVAL": true, "SETVAL": true, "CURRVAL": true, "LASTVAL": true,
+	"PG_ADVISORY_LOCK": true, "PG_ADVISORY_XACT_LOCK": true,
+	"PG_TRY_ADVISORY_LOCK": true, "PG_TRY_ADVISORY_XACT_LOCK": true,
+	"PG_ADVISORY_LOCK_SHARED": true, "PG_ADVISORY_UNLOCK": true,
+	"TXID_CURRENT": true, "PG_CURRENT_XACT_ID": true,
+	"GET_LOCK": true, "RELEASE_LOCK": true, "LAST_INSERT_ID": true,
+}
+
+// isReplicaRead reports if the query only reads, and can be sent to a replica
+func isReplicaRead(query string) bool {
+	if checkReadOnly(query) != nil {
+		return false
+	}
+
+	words := sqlKeywords(query)
+	for i, w := range words {
+		if primaryFunctions[w] {
+			return false
+		}
+
+		// FOR UPDATE, FOR NO KEY UPDATE, FOR SHARE, FOR KEY SHARE and LOCK IN SHARE MODE
+		if i+1 < len(words) {
+			switch next := words[i+1]; {
+			case w == "FOR" && (next == "UPDATE" || next == "SHARE" || next == "NO" || next == "KEY"):
+				return false
+			case w == "LOCK" && next == "IN":
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+type replicaLag struct {
+	lag time.Duration
+	err error
+	at  time.Time
+}
+
+// replicaLags caches the lag of the replicas for the LagTTL of the routers
+//
+//nolint:gochecknoglobals
+var replicaLags sync.Map
+
+// lag returns the lag of the replica, cached for LagTTL
+func (r ReplicaRouter) lag(ctx context.Context, replica Executor) (time.Duration, error) {
+	ttl := r.LagTTL
+	if ttl == 0 {
+		ttl = time.Second
+	}
+
+	cacheable := ttl > 0 && replica != nil && reflect.TypeOf(replica).Comparable()
+	if cacheable {
+		if cached, ok := replicaLags.Load(replica); ok {
+			if c := cached.(replicaLag); time.Since(c.at) < ttl {
+				return c.lag, c.err
+			}
+		}
+	}
+
+	lag, err := r.Lag(ctx, replica)
+	if cacheable && ctx.Err() == nil {
+		replicaLags.Store(replica, replicaLag{lag: lag, err: err, at: time.Now()})
+	}
+
+	return lag, err
+}
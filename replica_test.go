@@ -0,0 +1,193 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stephenafamo/scan"
+)
+
+// namedExecutor records the queries sent to it in a shared log
+type namedExecutor struct {
+	name string
+	log  *[]string
+}
+
+func (n namedExecutor) ExecContext(context.Context, string, ...any) (sql.Result, error) {
+	*n.log = append(*n.log, n.name)
+	return nil, nil
+}
+
+func (n namedExecutor) QueryContext(context.Context, string, ...any) (scan.Rows, error) {
+	*n.log = append(*n.log, n.name)
+	return nil, nil
+}
+
+func TestReplicaRouter(t *testing.T) {
+	var log []string
+	lags := map[string]time.Duration{"replica-1": time.Second, "replica-2": time.Minute}
+
+	router := ReplicaRouter{
+		Primary:  namedExecutor{"primary", &log},
+		Replicas: []Executor{namedExecutor{"replica-1", &log}, namedExecutor{"replica-2", &log}},
+		Lag: func(_ context.Context, exec Executor) (time.Duration, error) {
+			return lags[exec.(namedExecutor).name], nil
+		},
+	}
+
+	ctx := context.Background()
+	cases := map[string]struct {
+		ctx      context.Context
+		query    string
+		expected []string
+	}{
+		"read":             {ctx, "SELECT * FROM users", []string{"replica-1", "replica-2"}},
+		"write":            {ctx, "UPDATE users SET name = $1", []string{"primary"}},
+		"require primary":  {RequirePrimary(ctx), "SELECT * FROM users", []string{"primary"}},
+		"prefer replica":   {PreferReplica(ctx), "SELECT refresh_stats()", []string{"replica-1", "replica-2"}},
+		"overridden":       {RequirePrimary(PreferReplica(ctx)), "SELECT 1", []string{"primary"}},
+		"max staleness":    {MaxStaleness(ctx, 10*time.Second), "SELECT * FROM users", []string{"replica-1"}},
+		"too stale":        {MaxStaleness(ctx, time.Millisecond), "SELECT * FROM users", []string{"primary"}},
+		"stale write":      {MaxStaleness(ctx, time.Hour), "DELETE FROM users", []string{"primary"}},
+		"for update":       {ctx, "SELECT * FROM users FOR UPDATE", []string{"primary"}},
+		"for share":        {ctx, "SELECT * FROM users FOR SHARE SKIP LOCKED", []string{"primary"}},
+		"lock in share":    {ctx, "SELECT * FROM users LOCK IN SHARE MODE", []string{"primary"}},
+		"nextval":          {ctx, "SELECT nextval('users_id_seq')", []string{"primary"}},
+		"advisory lock":    {ctx, "SELECT pg_advisory_lock(1)", []string{"primary"}},
+		"column named for": {ctx, `SELECT "for" FROM users`, []string{"replica-1", "replica-2"}},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			for i := 0; i < 10; i++ {
+				log = nil
+				if _, err := router.QueryContext(tc.ctx, tc.query); err != nil {
+					t.Fatal(err)
+				}
+				if len(log) != 1 || !containsString(tc.expected, log[0]) {
+					t.Fatalf("sent to %v, expected one of %v", log, tc.expected)
+				}
+			}
+		})
+	}
+
+	// Without replicas, everything goes to the primary
+	log = nil
+	only := ReplicaRouter{Primary: namedExecutor{"primary", &log}}
+	if _, err := only.ExecContext(PreferReplica(ctx), "SELECT 1"); err != nil {
+		t.Fatal(err)
+	}
+	if len(log) != 1 || log[0] != "primary" {
+		t.Fatalf("sent to %v", log)
+	}
+}
+
+func TestReplicaRouterLagCache(t *testing.T) {
+	var log []string
+	var calls int
+
+	router := ReplicaRouter{
+		Primary:  namedExecutor{"primary", &log},
+		Replicas: []Executor{namedExecutor{"replica-1", &log}},
+		Lag: func(context.Context, Executor) (time.Duration, error) {
+			calls++
+			return time.Second, nil
+		},
+	}
+
+	ctx := MaxStaleness(context.Background(), time.Minute)
+	for i := 0; i < 5; i++ {
+		if _, err := router.QueryContext(ctx, "SELECT 1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 1 {
+		t.Fatalf("expected the lag to be checked once, got %d", calls)
+	}
+
+	router.LagTTL = -1
+	for i := 0; i < 5; i++ {
+		if _, err := router.QueryContext(ctx, "SELECT 1"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if calls != 6 {
+		t.Fatalf("expected the lag to be checked for every query, got %d", calls)
+	}
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
+
+func TestReplicaRouterConsistency(t *testing.T) {
+	var log []string
+	var mu sync.Mutex
+	applied := map[string]int{"primary": 10, "replica-1": 5, "replica-2": 7}
+
+	router := ReplicaRouter{
+		Primary:  namedExecutor{"primary", &log},
+		Replicas: []Executor{namedExecutor{"replica-1", &log}, namedExecutor{"replica-2", &log}},
+		Token: func(_ context.Context, exec Executor) (string, error) {
+			return strconv.Itoa(applied[exec.(namedExecutor).name]), nil
+		},
+		CaughtUp: func(_ context.Context, exec Executor, token string) (bool, error) {
+			mu.Lock()
+			defer mu.Unlock()
+
+			position, err := strconv.Atoi(token)
+			return applied[exec.(namedExecutor).name] >= position, err
+		},
+	}
+
+	ctx := context.Background()
+	token, err := router.ConsistencyToken(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if token != "10" {
+		t.Fatalf("expected the token of the primary, got %q", token)
+	}
+
+	route := func(ctx context.Context, router ReplicaRouter) string {
+		t.Helper()
+		log = nil
+		if _, err := router.QueryContext(ctx, "SELECT * FROM users"); err != nil {
+			t.Fatal(err)
+		}
+		return log[0]
+	}
+
+	if sent := route(WithConsistencyToken(ctx, "6"), router); sent != "replica-2" {
+		t.Fatalf("expected the replica that caught up, got %s", sent)
+	}
+	if sent := route(WithConsistencyToken(ctx, token), router); sent != "primary" {
+		t.Fatalf("expected the primary without a timeout, got %s", sent)
+	}
+
+	// replica-1 catches up while the read waits
+	go func() {
+		time.Sleep(3 * catchUpPoll)
+		mu.Lock()
+		applied["replica-1"] = 10
+		mu.Unlock()
+	}()
+	router.CatchUpTimeout = time.Second
+	if sent := route(WithConsistencyToken(ctx, token), router); sent != "replica-1" {
+		t.Fatalf("expected the replica that caught up while waiting, got %s", sent)
+	}
+
+	router.CaughtUp = nil
+	if sent := route(WithConsistencyToken(ctx, "1"), router); sent != "primary" {
+		t.Fatalf("expected the primary without CaughtUp, got %s", sent)
+	}
+}
@@ -0,0 +1,88 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"io"
+	"syscall"
+
+	"github.com/stephenafamo/scan"
+)
+
+// IsConnError reports if the error means that the connection to the database
+// was lost or could not be made, such as [driver.ErrBadConn] or a reset connection.
+// It is the default classifier of [RetryReads]
+func IsConnError(err error) bool {
+	switch {
+	case err == nil:
+		return false
+	case connLost(err),
+		errors.Is(err, io.EOF),
+		errors.Is(err, io.ErrUnexpectedEOF),
+		errors.Is(err, syscall.ECONNRESET),
+		errors.Is(err, syscall.ECONNREFUSED),
+		errors.Is(err, syscall.ECONNABORTED),
+		errors.Is(err, syscall.EPIPE):
+		return true
+	default:
+		return false
+	}
+}
+
+// RetryReads wraps an [Executor] so that read-only queries, as allowed by [ReadOnly],
+// are sent again once if they fail with an error for which isRetryable returns true,
+// e.g. while the database fails over. If isRetryable is nil, [IsConnError] is used.
+// The dialects may have their own classifiers, such as psql.IsConnError.
+//
+// Only the error of running the query is checked: errors while reading the rows are
+// returned as usual. Queries that change data are never retried, since they
+// may have been applied before the connection was lost.
+// If the executor is a [Preparer], so is the returned executor, but statements are not retried
+func RetryReads(exec Executor, isRetryable func(error) bool) Executor {
+	if isRetryable == nil {
+		isRetryable = IsConnError
+	}
+
+	r := retryExecutor{Executor: exec, isRetryable: isRetryable}
+	if p, ok := exec.(Preparer); ok {
+		return retryPreparer{retryExecutor: r, preparer: p}
+	}
+
+	return r
+}
+
+type retryExecutor struct {
+	Executor
+	isRetryable func(error) bool
+}
+
+// Unwrap returns the wrapped executor
+func (r retryExecutor) Unwrap() Executor {
+	return r.Executor
+}
+
+func (r retryExecutor) QueryContext(ctx context.Context, query string, args ...any) (scan.Rows, error) {
+	rows, err := r.Executor.QueryContext(ctx, query, args...)
+	if err == nil || ctx.Err() != nil || !r.isRetryable(err) || checkReadOnly(query) != nil {
+		return rows, err
+	}
+
+	return r.Executor.QueryContext(ctx, query, args...)
+}
+
+type retryPreparer struct {
+	retryExecutor
+	preparer Preparer
+}
+
+func (r retryPreparer) PrepareContext(ctx context.Context, query string) (Statement, error) {
+	return r.preparer.PrepareContext(ctx, query)
+}
+
+// connLost reports if the query could not be sent because the connection
+// was lost, in which case it is safe to retry
+func connLost(err error) bool {
+	return errors.Is(err, driver.ErrBadConn) || errors.Is(err, sql.ErrConnDone)
+}
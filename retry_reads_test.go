@@ -0,0 +1,74 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"syscall"
+	"testing"
+
+	"github.com/stephenafamo/scan"
+)
+
+// failingExecutor fails the first queries with err
+type failingExecutor struct {
+	NoopExecutor
+	err      error
+	failures int
+	queries  int
+}
+
+func (f *failingExecutor) QueryContext(context.Context, string, ...any) (scan.Rows, error) {
+	f.queries++
+	if f.queries <= f.failures {
+		return nil, f.err
+	}
+	return nil, nil
+}
+
+func TestRetryReads(t *testing.T) {
+	ctx := context.Background()
+
+	cases := map[string]struct {
+		query     string
+		err       error
+		failures  int
+		isConn    func(error) bool
+		queries   int
+		expectErr bool
+	}{
+		"select":          {query: "SELECT * FROM users", err: driver.ErrBadConn, failures: 1, queries: 2},
+		"connection done": {query: "SELECT 1", err: fmt.Errorf("query: %w", sql.ErrConnDone), failures: 1, queries: 2},
+		"reset":           {query: "SELECT 1", err: syscall.ECONNRESET, failures: 1, queries: 2},
+		"only once":       {query: "SELECT 1", err: driver.ErrBadConn, failures: 2, queries: 2, expectErr: true},
+		"write":           {query: "INSERT INTO users DEFAULT VALUES RETURNING id", err: driver.ErrBadConn, failures: 1, queries: 1, expectErr: true},
+		"other error":     {query: "SELECT 1", err: errors.New("syntax error"), failures: 1, queries: 1, expectErr: true},
+		"classifier": {
+			query: "SELECT 1", err: errors.New("failover"), failures: 1, queries: 2,
+			isConn: func(err error) bool { return err.Error() == "failover" },
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			exec := &failingExecutor{err: tc.err, failures: tc.failures}
+
+			_, err := RetryReads(exec, tc.isConn).QueryContext(ctx, tc.query)
+			if tc.expectErr != (err != nil) {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if exec.queries != tc.queries {
+				t.Fatalf("got %d queries, want %d", exec.queries, tc.queries)
+			}
+		})
+	}
+
+	if _, ok := RetryReads(&failingExecutor{}, nil).(Preparer); ok {
+		t.Fatal("the executor should not be a preparer")
+	}
+	if _, ok := RetryReads(countingPreparer{}, nil).(Preparer); !ok {
+		t.Fatal("the executor should be a preparer")
+	}
+}
@@ -0,0 +1,52 @@
+package bob
+
+import (
+	"context"
+
+	"github.com/stephenafamo/scan"
+)
+
+// returner is a query that can have a RETURNING clause,
+// such as the INSERT, UPDATE and DELETE queries of Postgres and SQLite
+type returner interface {
+	HasReturning() bool
+	AppendReturning(columns ...any)
+}
+
+// withReturning returns a copy of the query that returns all columns
+// if it can have a RETURNING clause but has none
+func (b BaseQuery[E]) withReturning() Query {
+	if r, ok := any(b.Expression).(returner); !ok || r.HasReturning() {
+		return b
+	}
+
+	c := b.Clone()
+	any(c.Expression).(returner).AppendReturning("*")
+
+	return c
+}
+
+// ReturningOne runs an INSERT, UPDATE or DELETE query and scans the returned row
+// into T with [scan.StructMapper], so that a row can be written and read back in one call.
+// If the query has no RETURNING clause, all the columns are returned
+//
+//	user, err := bob.ReturningOne[User](ctx, db, psql.Insert(
+//		im.Into("users", "name"),
+//		im.Values(psql.Arg("Alice")),
+//	))
+func ReturningOne[T any](ctx context.Context, exec Executor, q Query) (T, error) {
+	return One(ctx, exec, returningQuery(q), scan.StructMapper[T]())
+}
+
+// ReturningAll is like [ReturningOne] for a query that returns several rows
+func ReturningAll[T any](ctx context.Context, exec Executor, q Query) ([]T, error) {
+	return All(ctx, exec, returningQuery(q), scan.StructMapper[T]())
+}
+
+func returningQuery(q Query) Query {
+	if r, ok := q.(interface{ withReturning() Query }); ok {
+		return r.withReturning()
+	}
+
+	return q
+}
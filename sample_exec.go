@@ -0,0 +1,157 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"math/rand"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/stephenafamo/scan"
+)
+
+// QuerySample is a query recorded by [SampleQueries], with its args and result
+type QuerySample struct {
+	Query string
+	Args  []any
+	// The time the query took. For QueryContext, this is until the rows are closed
+	Duration time.Duration
+	// The rows affected by ExecContext, or read by QueryContext.
+	// -1 if the driver does not report the affected rows
+	Rows int64
+	Err  error
+	// True if the query was recorded because it was slower than SlowerThan
+	Slow bool
+}
+
+// SampleOptions are the options of [SampleQueries]
+type SampleOptions struct {
+	// The fraction of the queries that are recorded, such as 0.01 for 1%
+	Rate float64
+	// Queries that take longer are always recorded, with Slow set. Not checked if zero
+	SlowerThan time.Duration
+	// Record is called for each sampled query, after it is done.
+	// If nil, the queries are printed to os.Stdout
+	Record func(ctx context.Context, s QuerySample)
+}
+
+// SampleQueries wraps an [Executor] and records the SQL, args and results of a part
+// of the queries and of all the slow ones, for more insight than metrics without
+// the volume of logging every query with [Debug]
+func SampleQueries(exec Executor, opts SampleOptions) Executor {
+	if opts.Record == nil {
+		opts.Record = printSample
+	}
+
+	return sampleExecutor{exec: exec, opts: opts}
+}
+
+type sampleExecutor struct {
+	exec Executor
+	opts SampleOptions
+}
+
+// Unwrap returns the wrapped executor
+func (s sampleExecutor) Unwrap() Executor {
+	return s.exec
+}
+
+func (s sampleExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	sampled := s.sampled()
+	if !sampled && s.opts.SlowerThan <= 0 {
+		return s.exec.ExecContext(ctx, query, args...)
+	}
+
+	start := time.Now()
+	result, err := s.exec.ExecContext(ctx, query, args...)
+
+	sample := QuerySample{Query: query, Args: args, Duration: time.Since(start), Rows: -1, Err: err}
+	if err == nil {
+		if n, err := result.RowsAffected(); err == nil {
+			sample.Rows = n
+		}
+	}
+	s.record(ctx, sampled, sample)
+
+	return result, err
+}
+
+func (s sampleExecutor) QueryContext(ctx context.Context, query string, args ...any) (scan.Rows, error) {
+	sampled := s.sampled()
+	if !sampled && s.opts.SlowerThan <= 0 {
+		return s.exec.QueryContext(ctx, query, args...)
+	}
+
+	start := time.Now()
+	rows, err := s.exec.QueryContext(ctx, query, args...)
+	if err != nil {
+		s.record(ctx, sampled, QuerySample{Query: query, Args: args, Duration: time.Since(start), Err: err})
+		return nil, err
+	}
+
+	sr := &sampledRows{Rows: rows, done: func(read int64, err error) {
+		s.record(ctx, sampled, QuerySample{Query: query, Args: args, Duration: time.Since(start), Rows: read, Err: err})
+	}}
+	if _, ok := rows.(interface{ NextResultSet() bool }); ok {
+		return sampledMultiRows{sr}, nil
+	}
+
+	return sr, nil
+}
+
+func (s sampleExecutor) sampled() bool {
+	return s.opts.Rate > 0 && rand.Float64() < s.opts.Rate
+}
+
+func (s sampleExecutor) record(ctx context.Context, sampled bool, sample QuerySample) {
+	sample.Slow = s.opts.SlowerThan > 0 && sample.Duration > s.opts.SlowerThan
+	if sampled || sample.Slow {
+		s.opts.Record(ctx, sample)
+	}
+}
+
+func printSample(_ context.Context, s QuerySample) {
+	writerPrinter{os.Stdout}.PrintQuery(s.Query, s.Args...)
+	fmt.Fprintf(os.Stdout, "took %s, %d rows, error: %v, slow: %t\n\n", s.Duration, s.Rows, s.Err, s.Slow)
+}
+
+// sampledRows counts the rows that are read, and records the query once they are closed
+type sampledRows struct {
+	scan.Rows
+	read int64
+	once sync.Once
+	done func(read int64, err error)
+}
+
+func (r *sampledRows) Next() bool {
+	if r.Rows.Next() {
+		r.read++
+		return true
+	}
+
+	return false
+}
+
+func (r *sampledRows) Close() error {
+	err := r.Rows.Close()
+	r.once.Do(func() {
+		rowsErr := r.Rows.Err()
+		if rowsErr == nil {
+			rowsErr = err
+		}
+		r.done(r.read, rowsErr)
+	})
+
+	return err
+}
+
+// sampledMultiRows keeps the NextResultSet method of the rows, for [MultiResult]
+type sampledMultiRows struct {
+	*sampledRows
+}
+
+func (r sampledMultiRows) NextResultSet() bool {
+	return r.Rows.(interface{ NextResultSet() bool }).NextResultSet()
+}
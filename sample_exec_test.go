@@ -0,0 +1,83 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stephenafamo/scan"
+	_ "modernc.org/sqlite"
+)
+
+func TestSampleQueries(t *testing.T) {
+	ctx := context.Background()
+
+	sqlDB, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	sqlDB.SetMaxOpenConns(1)
+
+	db := NewDB(sqlDB)
+	if _, err := db.ExecContext(ctx, "CREATE TABLE users (name TEXT NOT NULL)"); err != nil {
+		t.Fatal(err)
+	}
+
+	var samples []QuerySample
+	record := func(_ context.Context, s QuerySample) { samples = append(samples, s) }
+
+	run := func(exec Executor) {
+		t.Helper()
+		samples = nil
+		if _, err := exec.ExecContext(ctx, "INSERT INTO users (name) VALUES (?), (?)", "Stephen", "Bob"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := scan.All(ctx, exec, scan.SingleColumnMapper[string], "SELECT name FROM users"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := exec.ExecContext(ctx, "SELECT missing FROM users"); err == nil {
+			t.Fatal("expected an error")
+		}
+	}
+
+	run(SampleQueries(db, SampleOptions{Rate: 1, Record: record}))
+	if len(samples) != 3 {
+		t.Fatalf("expected all 3 queries to be sampled, got %d", len(samples))
+	}
+	if s := samples[0]; s.Rows != 2 || len(s.Args) != 2 || s.Slow {
+		t.Fatalf("wrong sample of the insert: %+v", s)
+	}
+	if s := samples[1]; s.Query != "SELECT name FROM users" || s.Rows != 2 || s.Err != nil {
+		t.Fatalf("wrong sample of the select: %+v", s)
+	}
+	if s := samples[2]; s.Err == nil {
+		t.Fatalf("expected the error in the sample: %+v", s)
+	}
+
+	run(SampleQueries(db, SampleOptions{Record: record}))
+	if len(samples) != 0 {
+		t.Fatalf("expected no queries to be sampled, got %d", len(samples))
+	}
+
+	run(SampleQueries(db, SampleOptions{SlowerThan: time.Nanosecond, Record: record}))
+	if len(samples) != 3 || !samples[1].Slow {
+		t.Fatalf("expected all 3 queries to be slow, got %+v", samples)
+	}
+
+	run(SampleQueries(db, SampleOptions{SlowerThan: time.Hour, Record: record}))
+	if len(samples) != 0 {
+		t.Fatalf("expected no queries to be slow, got %d", len(samples))
+	}
+
+	// The rows keep their result sets for MultiResult
+	rows, err := SampleQueries(db, SampleOptions{Rate: 1, Record: record}).QueryContext(ctx, "SELECT 1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rows.Close()
+	if _, ok := rows.(interface{ NextResultSet() bool }); !ok {
+		t.Fatal("expected the rows to have NextResultSet")
+	}
+}
@@ -0,0 +1,211 @@
+package bob
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+// A line with only GO, the batch separator of SQL Server tools
+var goSeparator = regexp.MustCompile(`(?im)^[ \t]*GO(?:[ \t]+\d+)?[ \t]*;?[ \t]*\r?$`)
+
+// ExecScript splits the script with [SplitStatements] and executes the
+// statements one after the other with the executor, so scripts go through
+// the same executor wrappers as other queries.
+// It stops at the first error, which includes the number of the statement
+func ExecScript(ctx context.Context, exec Executor, script string) error {
+	for i, stmt := range SplitStatements(script) {
+		if _, err := exec.ExecContext(ctx, stmt); err != nil {
+			return fmt.Errorf("statement %d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+// SplitStatements splits an SQL script into its statements.
+//
+//   - Statements end with a semicolon, except in strings, quoted identifiers,
+//     comments, Postgres dollar quoted strings and BEGIN...END blocks,
+//     such as the body of a trigger.
+//   - If the script has GO lines, as in SQL Server scripts, it is only split at them.
+//   - The MySQL DELIMITER command changes the separator, e.g. for stored procedures.
+//
+// Statements are trimmed, and ones with only comments are left out
+func SplitStatements(script string) []string {
+	var parts []string
+	if goSeparator.MatchString(script) {
+		parts = goSeparator.Split(script, -1)
+	} else {
+		parts = (&splitter{script: script, delimiter: ";"}).split()
+	}
+
+	statements := make([]string, 0, len(parts))
+	for _, p := range parts {
+		p = strings.TrimSpace(p)
+		if len(sqlKeywords(p)) == 0 {
+			continue
+		}
+		statements = append(statements, p)
+	}
+
+	return statements
+}
+
+type splitter struct {
+	script    string
+	delimiter string
+	start     int
+	parts     []string
+
+	// The depth of BEGIN...END and CASE...END
+	depth int
+	// The last word was BEGIN or END, which depends on the next word
+	afterBegin bool
+	afterEnd   bool
+}
+
+func (s *splitter) split() []string {
+	src := s.script
+
+	for i := 0; i < len(src); {
+		// DELIMITER is a client command at the start of a line
+		if (i == 0 || src[i-1] == '\n') && hasPrefixFold(strings.TrimLeft(src[i:], " \t"), "DELIMITER ") {
+			end := strings.IndexByte(src[i:], '\n')
+			if end < 0 {
+				end = len(src) - i
+			}
+
+			s.cut(i)
+			fields := strings.Fields(src[i : i+end])
+			if len(fields) > 1 {
+				s.delimiter = fields[1]
+			}
+			i += end
+			s.start = i
+			continue
+		}
+
+		if strings.HasPrefix(src[i:], s.delimiter) && (s.delimiter != ";" || s.depth == 0) {
+			s.cut(i)
+			i += len(s.delimiter)
+			s.start = i
+			s.afterBegin, s.afterEnd = false, false
+			continue
+		}
+
+		c := src[i]
+		switch {
+		case strings.HasPrefix(src[i:], "--"):
+			i = skipTo(src, i, "\n")
+
+		case strings.HasPrefix(src[i:], "/*"):
+			i = skipTo(src, i+2, "*/")
+
+		case c == '\'' || c == '"' || c == '`':
+			i = skipTo(src, i+1, string(c))
+
+		case c == '$':
+			if tag := dollarTag(src[i:]); tag != "" {
+				i = skipTo(src, i+len(tag), tag)
+			} else {
+				i++
+			}
+
+		case c == ';':
+			// A semicolon in a block
+			s.afterBegin, s.afterEnd = false, false
+			i++
+
+		case c == '_' || unicode.IsLetter(rune(c)):
+			start := i
+			for i < len(src) && (src[i] == '_' || unicode.IsLetter(rune(src[i])) || unicode.IsDigit(rune(src[i]))) {
+				i++
+			}
+			s.word(strings.ToUpper(src[start:i]))
+
+		default:
+			i++
+		}
+	}
+
+	s.cut(len(src))
+	return s.parts
+}
+
+func (s *splitter) word(w string) {
+	if s.afterBegin {
+		s.afterBegin = false
+		switch w {
+		// Starting a transaction
+		case "TRANSACTION", "TRAN", "WORK", "DEFERRED", "IMMEDIATE", "EXCLUSIVE", "ISOLATION", "READ":
+		default:
+			s.depth++
+		}
+	}
+
+	if s.afterEnd {
+		s.afterEnd = false
+		switch w {
+		// These close constructs that are not counted
+		case "IF", "LOOP", "WHILE", "REPEAT":
+			s.depth++
+			return
+		// END CASE closes a CASE statement
+		case "CASE":
+			return
+		}
+	}
+
+	switch w {
+	case "BEGIN":
+		s.afterBegin = true
+	case "CASE":
+		s.depth++
+	case "END":
+		if s.depth > 0 {
+			s.depth--
+			s.afterEnd = true
+		}
+	}
+}
+
+func (s *splitter) cut(end int) {
+	if end > s.start {
+		s.parts = append(s.parts, s.script[s.start:end])
+	}
+}
+
+// skipTo returns the index after the next occurrence of end
+func skipTo(src string, from int, end string) int {
+	i := strings.Index(src[from:], end)
+	if i < 0 {
+		return len(src)
+	}
+
+	return from + i + len(end)
+}
+
+// dollarTag returns the opening tag of a dollar quoted string, e.g. $$ or $body$
+func dollarTag(src string) string {
+	for i := 1; i < len(src); i++ {
+		c := src[i]
+		switch {
+		case c == '$':
+			return src[:i+1]
+		case c == '_' || unicode.IsLetter(rune(c)):
+		case unicode.IsDigit(rune(c)) && i > 1:
+		default:
+			// e.g. a $1 placeholder
+			return ""
+		}
+	}
+
+	return ""
+}
+
+func hasPrefixFold(s, prefix string) bool {
+	return len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix)
+}
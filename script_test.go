@@ -0,0 +1,140 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSplitStatements(t *testing.T) {
+	examples := map[string]struct {
+		script   string
+		expected []string
+	}{
+		"simple": {
+			script:   "CREATE TABLE a (id int);\nINSERT INTO a VALUES (1);\n\n-- done\n",
+			expected: []string{"CREATE TABLE a (id int)", "INSERT INTO a VALUES (1)"},
+		},
+		"strings and comments": {
+			script: `INSERT INTO a VALUES ('a;b', "c;d", ` + "`e;f`" + `); -- comment; here
+/* block; comment */ SELECT 'it''s; fine'`,
+			expected: []string{
+				"INSERT INTO a VALUES ('a;b', \"c;d\", `e;f`)",
+				"-- comment; here\n/* block; comment */ SELECT 'it''s; fine'",
+			},
+		},
+		"dollar quoting": {
+			script: `CREATE FUNCTION f() RETURNS int AS $body$
+BEGIN
+  RETURN 1;
+END;
+$body$ LANGUAGE plpgsql;
+SELECT $$a;b$$, $1;`,
+			expected: []string{
+				"CREATE FUNCTION f() RETURNS int AS $body$\nBEGIN\n  RETURN 1;\nEND;\n$body$ LANGUAGE plpgsql",
+				"SELECT $$a;b$$, $1",
+			},
+		},
+		"trigger": {
+			script: `CREATE TRIGGER t AFTER INSERT ON a
+BEGIN
+  UPDATE b SET n = CASE WHEN n > 0 THEN n + 1 ELSE 1 END;
+  DELETE FROM c;
+END;
+BEGIN TRANSACTION;
+COMMIT;`,
+			expected: []string{
+				"CREATE TRIGGER t AFTER INSERT ON a\nBEGIN\n  UPDATE b SET n = CASE WHEN n > 0 THEN n + 1 ELSE 1 END;\n  DELETE FROM c;\nEND",
+				"BEGIN TRANSACTION",
+				"COMMIT",
+			},
+		},
+		"procedure with control flow": {
+			script: `CREATE PROCEDURE p()
+BEGIN
+  IF 1 THEN
+    SELECT 1;
+  END IF;
+  CASE WHEN 1 THEN SELECT 2; END CASE;
+END;
+SELECT 3;`,
+			expected: []string{
+				"CREATE PROCEDURE p()\nBEGIN\n  IF 1 THEN\n    SELECT 1;\n  END IF;\n  CASE WHEN 1 THEN SELECT 2; END CASE;\nEND",
+				"SELECT 3",
+			},
+		},
+		"mysql delimiter": {
+			script: `DELIMITER //
+CREATE PROCEDURE p() SELECT 1; SELECT 2 //
+DELIMITER ;
+SELECT 3;`,
+			expected: []string{
+				"CREATE PROCEDURE p() SELECT 1; SELECT 2",
+				"SELECT 3",
+			},
+		},
+		"go separators": {
+			script: `CREATE PROCEDURE p AS
+SELECT 1;
+SELECT 2;
+GO
+go
+SELECT 3
+GO 2
+`,
+			expected: []string{
+				"CREATE PROCEDURE p AS\nSELECT 1;\nSELECT 2;",
+				"SELECT 3",
+			},
+		},
+	}
+
+	for name, tc := range examples {
+		t.Run(name, func(t *testing.T) {
+			got := SplitStatements(tc.script)
+			if !reflect.DeepEqual(tc.expected, got) {
+				t.Fatalf("expected:\n%q\ngot:\n%q", tc.expected, got)
+			}
+		})
+	}
+}
+
+type recordingExecutor struct {
+	NoopExecutor
+	queries *[]string
+	failOn  string
+}
+
+func (r recordingExecutor) ExecContext(_ context.Context, query string, _ ...any) (sql.Result, error) {
+	if r.failOn != "" && strings.Contains(query, r.failOn) {
+		return nil, errors.New("failed")
+	}
+
+	*r.queries = append(*r.queries, query)
+	return nil, nil
+}
+
+func TestExecScript(t *testing.T) {
+	ctx := context.Background()
+	script := "CREATE TABLE a (id int);\nINSERT INTO a VALUES (1);\nINSERT INTO b VALUES (2);"
+
+	var queries []string
+	if err := ExecScript(ctx, recordingExecutor{queries: &queries}, script); err != nil {
+		t.Fatal(err)
+	}
+	if len(queries) != 3 {
+		t.Fatalf("expected 3 statements to be executed, got %q", queries)
+	}
+
+	queries = nil
+	err := ExecScript(ctx, recordingExecutor{queries: &queries, failOn: "INTO b"}, script)
+	if err == nil || !strings.HasPrefix(err.Error(), "statement 3:") {
+		t.Fatalf("expected the error of the third statement, got %v", err)
+	}
+	if len(queries) != 2 {
+		t.Fatalf("expected to stop at the failing statement, got %q", queries)
+	}
+}
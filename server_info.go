@@ -0,0 +1,307 @@
+package bob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrUnsupported is returned for queries that use a feature the database
+// server does not support
+var ErrUnsupported = errors.New("not supported by the database server")
+
+// The names of the database servers detected by [ServerInfo]
+const (
+	ServerPostgres = "postgres"
+	ServerMySQL    = "mysql"
+	ServerMariaDB  = "mariadb"
+	ServerSQLite   = "sqlite"
+	ServerMSSQL    = "mssql"
+)
+
+// Version is the version of a database server
+type Version struct {
+	Major, Minor, Patch int
+}
+
+// ParseVersion reads the first version number in s, such as 16.1 in
+// "PostgreSQL 16.1 on x86_64-pc-linux-gnu"
+func ParseVersion(s string) (Version, bool) {
+	start := strings.IndexFunc(s, func(r rune) bool { return r >= '0' && r <= '9' })
+	if start < 0 {
+		return Version{}, false
+	}
+
+	end := start
+	for end < len(s) && (isDigit(s[end]) || s[end] == '.') {
+		end++
+	}
+
+	var parts [3]int
+	for i, part := range strings.SplitN(strings.Trim(s[start:end], "."), ".", 4) {
+		if i == len(parts) {
+			break
+		}
+		parts[i], _ = strconv.Atoi(part)
+	}
+
+	return Version{Major: parts[0], Minor: parts[1], Patch: parts[2]}, true
+}
+
+func (v Version) String() string {
+	return fmt.Sprintf("%d.%d.%d", v.Major, v.Minor, v.Patch)
+}
+
+// AtLeast returns true if v is the same as or later than other
+func (v Version) AtLeast(other Version) bool {
+	if v.Major != other.Major {
+		return v.Major > other.Major
+	}
+	if v.Minor != other.Minor {
+		return v.Minor > other.Minor
+	}
+	return v.Patch >= other.Patch
+}
+
+// Feature is a part of the SQL syntax that a database server
+// only supports since some version
+type Feature struct {
+	Name string
+	// One of the Server constants
+	Server string
+	Since  Version
+	// The other kinds of servers do not support the feature at all,
+	// such as RETURNING, which MariaDB has but MySQL does not
+	Exclusive bool
+}
+
+// FeatureRequirer is implemented by queries that use features
+// which are not supported by every version of the database server
+type FeatureRequirer interface {
+	RequiredFeatures() []Feature
+}
+
+// Server describes a database server
+type Server struct {
+	// One of the Server constants, or empty if the server is not known
+	Name    string
+	Version Version
+	// The version as reported by the server
+	RawVersion string
+}
+
+// Supports returns false if the feature is for this kind of server and the
+// server is older than the version that added it.
+// Features of other kinds of servers are supported, since they are not
+// checked against the right version, unless the feature is exclusive
+func (s Server) Supports(f Feature) bool {
+	if s.Name != f.Server {
+		return !f.Exclusive || s.Name == ""
+	}
+
+	return s.Version.AtLeast(f.Since)
+}
+
+// Require returns an error wrapping [ErrUnsupported] if one of the features is not supported
+func (s Server) Require(features ...Feature) error {
+	for _, f := range features {
+		if s.Supports(f) {
+			continue
+		}
+
+		if s.Name != f.Server {
+			return fmt.Errorf("%s %w: it requires %s %s, the server is %s %s",
+				f.Name, ErrUnsupported, f.Server, f.Since, s.Name, s.Version)
+		}
+
+		return fmt.Errorf("%s %w: it requires %s %s, the server is %s",
+			f.Name, ErrUnsupported, f.Server, f.Since, s.Version)
+	}
+
+	return nil
+}
+
+// Check returns an error wrapping [ErrUnsupported] if the query uses
+// a feature that the server does not support
+func (s Server) Check(q Query) error {
+	r, ok := q.(FeatureRequirer)
+	if !ok {
+		return nil
+	}
+
+	return s.Require(r.RequiredFeatures()...)
+}
+
+//nolint:gochecknoglobals
+var servers sync.Map
+
+// ServerInfo detects the database server and its version.
+// The result is cached for the executor, and once it is known, queries run with
+// [Exec], [One], [All], [Cursor] and [Prepare] on the same executor are checked with
+// [Server.Check], so that a query using a feature the server does not have
+// fails with [ErrUnsupported] instead of a syntax error.
+//
+// Transactions and connections are not cached, since they only live for a while.
+// The ones begun from a [DB] whose server is known are checked with that server.
+//
+// Postgres, MySQL, MariaDB, SQLite and SQL Server are detected
+func ServerInfo(ctx context.Context, exec Executor) (Server, error) {
+	if s, ok := cachedServer(exec); ok {
+		return s, nil
+	}
+
+	server, err := detectServer(ctx, exec)
+	if err != nil {
+		return Server{}, err
+	}
+
+	if isCacheable(exec) {
+		servers.Store(exec, server)
+	}
+
+	return server, nil
+}
+
+func cachedServer(exec Executor) (Server, bool) {
+	switch e := exec.(type) {
+	case Tx:
+		if e.server != nil {
+			return *e.server, true
+		}
+	case Conn:
+		if e.server != nil {
+			return *e.server, true
+		}
+	}
+
+	if !isCacheable(exec) {
+		return Server{}, false
+	}
+
+	s, ok := servers.Load(exec)
+	if !ok {
+		return Server{}, false
+	}
+
+	return s.(Server), true
+}
+
+// knownServer returns the cached server of the executor, or nil
+func knownServer(exec Executor) *Server {
+	if s, ok := cachedServer(exec); ok {
+		return &s
+	}
+
+	return nil
+}
+
+// isCacheable returns false for transactions and connections,
+// which would otherwise be kept in the cache after they end
+func isCacheable(exec Executor) bool {
+	if exec == nil || !reflect.TypeOf(exec).Comparable() {
+		return false
+	}
+
+	switch exec.(type) {
+	case Tx, Conn, interface{ Commit() error }:
+		return false
+	}
+
+	return true
+}
+
+// checkFeatures checks the query against the server of the executor, if known
+func checkFeatures(exec Executor, q Query) error {
+	if _, ok := q.(FeatureRequirer); !ok {
+		return nil
+	}
+
+	server, ok := cachedServer(exec)
+	if !ok {
+		return nil
+	}
+
+	return server.Check(q)
+}
+
+// detectServer tries the version queries of each database.
+// VERSION() is tried first, since a failing query aborts a Postgres transaction
+func detectServer(ctx context.Context, exec Executor) (Server, error) {
+	if raw, err := queryVersion(ctx, exec, "SELECT VERSION()"); err == nil {
+		server := Server{RawVersion: raw}
+		switch {
+		case strings.HasPrefix(raw, "PostgreSQL"):
+			server.Name = ServerPostgres
+		case strings.Contains(raw, "MariaDB"):
+			server.Name = ServerMariaDB
+		case len(raw) > 0 && isDigit(raw[0]):
+			server.Name = ServerMySQL
+		}
+		server.Version, _ = ParseVersion(raw)
+
+		return server, nil
+	}
+
+	if raw, err := queryVersion(ctx, exec, "SELECT sqlite_version()"); err == nil {
+		v, _ := ParseVersion(raw)
+		return Server{Name: ServerSQLite, Version: v, RawVersion: raw}, nil
+	}
+
+	raw, err := queryVersion(ctx, exec, "SELECT CAST(SERVERPROPERTY('ProductVersion') AS NVARCHAR(128))")
+	if err != nil {
+		return Server{}, fmt.Errorf("server info: could not detect the database server: %w", err)
+	}
+
+	v, _ := ParseVersion(raw)
+	return Server{Name: ServerMSSQL, Version: v, RawVersion: raw}, nil
+}
+
+func queryVersion(ctx context.Context, exec Executor, query string) (string, error) {
+	rows, err := exec.QueryContext(ctx, query)
+	if err != nil {
+		return "", err
+	}
+	defer rows.Close()
+
+	if !rows.Next() {
+		if err := rows.Err(); err != nil {
+			return "", err
+		}
+		return "", errors.New("no version returned")
+	}
+
+	var version string
+	if err := rows.Scan(&version); err != nil {
+		return "", err
+	}
+
+	return version, rows.Err()
+}
+
+// VersionTargeter is implemented by dialects that can write queries
+// for an older version of the database server
+type VersionTargeter interface {
+	TargetVersion() Version
+}
+
+// FeatureEmulator is implemented by dialects that write queries using a
+// feature differently when they target a server that does not have it
+type FeatureEmulator interface {
+	Emulates(Feature) bool
+}
+
+// TargetsBefore returns true if the dialect targets a version of the
+// server older than v, so syntax added in v should not be used
+func TargetsBefore(d Dialect, v Version) bool {
+	t, ok := d.(VersionTargeter)
+	if !ok {
+		return false
+	}
+
+	target := t.TargetVersion()
+	return target != Version{} && !target.AtLeast(v)
+}
@@ -0,0 +1,233 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stephenafamo/scan"
+)
+
+// versionExecutor answers the version query of one database
+// and fails the others
+type versionExecutor struct {
+	query, version string
+	queries        int
+}
+
+func (v *versionExecutor) ExecContext(context.Context, string, ...any) (sql.Result, error) {
+	v.queries++
+	return dryRunResult{}, nil
+}
+
+func (v *versionExecutor) QueryContext(_ context.Context, query string, _ ...any) (scan.Rows, error) {
+	v.queries++
+	if query != v.query {
+		return nil, errors.New("no such function")
+	}
+
+	return &planRows{plan: v.version}, nil
+}
+
+var featureCTE = Feature{Name: "WITH", Server: ServerMySQL, Since: Version{Major: 8}}
+
+type featureExpression struct{}
+
+func (featureExpression) WriteSQL(w io.Writer, _ Dialect, _ int) ([]any, error) {
+	w.Write([]byte("WITH x AS (SELECT 1) SELECT * FROM x"))
+	return nil, nil
+}
+
+func (featureExpression) RequiredFeatures() []Feature {
+	return []Feature{featureCTE}
+}
+
+func TestParseVersion(t *testing.T) {
+	cases := map[string]Version{
+		"PostgreSQL 16.1 (Debian 16.1-1.pgdg120+1) on x86_64-pc-linux-gnu": {16, 1, 0},
+		"8.0.35": {8, 0, 35},
+		"10.11.2-MariaDB-1:10.11.2+maria~ubu2204": {10, 11, 2},
+		"3.45.1":      {3, 45, 1},
+		"16.0.1000.6": {16, 0, 1000},
+	}
+
+	for raw, want := range cases {
+		got, ok := ParseVersion(raw)
+		if !ok || got != want {
+			t.Errorf("ParseVersion(%q) = %v, %t, want %v", raw, got, ok, want)
+		}
+	}
+
+	if _, ok := ParseVersion("unknown"); ok {
+		t.Error("expected no version")
+	}
+}
+
+func TestServerInfo(t *testing.T) {
+	ctx := context.Background()
+
+	cases := map[string]struct {
+		query, version string
+		want           Server
+	}{
+		"postgres": {
+			query:   "SELECT VERSION()",
+			version: "PostgreSQL 14.9 on x86_64-pc-linux-gnu",
+			want:    Server{Name: ServerPostgres, Version: Version{14, 9, 0}},
+		},
+		"mysql": {
+			query:   "SELECT VERSION()",
+			version: "5.7.44-log",
+			want:    Server{Name: ServerMySQL, Version: Version{5, 7, 44}},
+		},
+		"mariadb": {
+			query:   "SELECT VERSION()",
+			version: "10.11.2-MariaDB",
+			want:    Server{Name: ServerMariaDB, Version: Version{10, 11, 2}},
+		},
+		"sqlite": {
+			query:   "SELECT sqlite_version()",
+			version: "3.31.1",
+			want:    Server{Name: ServerSQLite, Version: Version{3, 31, 1}},
+		},
+		"mssql": {
+			query:   "SELECT CAST(SERVERPROPERTY('ProductVersion') AS NVARCHAR(128))",
+			version: "16.0.1000.6",
+			want:    Server{Name: ServerMSSQL, Version: Version{16, 0, 1000}},
+		},
+	}
+
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			exec := &versionExecutor{query: tc.query, version: tc.version}
+
+			got, err := ServerInfo(ctx, exec)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tc.want.RawVersion = tc.version
+			if got != tc.want {
+				t.Fatalf("got %+v, want %+v", got, tc.want)
+			}
+
+			// It is cached
+			queries := exec.queries
+			if _, err := ServerInfo(ctx, exec); err != nil {
+				t.Fatal(err)
+			}
+			if exec.queries != queries {
+				t.Fatal("the server info was not cached")
+			}
+		})
+	}
+
+	if _, err := ServerInfo(ctx, &versionExecutor{}); err == nil {
+		t.Fatal("expected an error for an unknown server")
+	}
+}
+
+func TestFeatureGating(t *testing.T) {
+	ctx := context.Background()
+	q := BaseQuery[Expression]{Expression: featureExpression{}, Dialect: d}
+
+	// Not checked until the server is known
+	old := &versionExecutor{query: "SELECT VERSION()", version: "5.7.44"}
+	if _, err := Exec(ctx, old, q); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := ServerInfo(ctx, old); err != nil {
+		t.Fatal(err)
+	}
+
+	queries := old.queries
+	_, err := Exec(ctx, old, q)
+	if !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("expected ErrUnsupported, got %v", err)
+	}
+	if want := "WITH not supported by the database server: it requires mysql 8.0.0, the server is 5.7.44"; err.Error() != want {
+		t.Fatalf("got error %q, want %q", err, want)
+	}
+	if _, err := All(ctx, old, q, scan.SingleColumnMapper[int]); !errors.Is(err, ErrUnsupported) {
+		t.Fatalf("expected ErrUnsupported, got %v", err)
+	}
+	if old.queries != queries {
+		t.Fatal("an unsupported query was run")
+	}
+
+	current := &versionExecutor{query: "SELECT VERSION()", version: "8.0.35"}
+	if _, err := ServerInfo(ctx, current); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Exec(ctx, current, q); err != nil {
+		t.Fatal(err)
+	}
+
+	// Features of other servers are not checked
+	pg := Server{Name: ServerPostgres, Version: Version{Major: 9}}
+	if err := pg.Check(q); err != nil {
+		t.Fatal(err)
+	}
+	// Exclusive features are not supported by other servers
+	returning := Feature{Name: "RETURNING", Server: ServerMariaDB, Since: Version{Major: 10, Minor: 5}, Exclusive: true}
+	mysql := Server{Name: ServerMySQL, Version: Version{Major: 8}}
+	err = mysql.Require(returning)
+	if want := "RETURNING not supported by the database server: it requires mariadb 10.5.0, the server is mysql 8.0.0"; err == nil || err.Error() != want {
+		t.Fatalf("got error %v, want %q", err, want)
+	}
+	if err := (Server{Name: ServerMariaDB, Version: Version{Major: 11}}).Require(returning); err != nil {
+		t.Fatal(err)
+	}
+	if err := (Server{}).Require(returning); err != nil {
+		t.Fatalf("expected an unknown server not to be checked, got %v", err)
+	}
+}
+
+// versionTx is a transaction of a versionExecutor
+type versionTx struct {
+	*versionExecutor
+}
+
+func (versionTx) Commit() error { return nil }
+
+func TestServerInfoTransactions(t *testing.T) {
+	ctx := context.Background()
+
+	sqlDB, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	db := NewDB(sqlDB)
+
+	server, err := ServerInfo(ctx, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	// The transaction is checked with the server of its database
+	if got, ok := cachedServer(tx); !ok || got != server {
+		t.Fatalf("expected the transaction to have the server %+v, got %+v", server, got)
+	}
+	if _, ok := servers.Load(tx); ok {
+		t.Fatal("the transaction was cached")
+	}
+
+	// Transactions of other types are not cached
+	other := versionTx{&versionExecutor{query: "SELECT VERSION()", version: "8.0.35"}}
+	if _, err := ServerInfo(ctx, other); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cachedServer(other); ok {
+		t.Fatal("the transaction was cached")
+	}
+}
@@ -0,0 +1,230 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"sync"
+
+	"github.com/stephenafamo/scan"
+)
+
+// ErrNoShardKey is returned by a [ShardRouter] for a query that has no
+// shard key and does not run on all the shards
+var ErrNoShardKey = errors.New("no shard key")
+
+// ErrNoShard is returned by a [ShardRouter] when its Pick function returns
+// an index that is not one of its shards
+var ErrNoShard = errors.New("no shard for the key")
+
+type (
+	shardKeyCtx struct{}
+	fanOutCtx   struct{}
+)
+
+// WithShardKey returns a context that sends the queries of a [ShardRouter]
+// to the shard of the key
+func WithShardKey(ctx context.Context, key any) context.Context {
+	return context.WithValue(ctx, shardKeyCtx{}, key)
+}
+
+// ShardKeyFrom returns the shard key of the context
+func ShardKeyFrom(ctx context.Context) (any, bool) {
+	key := ctx.Value(shardKeyCtx{})
+	return key, key != nil
+}
+
+// FanOut returns a context that runs the read queries of a [ShardRouter] on all
+// the shards. The rows of every shard are returned one shard after the other,
+// so they are merged by the mapper of the query.
+// ORDER BY, LIMIT and aggregates apply to each shard separately
+func FanOut(ctx context.Context) context.Context {
+	return context.WithValue(ctx, fanOutCtx{}, true)
+}
+
+// ShardRouter is an [Executor] that sends each query to one of the shards.
+// The shard is picked with the shard key of the context, see [WithShardKey],
+// or else with the argument at KeyArg.
+// With a [FanOut] context, read queries run on all the shards
+type ShardRouter struct {
+	Shards []Executor
+	// The position of the argument that is the shard key, starting from 1 like
+	// the placeholders. If 0, the key only comes from the context
+	KeyArg int
+	// Pick returns the index of the shard for a key.
+	// By default, the FNV hash of the key is used.
+	// An index outside of Shards fails the query with [ErrNoShard]
+	Pick func(key any, shards int) int
+}
+
+func (r ShardRouter) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	if fanOut, _ := ctx.Value(fanOutCtx{}).(bool); fanOut {
+		return nil, errors.New("shard router: only read queries can run on all the shards")
+	}
+
+	exec, err := r.shard(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return exec.ExecContext(ctx, query, args...)
+}
+
+func (r ShardRouter) QueryContext(ctx context.Context, query string, args ...any) (scan.Rows, error) {
+	if fanOut, _ := ctx.Value(fanOutCtx{}).(bool); fanOut {
+		if err := checkReadOnly(query); err != nil {
+			return nil, fmt.Errorf("shard router: %w", err)
+		}
+		return r.fanOut(ctx, query, args)
+	}
+
+	exec, err := r.shard(ctx, args)
+	if err != nil {
+		return nil, err
+	}
+
+	return exec.QueryContext(ctx, query, args...)
+}
+
+// Shard returns the shard of the key.
+// It returns an error wrapping [ErrNoShard] if Pick returns an index outside of Shards
+func (r ShardRouter) Shard(key any) (Executor, error) {
+	if len(r.Shards) == 0 {
+		return nil, errors.New("shard router: no shards")
+	}
+
+	pick := r.Pick
+	if pick == nil {
+		pick = hashShard
+	}
+
+	i := pick(key, len(r.Shards))
+	if i < 0 || i >= len(r.Shards) {
+		return nil, fmt.Errorf("shard router: %w %v: picked shard %d of %d", ErrNoShard, key, i, len(r.Shards))
+	}
+
+	return r.Shards[i], nil
+}
+
+func (r ShardRouter) shard(ctx context.Context, args []any) (Executor, error) {
+	if key, ok := ShardKeyFrom(ctx); ok {
+		return r.Shard(key)
+	}
+
+	if r.KeyArg > 0 && r.KeyArg <= len(args) {
+		key := args[r.KeyArg-1]
+		if named, ok := key.(sql.NamedArg); ok {
+			key = named.Value
+		}
+		return r.Shard(key)
+	}
+
+	if len(r.Shards) == 0 {
+		return nil, errors.New("shard router: no shards")
+	}
+
+	return nil, fmt.Errorf("shard router: %w", ErrNoShardKey)
+}
+
+func hashShard(key any, shards int) int {
+	h := fnv.New32a()
+	fmt.Fprint(h, key)
+	return int(h.Sum32() % uint32(shards))
+}
+
+// fanOut runs the query on all the shards at the same time
+func (r ShardRouter) fanOut(ctx context.Context, query string, args []any) (scan.Rows, error) {
+	rows := make([]scan.Rows, len(r.Shards))
+	err := r.gather(func(i int, shard Executor) error {
+		var err error
+		rows[i], err = shard.QueryContext(ctx, query, args...)
+		return err
+	})
+	if err != nil {
+		for _, r := range rows {
+			if r != nil {
+				r.Close()
+			}
+		}
+		return nil, err
+	}
+
+	return &fanOutRows{rows: rows}, nil
+}
+
+// gather runs fn for every shard at the same time and returns the
+// error of the first shard that failed
+func (r ShardRouter) gather(fn func(i int, shard Executor) error) error {
+	if len(r.Shards) == 0 {
+		return errors.New("shard router: no shards")
+	}
+
+	errs := make([]error, len(r.Shards))
+
+	var wg sync.WaitGroup
+	for i, shard := range r.Shards {
+		wg.Add(1)
+		go func(i int, shard Executor) {
+			defer wg.Done()
+			errs[i] = fn(i, shard)
+		}(i, shard)
+	}
+	wg.Wait()
+
+	for i, err := range errs {
+		if err != nil {
+			return fmt.Errorf("shard router: shard %d: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// fanOutRows reads the rows of each shard one after the other
+type fanOutRows struct {
+	rows []scan.Rows
+	i    int
+	err  error
+}
+
+func (f *fanOutRows) Columns() ([]string, error) {
+	return f.rows[0].Columns()
+}
+
+func (f *fanOutRows) Next() bool {
+	for f.i < len(f.rows) {
+		if f.rows[f.i].Next() {
+			return true
+		}
+
+		if err := f.rows[f.i].Err(); err != nil {
+			f.err = fmt.Errorf("shard router: shard %d: %w", f.i, err)
+			return false
+		}
+
+		f.i++
+	}
+
+	return false
+}
+
+func (f *fanOutRows) Scan(dest ...any) error {
+	return f.rows[f.i].Scan(dest...)
+}
+
+func (f *fanOutRows) Err() error {
+	return f.err
+}
+
+func (f *fanOutRows) Close() error {
+	var first error
+	for _, r := range f.rows {
+		if err := r.Close(); err != nil && first == nil {
+			first = err
+		}
+	}
+
+	return first
+}
@@ -0,0 +1,158 @@
+package bob
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/stephenafamo/scan"
+)
+
+// ShardNumber is a type that the aggregates of shards can be summed as
+type ShardNumber interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 |
+		~float32 | ~float64
+}
+
+// ShardOrdered is a type that the aggregates of shards can be compared as
+type ShardOrdered interface {
+	ShardNumber | ~string
+}
+
+// GatherAll runs the query on all the shards of the router at the same time
+// and returns the rows of each shard
+func GatherAll[T any](ctx context.Context, r ShardRouter, q Query, m scan.Mapper[T], opts ...ExecOption[T]) ([][]T, error) {
+	results := make([][]T, len(r.Shards))
+	err := r.gather(func(i int, shard Executor) error {
+		var err error
+		results[i], err = All(ctx, shard, q, m, opts...)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+// gatherOne runs a query that returns a single aggregate on all the shards.
+// The shards where the aggregate is NULL, e.g. for no rows, are left out
+func gatherOne[T any](ctx context.Context, r ShardRouter, q Query) ([]T, error) {
+	results, err := GatherAll(ctx, r, q, scan.SingleColumnMapper[*T])
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]T, 0, len(results))
+	for _, rows := range results {
+		if len(rows) > 0 && rows[0] != nil {
+			values = append(values, *rows[0])
+		}
+	}
+
+	return values, nil
+}
+
+// GatherCount runs a query that selects a count on all the shards
+// and returns the total, such as SELECT count(*) FROM users
+func GatherCount(ctx context.Context, r ShardRouter, q Query) (int64, error) {
+	return GatherSum[int64](ctx, r, q)
+}
+
+// GatherSum runs a query that selects a sum on all the shards and returns
+// the sum of the shards, such as SELECT sum(total) FROM orders
+func GatherSum[T ShardNumber](ctx context.Context, r ShardRouter, q Query) (T, error) {
+	values, err := gatherOne[T](ctx, r, q)
+	if err != nil {
+		return 0, err
+	}
+
+	var sum T
+	for _, v := range values {
+		sum += v
+	}
+
+	return sum, nil
+}
+
+// GatherMin runs a query that selects a minimum on all the shards and returns
+// the smallest, such as SELECT min(total) FROM orders.
+// Times are not a [ShardOrdered] type, select them as a number instead,
+// such as SELECT min(extract(epoch FROM created_at)) FROM orders.
+// The bool is false if no shard had a value
+func GatherMin[T ShardOrdered](ctx context.Context, r ShardRouter, q Query) (T, bool, error) {
+	return gatherBest[T](ctx, r, q, func(a, b T) bool { return a < b })
+}
+
+// GatherMax runs a query that selects a maximum on all the shards and returns
+// the largest, such as SELECT max(total) FROM orders.
+// The bool is false if no shard had a value
+func GatherMax[T ShardOrdered](ctx context.Context, r ShardRouter, q Query) (T, bool, error) {
+	return gatherBest[T](ctx, r, q, func(a, b T) bool { return a > b })
+}
+
+func gatherBest[T any](ctx context.Context, r ShardRouter, q Query, better func(a, b T) bool) (T, bool, error) {
+	var best T
+
+	values, err := gatherOne[T](ctx, r, q)
+	if err != nil || len(values) == 0 {
+		return best, false, err
+	}
+
+	best = values[0]
+	for _, v := range values[1:] {
+		if better(v, best) {
+			best = v
+		}
+	}
+
+	return best, true, nil
+}
+
+// GatherSorted reads a page of rows from all the shards in the order given by less.
+// The query must have the same ORDER BY as less and a LIMIT of offset+limit with no OFFSET,
+// so that every shard returns the rows that may be on the page.
+// The rows of the shards are merged in order, and the page from offset to offset+limit is returned.
+// offset and limit cannot be negative
+func GatherSorted[T any](ctx context.Context, r ShardRouter, q Query, m scan.Mapper[T], less func(a, b T) bool, offset, limit int) ([]T, error) {
+	if offset < 0 || limit < 0 {
+		return nil, fmt.Errorf("shard router: invalid page, offset %d and limit %d", offset, limit)
+	}
+
+	results, err := GatherAll(ctx, r, q, m)
+	if err != nil {
+		return nil, err
+	}
+
+	page := make([]T, 0, limit)
+	next := make([]int, len(results))
+
+	for skipped := 0; len(page) < limit; {
+		// The shard with the smallest next row
+		shard := -1
+		for i, rows := range results {
+			if next[i] >= len(rows) {
+				continue
+			}
+			if shard < 0 || less(rows[next[i]], results[shard][next[shard]]) {
+				shard = i
+			}
+		}
+
+		if shard < 0 {
+			break
+		}
+
+		row := results[shard][next[shard]]
+		next[shard]++
+
+		if skipped < offset {
+			skipped++
+			continue
+		}
+
+		page = append(page, row)
+	}
+
+	return page, nil
+}
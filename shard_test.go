@@ -0,0 +1,184 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/stephenafamo/scan"
+	_ "modernc.org/sqlite"
+)
+
+// testShards returns sqlite databases with an orders table holding the given totals
+func testShards(t *testing.T, totals ...[]int) ShardRouter {
+	t.Helper()
+	ctx := context.Background()
+
+	shards := make([]Executor, len(totals))
+	for i, shardTotals := range totals {
+		sqlDB, err := sql.Open("sqlite", ":memory:")
+		if err != nil {
+			t.Fatal(err)
+		}
+		t.Cleanup(func() { sqlDB.Close() })
+		sqlDB.SetMaxOpenConns(1)
+
+		shards[i] = NewDB(sqlDB)
+		if _, err := shards[i].ExecContext(ctx, "CREATE TABLE orders (total INTEGER)"); err != nil {
+			t.Fatal(err)
+		}
+		for _, total := range shardTotals {
+			if _, err := shards[i].ExecContext(ctx, "INSERT INTO orders (total) VALUES ($1)", total); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	return ShardRouter{Shards: shards}
+}
+
+func TestShardRouter(t *testing.T) {
+	ctx := context.Background()
+
+	shards := make([]Executor, 2)
+	for i := range shards {
+		sqlDB, err := sql.Open("sqlite", ":memory:")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer sqlDB.Close()
+		sqlDB.SetMaxOpenConns(1)
+
+		shards[i] = NewDB(sqlDB)
+		if _, err := shards[i].ExecContext(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	router := ShardRouter{
+		Shards: shards,
+		KeyArg: 1,
+		Pick:   func(key any, shards int) int { return int(key.(int64)) % shards },
+	}
+
+	users := map[int64]string{1: "Alice", 2: "Bob", 3: "Carol"}
+	for id, name := range users {
+		if _, err := router.ExecContext(WithShardKey(ctx, id), "INSERT INTO users (id, name) VALUES ($1, $2)", id, name); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Routed by the argument
+	for id, name := range users {
+		got, err := scan.One(ctx, router, scan.SingleColumnMapper[string], "SELECT name FROM users WHERE id = $1", id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != name {
+			t.Fatalf("got %q for user %d, want %q", got, id, name)
+		}
+	}
+
+	// Each shard only has its own rows
+	odd, err := scan.All(WithShardKey(ctx, int64(1)), router, scan.SingleColumnMapper[string], "SELECT name FROM users ORDER BY id")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"Alice", "Carol"}; !reflect.DeepEqual(odd, want) {
+		t.Fatalf("got %q, want %q", odd, want)
+	}
+
+	// Merged from all the shards
+	all, err := scan.All(FanOut(ctx), router, scan.SingleColumnMapper[string], "SELECT name FROM users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	sort.Strings(all)
+	if want := []string{"Alice", "Bob", "Carol"}; !reflect.DeepEqual(all, want) {
+		t.Fatalf("got %q, want %q", all, want)
+	}
+
+	if _, err := router.QueryContext(ctx, "SELECT name FROM users"); !errors.Is(err, ErrNoShardKey) {
+		t.Fatalf("expected ErrNoShardKey, got %v", err)
+	}
+
+	if _, err := router.QueryContext(FanOut(ctx), "DELETE FROM users RETURNING id"); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected ErrReadOnly, got %v", err)
+	}
+
+	if _, err := router.ExecContext(FanOut(ctx), "DELETE FROM users"); err == nil {
+		t.Fatal("expected an error for a write on all the shards")
+	}
+
+	if _, err := router.QueryContext(FanOut(ctx), "SELECT 1; DELETE FROM users"); !errors.Is(err, ErrReadOnly) {
+		t.Fatalf("expected ErrReadOnly for several statements, got %v", err)
+	}
+
+	// A key picked outside of the shards
+	if _, err := router.ExecContext(WithShardKey(ctx, int64(-1)), "DELETE FROM users"); !errors.Is(err, ErrNoShard) {
+		t.Fatalf("expected ErrNoShard, got %v", err)
+	}
+}
+
+func TestGatherAggregates(t *testing.T) {
+	ctx := context.Background()
+	r := testShards(t, []int{5, 1}, []int{}, []int{7, 3, 9})
+
+	count, err := GatherCount(ctx, r, rawQuery(d, "SELECT count(*) FROM orders"))
+	if err != nil || count != 5 {
+		t.Fatalf("got count %d, %v", count, err)
+	}
+
+	sum, err := GatherSum[int](ctx, r, rawQuery(d, "SELECT sum(total) FROM orders"))
+	if err != nil || sum != 25 {
+		t.Fatalf("got sum %d, %v", sum, err)
+	}
+
+	lowest, ok, err := GatherMin[int](ctx, r, rawQuery(d, "SELECT min(total) FROM orders"))
+	if err != nil || !ok || lowest != 1 {
+		t.Fatalf("got min %d, %t, %v", lowest, ok, err)
+	}
+
+	highest, ok, err := GatherMax[int](ctx, r, rawQuery(d, "SELECT max(total) FROM orders"))
+	if err != nil || !ok || highest != 9 {
+		t.Fatalf("got max %d, %t, %v", highest, ok, err)
+	}
+
+	if _, ok, err := GatherMax[int](ctx, r, rawQuery(d, "SELECT max(total) FROM orders WHERE total > 100")); err != nil || ok {
+		t.Fatalf("expected no max, got %t, %v", ok, err)
+	}
+}
+
+func TestGatherSorted(t *testing.T) {
+	ctx := context.Background()
+	r := testShards(t, []int{5, 1, 8}, []int{}, []int{7, 3, 9, 2})
+
+	less := func(a, b int) bool { return a < b }
+	query := rawQuery(d, "SELECT total FROM orders ORDER BY total LIMIT 5")
+
+	page, err := GatherSorted(ctx, r, query, scan.SingleColumnMapper[int], less, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{3, 5, 7}; !reflect.DeepEqual(page, want) {
+		t.Fatalf("got page %v, want %v", page, want)
+	}
+
+	last, err := GatherSorted(ctx, r, rawQuery(d, "SELECT total FROM orders ORDER BY total LIMIT 8"), scan.SingleColumnMapper[int], less, 6, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []int{9}; !reflect.DeepEqual(last, want) {
+		t.Fatalf("got last page %v, want %v", last, want)
+	}
+
+	if _, err := GatherSorted(ctx, r, query, scan.SingleColumnMapper[int], less, 0, -1); err == nil {
+		t.Fatal("expected an error for a negative limit")
+	}
+	if _, err := GatherSorted(ctx, r, query, scan.SingleColumnMapper[int], less, -1, 3); err == nil {
+		t.Fatal("expected an error for a negative offset")
+	}
+}
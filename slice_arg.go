@@ -0,0 +1,13 @@
+package bob
+
+import "io"
+
+// SliceArgWriter is implemented by dialects that bind a slice as a single argument,
+// e.g. as an array in Postgres, instead of one placeholder for each element.
+// See expr.Chain.InSlice
+type SliceArgWriter interface {
+	// WriteInSlice writes the operator and the right side of a condition that is
+	// true if the left side is one of the elements of the slice, or none of them if not is true.
+	// The slice is passed as given, so it can also be a driver.Valuer that encodes the slice
+	WriteInSlice(w io.Writer, start int, slice any, not bool) ([]any, error)
+}
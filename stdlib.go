@@ -4,6 +4,8 @@ import (
 	"context"
 	"database/sql"
 	"database/sql/driver"
+	"errors"
+	"sync"
 
 	"github.com/stephenafamo/scan"
 	"github.com/stephenafamo/scan/stdscan"
@@ -93,24 +95,117 @@ func (d DB) BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error) {
 		return Tx{}, err
 	}
 
-	return NewTx(tx), nil
+	t := NewTx(tx)
+	t.server = knownServer(d)
+
+	return t, nil
+}
+
+// Conn is similar to [*sql.DB.Conn], but returns a connection that
+// implements [Queryer]
+func (d DB) Conn(ctx context.Context) (Conn, error) {
+	conn, err := d.wrapped.Conn(ctx)
+	if err != nil {
+		return Conn{}, err
+	}
+
+	c := NewConn(conn)
+	c.server = knownServer(d)
+
+	return c, nil
 }
 
 // NewTx wraps an [*sql.Tx] and returns a type that implements [Queryer] but still
 // retains the expected methods used by *sql.Tx
 // This is useful when an existing *sql.Tx is used in other places in the codebase
 func NewTx(tx *sql.Tx) Tx {
-	return Tx{New(tx)}
+	return Tx{common: New(tx), afterCommit: &txCallbacks{}}
+}
+
+// ErrNoAfterCommit is returned when a function cannot be registered to be called
+// after a commit, because the executor is not in such a transaction
+var ErrNoAfterCommit = errors.New("executor cannot call functions after a commit")
+
+// AfterCommitter is implemented by transactions that can call functions
+// after they are committed, such as [Tx]
+type AfterCommitter interface {
+	AfterCommit(fn func()) error
+}
+
+// ExecutorWrapper is implemented by executors that wrap another executor,
+// such as [Debug] and [ReadOnly], so that [AfterCommit] can find the transaction
+type ExecutorWrapper interface {
+	Unwrap() Executor
+}
+
+// AfterCommit registers fn to be called after the transaction of exec is committed.
+// exec is unwrapped with [ExecutorWrapper] until an [AfterCommitter] is found.
+// If there is none, fn is not registered and [ErrNoAfterCommit] is returned
+func AfterCommit(exec Executor, fn func()) error {
+	for exec != nil {
+		if tx, ok := exec.(AfterCommitter); ok {
+			return tx.AfterCommit(fn)
+		}
+
+		w, ok := exec.(ExecutorWrapper)
+		if !ok {
+			break
+		}
+		exec = w.Unwrap()
+	}
+
+	return ErrNoAfterCommit
 }
 
 // Tx is similar to *sql.Tx but implements [Queryer]
 type Tx struct {
 	common[*sql.Tx]
+	afterCommit *txCallbacks
+	// the server of the database that began the transaction, if known
+	server *Server
+}
+
+type txCallbacks struct {
+	mu  sync.Mutex
+	fns []func()
 }
 
 // Commit works the same as [*sql.Tx.Commit]
+// If the transaction is committed, the functions registered with
+// [Tx.AfterCommit] are then called in order
 func (t Tx) Commit() error {
-	return t.wrapped.Commit()
+	if err := t.wrapped.Commit(); err != nil {
+		return err
+	}
+
+	if t.afterCommit != nil {
+		t.afterCommit.mu.Lock()
+		fns := t.afterCommit.fns
+		t.afterCommit.fns = nil
+		t.afterCommit.mu.Unlock()
+
+		for _, fn := range fns {
+			fn()
+		}
+	}
+
+	return nil
+}
+
+// AfterCommit registers a function to call after the transaction is committed.
+// It is not called if the transaction is rolled back.
+// It returns [ErrNoAfterCommit] if the transaction was not created with [NewTx]
+// or one of the BeginTx methods
+func (t Tx) AfterCommit(fn func()) error {
+	if t.afterCommit == nil {
+		return ErrNoAfterCommit
+	}
+
+	t.afterCommit.mu.Lock()
+	defer t.afterCommit.mu.Unlock()
+	t.afterCommit.fns = append(t.afterCommit.fns, fn)
+
+	return nil
 }
 
 // Rollback works the same as [*sql.Tx.Rollback]
@@ -121,12 +216,14 @@ func (t Tx) Rollback() error {
 // NewConn wraps an [*sql.Conn] and returns a type that implements [Queryer]
 // This is useful when an existing *sql.Conn is used in other places in the codebase
 func NewConn(conn *sql.Conn) Conn {
-	return Conn{New(conn)}
+	return Conn{common: New(conn)}
 }
 
 // Conn is similar to *sql.Conn but implements [Queryer]
 type Conn struct {
 	common[*sql.Conn]
+	// the server of the database of the connection, if known
+	server *Server
 }
 
 // PingContext verifies a connection to the database is still alive, establishing a connection if necessary.
@@ -147,7 +244,15 @@ func (c Conn) BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error) {
 		return Tx{}, err
 	}
 
-	return NewTx(tx), nil
+	t := NewTx(tx)
+	t.server = c.server
+
+	return t, nil
+}
+
+// Raw works the same as [*sql.Conn.Raw], giving access to the driver connection
+func (c Conn) Raw(f func(driverConn any) error) error {
+	return c.wrapped.Raw(f)
 }
 
 type stdStmt struct {
@@ -21,6 +21,12 @@ type Statement interface {
 // retains the expected methods used by *sql.Stmt
 // This is useful when an existing *sql.Stmt is used in other places in the codebase
 func Prepare(ctx context.Context, exec Preparer, q Query) (Stmt, error) {
+	if err := checkFeatures(exec, q); err != nil {
+		return Stmt{}, err
+	}
+
+	exec = withDefaultTimeZonePreparer(exec)
+
 	query, args, err := Build(q)
 	if err != nil {
 		return Stmt{}, err
@@ -143,7 +149,7 @@ func (s QueryStmt[T, Ts]) All(ctx context.Context, args ...any) (Ts, error) {
 		return nil, err
 	}
 
-	rawSlice, err := scan.AllFromRows(ctx, s.mapper, rows)
+	rawSlice, err := scanAllFromRows(ctx, s.mapper, s.settings, rows)
 	if err != nil {
 		return nil, err
 	}
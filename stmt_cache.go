@@ -0,0 +1,219 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/stephenafamo/scan"
+)
+
+// DefaultStmtCacheSize is the number of statements kept by a [StmtCache]
+// created with a size of 0
+const DefaultStmtCacheSize = 256
+
+// StmtCache is a [Preparer] that prepares each query once and reuses the
+// statement for every later query with the same SQL, also for the queries
+// run with its ExecContext and QueryContext.
+//
+// It keeps the statements of the most recently used queries, up to its size.
+// The statement of the least recently used query is closed when a new one is
+// prepared over the size. The statements returned by PrepareContext stay usable
+// afterwards, their query is prepared again the next time they are used
+type StmtCache struct {
+	exec Preparer
+
+	mu    sync.Mutex
+	stmts *lru[*cacheEntry]
+}
+
+type cacheEntry struct {
+	stmt Statement
+	// the number of queries running with the statement
+	refs int
+	// removed from the cache, closed when the running queries are done
+	evicted bool
+}
+
+// NewStmtCache returns a statement cache that prepares the statements with exec,
+// and keeps at most size of them. A size of 0 uses [DefaultStmtCacheSize]
+func NewStmtCache(exec Preparer, size int) *StmtCache {
+	if size <= 0 {
+		size = DefaultStmtCacheSize
+	}
+
+	return &StmtCache{
+		exec:  exec,
+		stmts: newLRU[*cacheEntry](size),
+	}
+}
+
+// PrepareContext prepares the query if it is not cached yet, and returns
+// a statement that runs it with the cached statement
+func (c *StmtCache) PrepareContext(ctx context.Context, query string) (Statement, error) {
+	entry, err := c.acquire(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	c.release(entry)
+
+	return cachedStatement{cache: c, query: query}, nil
+}
+
+func (c *StmtCache) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	entry, err := c.acquire(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release(entry)
+
+	return entry.stmt.ExecContext(ctx, args...)
+}
+
+// QueryContext runs the query with the cached statement.
+// A statement of an [*sql.DB] that is closed while the rows are read is only
+// closed once the rows are closed
+func (c *StmtCache) QueryContext(ctx context.Context, query string, args ...any) (scan.Rows, error) {
+	entry, err := c.acquire(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer c.release(entry)
+
+	return entry.stmt.QueryContext(ctx, args...)
+}
+
+// acquire returns the cached statement of the query, prepared if needed.
+// It is not closed until it is released
+func (c *StmtCache) acquire(ctx context.Context, query string) (*cacheEntry, error) {
+	c.mu.Lock()
+	if entry, ok := c.stmts.get(query); ok {
+		entry.refs++
+		c.mu.Unlock()
+		return entry, nil
+	}
+	c.mu.Unlock()
+
+	prepared, err := c.exec.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Prepared at the same time by another caller
+	if entry, ok := c.stmts.get(query); ok {
+		closeStatement(prepared)
+		entry.refs++
+		return entry, nil
+	}
+
+	entry := &cacheEntry{stmt: prepared, refs: 1}
+	for _, old := range c.stmts.add(query, entry) {
+		c.evict(old)
+	}
+
+	return entry, nil
+}
+
+func (c *StmtCache) release(entry *cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry.refs--
+	if entry.evicted && entry.refs == 0 {
+		closeStatement(entry.stmt)
+	}
+}
+
+// evict marks the entry that was removed from the cache, and closes its statement
+// if no query is running with it. The lock must be held
+func (c *StmtCache) evict(entry *cacheEntry) error {
+	entry.evicted = true
+
+	if entry.refs == 0 {
+		return closeStatement(entry.stmt)
+	}
+
+	return nil
+}
+
+// Len returns the number of cached statements
+func (c *StmtCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.stmts.len()
+}
+
+// Close closes all the cached statements and empties the cache
+func (c *StmtCache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var first error
+	for {
+		entry, ok := c.stmts.removeOldest()
+		if !ok {
+			break
+		}
+		if err := c.evict(entry); err != nil && first == nil {
+			first = err
+		}
+	}
+
+	return first
+}
+
+// cachedStatement runs its query with the statement in the cache.
+// It cannot be closed by the callers, since the statement is shared
+type cachedStatement struct {
+	cache *StmtCache
+	query string
+}
+
+func (s cachedStatement) ExecContext(ctx context.Context, args ...any) (sql.Result, error) {
+	return s.cache.ExecContext(ctx, s.query, args...)
+}
+
+func (s cachedStatement) QueryContext(ctx context.Context, args ...any) (scan.Rows, error) {
+	return s.cache.QueryContext(ctx, s.query, args...)
+}
+
+func closeStatement(stmt Statement) error {
+	if c, ok := stmt.(io.Closer); ok {
+		return c.Close()
+	}
+
+	return nil
+}
+
+// Warmup prepares the given queries, e.g. the hot queries of a service when it
+// starts, so that the first requests do not wait for the statements to be prepared.
+// With a [StmtCache], the statements are kept in the cache. With other preparers,
+// they are closed again, which still checks the queries with drivers that prepare on the server.
+// Use [Queries.Warmup] for named queries
+func Warmup(ctx context.Context, exec Preparer, queries ...Query) error {
+	_, keep := exec.(*StmtCache)
+
+	for i, q := range queries {
+		query, _, err := Build(q)
+		if err != nil {
+			return fmt.Errorf("warmup query %d: %w", i, err)
+		}
+
+		stmt, err := exec.PrepareContext(ctx, query)
+		if err != nil {
+			return fmt.Errorf("warmup query %d: %w", i, err)
+		}
+
+		if !keep {
+			closeStatement(stmt)
+		}
+	}
+
+	return nil
+}
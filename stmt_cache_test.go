@@ -0,0 +1,118 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stephenafamo/scan"
+	_ "modernc.org/sqlite"
+)
+
+type trackedPreparer struct {
+	Preparer
+	prepared *int
+}
+
+func (c trackedPreparer) PrepareContext(ctx context.Context, query string) (Statement, error) {
+	*c.prepared++
+	return c.Preparer.PrepareContext(ctx, query)
+}
+
+func TestStmtCache(t *testing.T) {
+	ctx := context.Background()
+
+	sqlDB, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	sqlDB.SetMaxOpenConns(1)
+	db := NewDB(sqlDB)
+
+	if _, err := db.ExecContext(ctx, "CREATE TABLE users (id INTEGER PRIMARY KEY, name TEXT)"); err != nil {
+		t.Fatal(err)
+	}
+
+	var prepared int
+	cache := NewStmtCache(trackedPreparer{Preparer: db, prepared: &prepared}, 0)
+	defer cache.Close()
+
+	insert := rawQuery(d, "INSERT INTO users (name) VALUES (#)", "Alice")
+	count := rawQuery(d, "SELECT count(*) FROM users")
+
+	if err := Warmup(ctx, cache, insert, count); err != nil {
+		t.Fatal(err)
+	}
+	if prepared != 2 || cache.Len() != 2 {
+		t.Fatalf("got %d prepared and %d cached", prepared, cache.Len())
+	}
+
+	for i := 0; i < 3; i++ {
+		if _, err := Exec(ctx, cache, insert); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	n, err := One(ctx, cache, count, scan.SingleColumnMapper[int])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Fatalf("got %d users", n)
+	}
+
+	if prepared != 2 {
+		t.Fatalf("the statements were prepared again, %d times", prepared)
+	}
+
+	if err := cache.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if cache.Len() != 0 {
+		t.Fatalf("got %d cached after closing", cache.Len())
+	}
+}
+
+func TestStmtCacheEviction(t *testing.T) {
+	ctx := context.Background()
+
+	sqlDB, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	sqlDB.SetMaxOpenConns(1)
+	db := NewDB(sqlDB)
+
+	var prepared int
+	cache := NewStmtCache(trackedPreparer{Preparer: db, prepared: &prepared}, 1)
+	defer cache.Close()
+
+	one := rawQuery(d, "SELECT 1")
+	two := rawQuery(d, "SELECT 2")
+
+	stmt, err := PrepareQuery(ctx, cache, one, scan.SingleColumnMapper[int])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := One(ctx, cache, two, scan.SingleColumnMapper[int]); err != nil {
+		t.Fatal(err)
+	}
+	if cache.Len() != 1 || prepared != 2 {
+		t.Fatalf("got %d cached and %d prepared", cache.Len(), prepared)
+	}
+
+	// The evicted statement is prepared again
+	n, err := stmt.One(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Fatalf("got %d", n)
+	}
+	if cache.Len() != 1 || prepared != 3 {
+		t.Fatalf("got %d cached and %d prepared", cache.Len(), prepared)
+	}
+}
@@ -91,6 +91,10 @@ type DriverTestConfig[T any] struct {
 	OverwriteGolden bool
 	GoldenFile      string
 	GetDriver       func() drivers.Interface[T]
+
+	// The generation config, e.g. to generate the optional helpers.
+	// The aliases are set by the test when it generates with aliases
+	Config gen.Config
 }
 
 func TestDriver[T any](t *testing.T, config DriverTestConfig[T]) {
@@ -134,7 +138,7 @@ func TestDriver[T any](t *testing.T, config DriverTestConfig[T]) {
 	aliaser := &aliasPlugin[T]{}
 
 	t.Run("generate", func(t *testing.T) {
-		testDriver[T](t, defaultFolder, config.Templates, gen.Config{}, d, goModFilePath, aliaser)
+		testDriver[T](t, defaultFolder, config.Templates, config.Config, d, goModFilePath, aliaser)
 	})
 
 	aliasesFolder := filepath.Join(config.Root, "aliases")
@@ -144,7 +148,9 @@ func TestDriver[T any](t *testing.T, config DriverTestConfig[T]) {
 	}
 
 	t.Run("generate with aliases", func(t *testing.T) {
-		testDriver[T](t, aliasesFolder, config.Templates, gen.Config{Aliases: aliases}, d, goModFilePath, aliaser)
+		withAliases := config.Config
+		withAliases.Aliases = aliases
+		testDriver[T](t, aliasesFolder, config.Templates, withAliases, d, goModFilePath, aliaser)
 	})
 }
 
@@ -328,3 +334,19 @@ func (a *aliasPlugin[T]) PlugTemplateData(data *gen.TemplateData[any]) error {
 	a.rels = data.Relationships
 	return nil
 }
+
+// AssertGenerated fails the test if the generated code is different from the
+// files in the output folders.
+// This makes template changes and schema drift show up in the tests
+func AssertGenerated[T any](t testing.TB, s *gen.State, driver drivers.Interface[T], plugins ...gen.Plugin) {
+	t.Helper()
+
+	diff, err := gen.Check(context.Background(), s, driver, plugins...)
+	if err != nil {
+		t.Fatalf("generating: %v", err)
+	}
+
+	if diff != "" {
+		t.Fatalf("the generated code is out of date, run bobgen to update it:\n%s", diff)
+	}
+}
@@ -0,0 +1,214 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"sync/atomic"
+	"time"
+
+	"github.com/stephenafamo/scan"
+)
+
+//nolint:gochecknoglobals
+var defaultTimeZone atomic.Value // holds a tzSetting
+
+type tzSetting struct{ loc *time.Location }
+
+// SetDefaultTimeZone makes [Exec], [One], [All], [Cursor] and [Prepare]
+// behave as if the executor was wrapped with [InTimeZone] for the given location.
+// Executors that were wrapped explicitly keep their own location.
+// Pass nil to turn it off again
+func SetDefaultTimeZone(loc *time.Location) {
+	defaultTimeZone.Store(tzSetting{loc: loc})
+}
+
+// InTimeZone wraps an [Executor] so that all outgoing time values are
+// converted to the given location, and all scanned time values are
+// converted to it as well.
+// This hides the differences between database drivers, some of which
+// return timestamps in UTC and others in the local time zone.
+//
+// It handles time.Time, *time.Time, sql.NullTime and nullable wrappers such
+// as null.Val[time.Time] that have Get() and Set() methods.
+// If the executor is also a [Preparer], so is the returned executor
+func InTimeZone(exec Executor, loc *time.Location) Executor {
+	if loc == nil {
+		return exec
+	}
+
+	tz := tzExecutor{exec: exec, loc: loc}
+	if p, ok := exec.(Preparer); ok {
+		return tzPreparer{tzExecutor: tz, prep: p}
+	}
+
+	return tz
+}
+
+// withDefaultTimeZone applies the default time zone to the executor
+// if one is set and the executor does not have one already
+func withDefaultTimeZone(exec Executor) Executor {
+	setting, _ := defaultTimeZone.Load().(tzSetting)
+	if setting.loc == nil {
+		return exec
+	}
+
+	switch exec.(type) {
+	case tzExecutor, tzPreparer:
+		return exec
+	}
+
+	return InTimeZone(exec, setting.loc)
+}
+
+// withDefaultTimeZonePreparer is the same as withDefaultTimeZone
+// but keeps the preparer type
+func withDefaultTimeZonePreparer(exec Preparer) Preparer {
+	if p, ok := withDefaultTimeZone(exec).(Preparer); ok {
+		return p
+	}
+
+	return exec
+}
+
+type tzExecutor struct {
+	exec Executor
+	loc  *time.Location
+}
+
+// Unwrap returns the wrapped executor
+func (t tzExecutor) Unwrap() Executor {
+	return t.exec
+}
+
+func (t tzExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	return t.exec.ExecContext(ctx, query, convertTimeArgs(t.loc, args)...)
+}
+
+func (t tzExecutor) QueryContext(ctx context.Context, query string, args ...any) (scan.Rows, error) {
+	rows, err := t.exec.QueryContext(ctx, query, convertTimeArgs(t.loc, args)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return tzRows{Rows: rows, loc: t.loc}, nil
+}
+
+type tzPreparer struct {
+	tzExecutor
+	prep Preparer
+}
+
+func (t tzPreparer) PrepareContext(ctx context.Context, query string) (Statement, error) {
+	stmt, err := t.prep.PrepareContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	return tzStatement{stmt: stmt, loc: t.loc}, nil
+}
+
+type tzStatement struct {
+	stmt Statement
+	loc  *time.Location
+}
+
+func (t tzStatement) ExecContext(ctx context.Context, args ...any) (sql.Result, error) {
+	return t.stmt.ExecContext(ctx, convertTimeArgs(t.loc, args)...)
+}
+
+func (t tzStatement) QueryContext(ctx context.Context, args ...any) (scan.Rows, error) {
+	rows, err := t.stmt.QueryContext(ctx, convertTimeArgs(t.loc, args)...)
+	if err != nil {
+		return nil, err
+	}
+
+	return tzRows{Rows: rows, loc: t.loc}, nil
+}
+
+type tzRows struct {
+	scan.Rows
+	loc *time.Location
+}
+
+// NextResultSet moves to the next result set if the wrapped rows have several.
+// See [MultiResult]
+func (r tzRows) NextResultSet() bool {
+	if n, ok := r.Rows.(resultSetter); ok {
+		return n.NextResultSet()
+	}
+
+	return false
+}
+
+func (r tzRows) Scan(dest ...any) error {
+	if err := r.Rows.Scan(dest...); err != nil {
+		return err
+	}
+
+	for _, d := range dest {
+		switch d := d.(type) {
+		case *time.Time:
+			*d = d.In(r.loc)
+		case **time.Time:
+			if *d != nil {
+				t := (*d).In(r.loc)
+				*d = &t
+			}
+		case *sql.NullTime:
+			if d.Valid {
+				d.Time = d.Time.In(r.loc)
+			}
+		case interface {
+			Get() (time.Time, bool)
+			Set(time.Time)
+		}:
+			if t, ok := d.Get(); ok {
+				d.Set(t.In(r.loc))
+			}
+		}
+	}
+
+	return nil
+}
+
+func convertTimeArgs(loc *time.Location, args []any) []any {
+	var converted []any // only copy the args if there is a time value
+
+	for i, arg := range args {
+		var t time.Time
+		switch a := arg.(type) {
+		case time.Time:
+			t = a.In(loc)
+		case *time.Time:
+			if a == nil {
+				continue
+			}
+			t = a.In(loc)
+		case sql.NullTime:
+			if !a.Valid {
+				continue
+			}
+			t = a.Time.In(loc)
+		case interface{ Get() (time.Time, bool) }:
+			val, ok := a.Get()
+			if !ok {
+				continue
+			}
+			t = val.In(loc)
+		default:
+			continue
+		}
+
+		if converted == nil {
+			converted = make([]any, len(args))
+			copy(converted, args)
+		}
+		converted[i] = t
+	}
+
+	if converted == nil {
+		return args
+	}
+
+	return converted
+}
@@ -0,0 +1,104 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stephenafamo/scan"
+)
+
+// timeRows is a set of rows with a single timestamp column
+type timeRows struct {
+	intRows
+	times []time.Time
+}
+
+func (r *timeRows) Scan(dest ...any) error {
+	*(dest[0].(*time.Time)) = r.times[r.current-1]
+	return nil
+}
+
+func (r *timeRows) Columns() ([]string, error) {
+	return []string{"created_at"}, nil
+}
+
+func (r *timeRows) Next() bool {
+	r.current++
+	return r.current <= len(r.times)
+}
+
+type timeExecutor struct {
+	NoopExecutor
+	rows *timeRows
+	args *[]any
+}
+
+func (e timeExecutor) ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error) {
+	*e.args = args
+	return nil, nil
+}
+
+func (e timeExecutor) QueryContext(ctx context.Context, query string, args ...any) (scan.Rows, error) {
+	*e.args = args
+	return e.rows, nil
+}
+
+func TestInTimeZone(t *testing.T) {
+	berlin := time.FixedZone("Berlin", 2*60*60)
+	utcTime := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+
+	var args []any
+	exec := InTimeZone(timeExecutor{
+		rows: &timeRows{times: []time.Time{utcTime}},
+		args: &args,
+	}, berlin)
+
+	_, err := exec.ExecContext(context.Background(), "UPDATE", utcTime, &utcTime, "untouched")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, arg := range args[:2] {
+		if arg.(time.Time).Location() != berlin {
+			t.Fatalf("arg %d was not converted: %v", i, arg)
+		}
+	}
+
+	if args[2] != "untouched" {
+		t.Fatalf("unexpected arg %v", args[2])
+	}
+
+	got, err := All(context.Background(), exec, selectIDs, scan.SingleColumnMapper[time.Time])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got[0].Location() != berlin || !got[0].Equal(utcTime) {
+		t.Fatalf("scanned time was not converted: %v", got[0])
+	}
+}
+
+func TestSetDefaultTimeZone(t *testing.T) {
+	berlin := time.FixedZone("Berlin", 2*60*60)
+	SetDefaultTimeZone(berlin)
+	defer SetDefaultTimeZone(nil)
+
+	var args []any
+	exec := timeExecutor{args: &args}
+
+	_, err := Exec(context.Background(), exec, BaseQuery[ExpressionFunc]{
+		Expression: func(w io.Writer, d Dialect, start int) ([]any, error) {
+			return []any{time.Now()}, nil
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if args[0].(time.Time).Location() != berlin {
+		t.Fatalf("arg was not converted: %v", args[0])
+	}
+}
@@ -0,0 +1,238 @@
+package bob
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// QueryTrace records what each mod changed while building a query.
+// It is only recorded for queries built with [Traced]
+type QueryTrace struct {
+	Steps []TraceStep
+}
+
+// TraceStep is a mod applied to a traced query
+type TraceStep struct {
+	// The mod, e.g. sm.Where or mods.Where
+	Mod string
+	// Where the mod was applied, or created if it was wrapped with [TraceMod]
+	Caller string
+	// The clauses of the query changed by the mod
+	Changes []TraceChange
+}
+
+// TraceChange is a clause changed by a mod
+type TraceChange struct {
+	Clause string
+	Before string
+	After  string
+	// The args added to the clause
+	Args []any
+}
+
+// Traced builds a query with the mods, recording which clauses and args
+// each mod contributed. The build function is the query starter, e.g.
+//
+//	q := bob.Traced(psql.Select, mods...)
+//	fmt.Println(q.Trace())
+//
+// Mods applied later with [BaseQuery.Apply] are also recorded.
+// Tracing renders the clauses after each mod, so it is meant for debugging
+func Traced[E Expression](build func(...Mod[E]) BaseQuery[E], mods ...Mod[E]) BaseQuery[E] {
+	q := build()
+	q.trace = &QueryTrace{}
+	q.applyTraced(callerOf(2), mods)
+
+	return q
+}
+
+// TraceMod records where the mod is created instead of where it is applied
+// when it is applied to a traced query
+func TraceMod[T any](mod Mod[T]) Mod[T] {
+	return tracedMod[T]{mod: mod, caller: callerOf(2)}
+}
+
+type tracedMod[T any] struct {
+	mod    Mod[T]
+	caller string
+}
+
+func (t tracedMod[T]) Apply(q T) {
+	t.mod.Apply(q)
+}
+
+// Trace returns what each mod changed in the query,
+// or nil if the query was not built with [Traced]
+func (b BaseQuery[E]) Trace() *QueryTrace {
+	return b.trace
+}
+
+func (b BaseQuery[E]) applyTraced(caller string, mods []Mod[E]) {
+	for _, mod := range mods {
+		step := TraceStep{Mod: modName(mod), Caller: caller}
+		if t, ok := any(mod).(tracedMod[E]); ok {
+			step.Mod = modName(t.mod)
+			step.Caller = t.caller
+		}
+
+		before := clauseSnapshots(b.Expression, b.Dialect)
+		mod.Apply(b.Expression)
+		after := clauseSnapshots(b.Expression, b.Dialect)
+
+		for i, a := range after {
+			if a.sql == before[i].sql && reflect.DeepEqual(a.args, before[i].args) {
+				continue
+			}
+
+			change := TraceChange{Clause: a.name, Before: before[i].sql, After: a.sql}
+			if len(a.args) > len(before[i].args) {
+				change.Args = a.args[len(before[i].args):]
+			}
+			step.Changes = append(step.Changes, change)
+		}
+
+		b.trace.Steps = append(b.trace.Steps, step)
+	}
+}
+
+// For returns the steps that changed the clause, e.g. "Where"
+func (t *QueryTrace) For(clause string) []TraceStep {
+	var steps []TraceStep
+	for _, s := range t.Steps {
+		for _, c := range s.Changes {
+			if c.Clause == clause {
+				steps = append(steps, s)
+				break
+			}
+		}
+	}
+
+	return steps
+}
+
+func (t *QueryTrace) String() string {
+	var sb strings.Builder
+
+	for i, s := range t.Steps {
+		fmt.Fprintf(&sb, "%d. %s (%s)\n", i+1, s.Mod, s.Caller)
+		if len(s.Changes) == 0 {
+			sb.WriteString("   no changes\n")
+		}
+
+		for _, c := range s.Changes {
+			fmt.Fprintf(&sb, "   %s: %s", c.Clause, c.After)
+			if len(c.Args) > 0 {
+				fmt.Fprintf(&sb, " args: %v", c.Args)
+			}
+			sb.WriteString("\n")
+		}
+	}
+
+	return sb.String()
+}
+
+type clauseSnapshot struct {
+	name string
+	sql  string
+	args []any
+}
+
+// clauseSnapshots renders each field of the query struct
+func clauseSnapshots(e any, d Dialect) []clauseSnapshot {
+	v := reflect.ValueOf(e)
+	for v.Kind() == reflect.Pointer || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return nil
+		}
+		v = v.Elem()
+	}
+
+	if v.Kind() != reflect.Struct {
+		return []clauseSnapshot{renderClause("Query", v, d)}
+	}
+
+	snapshots := make([]clauseSnapshot, 0, v.NumField())
+	for i := 0; i < v.NumField(); i++ {
+		snapshots = append(snapshots, renderClause(v.Type().Field(i).Name, v.Field(i), d))
+	}
+
+	return snapshots
+}
+
+func renderClause(name string, v reflect.Value, d Dialect) (s clauseSnapshot) {
+	s.name = name
+
+	ex, ok := Expression(nil), false
+	if v.CanInterface() {
+		ex, ok = v.Interface().(Expression)
+	}
+	if !ok && v.CanAddr() && v.Addr().CanInterface() {
+		ex, ok = v.Addr().Interface().(Expression)
+	}
+	if !ok {
+		// Unexported fields and settings such as ONLY
+		s.sql = fmt.Sprintf("%v", v)
+		return s
+	}
+
+	// Incomplete clauses are not always valid on their own
+	defer func() {
+		if r := recover(); r != nil {
+			s.sql = fmt.Sprintf("%v", v)
+		}
+	}()
+
+	var buf bytes.Buffer
+	args, err := ex.WriteSQL(&buf, d, 1)
+	if err != nil {
+		s.sql = fmt.Sprintf("%v", v)
+		return s
+	}
+
+	s.sql = strings.TrimSpace(buf.String())
+	s.args = args
+	return s
+}
+
+func modName(mod any) string {
+	v := reflect.ValueOf(mod)
+
+	// mods.Where[*github.com/stephenafamo/bob/dialect/psql/dialect.SelectQuery] => mods.Where
+	typeName, _, _ := strings.Cut(v.Type().String(), "[")
+	if v.Kind() != reflect.Func {
+		return typeName
+	}
+
+	fn := runtime.FuncForPC(v.Pointer())
+	if fn == nil {
+		return typeName
+	}
+
+	// github.com/stephenafamo/bob/dialect/psql/sm.Where.func1 => sm.Where
+	name := fn.Name()
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	for {
+		i := strings.LastIndex(name, ".func")
+		if i < 0 {
+			break
+		}
+		name = name[:i]
+	}
+
+	return name
+}
+
+func callerOf(skip int) string {
+	_, file, line, ok := runtime.Caller(skip)
+	if !ok {
+		return "unknown"
+	}
+
+	return fmt.Sprintf("%s:%d", filepath.Base(file), line)
+}
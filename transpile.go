@@ -0,0 +1,109 @@
+package bob
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Construct is a part of a query that not every dialect supports
+type Construct string
+
+const (
+	ConstructDistinctOn      Construct = "DISTINCT ON"
+	ConstructLocking         Construct = "row locking (FOR UPDATE/SHARE)"
+	ConstructFetch           Construct = "FETCH"
+	ConstructReturning       Construct = "RETURNING"
+	ConstructUpdateReturning Construct = "RETURNING in UPDATE"
+	ConstructOnConflict      Construct = "ON CONFLICT"
+	ConstructOnDuplicateKey  Construct = "ON DUPLICATE KEY UPDATE"
+	ConstructFullJoin        Construct = "FULL JOIN"
+	ConstructLateral         Construct = "LATERAL"
+	ConstructOrderedWrite    Construct = "ORDER BY or LIMIT in UPDATE/DELETE"
+	ConstructHints           Construct = "optimizer hints"
+	ConstructModifiers       Construct = "query modifiers"
+	ConstructOrAction        Construct = "INSERT OR/UPDATE OR"
+	ConstructAsOf            Construct = "AS OF (time travel)"
+)
+
+// ConstructLister is implemented by query expressions to list the
+// constructs they use that not every dialect supports
+type ConstructLister interface {
+	Constructs() []Construct
+}
+
+// ConstructSupporter is implemented by dialects to report the constructs
+// they support when transpiling queries
+type ConstructSupporter interface {
+	Supports(Construct) bool
+}
+
+// UnsupportedError is returned by [Transpile] when the query uses
+// constructs that the target dialect does not support
+type UnsupportedError struct {
+	Constructs []Construct
+}
+
+func (e UnsupportedError) Error() string {
+	names := make([]string, len(e.Constructs))
+	for i, c := range e.Constructs {
+		names[i] = string(c)
+	}
+
+	return "the target dialect does not support " + strings.Join(names, ", ")
+}
+
+type transpilable interface {
+	Query
+	withDialect(Dialect) Query
+}
+
+func (b BaseQuery[E]) Constructs() []Construct {
+	if l, ok := any(b.Expression).(ConstructLister); ok {
+		return l.Constructs()
+	}
+
+	return nil
+}
+
+func (b BaseQuery[E]) withDialect(d Dialect) Query {
+	return BaseQuery[E]{
+		Expression: b.Expression,
+		Dialect:    d,
+		trace:      b.trace,
+	}
+}
+
+// Transpile returns the query written with the syntax of the target dialect,
+// e.g. to run a query built with the psql builder on SQLite in tests.
+// The placeholders and quoted identifiers follow the target dialect.
+//
+// If the target dialect implements [ConstructSupporter], an [UnsupportedError]
+// is returned when the query uses constructs it does not support.
+// Only the clauses of the query itself are checked, raw SQL, functions and
+// subqueries are written as they are
+func Transpile(q Query, target Dialect) (Query, error) {
+	t, ok := q.(transpilable)
+	if !ok {
+		return nil, fmt.Errorf("cannot transpile %T, only queries built with a dialect can be transpiled", q)
+	}
+
+	if s, ok := target.(ConstructSupporter); ok {
+		var unsupported []Construct
+		seen := make(map[Construct]struct{})
+		if l, ok := q.(ConstructLister); ok {
+			for _, c := range l.Constructs() {
+				if _, ok := seen[c]; ok || s.Supports(c) {
+					continue
+				}
+				seen[c] = struct{}{}
+				unsupported = append(unsupported, c)
+			}
+		}
+
+		if len(unsupported) > 0 {
+			return nil, UnsupportedError{Constructs: unsupported}
+		}
+	}
+
+	return t.withDialect(target), nil
+}
@@ -0,0 +1,161 @@
+package bob
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/stephenafamo/scan"
+)
+
+// ErrPreparedPending is returned by [TwoPhaseCommit] when every participant
+// was prepared but some of them could not be committed.
+// They stay prepared in the database until they are committed with [CommitPrepared],
+// which should be retried since the decision to commit was already made
+var ErrPreparedPending = errors.New("prepared transactions are pending")
+
+// PrepareTransaction prepares the transaction for a two-phase commit with
+// PREPARE TRANSACTION, as supported by Postgres.
+// The transaction is then no longer tied to this session, and is finished with
+// [CommitPrepared] or [RollbackPrepared] from any session, also after the database restarts.
+//
+// The functions registered with [Tx.AfterCommit] are not called
+func (t Tx) PrepareTransaction(ctx context.Context, id string) error {
+	if _, err := t.ExecContext(ctx, "PREPARE TRANSACTION "+quoteLiteral(id)); err != nil {
+		return err
+	}
+
+	// The session has left the transaction, this only gives back the connection
+	return t.wrapped.Commit()
+}
+
+// CommitPrepared commits a transaction prepared with [Tx.PrepareTransaction]
+func CommitPrepared(ctx context.Context, exec Executor, id string) error {
+	_, err := exec.ExecContext(ctx, "COMMIT PREPARED "+quoteLiteral(id))
+	return err
+}
+
+// RollbackPrepared rolls back a transaction prepared with [Tx.PrepareTransaction]
+func RollbackPrepared(ctx context.Context, exec Executor, id string) error {
+	_, err := exec.ExecContext(ctx, "ROLLBACK PREPARED "+quoteLiteral(id))
+	return err
+}
+
+// PreparedTransactions returns the ids of the prepared transactions of the
+// database that start with prefix, to finish them after a failure
+func PreparedTransactions(ctx context.Context, exec Executor, prefix string) ([]string, error) {
+	return scan.All(ctx, exec, scan.SingleColumnMapper[string],
+		"SELECT gid FROM pg_prepared_xacts WHERE database = current_database() AND left(gid, length($1)) = $1 ORDER BY prepared",
+		prefix)
+}
+
+func quoteLiteral(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", "''") + "'"
+}
+
+// TwoPhaseDB is a database that can take part in a [TwoPhaseCommit], such as [DB]
+type TwoPhaseDB interface {
+	Executor
+	Transactor
+}
+
+// TwoPhaseParticipant is the part of a [TwoPhaseCommit] that writes to one database
+type TwoPhaseParticipant struct {
+	DB  TwoPhaseDB
+	Run func(ctx context.Context, tx Tx) error
+}
+
+// TwoPhaseCommit writes to several databases so that either all or none of the writes are committed.
+// Each participant runs in its own transaction, which is prepared as id-N, N being the index of the
+// participant. Once all are prepared, they are committed.
+//
+// If a participant fails before all are prepared, every transaction is rolled back,
+// also when ctx is canceled: the rollbacks get their own timeout of [TwoPhaseAbortTimeout].
+// If a prepared transaction fails to commit, the error wraps [ErrPreparedPending], and it must be
+// committed later, e.g. with [PreparedTransactions] and [CommitPrepared]
+func TwoPhaseCommit(ctx context.Context, id string, participants ...TwoPhaseParticipant) error {
+	txs := make([]Tx, 0, len(participants))
+	prepared := make([]bool, len(participants))
+
+	abort := func(err error) error {
+		ctx, cancel := abortContext(ctx)
+		defer cancel()
+
+		for i, tx := range txs {
+			if prepared[i] {
+				_ = RollbackPrepared(ctx, participants[i].DB, preparedID(id, i))
+				continue
+			}
+			_ = tx.Rollback()
+		}
+		return err
+	}
+
+	for i, p := range participants {
+		tx, err := p.DB.BeginTx(ctx, nil)
+		if err != nil {
+			return abort(fmt.Errorf("two-phase commit: participant %d: %w", i, err))
+		}
+		txs = append(txs, tx)
+
+		if err := p.Run(ctx, tx); err != nil {
+			return abort(fmt.Errorf("two-phase commit: participant %d: %w", i, err))
+		}
+	}
+
+	for i, tx := range txs {
+		if err := tx.PrepareTransaction(ctx, preparedID(id, i)); err != nil {
+			err = abort(fmt.Errorf("two-phase commit: prepare participant %d: %w", i, err))
+			// It may have been prepared before the connection failed
+			ctx, cancel := abortContext(ctx)
+			defer cancel()
+			_ = RollbackPrepared(ctx, participants[i].DB, preparedID(id, i))
+			return err
+		}
+		prepared[i] = true
+	}
+
+	var pending []string
+	var firstErr error
+	for i, p := range participants {
+		if err := CommitPrepared(ctx, p.DB, preparedID(id, i)); err != nil {
+			pending = append(pending, preparedID(id, i))
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+
+	if len(pending) > 0 {
+		return fmt.Errorf("two-phase commit: %w: %s: %v", ErrPreparedPending, strings.Join(pending, ", "), firstErr)
+	}
+
+	return nil
+}
+
+// TwoPhaseAbortTimeout is the time given to roll back the transactions
+// of a failed [TwoPhaseCommit]
+var TwoPhaseAbortTimeout = 10 * time.Second
+
+// abortContext returns a context with the values of ctx that is not
+// canceled with it, so that the transactions are rolled back
+// after ctx is canceled, but only for [TwoPhaseAbortTimeout]
+func abortContext(ctx context.Context) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(detachedContext{ctx}, TwoPhaseAbortTimeout)
+}
+
+// detachedContext has the values of its parent, but not its deadline and cancellation
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+func (d detachedContext) Value(key any) any         { return d.parent.Value(key) }
+
+func preparedID(id string, i int) string {
+	return fmt.Sprintf("%s-%d", id, i)
+}
@@ -0,0 +1,96 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stephenafamo/scan"
+	_ "modernc.org/sqlite"
+)
+
+func TestQuoteLiteral(t *testing.T) {
+	if got, want := quoteLiteral("it's"), "'it''s'"; got != want {
+		t.Fatalf("got %s, want %s", got, want)
+	}
+}
+
+func TestTwoPhaseCommitRollback(t *testing.T) {
+	ctx := context.Background()
+
+	dbs := make([]DB, 2)
+	for i := range dbs {
+		sqlDB, err := sql.Open("sqlite", ":memory:")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer sqlDB.Close()
+		sqlDB.SetMaxOpenConns(1)
+
+		dbs[i] = NewDB(sqlDB)
+		if _, err := dbs[i].ExecContext(ctx, "CREATE TABLE transfers (amount INTEGER)"); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	insert := func(amount int) func(context.Context, Tx) error {
+		return func(ctx context.Context, tx Tx) error {
+			_, err := tx.ExecContext(ctx, "INSERT INTO transfers (amount) VALUES ($1)", amount)
+			return err
+		}
+	}
+
+	count := func(db DB) int {
+		n, err := scan.One(ctx, db, scan.SingleColumnMapper[int], "SELECT count(*) FROM transfers")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return n
+	}
+
+	// A participant fails
+	failed := errors.New("failed")
+	err := TwoPhaseCommit(ctx, "transfer",
+		TwoPhaseParticipant{DB: dbs[0], Run: insert(-10)},
+		TwoPhaseParticipant{DB: dbs[1], Run: func(context.Context, Tx) error { return failed }},
+	)
+	if !errors.Is(err, failed) {
+		t.Fatalf("expected the error of the participant, got %v", err)
+	}
+
+	// SQLite cannot prepare transactions
+	err = TwoPhaseCommit(ctx, "transfer",
+		TwoPhaseParticipant{DB: dbs[0], Run: insert(-10)},
+		TwoPhaseParticipant{DB: dbs[1], Run: insert(10)},
+	)
+	if err == nil {
+		t.Fatal("expected the prepare to fail")
+	}
+
+	for i, db := range dbs {
+		if n := count(db); n != 0 {
+			t.Fatalf("database %d has %d rows after a rollback", i, n)
+		}
+	}
+}
+
+func TestAbortContext(t *testing.T) {
+	type key struct{}
+
+	parent, cancel := context.WithCancel(context.WithValue(context.Background(), key{}, "value"))
+	cancel()
+
+	ctx, cancelAbort := abortContext(parent)
+	defer cancelAbort()
+
+	if err := ctx.Err(); err != nil {
+		t.Fatalf("expected the abort context not to be canceled with its parent, got %v", err)
+	}
+	if _, ok := ctx.Deadline(); !ok {
+		t.Fatal("expected the abort context to have a deadline")
+	}
+	if got := ctx.Value(key{}); got != "value" {
+		t.Fatalf("expected the values of the parent, got %v", got)
+	}
+}
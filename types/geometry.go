@@ -0,0 +1,198 @@
+package types
+
+import (
+	"database/sql/driver"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	wkbPoint     = 1
+	ewkbSRIDFlag = 0x20000000
+)
+
+var errInvalidGeometry = errors.New("invalid geometry")
+
+// NewPoint returns a point geometry with the given coordinates
+func NewPoint(lng, lat float64, srid uint32) Geometry {
+	wkb := make([]byte, 21)
+	wkb[0] = 1 // little endian
+	binary.LittleEndian.PutUint32(wkb[1:], wkbPoint)
+	binary.LittleEndian.PutUint64(wkb[5:], math.Float64bits(lng))
+	binary.LittleEndian.PutUint64(wkb[13:], math.Float64bits(lat))
+
+	return Geometry{SRID: srid, WKB: wkb}
+}
+
+// Geometry is a spatial value stored as standard WKB with a separate SRID.
+//
+// It can be scanned from PostGIS (hex or binary EWKB) and from MySQL
+// (the internal SRID prefixed WKB format).
+// Its Value is hex EWKB, which PostGIS accepts directly for geometry
+// and geography columns. For MySQL and SpatiaLite, send the WKB with
+// st.GeomFromWKB instead.
+type Geometry struct {
+	SRID uint32
+	WKB  []byte
+}
+
+// Point returns the coordinates of the geometry if it is a point
+func (g Geometry) Point() (lng, lat float64, ok bool) {
+	if len(g.WKB) != 21 {
+		return 0, 0, false
+	}
+
+	order := byteOrder(g.WKB[0])
+	if order == nil || order.Uint32(g.WKB[1:]) != wkbPoint {
+		return 0, 0, false
+	}
+
+	lng = math.Float64frombits(order.Uint64(g.WKB[5:]))
+	lat = math.Float64frombits(order.Uint64(g.WKB[13:]))
+	return lng, lat, true
+}
+
+// EWKB returns the geometry in the extended WKB format used by PostGIS
+func (g Geometry) EWKB() []byte {
+	if g.SRID == 0 || len(g.WKB) < 5 {
+		return g.WKB
+	}
+
+	order := byteOrder(g.WKB[0])
+	if order == nil {
+		return g.WKB
+	}
+
+	ewkb := make([]byte, 0, len(g.WKB)+4)
+	ewkb = append(ewkb, g.WKB[0])
+	ewkb = appendUint32(order, ewkb, order.Uint32(g.WKB[1:])|ewkbSRIDFlag)
+	ewkb = appendUint32(order, ewkb, g.SRID)
+	return append(ewkb, g.WKB[5:]...)
+}
+
+// Value implements the driver Valuer interface.
+func (g Geometry) Value() (driver.Value, error) {
+	if g.WKB == nil {
+		return nil, nil
+	}
+
+	return hex.EncodeToString(g.EWKB()), nil
+}
+
+// Scan implements the Scanner interface.
+func (g *Geometry) Scan(value any) error {
+	var b []byte
+	switch x := value.(type) {
+	case nil:
+		*g = Geometry{}
+		return nil
+	case string:
+		b = []byte(x)
+	case []byte:
+		b = x
+	default:
+		return fmt.Errorf("cannot scan type %T: %v", value, value)
+	}
+
+	// PostGIS sends hex encoded EWKB in the text format
+	if isHex(b) {
+		decoded := make([]byte, hex.DecodedLen(len(b)))
+		if _, err := hex.Decode(decoded, b); err != nil {
+			return err
+		}
+		b = decoded
+	}
+
+	if len(b) < 5 {
+		return errInvalidGeometry
+	}
+
+	// MySQL prefixes the WKB with a little endian SRID.
+	// It is checked first, since an SRID of 0 starts with a byte that reads as a byte order
+	if isMySQLGeometry(b) {
+		*g = Geometry{
+			SRID: binary.LittleEndian.Uint32(b),
+			WKB:  append([]byte(nil), b[4:]...),
+		}
+		return nil
+	}
+
+	// (E)WKB starts with the byte order
+	if order := byteOrder(b[0]); order != nil {
+		return g.scanEWKB(order, b)
+	}
+
+	return errInvalidGeometry
+}
+
+// isMySQLGeometry reports if b is in the internal format of MySQL,
+// a 4 byte SRID followed by WKB of one of the 7 base geometry types.
+// In (E)WKB, the bytes at the same offsets are the end of the type and the
+// start of the coordinates or SRID, which do not match both checks
+func isMySQLGeometry(b []byte) bool {
+	if len(b) < 9 {
+		return false
+	}
+
+	order := byteOrder(b[4])
+	if order == nil {
+		return false
+	}
+
+	typ := order.Uint32(b[5:])
+	return typ >= 1 && typ <= 7
+}
+
+func (g *Geometry) scanEWKB(order binary.ByteOrder, b []byte) error {
+	typ := order.Uint32(b[1:])
+	if typ&ewkbSRIDFlag == 0 {
+		*g = Geometry{WKB: append([]byte(nil), b...)}
+		return nil
+	}
+
+	if len(b) < 9 {
+		return errInvalidGeometry
+	}
+
+	wkb := make([]byte, 0, len(b)-4)
+	wkb = append(wkb, b[0])
+	wkb = appendUint32(order, wkb, typ&^ewkbSRIDFlag)
+	wkb = append(wkb, b[9:]...)
+
+	*g = Geometry{SRID: order.Uint32(b[5:]), WKB: wkb}
+	return nil
+}
+
+func byteOrder(b byte) binary.ByteOrder {
+	switch b {
+	case 0:
+		return binary.BigEndian
+	case 1:
+		return binary.LittleEndian
+	default:
+		return nil
+	}
+}
+
+func appendUint32(order binary.ByteOrder, b []byte, v uint32) []byte {
+	var buf [4]byte
+	order.PutUint32(buf[:], v)
+	return append(b, buf[:]...)
+}
+
+func isHex(b []byte) bool {
+	if len(b) < 10 || len(b)%2 != 0 {
+		return false
+	}
+
+	for _, c := range b {
+		if !(c >= '0' && c <= '9') && !(c >= 'a' && c <= 'f') && !(c >= 'A' && c <= 'F') {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,86 @@
+package types
+
+import (
+	"encoding/binary"
+	"encoding/hex"
+	"math"
+	"testing"
+)
+
+func TestGeometryPostGIS(t *testing.T) {
+	// SELECT ST_SetSRID(ST_MakePoint(13.405, 52.52), 4326)
+	const ewkb = "0101000020E61000008FC2F5285CCF2A40C3F5285C8F424A40"
+
+	var g Geometry
+	if err := g.Scan(ewkb); err != nil {
+		t.Fatal(err)
+	}
+
+	if g.SRID != 4326 {
+		t.Fatalf("expected SRID 4326, got %d", g.SRID)
+	}
+
+	lng, lat, ok := g.Point()
+	if !ok || lng != 13.405 || lat != 52.52 {
+		t.Fatalf("unexpected point %v %v %v", lng, lat, ok)
+	}
+
+	val, err := g.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if val != hex.EncodeToString(NewPoint(13.405, 52.52, 4326).EWKB()) {
+		t.Fatalf("unexpected value %v", val)
+	}
+}
+
+func TestGeometryMySQL(t *testing.T) {
+	point := NewPoint(13.405, 52.52, 0)
+
+	for _, srid := range []uint32{4326, 0} {
+		b := appendUint32(binary.LittleEndian, nil, srid)
+		b = append(b, point.WKB...)
+
+		var g Geometry
+		if err := g.Scan(b); err != nil {
+			t.Fatal(err)
+		}
+
+		if g.SRID != srid {
+			t.Fatalf("expected SRID %d, got %d", srid, g.SRID)
+		}
+
+		lng, lat, ok := g.Point()
+		if !ok || lng != 13.405 || lat != 52.52 {
+			t.Fatalf("SRID %d: unexpected point %v %v %v", srid, lng, lat, ok)
+		}
+	}
+}
+
+func TestGeometryWKB(t *testing.T) {
+	for _, order := range []byte{0, 1} {
+		point := NewPoint(13.405, 52.52, 0)
+		if order == 0 {
+			// the same point in big endian
+			wkb := []byte{0}
+			wkb = appendUint32(binary.BigEndian, wkb, wkbPoint)
+			for _, f := range []float64{13.405, 52.52} {
+				var buf [8]byte
+				binary.BigEndian.PutUint64(buf[:], math.Float64bits(f))
+				wkb = append(wkb, buf[:]...)
+			}
+			point.WKB = wkb
+		}
+
+		var g Geometry
+		if err := g.Scan(point.WKB); err != nil {
+			t.Fatal(err)
+		}
+
+		lng, lat, ok := g.Point()
+		if g.SRID != 0 || !ok || lng != 13.405 || lat != 52.52 {
+			t.Fatalf("byte order %d: unexpected geometry %d %v %v %v", order, g.SRID, lng, lat, ok)
+		}
+	}
+}
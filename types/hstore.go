@@ -30,6 +30,37 @@ import (
 // HStore is a wrapper for transferring HStore values back and forth easily.
 type HStore map[string]null.Val[string]
 
+// NewHStore creates an HStore with no NULL values from the given map
+func NewHStore(m map[string]string) HStore {
+	if m == nil {
+		return nil
+	}
+
+	h := make(HStore, len(m))
+	for k, v := range m {
+		h[k] = null.From(v)
+	}
+
+	return h
+}
+
+// Map returns the hstore as a map[string]string.
+// Keys with NULL values are left out
+func (h HStore) Map() map[string]string {
+	if h == nil {
+		return nil
+	}
+
+	m := make(map[string]string, len(h))
+	for k, v := range h {
+		if val, ok := v.Get(); ok {
+			m[k] = val
+		}
+	}
+
+	return m
+}
+
 // escapes and quotes hstore keys/values
 // s should be a sql.NullString or string
 func hQuote(s any) string {
@@ -0,0 +1,25 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestHStoreMap(t *testing.T) {
+	m := map[string]string{"brand": "acme", "color": `dark "red"`}
+
+	val, err := NewHStore(m).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var h HStore
+	if err := h.Scan(val); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff(m, h.Map()); diff != "" {
+		t.Fatal(diff)
+	}
+}
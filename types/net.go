@@ -0,0 +1,101 @@
+package types
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"net"
+	"net/netip"
+	"strings"
+)
+
+// Prefix wraps netip.Prefix so it can be used with postgres inet and cidr columns.
+// Host addresses, which postgres sends without a mask, are read
+// as a prefix covering only that address. Use Addr() to get the address
+type Prefix struct {
+	netip.Prefix
+}
+
+// Value implements the driver Valuer interface.
+func (p Prefix) Value() (driver.Value, error) {
+	if !p.IsValid() {
+		return nil, nil
+	}
+
+	if p.IsSingleIP() {
+		return p.Addr().String(), nil
+	}
+
+	return p.String(), nil
+}
+
+// Scan implements the Scanner interface.
+func (p *Prefix) Scan(value any) error {
+	var s string
+	switch x := value.(type) {
+	case string:
+		s = x
+	case []byte:
+		s = string(x)
+	case nil:
+		*p = Prefix{}
+		return nil
+	default:
+		return fmt.Errorf("cannot scan type %T: %v", value, value)
+	}
+
+	if !strings.Contains(s, "/") {
+		addr, err := netip.ParseAddr(s)
+		if err != nil {
+			return err
+		}
+
+		p.Prefix = netip.PrefixFrom(addr, addr.BitLen())
+		return nil
+	}
+
+	prefix, err := netip.ParsePrefix(s)
+	if err != nil {
+		return err
+	}
+
+	p.Prefix = prefix
+	return nil
+}
+
+// HardwareAddr wraps net.HardwareAddr so it can be used with postgres macaddr columns
+type HardwareAddr struct {
+	net.HardwareAddr
+}
+
+// Value implements the driver Valuer interface.
+func (h HardwareAddr) Value() (driver.Value, error) {
+	if h.HardwareAddr == nil {
+		return nil, nil
+	}
+
+	return h.String(), nil
+}
+
+// Scan implements the Scanner interface.
+func (h *HardwareAddr) Scan(value any) error {
+	var s string
+	switch x := value.(type) {
+	case string:
+		s = x
+	case []byte:
+		s = string(x)
+	case nil:
+		*h = HardwareAddr{}
+		return nil
+	default:
+		return fmt.Errorf("cannot scan type %T: %v", value, value)
+	}
+
+	addr, err := net.ParseMAC(s)
+	if err != nil {
+		return err
+	}
+
+	h.HardwareAddr = addr
+	return nil
+}
@@ -0,0 +1,52 @@
+package types
+
+import (
+	"net/netip"
+	"testing"
+)
+
+func TestPrefix(t *testing.T) {
+	var host Prefix
+	if err := host.Scan("192.168.0.1"); err != nil {
+		t.Fatal(err)
+	}
+	if host.Addr() != netip.MustParseAddr("192.168.0.1") || !host.IsSingleIP() {
+		t.Fatalf("unexpected prefix %v", host)
+	}
+
+	val, err := host.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val != "192.168.0.1" {
+		t.Fatalf("unexpected value %v", val)
+	}
+
+	var network Prefix
+	if err := network.Scan([]byte("10.0.0.0/8")); err != nil {
+		t.Fatal(err)
+	}
+
+	val, err = network.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val != "10.0.0.0/8" {
+		t.Fatalf("unexpected value %v", val)
+	}
+}
+
+func TestHardwareAddr(t *testing.T) {
+	var mac HardwareAddr
+	if err := mac.Scan("08:00:2b:01:02:03"); err != nil {
+		t.Fatal(err)
+	}
+
+	val, err := mac.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val != "08:00:2b:01:02:03" {
+		t.Fatalf("unexpected value %v", val)
+	}
+}
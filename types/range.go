@@ -0,0 +1,212 @@
+package types
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/aarondl/opt"
+)
+
+// the layouts postgres uses for the bounds of tsrange, tstzrange and daterange
+//
+//nolint:gochecknoglobals
+var rangeTimeLayouts = []string{
+	"2006-01-02 15:04:05.999999999Z07:00:00",
+	"2006-01-02 15:04:05.999999999Z07:00",
+	"2006-01-02 15:04:05.999999999Z07",
+	"2006-01-02 15:04:05.999999999",
+	"2006-01-02",
+}
+
+// NewRange returns a range with both bounds set
+func NewRange[T any](lower, upper T, lowerInc, upperInc bool) Range[T] {
+	return Range[T]{
+		Lower:          &lower,
+		Upper:          &upper,
+		LowerInclusive: lowerInc,
+		UpperInclusive: upperInc,
+	}
+}
+
+// Range is a postgres range such as int4range, tstzrange or daterange.
+// A nil bound is unbounded
+type Range[T any] struct {
+	Lower          *T
+	Upper          *T
+	LowerInclusive bool
+	UpperInclusive bool
+	Empty          bool
+}
+
+// Value implements the driver Valuer interface.
+func (r Range[T]) Value() (driver.Value, error) {
+	if r.Empty {
+		return "empty", nil
+	}
+
+	s := &strings.Builder{}
+	if r.LowerInclusive && r.Lower != nil {
+		s.WriteByte('[')
+	} else {
+		s.WriteByte('(')
+	}
+
+	if err := writeRangeBound(s, r.Lower); err != nil {
+		return nil, err
+	}
+	s.WriteByte(',')
+	if err := writeRangeBound(s, r.Upper); err != nil {
+		return nil, err
+	}
+
+	if r.UpperInclusive && r.Upper != nil {
+		s.WriteByte(']')
+	} else {
+		s.WriteByte(')')
+	}
+
+	return s.String(), nil
+}
+
+// Scan implements the Scanner interface.
+func (r *Range[T]) Scan(value any) error {
+	var s string
+	switch x := value.(type) {
+	case string:
+		s = x
+	case []byte:
+		s = string(x)
+	case nil:
+		*r = Range[T]{}
+		return nil
+	default:
+		return fmt.Errorf("cannot scan type %T: %v", value, value)
+	}
+
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, "empty") {
+		*r = Range[T]{Empty: true}
+		return nil
+	}
+
+	if len(s) < 3 {
+		return fmt.Errorf("invalid range %q", s)
+	}
+
+	var rng Range[T]
+	switch s[0] {
+	case '[':
+		rng.LowerInclusive = true
+	case '(':
+	default:
+		return fmt.Errorf("invalid range %q", s)
+	}
+
+	switch s[len(s)-1] {
+	case ']':
+		rng.UpperInclusive = true
+	case ')':
+	default:
+		return fmt.Errorf("invalid range %q", s)
+	}
+
+	lower, upper, ok := splitRange(s[1 : len(s)-1])
+	if !ok {
+		return fmt.Errorf("invalid range %q", s)
+	}
+
+	var err error
+	if rng.Lower, err = parseRangeBound[T](lower); err != nil {
+		return err
+	}
+	if rng.Upper, err = parseRangeBound[T](upper); err != nil {
+		return err
+	}
+
+	*r = rng
+	return nil
+}
+
+// splitRange splits the inside of a range on the comma
+// that is not inside a quoted bound
+func splitRange(s string) (string, string, bool) {
+	inQuote := false
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			inQuote = !inQuote
+		case ',':
+			if !inQuote {
+				return s[:i], s[i+1:], true
+			}
+		}
+	}
+
+	return "", "", false
+}
+
+func parseRangeBound[T any](s string) (*T, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	if len(s) > 1 && s[0] == '"' && s[len(s)-1] == '"' {
+		s = strings.ReplaceAll(s[1:len(s)-1], `\"`, `"`)
+		s = strings.ReplaceAll(s, `\\`, `\`)
+	}
+
+	t := new(T)
+	if tt, ok := any(t).(*time.Time); ok {
+		for _, layout := range rangeTimeLayouts {
+			parsed, err := time.Parse(layout, s)
+			if err == nil {
+				*tt = parsed
+				return t, nil
+			}
+		}
+
+		return nil, fmt.Errorf("cannot parse range bound %q as time", s)
+	}
+
+	if err := opt.ConvertAssign(t, s); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+func writeRangeBound[T any](s *strings.Builder, bound *T) error {
+	if bound == nil {
+		return nil
+	}
+
+	var val any = *bound
+	if valuer, ok := val.(driver.Valuer); ok {
+		v, err := valuer.Value()
+		if err != nil {
+			return err
+		}
+		val = v
+	}
+
+	var str string
+	switch v := val.(type) {
+	case time.Time:
+		str = v.Format(rangeTimeLayouts[1])
+	case string:
+		str = v
+	case []byte:
+		str = string(v)
+	default:
+		str = fmt.Sprint(v)
+	}
+
+	s.WriteByte('"')
+	s.WriteString(strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(str))
+	s.WriteByte('"')
+	return nil
+}
@@ -0,0 +1,72 @@
+package types
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRangeScan(t *testing.T) {
+	var ints Range[int32]
+	if err := ints.Scan("[1,10)"); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(NewRange[int32](1, 10, true, false), ints); diff != "" {
+		t.Fatal(diff)
+	}
+
+	var unbounded Range[int64]
+	if err := unbounded.Scan([]byte("(,5]")); err != nil {
+		t.Fatal(err)
+	}
+	if unbounded.Lower != nil || *unbounded.Upper != 5 || !unbounded.UpperInclusive {
+		t.Fatalf("unexpected range %#v", unbounded)
+	}
+
+	var empty Range[int32]
+	if err := empty.Scan("empty"); err != nil {
+		t.Fatal(err)
+	}
+	if !empty.Empty {
+		t.Fatal("expected empty range")
+	}
+
+	var times Range[time.Time]
+	if err := times.Scan(`["2024-01-02 10:00:00+00","2024-01-03 10:30:00.5+05:30")`); err != nil {
+		t.Fatal(err)
+	}
+	if !times.Lower.Equal(time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected lower bound %v", times.Lower)
+	}
+	if !times.Upper.Equal(time.Date(2024, 1, 3, 5, 0, 0, 5e8, time.UTC)) {
+		t.Fatalf("unexpected upper bound %v", times.Upper)
+	}
+
+	var dates Range[time.Time]
+	if err := dates.Scan("[2024-01-02,2024-02-01)"); err != nil {
+		t.Fatal(err)
+	}
+	if !dates.Upper.Equal(time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("unexpected upper bound %v", dates.Upper)
+	}
+}
+
+func TestRangeValue(t *testing.T) {
+	val, err := NewRange[int32](1, 10, true, true).Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val != `["1","10"]` {
+		t.Fatalf("unexpected value %v", val)
+	}
+
+	upper := time.Date(2024, 1, 2, 10, 0, 0, 0, time.UTC)
+	val, err = Range[time.Time]{Upper: &upper}.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val != `(,"2024-01-02 10:00:00Z")` {
+		t.Fatalf("unexpected value %v", val)
+	}
+}
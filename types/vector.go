@@ -0,0 +1,71 @@
+package types
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Vector is a pgvector vector.
+// It is sent and scanned in the text format, e.g. [1,2,3]
+type Vector []float32
+
+// Value implements the driver Valuer interface.
+func (v Vector) Value() (driver.Value, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	s := &strings.Builder{}
+	s.WriteByte('[')
+	for i, f := range v {
+		if i > 0 {
+			s.WriteByte(',')
+		}
+		s.WriteString(strconv.FormatFloat(float64(f), 'f', -1, 32))
+	}
+	s.WriteByte(']')
+
+	return s.String(), nil
+}
+
+// Scan implements the Scanner interface.
+func (v *Vector) Scan(value any) error {
+	var s string
+	switch x := value.(type) {
+	case string:
+		s = x
+	case []byte:
+		s = string(x)
+	case nil:
+		*v = nil
+		return nil
+	default:
+		return fmt.Errorf("cannot scan type %T: %v", value, value)
+	}
+
+	s = strings.TrimSpace(s)
+	if len(s) < 2 || s[0] != '[' || s[len(s)-1] != ']' {
+		return fmt.Errorf("invalid vector %q", s)
+	}
+
+	s = s[1 : len(s)-1]
+	if s == "" {
+		*v = Vector{}
+		return nil
+	}
+
+	parts := strings.Split(s, ",")
+	vec := make(Vector, len(parts))
+	for i, part := range parts {
+		f, err := strconv.ParseFloat(strings.TrimSpace(part), 32)
+		if err != nil {
+			return err
+		}
+		vec[i] = float32(f)
+	}
+
+	*v = vec
+	return nil
+}
@@ -0,0 +1,25 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestVector(t *testing.T) {
+	val, err := Vector{1, 2.5, -0.125}.Value()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if val != "[1,2.5,-0.125]" {
+		t.Fatalf("unexpected value %v", val)
+	}
+
+	var v Vector
+	if err := v.Scan([]byte("[1, 2.5,-0.125]")); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(Vector{1, 2.5, -0.125}, v); diff != "" {
+		t.Fatal(diff)
+	}
+}
@@ -0,0 +1,277 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sync"
+)
+
+// UnitTable is the part of the table models of each dialect that is used by a [UnitOfWork].
+// The tables of the generated models implement it
+type UnitTable[T any, Tslice ~[]T, Tset any] interface {
+	InsertMany(ctx context.Context, exec Executor, rows ...Tset) (Tslice, error)
+	Update(ctx context.Context, exec Executor, vals Tset, rows ...T) error
+	Delete(ctx context.Context, exec Executor, rows ...T) error
+}
+
+// Transactor can start a transaction, such as [DB] and [Conn]
+type Transactor interface {
+	BeginTx(ctx context.Context, opts *sql.TxOptions) (Tx, error)
+}
+
+// UnitOfWork collects inserts, updates and deletes of table models and runs
+// them in one transaction with [UnitOfWork.Flush].
+//
+// The changes are run in the order of the foreign keys between the tables: inserts and updates
+// of a table run after the ones of the tables it references, and deletes run before the
+// deletes of the tables it references.
+// The inserts into a table are run with a single InsertMany, and the deletes from a table
+// with a single Delete.
+//
+// Changes are added with the UnitInsert, UnitUpdate and UnitDelete methods of the table models
+type UnitOfWork struct {
+	mu     sync.Mutex
+	deps   map[string][]string
+	tables map[string]*unitChanges
+	// The tables in the order they were first changed
+	order []string
+}
+
+type unitChanges struct {
+	inserts []unitBatch
+	updates []func(context.Context, Executor) error
+	deletes []unitBatch
+}
+
+type unitBatch interface {
+	flush(context.Context, Executor) error
+}
+
+// NewUnitOfWork returns a unit of work that orders the changes with deps,
+// which maps a table name to the names of the tables it references.
+// The generated models have it as TableDependencies
+func NewUnitOfWork(deps map[string][]string) *UnitOfWork {
+	return &UnitOfWork{deps: deps, tables: make(map[string]*unitChanges)}
+}
+
+func (u *UnitOfWork) changes(table string) *unitChanges {
+	c, ok := u.tables[table]
+	if !ok {
+		c = &unitChanges{}
+		u.tables[table] = c
+		u.order = append(u.order, table)
+	}
+
+	return c
+}
+
+// Pending holds the rows of a change added to a [UnitOfWork]
+// once it has been flushed
+type Pending[Tslice any] struct {
+	rows Tslice
+}
+
+// Rows returns the rows, which are only set after the unit of work is flushed
+func (p *Pending[Tslice]) Rows() Tslice {
+	return p.rows
+}
+
+type unitInserts[T any, Tslice ~[]T, Tset any] struct {
+	table   UnitTable[T, Tslice, Tset]
+	rows    []Tset
+	pending []*Pending[Tslice]
+	counts  []int
+}
+
+func (b *unitInserts[T, Tslice, Tset]) flush(ctx context.Context, exec Executor) error {
+	inserted, err := b.table.InsertMany(ctx, exec, b.rows...)
+	if err != nil {
+		return err
+	}
+
+	if len(inserted) != len(b.rows) {
+		return fmt.Errorf("unit of work: inserted %d rows but got %d back", len(b.rows), len(inserted))
+	}
+
+	start := 0
+	for i, p := range b.pending {
+		p.rows = inserted[start : start+b.counts[i]]
+		start += b.counts[i]
+	}
+
+	return nil
+}
+
+type unitDeletes[T any, Tslice ~[]T, Tset any] struct {
+	table UnitTable[T, Tslice, Tset]
+	rows  []T
+}
+
+func (b *unitDeletes[T, Tslice, Tset]) flush(ctx context.Context, exec Executor) error {
+	return b.table.Delete(ctx, exec, b.rows...)
+}
+
+// UnitInsert adds rows to insert into the table.
+// The rows of every UnitInsert into the same table model are inserted together
+func UnitInsert[T any, Tslice ~[]T, Tset any](u *UnitOfWork, name string, table UnitTable[T, Tslice, Tset], rows ...Tset) *Pending[Tslice] {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	p := &Pending[Tslice]{}
+	c := u.changes(name)
+
+	for _, b := range c.inserts {
+		if b, ok := b.(*unitInserts[T, Tslice, Tset]); ok && b.table == table {
+			b.rows = append(b.rows, rows...)
+			b.pending = append(b.pending, p)
+			b.counts = append(b.counts, len(rows))
+			return p
+		}
+	}
+
+	c.inserts = append(c.inserts, &unitInserts[T, Tslice, Tset]{
+		table:   table,
+		rows:    rows,
+		pending: []*Pending[Tslice]{p},
+		counts:  []int{len(rows)},
+	})
+
+	return p
+}
+
+// UnitUpdate adds an update of the rows of the table with vals
+func UnitUpdate[T any, Tslice ~[]T, Tset any](u *UnitOfWork, name string, table UnitTable[T, Tslice, Tset], vals Tset, rows ...T) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	c := u.changes(name)
+	c.updates = append(c.updates, func(ctx context.Context, exec Executor) error {
+		return table.Update(ctx, exec, vals, rows...)
+	})
+}
+
+// UnitDelete adds rows to delete from the table.
+// The rows of every UnitDelete from the same table model are deleted together
+func UnitDelete[T any, Tslice ~[]T, Tset any](u *UnitOfWork, name string, table UnitTable[T, Tslice, Tset], rows ...T) {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	c := u.changes(name)
+	for _, b := range c.deletes {
+		if b, ok := b.(*unitDeletes[T, Tslice, Tset]); ok && b.table == table {
+			b.rows = append(b.rows, rows...)
+			return
+		}
+	}
+
+	c.deletes = append(c.deletes, &unitDeletes[T, Tslice, Tset]{table: table, rows: rows})
+}
+
+// Flush runs the changes in one transaction and commits it.
+// If a change fails, the transaction is rolled back and the changes are kept,
+// otherwise the unit of work is emptied
+func (u *UnitOfWork) Flush(ctx context.Context, db Transactor) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	if len(u.order) == 0 {
+		return nil
+	}
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := u.run(ctx, tx); err != nil {
+		if rbErr := tx.Rollback(); rbErr != nil {
+			return fmt.Errorf("%w; rollback: %v", err, rbErr)
+		}
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	u.tables = make(map[string]*unitChanges)
+	u.order = nil
+
+	return nil
+}
+
+func (u *UnitOfWork) run(ctx context.Context, exec Executor) error {
+	sorted := u.sortTables()
+
+	for _, table := range sorted {
+		for _, b := range u.tables[table].inserts {
+			if err := b.flush(ctx, exec); err != nil {
+				return fmt.Errorf("unit of work: insert into %s: %w", table, err)
+			}
+		}
+	}
+
+	for _, table := range sorted {
+		for _, update := range u.tables[table].updates {
+			if err := update(ctx, exec); err != nil {
+				return fmt.Errorf("unit of work: update %s: %w", table, err)
+			}
+		}
+	}
+
+	for i := len(sorted) - 1; i >= 0; i-- {
+		table := sorted[i]
+		for _, b := range u.tables[table].deletes {
+			if err := b.flush(ctx, exec); err != nil {
+				return fmt.Errorf("unit of work: delete from %s: %w", table, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// sortTables orders the changed tables so that every table comes after the
+// tables it references. Tables in a cycle keep the order they were first changed
+func (u *UnitOfWork) sortTables() []string {
+	sorted := make([]string, 0, len(u.order))
+	placed := make(map[string]bool, len(u.order))
+
+	ready := func(table string) bool {
+		for _, dep := range u.deps[table] {
+			if dep == table || placed[dep] {
+				continue
+			}
+			if _, ok := u.tables[dep]; ok {
+				return false
+			}
+		}
+		return true
+	}
+
+	for len(sorted) < len(u.order) {
+		next := ""
+		for _, table := range u.order {
+			if !placed[table] && ready(table) {
+				next = table
+				break
+			}
+		}
+
+		// A cycle, take the first remaining table
+		if next == "" {
+			for _, table := range u.order {
+				if !placed[table] {
+					next = table
+					break
+				}
+			}
+		}
+
+		placed[next] = true
+		sorted = append(sorted, next)
+	}
+
+	return sorted
+}
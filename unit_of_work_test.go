@@ -0,0 +1,147 @@
+package bob
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"reflect"
+	"testing"
+
+	_ "modernc.org/sqlite"
+)
+
+type unitRow struct {
+	ID int
+}
+
+// unitTable records the statements that would be run
+type unitTable struct {
+	name string
+	log  *[]string
+	fail bool
+}
+
+func (t *unitTable) InsertMany(_ context.Context, _ Executor, rows ...int) ([]*unitRow, error) {
+	if t.fail {
+		return nil, errors.New("failed")
+	}
+
+	*t.log = append(*t.log, fmt.Sprintf("insert %s %v", t.name, rows))
+	inserted := make([]*unitRow, len(rows))
+	for i, id := range rows {
+		inserted[i] = &unitRow{ID: id}
+	}
+	return inserted, nil
+}
+
+func (t *unitTable) Update(_ context.Context, _ Executor, vals int, rows ...*unitRow) error {
+	*t.log = append(*t.log, fmt.Sprintf("update %s %d rows to %d", t.name, len(rows), vals))
+	return nil
+}
+
+func (t *unitTable) Delete(_ context.Context, _ Executor, rows ...*unitRow) error {
+	ids := make([]int, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	*t.log = append(*t.log, fmt.Sprintf("delete %s %v", t.name, ids))
+	return nil
+}
+
+func TestUnitOfWork(t *testing.T) {
+	ctx := context.Background()
+
+	sqlDB, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	db := NewDB(sqlDB)
+
+	var log []string
+	users := &unitTable{name: "users", log: &log}
+	posts := &unitTable{name: "posts", log: &log}
+	comments := &unitTable{name: "comments", log: &log}
+
+	u := NewUnitOfWork(map[string][]string{
+		"users":    {},
+		"posts":    {"users"},
+		"comments": {"posts", "users", "comments"},
+	})
+
+	UnitDelete[*unitRow, []*unitRow, int](u, "users", users, &unitRow{ID: 9})
+	UnitInsert[*unitRow, []*unitRow, int](u, "comments", comments, 100)
+	firstPosts := UnitInsert[*unitRow, []*unitRow, int](u, "posts", posts, 10, 11)
+	UnitUpdate[*unitRow, []*unitRow, int](u, "posts", posts, 5, &unitRow{ID: 1}, &unitRow{ID: 2})
+	UnitInsert[*unitRow, []*unitRow, int](u, "users", users, 1)
+	morePosts := UnitInsert[*unitRow, []*unitRow, int](u, "posts", posts, 12)
+	UnitDelete[*unitRow, []*unitRow, int](u, "comments", comments, &unitRow{ID: 7})
+	UnitDelete[*unitRow, []*unitRow, int](u, "users", users, &unitRow{ID: 8})
+
+	if err := u.Flush(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"insert users [1]",
+		"insert posts [10 11 12]",
+		"insert comments [100]",
+		"update posts 2 rows to 5",
+		"delete comments [7]",
+		"delete users [9 8]",
+	}
+	if !reflect.DeepEqual(log, want) {
+		t.Fatalf("got statements\n%q\nwant\n%q", log, want)
+	}
+
+	if len(firstPosts.Rows()) != 2 || firstPosts.Rows()[1].ID != 11 {
+		t.Fatalf("wrong rows for the first insert: %v", firstPosts.Rows())
+	}
+	if len(morePosts.Rows()) != 1 || morePosts.Rows()[0].ID != 12 {
+		t.Fatalf("wrong rows for the second insert: %v", morePosts.Rows())
+	}
+
+	// Flushed changes are not run again
+	log = nil
+	if err := u.Flush(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+	if len(log) != 0 {
+		t.Fatalf("flushed changes were run again: %q", log)
+	}
+}
+
+func TestUnitOfWorkRollback(t *testing.T) {
+	ctx := context.Background()
+
+	sqlDB, err := sql.Open("sqlite", ":memory:")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sqlDB.Close()
+	db := NewDB(sqlDB)
+
+	var log []string
+	users := &unitTable{name: "users", log: &log}
+	posts := &unitTable{name: "posts", log: &log, fail: true}
+
+	u := NewUnitOfWork(map[string][]string{"posts": {"users"}})
+	UnitInsert[*unitRow, []*unitRow, int](u, "posts", posts, 10)
+	UnitInsert[*unitRow, []*unitRow, int](u, "users", users, 1)
+
+	err = u.Flush(ctx, db)
+	if err == nil || err.Error() != "unit of work: insert into posts: failed" {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The changes are kept to be flushed again
+	posts.fail = false
+	log = nil
+	if err := u.Flush(ctx, db); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"insert users [1]", "insert posts [10]"}; !reflect.DeepEqual(log, want) {
+		t.Fatalf("got statements %q, want %q", log, want)
+	}
+}
@@ -0,0 +1,126 @@
+package bob
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+
+	"github.com/stephenafamo/bob/internal/mappings"
+)
+
+// StructSetOption configures the columns of [SetFromStruct]
+type StructSetOption func(*structSet)
+
+type structSet struct {
+	columns []string
+	nonZero bool
+}
+
+// SetColumns only sets the given columns, e.g. the fields present in the
+// body of a PATCH request. This includes primary keys and generated columns
+func SetColumns(columns ...string) StructSetOption {
+	return func(s *structSet) {
+		s.columns = columns
+	}
+}
+
+// SetNonZero leaves out the fields with the zero value, such as nil pointers
+// or unset omit.Val fields
+func SetNonZero() StructSetOption {
+	return func(s *structSet) {
+		s.nonZero = true
+	}
+}
+
+// SetFromStruct returns the SET expressions of an UPDATE for the fields of a
+// struct, for projects that use the query builder without generated setters.
+// The columns are mapped with the "db" tags as in scanning.
+// By default, every column is set except the ones tagged "pk" or "generated".
+//
+// It returns no expressions if there is nothing to set, and an error if
+// a column given with [SetColumns] is not in the struct
+//
+//	sets, err := bob.SetFromStruct(patch, bob.SetNonZero())
+//	if err != nil {
+//		return err
+//	}
+//	if len(sets) > 0 {
+//		q := psql.Update(um.Table("users"), um.Set(sets...), um.Where(...))
+//	}
+func SetFromStruct(row any, opts ...StructSetOption) ([]Expression, error) {
+	var s structSet
+	for _, o := range opts {
+		o(&s)
+	}
+
+	val := reflect.ValueOf(row)
+	for val.Kind() == reflect.Pointer {
+		if val.IsNil() {
+			return nil, fmt.Errorf("set from struct: nil %s", val.Type())
+		}
+		val = val.Elem()
+	}
+	if val.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("set from struct: %T is not a struct", row)
+	}
+
+	fields, err := s.fields(val)
+	if err != nil {
+		return nil, err
+	}
+
+	sets := make([]Expression, 0, len(fields))
+	for _, f := range fields {
+		name, value := f.name, val.Field(f.index).Interface()
+		sets = append(sets, ExpressionFunc(func(w io.Writer, d Dialect, start int) ([]any, error) {
+			d.WriteQuoted(w, name)
+			w.Write([]byte(" = "))
+			d.WriteArg(w, start)
+			return []any{value}, nil
+		}))
+	}
+
+	return sets, nil
+}
+
+// fields returns the struct fields to set
+func (s structSet) fields(val reflect.Value) ([]structField, error) {
+	m := mappings.GetMappings(val.Type())
+
+	var fields []structField
+	if s.columns != nil {
+		index := make(map[string]int, len(m.All))
+		for i, name := range m.All {
+			if name != "" {
+				index[name] = i
+			}
+		}
+
+		for _, name := range s.columns {
+			i, ok := index[name]
+			if !ok {
+				return nil, fmt.Errorf("set from struct: %s has no column %q", val.Type(), name)
+			}
+			fields = append(fields, structField{name: name, index: i})
+		}
+	} else {
+		for i, name := range m.All {
+			if name == "" || m.PKs[i] != "" || m.Generated[i] != "" {
+				continue
+			}
+			fields = append(fields, structField{name: name, index: i})
+		}
+	}
+
+	if s.nonZero {
+		kept := fields[:0]
+		for _, f := range fields {
+			if !val.Field(f.index).IsZero() {
+				kept = append(kept, f)
+			}
+		}
+		fields = kept
+	}
+
+	return fields, nil
+}
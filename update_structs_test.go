@@ -0,0 +1,98 @@
+package bob
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+type structSetUser struct {
+	ID       int     `db:"id,pk"`
+	Name     *string `db:"name"`
+	Email    string  `db:"email"`
+	Searched string  `db:"searched,generated"`
+}
+
+func TestSetFromStruct(t *testing.T) {
+	name := "Alice"
+
+	tests := map[string]struct {
+		row          any
+		opts         []StructSetOption
+		expectedSQL  []string
+		expectedArgs []any
+		expectedErr  string
+	}{
+		"all columns": {
+			row:          structSetUser{ID: 1, Name: &name},
+			expectedSQL:  []string{`"name" = $1`, `"email" = $1`},
+			expectedArgs: []any{&name, ""},
+		},
+		"non zero": {
+			row:          &structSetUser{ID: 1, Name: &name},
+			opts:         []StructSetOption{SetNonZero()},
+			expectedSQL:  []string{`"name" = $1`},
+			expectedArgs: []any{&name},
+		},
+		"field mask": {
+			row:          structSetUser{ID: 1, Email: "a@example.com"},
+			opts:         []StructSetOption{SetColumns("email", "id")},
+			expectedSQL:  []string{`"email" = $1`, `"id" = $1`},
+			expectedArgs: []any{"a@example.com", 1},
+		},
+		"field mask and non zero": {
+			row:          structSetUser{ID: 1},
+			opts:         []StructSetOption{SetColumns("email", "name"), SetNonZero()},
+			expectedSQL:  []string{},
+			expectedArgs: []any{},
+		},
+		"unknown column": {
+			row:         structSetUser{},
+			opts:        []StructSetOption{SetColumns("nope")},
+			expectedErr: `has no column "nope"`,
+		},
+		"nil": {
+			row:         (*structSetUser)(nil),
+			expectedErr: "nil *bob.structSetUser",
+		},
+		"not a struct": {
+			row:         1,
+			expectedErr: "int is not a struct",
+		},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			sets, err := SetFromStruct(tc.row, tc.opts...)
+			if tc.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.expectedErr) {
+					t.Fatalf("expected error containing %q, got %v", tc.expectedErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			sql := make([]string, len(sets))
+			args := []any{}
+			for i, set := range sets {
+				var sb strings.Builder
+				setArgs, err := set.WriteSQL(&sb, d, 1)
+				if err != nil {
+					t.Fatal(err)
+				}
+				sql[i] = sb.String()
+				args = append(args, setArgs...)
+			}
+
+			if !reflect.DeepEqual(sql, tc.expectedSQL) {
+				t.Fatalf("wrong SQL\nexpected: %q\n     got: %q", tc.expectedSQL, sql)
+			}
+
+			if !reflect.DeepEqual(args, tc.expectedArgs) {
+				t.Fatalf("wrong args\nexpected: %#v\n     got: %#v", tc.expectedArgs, args)
+			}
+		})
+	}
+}